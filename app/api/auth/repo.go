@@ -66,3 +66,67 @@ func IsRepoOwner(
 
 	return err == nil, nil
 }
+
+// CheckVisibilities returns the visibilities of the status checks of the repo the current auth session can see:
+// public status checks are visible to all viewers of the repo, restricted ones to its contributors
+// and private ones to its owners.
+func CheckVisibilities(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	session *auth.Session,
+	repo *types.Repository,
+) ([]enum.CheckVisibility, error) {
+	visibilities := []enum.CheckVisibility{enum.CheckVisibilityPublic}
+
+	err := CheckRepo(ctx, authorizer, session, repo, enum.PermissionRepoPush)
+	if errors.Is(err, ErrNotAuthorized) {
+		return visibilities, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check contributor access: %w", err)
+	}
+
+	visibilities = append(visibilities, enum.CheckVisibilityRestricted)
+
+	isRepoOwner, err := IsRepoOwner(ctx, authorizer, session, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if isRepoOwner {
+		visibilities = append(visibilities, enum.CheckVisibilityPrivate)
+	}
+
+	return visibilities, nil
+}
+
+// SpaceCheckVisibilities returns the visibilities of the status checks of all repos in the space
+// the current auth session can see, based on the repo permissions granted in the scope of the space.
+func SpaceCheckVisibilities(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	session *auth.Session,
+	space *types.Space,
+) ([]enum.CheckVisibility, error) {
+	visibilities := []enum.CheckVisibility{enum.CheckVisibilityPublic}
+
+	for _, v := range []struct {
+		permission enum.Permission
+		visibility enum.CheckVisibility
+	}{
+		{permission: enum.PermissionRepoPush, visibility: enum.CheckVisibilityRestricted},
+		{permission: enum.PermissionRepoEdit, visibility: enum.CheckVisibilityPrivate},
+	} {
+		err := CheckSpaceScope(ctx, authorizer, session, space, enum.ResourceTypeRepo, v.permission)
+		if errors.Is(err, ErrNotAuthorized) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check repo access in space: %w", err)
+		}
+
+		visibilities = append(visibilities, v.visibility)
+	}
+
+	return visibilities, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxCheckAcknowledgementDuration is the longest time a status check failure can be acknowledged for.
+	maxCheckAcknowledgementDuration = 7 * 24 * time.Hour
+
+	maxCheckAcknowledgementReasonLength = 1024
+)
+
+type AcknowledgeInput struct {
+	Identifier string `json:"identifier"`
+	Namespace  string `json:"namespace"`
+	Reason     string `json:"reason"`
+	// Expires is the time (unix milliseconds) until the failure is acknowledged.
+	Expires int64 `json:"expires"`
+}
+
+func (in *AcknowledgeInput) Sanitize(now time.Time) error {
+	if in.Identifier == "" {
+		return usererror.BadRequest("Identifier is missing")
+	}
+
+	if in.Namespace == "" {
+		in.Namespace = types.CheckNamespaceDefault
+	}
+
+	if !types.IsValidCheckNamespace(in.Namespace) {
+		return usererror.BadRequestf("Namespace must match the regular expression: %s", types.CheckNamespaceRegexp)
+	}
+
+	in.Reason = strings.TrimSpace(in.Reason)
+	if in.Reason == "" {
+		return usererror.BadRequest("Reason is missing")
+	}
+
+	if len(in.Reason) > maxCheckAcknowledgementReasonLength {
+		return usererror.BadRequestf("Reason can be at most %d characters long", maxCheckAcknowledgementReasonLength)
+	}
+
+	expires := time.UnixMilli(in.Expires)
+	if !expires.After(now) {
+		return usererror.BadRequest("Acknowledgement expiration time must be in the future")
+	}
+
+	if expires.Sub(now) > maxCheckAcknowledgementDuration {
+		return usererror.BadRequestf("Status check failures can be acknowledged for at most %s",
+			maxCheckAcknowledgementDuration)
+	}
+
+	return nil
+}
+
+// Acknowledge marks the failure of a status check as known, e.g. when the status check is broken
+// for infrastructure reasons. The acknowledged failure doesn't block merging until it expires
+// or the status check is reported again.
+// Failures can be acknowledged by repository owners and by principals allowed to bypass the status check
+// by the protection rules of an open pull request of the commit, but not by the authors of such pull requests.
+func (c *Controller) Acknowledge(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *AcknowledgeInput,
+) (*types.CheckAcknowledgement, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !git.ValidateCommitSHA(commitSHA) {
+		return nil, usererror.BadRequest("invalid commit SHA provided")
+	}
+
+	if err = in.Sanitize(time.Now()); err != nil {
+		return nil, err
+	}
+
+	check, err := c.checkStore.FindInNamespace(ctx, repo.ID, commitSHA, in.Namespace, in.Identifier)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Status check not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status check: %w", err)
+	}
+
+	if !check.Status.IsFailed() {
+		return nil, usererror.BadRequestf("Only failed status checks can be acknowledged, the status check is %s",
+			check.Status)
+	}
+
+	if err = c.checkAcknowledgeAccess(ctx, session, repo, commitSHA, check.Identifier); err != nil {
+		return nil, err
+	}
+
+	err = c.checkStore.Acknowledge(ctx, check.ID, check.Updated, session.Principal.ID, in.Reason,
+		time.UnixMilli(in.Expires))
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge status check: %w", err)
+	}
+
+	return &types.CheckAcknowledgement{
+		CheckID:        check.ID,
+		Identifier:     check.Identifier,
+		AcknowledgedBy: session.Principal.ID,
+		Reason:         in.Reason,
+		Expires:        in.Expires,
+	}, nil
+}
+
+// checkAcknowledgeAccess verifies that the principal is allowed to acknowledge the failure of the status check.
+func (c *Controller) checkAcknowledgeAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	commitSHA string,
+	identifier string,
+) error {
+	pullReqs, err := c.pullreqStore.ListOpenBySourceSHA(ctx, repo.ID, commitSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list open pull requests of the commit: %w", err)
+	}
+
+	for _, pr := range pullReqs {
+		if pr.CreatedBy == session.Principal.ID {
+			return usererror.Forbidden("Status check failures can't be acknowledged by the pull request author")
+		}
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return fmt.Errorf("failed to determine if the user is the repository owner: %w", err)
+	}
+
+	if isRepoOwner {
+		return nil
+	}
+
+	if len(pullReqs) > 0 {
+		protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+		}
+
+		for _, pr := range pullReqs {
+			reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+				ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+				Actor:              &session.Principal,
+				IsRepoOwner:        false,
+				Repo:               repo,
+				PullReq:            pr,
+				ResolveCheckLabel: func(ctx context.Context, namespace, label string) ([]types.CheckResult, error) {
+					return c.checkStore.ListByLabel(ctx, repo.ID, commitSHA, namespace, label)
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get required checks of pull request %d: %w", pr.Number, err)
+			}
+
+			if _, ok := reqChecks.BypassableIdentifiers[identifier]; ok {
+				return nil
+			}
+		}
+	}
+
+	return usererror.Forbidden(
+		"Status check failures can only be acknowledged by repository owners or users allowed to bypass the check")
+}
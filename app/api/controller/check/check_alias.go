@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AliasCreateInput is used to record the rename of a status check identifier.
+type AliasCreateInput struct {
+	OldIdentifier string `json:"old_identifier"`
+	NewIdentifier string `json:"new_identifier"`
+}
+
+// Sanitize validates and sanitizes the AliasCreateInput data.
+func (in *AliasCreateInput) Sanitize() error {
+	for _, identifier := range []string{in.OldIdentifier, in.NewIdentifier} {
+		if !matcherCheckIdentifier.MatchString(identifier) {
+			return usererror.BadRequestf("Identifier must match the regular expression: %s", regexpCheckIdentifier)
+		}
+	}
+
+	if in.OldIdentifier == in.NewIdentifier {
+		return usererror.BadRequest("The old and the new identifier must be different.")
+	}
+
+	return nil
+}
+
+// ListAliases returns all status check identifier aliases of a repository.
+func (c *Controller) ListAliases(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.CheckAlias, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	aliases, err := c.aliasStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// CreateAlias records that a status check of a repository was renamed, so that protection rules requiring
+// the old identifier are satisfied by the status check reported with the new identifier.
+// Existing aliases of the old identifier are migrated to the new identifier.
+func (c *Controller) CreateAlias(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *AliasCreateInput,
+) (*types.CheckAlias, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	alias := &types.CheckAlias{
+		RepoID:        repo.ID,
+		OldIdentifier: in.OldIdentifier,
+		NewIdentifier: in.NewIdentifier,
+		CreatedBy:     session.Principal.ID,
+		Created:       now,
+		Updated:       now,
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		aliases, err := c.aliasStore.Map(ctx, repo.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get status check aliases: %w", err)
+		}
+
+		// aliases always point to the latest identifier, so the new identifier is resolved if it was renamed too.
+		if newIdentifier, ok := aliases[alias.NewIdentifier]; ok {
+			alias.NewIdentifier = newIdentifier
+		}
+
+		if alias.NewIdentifier == alias.OldIdentifier {
+			return usererror.BadRequestf("Status check %q is already renamed to %q.",
+				in.NewIdentifier, in.OldIdentifier)
+		}
+
+		if err := c.aliasStore.Create(ctx, alias); err != nil {
+			return fmt.Errorf("failed to create status check alias: %w", err)
+		}
+
+		err = c.aliasStore.Retarget(ctx, repo.ID, alias.OldIdentifier, alias.NewIdentifier, now)
+		if err != nil {
+			return fmt.Errorf("failed to migrate status check aliases: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return alias, nil
+}
+
+// DeleteAlias deletes the alias of an old status check identifier of a repository.
+func (c *Controller) DeleteAlias(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oldIdentifier string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err := c.aliasStore.Delete(ctx, repo.ID, oldIdentifier); err != nil {
+		return fmt.Errorf("failed to delete status check alias: %w", err)
+	}
+
+	return nil
+}
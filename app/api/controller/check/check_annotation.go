@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListAnnotations returns the status check annotations of a commit that are attached to the file
+// and cover any line of the (inclusive) line range, e.g. the changed lines shown in a diff view.
+func (c *Controller) ListAnnotations(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	path string,
+	lineFrom int,
+	lineTo int,
+) ([]types.CheckAnnotation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !git.ValidateCommitSHA(commitSHA) {
+		return nil, usererror.BadRequest("invalid commit SHA provided")
+	}
+
+	if path == "" {
+		return nil, usererror.BadRequest("Path is missing")
+	}
+
+	if lineFrom <= 0 || lineTo < lineFrom {
+		return nil, usererror.BadRequest("Invalid line range, line_from must be positive and not greater than line_to")
+	}
+
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	annotations, err := c.annotationStore.ListByLineRange(ctx, repo.ID, commitSHA, path, lineFrom, lineTo, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check annotations: %w", err)
+	}
+
+	return annotations, nil
+}
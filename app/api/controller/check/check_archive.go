@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/checkarchive"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListArchives lists the files of archived status check results of a repository.
+func (c *Controller) ListArchives(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	page, size int,
+) ([]*types.CheckArchive, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	archives, err := c.archiveStore.List(ctx, repo.ID, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check archives: %w", err)
+	}
+
+	return archives, nil
+}
+
+// RestoreArchive returns the status check results stored in an archive file of a repository.
+func (c *Controller) RestoreArchive(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	archiveID int64,
+) ([]types.ArchivedCheck, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	archive, err := c.archiveStore.Find(ctx, archiveID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status check archive: %w", err)
+	}
+
+	if archive.RepoID != repo.ID {
+		return nil, usererror.NotFound("Status check archive not found")
+	}
+
+	checks, err := c.archiver.Restore(ctx, archive)
+	if errors.Is(err, checkarchive.ErrNotConfigured) {
+		return nil, usererror.BadRequest("Status check archive storage is not configured.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore status check archive: %w", err)
+	}
+
+	return checks, nil
+}
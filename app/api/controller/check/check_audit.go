@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// ListAudit returns the status check reports of a principal recorded in the provided time range.
+func (c *Controller) ListAudit(
+	ctx context.Context,
+	session *auth.Session,
+	opts types.CheckAuditListOptions,
+) ([]*types.CheckAuditEntry, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if !opts.From.Before(opts.To) {
+		return nil, usererror.BadRequest("The start of the time range must be before its end")
+	}
+
+	entries, err := c.checkAuditStore.ListAuditByPrincipal(ctx,
+		opts.PrincipalID, opts.From, opts.To, opts.Page, opts.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check audit entries: %w", err)
+	}
+
+	return entries, nil
+}
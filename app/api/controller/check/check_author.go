@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// gitPatternEscaper escapes the special characters of the basic regular expressions used by git log.
+var gitPatternEscaper = strings.NewReplacer(
+	`\`, `\\`, `.`, `\.`, `*`, `\*`, `[`, `\[`, `]`, `\]`, `^`, `\^`, `$`, `\$`)
+
+// ListAuthorChecks returns the status check results of the latest commits of an author on the default branch.
+// The author is identified by the email address and defaults to the caller.
+// Only the author and admins can see the status checks of the author's commits.
+func (c *Controller) ListAuthorChecks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	authorEmail string,
+	limit int,
+) ([]types.AuthorCommitChecks, error) {
+	if authorEmail == "" {
+		authorEmail = session.Principal.Email
+	}
+
+	if !strings.EqualFold(authorEmail, session.Principal.Email) && !session.Principal.Admin {
+		return nil, usererror.Forbidden("Only the author or an admin can see the status checks of the author's commits.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	out, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     repo.DefaultBranch,
+		Page:       1,
+		Limit:      int32(limit),
+		Author:     "<" + gitPatternEscaper.Replace(authorEmail) + ">",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of author: %w", err)
+	}
+
+	commitSHAs := make([]string, len(out.Commits))
+	for i := range out.Commits {
+		commitSHAs[i] = out.Commits[i].SHA.String()
+	}
+
+	if len(commitSHAs) == 0 {
+		return []types.AuthorCommitChecks{}, nil
+	}
+
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	summaries, err := c.checkStore.ResultSummary(ctx, repo.ID, commitSHAs, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check summaries of the author's commits: %w", err)
+	}
+
+	result := make([]types.AuthorCommitChecks, len(out.Commits))
+	for i, commit := range out.Commits {
+		summary := summaries[commit.SHA]
+		result[i] = types.AuthorCommitChecks{
+			SHA:      commitSHAs[i],
+			Title:    commit.Title,
+			Authored: commit.Author.When,
+			Status:   summary.Status(),
+			Summary:  summary,
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ConfigUpdateInput is used to create or update the configuration of a status check.
+type ConfigUpdateInput struct {
+	RetryPolicy types.RetryPolicy `json:"retry_policy"`
+	SLA         *types.CheckSLA   `json:"sla,omitempty"`
+	URLTemplate string            `json:"url_template,omitempty"`
+	Required    *bool             `json:"required,omitempty"`
+}
+
+// Sanitize validates and sanitizes the ConfigUpdateInput data.
+func (in *ConfigUpdateInput) Sanitize() error {
+	if err := in.RetryPolicy.Sanitize(); err != nil {
+		return err
+	}
+
+	if in.SLA != nil {
+		if err := in.SLA.Validate(); err != nil {
+			return err
+		}
+	}
+
+	in.URLTemplate = strings.TrimSpace(in.URLTemplate)
+	if in.URLTemplate != "" {
+		if err := types.ValidateCheckURLTemplate(in.URLTemplate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListConfigs returns all status check configurations of a repository.
+func (c *Controller) ListConfigs(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.CheckConfig, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	configs, err := c.checkConfigStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check configs: %w", err)
+	}
+
+	return configs, nil
+}
+
+// FindConfig returns the configuration of a status check in a repository.
+func (c *Controller) FindConfig(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) (*types.CheckConfig, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	config, err := c.checkConfigStore.Find(ctx, repo.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status check config: %w", err)
+	}
+
+	return config, nil
+}
+
+// UpdateConfig creates or updates the configuration of a status check in a repository.
+func (c *Controller) UpdateConfig(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	in *ConfigUpdateInput,
+) (*types.CheckConfig, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !matcherCheckIdentifier.MatchString(identifier) {
+		return nil, usererror.BadRequestf("Identifier must match the regular expression: %s", regexpCheckIdentifier)
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	config := &types.CheckConfig{
+		RepoID:      repo.ID,
+		Identifier:  identifier,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		RetryPolicy: in.RetryPolicy,
+		SLA:         in.SLA,
+		URLTemplate: in.URLTemplate,
+		Required:    in.Required,
+	}
+
+	if err := c.checkConfigStore.Upsert(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to upsert status check config: %w", err)
+	}
+
+	return config, nil
+}
+
+// DeleteConfig deletes the configuration of a status check in a repository.
+func (c *Controller) DeleteConfig(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err := c.checkConfigStore.Delete(ctx, repo.ID, identifier); err != nil {
+		return fmt.Errorf("failed to delete status check config: %w", err)
+	}
+
+	return nil
+}
+
+// applyConfig applies the configuration of the status check in the repository to the reported status check.
+// It marks completed status checks that took longer than allowed by the SLA as breaching it,
+// and replaces the reported link with the one generated by the URL template, unless it generates none.
+func (c *Controller) applyConfig(ctx context.Context, check *types.Check) error {
+	config, err := c.checkConfigStore.Find(ctx, check.RepoID, check.Identifier)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find status check config: %w", err)
+	}
+
+	check.SLABreached = check.Status.IsCompleted() && config.SLA.IsBreached(check.Started, check.Ended)
+
+	if config.URLTemplate == "" {
+		return nil
+	}
+
+	link, err := types.RenderCheckURLTemplate(config.URLTemplate, check.Metadata)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("check_identifier", check.Identifier).
+			Msg("failed to generate status check link, keeping the reported link")
+		return nil
+	}
+
+	if link != "" {
+		check.Link = link
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// VerifyConsistency compares the cached status check summaries of a repository
+// against the status checks in the database and reports the differences.
+func (c *Controller) VerifyConsistency(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.ConsistencyReport, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	report, err := c.consistency.VerifyConsistency(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify status check consistency: %w", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/checkfeed"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/types"
+)
+
+// Feed streams the status changes of the status checks of all repos of the instance,
+// optionally limited to the repos of a space and to status checks changed to the provided statuses.
+func (c *Controller) Feed(
+	ctx context.Context,
+	session *auth.Session,
+	opts types.CheckFeedOptions,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
+	if !session.Principal.Admin {
+		return nil, nil, nil, usererror.ErrForbidden
+	}
+
+	filter := checkfeed.Filter{
+		Statuses: opts.Statuses,
+	}
+
+	if opts.SpaceRef != "" {
+		space, err := c.spaceStore.FindByRef(ctx, opts.SpaceRef)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		filter.SpaceID = space.ID
+
+		// the status checks of the space are limited to the ones visible with the permissions in the space.
+		filter.Visibilities, err = apiauth.SpaceCheckVisibilities(ctx, c.authorizer, session, space)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get visible status checks: %w", err)
+		}
+	}
+
+	chEvents, chErr, cleanup := c.feed.Subscribe(ctx, filter)
+
+	return chEvents, chErr, cleanup, nil
+}
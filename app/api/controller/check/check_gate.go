@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// checkGatePollInterval is how often the results of the required status checks are reloaded.
+	checkGatePollInterval = 2 * time.Second
+
+	checkGateMaxTimeout     = 10 * time.Minute
+	checkGateMaxIdentifiers = 50
+)
+
+// Gate waits until all required status checks of the commit are satisfied, one of them fails or the timeout expires.
+func (c *Controller) Gate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	opts types.CheckGateOptions,
+) (*types.CheckGateResult, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	required := slices.Clone(opts.RequiredIdentifiers)
+	slices.Sort(required)
+	required = slices.Compact(required)
+	if len(required) == 0 {
+		return nil, usererror.BadRequest("At least one required status check must be provided.")
+	}
+	if len(required) > checkGateMaxIdentifiers {
+		return nil, usererror.BadRequestf("At most %d required status checks can be provided.",
+			checkGateMaxIdentifiers)
+	}
+
+	if opts.Timeout <= 0 || opts.Timeout > checkGateMaxTimeout {
+		return nil, usererror.BadRequestf("The timeout must be between 1 and %d seconds.",
+			int(checkGateMaxTimeout.Seconds()))
+	}
+
+	timer := time.NewTimer(opts.Timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(checkGatePollInterval)
+	defer ticker.Stop()
+
+	for {
+		checks, err := c.checkStore.ListForMergeGate(ctx, repo.ID, commitSHA, required)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list required status checks for repo=%s: %w", repo.Identifier, err)
+		}
+
+		results := make([]types.CheckResult, len(checks))
+		for i, check := range checks {
+			results[i] = types.CheckResult{Identifier: check.Identifier, Status: check.Status}
+		}
+
+		result := evaluateCheckGate(required, results)
+		if result.Status != "" {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			result.Status = enum.CheckGateStatusTimedOut
+			return result, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// evaluateCheckGate returns the gate result of the required status checks.
+// The returned status is empty if the required status checks haven't all completed yet.
+func evaluateCheckGate(required []string, results []types.CheckResult) *types.CheckGateResult {
+	statuses := make(map[string]enum.CheckStatus, len(results))
+	for _, r := range results {
+		statuses[r.Identifier] = r.Status
+	}
+
+	gate := &types.CheckGateResult{
+		Status: enum.CheckGateStatusPassed,
+		Checks: make([]types.CheckResult, len(required)),
+	}
+
+	for i, identifier := range required {
+		status := statuses[identifier]
+		gate.Checks[i] = types.CheckResult{Identifier: identifier, Status: status}
+
+		switch {
+		case status.IsSatisfied():
+		case status.IsCompleted():
+			gate.Status = enum.CheckGateStatusFailed
+		case gate.Status == enum.CheckGateStatusPassed:
+			gate.Status = ""
+		}
+	}
+
+	return gate
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func Test_evaluateCheckGate(t *testing.T) {
+	results := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusSuccess},
+		{Identifier: "lint", Status: enum.CheckStatusSkipped},
+		{Identifier: "test", Status: enum.CheckStatusRunning},
+		{Identifier: "e2e", Status: enum.CheckStatusFailure},
+	}
+
+	tests := []struct {
+		name     string
+		required []string
+		want     enum.CheckGateStatus
+	}{
+		{name: "all satisfied", required: []string{"build"}, want: enum.CheckGateStatusPassed},
+		{name: "skipped", required: []string{"build", "lint"}, want: enum.CheckGateStatusFailed},
+		{name: "still running", required: []string{"build", "test"}, want: ""},
+		{name: "not reported", required: []string{"build", "deploy"}, want: ""},
+		{name: "failed before running", required: []string{"e2e", "test"}, want: enum.CheckGateStatusFailed},
+		{name: "running before failed", required: []string{"test", "e2e"}, want: enum.CheckGateStatusFailed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := evaluateCheckGate(test.required, results)
+			if got.Status != test.want {
+				t.Errorf("evaluateCheckGate() status = %q, want %q", got.Status, test.want)
+			}
+			if len(got.Checks) != len(test.required) {
+				t.Errorf("evaluateCheckGate() returned %d checks, want %d", len(got.Checks), len(test.required))
+			}
+		})
+	}
+
+	got := evaluateCheckGate([]string{"deploy"}, results)
+	want := []types.CheckResult{{Identifier: "deploy"}}
+	if !reflect.DeepEqual(got.Checks, want) {
+		t.Errorf("evaluateCheckGate() checks = %+v, want %+v", got.Checks, want)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/checknormalizer"
+	"github.com/harness/gitness/types"
+)
+
+// Ingest reports a status check from the webhook payload of a third-party CI system.
+// The payload is normalized to a status check by the normalizer of the source, e.g. "circleci".
+func (c *Controller) Ingest(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	source string,
+	raw []byte,
+) (*types.Check, error) {
+	normalized, err := c.normalizer.Normalize(raw, source)
+	if errors.Is(err, checknormalizer.ErrUnknownSource) {
+		return nil, usererror.BadRequestf("Unsupported status check source: %s", source)
+	}
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid %s status check payload: %s", source, err)
+	}
+
+	in := &ReportInput{
+		Identifier: normalized.Identifier,
+		Status:     normalized.Status,
+		Summary:    normalized.Summary,
+		Link:       normalized.Link,
+		Labels:     normalized.Labels,
+		Started:    normalized.Started,
+		Ended:      normalized.Ended,
+	}
+
+	return c.Report(ctx, session, repoRef, normalized.CommitSHA, in, map[string]string{"source": source})
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// OrgIngestionStats returns the organizations that reported the most status checks in the provided time range.
+// Organizations over the status check fair-use limit are marked.
+func (c *Controller) OrgIngestionStats(
+	ctx context.Context,
+	session *auth.Session,
+	opts types.OrgIngestionStatsOptions,
+) ([]*types.OrgIngestionStat, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if opts.From.After(opts.To) {
+		return nil, usererror.BadRequest("The ingestion stats start time must not be after its end time.")
+	}
+
+	stats, err := c.fairUse.Stats(ctx, opts.From, opts.To, opts.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check ingestion stats: %w", err)
+	}
+
+	return stats, nil
+}
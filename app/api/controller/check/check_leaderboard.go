@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Leaderboard returns the principals that reported the most status checks in the repository
+// in the provided time range.
+func (c *Controller) Leaderboard(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	opts types.CheckLeaderboardOptions,
+) ([]*types.CheckLeaderEntry, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if opts.From.After(opts.To) {
+		return nil, usererror.BadRequest("The leaderboard start time must not be after its end time.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	entries, err := c.analyticsStore.LeaderboardByPrincipal(ctx, repo.ID, opts.From, opts.To, opts.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check leaderboard: %w", err)
+	}
+
+	return entries, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -36,29 +37,24 @@ func (c *Controller) ListChecks(
 		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
-	var checks []types.Check
-	var count int
-
-	err = c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
-		checks, err = c.checkStore.List(ctx, repo.ID, commitSHA, opts)
-		if err != nil {
-			return fmt.Errorf("failed to list status check results for repo=%s: %w", repo.Identifier, err)
-		}
+	opts.Visibilities, err = apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
 
-		if opts.Page == 1 && len(checks) < opts.Size {
-			count = len(checks)
-			return nil
-		}
+	// status checks are listed without a transaction, which allows reading them from the replica of the region.
+	checks, err := c.replicatedStore.List(ctx, repo.ID, commitSHA, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list status check results for repo=%s: %w", repo.Identifier, err)
+	}
 
-		count, err = c.checkStore.Count(ctx, repo.ID, commitSHA, opts)
-		if err != nil {
-			return fmt.Errorf("failed to count status check results for repo=%s: %w", repo.Identifier, err)
-		}
+	if opts.Page == 1 && len(checks) < opts.Size {
+		return checks, len(checks), nil
+	}
 
-		return nil
-	})
+	count, err := c.checkStore.Count(ctx, repo.ID, commitSHA, opts)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, fmt.Errorf("failed to count status check results for repo=%s: %w", repo.Identifier, err)
 	}
 
 	return checks, count, nil
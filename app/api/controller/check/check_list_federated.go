@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListFederatedChecks returns status check results for a commit reported to this
+// and to the configured remote gitness instances.
+func (c *Controller) ListFederatedChecks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+) (*types.FederatedCheckList, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	checks, err := c.federatedStore.List(ctx, repo, commitSHA, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list federated status checks for repo=%s: %w", repo.Identifier, err)
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MoveInput struct {
+	// TargetSHA is the commit the status checks are moved to, e.g. the amended commit.
+	TargetSHA string `json:"target_sha"`
+}
+
+type MoveOutput struct {
+	Moved int `json:"moved"`
+}
+
+// Move moves all status checks of a commit to another commit of the repository,
+// so that a commit that replaced it (e.g. after being amended) inherits its status checks.
+func (c *Controller) Move(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *MoveInput,
+) (*MoveOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !git.ValidateCommitSHA(commitSHA) || !git.ValidateCommitSHA(in.TargetSHA) {
+		return nil, usererror.BadRequest("invalid commit SHA provided")
+	}
+
+	if commitSHA == in.TargetSHA {
+		return nil, usererror.BadRequest("Status checks can't be moved to the same commit")
+	}
+
+	if err = c.verifyCommitExists(ctx, repo, in.TargetSHA); err != nil {
+		return nil, err
+	}
+
+	var moved int
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		checks, err := c.checkStore.ListBySHAs(ctx, repo.ID, []string{commitSHA})
+		if err != nil {
+			return fmt.Errorf("failed to list status checks of the commit: %w", err)
+		}
+
+		moved, err = c.replicatedStore.MoveBySHA(ctx, repo.ID, commitSHA, in.TargetSHA)
+		if err != nil {
+			return err
+		}
+
+		// the moved status checks are recorded as created on the target commit.
+		now := time.Now().UnixMilli()
+		for _, check := range checks[commitSHA] {
+			movedCheck := *check
+			movedCheck.CommitSHA = in.TargetSHA
+			movedCheck.Updated = now
+
+			err = c.checkAuditStore.Create(ctx, types.NewCheckAuditEntry(session.Principal.ID, types.Check{}, &movedCheck))
+			if err != nil {
+				return fmt.Errorf("failed to create status check audit entry: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The target commit already has status checks with the same identifiers")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to move status checks: %w", err)
+	}
+
+	return &MoveOutput{Moved: moved}, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PayloadDiff returns the structural difference between the payloads of two status check reports of the repository,
+// identified by their audit log entries, e.g. to show which tests newly passed after a failed status check succeeded.
+func (c *Controller) PayloadDiff(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	fromEntryID, toEntryID int64,
+) (*types.CheckPayloadDiff, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	from, err := c.findRepoAuditEntry(ctx, repo.ID, fromEntryID, visibilities)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := c.findRepoAuditEntry(ctx, repo.ID, toEntryID, visibilities)
+	if err != nil {
+		return nil, err
+	}
+
+	fromPayload, err := payloadAsValue(*from.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payload of status check audit entry %d: %w", from.ID, err)
+	}
+
+	toPayload, err := payloadAsValue(*to.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payload of status check audit entry %d: %w", to.ID, err)
+	}
+
+	diff := &types.CheckPayloadDiff{
+		FromEntryID: from.ID,
+		ToEntryID:   to.ID,
+		Added:       []types.CheckPayloadFieldDiff{},
+		Removed:     []types.CheckPayloadFieldDiff{},
+		Changed:     []types.CheckPayloadFieldDiff{},
+	}
+
+	diffValues(diff, "", fromPayload, toPayload)
+
+	return diff, nil
+}
+
+// findRepoAuditEntry returns the status check audit log entry with the provided ID
+// if it belongs to the repository, the reported status check has any of the visibilities
+// and the reported payload is recorded.
+func (c *Controller) findRepoAuditEntry(
+	ctx context.Context,
+	repoID, entryID int64,
+	visibilities []enum.CheckVisibility,
+) (*types.CheckAuditEntry, error) {
+	entry, err := c.checkAuditStore.Find(ctx, entryID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) ||
+		(err == nil && (entry.RepoID != repoID || !slices.Contains(visibilities, auditEntryVisibility(entry)))) {
+		return nil, usererror.NotFoundf("Status check audit entry %d not found.", entryID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status check audit entry %d: %w", entryID, err)
+	}
+
+	if entry.Payload == nil {
+		return nil, usererror.NotFoundf("Payload of status check audit entry %d not found.", entryID)
+	}
+
+	return entry, nil
+}
+
+// auditEntryVisibility returns the visibility of the reported status check,
+// entries recorded before the visibility got recorded are of public status checks.
+func auditEntryVisibility(entry *types.CheckAuditEntry) enum.CheckVisibility {
+	if entry.After.Visibility == "" {
+		return enum.CheckVisibilityPublic
+	}
+
+	return entry.After.Visibility
+}
+
+// payloadAsValue converts the status check payload to its generic JSON representation.
+// The payload data is inlined, so that its fields are compared individually.
+func payloadAsValue(payload types.CheckPayload) (any, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err = json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// diffValues adds the differences of the two JSON values at the provided path to the diff.
+// Objects are compared by key and arrays by index, all other values are compared as a whole.
+func diffValues(diff *types.CheckPayloadDiff, path string, from, to any) {
+	switch fromValue := from.(type) {
+	case map[string]any:
+		if toValue, ok := to.(map[string]any); ok {
+			diffObjects(diff, path, fromValue, toValue)
+			return
+		}
+	case []any:
+		if toValue, ok := to.([]any); ok {
+			diffArrays(diff, path, fromValue, toValue)
+			return
+		}
+	default:
+		if equalValues(from, to) {
+			return
+		}
+	}
+
+	diff.Changed = append(diff.Changed, types.CheckPayloadFieldDiff{
+		Path: pointerPath(path),
+		Old:  marshalValue(from),
+		New:  marshalValue(to),
+	})
+}
+
+func diffObjects(diff *types.CheckPayloadDiff, path string, from, to map[string]any) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := path + "/" + escapePointerToken(key)
+
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+
+		switch {
+		case !inFrom:
+			diff.Added = append(diff.Added, types.CheckPayloadFieldDiff{Path: keyPath, New: marshalValue(toValue)})
+		case !inTo:
+			diff.Removed = append(diff.Removed, types.CheckPayloadFieldDiff{Path: keyPath, Old: marshalValue(fromValue)})
+		default:
+			diffValues(diff, keyPath, fromValue, toValue)
+		}
+	}
+}
+
+func diffArrays(diff *types.CheckPayloadDiff, path string, from, to []any) {
+	for i := 0; i < max(len(from), len(to)); i++ {
+		itemPath := path + "/" + strconv.Itoa(i)
+
+		switch {
+		case i >= len(from):
+			diff.Added = append(diff.Added, types.CheckPayloadFieldDiff{Path: itemPath, New: marshalValue(to[i])})
+		case i >= len(to):
+			diff.Removed = append(diff.Removed, types.CheckPayloadFieldDiff{Path: itemPath, Old: marshalValue(from[i])})
+		default:
+			diffValues(diff, itemPath, from[i], to[i])
+		}
+	}
+}
+
+func equalValues(a, b any) bool {
+	// scalar JSON values (strings, numbers, booleans and null) are comparable.
+	switch a.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	switch b.(type) {
+	case map[string]any, []any:
+		return false
+	}
+
+	return a == b
+}
+
+func marshalValue(value any) json.RawMessage {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	return raw
+}
+
+func pointerPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func Test_diffValues(t *testing.T) {
+	from := types.CheckPayload{
+		Kind: enum.CheckPayloadKindRaw,
+		Data: json.RawMessage(`{"tests":{"TestA":"failed","TestB":"passed","TestC":"failed"},"a/b":1}`),
+		Steps: []types.CheckStep{
+			{Name: "build", Status: enum.CheckStatusSuccess},
+			{Name: "test", Status: enum.CheckStatusFailure},
+		},
+	}
+	to := types.CheckPayload{
+		Kind: enum.CheckPayloadKindRaw,
+		Data: json.RawMessage(`{"tests":{"TestA":"passed","TestB":"passed","TestD":"passed"},"a/b":1}`),
+		Steps: []types.CheckStep{
+			{Name: "build", Status: enum.CheckStatusSuccess},
+		},
+	}
+
+	fromValue, err := payloadAsValue(from)
+	if err != nil {
+		t.Fatalf("payloadAsValue() error = %v", err)
+	}
+
+	toValue, err := payloadAsValue(to)
+	if err != nil {
+		t.Fatalf("payloadAsValue() error = %v", err)
+	}
+
+	diff := &types.CheckPayloadDiff{}
+	diffValues(diff, "", fromValue, toValue)
+
+	want := &types.CheckPayloadDiff{
+		Added: []types.CheckPayloadFieldDiff{
+			{Path: "/data/tests/TestD", New: json.RawMessage(`"passed"`)},
+		},
+		Removed: []types.CheckPayloadFieldDiff{
+			{Path: "/data/tests/TestC", Old: json.RawMessage(`"failed"`)},
+			{Path: "/steps/1", Old: json.RawMessage(`{"name":"test","status":"failure"}`)},
+		},
+		Changed: []types.CheckPayloadFieldDiff{
+			{Path: "/data/tests/TestA", Old: json.RawMessage(`"failed"`), New: json.RawMessage(`"passed"`)},
+		},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("diffValues() = %+v, want %+v", diff, want)
+	}
+}
+
+func Test_diffValues_TypeChange(t *testing.T) {
+	diff := &types.CheckPayloadDiff{}
+	diffValues(diff, "", map[string]any{"x/y": []any{1.0}}, map[string]any{"x/y": "1"})
+
+	want := []types.CheckPayloadFieldDiff{
+		{Path: "/x~1y", Old: json.RawMessage(`[1]`), New: json.RawMessage(`"1"`)},
+	}
+	if !reflect.DeepEqual(diff.Changed, want) || len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Errorf("diffValues() = %+v, want a single change %+v", diff, want)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -40,6 +41,11 @@ func (c *Controller) ListRecentChecks(
 		opts.Since = time.Now().Add(-30 * 24 * time.Hour).UnixMilli()
 	}
 
+	opts.Visibilities, err = apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
 	checkIdentifiers, err := c.checkStore.ListRecent(ctx, repo.ID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list status check results for repo=%s: %w", repo.Identifier, err)
@@ -47,3 +53,32 @@ func (c *Controller) ListRecentChecks(
 
 	return checkIdentifiers, nil
 }
+
+// ListRecentCheckStats returns the status checks that have been run recently with their flake score.
+func (c *Controller) ListRecentCheckStats(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	opts types.CheckRecentOptions,
+) ([]types.CheckIdentifierStats, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if opts.Since == 0 {
+		opts.Since = time.Now().Add(-30 * 24 * time.Hour).UnixMilli()
+	}
+
+	opts.Visibilities, err = apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	stats, err := c.checkStore.ListRecentStats(ctx, repo.ID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check stats for repo=%s: %w", repo.Identifier, err)
+	}
+
+	return stats, nil
+}
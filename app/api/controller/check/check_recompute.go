@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RecomputeOutput struct {
+	JobID string `json:"job_id"`
+}
+
+// Recompute starts a background job that re-evaluates the required status checks
+// of all open pull requests in the repository.
+func (c *Controller) Recompute(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*RecomputeOutput, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	jobID, err := c.recomputer.Run(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start status check recompute: %w", err)
+	}
+
+	return &RecomputeOutput{JobID: jobID}, nil
+}
+
+// RecomputeProgress returns the progress of a status check recompute job.
+// Once the job is finished its result contains the number of pull requests that changed the state.
+func (c *Controller) RecomputeProgress(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	jobID string,
+) (job.Progress, error) {
+	if !session.Principal.Admin {
+		return job.Progress{}, usererror.ErrForbidden
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return job.Progress{}, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	progress, err := c.recomputer.Progress(ctx, repo.ID, jobID)
+	if err != nil {
+		return job.Progress{}, fmt.Errorf("failed to get status check recompute progress: %w", err)
+	}
+
+	return progress, nil
+}
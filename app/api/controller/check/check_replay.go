@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReplayInput struct {
+	// From is the time (in Unix time millis) since which the status check audit log is replayed.
+	From int64 `json:"from"`
+}
+
+// Replay restores the status check results of the repository from the status check audit log,
+// for example after the database was restored from a backup.
+func (c *Controller) Replay(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ReplayInput,
+) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	if in.From < 0 {
+		return usererror.BadRequest("The replay start time can't be negative.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = c.recovery.Replay(ctx, session.Principal.ID, repo.ID, time.UnixMilli(in.From)); err != nil {
+		return fmt.Errorf("failed to replay status check audit log: %w", err)
+	}
+
+	return nil
+}
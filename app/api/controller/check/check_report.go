@@ -18,13 +18,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -39,18 +42,42 @@ type ReportInput struct {
 	Summary    string             `json:"summary"`
 	Link       string             `json:"link"`
 	Payload    types.CheckPayload `json:"payload"`
+	Labels     []string           `json:"labels"`
 
 	Started int64 `json:"started,omitempty"`
 	Ended   int64 `json:"ended,omitempty"`
+
+	// TargetRepoRef is the optional reference of another repository whose evaluation
+	// of the same commit (e.g. for merging pull requests) the status check belongs to.
+	TargetRepoRef string `json:"target_repo_ref,omitempty"`
+
+	// Annotations replace the existing annotations of the status check if provided.
+	Annotations []*types.CheckAnnotation `json:"annotations,omitempty"`
+
+	// ResourceUsage is the optional resource consumption of the status check run.
+	ResourceUsage *types.CheckResourceUsage `json:"resource_usage,omitempty"`
+
+	// FailureCategory is the optional kind of problem a failed or erroneous status check represents.
+	// If not provided, it's derived from the status.
+	FailureCategory enum.CheckFailureCategory `json:"failure_category,omitempty"`
+
+	// Visibility limits who can see the status check, defaults to public.
+	// Restricted status checks are visible to the contributors, private ones to the admins of the repository.
+	Visibility enum.CheckVisibility `json:"visibility,omitempty"`
+
+	// Namespace isolates the status check from the ones of other namespaces with the same identifier.
+	// It's provided as a query parameter and defaults to types.CheckNamespaceDefault.
+	Namespace string `json:"-"`
 }
 
-// TODO: Can we drop the '$' - depends on whether harness allows it.
-var regexpCheckIdentifier = "^[0-9a-zA-Z-_.$]{1,127}$"
+var regexpCheckIdentifier = types.CheckIdentifierRegexp
 var matcherCheckIdentifier = regexp.MustCompile(regexpCheckIdentifier)
 
 // Sanitize validates and sanitizes the ReportInput data.
 func (in *ReportInput) Sanitize(
-	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error, session *auth.Session,
+	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	session *auth.Session,
+	repo *types.Repository,
 ) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == "" {
@@ -65,6 +92,27 @@ func (in *ReportInput) Sanitize(
 		return usererror.BadRequestf("Identifier must match the regular expression: %s", regexpCheckIdentifier)
 	}
 
+	if in.Namespace == "" {
+		in.Namespace = types.CheckNamespaceDefault
+	}
+
+	if !types.IsValidCheckNamespace(in.Namespace) {
+		return usererror.BadRequestf("Namespace must match the regular expression: %s", types.CheckNamespaceRegexp)
+	}
+
+	if session != nil {
+		if md, ok := session.Metadata.(*auth.CheckReportMetadata); ok {
+			if repo.ID != md.RepoID {
+				return usererror.Forbidden(
+					fmt.Sprintf("Status checks can only be reported to the repository %q", md.RepoPath))
+			}
+			if in.Namespace != md.Namespace {
+				return usererror.Forbidden(
+					fmt.Sprintf("Status checks can only be reported in the namespace %q", md.Namespace))
+			}
+		}
+	}
+
 	_, ok := in.Status.Sanitize()
 	if !ok {
 		return usererror.BadRequest("Invalid value provided for status check status")
@@ -84,6 +132,164 @@ func (in *ReportInput) Sanitize(
 		return usererror.BadRequest("started time reported after ended time")
 	}
 
+	if err := in.sanitizeFailureCategory(); err != nil {
+		return err
+	}
+
+	visibility, ok := in.Visibility.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Invalid value provided for status check visibility")
+	}
+
+	in.Visibility = visibility
+
+	if err := sanitizeSteps(in.Payload.Steps); err != nil {
+		return err
+	}
+
+	if err := in.sanitizeLabels(); err != nil {
+		return err
+	}
+
+	if err := in.sanitizeAnnotations(); err != nil {
+		return err
+	}
+
+	if u := in.ResourceUsage; u != nil && (u.CPUSeconds < 0 || u.MemoryMB < 0 || u.NetworkBytes < 0) {
+		return usererror.BadRequest("Resource usage of a status check can't be negative")
+	}
+
+	return nil
+}
+
+const maxCheckLabels = 20
+
+func (in *ReportInput) sanitizeLabels() error {
+	if len(in.Labels) > maxCheckLabels {
+		return usererror.BadRequestf("A status check can have at most %d labels", maxCheckLabels)
+	}
+
+	labels := make([]string, 0, len(in.Labels))
+	for _, label := range in.Labels {
+		label = strings.TrimSpace(label)
+		if !matcherCheckIdentifier.MatchString(label) {
+			return usererror.BadRequestf("Label must match the regular expression: %s", regexpCheckIdentifier)
+		}
+
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	slices.Sort(labels)
+	in.Labels = labels
+
+	return nil
+}
+
+const (
+	maxCheckAnnotations             = 100
+	maxCheckAnnotationTitleLength   = 255
+	maxCheckAnnotationMessageLength = 4096
+)
+
+func (in *ReportInput) sanitizeAnnotations() error {
+	if len(in.Annotations) > maxCheckAnnotations {
+		return usererror.BadRequestf("A status check can have at most %d annotations", maxCheckAnnotations)
+	}
+
+	for _, annotation := range in.Annotations {
+		if annotation == nil {
+			return usererror.BadRequest("Annotation is missing")
+		}
+
+		annotation.Path = strings.Trim(strings.TrimSpace(annotation.Path), "/")
+		if annotation.Path == "" {
+			return usererror.BadRequest("Annotation path is missing")
+		}
+
+		if annotation.LineStart < 1 {
+			return usererror.BadRequest("Annotation line start must be a positive number")
+		}
+
+		if annotation.LineEnd == 0 {
+			annotation.LineEnd = annotation.LineStart
+		}
+
+		if annotation.LineEnd < annotation.LineStart {
+			return usererror.BadRequest("Annotation line end can't be before line start")
+		}
+
+		level, ok := annotation.Level.Sanitize()
+		if !ok {
+			return usererror.BadRequest("Invalid value provided for annotation level")
+		}
+		annotation.Level = level
+
+		annotation.Title = strings.TrimSpace(annotation.Title)
+		if len(annotation.Title) > maxCheckAnnotationTitleLength {
+			return usererror.BadRequestf("Annotation title can be at most %d characters long",
+				maxCheckAnnotationTitleLength)
+		}
+
+		annotation.Message = strings.TrimSpace(annotation.Message)
+		if annotation.Message == "" {
+			return usererror.BadRequest("Annotation message is missing")
+		}
+
+		if len(annotation.Message) > maxCheckAnnotationMessageLength {
+			return usererror.BadRequestf("Annotation message can be at most %d characters long",
+				maxCheckAnnotationMessageLength)
+		}
+	}
+
+	return nil
+}
+
+// sanitizeFailureCategory validates the failure category, which is only allowed for
+// failed or erroneous status checks, and derives it from the status if it's missing.
+func (in *ReportInput) sanitizeFailureCategory() error {
+	if in.FailureCategory == "" {
+		in.FailureCategory = in.Status.DefaultFailureCategory()
+		return nil
+	}
+
+	category, ok := in.FailureCategory.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Invalid value provided for status check failure category")
+	}
+
+	if in.Status != enum.CheckStatusFailure && in.Status != enum.CheckStatusError {
+		return usererror.BadRequest("Failure category is only allowed for failed or erroneous status checks")
+	}
+
+	in.FailureCategory = category
+
+	return nil
+}
+
+func sanitizeSteps(steps []types.CheckStep) error {
+	names := make(map[string]struct{}, len(steps))
+	for i := range steps {
+		steps[i].Name = strings.TrimSpace(steps[i].Name)
+		if steps[i].Name == "" {
+			return usererror.BadRequest("Step name is missing")
+		}
+
+		if _, ok := names[steps[i].Name]; ok {
+			return usererror.BadRequestf("Duplicate step name: %s", steps[i].Name)
+		}
+		names[steps[i].Name] = struct{}{}
+
+		if _, ok := steps[i].Status.Sanitize(); !ok {
+			return usererror.BadRequestf("Invalid value provided for the status of step %s", steps[i].Name)
+		}
+
+		if steps[i].Duration < 0 {
+			return usererror.BadRequestf("Duration of step %s can't be negative", steps[i].Name)
+		}
+	}
+
 	return nil
 }
 
@@ -131,32 +337,57 @@ func (c *Controller) Report(
 		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
-	if errValidate := in.Sanitize(c.sanitizers, session); errValidate != nil {
+	if errValidate := in.Sanitize(c.sanitizers, session, repo); errValidate != nil {
 		return nil, errValidate
 	}
 
+	if in.Payload.Data, err = c.payloadProcessor.Process(ctx, in.Payload.Data); err != nil {
+		return nil, fmt.Errorf("failed to process status check payload: %w", err)
+	}
+
 	if !git.ValidateCommitSHA(commitSHA) {
 		return nil, usererror.BadRequest("invalid commit SHA provided")
 	}
 
-	_, err = c.git.GetCommit(ctx, &git.GetCommitParams{
-		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
-		Revision:   commitSHA,
-	})
+	if err = c.checkReservedIdentifier(ctx, session, repo, in.Identifier); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkReportRate(ctx, repo.ID, commitSHA, in); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkFairUse(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	// repositories that are being imported or migrated might not contain all git objects yet.
+	if repo.State == enum.RepoStateActive {
+		if err = c.verifyCommitExists(ctx, repo, commitSHA); err != nil {
+			return nil, err
+		}
+	}
+
+	targetRepoID, err := c.getTargetRepoID(ctx, session, repo, in.TargetRepoRef)
 	if err != nil {
-		return nil, fmt.Errorf("failed to commit sha=%s: %w", commitSHA, err)
+		return nil, err
 	}
 
 	now := time.Now().UnixMilli()
 
 	metadataJSON, _ := json.Marshal(metadata)
 
-	existingCheck, err := c.checkStore.FindByIdentifier(ctx, repo.ID, commitSHA, in.Identifier)
+	existingCheck, err := c.checkStore.FindInNamespace(ctx, repo.ID, commitSHA, in.Namespace, in.Identifier)
 
 	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
 		return nil, fmt.Errorf("failed to find existing check for Identifier %q: %w", in.Identifier, err)
 	}
 
+	if err = enum.ValidateStatusTransition(existingCheck.Status, in.Status); err != nil {
+		return nil, usererror.Conflict(fmt.Sprintf("Status check %q can't be updated from %s to %s",
+			in.Identifier, existingCheck.Status, in.Status))
+	}
+
 	started := getStartTime(in, existingCheck, now)
 	ended := getEndTime(in, now)
 
@@ -167,6 +398,7 @@ func (c *Controller) Report(
 		RepoID:     repo.ID,
 		CommitSHA:  commitSHA,
 		Identifier: in.Identifier,
+		Namespace:  in.Namespace,
 		Status:     in.Status,
 		Summary:    in.Summary,
 		Link:       in.Link,
@@ -175,16 +407,101 @@ func (c *Controller) Report(
 		ReportedBy: session.Principal.ToPrincipalInfo(),
 		Started:    started,
 		Ended:      ended,
+		Labels:     in.Labels,
+
+		TargetRepoID:    targetRepoID,
+		ResourceUsage:   in.ResourceUsage,
+		FailureCategory: in.FailureCategory,
+		Visibility:      in.Visibility,
 	}
 
-	err = c.checkStore.Upsert(ctx, statusCheckReport)
+	if err = c.applyConfig(ctx, statusCheckReport); err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := c.replicatedStore.Upsert(ctx, statusCheckReport)
+		if err != nil {
+			return fmt.Errorf("failed to upsert status check result for repo=%s: %w", repo.Identifier, err)
+		}
+
+		// an identical report doesn't change the status check.
+		if !statusCheckReport.Deduplicated {
+			err = c.checkAuditStore.Create(ctx, types.NewCheckAuditEntry(session.Principal.ID, existingCheck, statusCheckReport))
+			if err != nil {
+				return fmt.Errorf("failed to create status check audit entry: %w", err)
+			}
+		}
+
+		if in.Annotations == nil {
+			return nil
+		}
+
+		err = c.annotationStore.Replace(ctx, statusCheckReport, in.Annotations)
+		if err != nil {
+			return fmt.Errorf("failed to replace status check annotations: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert status check result for repo=%s: %w", repo.Identifier, err)
+		return nil, err
+	}
+
+	if statusCheckReport.SLABreached && !existingCheck.SLABreached {
+		c.eventReporter.SLABreached(ctx, &checkevents.SLABreachedPayload{
+			RepoID:     repo.ID,
+			CheckID:    statusCheckReport.ID,
+			CommitSHA:  commitSHA,
+			Identifier: statusCheckReport.Identifier,
+			Started:    statusCheckReport.Started,
+			Ended:      statusCheckReport.Ended,
+		})
 	}
 
 	return statusCheckReport, nil
 }
 
+// getTargetRepoID returns the ID of the repository the reported status check targets, if any.
+// The principal must be allowed to report status checks in the target repository as well.
+func (c *Controller) getTargetRepoID(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	targetRepoRef string,
+) (*int64, error) {
+	if targetRepoRef == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, targetRepoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	if targetRepo.ID == repo.ID {
+		return nil, usererror.BadRequest("The target repository must differ from the repository of the status check")
+	}
+
+	return &targetRepo.ID, nil
+}
+
+// verifyCommitExists returns an unprocessable entity error if the commit doesn't exist in the repository.
+func (c *Controller) verifyCommitExists(ctx context.Context, repo *types.Repository, commitSHA string) error {
+	_, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Revision:   commitSHA,
+	})
+	if errors.IsNotFound(err) {
+		return usererror.UnprocessableEntityf("Commit %s doesn't exist in the repository", commitSHA)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get commit sha=%s: %w", commitSHA, err)
+	}
+
+	return nil
+}
+
 func getStartTime(in *ReportInput, check types.Check, now int64) int64 {
 	// start value came in api
 	if in.Started != 0 {
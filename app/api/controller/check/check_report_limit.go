@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReportRateLimiter limits how often the same status check can be reported.
+// Every status check of a commit is limited separately using a sliding window of its latest reports.
+// The limits are tracked in memory, so they apply per instance.
+type ReportRateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mx        sync.Mutex
+	reports   map[reportKey]*reportWindow
+	lastSweep time.Time
+}
+
+type reportKey struct {
+	repoID     int64
+	commitSHA  string
+	namespace  string
+	identifier string
+}
+
+type reportWindow struct {
+	// times holds the times of the accepted reports within the window, oldest first.
+	times     []time.Time
+	throttled bool
+}
+
+// NewReportRateLimiter returns a rate limiter that accepts up to limit reports of a status check per window.
+// A non-positive limit disables the rate limiting.
+func NewReportRateLimiter(limit int, window time.Duration) *ReportRateLimiter {
+	return &ReportRateLimiter{
+		limit:   limit,
+		window:  window,
+		reports: make(map[reportKey]*reportWindow),
+	}
+}
+
+// allow records a report of the status check at the provided time if it's within the limit.
+// Otherwise, it returns how long to wait before the next report is accepted and
+// whether it's the first rejected report since the last accepted one.
+func (l *ReportRateLimiter) allow(key reportKey, now time.Time) (bool, time.Duration, bool) {
+	if l == nil || l.limit <= 0 {
+		return true, 0, false
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	start := now.Add(-l.window)
+
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, w := range l.reports {
+			if !w.times[len(w.times)-1].After(start) {
+				delete(l.reports, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, ok := l.reports[key]
+	if !ok {
+		w = &reportWindow{times: make([]time.Time, 0, l.limit)}
+		l.reports[key] = w
+	}
+
+	expired := 0
+	for expired < len(w.times) && !w.times[expired].After(start) {
+		expired++
+	}
+	w.times = append(w.times[:0], w.times[expired:]...)
+
+	if len(w.times) < l.limit {
+		w.times = append(w.times, now)
+		w.throttled = false
+		return true, 0, false
+	}
+
+	first := !w.throttled
+	w.throttled = true
+
+	return false, w.times[0].Sub(start), first
+}
+
+// checkReportRate returns a too many requests error if the status check was reported too often.
+func (c *Controller) checkReportRate(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	in *ReportInput,
+) error {
+	key := reportKey{
+		repoID:     repoID,
+		commitSHA:  commitSHA,
+		namespace:  in.Namespace,
+		identifier: in.Identifier,
+	}
+
+	ok, retryAfter, first := c.reportLimiter.allow(key, time.Now())
+	if ok {
+		return nil
+	}
+
+	if first {
+		log.Ctx(ctx).Warn().
+			Int64("repo_id", repoID).
+			Str("commit_sha", commitSHA).
+			Str("namespace", in.Namespace).
+			Str("identifier", in.Identifier).
+			Msg("status check reports are throttled")
+	}
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+
+	return usererror.TooManyRequestsf(time.Duration(seconds)*time.Second,
+		"Status check %q is reported too often, retry in %d seconds.", in.Identifier, seconds)
+}
+
+// checkFairUse returns a too many requests error if the organization of the repository
+// exceeded the fair-use limit of status check reports.
+func (c *Controller) checkFairUse(ctx context.Context, repo *types.Repository) error {
+	if c.fairUse.Allow(ctx, repo.Path) {
+		return nil
+	}
+
+	return usererror.TooManyRequestsf(c.fairUse.RetryAfter(),
+		"The organization exceeded the fair-use limit of status check reports, retry later.")
+}
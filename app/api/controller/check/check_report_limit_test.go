@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportRateLimiter_Allow(t *testing.T) {
+	limiter := NewReportRateLimiter(2, time.Minute)
+
+	build := reportKey{repoID: 1, commitSHA: "abc", identifier: "build"}
+	test := reportKey{repoID: 1, commitSHA: "abc", identifier: "test"}
+
+	now := time.Now()
+
+	for i := range 2 {
+		if ok, _, _ := limiter.allow(build, now.Add(time.Duration(i)*time.Second)); !ok {
+			t.Fatalf("report %d within the limit was rejected", i)
+		}
+	}
+
+	ok, retryAfter, first := limiter.allow(build, now.Add(10*time.Second))
+	if ok {
+		t.Fatal("report above the limit was accepted")
+	}
+	if retryAfter != 50*time.Second {
+		t.Errorf("retry after = %s, want 50s", retryAfter)
+	}
+	if !first {
+		t.Error("first rejected report wasn't reported as first")
+	}
+
+	if _, _, first = limiter.allow(build, now.Add(20*time.Second)); first {
+		t.Error("second rejected report was reported as first")
+	}
+
+	if ok, _, _ = limiter.allow(test, now.Add(20*time.Second)); !ok {
+		t.Error("report of another status check was rejected")
+	}
+
+	// the first report leaves the window.
+	if ok, _, _ = limiter.allow(build, now.Add(time.Minute+time.Millisecond)); !ok {
+		t.Error("report after the window moved was rejected")
+	}
+}
+
+func TestReportRateLimiter_Disabled(t *testing.T) {
+	limiter := NewReportRateLimiter(0, time.Minute)
+
+	now := time.Now()
+	for range 100 {
+		if ok, _, _ := limiter.allow(reportKey{identifier: "build"}, now); !ok {
+			t.Fatal("report was rejected with disabled rate limiting")
+		}
+	}
+}
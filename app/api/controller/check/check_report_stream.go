@@ -0,0 +1,367 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// reportStreamBatchSize is the number of reported status checks that are written at once.
+	reportStreamBatchSize = 50
+	// reportStreamFlushInterval is the longest time a reported status check is buffered before it's written.
+	reportStreamFlushInterval = 500 * time.Millisecond
+)
+
+// ReportStreamInput is a status check result reported over a status check report stream.
+type ReportStreamInput struct {
+	CommitSHA string `json:"commit_sha"`
+	ReportInput
+}
+
+// reportStreamItem is a report received from the stream together with its position in the stream.
+type reportStreamItem struct {
+	seq int
+	in  *ReportStreamInput
+	err error
+}
+
+// ReportStream reports the status check results received from the stream, e.g. the step by step updates
+// of a CI system. The reports are written in batches, every report is answered with the ID of the status check
+// or with the reason it got rejected. It returns once recv returns io.EOF and all reports are written.
+func (c *Controller) ReportStream(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	recv func() (*ReportStreamInput, error),
+	send func(*types.CheckUpsertResponse) error,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReportCommitCheck)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make(chan reportStreamItem)
+	go func() {
+		defer close(items)
+		for seq := 1; ; seq++ {
+			in, err := recv()
+			select {
+			case items <- reportStreamItem{seq: seq, in: in, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	s := &reportStream{
+		c:         c,
+		session:   session,
+		repo:      repo,
+		send:      send,
+		commits:   map[string]struct{}{},
+		batch:     make([]reportStreamItem, 0, reportStreamBatchSize),
+		batchKeys: make(map[reportStreamKey]struct{}, reportStreamBatchSize),
+		batchSize: reportStreamBatchSize,
+	}
+
+	timer := time.NewTimer(reportStreamFlushInterval)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-timer.C:
+			if err = s.flush(ctx); err != nil {
+				return err
+			}
+
+		case item := <-items:
+			if errors.Is(item.err, io.EOF) {
+				return s.flush(ctx)
+			}
+			if item.err != nil {
+				return usererror.BadRequestf("Invalid status check report #%d: %s", item.seq, item.err)
+			}
+
+			added, err := s.add(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			if !added {
+				continue
+			}
+
+			if len(s.batch) == 1 {
+				timer.Reset(reportStreamFlushInterval)
+			}
+
+			if len(s.batch) >= s.batchSize {
+				timer.Stop()
+				if err = s.flush(ctx); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// reportStreamKey identifies a status check of the repository the reports are streamed to.
+type reportStreamKey struct {
+	commitSHA  string
+	identifier string
+}
+
+// reportStream holds the state of a status check report stream.
+type reportStream struct {
+	c         *Controller
+	session   *auth.Session
+	repo      *types.Repository
+	send      func(*types.CheckUpsertResponse) error
+	commits   map[string]struct{}
+	batch     []reportStreamItem
+	batchKeys map[reportStreamKey]struct{}
+	batchSize int
+}
+
+// add validates the reported status check and adds it to the batch.
+// Invalid reports are answered right away and false is returned.
+func (s *reportStream) add(ctx context.Context, item reportStreamItem) (bool, error) {
+	if err := s.validate(ctx, item.in); err != nil {
+		return false, s.reject(ctx, item, err)
+	}
+
+	// the same status check is reported more than once, its previous report is written first
+	// so that the status transition gets validated and both reports are audited.
+	key := reportStreamKey{commitSHA: item.in.CommitSHA, identifier: item.in.Identifier}
+	if _, ok := s.batchKeys[key]; ok {
+		if err := s.flush(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	s.batch = append(s.batch, item)
+	s.batchKeys[key] = struct{}{}
+
+	return true, nil
+}
+
+// reject answers the report with the reason it got rejected. Internal errors abort the stream.
+func (s *reportStream) reject(ctx context.Context, item reportStreamItem, err error) error {
+	uErr := usererror.Translate(ctx, err)
+	if uErr.Status >= http.StatusInternalServerError {
+		return err
+	}
+
+	return s.send(&types.CheckUpsertResponse{
+		Seq:        item.seq,
+		CommitSHA:  item.in.CommitSHA,
+		Identifier: item.in.Identifier,
+		Error:      uErr.Message,
+	})
+}
+
+// validate performs the same validation of the report as Report.
+func (s *reportStream) validate(ctx context.Context, in *ReportStreamInput) error {
+	if in.TargetRepoRef != "" || in.Annotations != nil {
+		return usererror.BadRequest("Target repositories and annotations can't be reported over a stream")
+	}
+
+	if err := in.Sanitize(s.c.sanitizers, s.session, s.repo); err != nil {
+		return err
+	}
+
+	var err error
+	if in.Payload.Data, err = s.c.payloadProcessor.Process(ctx, in.Payload.Data); err != nil {
+		return fmt.Errorf("failed to process status check payload: %w", err)
+	}
+
+	if !git.ValidateCommitSHA(in.CommitSHA) {
+		return usererror.BadRequest("invalid commit SHA provided")
+	}
+
+	if err := s.c.checkReservedIdentifier(ctx, s.session, s.repo, in.Identifier); err != nil {
+		return err
+	}
+
+	if err := s.c.checkReportRate(ctx, s.repo.ID, in.CommitSHA, &in.ReportInput); err != nil {
+		return err
+	}
+
+	if err := s.c.checkFairUse(ctx, s.repo); err != nil {
+		return err
+	}
+
+	// repositories that are being imported or migrated might not contain all git objects yet.
+	if s.repo.State != enum.RepoStateActive {
+		return nil
+	}
+
+	// every commit is verified only once per stream.
+	if _, ok := s.commits[in.CommitSHA]; ok {
+		return nil
+	}
+
+	if err := s.c.verifyCommitExists(ctx, s.repo, in.CommitSHA); err != nil {
+		return err
+	}
+
+	s.commits[in.CommitSHA] = struct{}{}
+
+	return nil
+}
+
+// flush writes the batched status checks, answers the reports and reports the status check events.
+func (s *reportStream) flush(ctx context.Context) error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+
+	batch := s.batch
+	s.batch = make([]reportStreamItem, 0, s.batchSize)
+	s.batchKeys = make(map[reportStreamKey]struct{}, s.batchSize)
+
+	now := time.Now().UnixMilli()
+	metadataJSON, _ := json.Marshal(map[string]string{})
+
+	items := make([]reportStreamItem, 0, len(batch))
+	checks := make([]*types.Check, 0, len(batch))
+	existingChecks := make([]types.Check, 0, len(batch))
+
+	for _, item := range batch {
+		in := item.in
+
+		existingCheck, err := s.c.checkStore.FindInNamespace(ctx, s.repo.ID, in.CommitSHA, in.Namespace, in.Identifier)
+		if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find existing check for Identifier %q: %w", in.Identifier, err)
+		}
+
+		if err = enum.ValidateStatusTransition(existingCheck.Status, in.Status); err != nil {
+			err = usererror.Conflict(fmt.Sprintf("Status check %q can't be updated from %s to %s",
+				in.Identifier, existingCheck.Status, in.Status))
+			if err = s.reject(ctx, item, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		check := &types.Check{
+			CreatedBy:  s.session.Principal.ID,
+			Created:    now,
+			Updated:    now,
+			RepoID:     s.repo.ID,
+			CommitSHA:  in.CommitSHA,
+			Identifier: in.Identifier,
+			Namespace:  in.Namespace,
+			Status:     in.Status,
+			Summary:    in.Summary,
+			Link:       in.Link,
+			Payload:    in.Payload,
+			Metadata:   metadataJSON,
+			ReportedBy: s.session.Principal.ToPrincipalInfo(),
+			Started:    getStartTime(&in.ReportInput, existingCheck, now),
+			Ended:      getEndTime(&in.ReportInput, now),
+			Labels:     in.Labels,
+
+			ResourceUsage:   in.ResourceUsage,
+			FailureCategory: in.FailureCategory,
+			Visibility:      in.Visibility,
+		}
+
+		if err = s.c.applyConfig(ctx, check); err != nil {
+			return err
+		}
+
+		items = append(items, item)
+		checks = append(checks, check)
+		existingChecks = append(existingChecks, existingCheck)
+	}
+
+	if len(checks) == 0 {
+		return nil
+	}
+
+	err := s.c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := s.c.replicatedStore.UpsertBatch(ctx, checks, enum.ConflictStrategyOverwrite)
+		if err != nil {
+			return fmt.Errorf("failed to upsert status check results for repo=%s: %w", s.repo.Identifier, err)
+		}
+
+		for i, check := range checks {
+			err = s.c.checkAuditStore.Create(ctx, types.NewCheckAuditEntry(s.session.Principal.ID, existingChecks[i], check))
+			if err != nil {
+				return fmt.Errorf("failed to create status check audit entry: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, check := range checks {
+		s.reportEvents(ctx, existingChecks[i], check)
+
+		err = s.send(&types.CheckUpsertResponse{
+			Seq:        items[i].seq,
+			ID:         check.ID,
+			CommitSHA:  check.CommitSHA,
+			Identifier: check.Identifier,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send status check report response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *reportStream) reportEvents(ctx context.Context, existing types.Check, check *types.Check) {
+	if check.SLABreached && !existing.SLABreached {
+		s.c.eventReporter.SLABreached(ctx, &checkevents.SLABreachedPayload{
+			RepoID:     check.RepoID,
+			CheckID:    check.ID,
+			CommitSHA:  check.CommitSHA,
+			Identifier: check.Identifier,
+			Started:    check.Started,
+			Ended:      check.Ended,
+		})
+	}
+}
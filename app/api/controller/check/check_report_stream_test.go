@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func Test_reportStream_addRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *ReportStreamInput
+	}{
+		{
+			name: "target repository",
+			in: &ReportStreamInput{
+				CommitSHA:   "1d0e5c9ba5a0e1d7e59e1e77b05dc3dd54f3c83c",
+				ReportInput: ReportInput{Identifier: "build", TargetRepoRef: "space/repo"},
+			},
+		},
+		{
+			name: "annotations",
+			in: &ReportStreamInput{
+				CommitSHA:   "1d0e5c9ba5a0e1d7e59e1e77b05dc3dd54f3c83c",
+				ReportInput: ReportInput{Identifier: "build", Annotations: []*types.CheckAnnotation{}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var responses []*types.CheckUpsertResponse
+
+			s := &reportStream{
+				send: func(resp *types.CheckUpsertResponse) error {
+					responses = append(responses, resp)
+					return nil
+				},
+				batchKeys: map[reportStreamKey]struct{}{},
+			}
+
+			added, err := s.add(context.Background(), reportStreamItem{seq: 3, in: test.in})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if added || len(s.batch) != 0 {
+				t.Errorf("rejected report must not be added to the batch")
+			}
+
+			if len(responses) != 1 {
+				t.Fatalf("expected a single response, got %d", len(responses))
+			}
+
+			if resp := responses[0]; resp.Seq != 3 || resp.ID != 0 || resp.Error == "" {
+				t.Errorf("unexpected response: %+v", resp)
+			}
+		})
+	}
+}
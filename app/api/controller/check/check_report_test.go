@@ -15,8 +15,12 @@
 package check
 
 import (
+	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -213,3 +217,167 @@ func Test_getStartedTime(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateReservedIdentifier(t *testing.T) {
+	reservations := []*types.ReservedCheck{
+		{SpaceID: 1, Identifier: "gitness/security-scan", Reporters: []int64{2}},
+		{SpaceID: 3, Identifier: "gitness/security-scan", Reporters: []int64{4, 5}},
+	}
+
+	tests := []struct {
+		name         string
+		principalID  int64
+		identifier   string
+		reservations []*types.ReservedCheck
+		wantErr      bool
+	}{
+		{
+			name:        "not reserved",
+			principalID: 2,
+			identifier:  "build",
+		},
+		{
+			name:        "system prefix without reservation",
+			principalID: 2,
+			identifier:  "gitness/security-scan",
+			wantErr:     true,
+		},
+		{
+			name:         "reserved for principal",
+			principalID:  2,
+			identifier:   "gitness/security-scan",
+			reservations: reservations,
+		},
+		{
+			name:         "reserved for principal in child space overridden by root space",
+			principalID:  5,
+			identifier:   "gitness/security-scan",
+			reservations: reservations,
+			wantErr:      true,
+		},
+		{
+			name:         "reserved for principal in nearest space",
+			principalID:  5,
+			identifier:   "gitness/security-scan",
+			reservations: reservations[1:],
+		},
+		{
+			name:         "reserved for other principals",
+			principalID:  6,
+			identifier:   "gitness/security-scan",
+			reservations: reservations,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateReservedIdentifier(tt.principalID, tt.identifier, []int64{1, 3}, tt.reservations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateReservedIdentifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var uErr *usererror.Error
+			if err != nil && (!errors.As(err, &uErr) || uErr.Status != http.StatusForbidden) {
+				t.Errorf("validateReservedIdentifier() error = %v, want forbidden", err)
+			}
+		})
+	}
+}
+
+func Test_systemReservationsEqual(t *testing.T) {
+	existing := []*types.ReservedCheck{
+		{SpaceID: 1, Identifier: "gitness/security-scan", Reporters: []int64{4, 2}},
+		{SpaceID: 1, Identifier: "deploy", Reporters: []int64{3}},
+	}
+
+	tests := []struct {
+		name string
+		in   []ReservedCheckInput
+		want bool
+	}{
+		{
+			name: "unchanged",
+			in: []ReservedCheckInput{
+				{Identifier: "gitness/security-scan", Reporters: []int64{2, 4}},
+				{Identifier: "deploy", Reporters: []int64{3}},
+			},
+			want: true,
+		},
+		{
+			name: "other reservations changed",
+			in: []ReservedCheckInput{
+				{Identifier: "gitness/security-scan", Reporters: []int64{2, 4}},
+				{Identifier: "build", Reporters: []int64{5}},
+			},
+			want: true,
+		},
+		{
+			name: "reporters changed",
+			in:   []ReservedCheckInput{{Identifier: "gitness/security-scan", Reporters: []int64{2, 5}}},
+		},
+		{
+			name: "added",
+			in: []ReservedCheckInput{
+				{Identifier: "gitness/security-scan", Reporters: []int64{2, 4}},
+				{Identifier: "gitness/lint", Reporters: []int64{5}},
+			},
+		},
+		{
+			name: "removed",
+			in:   []ReservedCheckInput{{Identifier: "deploy", Reporters: []int64{3}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := systemReservationsEqual(existing, tt.in); got != tt.want {
+				t.Errorf("systemReservationsEqual() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportInput_Sanitize_Namespace(t *testing.T) {
+	sanitizers := map[enum.CheckPayloadKind]func(*ReportInput, *auth.Session) error{
+		enum.CheckPayloadKindEmpty: func(*ReportInput, *auth.Session) error { return nil },
+	}
+
+	restricted := &auth.Session{Metadata: &auth.CheckReportMetadata{
+		RepoID:    1,
+		RepoPath:  "space/repo",
+		Namespace: "deploy",
+	}}
+
+	tests := []struct {
+		name      string
+		session   *auth.Session
+		repoID    int64
+		namespace string
+		wantErr   bool
+	}{
+		{name: "unrestricted", session: &auth.Session{}, repoID: 2, namespace: "team-a"},
+		{name: "restricted to the namespace", session: restricted, repoID: 1, namespace: "deploy"},
+		{name: "restricted to other namespace", session: restricted, repoID: 1, namespace: "team-a", wantErr: true},
+		{name: "restricted default namespace", session: restricted, repoID: 1, wantErr: true},
+		{name: "restricted to other repository", session: restricted, repoID: 2, namespace: "deploy", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := &ReportInput{
+				Identifier: "build",
+				Namespace:  tt.namespace,
+				Status:     enum.CheckStatusSuccess,
+				Payload:    types.CheckPayload{Kind: enum.CheckPayloadKindEmpty},
+			}
+
+			err := in.Sanitize(sanitizers, tt.session, &types.Repository{ID: tt.repoID})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sanitize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var uErr *usererror.Error
+			if err != nil && (!errors.As(err, &uErr) || uErr.Status != http.StatusForbidden) {
+				t.Errorf("Sanitize() error = %v, want forbidden", err)
+			}
+		})
+	}
+}
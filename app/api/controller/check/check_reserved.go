@@ -0,0 +1,242 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxReservedChecks = 100
+
+	// systemCheckIdentifierPrefix is the prefix of the status check identifiers of system checks.
+	// Status checks with the prefix can only be reported if the identifier is reserved for the reporter.
+	systemCheckIdentifierPrefix = "gitness/"
+)
+
+// ReservedCheckInput holds a single reserved status check identifier of a space.
+type ReservedCheckInput struct {
+	Identifier string  `json:"identifier"`
+	Reporters  []int64 `json:"reporters"`
+}
+
+// ReservedCheckUpdateInput is used to replace the reserved status check identifiers of a space.
+type ReservedCheckUpdateInput struct {
+	Reservations []ReservedCheckInput `json:"reservations"`
+}
+
+// Sanitize validates and sanitizes the ReservedCheckUpdateInput data.
+func (in *ReservedCheckUpdateInput) Sanitize() error {
+	if len(in.Reservations) > maxReservedChecks {
+		return usererror.BadRequestf("A space can have at most %d reserved status checks", maxReservedChecks)
+	}
+
+	identifiers := make(map[string]struct{}, len(in.Reservations))
+	for i := range in.Reservations {
+		reservation := &in.Reservations[i]
+
+		if !matcherCheckIdentifier.MatchString(reservation.Identifier) {
+			return usererror.BadRequestf("Identifier must match the regular expression: %s", regexpCheckIdentifier)
+		}
+
+		if _, ok := identifiers[reservation.Identifier]; ok {
+			return usererror.BadRequestf("Duplicate reserved status check: %s", reservation.Identifier)
+		}
+		identifiers[reservation.Identifier] = struct{}{}
+
+		slices.Sort(reservation.Reporters)
+		reservation.Reporters = slices.Compact(reservation.Reporters)
+	}
+
+	return nil
+}
+
+// ListReservedChecks returns all reserved status check identifiers of a space.
+func (c *Controller) ListReservedChecks(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.ReservedCheck, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	reservations, err := c.reservedStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reserved status checks: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// UpdateReservedChecks replaces all reserved status check identifiers of a space.
+// The reservations apply to all repositories in the space and its child spaces.
+// Reservations of system check identifiers can only be changed by site admins.
+func (c *Controller) UpdateReservedChecks(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *ReservedCheckUpdateInput,
+) ([]*types.ReservedCheck, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	if !session.Principal.Admin {
+		existing, err := c.reservedStore.List(ctx, space.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reserved status checks: %w", err)
+		}
+
+		if !systemReservationsEqual(existing, in.Reservations) {
+			return nil, usererror.Forbidden(fmt.Sprintf(
+				"Only admins can change reservations of status check identifiers starting with %q",
+				systemCheckIdentifierPrefix))
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	reservations := make([]*types.ReservedCheck, len(in.Reservations))
+	for i, reservation := range in.Reservations {
+		reservations[i] = &types.ReservedCheck{
+			SpaceID:    space.ID,
+			Identifier: reservation.Identifier,
+			Reporters:  reservation.Reporters,
+			CreatedBy:  session.Principal.ID,
+			Created:    now,
+			Updated:    now,
+		}
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.reservedStore.Replace(ctx, space.ID, reservations)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace reserved status checks: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// systemReservationsEqual returns true if the reservations of system check identifiers are the same
+// in the existing reservations and the input.
+func systemReservationsEqual(existing []*types.ReservedCheck, in []ReservedCheckInput) bool {
+	systemReservations := make(map[string][]int64)
+	for _, reservation := range existing {
+		if strings.HasPrefix(reservation.Identifier, systemCheckIdentifierPrefix) {
+			reporters := slices.Clone(reservation.Reporters)
+			slices.Sort(reporters)
+			systemReservations[reservation.Identifier] = reporters
+		}
+	}
+
+	var count int
+	for _, reservation := range in {
+		if !strings.HasPrefix(reservation.Identifier, systemCheckIdentifierPrefix) {
+			continue
+		}
+
+		reporters, ok := systemReservations[reservation.Identifier]
+		if !ok || !slices.Equal(reporters, reservation.Reporters) {
+			return false
+		}
+
+		count++
+	}
+
+	return count == len(systemReservations)
+}
+
+// checkReservedIdentifier verifies that the principal is allowed to report the status check identifier
+// in the repository. Site admins can report all status checks.
+func (c *Controller) checkReservedIdentifier(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	identifier string,
+) error {
+	if session.Principal.Admin {
+		return nil
+	}
+
+	spaces, err := c.spaceStore.GetAncestors(ctx, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get space ancestors of repository: %w", err)
+	}
+
+	// order the spaces from the root space down to the parent space of the repository.
+	slices.SortFunc(spaces, func(a, b *types.Space) int {
+		return strings.Count(a.Path, "/") - strings.Count(b.Path, "/")
+	})
+
+	spaceIDs := make([]int64, len(spaces))
+	for i, space := range spaces {
+		spaceIDs[i] = space.ID
+	}
+
+	reservations, err := c.reservedStore.ListByIdentifier(ctx, spaceIDs, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to list reservations of status check: %w", err)
+	}
+
+	return validateReservedIdentifier(session.Principal.ID, identifier, spaceIDs, reservations)
+}
+
+// validateReservedIdentifier verifies that the principal is allowed to report the status check identifier.
+// The space IDs must be ordered from the root space down to the parent space of the repository.
+// If the identifier is reserved in multiple spaces, the reservation of the space closest to the root wins,
+// so that child spaces can't grant themselves access to status checks reserved by their ancestors.
+func validateReservedIdentifier(
+	principalID int64,
+	identifier string,
+	spaceIDs []int64,
+	reservations []*types.ReservedCheck,
+) error {
+	for _, spaceID := range spaceIDs {
+		idx := slices.IndexFunc(reservations, func(r *types.ReservedCheck) bool { return r.SpaceID == spaceID })
+		if idx < 0 {
+			continue
+		}
+
+		if slices.Contains(reservations[idx].Reporters, principalID) {
+			return nil
+		}
+
+		return usererror.Forbidden(fmt.Sprintf("Status check %q is reserved and can't be reported by you", identifier))
+	}
+
+	if strings.HasPrefix(identifier, systemCheckIdentifierPrefix) {
+		return usererror.Forbidden(fmt.Sprintf(
+			"Status check identifiers starting with %q are reserved for system checks",
+			systemCheckIdentifierPrefix))
+	}
+
+	return nil
+}
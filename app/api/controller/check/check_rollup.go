@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	checkRollupPageSize   = 100
+	checkRollupMaxCommits = 1000
+)
+
+// RollupForRange aggregates the status check results of all commits reachable from toSHA,
+// but not from fromSHA, e.g. of all commits of a release since the previous tag.
+// If fromSHA is empty, all commits reachable from toSHA are included.
+func (c *Controller) RollupForRange(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	fromSHA, toSHA string,
+) (*types.CheckRollup, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var commitSHAs []string
+
+	for page := 1; ; page++ {
+		out, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: git.CreateReadParams(repo),
+			GitREF:     toSHA,
+			After:      fromSHA,
+			Page:       int32(page),
+			Limit:      checkRollupPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of range: %w", err)
+		}
+
+		for _, commit := range out.Commits {
+			commitSHAs = append(commitSHAs, commit.SHA.String())
+		}
+
+		if len(commitSHAs) > checkRollupMaxCommits {
+			return nil, usererror.BadRequestf("The commit range can't contain more than %d commits.",
+				checkRollupMaxCommits)
+		}
+
+		if len(out.Commits) < checkRollupPageSize {
+			break
+		}
+	}
+
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	summaries, err := c.checkStore.ResultSummary(ctx, repo.ID, commitSHAs, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check summaries of commit range: %w", err)
+	}
+
+	return rollupCheckSummaries(commitSHAs, summaries), nil
+}
+
+// rollupCheckSummaries aggregates the status check summaries of the commits.
+func rollupCheckSummaries(commitSHAs []string, summaries map[sha.SHA]types.CheckCountSummary) *types.CheckRollup {
+	rollup := &types.CheckRollup{
+		CommitCount:    len(commitSHAs),
+		FailingCommits: []string{},
+	}
+
+	for _, commitSHA := range commitSHAs {
+		summary, ok := summaries[sha.Must(commitSHA)]
+		if !ok {
+			continue
+		}
+
+		rollup.Summary.Pending += summary.Pending
+		rollup.Summary.Running += summary.Running
+		rollup.Summary.Success += summary.Success
+		rollup.Summary.Failure += summary.Failure
+		rollup.Summary.Error += summary.Error
+		rollup.Summary.Skipped += summary.Skipped
+
+		if summary.Failure+summary.Error > 0 {
+			rollup.FailingCommits = append(rollup.FailingCommits, commitSHA)
+		}
+	}
+
+	rollup.WorstStatus = rollup.Summary.Status()
+
+	return rollup
+}
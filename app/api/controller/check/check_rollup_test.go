@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func Test_rollupCheckSummaries(t *testing.T) {
+	const (
+		sha1 = "1111111111111111111111111111111111111111"
+		sha2 = "2222222222222222222222222222222222222222"
+		sha3 = "3333333333333333333333333333333333333333"
+	)
+
+	tests := []struct {
+		name      string
+		summaries map[sha.SHA]types.CheckCountSummary
+		exp       *types.CheckRollup
+	}{
+		{
+			name:      "no-checks",
+			summaries: map[sha.SHA]types.CheckCountSummary{},
+			exp: &types.CheckRollup{
+				CommitCount:    3,
+				FailingCommits: []string{},
+			},
+		},
+		{
+			name: "clean",
+			summaries: map[sha.SHA]types.CheckCountSummary{
+				sha.Must(sha1): {Success: 2},
+				sha.Must(sha3): {Success: 1, Skipped: 1},
+			},
+			exp: &types.CheckRollup{
+				CommitCount:    3,
+				Summary:        types.CheckCountSummary{Success: 3, Skipped: 1},
+				WorstStatus:    enum.CheckStatusSuccess,
+				FailingCommits: []string{},
+			},
+		},
+		{
+			name: "failing",
+			summaries: map[sha.SHA]types.CheckCountSummary{
+				sha.Must(sha1): {Success: 1, Running: 1},
+				sha.Must(sha2): {Success: 1, Error: 1},
+				sha.Must(sha3): {Failure: 1},
+			},
+			exp: &types.CheckRollup{
+				CommitCount:    3,
+				Summary:        types.CheckCountSummary{Success: 2, Running: 1, Error: 1, Failure: 1},
+				WorstStatus:    enum.CheckStatusFailure,
+				FailingCommits: []string{sha2, sha3},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := rollupCheckSummaries([]string{sha1, sha2, sha3}, test.summaries)
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("got=%+v, want=%+v", got, test.exp)
+			}
+		})
+	}
+}
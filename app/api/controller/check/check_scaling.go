@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// ScalingHints returns the status check queue and duration metrics of all repositories
+// informing the autoscaling of the CI infrastructure.
+func (c *Controller) ScalingHints(ctx context.Context, session *auth.Session) (*types.CheckScalingHints, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	hints, err := c.scaling.Hints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check scaling hints: %w", err)
+	}
+
+	return hints, nil
+}
+
+// ScalingMetricsHandler returns the HTTP handler exposing the status check scaling hints as Prometheus metrics.
+func (c *Controller) ScalingMetricsHandler(session *auth.Session) (http.Handler, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	return c.scaling.MetricsHandler(), nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/checksearch"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Search returns the status checks of all repositories matching the text of their summaries
+// and the structured filters of the query, newest first.
+func (c *Controller) Search(
+	ctx context.Context,
+	session *auth.Session,
+	query types.CheckSearchQuery,
+) (*types.CheckSearchResult, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if !query.From.IsZero() && !query.To.IsZero() && query.From.After(query.To) {
+		return nil, usererror.BadRequest("The search start time must not be after its end time.")
+	}
+
+	if query.RepoRef != "" {
+		repo, err := c.getRepoCheckAccess(ctx, session, query.RepoRef, enum.PermissionRepoView)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+		}
+
+		query.RepoID = repo.ID
+
+		query.Visibilities, err = apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+		}
+	}
+
+	result, err := c.searcher.Search(ctx, query)
+	if errors.Is(err, checksearch.ErrInvalidCursor) {
+		return nil, usererror.BadRequest("The search cursor is invalid.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search status checks: %w", err)
+	}
+
+	return result, nil
+}
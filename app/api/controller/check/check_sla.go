@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SLABreaches returns the SLA breach rates of the status checks of a repository
+// that completed in the provided time range, per status check identifier.
+func (c *Controller) SLABreaches(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	opts types.CheckSLABreachOptions,
+) ([]types.CheckSLABreachRate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !opts.From.Before(opts.To) {
+		return nil, usererror.BadRequest("The start of the time range must be before its end")
+	}
+
+	rates, err := c.analyticsStore.SLABreachRates(ctx, repo.ID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check SLA breach rates for repo=%s: %w", repo.Identifier, err)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxSpaceCheckPolicies = 100
+
+// SpacePolicyInput holds the policy of a single status check in a space.
+type SpacePolicyInput struct {
+	Identifier string `json:"identifier"`
+	ConfigUpdateInput
+}
+
+// SpacePolicyUpdateInput is used to replace the status check policies of a space.
+type SpacePolicyUpdateInput struct {
+	Policies []SpacePolicyInput `json:"policies"`
+}
+
+// Sanitize validates and sanitizes the SpacePolicyUpdateInput data.
+func (in *SpacePolicyUpdateInput) Sanitize() error {
+	if len(in.Policies) > maxSpaceCheckPolicies {
+		return usererror.BadRequestf("A space can have at most %d status check policies", maxSpaceCheckPolicies)
+	}
+
+	identifiers := make(map[string]struct{}, len(in.Policies))
+	for i := range in.Policies {
+		policy := &in.Policies[i]
+
+		if !matcherCheckIdentifier.MatchString(policy.Identifier) {
+			return usererror.BadRequestf("Identifier must match the regular expression: %s", regexpCheckIdentifier)
+		}
+
+		if _, ok := identifiers[policy.Identifier]; ok {
+			return usererror.BadRequestf("Duplicate status check policy: %s", policy.Identifier)
+		}
+		identifiers[policy.Identifier] = struct{}{}
+
+		if policy.SLA != nil {
+			return usererror.BadRequestf("SLA of status check %s can only be configured for repositories",
+				policy.Identifier)
+		}
+
+		if err := policy.ConfigUpdateInput.Sanitize(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListSpacePolicies returns all status check policies of a space.
+func (c *Controller) ListSpacePolicies(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.SpaceCheckPolicy, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	policies, err := c.spacePolicyStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space status check policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// UpdateSpacePolicies replaces all status check policies of a space.
+// The policies apply to all repositories in the space and its child spaces, unless overridden.
+func (c *Controller) UpdateSpacePolicies(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *SpacePolicyUpdateInput,
+) ([]*types.SpaceCheckPolicy, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	policies := make([]*types.SpaceCheckPolicy, len(in.Policies))
+	for i, policy := range in.Policies {
+		policies[i] = &types.SpaceCheckPolicy{
+			SpaceID:     space.ID,
+			Identifier:  policy.Identifier,
+			CreatedBy:   session.Principal.ID,
+			Created:     now,
+			Updated:     now,
+			RetryPolicy: policy.RetryPolicy,
+			Required:    policy.Required,
+		}
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.spacePolicyStore.Replace(ctx, space.ID, policies)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace space status check policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	reqPermission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, reqPermission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return space, nil
+}
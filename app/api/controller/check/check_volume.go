@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// checkVolumeMaxBucketMinutes is the largest allowed bucket of the status check volume histogram (one week).
+	checkVolumeMaxBucketMinutes = 7 * 24 * 60
+
+	// checkVolumeMaxBuckets is the maximum number of buckets the status check volume histogram can have.
+	checkVolumeMaxBuckets = 10_000
+)
+
+// VolumeHistogram returns the number of status checks reported in the repository in the provided time range,
+// bucketed by the requested interval.
+func (c *Controller) VolumeHistogram(
+	ctx context.Context,
+	session *auth.Session,
+	opts types.CheckVolumeOptions,
+) ([]*types.CheckVolumePoint, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if opts.From.After(opts.To) {
+		return nil, usererror.BadRequest("The histogram start time must not be after its end time.")
+	}
+
+	if opts.BucketMinutes < 1 || opts.BucketMinutes > checkVolumeMaxBucketMinutes {
+		return nil, usererror.BadRequestf("The histogram bucket size must be between 1 and %d minutes.",
+			checkVolumeMaxBucketMinutes)
+	}
+
+	bucket := time.Duration(opts.BucketMinutes) * time.Minute
+	if opts.To.Sub(opts.From)/bucket >= checkVolumeMaxBuckets {
+		return nil, usererror.BadRequestf("The histogram can't have more than %d buckets.", checkVolumeMaxBuckets)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, opts.RepoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	points, err := c.analyticsStore.VolumeHistogram(ctx, repo.ID, opts.From, opts.To, opts.BucketMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check volume histogram: %w", err)
+	}
+
+	return points, nil
+}
@@ -22,6 +22,21 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/checkarchive"
+	"github.com/harness/gitness/app/services/checkconsistency"
+	"github.com/harness/gitness/app/services/checkfairuse"
+	"github.com/harness/gitness/app/services/checkfederation"
+	"github.com/harness/gitness/app/services/checkfeed"
+	"github.com/harness/gitness/app/services/checknormalizer"
+	"github.com/harness/gitness/app/services/checkprocessor"
+	"github.com/harness/gitness/app/services/checkrecompute"
+	"github.com/harness/gitness/app/services/checkrecovery"
+	"github.com/harness/gitness/app/services/checkreplication"
+	"github.com/harness/gitness/app/services/checkscaling"
+	"github.com/harness/gitness/app/services/checksearch"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -30,29 +45,107 @@ import (
 )
 
 type Controller struct {
-	tx         dbtx.Transactor
-	authorizer authz.Authorizer
-	repoStore  store.RepoStore
-	checkStore store.CheckStore
-	git        git.Interface
-	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+	tx                dbtx.Transactor
+	authorizer        authz.Authorizer
+	repoStore         store.RepoStore
+	spaceStore        store.SpaceStore
+	checkStore        store.CheckStore
+	checkConfigStore  store.CheckConfigStore
+	checkAuditStore   store.CheckAuditStore
+	annotationStore   store.CheckAnnotationStore
+	spacePolicyStore  store.SpaceCheckPolicyStore
+	reservedStore     store.ReservedCheckStore
+	aliasStore        store.CheckAliasStore
+	git               git.Interface
+	sanitizers        map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+	eventReporter     *checkevents.Reporter
+	recomputer        *checkrecompute.Service
+	federatedStore    *checkfederation.FederatedCheckStore
+	recovery          *checkrecovery.Service
+	normalizer        checknormalizer.PayloadNormalizer
+	analyticsStore    store.CheckAnalyticsStore
+	feed              *checkfeed.Service
+	archiveStore      store.CheckArchiveStore
+	archiver          *checkarchive.Archiver
+	replicatedStore   *checkreplication.ReplicatedCheckStore
+	payloadProcessor  checkprocessor.PayloadProcessor
+	reportLimiter     *ReportRateLimiter
+	consistency       *checkconsistency.Service
+	searcher          *checksearch.CheckSearchService
+	scaling           *checkscaling.Service
+	fairUse           *checkfairuse.Service
+	pullreqStore      store.PullReqStore
+	protectionManager *protection.Manager
+	userGroupService  usergroup.SearchService
 }
 
 func NewController(
 	tx dbtx.Transactor,
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	checkStore store.CheckStore,
+	checkConfigStore store.CheckConfigStore,
+	checkAuditStore store.CheckAuditStore,
+	annotationStore store.CheckAnnotationStore,
+	spacePolicyStore store.SpaceCheckPolicyStore,
+	reservedStore store.ReservedCheckStore,
+	aliasStore store.CheckAliasStore,
 	git git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	eventReporter *checkevents.Reporter,
+	recomputer *checkrecompute.Service,
+	federatedStore *checkfederation.FederatedCheckStore,
+	recovery *checkrecovery.Service,
+	normalizer checknormalizer.PayloadNormalizer,
+	analyticsStore store.CheckAnalyticsStore,
+	feed *checkfeed.Service,
+	archiveStore store.CheckArchiveStore,
+	archiver *checkarchive.Archiver,
+	replicatedStore *checkreplication.ReplicatedCheckStore,
+	payloadProcessor checkprocessor.PayloadProcessor,
+	reportLimiter *ReportRateLimiter,
+	consistency *checkconsistency.Service,
+	searcher *checksearch.CheckSearchService,
+	scaling *checkscaling.Service,
+	fairUse *checkfairuse.Service,
+	pullreqStore store.PullReqStore,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.SearchService,
 ) *Controller {
 	return &Controller{
-		tx:         tx,
-		authorizer: authorizer,
-		repoStore:  repoStore,
-		checkStore: checkStore,
-		git:        git,
-		sanitizers: sanitizers,
+		tx:                tx,
+		authorizer:        authorizer,
+		repoStore:         repoStore,
+		spaceStore:        spaceStore,
+		checkStore:        checkStore,
+		checkConfigStore:  checkConfigStore,
+		checkAuditStore:   checkAuditStore,
+		annotationStore:   annotationStore,
+		spacePolicyStore:  spacePolicyStore,
+		reservedStore:     reservedStore,
+		aliasStore:        aliasStore,
+		git:               git,
+		sanitizers:        sanitizers,
+		eventReporter:     eventReporter,
+		recomputer:        recomputer,
+		federatedStore:    federatedStore,
+		recovery:          recovery,
+		normalizer:        normalizer,
+		analyticsStore:    analyticsStore,
+		feed:              feed,
+		archiveStore:      archiveStore,
+		archiver:          archiver,
+		replicatedStore:   replicatedStore,
+		payloadProcessor:  payloadProcessor,
+		reportLimiter:     reportLimiter,
+		consistency:       consistency,
+		searcher:          searcher,
+		scaling:           scaling,
+		fairUse:           fairUse,
+		pullreqStore:      pullreqStore,
+		protectionManager: protectionManager,
+		userGroupService:  userGroupService,
 	}
 }
 
@@ -15,6 +15,8 @@
 package check
 
 import (
+	"fmt"
+
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
@@ -32,6 +34,14 @@ func ProvideCheckSanitizers() map[enum.CheckPayloadKind]func(in *ReportInput, s
 	registeredCheckSanitizers[enum.CheckPayloadKindMarkdown] = registeredCheckSanitizers[enum.CheckPayloadKindRaw]
 
 	registeredCheckSanitizers[enum.CheckPayloadKindPipeline] = createPipelinePayloadSanitizer()
+
+	// all other payload kinds (including the ones registered by plugins) are validated against their payload type.
+	for _, kind := range types.RegisteredPayloadKinds() {
+		if _, ok := registeredCheckSanitizers[kind]; !ok {
+			registeredCheckSanitizers[kind] = createTypedPayloadSanitizer()
+		}
+	}
+
 	return registeredCheckSanitizers
 }
 
@@ -73,3 +83,21 @@ func createPipelinePayloadSanitizer() func(in *ReportInput, _ *auth.Session) err
 		return usererror.BadRequest("Kind cannot be pipeline for external checks")
 	}
 }
+
+func createTypedPayloadSanitizer() func(in *ReportInput, _ *auth.Session) error {
+	return func(in *ReportInput, _ *auth.Session) error {
+		payload, err := types.NewPayload(in.Payload.Kind)
+		if err != nil {
+			return fmt.Errorf("failed to create payload for the payload kind '%s': %w", in.Payload.Kind, err)
+		}
+
+		payloadDataJSON, err := SanitizeJSONPayload(in.Payload.Data, payload)
+		if err != nil {
+			return err
+		}
+
+		in.Payload.Data = payloadDataJSON
+
+		return nil
+	}
+}
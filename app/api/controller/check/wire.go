@@ -17,9 +17,25 @@ package check
 import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/checkarchive"
+	"github.com/harness/gitness/app/services/checkconsistency"
+	"github.com/harness/gitness/app/services/checkfairuse"
+	"github.com/harness/gitness/app/services/checkfederation"
+	"github.com/harness/gitness/app/services/checkfeed"
+	"github.com/harness/gitness/app/services/checknormalizer"
+	"github.com/harness/gitness/app/services/checkprocessor"
+	"github.com/harness/gitness/app/services/checkrecompute"
+	"github.com/harness/gitness/app/services/checkrecovery"
+	"github.com/harness/gitness/app/services/checkreplication"
+	"github.com/harness/gitness/app/services/checkscaling"
+	"github.com/harness/gitness/app/services/checksearch"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/google/wire"
@@ -28,23 +44,81 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideCheckSanitizers,
+	ProvideReportRateLimiter,
 	ProvideController,
 )
 
+// ProvideReportRateLimiter provides the rate limiter of status check reports.
+func ProvideReportRateLimiter(config *types.Config) *ReportRateLimiter {
+	return NewReportRateLimiter(config.Checks.ReportRateLimit, config.Checks.ReportRateLimitWindow)
+}
+
 func ProvideController(
 	tx dbtx.Transactor,
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
 	checkStore store.CheckStore,
+	checkConfigStore store.CheckConfigStore,
+	checkAuditStore store.CheckAuditStore,
+	annotationStore store.CheckAnnotationStore,
+	spacePolicyStore store.SpaceCheckPolicyStore,
+	reservedStore store.ReservedCheckStore,
+	aliasStore store.CheckAliasStore,
 	rpcClient git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	eventReporter *checkevents.Reporter,
+	recomputer *checkrecompute.Service,
+	federatedStore *checkfederation.FederatedCheckStore,
+	recovery *checkrecovery.Service,
+	normalizer checknormalizer.PayloadNormalizer,
+	analyticsStore store.CheckAnalyticsStore,
+	feed *checkfeed.Service,
+	archiveStore store.CheckArchiveStore,
+	archiver *checkarchive.Archiver,
+	replicatedStore *checkreplication.ReplicatedCheckStore,
+	payloadProcessor checkprocessor.PayloadProcessor,
+	reportLimiter *ReportRateLimiter,
+	consistency *checkconsistency.Service,
+	searcher *checksearch.CheckSearchService,
+	scaling *checkscaling.Service,
+	fairUse *checkfairuse.Service,
+	pullreqStore store.PullReqStore,
+	protectionManager *protection.Manager,
+	userGroupService usergroup.SearchService,
 ) *Controller {
 	return NewController(
 		tx,
 		authorizer,
 		repoStore,
+		spaceStore,
 		checkStore,
+		checkConfigStore,
+		checkAuditStore,
+		annotationStore,
+		spacePolicyStore,
+		reservedStore,
+		aliasStore,
 		rpcClient,
 		sanitizers,
+		eventReporter,
+		recomputer,
+		federatedStore,
+		recovery,
+		normalizer,
+		analyticsStore,
+		feed,
+		archiveStore,
+		archiver,
+		replicatedStore,
+		payloadProcessor,
+		reportLimiter,
+		consistency,
+		searcher,
+		scaling,
+		fairUse,
+		pullreqStore,
+		protectionManager,
+		userGroupService,
 	)
 }
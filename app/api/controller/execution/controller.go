@@ -24,16 +24,17 @@ import (
 )
 
 type Controller struct {
-	tx             dbtx.Transactor
-	authorizer     authz.Authorizer
-	executionStore store.ExecutionStore
-	checkStore     store.CheckStore
-	canceler       canceler.Canceler
-	commitService  commit.Service
-	triggerer      triggerer.Triggerer
-	repoStore      store.RepoStore
-	stageStore     store.StageStore
-	pipelineStore  store.PipelineStore
+	tx              dbtx.Transactor
+	authorizer      authz.Authorizer
+	executionStore  store.ExecutionStore
+	checkStore      store.CheckStore
+	checkAuditStore store.CheckAuditStore
+	canceler        canceler.Canceler
+	commitService   commit.Service
+	triggerer       triggerer.Triggerer
+	repoStore       store.RepoStore
+	stageStore      store.StageStore
+	pipelineStore   store.PipelineStore
 }
 
 func NewController(
@@ -41,6 +42,7 @@ func NewController(
 	authorizer authz.Authorizer,
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
+	checkAuditStore store.CheckAuditStore,
 	canceler canceler.Canceler,
 	commitService commit.Service,
 	triggerer triggerer.Triggerer,
@@ -49,15 +51,16 @@ func NewController(
 	pipelineStore store.PipelineStore,
 ) *Controller {
 	return &Controller{
-		tx:             tx,
-		authorizer:     authorizer,
-		executionStore: executionStore,
-		checkStore:     checkStore,
-		canceler:       canceler,
-		commitService:  commitService,
-		triggerer:      triggerer,
-		repoStore:      repoStore,
-		stageStore:     stageStore,
-		pipelineStore:  pipelineStore,
+		tx:              tx,
+		authorizer:      authorizer,
+		executionStore:  executionStore,
+		checkStore:      checkStore,
+		checkAuditStore: checkAuditStore,
+		canceler:        canceler,
+		commitService:   commitService,
+		triggerer:       triggerer,
+		repoStore:       repoStore,
+		stageStore:      stageStore,
+		pipelineStore:   pipelineStore,
 	}
 }
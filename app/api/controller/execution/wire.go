@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/checkreplication"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -34,7 +35,8 @@ func ProvideController(
 	tx dbtx.Transactor,
 	authorizer authz.Authorizer,
 	executionStore store.ExecutionStore,
-	checkStore store.CheckStore,
+	checkStore *checkreplication.ReplicatedCheckStore,
+	checkAuditStore store.CheckAuditStore,
 	canceler canceler.Canceler,
 	commitService commit.Service,
 	triggerer triggerer.Triggerer,
@@ -42,6 +44,6 @@ func ProvideController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 ) *Controller {
-	return NewController(tx, authorizer, executionStore, checkStore,
+	return NewController(tx, authorizer, executionStore, checkStore, checkAuditStore,
 		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
 }
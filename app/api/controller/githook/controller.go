@@ -33,6 +33,7 @@ import (
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -45,6 +46,10 @@ type Controller struct {
 	repoReporter        *eventsrepo.Reporter
 	git                 git.Interface
 	pullreqStore        store.PullReqStore
+	checkStore          store.CheckStore
+	checkAliasStore     store.CheckAliasStore
+	checkAuditStore     store.CheckAuditStore
+	tx                  dbtx.Transactor
 	urlProvider         url.Provider
 	protectionManager   *protection.Manager
 	limiter             limiter.ResourceLimiter
@@ -62,6 +67,10 @@ func NewController(
 	repoReporter *eventsrepo.Reporter,
 	git git.Interface,
 	pullreqStore store.PullReqStore,
+	checkStore store.CheckStore,
+	checkAliasStore store.CheckAliasStore,
+	checkAuditStore store.CheckAuditStore,
+	tx dbtx.Transactor,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
 	limiter limiter.ResourceLimiter,
@@ -78,6 +87,10 @@ func NewController(
 		repoReporter:        repoReporter,
 		git:                 git,
 		pullreqStore:        pullreqStore,
+		checkStore:          checkStore,
+		checkAliasStore:     checkAliasStore,
+		checkAuditStore:     checkAuditStore,
+		tx:                  tx,
 		urlProvider:         urlProvider,
 		protectionManager:   protectionManager,
 		limiter:             limiter,
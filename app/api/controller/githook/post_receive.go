@@ -69,6 +69,9 @@ func (c *Controller) PostReceive(
 	// handle branch updates related to PRs - best effort
 	c.handlePRMessaging(ctx, repo, in.PostReceiveInput, &out)
 
+	// skip required status checks of PRs if requested by the commit message - best effort
+	c.handleSkipChecks(ctx, rgit, repo, in.PrincipalID, in.PostReceiveInput, &out)
+
 	err = c.postReceiveExtender.Extend(ctx, rgit, session, repo, in, &out)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to extend post-receive hook: %w", err)
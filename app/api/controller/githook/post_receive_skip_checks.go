@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// skippedCheckSummary is the summary of status checks that were skipped because of a commit message directive.
+const skippedCheckSummary = "Skipped by commit message directive."
+
+// handleSkipChecks reports the required status checks of open pull requests as skipped
+// for every pushed branch whose latest commit message requests to skip status checks.
+// The directive is ignored for protected branches, and only status checks required by protection rules
+// that allow skipped status checks are skipped.
+// Status checks that were already reported for the commit are left untouched.
+func (c *Controller) handleSkipChecks(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	principalID int64,
+	in hook.PostReceiveInput,
+	out *hook.Output,
+) {
+	// map of branch name to the commit sha for which status checks should be skipped.
+	skipBranches := make(map[string]string)
+	for _, refUpdate := range in.RefUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		commit, err := rgit.GetCommit(ctx, &git.GetCommitParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			Revision: refUpdate.New.String(),
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to get commit %s to parse commit directives", refUpdate.New)
+			continue
+		}
+
+		if !types.ParseCommitDirectives(commit.Commit.Message).SkipChecks {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+
+		protected, err := c.protectionManager.IsBranchProtected(ctx, repo, branchName)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to check whether branch %q is protected", branchName)
+			continue
+		}
+
+		if protected {
+			out.Messages = append(out.Messages,
+				fmt.Sprintf("Status checks can't be skipped for the protected branch %q.", branchName))
+			continue
+		}
+
+		skipBranches[branchName] = refUpdate.New.String()
+	}
+
+	if len(skipBranches) == 0 {
+		return
+	}
+
+	branchNames := make([]string, 0, len(skipBranches))
+	for branchName := range skipBranches {
+		branchNames = append(branchNames, branchName)
+	}
+
+	prsPerBranch, err := c.pullreqStore.ListOpenByBranchName(ctx, repo.ID, branchNames)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list open pull requests to skip status checks")
+		return
+	}
+
+	if len(prsPerBranch) == 0 {
+		return
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get protection rules to skip status checks")
+		return
+	}
+
+	for branchName, prs := range prsPerBranch {
+		commitSHA := skipBranches[branchName]
+
+		count, err := c.skipRequiredChecks(ctx, protectionRules, repo, principalID, commitSHA, prs)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to skip status checks of commit %s", commitSHA)
+			continue
+		}
+
+		if count > 0 {
+			out.Messages = append(out.Messages,
+				fmt.Sprintf("Skipped %d required status checks for branch %q.", count, branchName))
+		}
+	}
+}
+
+// skipRequiredChecks reports the status checks required by any of the provided pull requests as skipped
+// for the provided commit, if the protection rules requiring them allow skipped status checks.
+// It returns the number of status checks that were reported as skipped.
+func (c *Controller) skipRequiredChecks(
+	ctx context.Context,
+	protectionRules protection.Protection,
+	repo *types.Repository,
+	principalID int64,
+	commitSHA string,
+	prs []*types.PullReq,
+) (int, error) {
+	checkAliases, err := c.checkAliasStore.Map(ctx, repo.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get status check aliases: %w", err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, repo.ID, commitSHA)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list status check results: %w", err)
+	}
+
+	identifiers := make(map[string]struct{})
+	for _, pr := range prs {
+		// the source SHA of the pull request isn't updated yet, so use the pushed commit instead.
+		pr.SourceSHA = commitSHA
+
+		reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+			Repo:    repo,
+			PullReq: pr,
+			ResolveCheckLabel: func(ctx context.Context, namespace, label string) ([]types.CheckResult, error) {
+				return c.checkStore.ListByLabel(ctx, repo.ID, commitSHA, namespace, label)
+			},
+			CheckAliases: checkAliases,
+			CheckResults: checkResults,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get required checks of pull request %d: %w", pr.Number, err)
+		}
+
+		for identifier := range reqChecks.SkippableIdentifiers {
+			// fan-in status checks are virtual, the status checks they aggregate can't be known in advance.
+			if protection.IsFanInCheck(identifier) {
+				continue
+			}
+			identifiers[identifier] = struct{}{}
+		}
+	}
+
+	if len(identifiers) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UnixMilli()
+
+	checks := make([]*types.Check, 0, len(identifiers))
+	for identifier := range identifiers {
+		checks = append(checks, &types.Check{
+			CreatedBy:  principalID,
+			Created:    now,
+			Updated:    now,
+			RepoID:     repo.ID,
+			CommitSHA:  commitSHA,
+			Identifier: identifier,
+			Status:     enum.CheckStatusSkipped,
+			Summary:    skippedCheckSummary,
+			Metadata:   []byte("{}"),
+			Payload:    types.CheckPayload{Kind: enum.CheckPayloadKindEmpty, Data: []byte("{}")},
+			Started:    now,
+			Ended:      now,
+		})
+	}
+
+	var written int
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		// checks that were already reported for the commit take precedence.
+		err := c.checkStore.UpsertBatch(ctx, checks, enum.ConflictStrategyIgnore)
+		if err != nil {
+			return fmt.Errorf("failed to report skipped status checks: %w", err)
+		}
+
+		for _, check := range checks {
+			// status checks that weren't written don't have an ID.
+			if check.ID == 0 {
+				continue
+			}
+
+			written++
+
+			err = c.checkAuditStore.Create(ctx, types.NewCheckAuditEntry(principalID, types.Check{}, check))
+			if err != nil {
+				return fmt.Errorf("failed to create status check audit entry: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
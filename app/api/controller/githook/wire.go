@@ -19,12 +19,14 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/checkreplication"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
 )
@@ -48,6 +50,10 @@ func ProvideController(
 	repoReporter *eventsrepo.Reporter,
 	git git.Interface,
 	pullreqStore store.PullReqStore,
+	checkStore *checkreplication.ReplicatedCheckStore,
+	checkAliasStore store.CheckAliasStore,
+	checkAuditStore store.CheckAuditStore,
+	tx dbtx.Transactor,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
 	githookFactory hook.ClientFactory,
@@ -65,6 +71,10 @@ func ProvideController(
 		repoReporter,
 		git,
 		pullreqStore,
+		checkStore,
+		checkAliasStore,
+		checkAuditStore,
+		tx,
 		urlProvider,
 		protectionManager,
 		limiter,
@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type repoBranch struct {
+	repoID int64
+	branch string
+}
+
+// setHeadCheckStatus sets the status check status of the branch head on all file matches contained
+// in the head commit of their branch. File matches without a commit SHA are assumed to be from the branch head,
+// their commit SHA is set to the head commit.
+// Status check summaries are loaded with a single query per repository for all commits of the result,
+// counting only the status checks visible to the session.
+// Failures are only logged, because the status check status is not essential for the search result.
+func (c *Controller) setHeadCheckStatus(ctx context.Context, session *auth.Session, fileMatches []types.FileMatch) {
+	heads := make(map[repoBranch]string)
+	repos := make(map[int64]*types.Repository)
+	repoCommits := make(map[int64][]string)
+
+	for i := range fileMatches {
+		key := repoBranch{repoID: fileMatches[i].RepoID, branch: fileMatches[i].RepoBranch}
+		if _, ok := heads[key]; ok {
+			continue
+		}
+
+		headSHA, err := c.getBranchHead(ctx, repos, key)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", key.repoID).
+				Str("branch", key.branch).
+				Msg("failed to get branch head for search result status checks")
+		}
+
+		heads[key] = headSHA
+		if headSHA != "" {
+			repoCommits[key.repoID] = append(repoCommits[key.repoID], headSHA)
+		}
+	}
+
+	summaries := make(map[int64]map[sha.SHA]types.CheckCountSummary, len(repoCommits))
+	for repoID, commitSHAs := range repoCommits {
+		visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repos[repoID])
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repoID).
+				Msg("failed to get visible status checks for search result")
+			continue
+		}
+
+		summary, err := c.checkStore.ResultSummary(ctx, repoID, commitSHAs, visibilities)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repoID).
+				Msg("failed to get status check summary for search result")
+			continue
+		}
+
+		summaries[repoID] = summary
+	}
+
+	for i := range fileMatches {
+		headSHA := heads[repoBranch{repoID: fileMatches[i].RepoID, branch: fileMatches[i].RepoBranch}]
+		if headSHA == "" {
+			continue
+		}
+
+		if fileMatches[i].CommitSHA == "" {
+			fileMatches[i].CommitSHA = headSHA
+		}
+
+		if fileMatches[i].CommitSHA != headSHA {
+			continue
+		}
+
+		summary, ok := summaries[fileMatches[i].RepoID][sha.Must(headSHA)]
+		if !ok {
+			continue
+		}
+
+		status := summary.Status()
+		fileMatches[i].HeadCheckStatus = &status
+	}
+}
+
+// getBranchHead returns the head commit SHA of the branch, the found repositories are cached in repos.
+func (c *Controller) getBranchHead(
+	ctx context.Context,
+	repos map[int64]*types.Repository,
+	key repoBranch,
+) (string, error) {
+	repo, ok := repos[key.repoID]
+	if !ok {
+		var err error
+		repo, err = c.repoStore.Find(ctx, key.repoID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		repos[key.repoID] = repo
+	}
+
+	branch := key.branch
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	out, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: branch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch %q: %w", branch, err)
+	}
+
+	return out.Branch.SHA.String(), nil
+}
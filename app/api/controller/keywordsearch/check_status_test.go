@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	headSHA  = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	otherSHA = "1111111111111111111111111111111111111111"
+)
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id, Path: "space/repo", DefaultBranch: "main"}, nil
+}
+
+// fakeAuthorizer grants all permissions but the ones to edit repositories.
+type fakeAuthorizer struct{}
+
+func (fakeAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return permission != enum.PermissionRepoEdit, nil
+}
+
+func (fakeAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return false, nil
+}
+
+type fakeGit struct {
+	git.Interface
+}
+
+func (fakeGit) GetBranch(context.Context, *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{Branch: git.Branch{Name: "main", SHA: sha.Must(headSHA)}}, nil
+}
+
+type fakeCheckStore struct {
+	store.CheckStore
+	calls        int
+	visibilities []enum.CheckVisibility
+}
+
+func (s *fakeCheckStore) ResultSummary(
+	_ context.Context,
+	_ int64,
+	commitSHAs []string,
+	visibilities []enum.CheckVisibility,
+) (map[sha.SHA]types.CheckCountSummary, error) {
+	s.calls++
+	s.visibilities = visibilities
+	result := make(map[sha.SHA]types.CheckCountSummary)
+	for _, commitSHA := range commitSHAs {
+		result[sha.Must(commitSHA)] = types.CheckCountSummary{Success: 2, Failure: 1}
+	}
+	return result, nil
+}
+
+func TestController_setHeadCheckStatus(t *testing.T) {
+	checkStore := &fakeCheckStore{}
+	c := &Controller{authorizer: fakeAuthorizer{}, repoStore: fakeRepoStore{}, checkStore: checkStore, git: fakeGit{}}
+
+	fileMatches := []types.FileMatch{
+		{FileName: "a.go", RepoID: 1},
+		{FileName: "b.go", RepoID: 1, CommitSHA: headSHA},
+		{FileName: "c.go", RepoID: 1, CommitSHA: otherSHA},
+	}
+
+	c.setHeadCheckStatus(context.Background(), &auth.Session{}, fileMatches)
+
+	for _, fileMatch := range fileMatches[:2] {
+		if fileMatch.HeadCheckStatus == nil || *fileMatch.HeadCheckStatus != enum.CheckStatusFailure {
+			t.Errorf("expected failure head check status for %s, got %v", fileMatch.FileName, fileMatch.HeadCheckStatus)
+		}
+	}
+
+	if fileMatches[0].CommitSHA != headSHA {
+		t.Errorf("expected commit SHA of match without one to be the branch head, got %q", fileMatches[0].CommitSHA)
+	}
+
+	if fileMatches[2].HeadCheckStatus != nil {
+		t.Errorf("expected no head check status for match outside of branch head, got %v",
+			*fileMatches[2].HeadCheckStatus)
+	}
+
+	if checkStore.calls != 1 {
+		t.Errorf("expected a single status check summary query, got %d", checkStore.calls)
+	}
+
+	wantVisibilities := []enum.CheckVisibility{enum.CheckVisibilityPublic, enum.CheckVisibilityRestricted}
+	if !slices.Equal(checkStore.visibilities, wantVisibilities) {
+		t.Errorf("expected status checks visible to contributors %v, got %v", wantVisibilities, checkStore.visibilities)
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
 )
 
 type Controller struct {
@@ -26,6 +28,9 @@ type Controller struct {
 	repoCtrl   *repo.Controller
 	searcher   keywordsearch.Searcher
 	spaceCtrl  *space.Controller
+	repoStore  store.RepoStore
+	checkStore store.CheckStore
+	git        git.Interface
 }
 
 func NewController(
@@ -33,11 +38,17 @@ func NewController(
 	searcher keywordsearch.Searcher,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
+	repoStore store.RepoStore,
+	checkStore store.CheckStore,
+	git git.Interface,
 ) *Controller {
 	return &Controller{
 		authorizer: authorizer,
 		searcher:   searcher,
 		repoCtrl:   repoCtrl,
 		spaceCtrl:  spaceCtrl,
+		repoStore:  repoStore,
+		checkStore: checkStore,
+		git:        git,
 	}
 }
@@ -78,6 +78,11 @@ func (c *Controller) Search(
 		}
 		result.FileMatches[idx].RepoPath = repoPath
 	}
+
+	if in.IncludeChecks {
+		c.setHeadCheckStatus(ctx, session, result.FileMatches)
+	}
+
 	return result, nil
 }
 
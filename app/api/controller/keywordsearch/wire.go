@@ -19,6 +19,8 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -33,6 +35,9 @@ func ProvideController(
 	searcher keywordsearch.Searcher,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
+	repoStore store.RepoStore,
+	checkStore store.CheckStore,
+	git git.Interface,
 ) *Controller {
-	return NewController(authorizer, searcher, repoCtrl, spaceCtrl)
+	return NewController(authorizer, searcher, repoCtrl, spaceCtrl, repoStore, checkStore, git)
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sort"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/types"
@@ -48,12 +49,30 @@ func (c *Controller) ListChecks(
 		return types.PullReqChecks{}, fmt.Errorf("failed to fetch rules: %w", err)
 	}
 
+	resolveCheckLabel := func(ctx context.Context, namespace, label string) ([]types.CheckResult, error) {
+		return c.checkStore.ListByLabel(ctx, repo.ID, pr.SourceSHA, namespace, label)
+	}
+
+	checkAliases, err := c.checkAliasStore.Map(ctx, repo.ID)
+	if err != nil {
+		return types.PullReqChecks{}, fmt.Errorf("failed to get status check aliases: %w", err)
+	}
+
+	// all reported status checks are considered to resolve renamed required status checks, like on merge.
+	reportedResults, err := c.checkStore.ListResults(ctx, repo.ID, pr.SourceSHA)
+	if err != nil {
+		return types.PullReqChecks{}, fmt.Errorf("failed to list status check results: %w", err)
+	}
+
 	reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
 		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
 		Actor:              &session.Principal,
 		IsRepoOwner:        isRepoOwner,
 		Repo:               repo,
 		PullReq:            pr,
+		ResolveCheckLabel:  resolveCheckLabel,
+		CheckAliases:       checkAliases,
+		CheckResults:       reportedResults,
 	})
 	if err != nil {
 		return types.PullReqChecks{}, fmt.Errorf("failed to get identifiers of required checks: %w", err)
@@ -61,7 +80,12 @@ func (c *Controller) ListChecks(
 
 	commitSHA := pr.SourceSHA
 
-	checks, err := c.checkStore.List(ctx, repo.ID, commitSHA, types.CheckListOptions{})
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return types.PullReqChecks{}, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	checks, err := c.checkStore.List(ctx, repo.ID, commitSHA, types.CheckListOptions{Visibilities: visibilities})
 	if err != nil {
 		return types.PullReqChecks{}, fmt.Errorf("failed to list status check results for repo: %w", err)
 	}
@@ -89,6 +113,20 @@ func (c *Controller) ListChecks(
 		})
 	}
 
+	// required status checks that weren't reported yet are pending,
+	// the status of fan-in status checks is computed from the status checks matching their pattern.
+	checkResults := make([]types.CheckResult, len(checks))
+	for i := range checks {
+		checkResults[i] = types.CheckResult{Identifier: checks[i].Identifier, Status: checks[i].Status}
+	}
+
+	missingCheckStatus := func(identifier string) enum.CheckStatus {
+		if protection.IsFanInCheck(identifier) {
+			return protection.FanInCheckStatus(identifier, checkResults)
+		}
+		return enum.CheckStatusPending
+	}
+
 	for requiredID := range reqChecks.RequiredIdentifiers {
 		result.Checks = append(result.Checks, types.PullReqCheck{
 			Required:   true,
@@ -97,7 +135,7 @@ func (c *Controller) ListChecks(
 				RepoID:     repo.ID,
 				CommitSHA:  commitSHA,
 				Identifier: requiredID,
-				Status:     enum.CheckStatusPending,
+				Status:     missingCheckStatus(requiredID),
 				Metadata:   json.RawMessage("{}"),
 			},
 		})
@@ -111,12 +149,16 @@ func (c *Controller) ListChecks(
 				RepoID:     repo.ID,
 				CommitSHA:  commitSHA,
 				Identifier: bypassableID,
-				Status:     enum.CheckStatusPending,
+				Status:     missingCheckStatus(bypassableID),
 				Metadata:   json.RawMessage("{}"),
 			},
 		})
 	}
 
+	if err = c.setBaseCheckStatuses(ctx, repo.ID, pr.MergeBaseSHA, &result); err != nil {
+		return types.PullReqChecks{}, err
+	}
+
 	// Note: The DB List method sorts by "check_updated desc", but here we sort by Identifier,
 	// because we extended the list to include required elements not yet reported (their check_updated timestamp is 0).
 	sort.Slice(result.Checks, func(i, j int) bool {
@@ -125,3 +167,34 @@ func (c *Controller) ListChecks(
 
 	return result, nil
 }
+
+// setBaseCheckStatuses sets the status each status check has on the merge base commit of the pull request.
+func (c *Controller) setBaseCheckStatuses(
+	ctx context.Context,
+	repoID int64,
+	mergeBaseSHA string,
+	result *types.PullReqChecks,
+) error {
+	// the merge base isn't known for pull requests that weren't updated since it's being tracked.
+	if mergeBaseSHA == "" {
+		return nil
+	}
+
+	baseResults, err := c.checkStore.ListResults(ctx, repoID, mergeBaseSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list status check results of the merge base: %w", err)
+	}
+
+	baseStatuses := make(map[string]enum.CheckStatus, len(baseResults))
+	for _, baseResult := range baseResults {
+		baseStatuses[baseResult.Identifier] = baseResult.Status
+	}
+
+	result.MergeBaseSHA = mergeBaseSHA
+
+	for i := range result.Checks {
+		result.Checks[i].BaseStatus = baseStatuses[result.Checks[i].Check.Identifier]
+	}
+
+	return nil
+}
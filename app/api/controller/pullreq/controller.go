@@ -24,6 +24,8 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/checkrecompute"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
@@ -32,6 +34,7 @@ import (
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -64,6 +67,9 @@ type Controller struct {
 	fileViewStore          store.PullReqFileViewStore
 	membershipStore        store.MembershipStore
 	checkStore             store.CheckStore
+	checkAliasStore        store.CheckAliasStore
+	checkConfigResolver    *checkconfig.Resolver
+	checkRecomputer        *checkrecompute.Service
 	git                    git.Interface
 	eventReporter          *pullreqevents.Reporter
 	codeCommentMigrator    *codecomments.Migrator
@@ -75,6 +81,7 @@ type Controller struct {
 	locker                 *locker.Locker
 	importer               *migrate.PullReq
 	labelSvc               *label.Service
+	settings               *settings.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
 }
@@ -97,6 +104,9 @@ func NewController(
 	fileViewStore store.PullReqFileViewStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
+	checkAliasStore store.CheckAliasStore,
+	checkConfigResolver *checkconfig.Resolver,
+	checkRecomputer *checkrecompute.Service,
 	git git.Interface,
 	eventReporter *pullreqevents.Reporter,
 	codeCommentMigrator *codecomments.Migrator,
@@ -108,6 +118,7 @@ func NewController(
 	locker *locker.Locker,
 	importer *migrate.PullReq,
 	labelSvc *label.Service,
+	settings *settings.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
 ) *Controller {
@@ -129,6 +140,9 @@ func NewController(
 		fileViewStore:          fileViewStore,
 		membershipStore:        membershipStore,
 		checkStore:             checkStore,
+		checkAliasStore:        checkAliasStore,
+		checkConfigResolver:    checkConfigResolver,
+		checkRecomputer:        checkRecomputer,
 		git:                    git,
 		codeCommentMigrator:    codeCommentMigrator,
 		eventReporter:          eventReporter,
@@ -140,6 +154,7 @@ func NewController(
 		locker:                 locker,
 		importer:               importer,
 		labelSvc:               labelSvc,
+		settings:               settings,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
 	}
@@ -206,6 +206,19 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
 	}
 
+	resolveCheckLabel := func(ctx context.Context, namespace, label string) ([]types.CheckResult, error) {
+		return c.checkStore.ListByLabel(ctx, targetRepo.ID, pr.SourceSHA, namespace, label)
+	}
+
+	resolveCheckNamespace := func(ctx context.Context, namespace string) ([]types.CheckResult, error) {
+		return c.checkStore.ListResultsInNamespace(ctx, targetRepo.ID, pr.SourceSHA, namespace)
+	}
+
+	checkAliases, err := c.checkAliasStore.Map(ctx, targetRepo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get status check aliases: %w", err)
+	}
+
 	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
 		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
 		Actor:              &session.Principal,
@@ -217,12 +230,22 @@ func (c *Controller) Merge(
 		Reviewers:          reviewers,
 		Method:             in.Method, // the method can be empty for dry run or dry run rules
 		CheckResults:       checkResults,
+		ResolveCheckLabel:  resolveCheckLabel,
+		CheckAliases:       checkAliases,
 		CodeOwners:         codeOwnerWithApproval,
+
+		ResolveCheckNamespace: resolveCheckNamespace,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	// keep the required checks status of the pull request, as shown in pull request listings, up to date.
+	_, err = c.checkRecomputer.Refresh(ctx, protectionRules, targetRepo, pr, checkResults, checkAliases)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to refresh required status checks status of pull request")
+	}
+
 	if in.DryRunRules {
 		return &types.MergeResponse{
 			BranchDeleted:  ruleOut.DeleteSourceBranch,
@@ -249,6 +272,11 @@ func (c *Controller) Merge(
 	)
 	defer cancel()
 
+	// the merge freeze applies to dry runs too, so that they don't report a frozen pull request as mergeable.
+	if err = c.verifyMergeFreeze(ctx, targetRepo.ID, checkResults, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
 	//nolint:nestif
 	if in.DryRun {
 		// As the merge API is always executed under a global lock, we use the opportunity of dry-running the merge
@@ -387,6 +415,10 @@ func (c *Controller) Merge(
 		}, nil
 	}
 
+	if err = c.verifyRequiredChecks(ctx, targetRepo, checkResults, checkAliases); err != nil {
+		return nil, nil, err
+	}
+
 	// commit details: author, committer and message
 
 	var author *git.Identity
@@ -423,6 +455,13 @@ func (c *Controller) Merge(
 		}
 	}
 
+	if in.Method == enum.MergeMethodMerge || in.Method == enum.MergeMethodSquash {
+		in.Message, err = c.appendCheckTrailers(ctx, targetRepo.ID, in.Message, checkResults)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// create merge commit(s)
 
 	log.Ctx(ctx).Debug().Msgf("all pre-check passed, merge PR")
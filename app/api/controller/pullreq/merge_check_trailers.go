@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	trailerCheckStatus = "Check-Status"
+	trailerCheckPassed = "Check-Passed"
+)
+
+// appendCheckTrailers appends the status check trailers, in the format configured for the repository,
+// to the commit message body.
+func (c *Controller) appendCheckTrailers(
+	ctx context.Context,
+	repoID int64,
+	body string,
+	checkResults []types.CheckResult,
+) (string, error) {
+	format, err := settings.RepoGet(
+		ctx,
+		c.settings,
+		repoID,
+		settings.KeyMergeCheckTrailers,
+		settings.DefaultMergeCheckTrailers,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get merge status check trailers format from settings: %w", err)
+	}
+
+	trailers := checkTrailers(format, checkResults)
+	if trailers == "" {
+		return body, nil
+	}
+
+	if body == "" {
+		return trailers, nil
+	}
+
+	return body + "\n\n" + trailers, nil
+}
+
+// checkTrailers returns the status check trailers of the commit message.
+// The trailer values are serialized as RFC 8941 structured fields:
+// the overall status is a token and the passed status checks are a list of strings.
+func checkTrailers(format enum.CheckTrailerFormat, checkResults []types.CheckResult) string {
+	if format == enum.CheckTrailerFormatNone || len(checkResults) == 0 {
+		return ""
+	}
+
+	summary := types.CheckCountSummary{}
+	passed := make([]string, 0, len(checkResults))
+	for _, checkResult := range checkResults {
+		switch checkResult.Status {
+		case enum.CheckStatusPending:
+			summary.Pending++
+		case enum.CheckStatusRunning:
+			summary.Running++
+		case enum.CheckStatusSuccess:
+			summary.Success++
+			passed = append(passed, sfString(checkResult.Identifier))
+		case enum.CheckStatusFailure:
+			summary.Failure++
+		case enum.CheckStatusError:
+			summary.Error++
+		case enum.CheckStatusSkipped:
+			summary.Skipped++
+		}
+	}
+
+	trailers := trailerCheckStatus + ": " + string(summary.Status())
+
+	if format == enum.CheckTrailerFormatVerbose && len(passed) > 0 {
+		trailers += "\n" + trailerCheckPassed + ": " + strings.Join(passed, ", ")
+	}
+
+	return trailers
+}
+
+// sfString serializes the value as an RFC 8941 structured field string.
+func sfString(value string) string {
+	sb := strings.Builder{}
+	sb.WriteByte('"')
+	for _, r := range value {
+		// only printable ASCII characters are allowed in structured field strings.
+		if r < 0x20 || r > 0x7e {
+			continue
+		}
+		if r == '"' || r == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
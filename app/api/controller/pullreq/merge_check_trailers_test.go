@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCheckTrailers(t *testing.T) {
+	checkResults := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusSuccess},
+		{Identifier: "gitness/lint", Status: enum.CheckStatusSuccess},
+		{Identifier: `say "hi"`, Status: enum.CheckStatusSuccess},
+		{Identifier: "e2e", Status: enum.CheckStatusSkipped},
+	}
+
+	tests := []struct {
+		name         string
+		format       enum.CheckTrailerFormat
+		checkResults []types.CheckResult
+		want         string
+	}{
+		{
+			name:         "none",
+			format:       enum.CheckTrailerFormatNone,
+			checkResults: checkResults,
+			want:         "",
+		},
+		{
+			name:   "no-checks",
+			format: enum.CheckTrailerFormatVerbose,
+			want:   "",
+		},
+		{
+			name:         "short",
+			format:       enum.CheckTrailerFormatShort,
+			checkResults: checkResults,
+			want:         "Check-Status: success",
+		},
+		{
+			name:         "verbose",
+			format:       enum.CheckTrailerFormatVerbose,
+			checkResults: checkResults,
+			want: "Check-Status: success\n" +
+				`Check-Passed: "build", "gitness/lint", "say \"hi\""`,
+		},
+		{
+			name:   "verbose-failure",
+			format: enum.CheckTrailerFormatVerbose,
+			checkResults: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusFailure},
+				{Identifier: "lint", Status: enum.CheckStatusSuccess},
+			},
+			want: "Check-Status: failure\n" +
+				`Check-Passed: "lint"`,
+		},
+		{
+			name:   "verbose-none-passed",
+			format: enum.CheckTrailerFormatVerbose,
+			checkResults: []types.CheckResult{
+				{Identifier: "build", Status: enum.CheckStatusError},
+			},
+			want: "Check-Status: error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := checkTrailers(test.format, test.checkResults); got != test.want {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// verifyMergeFreeze returns an error if the repository is in a merge freeze window
+// and the merge freeze bypass condition isn't fulfilled by the status checks of the source commit.
+func (c *Controller) verifyMergeFreeze(
+	ctx context.Context,
+	repoID int64,
+	checkResults []types.CheckResult,
+	now time.Time,
+) error {
+	freeze, err := settings.RepoGet(
+		ctx,
+		c.settings,
+		repoID,
+		settings.KeyMergeFreeze,
+		settings.DefaultMergeFreeze,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get merge freeze from settings: %w", err)
+	}
+
+	active, err := mergeFreezeActive(freeze, now)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate merge freeze schedule: %w", err)
+	}
+
+	if !active || mergeFreezeBypassed(freeze.Bypass, checkResults) {
+		return nil
+	}
+
+	return usererror.NewWithPayload(http.StatusPreconditionFailed,
+		"Pull request can't be merged during a merge freeze.",
+		map[string]any{
+			"schedule": freeze.Schedule,
+			"timezone": freeze.Timezone,
+			"bypass":   freeze.Bypass,
+		})
+}
+
+// mergeFreezeActive returns true if the merge freeze schedule matches the minute of the provided time.
+func mergeFreezeActive(freeze types.MergeFreeze, now time.Time) (bool, error) {
+	if freeze.Schedule == "" {
+		return false, nil
+	}
+
+	expr, err := cronexpr.Parse(freeze.Schedule)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse cron expression: %w", err)
+	}
+
+	loc, err := time.LoadLocation(freeze.Timezone)
+	if err != nil {
+		return false, fmt.Errorf("failed to load time zone: %w", err)
+	}
+
+	minute := now.In(loc).Truncate(time.Minute)
+
+	return expr.Next(minute.Add(-time.Second)).Equal(minute), nil
+}
+
+// mergeFreezeBypassed returns true if the status checks fulfill the merge freeze bypass condition.
+func mergeFreezeBypassed(bypass enum.MergeFreezeBypass, checkResults []types.CheckResult) bool {
+	if bypass != enum.MergeFreezeBypassChecksPassing || len(checkResults) == 0 {
+		return false
+	}
+
+	for _, checkResult := range checkResults {
+		if !checkResult.IsSatisfied() {
+			return false
+		}
+	}
+
+	return true
+}
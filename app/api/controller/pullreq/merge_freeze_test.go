@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMergeFreezeActive(t *testing.T) {
+	// Friday, 2024-03-15 16:30 UTC
+	now := time.Date(2024, 3, 15, 16, 30, 20, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		freeze types.MergeFreeze
+		want   bool
+	}{
+		{
+			name:   "no-schedule",
+			freeze: types.MergeFreeze{},
+			want:   false,
+		},
+		{
+			name:   "friday-afternoon",
+			freeze: types.MergeFreeze{Schedule: "* 15-23 * * 5"},
+			want:   true,
+		},
+		{
+			name:   "friday-morning",
+			freeze: types.MergeFreeze{Schedule: "* 0-11 * * 5"},
+			want:   false,
+		},
+		{
+			name:   "timezone",
+			freeze: types.MergeFreeze{Schedule: "* 15-23 * * 5", Timezone: "America/Los_Angeles"},
+			want:   false,
+		},
+		{
+			name:   "timezone-morning",
+			freeze: types.MergeFreeze{Schedule: "* 9 * * 5", Timezone: "America/Los_Angeles"},
+			want:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := mergeFreezeActive(test.freeze, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMergeFreezeBypassed(t *testing.T) {
+	passing := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusSuccess},
+		{Identifier: "e2e", Status: enum.CheckStatusSuccess},
+	}
+	skipped := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusSuccess},
+		{Identifier: "e2e", Status: enum.CheckStatusSkipped},
+	}
+	failing := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusSuccess},
+		{Identifier: "e2e", Status: enum.CheckStatusPending},
+	}
+
+	tests := []struct {
+		name         string
+		bypass       enum.MergeFreezeBypass
+		checkResults []types.CheckResult
+		want         bool
+	}{
+		{name: "none", bypass: enum.MergeFreezeBypassNone, checkResults: passing, want: false},
+		{name: "passing", bypass: enum.MergeFreezeBypassChecksPassing, checkResults: passing, want: true},
+		{name: "skipped", bypass: enum.MergeFreezeBypassChecksPassing, checkResults: skipped, want: false},
+		{name: "failing", bypass: enum.MergeFreezeBypassChecksPassing, checkResults: failing, want: false},
+		{name: "no-checks", bypass: enum.MergeFreezeBypassChecksPassing, checkResults: nil, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := mergeFreezeBypassed(test.bypass, test.checkResults); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
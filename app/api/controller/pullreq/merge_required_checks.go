@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+// verifyRequiredChecks returns an error if a status check required by the status check configuration
+// of the repository, or by the policy of a space above it, didn't succeed.
+func (c *Controller) verifyRequiredChecks(
+	ctx context.Context,
+	repo *types.Repository,
+	checkResults []types.CheckResult,
+	checkAliases map[string]string,
+) error {
+	passing, failing, err := c.checkConfigResolver.AreRequiredChecksPassing(ctx, repo, checkResults, checkAliases)
+	if err != nil {
+		return fmt.Errorf("failed to verify required status checks: %w", err)
+	}
+
+	if passing {
+		return nil
+	}
+
+	return usererror.NewWithPayload(http.StatusPreconditionFailed,
+		fmt.Sprintf("The following status checks are required to be completed successfully: %s",
+			strings.Join(failing, ", ")),
+		map[string]any{
+			"checks": failing,
+		})
+}
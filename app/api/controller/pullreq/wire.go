@@ -17,6 +17,8 @@ package pullreq
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/checkrecompute"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
@@ -25,6 +27,7 @@ import (
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -57,11 +60,15 @@ func ProvideController(
 	fileViewStore store.PullReqFileViewStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
+	checkAliasStore store.CheckAliasStore,
+	checkConfigResolver *checkconfig.Resolver,
+	checkRecomputer *checkrecompute.Service,
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, pullreqListService *pullreq.ListService,
 	ruleManager *protection.Manager, sseStreamer sse.Streamer,
 	codeOwners *codeowners.Service, locker *locker.Locker, importer *migrate.PullReq,
 	labelSvc *label.Service,
+	settings *settings.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
 ) *Controller {
@@ -82,6 +89,9 @@ func ProvideController(
 		fileViewStore,
 		membershipStore,
 		checkStore,
+		checkAliasStore,
+		checkConfigResolver,
+		checkRecomputer,
 		rpcClient,
 		eventReporter,
 		codeCommentMigrator,
@@ -93,6 +103,7 @@ func ProvideController(
 		locker,
 		importer,
 		labelSvc,
+		settings,
 		instrumentation,
 		userGroupService,
 	)
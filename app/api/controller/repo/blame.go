@@ -16,20 +16,35 @@ package repo
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"strings"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
+// BlamePart is a part of the blame output of a file.
+type BlamePart struct {
+	*git.BlamePart
+
+	// CheckStatus is the overall status of the status checks of the commit of the blame part.
+	// It's only set if requested and if status checks were reported for the commit.
+	CheckStatus *enum.CheckStatus `json:"check_status,omitempty"`
+}
+
 func (c *Controller) Blame(ctx context.Context,
 	session *auth.Session,
 	repoRef, gitRef, path string,
 	lineFrom, lineTo int,
-) (types.Stream[*git.BlamePart], error) {
+	includeChecks bool,
+) (types.Stream[*BlamePart], error) {
 	path = strings.TrimSpace(path)
 	if path == "" {
 		return nil, usererror.BadRequest("File path needs to specified.")
@@ -57,5 +72,89 @@ func (c *Controller) Blame(ctx context.Context,
 			LineTo:     lineTo,
 		}))
 
-	return reader, nil
+	if !includeChecks {
+		return &blamePartStream{parts: reader}, nil
+	}
+
+	// the status checks of all commits are loaded at once, so the whole blame output is read upfront.
+	parts := make([]*git.BlamePart, 0)
+	commitSHAs := make([]string, 0)
+	seen := make(map[sha.SHA]struct{})
+	for {
+		part, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, part)
+
+		if part.Commit == nil {
+			continue
+		}
+		if _, ok := seen[part.Commit.SHA]; !ok {
+			seen[part.Commit.SHA] = struct{}{}
+			commitSHAs = append(commitSHAs, part.Commit.SHA.String())
+		}
+	}
+
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	checkSummary, err := c.checkStore.ResultSummary(ctx, repo.ID, commitSHAs, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch check summary for commits: %w", err)
+	}
+
+	statuses := make(map[sha.SHA]enum.CheckStatus, len(checkSummary))
+	for commitSHA, summary := range checkSummary {
+		if status := summary.Status(); status != "" {
+			statuses[commitSHA] = status
+		}
+	}
+
+	return &blamePartStream{parts: &sliceStream[*git.BlamePart]{items: parts}, statuses: statuses}, nil
+}
+
+// blamePartStream adds the status check status of the commits to the git blame parts.
+type blamePartStream struct {
+	parts    types.Stream[*git.BlamePart]
+	statuses map[sha.SHA]enum.CheckStatus
+}
+
+func (s *blamePartStream) Next() (*BlamePart, error) {
+	part, err := s.parts.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	out := &BlamePart{BlamePart: part}
+	if part.Commit != nil {
+		if status, ok := s.statuses[part.Commit.SHA]; ok {
+			out.CheckStatus = &status
+		}
+	}
+
+	return out, nil
+}
+
+// sliceStream streams the elements of a slice.
+type sliceStream[T any] struct {
+	items []T
+}
+
+func (s *sliceStream[T]) Next() (T, error) {
+	var null T
+	if len(s.items) == 0 {
+		return null, io.EOF
+	}
+
+	item := s.items[0]
+	s.items = s.items[1:]
+
+	return item, nil
 }
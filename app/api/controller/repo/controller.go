@@ -27,6 +27,8 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/checkhead"
+	"github.com/harness/gitness/app/services/checkhealth"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
@@ -54,6 +56,23 @@ type RepositoryOutput struct {
 	types.Repository
 	IsPublic  bool `json:"is_public" yaml:"is_public"`
 	Importing bool `json:"importing" yaml:"-"`
+	// LatestCheckStatus is the overall status of the status checks of the latest checked commit.
+	// It's only populated when listing repositories.
+	LatestCheckStatus enum.CheckStatus `json:"latest_check_status,omitempty" yaml:"-"`
+	// HealthScore is the ratio of successful status checks completed in the past day, between 0 and 1.
+	HealthScore *float64 `json:"health_score,omitempty" yaml:"-"`
+	// HealthDegraded is true if the status check failure rate of the past day
+	// is significantly higher than the one of the preceding week.
+	HealthDegraded bool `json:"health_degraded,omitempty" yaml:"-"`
+	// DefaultBranchCheckStatus is the overall status of the status checks of the default branch head.
+	// It's only populated when listing forks with status checks included.
+	DefaultBranchCheckStatus *enum.CheckStatus `json:"default_branch_check_status,omitempty" yaml:"-"`
+}
+
+// SetCheckHealth populates the status check health fields of the repository output.
+func (r *RepositoryOutput) SetCheckHealth(health types.CheckHealth) {
+	r.HealthScore = &health.Score
+	r.HealthDegraded = health.Degraded
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -82,6 +101,10 @@ type Controller struct {
 	principalStore     store.PrincipalStore
 	ruleStore          store.RuleStore
 	checkStore         store.CheckStore
+	annotationStore    store.CheckAnnotationStore
+	checkHealth        *checkhealth.Service
+	checkHeads         *checkhead.Service
+	checkSummaryCache  store.RepoCheckSummaryCache
 	pullReqStore       store.PullReqStore
 	settings           *settings.Service
 	principalInfoCache store.PrincipalInfoCache
@@ -116,6 +139,10 @@ func NewController(
 	principalStore store.PrincipalStore,
 	ruleStore store.RuleStore,
 	checkStore store.CheckStore,
+	annotationStore store.CheckAnnotationStore,
+	checkHealth *checkhealth.Service,
+	checkHeads *checkhead.Service,
+	checkSummaryCache store.RepoCheckSummaryCache,
 	pullReqStore store.PullReqStore,
 	settings *settings.Service,
 	principalInfoCache store.PrincipalInfoCache,
@@ -149,6 +176,10 @@ func NewController(
 		principalStore:     principalStore,
 		ruleStore:          ruleStore,
 		checkStore:         checkStore,
+		annotationStore:    annotationStore,
+		checkHealth:        checkHealth,
+		checkHeads:         checkHeads,
+		checkSummaryCache:  checkSummaryCache,
 		pullReqStore:       pullReqStore,
 		settings:           settings,
 		principalInfoCache: principalInfoCache,
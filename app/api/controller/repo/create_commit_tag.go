@@ -81,6 +81,10 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		return nil, violations, nil
 	}
 
+	if err = c.verifyTagChecks(ctx, repo, in.Target); err != nil {
+		return nil, nil, err
+	}
+
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
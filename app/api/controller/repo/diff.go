@@ -16,6 +16,7 @@ package repo
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
@@ -98,6 +99,7 @@ func (c *Controller) DiffStats(
 	session *auth.Session,
 	repoRef string,
 	path string,
+	includeCheckAnnotations bool,
 ) (types.DiffStats, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -123,7 +125,115 @@ func (c *Controller) DiffStats(
 		return types.DiffStats{}, err
 	}
 
-	return types.NewDiffStats(output.Commits, output.FilesChanged, output.Additions, output.Deletions), nil
+	stats := types.NewDiffStats(output.Commits, output.FilesChanged, output.Additions, output.Deletions)
+
+	if includeCheckAnnotations {
+		stats.CheckAnnotations, err = c.diffCheckAnnotations(ctx, session, repo, info)
+		if err != nil {
+			return types.DiffStats{}, fmt.Errorf("failed to get status check annotations: %w", err)
+		}
+	}
+
+	return stats, nil
+}
+
+// diffCheckAnnotations returns status check annotations reported for the head commit
+// grouped by the changed line ranges they affect.
+// Same as the diff stats, the changes are always calculated against the merge base.
+func (c *Controller) diffCheckAnnotations(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	info CompareInfo,
+) ([]types.DiffCheckAnnotations, error) {
+	readParams := git.CreateReadParams(repo)
+
+	headCommit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		Revision:   info.HeadRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head commit: %w", err)
+	}
+
+	mergeBase, err := c.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       info.BaseRef,
+		Ref2:       info.HeadRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	headSHA := headCommit.Commit.SHA.String()
+
+	hunks, err := c.git.GetDiffHunkHeaders(ctx, git.GetDiffHunkHeadersParams{
+		ReadParams:      readParams,
+		SourceCommitSHA: mergeBase.MergeBaseSHA.String(),
+		TargetCommitSHA: headSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff hunk headers: %w", err)
+	}
+
+	paths := make([]string, 0, len(hunks.Files))
+	for _, file := range hunks.Files {
+		if file.FileHeader.NewName != "" {
+			paths = append(paths, file.FileHeader.NewName)
+		}
+	}
+
+	visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+	}
+
+	annotations, err := c.annotationStore.ListByPaths(ctx, repo.ID, headSHA, paths, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check annotations: %w", err)
+	}
+
+	annotationsPerPath := make(map[string][]types.CheckAnnotation)
+	for _, annotation := range annotations {
+		annotationsPerPath[annotation.Path] = append(annotationsPerPath[annotation.Path], annotation)
+	}
+
+	result := make([]types.DiffCheckAnnotations, 0)
+	for _, file := range hunks.Files {
+		fileAnnotations := annotationsPerPath[file.FileHeader.NewName]
+		if len(fileAnnotations) == 0 {
+			continue
+		}
+
+		for _, hunk := range file.HunkHeaders {
+			if hunk.NewSpan == 0 {
+				continue // only lines were removed, nothing to annotate in the head commit
+			}
+
+			lineStart := hunk.NewLine
+			lineEnd := hunk.NewLine + hunk.NewSpan - 1
+
+			var affecting []types.CheckAnnotation
+			for _, annotation := range fileAnnotations {
+				if annotation.Overlaps(lineStart, lineEnd) {
+					affecting = append(affecting, annotation)
+				}
+			}
+
+			if len(affecting) == 0 {
+				continue
+			}
+
+			result = append(result, types.DiffCheckAnnotations{
+				Path:        file.FileHeader.NewName,
+				LineStart:   lineStart,
+				LineEnd:     lineEnd,
+				Annotations: affecting,
+			})
+		}
+	}
+
+	return result, nil
 }
 
 func (c *Controller) Diff(
@@ -20,6 +20,8 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Find finds a repo.
@@ -38,5 +40,18 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session, repoRef st
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
 
-	return GetRepoOutput(ctx, c.publicAccess, repo)
+	repoOut, err := GetRepoOutput(ctx, c.publicAccess, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// the status check health isn't essential for the repository, it's omitted if it can't be computed.
+	health, err := c.checkHealth.Health(ctx, []int64{repo.ID})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get status check health of repo")
+	} else {
+		repoOut.SetCheckHealth(health[repo.ID])
+	}
+
+	return repoOut, nil
 }
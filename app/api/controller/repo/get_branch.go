@@ -45,7 +45,7 @@ func (c *Controller) GetBranch(ctx context.Context,
 		return nil, fmt.Errorf("failed to get branch: %w", err)
 	}
 
-	metadata, err := c.collectBranchMetadata(ctx, repo, []git.Branch{rpcOut.Branch}, options)
+	metadata, err := c.collectBranchMetadata(ctx, session, repo, []git.Branch{rpcOut.Branch}, options)
 	if err != nil {
 		return nil, fmt.Errorf("fail to collect branch metadata: %w", err)
 	}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
@@ -25,12 +26,17 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+// commitChecksLimit is the maximum number of status checks returned with a commit.
+const commitChecksLimit = 100
+
 // GetCommit gets a repo commit.
+// If includeChecks is true, the status checks reported for the commit are returned as well.
 func (c *Controller) GetCommit(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	sha string,
-) (*types.Commit, error) {
+	includeChecks bool,
+) (*types.CommitExtended, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, err
@@ -50,5 +56,24 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
-	return commit, nil
+	out := &types.CommitExtended{Commit: *commit}
+
+	if includeChecks {
+		visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get visible status checks: %w", err)
+		}
+
+		out.Checks, err = c.checkStore.List(ctx, repo.ID, commit.SHA, types.CheckListOptions{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: 1, Size: commitChecksLimit},
+			},
+			Visibilities: visibilities,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list status checks of the commit: %w", err)
+		}
+	}
+
+	return out, nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
@@ -55,7 +56,7 @@ func (c *Controller) ListBranches(ctx context.Context,
 
 	branches := rpcOut.Branches
 
-	metadata, err := c.collectBranchMetadata(ctx, repo, branches, filter.BranchMetadataOptions)
+	metadata, err := c.collectBranchMetadata(ctx, session, repo, branches, filter.BranchMetadataOptions)
 	if err != nil {
 		return nil, fmt.Errorf("fail to collect branch metadata: %w", err)
 	}
@@ -80,6 +81,7 @@ func (c *Controller) ListBranches(ctx context.Context,
 // Each of these would be returned only if the corresponding option is true.
 func (c *Controller) collectBranchMetadata(
 	ctx context.Context,
+	session *auth.Session,
 	repo *types.Repository,
 	branches []git.Branch,
 	options types.BranchMetadataOptions,
@@ -98,7 +100,12 @@ func (c *Controller) collectBranchMetadata(
 			commitSHAs[i] = branches[i].SHA.String()
 		}
 
-		checkSummary, err = c.checkStore.ResultSummary(ctx, repo.ID, commitSHAs)
+		visibilities, err := apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+		if err != nil {
+			return branchMetadataOutput{}, fmt.Errorf("failed to get visible status checks: %w", err)
+		}
+
+		checkSummary, err = c.checkStore.ResultSummary(ctx, repo.ID, commitSHAs, visibilities)
 		if err != nil {
 			return branchMetadataOutput{}, fmt.Errorf("fail to fetch check summary for commits: %w", err)
 		}
@@ -18,9 +18,11 @@ import (
 	"context"
 	"fmt"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -42,18 +44,20 @@ func (c *Controller) ListCommits(ctx context.Context,
 		gitRef = repo.DefaultBranch
 	}
 
-	rpcOut, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
-		ReadParams:   git.CreateReadParams(repo),
-		GitREF:       gitRef,
-		After:        filter.After,
-		Page:         int32(filter.Page),
-		Limit:        int32(filter.Limit),
-		Path:         filter.Path,
-		Since:        filter.Since,
-		Until:        filter.Until,
-		Committer:    filter.Committer,
-		IncludeStats: filter.IncludeStats,
-	})
+	var checkVisibilities []enum.CheckVisibility
+	if filter.ChecksStatus != "" || filter.ChecksIdentifier != "" || filter.IncludeChecks {
+		checkVisibilities, err = apiauth.CheckVisibilities(ctx, c.authorizer, session, repo)
+		if err != nil {
+			return types.ListCommitResponse{}, fmt.Errorf("failed to get visible status checks: %w", err)
+		}
+	}
+
+	var rpcOut *git.ListCommitsOutput
+	if filter.ChecksStatus != "" || filter.ChecksIdentifier != "" {
+		rpcOut, err = c.listCommitsByChecks(ctx, repo, gitRef, filter, checkVisibilities)
+	} else {
+		rpcOut, err = c.git.ListCommits(ctx, listCommitsParams(repo, gitRef, filter, filter.Page, filter.Limit))
+	}
 	if err != nil {
 		return types.ListCommitResponse{}, err
 	}
@@ -68,6 +72,18 @@ func (c *Controller) ListCommits(ctx context.Context,
 		commits[i] = *commit
 	}
 
+	if filter.IncludeChecks {
+		offset := 0
+		if filter.Page > 1 {
+			offset = (filter.Page - 1) * filter.Limit
+		}
+
+		err = c.addCommitCheckStatuses(ctx, repo.ID, commits, offset, rpcOut.TotalCommits, checkVisibilities)
+		if err != nil {
+			return types.ListCommitResponse{}, err
+		}
+	}
+
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
 	for i := range rpcOut.RenameDetails {
 		renameDetails := controller.MapRenameDetails(rpcOut.RenameDetails[i])
@@ -82,3 +98,175 @@ func (c *Controller) ListCommits(ctx context.Context,
 		TotalCommits:  rpcOut.TotalCommits,
 	}, nil
 }
+
+func listCommitsParams(
+	repo *types.Repository,
+	gitRef string,
+	filter *types.CommitFilter,
+	page int,
+	limit int,
+) *git.ListCommitsParams {
+	return &git.ListCommitsParams{
+		ReadParams:   git.CreateReadParams(repo),
+		GitREF:       gitRef,
+		After:        filter.After,
+		Page:         int32(page),
+		Limit:        int32(limit),
+		Path:         filter.Path,
+		Since:        filter.Since,
+		Until:        filter.Until,
+		Committer:    filter.Committer,
+		IncludeStats: filter.IncludeStats,
+	}
+}
+
+const (
+	// commitChecksFilterBatchSize is the number of commits read from git at once
+	// when the commits are filtered by their status checks.
+	commitChecksFilterBatchSize = 500
+	// commitChecksFilterMaxScan is the maximum number of commits that are filtered by their status checks.
+	// Older commits aren't considered, neither for the page nor for the total.
+	commitChecksFilterMaxScan = 10000
+)
+
+// listCommitsByChecks lists the commits that have a status check result matching the filter.
+// Git has no knowledge of status checks, so the commits are read from git in batches and filtered
+// until the requested page is full. The scan continues up to commitChecksFilterMaxScan commits
+// to count the total number of matching commits.
+func (c *Controller) listCommitsByChecks(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	filter *types.CommitFilter,
+	visibilities []enum.CheckVisibility,
+) (*git.ListCommitsOutput, error) {
+	skip := 0
+	if filter.Page > 1 {
+		skip = (filter.Page - 1) * filter.Limit
+	}
+
+	out := &git.ListCommitsOutput{}
+	matched := 0
+
+	for page := 1; (page-1)*commitChecksFilterBatchSize < commitChecksFilterMaxScan; page++ {
+		batch, err := c.git.ListCommits(ctx,
+			listCommitsParams(repo, gitRef, filter, page, commitChecksFilterBatchSize))
+		if err != nil {
+			return nil, err
+		}
+
+		commits, err := c.filterCommitsByChecks(ctx, repo.ID, batch.Commits, filter, visibilities)
+		if err != nil {
+			return nil, err
+		}
+
+		pageSize := len(out.Commits)
+		for i := range commits {
+			if matched >= skip && len(out.Commits) < filter.Limit {
+				out.Commits = append(out.Commits, commits[i])
+			}
+			matched++
+		}
+
+		if len(out.Commits) > pageSize {
+			out.RenameDetails = append(out.RenameDetails, batch.RenameDetails...)
+		}
+
+		if len(batch.Commits) < commitChecksFilterBatchSize {
+			break
+		}
+	}
+
+	out.TotalCommits = matched
+
+	return out, nil
+}
+
+// filterCommitsByChecks keeps only the commits that have a status check result matching the filter.
+func (c *Controller) filterCommitsByChecks(
+	ctx context.Context,
+	repoID int64,
+	commits []git.Commit,
+	filter *types.CommitFilter,
+	visibilities []enum.CheckVisibility,
+) ([]git.Commit, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+
+	commitSHAs := make([]string, len(commits))
+	for i := range commits {
+		commitSHAs[i] = commits[i].SHA.String()
+	}
+
+	matchingSHAs, err := c.checkStore.FilterCommitSHAs(
+		ctx, repoID, commitSHAs, filter.ChecksIdentifier, filter.ChecksStatus, visibilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter commits by status checks: %w", err)
+	}
+
+	matching := make(map[string]struct{}, len(matchingSHAs))
+	for _, commitSHA := range matchingSHAs {
+		matching[commitSHA] = struct{}{}
+	}
+
+	filtered := make([]git.Commit, 0, len(matchingSHAs))
+	for i := range commits {
+		if _, ok := matching[commits[i].SHA.String()]; ok {
+			filtered = append(filtered, commits[i])
+		}
+	}
+
+	return filtered, nil
+}
+
+const (
+	// commitChecksMaxRange is the size of a compare range above which
+	// only the first commitChecksLargeRangeCommits commits of the range get their status check status.
+	commitChecksMaxRange          = 100
+	commitChecksLargeRangeCommits = 50
+)
+
+// addCommitCheckStatuses sets the status check status of the commits, loaded for all commits with a single query.
+// The offset is the position of the first commit in the listed range, and total is the number of commits
+// of the range. Commits without status checks are left without a status.
+func (c *Controller) addCommitCheckStatuses(
+	ctx context.Context,
+	repoID int64,
+	commits []types.Commit,
+	offset int,
+	total int,
+	visibilities []enum.CheckVisibility,
+) error {
+	n := len(commits)
+	if total > commitChecksMaxRange {
+		n = max(min(n, commitChecksLargeRangeCommits-offset), 0)
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	commitSHAs := make([]string, n)
+	for i := range n {
+		commitSHAs[i] = commits[i].SHA
+	}
+
+	summaries, err := c.checkStore.ResultSummary(ctx, repoID, commitSHAs, visibilities)
+	if err != nil {
+		return fmt.Errorf("failed to fetch check summary for commits: %w", err)
+	}
+
+	for i := range n {
+		commitSHA, err := sha.New(commits[i].SHA)
+		if err != nil {
+			return fmt.Errorf("failed to parse commit SHA: %w", err)
+		}
+
+		if status := summaries[commitSHA].Status(); status != "" {
+			commits[i].CheckStatus = &status
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// forksBatchSize is the number of forks read from the database at once.
+const forksBatchSize = 100
+
+// ListForks lists the forks of a repo. Forks the caller isn't allowed to view are omitted,
+// both from the list and from the count.
+func (c *Controller) ListForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.RepoForkFilter,
+) ([]*RepositoryOutput, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// forks can live in any space, so the permission is checked for each of them
+	// and the visible forks are paginated here.
+	var forks []*types.Repository
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		batchFilter := filter.RepoFilter
+		batchFilter.Size = forksBatchSize
+
+		for batchFilter.Page = 1; ; batchFilter.Page++ {
+			batch, err := c.repoStore.ListForks(ctx, repo.ID, &batchFilter)
+			if err != nil {
+				return fmt.Errorf("failed to list forks: %w", err)
+			}
+
+			for _, fork := range batch {
+				err = apiauth.CheckRepo(ctx, c.authorizer, session, fork, enum.PermissionRepoView)
+				if errors.Is(err, apiauth.ErrNotAuthorized) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+
+				forks = append(forks, fork)
+			}
+
+			if len(batch) < forksBatchSize {
+				return nil
+			}
+		}
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count := int64(len(forks))
+	forks = paginateForks(forks, filter.Page, filter.Size)
+
+	checkSummaries := map[int64]types.CheckCountSummary{}
+	if filter.IncludeChecks && len(forks) > 0 {
+		if err = c.checkHeads.Resolve(ctx, forks); err != nil {
+			return nil, 0, fmt.Errorf("failed to resolve default branch heads of forks: %w", err)
+		}
+
+		forkIDs := make([]int64, len(forks))
+		for i, fork := range forks {
+			forkIDs[i] = fork.ID
+		}
+
+		checkSummaries, err = c.checkStore.HeadResultSummary(ctx, forkIDs)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get status check summaries of forks: %w", err)
+		}
+	}
+
+	forksOut := make([]*RepositoryOutput, 0, len(forks))
+	for _, fork := range forks {
+		// backfill URLs
+		fork.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, fork.Path)
+		fork.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, fork.Path)
+
+		forkOut, err := GetRepoOutput(ctx, c.publicAccess, fork)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get fork %q output: %w", fork.Path, err)
+		}
+
+		if status := checkSummaries[fork.ID].Status(); status != "" {
+			forkOut.DefaultBranchCheckStatus = &status
+		}
+
+		forksOut = append(forksOut, forkOut)
+	}
+
+	return forksOut, count, nil
+}
+
+// paginateForks returns the page of the forks, pages are numbered from 1.
+func paginateForks(forks []*types.Repository, page, size int) []*types.Repository {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = int(database.Limit(size))
+	}
+
+	start := (page - 1) * size
+	if start >= len(forks) {
+		return nil
+	}
+
+	return forks[start:min(start+size, len(forks))]
+}
@@ -25,10 +25,12 @@ import (
 )
 
 // Summary returns commit, branch, tag and pull req count for a repo.
+// If includeChecks is set, the status check metrics of the repo are included as well.
 func (c *Controller) Summary(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
+	includeChecks bool,
 ) (*types.RepositorySummary, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
@@ -40,6 +42,14 @@ func (c *Controller) Summary(
 		return nil, fmt.Errorf("failed to get repo summary: %w", err)
 	}
 
+	var checks *types.RepositoryCheckSummary
+	if includeChecks {
+		checks, err = c.checkSummaryCache.Get(ctx, repo.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repo status check summary: %w", err)
+		}
+	}
+
 	return &types.RepositorySummary{
 		DefaultBranchCommitCount: summary.CommitCount,
 		BranchCount:              summary.BranchCount,
@@ -49,5 +59,6 @@ func (c *Controller) Summary(
 			ClosedCount: repo.NumClosedPulls,
 			MergedCount: repo.NumMergedPulls,
 		},
+		Checks: checks,
 	}, nil
 }
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// verifyTagChecks blocks the tag creation if the repository requires all status checks
+// of the tag target to pass and some of them didn't.
+func (c *Controller) verifyTagChecks(ctx context.Context, repo *types.Repository, target string) error {
+	required, err := settings.RepoGet(
+		ctx,
+		c.settings,
+		repo.ID,
+		settings.KeyTagRequireChecksPassing,
+		settings.DefaultTagRequireChecksPassing,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check settings whether tags require passing status checks: %w", err)
+	}
+	if !required {
+		return nil
+	}
+
+	passing, failing, err := c.areChecksPassingForTag(ctx, repo, target)
+	if err != nil {
+		return err
+	}
+	if !passing {
+		return usererror.NewWithPayload(http.StatusPreconditionFailed,
+			"All status checks of the tag target must be completed successfully",
+			map[string]any{"failing_checks": failing})
+	}
+
+	return nil
+}
+
+// areChecksPassingForTag resolves the tag target to a commit and returns whether all status checks
+// reported for the commit completed successfully, together with the identifiers of those that didn't.
+func (c *Controller) areChecksPassingForTag(
+	ctx context.Context,
+	repo *types.Repository,
+	target string,
+) (bool, []string, error) {
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   target,
+	})
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to resolve tag target %q: %w", target, err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, repo.ID, commit.Commit.SHA.String())
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to list status checks of commit %s: %w", commit.Commit.SHA, err)
+	}
+
+	var failing []string
+	for _, checkResult := range checkResults {
+		if !checkResult.Status.IsSatisfied() {
+			failing = append(failing, checkResult.Identifier)
+		}
+	}
+
+	return len(failing) == 0, failing, nil
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/checkhead"
+	"github.com/harness/gitness/app/services/checkhealth"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
@@ -57,6 +59,10 @@ func ProvideController(
 	executionStore store.ExecutionStore,
 	ruleStore store.RuleStore,
 	checkStore store.CheckStore,
+	annotationStore store.CheckAnnotationStore,
+	checkHealth *checkhealth.Service,
+	checkHeads *checkhead.Service,
+	checkSummaryCache store.RepoCheckSummaryCache,
 	pullReqStore store.PullReqStore,
 	settings *settings.Service,
 	principalInfoCache store.PrincipalInfoCache,
@@ -81,7 +87,7 @@ func ProvideController(
 	return NewController(config, tx, urlProvider,
 		authorizer,
 		repoStore, spaceStore, pipelineStore, executionStore,
-		principalStore, ruleStore, checkStore, pullReqStore, settings,
+		principalStore, ruleStore, checkStore, annotationStore, checkHealth, checkHeads, checkSummaryCache, pullReqStore, settings,
 		principalInfoCache, protectionManager, rpcClient, importer,
 		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService)
@@ -16,6 +16,8 @@ package reposettings
 
 import (
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
 )
@@ -23,22 +25,48 @@ import (
 // GeneralSettings represent the general repository settings as exposed externally.
 type GeneralSettings struct {
 	FileSizeLimit *int64 `json:"file_size_limit" yaml:"file_size_limit"`
+	// GithubStatusMirrorRepo is the GitHub repository (owner/repo) status check results are mirrored to.
+	GithubStatusMirrorRepo *string `json:"github_status_mirror_repo" yaml:"github_status_mirror_repo"`
+	// TagRequireChecksPassing blocks tag creation unless all status checks of the target commit passed.
+	TagRequireChecksPassing *bool `json:"tag_require_checks_passing" yaml:"tag_require_checks_passing"`
+	// MergeCheckTrailers is the format of the status check trailers added to merge commit messages.
+	MergeCheckTrailers *enum.CheckTrailerFormat `json:"merge_check_trailers" yaml:"merge_check_trailers"`
+	// CheckIssueTracker is the issue tracker failed status checks are propagated to.
+	CheckIssueTracker *enum.IssueTracker `json:"check_issue_tracker" yaml:"check_issue_tracker"`
+	// CheckIssueTrackerTransition is the transition of the referenced issues when a status check fails.
+	CheckIssueTrackerTransition *string `json:"check_issue_tracker_transition" yaml:"check_issue_tracker_transition"`
+	// MergeFreeze defines the time windows in which pull requests can't be merged.
+	MergeFreeze *types.MergeFreeze `json:"merge_freeze" yaml:"merge_freeze"`
 }
 
 func GetDefaultGeneralSettings() *GeneralSettings {
 	return &GeneralSettings{
-		FileSizeLimit: ptr.Int64(settings.DefaultFileSizeLimit),
+		FileSizeLimit:           ptr.Int64(settings.DefaultFileSizeLimit),
+		GithubStatusMirrorRepo:  ptr.String(settings.DefaultGithubStatusMirrorRepo),
+		TagRequireChecksPassing: ptr.Bool(settings.DefaultTagRequireChecksPassing),
+		MergeCheckTrailers:      ptr.Of(settings.DefaultMergeCheckTrailers),
+
+		CheckIssueTracker:           ptr.Of(settings.DefaultCheckIssueTracker),
+		CheckIssueTrackerTransition: ptr.String(settings.DefaultCheckIssueTrackerTransition),
+
+		MergeFreeze: ptr.Of(settings.DefaultMergeFreeze),
 	}
 }
 
 func GetGeneralSettingsMappings(s *GeneralSettings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeyFileSizeLimit, s.FileSizeLimit),
+		settings.Mapping(settings.KeyGithubStatusMirrorRepo, s.GithubStatusMirrorRepo),
+		settings.Mapping(settings.KeyTagRequireChecksPassing, s.TagRequireChecksPassing),
+		settings.Mapping(settings.KeyMergeCheckTrailers, s.MergeCheckTrailers),
+		settings.Mapping(settings.KeyCheckIssueTracker, s.CheckIssueTracker),
+		settings.Mapping(settings.KeyCheckIssueTrackerTransition, s.CheckIssueTrackerTransition),
+		settings.Mapping(settings.KeyMergeFreeze, s.MergeFreeze),
 	}
 }
 
 func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
-	kvs := make([]settings.KeyValue, 0, 1)
+	kvs := make([]settings.KeyValue, 0, 7)
 
 	if s.FileSizeLimit != nil {
 		kvs = append(kvs, settings.KeyValue{
@@ -46,5 +74,41 @@ func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
 			Value: s.FileSizeLimit,
 		})
 	}
+	if s.GithubStatusMirrorRepo != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyGithubStatusMirrorRepo,
+			Value: s.GithubStatusMirrorRepo,
+		})
+	}
+	if s.TagRequireChecksPassing != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyTagRequireChecksPassing,
+			Value: s.TagRequireChecksPassing,
+		})
+	}
+	if s.MergeCheckTrailers != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyMergeCheckTrailers,
+			Value: s.MergeCheckTrailers,
+		})
+	}
+	if s.CheckIssueTracker != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyCheckIssueTracker,
+			Value: s.CheckIssueTracker,
+		})
+	}
+	if s.CheckIssueTrackerTransition != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyCheckIssueTrackerTransition,
+			Value: s.CheckIssueTrackerTransition,
+		})
+	}
+	if s.MergeFreeze != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyMergeFreeze,
+			Value: s.MergeFreeze,
+		})
+	}
 	return kvs
 }
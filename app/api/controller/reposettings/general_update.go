@@ -17,12 +17,16 @@ package reposettings
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/gorhill/cronexpr"
 	"github.com/rs/zerolog/log"
 )
 
@@ -38,6 +42,10 @@ func (c *Controller) GeneralUpdate(
 		return nil, err
 	}
 
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
 	// read old settings values
 	old := GetDefaultGeneralSettings()
 	oldMappings := GetGeneralSettingsMappings(old)
@@ -73,3 +81,61 @@ func (c *Controller) GeneralUpdate(
 
 	return out, nil
 }
+
+func (in *GeneralSettings) sanitize() error {
+	if in.GithubStatusMirrorRepo != nil {
+		target := strings.TrimSpace(*in.GithubStatusMirrorRepo)
+		in.GithubStatusMirrorRepo = &target
+
+		if target != "" {
+			owner, repo, ok := strings.Cut(target, "/")
+			if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+				return usererror.BadRequest("GitHub status mirror repository must be in the format 'owner/repo'.")
+			}
+		}
+	}
+
+	if in.MergeCheckTrailers != nil {
+		format, ok := in.MergeCheckTrailers.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid merge status check trailers format: %q", *in.MergeCheckTrailers)
+		}
+		in.MergeCheckTrailers = &format
+	}
+
+	if in.CheckIssueTracker != nil {
+		tracker, ok := in.CheckIssueTracker.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid status check issue tracker: %q", *in.CheckIssueTracker)
+		}
+		in.CheckIssueTracker = &tracker
+	}
+
+	if in.CheckIssueTrackerTransition != nil {
+		transition := strings.TrimSpace(*in.CheckIssueTrackerTransition)
+		in.CheckIssueTrackerTransition = &transition
+	}
+
+	if in.MergeFreeze != nil {
+		in.MergeFreeze.Schedule = strings.TrimSpace(in.MergeFreeze.Schedule)
+		in.MergeFreeze.Timezone = strings.TrimSpace(in.MergeFreeze.Timezone)
+
+		if in.MergeFreeze.Schedule != "" {
+			if _, err := cronexpr.Parse(in.MergeFreeze.Schedule); err != nil {
+				return usererror.BadRequestf("Invalid merge freeze schedule: %s", err)
+			}
+		}
+
+		if _, err := time.LoadLocation(in.MergeFreeze.Timezone); err != nil {
+			return usererror.BadRequestf("Invalid merge freeze timezone: %q", in.MergeFreeze.Timezone)
+		}
+
+		bypass, ok := in.MergeFreeze.Bypass.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid merge freeze bypass condition: %q", in.MergeFreeze.Bypass)
+		}
+		in.MergeFreeze.Bypass = bypass
+	}
+
+	return nil
+}
@@ -27,14 +27,16 @@ type Controller struct {
 	principalUIDCheck check.PrincipalUID
 	authorizer        authz.Authorizer
 	principalStore    store.PrincipalStore
+	pInfoEvictor      store.PrincipalInfoEvictor
 }
 
 func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
-	principalStore store.PrincipalStore) *Controller {
+	principalStore store.PrincipalStore, pInfoEvictor store.PrincipalInfoEvictor) *Controller {
 	return &Controller{
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
 		principalStore:    principalStore,
+		pInfoEvictor:      pInfoEvictor,
 	}
 }
 
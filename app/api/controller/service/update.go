@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
 )
 
 // UpdateInput store infos to update an existing service.
@@ -65,6 +66,10 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	if err = c.pInfoEvictor.Evict(ctx, svc.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to evict principal %d from principal info cache", svc.ID)
+	}
+
 	return svc, nil
 }
 
@@ -28,6 +28,6 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
-	principalStore store.PrincipalStore) *Controller {
-	return NewController(principalUIDCheck, authorizer, principalStore)
+	principalStore store.PrincipalStore, pInfoEvictor store.PrincipalInfoEvictor) *Controller {
+	return NewController(principalUIDCheck, authorizer, principalStore, pInfoEvictor)
 }
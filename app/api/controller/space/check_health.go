@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckHealth returns the status check health of all repositories of a space and its descendant spaces.
+func (c *Controller) CheckHealth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.CheckHealthRollup, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpaceScope(
+		ctx,
+		c.authorizer,
+		session,
+		space,
+		enum.ResourceTypeRepo,
+		enum.PermissionRepoView,
+	); err != nil {
+		return nil, err
+	}
+
+	health, err := c.checkHealth.SpaceHealth(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check health of space: %w", err)
+	}
+
+	return &health, nil
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/checkhead"
+	"github.com/harness/gitness/app/services/checkhealth"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
@@ -77,6 +79,9 @@ type Controller struct {
 	templateStore   store.TemplateStore
 	spaceStore      store.SpaceStore
 	repoStore       store.RepoStore
+	checkStore      store.CheckStore
+	checkHealth     *checkhealth.Service
+	checkHeads      *checkhead.Service
 	principalStore  store.PrincipalStore
 	repoCtrl        *repo.Controller
 	membershipStore store.MembershipStore
@@ -95,7 +100,9 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	sseStreamer sse.Streamer, identifierCheck check.SpaceIdentifier, authorizer authz.Authorizer,
 	spacePathStore store.SpacePathStore, pipelineStore store.PipelineStore, secretStore store.SecretStore,
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
-	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
+	repoStore store.RepoStore, checkStore store.CheckStore, checkHealth *checkhealth.Service,
+	checkHeads *checkhead.Service,
+	principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, prListService *pullreq.ListService,
 	importer *importer.Repository, exporter *exporter.Repository,
 	limiter limiter.ResourceLimiter, publicAccess publicaccess.Service, auditService audit.Service,
@@ -116,6 +123,9 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		templateStore:       templateStore,
 		spaceStore:          spaceStore,
 		repoStore:           repoStore,
+		checkStore:          checkStore,
+		checkHealth:         checkHealth,
+		checkHeads:          checkHeads,
 		principalStore:      principalStore,
 		repoCtrl:            repoCtrl,
 		membershipStore:     membershipStore,
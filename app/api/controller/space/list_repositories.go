@@ -24,6 +24,8 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // ListRepositories lists the repositories of a space.
@@ -78,6 +80,26 @@ func (c *Controller) ListRepositoriesNoAuth(
 		return nil, 0, err
 	}
 
+	repoIDs := make([]int64, len(repos))
+	for i, repo := range repos {
+		repoIDs[i] = repo.ID
+	}
+
+	if err = c.checkHeads.Resolve(ctx, repos); err != nil {
+		return nil, 0, fmt.Errorf("failed to resolve default branch heads of repos: %w", err)
+	}
+
+	checkSummaries, err := c.checkStore.HeadResultSummary(ctx, repoIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get status check summaries of repos: %w", err)
+	}
+
+	// the status check health isn't essential for the list, it's omitted if it can't be computed.
+	checkHealth, err := c.checkHealth.Health(ctx, repoIDs)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get status check health of repos")
+	}
+
 	reposOut := []*repoCtrl.RepositoryOutput{}
 	for _, repo := range repos {
 		// backfill URLs
@@ -89,6 +111,11 @@ func (c *Controller) ListRepositoriesNoAuth(
 			return nil, 0, fmt.Errorf("failed to get repo %q output: %w", repo.Path, err)
 		}
 
+		repoOut.LatestCheckStatus = checkSummaries[repo.ID].Status()
+		if checkHealth != nil {
+			repoOut.SetCheckHealth(checkHealth[repo.ID])
+		}
+
 		reposOut = append(reposOut, repoOut)
 	}
 
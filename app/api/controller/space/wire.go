@@ -18,6 +18,8 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/checkhead"
+	"github.com/harness/gitness/app/services/checkhealth"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
@@ -45,8 +47,9 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	identifierCheck check.SpaceIdentifier, authorizer authz.Authorizer, spacePathStore store.SpacePathStore,
 	pipelineStore store.PipelineStore, secretStore store.SecretStore,
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore,
-	spaceStore store.SpaceStore, repoStore store.RepoStore, principalStore store.PrincipalStore,
-	repoCtrl *repo.Controller, membershipStore store.MembershipStore, prListService *pullreq.ListService,
+	spaceStore store.SpaceStore, repoStore store.RepoStore, checkStore store.CheckStore, checkHealth *checkhealth.Service,
+	checkHeads *checkhead.Service,
+	principalStore store.PrincipalStore, repoCtrl *repo.Controller, membershipStore store.MembershipStore, prListService *pullreq.ListService,
 	importer *importer.Repository,
 	exporter *exporter.Repository, limiter limiter.ResourceLimiter, publicAccess publicaccess.Service,
 	auditService audit.Service, gitspaceService *gitspace.Service,
@@ -56,7 +59,7 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	return NewController(config, tx, urlProvider, sseStreamer, identifierCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
 		connectorStore, templateStore,
-		spaceStore, repoStore, principalStore,
+		spaceStore, repoStore, checkStore, checkHealth, checkHeads, principalStore,
 		repoCtrl, membershipStore, prListService, importer,
 		exporter, limiter, publicAccess,
 		auditService, gitspaceService,
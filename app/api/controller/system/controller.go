@@ -16,6 +16,7 @@ package system
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
@@ -23,16 +24,31 @@ import (
 
 type Controller struct {
 	principalStore store.PrincipalStore
+	checkStore     store.CheckStore
 	config         *types.Config
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	config *types.Config,
+) *Controller {
 	return &Controller{
 		principalStore: principalStore,
+		checkStore:     checkStore,
 		config:         config,
 	}
 }
 
+// Health verifies that the stores required to serve requests are reachable.
+func (c *Controller) Health(ctx context.Context) error {
+	if err := c.checkStore.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping check store: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 	usrCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{})
 	if err != nil {
@@ -26,6 +26,10 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	config *types.Config,
+) *Controller {
+	return NewController(principalStore, checkStore, config)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -74,6 +75,10 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	if err = c.pInfoEvictor.Evict(ctx, user.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to evict principal %d from principal info cache", user.ID)
+	}
+
 	return user, nil
 }
 
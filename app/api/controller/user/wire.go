@@ -36,6 +36,7 @@ func ProvideController(
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	pInfoEvictor store.PrincipalInfoEvictor,
 ) *Controller {
 	return NewController(
 		tx,
@@ -44,5 +45,6 @@ func ProvideController(
 		principalStore,
 		tokenStore,
 		membershipStore,
-		publicKeyStore)
+		publicKeyStore,
+		pInfoEvictor)
 }
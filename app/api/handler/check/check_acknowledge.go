@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+// HandleCheckAcknowledge is an HTTP handler for acknowledging the failure of a status check.
+func HandleCheckAcknowledge(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		commitSHA, err := request.GetCommitSHAFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		in := new(check.AcknowledgeInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		out, err := checkCtrl.Acknowledge(ctx, session, repoRef, commitSHA, in)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+// HandleCheckAliasList is an HTTP handler for listing status check identifier aliases of a repository.
+func HandleCheckAliasList(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		aliases, err := checkCtrl.ListAliases(ctx, session, repoRef)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, aliases)
+	}
+}
+
+// HandleCheckAliasCreate is an HTTP handler for recording the rename of a status check identifier.
+func HandleCheckAliasCreate(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		in := new(check.AliasCreateInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		alias, err := checkCtrl.CreateAlias(ctx, session, repoRef, in)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusCreated, alias)
+	}
+}
+
+// HandleCheckAliasDelete is an HTTP handler for deleting the alias of an old status check identifier.
+func HandleCheckAliasDelete(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		identifier, err := request.GetCheckIdentifierFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		err = checkCtrl.DeleteAlias(ctx, session, repoRef, identifier)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleCheckAnnotationList is an HTTP handler for listing the status check annotations
+// of a commit within a line range of a file.
+func HandleCheckAnnotationList(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		commitSHA, err := request.GetCommitSHAFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		lineFrom, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamLineFrom, 0)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		lineTo, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamLineTo, 0)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		path := request.QueryParamOrDefault(r, request.QueryParamPath, "")
+
+		annotations, err := checkCtrl.ListAnnotations(ctx, session, repoRef, commitSHA, path,
+			int(lineFrom), int(lineTo))
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, annotations)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleCheckArchiveList is an HTTP handler for listing the status check archive files of a repository.
+func HandleCheckArchiveList(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		page := request.ParsePage(r)
+		size := request.ParseLimit(r)
+
+		archives, err := checkCtrl.ListArchives(ctx, session, repoRef, page, size)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.PaginationNoTotal(r, w, page, size, len(archives) < size)
+		render.JSON(w, http.StatusOK, archives)
+	}
+}
+
+// HandleCheckArchiveRestore is an HTTP handler for restoring the status check results of an archive file.
+func HandleCheckArchiveRestore(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		archiveID, err := request.GetCheckArchiveIDFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		checks, err := checkCtrl.RestoreArchive(ctx, session, repoRef, archiveID)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, checks)
+	}
+}
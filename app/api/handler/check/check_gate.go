@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleCheckGate is an HTTP handler that waits until the required status checks of a commit have passed.
+// It responds with 408 if the status checks didn't complete in time and with 424 if any of them failed.
+func HandleCheckGate(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		commitSHA, err := request.GetCommitSHAFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		opts, err := request.ParseCheckGateOptions(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		result, err := checkCtrl.Gate(ctx, session, repoRef, commitSHA, opts)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		switch result.Status {
+		case enum.CheckGateStatusFailed:
+			render.JSON(w, http.StatusFailedDependency, result)
+		case enum.CheckGateStatusTimedOut:
+			render.JSON(w, http.StatusRequestTimeout, result)
+		default:
+			render.JSON(w, http.StatusOK, result)
+		}
+	}
+}
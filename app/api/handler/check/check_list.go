@@ -30,21 +30,25 @@ func HandleCheckList(checkCtrl *check.Controller) http.HandlerFunc {
 
 		repoRef, err := request.GetRepoRefFromPath(r)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
 		commitSHA, err := request.GetCommitSHAFromPath(r)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
-		opts := request.ParseCheckListOptions(r)
+		opts, err := request.ParseCheckListOptions(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
 
 		checks, count, err := checkCtrl.ListChecks(ctx, session, repoRef, commitSHA, opts)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
@@ -30,22 +30,51 @@ func HandleCheckListRecent(checkCtrl *check.Controller) http.HandlerFunc {
 
 		repoRef, err := request.GetRepoRefFromPath(r)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
 		opts, err := request.ParseCheckRecentOptions(r)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
 		checkIdentifiers, err := checkCtrl.ListRecentChecks(ctx, session, repoRef, opts)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
 		render.JSON(w, http.StatusOK, checkIdentifiers)
 	}
 }
+
+// HandleCheckListRecentStats is an HTTP handler for listing recently executed status checks
+// of a repository with their flake score.
+func HandleCheckListRecentStats(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		opts, err := request.ParseCheckRecentOptions(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		stats, err := checkCtrl.ListRecentCheckStats(ctx, session, repoRef, opts)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stats)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
 )
 
 // HandleCheckReport is an HTTP handler for reporting status check results.
@@ -31,27 +32,35 @@ func HandleCheckReport(checkCtrl *check.Controller) http.HandlerFunc {
 
 		repoRef, err := request.GetRepoRefFromPath(r)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
 		commitSHA, err := request.GetCommitSHAFromPath(r)
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		namespace, err := request.GetCheckNamespaceFromQuery(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
 		in := new(check.ReportInput)
 		err = json.NewDecoder(r.Body).Decode(in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.ProblemDetails(ctx, w, r, usererror.BadRequestf("Invalid Request Body: %s.", err))
 			return
 		}
 
+		in.Namespace = namespace
+
 		statusCheck, err := checkCtrl.Report(ctx, session,
 			repoRef, commitSHA, in, map[string]string{})
 		if err != nil {
-			render.TranslatedUserError(ctx, w, err)
+			render.ProblemDetails(ctx, w, r, err)
 			return
 		}
 
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/check"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleCheckReportStream is an HTTP handler for reporting a stream of status check results.
+// The request body and the response body are newline delimited JSON streams, every report is
+// answered as soon as it's written.
+func HandleCheckReportStream(checkCtrl *check.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+
+		// the responses are written while the request body is still being read.
+		rc := http.NewResponseController(w)
+		if err = rc.EnableFullDuplex(); err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to enable full duplex for status check report stream")
+		}
+
+		started := false
+
+		dec := json.NewDecoder(r.Body)
+		enc := json.NewEncoder(w)
+
+		recv := func() (*check.ReportStreamInput, error) {
+			in := new(check.ReportStreamInput)
+			if err := dec.Decode(in); err != nil {
+				return nil, err
+			}
+			return in, nil
+		}
+
+		send := func(resp *types.CheckUpsertResponse) error {
+			if !started {
+				started = true
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				w.WriteHeader(http.StatusOK)
+			}
+
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+
+			return rc.Flush()
+		}
+
+		err = checkCtrl.ReportStream(ctx, session, repoRef, recv, send)
+		if err != nil && !started {
+			render.ProblemDetails(ctx, w, r, err)
+			return
+		}
+		if err != nil {
+			// the response status is already sent, the stream is just ended.
+			log.Ctx(ctx).Warn().Err(err).Msg("status check report stream failed")
+			return
+		}
+
+		if !started {
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+}
@@ -53,7 +53,14 @@ func HandleBlame(repoCtrl *repo.Controller) http.HandlerFunc {
 
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
 
-		stream, err := repoCtrl.Blame(ctx, session, repoRef, gitRef, path, int(lineFrom), int(lineTo))
+		includeChecks, err := request.GetIncludeChecksFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		stream, err := repoCtrl.Blame(ctx, session, repoRef, gitRef, path, int(lineFrom), int(lineTo),
+			includeChecks)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -110,7 +110,13 @@ func HandleDiffStats(repoCtrl *repo.Controller) http.HandlerFunc {
 
 		path := request.GetOptionalRemainderFromPath(r)
 
-		output, err := repoCtrl.DiffStats(ctx, session, repoRef, path)
+		includeCheckAnnotations, err := request.GetIncludeCheckAnnotationsFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		output, err := repoCtrl.DiffStats(ctx, session, repoRef, path, includeCheckAnnotations)
 		if uErr := gittypes.AsUnrelatedHistoriesError(err); uErr != nil {
 			render.JSON(w, http.StatusOK, &usererror.Error{
 				Message: uErr.Error(),
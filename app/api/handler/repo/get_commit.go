@@ -40,7 +40,13 @@ func HandleGetCommit(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		commit, err := repoCtrl.GetCommit(ctx, session, repoRef, commitSHA)
+		includeChecks, err := request.GetIncludeChecksFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		commit, err := repoCtrl.GetCommit(ctx, session, repoRef, commitSHA, includeChecks)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -33,7 +33,13 @@ func HandleSummary(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		summary, err := repoCtrl.Summary(ctx, session, repoRef)
+		includeChecks, err := request.ParseIncludeChecksFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		summary, err := repoCtrl.Summary(ctx, session, repoRef, includeChecks)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
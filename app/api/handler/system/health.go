@@ -14,10 +14,28 @@
 
 package system
 
-import "net/http"
+import (
+	"net/http"
 
-// HandleHealth writes a 200 OK status to the http.Response
-// if the server is healthy.
-func HandleHealth(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusOK)
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleHealth returns an http.HandlerFunc that writes a 200 OK status to the http.Response
+// if the server is healthy, and a 503 Service Unavailable status otherwise.
+func HandleHealth(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if err := sysCtrl.Health(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("health check failed")
+			render.UserError(ctx, w, usererror.New(http.StatusServiceUnavailable, "Service is unhealthy"))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
 }
@@ -19,8 +19,9 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/request"
-	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
 	"github.com/swaggest/openapi-go/openapi3"
@@ -40,6 +41,51 @@ var queryParameterStatusCheckQuery = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterStatusCheckStep = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamStep,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The name of the step the status checks are required to contain."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterStatusCheckNamespace = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamCheckNamespace,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String(
+			"The namespace of the status checks. Status checks are reported to the default namespace if omitted."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSortStatusCheck = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the status checks are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.CheckSortUpdated),
+				Enum:    enum.CheckSort("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterStatusCheckSince = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSince,
@@ -54,42 +100,479 @@ var queryParameterStatusCheckSince = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterCheckAuditPrincipalID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditPrincipalID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the principal whose status check reports are listed."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCheckAuditFrom = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditFrom,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The start of the time range (in Unix time millis). Defaults to 30 days before its end."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCheckGateRequired = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckGateRequired,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Comma separated identifiers of the status checks that are required to pass."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckGateTimeout = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckGateTimeout,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("How long to wait for the required status checks (in seconds)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(300),
+				Minimum: ptr.Float64(1),
+				Maximum: ptr.Float64(600),
+			},
+		},
+	},
+}
+
+var queryParameterCheckRollupFrom = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckRollupFrom,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The commit (exclusive) at which the commit range starts, e.g. the previous release tag."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckAuthor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckAuthor,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The email of the commit author, defaults to the caller. Only admins can provide others."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckRollupTo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckRollupTo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The commit (inclusive) at which the commit range ends."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckDiffFrom = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckDiffFrom,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the status check audit log entry whose payload is the base of the comparison."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCheckDiffTo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckDiffTo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the status check audit log entry whose payload is compared to the base."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCheckVolumeRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckVolumeRepo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The reference of the repository whose status checks are counted."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckVolumeBucketMinutes = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckVolumeBucketMinutes,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The size of the histogram buckets (in minutes)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(60),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
+var queryParameterCheckFeedSpace = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckFeedSpace,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The reference of the space whose repositories' status checks are streamed."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckFeedStatus = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckFeedStatus,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The statuses the streamed status checks changed to."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.CheckStatus("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterCheckSearchText = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The text searched for in the summaries of the status checks."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckSearchRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckSearchRepo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The reference of the repository whose status checks are searched."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckSearchStatus = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckSearchStatus,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The statuses of the searched status checks."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.CheckStatus("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterCheckSearchFrom = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditFrom,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The start of the update time range of the searched status checks (in Unix time millis)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCheckSearchTo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditTo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The end of the update time range of the searched status checks (in Unix time millis)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCheckSearchCursor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCheckSearchCursor,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The cursor returned with the previous page of the search results."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCheckAuditTo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuditTo,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The end of the time range (in Unix time millis). Defaults to now."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
 func checkOperations(reflector *openapi3.Reflector) {
 	const tag = "status_checks"
 
 	reportStatusCheckResults := openapi3.Operation{}
 	reportStatusCheckResults.WithTags(tag)
 	reportStatusCheckResults.WithMapOfAnything(map[string]interface{}{"operationId": "reportStatusCheckResults"})
+	reportStatusCheckResults.WithParameters(queryParameterStatusCheckNamespace)
 	_ = reflector.SetRequest(&reportStatusCheckResults, struct {
 		repoRequest
 		CommitSHA string `path:"commit_sha"`
 		check.ReportInput
 	}{}, http.MethodPut)
 	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.Check), http.StatusOK)
-	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(usererror.Error), http.StatusBadRequest)
-	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(usererror.Error), http.StatusInternalServerError)
-	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(usererror.Error), http.StatusUnauthorized)
-	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusTooManyRequests)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusUnprocessableEntity)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResults, new(types.ProblemDetails), http.StatusConflict)
 	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/checks/commits/{commit_sha}",
 		reportStatusCheckResults)
 
+	moveStatusChecks := openapi3.Operation{}
+	moveStatusChecks.WithTags(tag)
+	moveStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "moveStatusChecks"})
+	_ = reflector.SetRequest(&moveStatusChecks, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+		check.MoveInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(check.MoveOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(types.ProblemDetails), http.StatusUnprocessableEntity)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&moveStatusChecks, new(types.ProblemDetails), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/commits/{commit_sha}/move",
+		moveStatusChecks)
+
+	acknowledgeStatusCheck := openapi3.Operation{}
+	acknowledgeStatusCheck.WithTags(tag)
+	acknowledgeStatusCheck.WithMapOfAnything(map[string]interface{}{"operationId": "acknowledgeStatusCheck"})
+	_ = reflector.SetRequest(&acknowledgeStatusCheck, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+		check.AcknowledgeInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&acknowledgeStatusCheck, new(types.CheckAcknowledgement), http.StatusOK)
+	_ = reflector.SetJSONResponse(&acknowledgeStatusCheck, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&acknowledgeStatusCheck, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&acknowledgeStatusCheck, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&acknowledgeStatusCheck, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&acknowledgeStatusCheck, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/commits/{commit_sha}/acknowledge",
+		acknowledgeStatusCheck)
+
+	ingestStatusCheckResult := openapi3.Operation{}
+	ingestStatusCheckResult.WithTags(tag)
+	ingestStatusCheckResult.WithMapOfAnything(map[string]interface{}{"operationId": "ingestStatusCheckResult"})
+	ingestStatusCheckResult.WithDescription(
+		"Reports a status check from the webhook payload of a third-party CI system (circleci, travis, jenkins).")
+	_ = reflector.SetRequest(&ingestStatusCheckResult, struct {
+		repoRequest
+		Source string `path:"check_source" enum:"circleci,travis,jenkins"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&ingestStatusCheckResult, new(types.Check), http.StatusOK)
+	_ = reflector.SetJSONResponse(&ingestStatusCheckResult, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&ingestStatusCheckResult, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&ingestStatusCheckResult, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&ingestStatusCheckResult, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&ingestStatusCheckResult, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&ingestStatusCheckResult, new(types.ProblemDetails), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/ingest/{check_source}",
+		ingestStatusCheckResult)
+
+	reportStatusCheckResultStream := openapi3.Operation{}
+	reportStatusCheckResultStream.WithTags(tag)
+	reportStatusCheckResultStream.WithMapOfAnything(
+		map[string]interface{}{"operationId": "reportStatusCheckResultStream"})
+	reportStatusCheckResultStream.WithDescription(
+		"Reports a stream of status check results. The request and the response are newline delimited JSON " +
+			"streams, every reported status check is answered once it's written.")
+	_ = reflector.SetRequest(&reportStatusCheckResultStream, struct {
+		repoRequest
+		check.ReportStreamInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResultStream, new(types.CheckUpsertResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResultStream, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResultStream, new(types.ProblemDetails),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResultStream, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResultStream, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reportStatusCheckResultStream, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/stream",
+		reportStatusCheckResultStream)
+
 	listStatusCheckResults := openapi3.Operation{}
 	listStatusCheckResults.WithTags(tag)
 	listStatusCheckResults.WithParameters(
-		QueryParameterPage, QueryParameterLimit, queryParameterStatusCheckQuery)
+		QueryParameterPage, QueryParameterLimit, queryParameterStatusCheckQuery, queryParameterStatusCheckStep,
+		queryParameterStatusCheckNamespace, queryParameterSortStatusCheck, queryParameterOrder)
 	listStatusCheckResults.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckResults"})
 	_ = reflector.SetRequest(&listStatusCheckResults, struct {
 		repoRequest
 		CommitSHA string `path:"commit_sha"`
 	}{}, http.MethodGet)
 	_ = reflector.SetJSONResponse(&listStatusCheckResults, new([]types.Check), http.StatusOK)
-	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(usererror.Error), http.StatusBadRequest)
-	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(usererror.Error), http.StatusInternalServerError)
-	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(usererror.Error), http.StatusUnauthorized)
-	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckResults, new(types.ProblemDetails), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}",
 		listStatusCheckResults)
 
+	listFederatedStatusCheckResults := openapi3.Operation{}
+	listFederatedStatusCheckResults.WithTags(tag)
+	listFederatedStatusCheckResults.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listFederatedStatusCheckResults"})
+	_ = reflector.SetRequest(&listFederatedStatusCheckResults, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listFederatedStatusCheckResults, new(types.FederatedCheckList), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listFederatedStatusCheckResults, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listFederatedStatusCheckResults, new(types.ProblemDetails),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listFederatedStatusCheckResults, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listFederatedStatusCheckResults, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listFederatedStatusCheckResults, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}/federated",
+		listFederatedStatusCheckResults)
+
+	listStatusCheckAnnotations := openapi3.Operation{}
+	listStatusCheckAnnotations.WithTags(tag)
+	listStatusCheckAnnotations.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckAnnotations"})
+	listStatusCheckAnnotations.WithParameters(queryParameterPath, queryParameterLineFrom, queryParameterLineTo)
+	_ = reflector.SetRequest(&listStatusCheckAnnotations, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new([]types.CheckAnnotation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}/annotations",
+		listStatusCheckAnnotations)
+
+	waitStatusCheckGate := openapi3.Operation{}
+	waitStatusCheckGate.WithTags(tag)
+	waitStatusCheckGate.WithSummary("Wait until the required status checks of a commit have passed")
+	waitStatusCheckGate.WithParameters(queryParameterCheckGateRequired, queryParameterCheckGateTimeout)
+	waitStatusCheckGate.WithMapOfAnything(map[string]interface{}{"operationId": "waitStatusCheckGate"})
+	_ = reflector.SetRequest(&waitStatusCheckGate, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.CheckGateResult), http.StatusOK)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.CheckGateResult), http.StatusRequestTimeout)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.CheckGateResult), http.StatusFailedDependency)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&waitStatusCheckGate, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/checks/gate",
+		waitStatusCheckGate)
+
 	listStatusCheckRecent := openapi3.Operation{}
 	listStatusCheckRecent.WithTags(tag)
 	listStatusCheckRecent.WithParameters(
@@ -100,10 +583,463 @@ func checkOperations(reflector *openapi3.Reflector) {
 		Since int
 	}{}, http.MethodGet)
 	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new([]string), http.StatusOK)
-	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusBadRequest)
-	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusInternalServerError)
-	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusUnauthorized)
-	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(types.ProblemDetails), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/recent",
 		listStatusCheckRecent)
+
+	listStatusCheckRecentStats := openapi3.Operation{}
+	listStatusCheckRecentStats.WithTags(tag)
+	listStatusCheckRecentStats.WithParameters(
+		queryParameterStatusCheckQuery, queryParameterStatusCheckSince)
+	listStatusCheckRecentStats.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckRecentStats"})
+	_ = reflector.SetRequest(&listStatusCheckRecentStats, struct {
+		repoRequest
+		Since int
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecentStats, new([]types.CheckIdentifierStats), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecentStats, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecentStats, new(types.ProblemDetails),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecentStats, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecentStats, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckRecentStats, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/recent/stats",
+		listStatusCheckRecentStats)
+
+	getStatusCheckResourceUsage := openapi3.Operation{}
+	getStatusCheckResourceUsage.WithTags(tag)
+	getStatusCheckResourceUsage.WithParameters(queryParameterCheckAuditFrom, queryParameterCheckAuditTo)
+	getStatusCheckResourceUsage.WithMapOfAnything(map[string]interface{}{"operationId": "getStatusCheckResourceUsage"})
+	_ = reflector.SetRequest(&getStatusCheckResourceUsage, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckResourceUsage, new([]types.CheckResourceUsageSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckResourceUsage, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getStatusCheckResourceUsage, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckResourceUsage, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckResourceUsage, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getStatusCheckResourceUsage, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/resource-usage",
+		getStatusCheckResourceUsage)
+
+	getStatusCheckPayloadDiff := openapi3.Operation{}
+	getStatusCheckPayloadDiff.WithTags(tag)
+	getStatusCheckPayloadDiff.WithParameters(queryParameterCheckDiffFrom, queryParameterCheckDiffTo)
+	getStatusCheckPayloadDiff.WithMapOfAnything(map[string]interface{}{"operationId": "getStatusCheckPayloadDiff"})
+	_ = reflector.SetRequest(&getStatusCheckPayloadDiff, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckPayloadDiff, new(types.CheckPayloadDiff), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckPayloadDiff, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getStatusCheckPayloadDiff, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckPayloadDiff, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckPayloadDiff, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getStatusCheckPayloadDiff, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/payload-diff",
+		getStatusCheckPayloadDiff)
+
+	getStatusCheckRollup := openapi3.Operation{}
+	getStatusCheckRollup.WithTags(tag)
+	getStatusCheckRollup.WithParameters(queryParameterCheckRollupFrom, queryParameterCheckRollupTo)
+	getStatusCheckRollup.WithMapOfAnything(map[string]interface{}{"operationId": "getStatusCheckRollup"})
+	_ = reflector.SetRequest(&getStatusCheckRollup, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckRollup, new(types.CheckRollup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckRollup, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getStatusCheckRollup, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckRollup, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckRollup, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getStatusCheckRollup, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/rollup", getStatusCheckRollup)
+
+	listAuthoredStatusChecks := openapi3.Operation{}
+	listAuthoredStatusChecks.WithTags(tag)
+	listAuthoredStatusChecks.WithParameters(queryParameterCheckAuthor, QueryParameterLimit)
+	listAuthoredStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "listAuthoredStatusChecks"})
+	_ = reflector.SetRequest(&listAuthoredStatusChecks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listAuthoredStatusChecks, new([]types.AuthorCommitChecks), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listAuthoredStatusChecks, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listAuthoredStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listAuthoredStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listAuthoredStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listAuthoredStatusChecks, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/authored", listAuthoredStatusChecks)
+
+	getStatusCheckOrgIngestionStats := openapi3.Operation{}
+	getStatusCheckOrgIngestionStats.WithTags(tag)
+	getStatusCheckOrgIngestionStats.WithSummary("Get the organizations that reported the most status checks")
+	getStatusCheckOrgIngestionStats.WithParameters(queryParameterCheckAuditFrom, queryParameterCheckAuditTo,
+		QueryParameterLimit)
+	getStatusCheckOrgIngestionStats.WithMapOfAnything(
+		map[string]interface{}{"operationId": "getStatusCheckOrgIngestionStats"})
+	_ = reflector.SetRequest(&getStatusCheckOrgIngestionStats, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckOrgIngestionStats, new([]types.OrgIngestionStat), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckOrgIngestionStats, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getStatusCheckOrgIngestionStats, new(types.ProblemDetails),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckOrgIngestionStats, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckOrgIngestionStats, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/analytics/checks/ingestion",
+		getStatusCheckOrgIngestionStats)
+
+	getStatusCheckLeaderboard := openapi3.Operation{}
+	getStatusCheckLeaderboard.WithTags(tag)
+	getStatusCheckLeaderboard.WithParameters(queryParameterCheckAuditFrom, queryParameterCheckAuditTo,
+		QueryParameterLimit)
+	getStatusCheckLeaderboard.WithMapOfAnything(map[string]interface{}{"operationId": "getStatusCheckLeaderboard"})
+	_ = reflector.SetRequest(&getStatusCheckLeaderboard, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckLeaderboard, new([]types.CheckLeaderEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckLeaderboard, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getStatusCheckLeaderboard, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckLeaderboard, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckLeaderboard, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getStatusCheckLeaderboard, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repos/{repo_ref}/checks/leaderboard",
+		getStatusCheckLeaderboard)
+
+	listStatusCheckConfigs := openapi3.Operation{}
+	listStatusCheckConfigs.WithTags(tag)
+	listStatusCheckConfigs.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckConfigs"})
+	_ = reflector.SetRequest(&listStatusCheckConfigs, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckConfigs, new([]types.CheckConfig), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckConfigs, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckConfigs, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckConfigs, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/configs",
+		listStatusCheckConfigs)
+
+	findStatusCheckConfig := openapi3.Operation{}
+	findStatusCheckConfig.WithTags(tag)
+	findStatusCheckConfig.WithMapOfAnything(map[string]interface{}{"operationId": "findStatusCheckConfig"})
+	_ = reflector.SetRequest(&findStatusCheckConfig, struct {
+		repoRequest
+		Identifier string `path:"check_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&findStatusCheckConfig, new(types.CheckConfig), http.StatusOK)
+	_ = reflector.SetJSONResponse(&findStatusCheckConfig, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&findStatusCheckConfig, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&findStatusCheckConfig, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&findStatusCheckConfig, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/configs/{check_identifier}",
+		findStatusCheckConfig)
+
+	updateStatusCheckConfig := openapi3.Operation{}
+	updateStatusCheckConfig.WithTags(tag)
+	updateStatusCheckConfig.WithMapOfAnything(map[string]interface{}{"operationId": "updateStatusCheckConfig"})
+	_ = reflector.SetRequest(&updateStatusCheckConfig, struct {
+		repoRequest
+		Identifier string `path:"check_identifier"`
+		check.ConfigUpdateInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&updateStatusCheckConfig, new(types.CheckConfig), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateStatusCheckConfig, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateStatusCheckConfig, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateStatusCheckConfig, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateStatusCheckConfig, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/checks/configs/{check_identifier}",
+		updateStatusCheckConfig)
+
+	deleteStatusCheckConfig := openapi3.Operation{}
+	deleteStatusCheckConfig.WithTags(tag)
+	deleteStatusCheckConfig.WithMapOfAnything(map[string]interface{}{"operationId": "deleteStatusCheckConfig"})
+	_ = reflector.SetRequest(&deleteStatusCheckConfig, struct {
+		repoRequest
+		Identifier string `path:"check_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckConfig, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckConfig, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckConfig, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckConfig, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/checks/configs/{check_identifier}",
+		deleteStatusCheckConfig)
+
+	listStatusCheckAliases := openapi3.Operation{}
+	listStatusCheckAliases.WithTags(tag)
+	listStatusCheckAliases.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckAliases"})
+	_ = reflector.SetRequest(&listStatusCheckAliases, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckAliases, new([]types.CheckAlias), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckAliases, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckAliases, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckAliases, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/aliases", listStatusCheckAliases)
+
+	createStatusCheckAlias := openapi3.Operation{}
+	createStatusCheckAlias.WithTags(tag)
+	createStatusCheckAlias.WithMapOfAnything(map[string]interface{}{"operationId": "createStatusCheckAlias"})
+	_ = reflector.SetRequest(&createStatusCheckAlias, struct {
+		repoRequest
+		check.AliasCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&createStatusCheckAlias, new(types.CheckAlias), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createStatusCheckAlias, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createStatusCheckAlias, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createStatusCheckAlias, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createStatusCheckAlias, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createStatusCheckAlias, new(types.ProblemDetails), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/aliases", createStatusCheckAlias)
+
+	deleteStatusCheckAlias := openapi3.Operation{}
+	deleteStatusCheckAlias.WithTags(tag)
+	deleteStatusCheckAlias.WithMapOfAnything(map[string]interface{}{"operationId": "deleteStatusCheckAlias"})
+	_ = reflector.SetRequest(&deleteStatusCheckAlias, struct {
+		repoRequest
+		Identifier string `path:"check_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckAlias, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckAlias, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckAlias, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckAlias, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteStatusCheckAlias, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/checks/aliases/{check_identifier}",
+		deleteStatusCheckAlias)
+
+	listSpaceStatusCheckPolicies := openapi3.Operation{}
+	listSpaceStatusCheckPolicies.WithTags(tag)
+	listSpaceStatusCheckPolicies.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listSpaceStatusCheckPolicies"})
+	_ = reflector.SetRequest(&listSpaceStatusCheckPolicies, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listSpaceStatusCheckPolicies, new([]types.SpaceCheckPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/check-policy",
+		listSpaceStatusCheckPolicies)
+
+	updateSpaceStatusCheckPolicies := openapi3.Operation{}
+	updateSpaceStatusCheckPolicies.WithTags(tag)
+	updateSpaceStatusCheckPolicies.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateSpaceStatusCheckPolicies"})
+	_ = reflector.SetRequest(&updateSpaceStatusCheckPolicies, struct {
+		spaceRequest
+		check.SpacePolicyUpdateInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&updateSpaceStatusCheckPolicies, new([]types.SpaceCheckPolicy), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateSpaceStatusCheckPolicies, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/check-policy",
+		updateSpaceStatusCheckPolicies)
+
+	getSpaceStatusCheckHealth := openapi3.Operation{}
+	getSpaceStatusCheckHealth.WithTags(tag)
+	getSpaceStatusCheckHealth.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceStatusCheckHealth"})
+	_ = reflector.SetRequest(&getSpaceStatusCheckHealth, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getSpaceStatusCheckHealth, new(types.CheckHealthRollup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getSpaceStatusCheckHealth, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getSpaceStatusCheckHealth, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getSpaceStatusCheckHealth, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getSpaceStatusCheckHealth, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/check-health",
+		getSpaceStatusCheckHealth)
+
+	listReservedStatusChecks := openapi3.Operation{}
+	listReservedStatusChecks.WithTags(tag)
+	listReservedStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "listReservedStatusChecks"})
+	_ = reflector.SetRequest(&listReservedStatusChecks, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listReservedStatusChecks, new([]types.ReservedCheck), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listReservedStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listReservedStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listReservedStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listReservedStatusChecks, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/reserved-checks",
+		listReservedStatusChecks)
+
+	updateReservedStatusChecks := openapi3.Operation{}
+	updateReservedStatusChecks.WithTags(tag)
+	updateReservedStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "updateReservedStatusChecks"})
+	_ = reflector.SetRequest(&updateReservedStatusChecks, struct {
+		spaceRequest
+		check.ReservedCheckUpdateInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&updateReservedStatusChecks, new([]types.ReservedCheck), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateReservedStatusChecks, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateReservedStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateReservedStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateReservedStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateReservedStatusChecks, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/reserved-checks",
+		updateReservedStatusChecks)
+
+	recomputeStatusChecks := openapi3.Operation{}
+	recomputeStatusChecks.WithTags(tag)
+	recomputeStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "recomputeStatusChecks"})
+	_ = reflector.SetRequest(&recomputeStatusChecks, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecks, new(check.RecomputeOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecks, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/repos/{repo_ref}/checks/recompute",
+		recomputeStatusChecks)
+
+	recomputeStatusChecksProgress := openapi3.Operation{}
+	recomputeStatusChecksProgress.WithTags(tag)
+	recomputeStatusChecksProgress.WithMapOfAnything(
+		map[string]interface{}{"operationId": "recomputeStatusChecksProgress"})
+	_ = reflector.SetRequest(&recomputeStatusChecksProgress, struct {
+		repoRequest
+		JobID string `path:"job_id"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecksProgress, new(job.Progress), http.StatusOK)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecksProgress, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecksProgress, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecksProgress, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&recomputeStatusChecksProgress, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repos/{repo_ref}/checks/recompute/{job_id}",
+		recomputeStatusChecksProgress)
+
+	replayStatusChecks := openapi3.Operation{}
+	replayStatusChecks.WithTags(tag)
+	replayStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "replayStatusChecks"})
+	_ = reflector.SetRequest(&replayStatusChecks, struct {
+		repoRequest
+		check.ReplayInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&replayStatusChecks, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&replayStatusChecks, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&replayStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&replayStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&replayStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&replayStatusChecks, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/repos/{repo_ref}/checks/replay",
+		replayStatusChecks)
+
+	verifyStatusCheckConsistency := openapi3.Operation{}
+	verifyStatusCheckConsistency.WithTags(tag)
+	verifyStatusCheckConsistency.WithMapOfAnything(
+		map[string]interface{}{"operationId": "verifyStatusCheckConsistency"})
+	_ = reflector.SetRequest(&verifyStatusCheckConsistency, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&verifyStatusCheckConsistency, new(types.ConsistencyReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&verifyStatusCheckConsistency, new(types.ProblemDetails),
+		http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&verifyStatusCheckConsistency, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&verifyStatusCheckConsistency, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&verifyStatusCheckConsistency, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/repos/{repo_ref}/checks/verify-consistency",
+		verifyStatusCheckConsistency)
+
+	listStatusCheckArchives := openapi3.Operation{}
+	listStatusCheckArchives.WithTags(tag)
+	listStatusCheckArchives.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckArchives"})
+	listStatusCheckArchives.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&listStatusCheckArchives, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckArchives, new([]types.CheckArchive), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckArchives, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckArchives, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckArchives, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listStatusCheckArchives, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repos/{repo_ref}/checks/archives",
+		listStatusCheckArchives)
+
+	restoreStatusCheckArchive := openapi3.Operation{}
+	restoreStatusCheckArchive.WithTags(tag)
+	restoreStatusCheckArchive.WithMapOfAnything(map[string]interface{}{"operationId": "restoreStatusCheckArchive"})
+	_ = reflector.SetRequest(&restoreStatusCheckArchive, struct {
+		repoRequest
+		ArchiveID int64 `path:"check_archive_id"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&restoreStatusCheckArchive, new([]types.ArchivedCheck), http.StatusOK)
+	_ = reflector.SetJSONResponse(&restoreStatusCheckArchive, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&restoreStatusCheckArchive, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&restoreStatusCheckArchive, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&restoreStatusCheckArchive, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&restoreStatusCheckArchive, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/admin/repos/{repo_ref}/checks/archives/{check_archive_id}/checks", restoreStatusCheckArchive)
+
+	getStatusCheckSLABreaches := openapi3.Operation{}
+	getStatusCheckSLABreaches.WithTags(tag)
+	getStatusCheckSLABreaches.WithParameters(queryParameterCheckAuditFrom, queryParameterCheckAuditTo)
+	getStatusCheckSLABreaches.WithMapOfAnything(map[string]interface{}{"operationId": "getStatusCheckSLABreaches"})
+	_ = reflector.SetRequest(&getStatusCheckSLABreaches, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckSLABreaches, new([]types.CheckSLABreachRate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckSLABreaches, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getStatusCheckSLABreaches, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckSLABreaches, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckSLABreaches, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getStatusCheckSLABreaches, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/sla-breaches", getStatusCheckSLABreaches)
+
+	listStatusCheckAudit := openapi3.Operation{}
+	listStatusCheckAudit.WithTags(tag)
+	listStatusCheckAudit.WithParameters(QueryParameterPage, QueryParameterLimit,
+		queryParameterCheckAuditPrincipalID, queryParameterCheckAuditFrom, queryParameterCheckAuditTo)
+	listStatusCheckAudit.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckAudit"})
+	_ = reflector.SetRequest(&listStatusCheckAudit, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckAudit, new([]types.CheckAuditEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckAudit, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listStatusCheckAudit, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckAudit, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckAudit, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit/checks", listStatusCheckAudit)
+
+	streamStatusChecks := openapi3.Operation{}
+	streamStatusChecks.WithTags(tag)
+	streamStatusChecks.WithSummary("Stream status changes of all status checks")
+	streamStatusChecks.WithParameters(queryParameterCheckFeedSpace, queryParameterCheckFeedStatus)
+	streamStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "streamStatusChecks"})
+	_ = reflector.SetRequest(&streamStatusChecks, nil, http.MethodGet)
+	_ = reflector.SetStringResponse(&streamStatusChecks, http.StatusOK, "text/event-stream")
+	_ = reflector.SetJSONResponse(&streamStatusChecks, new(types.CheckFeedEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&streamStatusChecks, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&streamStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&streamStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&streamStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&streamStatusChecks, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/checks/stream", streamStatusChecks)
+
+	searchStatusChecks := openapi3.Operation{}
+	searchStatusChecks.WithTags(tag)
+	searchStatusChecks.WithSummary("Search the status checks of all repositories")
+	searchStatusChecks.WithParameters(queryParameterCheckSearchText, queryParameterCheckSearchRepo,
+		queryParameterCheckSearchStatus, queryParameterCheckSearchFrom, queryParameterCheckSearchTo,
+		queryParameterCheckSearchCursor, QueryParameterLimit)
+	searchStatusChecks.WithMapOfAnything(map[string]interface{}{"operationId": "searchStatusChecks"})
+	_ = reflector.SetRequest(&searchStatusChecks, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&searchStatusChecks, new(types.CheckSearchResult), http.StatusOK)
+	_ = reflector.SetJSONResponse(&searchStatusChecks, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&searchStatusChecks, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&searchStatusChecks, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&searchStatusChecks, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&searchStatusChecks, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/checks/search", searchStatusChecks)
+
+	getStatusCheckScalingHints := openapi3.Operation{}
+	getStatusCheckScalingHints.WithTags(tag)
+	getStatusCheckScalingHints.WithSummary("Get the status check autoscaling hints of the CI infrastructure")
+	getStatusCheckScalingHints.WithMapOfAnything(map[string]interface{}{"operationId": "getStatusCheckScalingHints"})
+	_ = reflector.SetRequest(&getStatusCheckScalingHints, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckScalingHints, new(types.CheckScalingHints), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckScalingHints, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckScalingHints, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckScalingHints, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/checks/scaling-hints", getStatusCheckScalingHints)
+
+	scrapeStatusCheckScalingHints := openapi3.Operation{}
+	scrapeStatusCheckScalingHints.WithTags(tag)
+	scrapeStatusCheckScalingHints.WithSummary("Scrape the status check autoscaling hints as Prometheus metrics")
+	scrapeStatusCheckScalingHints.WithMapOfAnything(
+		map[string]interface{}{"operationId": "scrapeStatusCheckScalingHints"})
+	_ = reflector.SetRequest(&scrapeStatusCheckScalingHints, nil, http.MethodGet)
+	_ = reflector.SetStringResponse(&scrapeStatusCheckScalingHints, http.StatusOK, "text/plain")
+	_ = reflector.SetJSONResponse(&scrapeStatusCheckScalingHints, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&scrapeStatusCheckScalingHints, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/checks/scaling-hints/metrics",
+		scrapeStatusCheckScalingHints)
+
+	getStatusCheckVolume := openapi3.Operation{}
+	getStatusCheckVolume.WithTags(tag)
+	getStatusCheckVolume.WithParameters(queryParameterCheckVolumeRepo, queryParameterCheckAuditFrom,
+		queryParameterCheckAuditTo, queryParameterCheckVolumeBucketMinutes)
+	getStatusCheckVolume.WithMapOfAnything(map[string]interface{}{"operationId": "getStatusCheckVolume"})
+	_ = reflector.SetRequest(&getStatusCheckVolume, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getStatusCheckVolume, new([]types.CheckVolumePoint), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getStatusCheckVolume, new(types.ProblemDetails), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getStatusCheckVolume, new(types.ProblemDetails), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getStatusCheckVolume, new(types.ProblemDetails), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getStatusCheckVolume, new(types.ProblemDetails), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getStatusCheckVolume, new(types.ProblemDetails), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/analytics/checks/volume", getStatusCheckVolume)
 }
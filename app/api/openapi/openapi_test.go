@@ -13,3 +13,91 @@
 // limitations under the License.
 
 package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_CheckOperations(t *testing.T) {
+	spec := NewOpenAPIService().Generate()
+
+	tests := []struct {
+		path   string
+		method string
+		opID   string
+	}{
+		{"/repos/{repo_ref}/checks/commits/{commit_sha}", http.MethodPut, "reportStatusCheckResults"},
+		{"/repos/{repo_ref}/checks/ingest/{check_source}", http.MethodPost, "ingestStatusCheckResult"},
+		{"/repos/{repo_ref}/checks/commits/{commit_sha}/move", http.MethodPost, "moveStatusChecks"},
+		{"/repos/{repo_ref}/checks/commits/{commit_sha}/acknowledge", http.MethodPost, "acknowledgeStatusCheck"},
+		{"/repos/{repo_ref}/checks/commits/{commit_sha}", http.MethodGet, "listStatusCheckResults"},
+		{"/repos/{repo_ref}/checks/commits/{commit_sha}/federated", http.MethodGet, "listFederatedStatusCheckResults"},
+		{"/repos/{repo_ref}/checks/recent", http.MethodGet, "listStatusCheckRecent"},
+		{"/repos/{repo_ref}/checks/recent/stats", http.MethodGet, "listStatusCheckRecentStats"},
+		{"/repos/{repo_ref}/checks/resource-usage", http.MethodGet, "getStatusCheckResourceUsage"},
+		{"/repos/{repo_ref}/checks/sla-breaches", http.MethodGet, "getStatusCheckSLABreaches"},
+		{"/repos/{repo_ref}/checks/configs", http.MethodGet, "listStatusCheckConfigs"},
+		{"/repos/{repo_ref}/checks/configs/{check_identifier}", http.MethodGet, "findStatusCheckConfig"},
+		{"/repos/{repo_ref}/checks/configs/{check_identifier}", http.MethodPut, "updateStatusCheckConfig"},
+		{"/repos/{repo_ref}/checks/configs/{check_identifier}", http.MethodDelete, "deleteStatusCheckConfig"},
+		{"/admin/audit/checks", http.MethodGet, "listStatusCheckAudit"},
+		{"/admin/checks/stream", http.MethodGet, "streamStatusChecks"},
+		{"/admin/checks/search", http.MethodGet, "searchStatusChecks"},
+		{"/admin/checks/scaling-hints", http.MethodGet, "getStatusCheckScalingHints"},
+		{"/admin/checks/scaling-hints/metrics", http.MethodGet, "scrapeStatusCheckScalingHints"},
+		{"/spaces/{space_ref}/check-health", http.MethodGet, "getSpaceStatusCheckHealth"},
+		{"/spaces/{space_ref}/check-policy", http.MethodGet, "listSpaceStatusCheckPolicies"},
+		{"/spaces/{space_ref}/check-policy", http.MethodPut, "updateSpaceStatusCheckPolicies"},
+		{"/admin/repos/{repo_ref}/checks/leaderboard", http.MethodGet, "getStatusCheckLeaderboard"},
+		{"/admin/analytics/checks/ingestion", http.MethodGet, "getStatusCheckOrgIngestionStats"},
+		{"/admin/analytics/checks/volume", http.MethodGet, "getStatusCheckVolume"},
+		{"/repos/{repo_ref}/checks/payload-diff", http.MethodGet, "getStatusCheckPayloadDiff"},
+		{"/repos/{repo_ref}/commits/{commit_sha}/checks/gate", http.MethodGet, "waitStatusCheckGate"},
+		{"/repos/{repo_ref}/checks/aliases", http.MethodGet, "listStatusCheckAliases"},
+		{"/repos/{repo_ref}/checks/aliases", http.MethodPost, "createStatusCheckAlias"},
+		{"/repos/{repo_ref}/checks/aliases/{check_identifier}", http.MethodDelete, "deleteStatusCheckAlias"},
+		{"/spaces/{space_ref}/reserved-checks", http.MethodGet, "listReservedStatusChecks"},
+		{"/repos/{repo_ref}/checks/rollup", http.MethodGet, "getStatusCheckRollup"},
+		{"/admin/repos/{repo_ref}/checks/archives", http.MethodGet, "listStatusCheckArchives"},
+		{"/admin/repos/{repo_ref}/checks/verify-consistency", http.MethodPost, "verifyStatusCheckConsistency"},
+		{"/admin/repos/{repo_ref}/checks/archives/{check_archive_id}/checks", http.MethodGet,
+			"restoreStatusCheckArchive"},
+		{"/spaces/{space_ref}/reserved-checks", http.MethodPut, "updateReservedStatusChecks"},
+	}
+	for _, test := range tests {
+		t.Run(test.opID, func(t *testing.T) {
+			op, ok := spec.Paths.MapOfPathItemValues[test.path].MapOfOperationValues[strings.ToLower(test.method)]
+			if !ok {
+				t.Fatalf("operation %s %s is not documented", test.method, test.path)
+			}
+			if got := op.MapOfAnything["operationId"]; got != test.opID {
+				t.Errorf("operationId = %v, want %s", got, test.opID)
+			}
+			_, ok = op.Responses.MapOfResponseOrRefValues["200"]
+			if _, created := op.Responses.MapOfResponseOrRefValues["201"]; created {
+				ok = true
+			}
+			if !ok && test.method != http.MethodDelete {
+				t.Errorf("operation %s has no success response", test.opID)
+			}
+		})
+	}
+
+	replay, ok := spec.Paths.MapOfPathItemValues["/admin/repos/{repo_ref}/checks/replay"].MapOfOperationValues["post"]
+	if !ok {
+		t.Errorf("operation replayStatusChecks is not documented")
+	} else if _, ok := replay.Responses.MapOfResponseOrRefValues["204"]; !ok {
+		t.Errorf("operation replayStatusChecks has no success response")
+	}
+
+	if _, err := json.Marshal(spec); err != nil {
+		t.Errorf("failed to marshal spec: %v", err)
+	}
+
+	if _, err := spec.MarshalYAML(); err != nil {
+		t.Errorf("failed to marshal spec to yaml: %v", err)
+	}
+}
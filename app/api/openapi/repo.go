@@ -327,6 +327,69 @@ var queryParameterIncludeCommit = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludeCheckAnnotations = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeCheckAnnotations,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If true, the status check annotations affecting changed lines would be included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterIncludeCommitChecks = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeChecks,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If true, the status checks reported for the commit would be included in the response."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterBlameIncludeChecks = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamIncludeChecks,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String(
+			"If true, the status check status of the commit of each blame part would be included in the response."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterCommitsIncludeChecks = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamIncludeChecks,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String(
+			"If true, the status check status of each commit would be included in the response. " +
+				"For compare ranges of more than 100 commits only the first 50 commits of the range are included."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterIncludeChecks = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamIncludeChecks,
@@ -343,6 +406,40 @@ var queryParameterIncludeChecks = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludeForkChecks = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInclude,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If set to checks, the status check status of the default branch of each fork is included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: []interface{}{
+					ptr.String(request.IncludeChecks),
+				},
+			},
+		},
+	},
+}
+
+var queryParameterIncludeSummaryChecks = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInclude,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If set to checks, the status check metrics of the last 30 days are included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: []interface{}{
+					ptr.String(request.IncludeChecks),
+				},
+			},
+		},
+	},
+}
+
 var queryParameterIncludeRules = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamIncludeRules,
@@ -562,6 +659,35 @@ var queryParameterCommitter = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterChecksStatus = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamChecksStatus,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only commits with a status check result in this status are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.CheckStatus("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterChecksIdentifier = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamChecksIdentifier,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only commits with a status check result of this identifier are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterQueryRuleList = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -877,6 +1003,20 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opServiceAccounts, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/service-accounts", opServiceAccounts)
 
+	opListForks := openapi3.Operation{}
+	opListForks.WithTags("repository")
+	opListForks.WithMapOfAnything(map[string]interface{}{"operationId": "listForks"})
+	opListForks.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
+		queryParameterIncludeForkChecks, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListForks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListForks, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/forks", opListForks)
+
 	opGetContent := openapi3.Operation{}
 	opGetContent.WithTags("repository")
 	opGetContent.WithMapOfAnything(map[string]interface{}{"operationId": "getContent"})
@@ -930,9 +1070,9 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
 	opGetBlame.WithParameters(queryParameterGitRef,
-		queryParameterLineFrom, queryParameterLineTo)
+		queryParameterLineFrom, queryParameterLineTo, queryParameterBlameIncludeChecks)
 	_ = reflector.SetRequest(&opGetBlame, new(getBlameRequest), http.MethodGet)
-	_ = reflector.SetJSONResponse(&opGetBlame, []git.BlamePart{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetBlame, []repo.BlamePart{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opGetBlame, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetBlame, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opGetBlame, new(usererror.Error), http.StatusForbidden)
@@ -944,9 +1084,11 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listCommits"})
 	opListCommits.WithParameters(queryParameterGitRef, queryParameterAfterCommits, queryParameterPath,
 		queryParameterSince, queryParameterUntil, queryParameterCommitter,
-		QueryParameterPage, QueryParameterLimit, QueryParamIncludeStats)
+		QueryParameterPage, QueryParameterLimit, QueryParamIncludeStats,
+		queryParameterChecksStatus, queryParameterChecksIdentifier, queryParameterCommitsIncludeChecks)
 	_ = reflector.SetRequest(&opListCommits, new(listCommitsRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opListCommits, new(types.ListCommitResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusForbidden)
@@ -956,8 +1098,9 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opGetCommit := openapi3.Operation{}
 	opGetCommit.WithTags("repository")
 	opGetCommit.WithMapOfAnything(map[string]interface{}{"operationId": "getCommit"})
+	opGetCommit.WithParameters(queryParameterIncludeCommitChecks)
 	_ = reflector.SetRequest(&opGetCommit, new(GetCommitRequest), http.MethodGet)
-	_ = reflector.SetJSONResponse(&opGetCommit, types.Commit{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetCommit, types.CommitExtended{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opGetCommit, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetCommit, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opGetCommit, new(usererror.Error), http.StatusForbidden)
@@ -1125,6 +1268,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDiffStats := openapi3.Operation{}
 	opDiffStats.WithTags("repository")
 	opDiffStats.WithMapOfAnything(map[string]interface{}{"operationId": "diffStats"})
+	opDiffStats.WithParameters(queryParameterIncludeCheckAnnotations)
 	_ = reflector.SetRequest(&opDiffStats, new(getRawDiffRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opDiffStats, new(types.DiffStats), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDiffStats, new(usererror.Error), http.StatusInternalServerError)
@@ -1320,6 +1464,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opSummary.WithTags("repository")
 	opSummary.WithMapOfAnything(
 		map[string]interface{}{"operationId": "summary"})
+	opSummary.WithParameters(queryParameterIncludeSummaryChecks)
 	_ = reflector.SetRequest(&opSummary, new(repoRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opSummary, new(types.RepositorySummary), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusBadRequest)
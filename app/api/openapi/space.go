@@ -94,6 +94,23 @@ var queryParameterQueryRepo = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterFilterChecksRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFilterChecks,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The status check filter which is used to filter the repositories."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: []interface{}{
+					ptr.String(request.FilterChecksFailing),
+				},
+			},
+		},
+	},
+}
+
 var queryParameterSortSpace = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -318,7 +335,7 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opRepos.WithTags("space")
 	opRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listRepos"})
 	opRepos.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
-		QueryParameterPage, QueryParameterLimit)
+		queryParameterFilterChecksRepo, QueryParameterPage, QueryParameterLimit)
 	_ = reflector.SetRequest(&opRepos, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opRepos, []repo.RepositoryOutput{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusInternalServerError)
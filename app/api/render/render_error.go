@@ -16,7 +16,10 @@ package render
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/harness/gitness/app/api/usererror"
 
@@ -28,6 +31,24 @@ func TranslatedUserError(ctx context.Context, w http.ResponseWriter, err error)
 	UserError(ctx, w, usererror.Translate(ctx, err))
 }
 
+// ProblemDetails writes the error translated to RFC 7807 problem details of the request.
+func ProblemDetails(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	problem := usererror.ProblemDetails(ctx, err, r.URL.Path)
+
+	log.Ctx(ctx).Debug().Err(err).Msgf("operation resulted in user facing error")
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	var uErr *usererror.Error
+	if errors.As(err, &uErr) {
+		setRetryAfter(w, uErr)
+	}
+
+	w.WriteHeader(problem.Status)
+	writeJSON(w, problem)
+}
+
 // NotFound writes the json-encoded message for a not found error.
 func NotFound(ctx context.Context, w http.ResponseWriter) {
 	UserError(ctx, w, usererror.ErrNotFound)
@@ -67,5 +88,15 @@ func InternalError(ctx context.Context, w http.ResponseWriter) {
 func UserError(ctx context.Context, w http.ResponseWriter, err *usererror.Error) {
 	log.Ctx(ctx).Debug().Err(err).Msgf("operation resulted in user facing error")
 
+	setRetryAfter(w, err)
 	JSON(w, err.Status, err)
 }
+
+// setRetryAfter sets the Retry-After header in seconds if the user error asks the client to retry later.
+func setRetryAfter(w http.ResponseWriter, err *usererror.Error) {
+	if err.RetryAfter <= 0 {
+		return
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(err.RetryAfter.Seconds()))))
+}
@@ -18,12 +18,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
 )
 
 func TestWriteErrorf(t *testing.T) {
@@ -141,6 +145,86 @@ func TestWriteBadRequest(t *testing.T) {
 	}
 }
 
+func TestWriteProblemDetails(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantType       string
+		wantRetryAfter string
+	}{
+		{
+			name:       "not found",
+			err:        fmt.Errorf("failed to find check: %w", store.ErrResourceNotFound),
+			wantStatus: http.StatusNotFound,
+			wantType:   usererror.ProblemTypeNotFound,
+		},
+		{
+			name:       "version conflict",
+			err:        store.ErrVersionConflict,
+			wantStatus: http.StatusConflict,
+			wantType:   usererror.ProblemTypeVersionConflict,
+		},
+		{
+			name:       "missing reference",
+			err:        store.ErrForeignKeyViolation,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantType:   usererror.ProblemTypeMissingReference,
+		},
+		{
+			name:       "bad request",
+			err:        usererror.BadRequest("invalid identifier"),
+			wantStatus: http.StatusBadRequest,
+			wantType:   usererror.ProblemTypeValidation,
+		},
+		{
+			name: "too many requests",
+			err: fmt.Errorf("rate limited: %w",
+				usererror.TooManyRequestsf(1500*time.Millisecond, "reported too often")),
+			wantStatus:     http.StatusTooManyRequests,
+			wantType:       usererror.ProblemTypeTooManyRequests,
+			wantRetryAfter: "2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/repos/space%2Frepo/checks/commits/abc", nil)
+
+			ProblemDetails(context.Background(), w, r, test.err)
+
+			if got, want := w.Code, test.wantStatus; got != want {
+				t.Errorf("Want status code %d, got %d", want, got)
+			}
+			if got, want := w.Header().Get("Content-Type"), "application/problem+json"; got != want {
+				t.Errorf("Want Content-Type %q, got %q", want, got)
+			}
+			if got := w.Header().Get("Retry-After"); got != test.wantRetryAfter {
+				t.Errorf("Want Retry-After %q, got %q", test.wantRetryAfter, got)
+			}
+
+			problem := new(types.ProblemDetails)
+			if err := json.NewDecoder(w.Body).Decode(problem); err != nil {
+				t.Fatalf("failed to decode problem details: %s", err)
+			}
+
+			if problem.Type != test.wantType {
+				t.Errorf("Want type %q, got %q", test.wantType, problem.Type)
+			}
+			if problem.Status != test.wantStatus || problem.Title != http.StatusText(test.wantStatus) {
+				t.Errorf("Want status %d with its title, got %d %q", test.wantStatus, problem.Status, problem.Title)
+			}
+			if problem.Instance != r.URL.Path {
+				t.Errorf("Want instance %q, got %q", r.URL.Path, problem.Instance)
+			}
+			if problem.Detail == "" || problem.Detail != problem.Message {
+				t.Errorf("Want detail equal to message, got %q and %q", problem.Detail, problem.Message)
+			}
+		})
+	}
+}
+
 func TestWriteJSON(t *testing.T) {
 	// without indent
 	{
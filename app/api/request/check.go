@@ -16,15 +16,101 @@ package request
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
+const (
+	PathParamCheckJobID      = "job_id"
+	PathParamCheckIdentifier = "check_identifier"
+	PathParamCheckSource     = "check_source"
+	PathParamCheckArchiveID  = "check_archive_id"
+	QueryParamStep           = "step"
+	QueryParamCheckNamespace = "namespace"
+	QueryParamCheckAuthor    = "author"
+
+	QueryParamCheckFeedSpace  = "space"
+	QueryParamCheckFeedStatus = "status"
+
+	QueryParamCheckVolumeRepo          = "repo"
+	QueryParamCheckVolumeBucketMinutes = "bucket_minutes"
+
+	// checkVolumeDefaultBucketMinutes is the bucket size of the status check volume histogram if none is provided.
+	checkVolumeDefaultBucketMinutes = 60
+
+	QueryParamCheckDiffFrom = "from_entry_id"
+	QueryParamCheckDiffTo   = "to_entry_id"
+
+	QueryParamCheckRollupFrom = "from_sha"
+	QueryParamCheckRollupTo   = "to_sha"
+
+	QueryParamCheckGateRequired = "required_uids"
+	QueryParamCheckGateTimeout  = "timeout_seconds"
+
+	// checkGateDefaultTimeout is how long the status checks are waited for if no timeout is provided.
+	checkGateDefaultTimeout = 300
+
+	QueryParamCheckSearchRepo   = "repo"
+	QueryParamCheckSearchStatus = "status"
+	QueryParamCheckSearchCursor = "cursor"
+
+	QueryParamAuditPrincipalID = "principal_id"
+	QueryParamAuditFrom        = "from"
+	QueryParamAuditTo          = "to"
+
+	// checkAuditDefaultRange is the time range of the status check audit log query if no start is provided.
+	checkAuditDefaultRange = 30 * 24 * time.Hour
+)
+
+// GetCheckSourceFromPath extracts the source of a third-party CI status check payload from the url.
+func GetCheckSourceFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCheckSource)
+}
+
+// GetCheckJobIDFromPath extracts the status check job ID from the url.
+func GetCheckJobIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCheckJobID)
+}
+
+// GetCheckArchiveIDFromPath extracts the status check archive ID from the url.
+func GetCheckArchiveIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamCheckArchiveID)
+}
+
 // ParseCheckListOptions extracts the status check list API options from the url.
-func ParseCheckListOptions(r *http.Request) types.CheckListOptions {
+func ParseCheckListOptions(r *http.Request) (types.CheckListOptions, error) {
+	sort, ok := enum.CheckSort(ParseSort(r)).Sanitize()
+	if !ok {
+		return types.CheckListOptions{}, usererror.BadRequest("Invalid value for the sort query parameter.")
+	}
+
+	namespace, err := GetCheckNamespaceFromQuery(r)
+	if err != nil {
+		return types.CheckListOptions{}, err
+	}
+
 	return types.CheckListOptions{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		StepName:        r.URL.Query().Get(QueryParamStep),
+		Namespace:       namespace,
+		Sort:            sort,
+		Order:           ParseOrder(r),
+	}, nil
+}
+
+// GetCheckNamespaceFromQuery extracts the optional status check namespace from the url.
+func GetCheckNamespaceFromQuery(r *http.Request) (string, error) {
+	namespace := r.URL.Query().Get(QueryParamCheckNamespace)
+	if namespace != "" && !types.IsValidCheckNamespace(namespace) {
+		return "", usererror.BadRequestf("Namespace must match the regular expression: %s",
+			types.CheckNamespaceRegexp)
 	}
+
+	return namespace, nil
 }
 
 // ParseCheckRecentOptions extracts the list recent status checks API options from the url.
@@ -39,3 +125,265 @@ func ParseCheckRecentOptions(r *http.Request) (types.CheckRecentOptions, error)
 		Since: since,
 	}, nil
 }
+
+// GetCheckIdentifierFromPath extracts the status check identifier from the url.
+func GetCheckIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCheckIdentifier)
+}
+
+// ParseCheckResourceUsageOptions extracts the status check resource usage API options from the url.
+// The time range is provided in unix milliseconds and defaults to the last 30 days.
+func ParseCheckResourceUsageOptions(r *http.Request) (types.CheckResourceUsageOptions, error) {
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, time.Now().UnixMilli())
+	if err != nil {
+		return types.CheckResourceUsageOptions{}, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom,
+		time.UnixMilli(to).Add(-checkAuditDefaultRange).UnixMilli())
+	if err != nil {
+		return types.CheckResourceUsageOptions{}, err
+	}
+
+	return types.CheckResourceUsageOptions{
+		From: time.UnixMilli(from),
+		To:   time.UnixMilli(to),
+	}, nil
+}
+
+// ParseCheckSLABreachOptions extracts the status check SLA breach rate API options from the url.
+// The time range is provided in unix milliseconds and defaults to the last 30 days.
+func ParseCheckSLABreachOptions(r *http.Request) (types.CheckSLABreachOptions, error) {
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, time.Now().UnixMilli())
+	if err != nil {
+		return types.CheckSLABreachOptions{}, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom,
+		time.UnixMilli(to).Add(-checkAuditDefaultRange).UnixMilli())
+	if err != nil {
+		return types.CheckSLABreachOptions{}, err
+	}
+
+	return types.CheckSLABreachOptions{
+		From: time.UnixMilli(from),
+		To:   time.UnixMilli(to),
+	}, nil
+}
+
+// ParseCheckLeaderboardOptions extracts the status check leaderboard API options from the url.
+// The time range is provided in unix milliseconds and defaults to the last 30 days.
+func ParseCheckLeaderboardOptions(r *http.Request) (types.CheckLeaderboardOptions, error) {
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, time.Now().UnixMilli())
+	if err != nil {
+		return types.CheckLeaderboardOptions{}, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom,
+		time.UnixMilli(to).Add(-checkAuditDefaultRange).UnixMilli())
+	if err != nil {
+		return types.CheckLeaderboardOptions{}, err
+	}
+
+	return types.CheckLeaderboardOptions{
+		From:  time.UnixMilli(from),
+		To:    time.UnixMilli(to),
+		Limit: ParseLimit(r),
+	}, nil
+}
+
+// ParseOrgIngestionStatsOptions extracts the status check ingestion per organization API options from the url.
+func ParseOrgIngestionStatsOptions(r *http.Request) (types.OrgIngestionStatsOptions, error) {
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, time.Now().UnixMilli())
+	if err != nil {
+		return types.OrgIngestionStatsOptions{}, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom,
+		time.UnixMilli(to).Add(-checkAuditDefaultRange).UnixMilli())
+	if err != nil {
+		return types.OrgIngestionStatsOptions{}, err
+	}
+
+	return types.OrgIngestionStatsOptions{
+		From:  time.UnixMilli(from),
+		To:    time.UnixMilli(to),
+		Limit: ParseLimit(r),
+	}, nil
+}
+
+// ParseCheckGateOptions extracts the status check gate API options from the url.
+// The required status check identifiers are provided as a comma separated list.
+func ParseCheckGateOptions(r *http.Request) (types.CheckGateOptions, error) {
+	values, _ := QueryParamList(r, QueryParamCheckGateRequired)
+
+	identifiers := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, identifier := range strings.Split(value, ",") {
+			if identifier = strings.TrimSpace(identifier); identifier != "" {
+				identifiers = append(identifiers, identifier)
+			}
+		}
+	}
+
+	timeout, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamCheckGateTimeout, checkGateDefaultTimeout)
+	if err != nil {
+		return types.CheckGateOptions{}, err
+	}
+
+	return types.CheckGateOptions{
+		RequiredIdentifiers: identifiers,
+		Timeout:             time.Duration(timeout) * time.Second,
+	}, nil
+}
+
+// ParseCheckPayloadDiffIDs extracts the IDs of the status check audit log entries
+// whose payloads are compared from the url.
+func ParseCheckPayloadDiffIDs(r *http.Request) (int64, int64, error) {
+	fromID, err := QueryParamAsPositiveInt64OrError(r, QueryParamCheckDiffFrom)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	toID, err := QueryParamAsPositiveInt64OrError(r, QueryParamCheckDiffTo)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return fromID, toID, nil
+}
+
+// ParseCheckRollupRange extracts the commit range of the status check rollup from the url.
+// The start of the range is optional.
+func ParseCheckRollupRange(r *http.Request) (string, string, error) {
+	toSHA, err := QueryParamOrError(r, QueryParamCheckRollupTo)
+	if err != nil {
+		return "", "", err
+	}
+
+	return r.URL.Query().Get(QueryParamCheckRollupFrom), toSHA, nil
+}
+
+// ParseCheckVolumeOptions extracts the status check volume histogram API options from the url.
+// The time range is provided in unix milliseconds and defaults to the last 30 days.
+func ParseCheckVolumeOptions(r *http.Request) (types.CheckVolumeOptions, error) {
+	repoRef, err := QueryParamOrError(r, QueryParamCheckVolumeRepo)
+	if err != nil {
+		return types.CheckVolumeOptions{}, err
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, time.Now().UnixMilli())
+	if err != nil {
+		return types.CheckVolumeOptions{}, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom,
+		time.UnixMilli(to).Add(-checkAuditDefaultRange).UnixMilli())
+	if err != nil {
+		return types.CheckVolumeOptions{}, err
+	}
+
+	bucketMinutes, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamCheckVolumeBucketMinutes,
+		checkVolumeDefaultBucketMinutes)
+	if err != nil {
+		return types.CheckVolumeOptions{}, err
+	}
+
+	return types.CheckVolumeOptions{
+		RepoRef:       repoRef,
+		From:          time.UnixMilli(from),
+		To:            time.UnixMilli(to),
+		BucketMinutes: int(bucketMinutes),
+	}, nil
+}
+
+// ParseCheckAuditListOptions extracts the status check audit log API options from the url.
+// The time range is provided in unix milliseconds and defaults to the last 30 days.
+func ParseCheckAuditListOptions(r *http.Request) (types.CheckAuditListOptions, error) {
+	principalID, err := QueryParamAsPositiveInt64OrError(r, QueryParamAuditPrincipalID)
+	if err != nil {
+		return types.CheckAuditListOptions{}, err
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, time.Now().UnixMilli())
+	if err != nil {
+		return types.CheckAuditListOptions{}, err
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom,
+		time.UnixMilli(to).Add(-checkAuditDefaultRange).UnixMilli())
+	if err != nil {
+		return types.CheckAuditListOptions{}, err
+	}
+
+	return types.CheckAuditListOptions{
+		PrincipalID: principalID,
+		From:        time.UnixMilli(from),
+		To:          time.UnixMilli(to),
+		Page:        ParsePage(r),
+		Size:        ParseLimit(r),
+	}, nil
+}
+
+// ParseCheckFeedOptions extracts the status check feed query parameters from the url.
+func ParseCheckFeedOptions(r *http.Request) (types.CheckFeedOptions, error) {
+	rawStatuses := r.URL.Query()[QueryParamCheckFeedStatus]
+
+	statuses := make([]enum.CheckStatus, 0, len(rawStatuses))
+	for _, raw := range rawStatuses {
+		status, ok := enum.CheckStatus(raw).Sanitize()
+		if !ok {
+			return types.CheckFeedOptions{}, usererror.BadRequestf("Invalid status check status: %q", raw)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return types.CheckFeedOptions{
+		SpaceRef: r.URL.Query().Get(QueryParamCheckFeedSpace),
+		Statuses: statuses,
+	}, nil
+}
+
+// ParseCheckSearchQuery extracts the status check search query from the url.
+// The optional time range is provided in unix milliseconds.
+func ParseCheckSearchQuery(r *http.Request) (types.CheckSearchQuery, error) {
+	rawStatuses := r.URL.Query()[QueryParamCheckSearchStatus]
+
+	statuses := make([]enum.CheckStatus, 0, len(rawStatuses))
+	for _, raw := range rawStatuses {
+		status, ok := enum.CheckStatus(raw).Sanitize()
+		if !ok {
+			return types.CheckSearchQuery{}, usererror.BadRequestf("Invalid status check status: %q", raw)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditFrom, 0)
+	if err != nil {
+		return types.CheckSearchQuery{}, err
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAuditTo, 0)
+	if err != nil {
+		return types.CheckSearchQuery{}, err
+	}
+
+	query := types.CheckSearchQuery{
+		Text:     strings.TrimSpace(ParseQuery(r)),
+		RepoRef:  r.URL.Query().Get(QueryParamCheckSearchRepo),
+		Statuses: statuses,
+		Cursor:   r.URL.Query().Get(QueryParamCheckSearchCursor),
+		Limit:    ParseLimit(r),
+	}
+
+	if from > 0 {
+		query.From = time.UnixMilli(from)
+	}
+	if to > 0 {
+		query.To = time.UnixMilli(to)
+	}
+
+	return query, nil
+}
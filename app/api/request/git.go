@@ -45,11 +45,14 @@ const (
 	QueryParamInternal           = "internal"
 	QueryParamService            = "service"
 	QueryParamCommitSHA          = "commit_sha"
-
-	QueryParamIncludeChecks   = "include_checks"
-	QueryParamIncludeRules    = "include_rules"
-	QueryParamIncludePullReqs = "include_pullreqs"
-	QueryParamMaxDivergence   = "max_divergence"
+	QueryParamChecksStatus       = "checks_status"
+	QueryParamChecksIdentifier   = "checks_uid"
+
+	QueryParamIncludeChecks           = "include_checks"
+	QueryParamIncludeCheckAnnotations = "include_check_annotations"
+	QueryParamIncludeRules            = "include_rules"
+	QueryParamIncludePullReqs         = "include_pullreqs"
+	QueryParamMaxDivergence           = "max_divergence"
 )
 
 func GetGitRefFromQueryOrDefault(r *http.Request, deflt string) string {
@@ -64,6 +67,10 @@ func GetIncludeChecksFromQueryOrDefault(r *http.Request, deflt bool) (bool, erro
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeChecks, deflt)
 }
 
+func GetIncludeCheckAnnotationsFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCheckAnnotations, deflt)
+}
+
 func GetIncludeRulesFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeRules, deflt)
 }
@@ -181,6 +188,17 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+	includeChecks, err := GetIncludeChecksFromQueryOrDefault(r, false)
+	if err != nil {
+		return nil, err
+	}
+	// checks status is optional, skipped if empty
+	checksStatus := enum.CheckStatus(QueryParamOrDefault(r, QueryParamChecksStatus, ""))
+	if checksStatus != "" {
+		if _, ok := checksStatus.Sanitize(); !ok {
+			return nil, usererror.BadRequestf("Invalid status check status: %q", checksStatus)
+		}
+	}
 
 	return &types.CommitFilter{
 		After: QueryParamOrDefault(r, QueryParamAfter, ""),
@@ -193,6 +211,10 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 		Until:        until,
 		Committer:    QueryParamOrDefault(r, QueryParamCommitter, ""),
 		IncludeStats: includeStats,
+
+		ChecksStatus:     checksStatus,
+		ChecksIdentifier: QueryParamOrDefault(r, QueryParamChecksIdentifier, ""),
+		IncludeChecks:    includeChecks,
 	}, nil
 }
 
@@ -17,6 +17,7 @@ package request
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -24,6 +25,12 @@ import (
 const (
 	PathParamRepoRef = "repo_ref"
 	QueryParamRepoID = "repo_id"
+
+	QueryParamFilterChecks = "filter_checks"
+	FilterChecksFailing    = "failing"
+
+	QueryParamInclude = "include"
+	IncludeChecks     = "checks"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
@@ -65,6 +72,11 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		deletedAt = &deletedAtVal
 	}
 
+	failingChecks, err := ParseFilterChecksFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.RepoFilter{
 		Query:             ParseQuery(r),
 		Order:             ParseOrder(r),
@@ -74,5 +86,53 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		Recursive:         recursive,
 		DeletedAt:         deletedAt,
 		DeletedBeforeOrAt: deletedBeforeOrAt,
+		FailingChecks:     failingChecks,
 	}, nil
 }
+
+// ParseFilterChecksFromQuery extracts the status check filter from the url.
+// It returns true if only repositories with failing status checks should be returned.
+func ParseFilterChecksFromQuery(r *http.Request) (bool, error) {
+	switch value := r.URL.Query().Get(QueryParamFilterChecks); value {
+	case "":
+		return false, nil
+	case FilterChecksFailing:
+		return true, nil
+	default:
+		return false, usererror.BadRequestf("Invalid value %q for query parameter %q, supported values: %q",
+			value, QueryParamFilterChecks, FilterChecksFailing)
+	}
+}
+
+// ParseRepoForkFilter extracts the fork list filter from the url.
+func ParseRepoForkFilter(r *http.Request) (*types.RepoForkFilter, error) {
+	includeChecks, err := ParseIncludeChecksFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RepoForkFilter{
+		RepoFilter: types.RepoFilter{
+			Query: ParseQuery(r),
+			Order: ParseOrder(r),
+			Page:  ParsePage(r),
+			Sort:  ParseSortRepo(r),
+			Size:  ParseLimit(r),
+		},
+		IncludeChecks: includeChecks,
+	}, nil
+}
+
+// ParseIncludeChecksFromQuery extracts the include parameter from the url.
+// It returns true if status check statuses should be included in the response.
+func ParseIncludeChecksFromQuery(r *http.Request) (bool, error) {
+	switch value := r.URL.Query().Get(QueryParamInclude); value {
+	case "":
+		return false, nil
+	case IncludeChecks:
+		return true, nil
+	default:
+		return false, usererror.BadRequestf("Invalid value %q for query parameter %q, supported values: %q",
+			value, QueryParamInclude, IncludeChecks)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usererror
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// problemTypeBlank is the problem type of errors that are only described by their status code.
+const problemTypeBlank = "about:blank"
+
+// Problem types of the RFC 7807 problem details.
+const (
+	ProblemTypeNotFound           = "urn:gitness:problem:not-found"
+	ProblemTypeDuplicate          = "urn:gitness:problem:duplicate"
+	ProblemTypeVersionConflict    = "urn:gitness:problem:version-conflict"
+	ProblemTypeMissingReference   = "urn:gitness:problem:missing-reference"
+	ProblemTypePreconditionFailed = "urn:gitness:problem:precondition-failed"
+	ProblemTypeValidation         = "urn:gitness:problem:validation"
+	ProblemTypeUnauthorized       = "urn:gitness:problem:unauthorized"
+	ProblemTypeForbidden          = "urn:gitness:problem:forbidden"
+	ProblemTypeConflict           = "urn:gitness:problem:conflict"
+	ProblemTypeUnprocessable      = "urn:gitness:problem:unprocessable"
+	ProblemTypeRequestTooLarge    = "urn:gitness:problem:request-too-large"
+	ProblemTypeResourceLocked     = "urn:gitness:problem:resource-locked"
+	ProblemTypeTooManyRequests    = "urn:gitness:problem:too-many-requests"
+	ProblemTypeInternal           = "urn:gitness:problem:internal"
+)
+
+// ProblemDetails translates the error to RFC 7807 problem details of the request to the provided instance path.
+// Store errors are mapped to their specific problem types, all other errors to the problem type of the status.
+func ProblemDetails(ctx context.Context, err error, instance string) *types.ProblemDetails {
+	var (
+		uErr        *Error
+		problemType string
+	)
+
+	switch {
+	case errors.Is(err, store.ErrResourceNotFound):
+		uErr, problemType = Translate(ctx, err), ProblemTypeNotFound
+	case errors.Is(err, store.ErrDuplicate):
+		uErr, problemType = Translate(ctx, err), ProblemTypeDuplicate
+	case errors.Is(err, store.ErrVersionConflict):
+		uErr = New(http.StatusConflict, "The resource was modified concurrently, please retry the operation.")
+		problemType = ProblemTypeVersionConflict
+	case errors.Is(err, store.ErrForeignKeyViolation):
+		uErr = New(http.StatusUnprocessableEntity, "A referenced resource doesn't exist.")
+		problemType = ProblemTypeMissingReference
+	case errors.Is(err, store.ErrPreConditionFailed):
+		uErr = ErrPreconditionFailed
+		problemType = ProblemTypePreconditionFailed
+	default:
+		uErr = Translate(ctx, err)
+		problemType = problemTypeFromStatus(uErr.Status)
+	}
+
+	return &types.ProblemDetails{
+		Type:     problemType,
+		Title:    http.StatusText(uErr.Status),
+		Status:   uErr.Status,
+		Detail:   uErr.Message,
+		Instance: instance,
+		Message:  uErr.Message,
+		Values:   uErr.Values,
+	}
+}
+
+// problemTypeFromStatus returns the problem type of errors with the provided status code.
+func problemTypeFromStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ProblemTypeValidation
+	case http.StatusUnauthorized:
+		return ProblemTypeUnauthorized
+	case http.StatusForbidden:
+		return ProblemTypeForbidden
+	case http.StatusNotFound:
+		return ProblemTypeNotFound
+	case http.StatusConflict:
+		return ProblemTypeConflict
+	case http.StatusPreconditionFailed:
+		return ProblemTypePreconditionFailed
+	case http.StatusRequestEntityTooLarge:
+		return ProblemTypeRequestTooLarge
+	case http.StatusUnprocessableEntity:
+		return ProblemTypeUnprocessable
+	case http.StatusLocked:
+		return ProblemTypeResourceLocked
+	case http.StatusTooManyRequests:
+		return ProblemTypeTooManyRequests
+	case http.StatusInternalServerError:
+		return ProblemTypeInternal
+	default:
+		return problemTypeBlank
+	}
+}
@@ -17,6 +17,7 @@ package usererror
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 var (
@@ -99,6 +100,9 @@ type Error struct {
 	Status  int            `json:"-"`
 	Message string         `json:"message"`
 	Values  map[string]any `json:"values,omitempty"`
+
+	// RetryAfter is how long the client should wait before retrying the request, if set.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *Error) Error() string {
@@ -150,6 +154,14 @@ func UnprocessableEntityf(format string, args ...any) *Error {
 	return Newf(http.StatusUnprocessableEntity, format, args...)
 }
 
+// TooManyRequestsf returns a new user facing too many requests error
+// that asks the client to retry after the provided duration.
+func TooManyRequestsf(retryAfter time.Duration, format string, args ...any) *Error {
+	err := Newf(http.StatusTooManyRequests, format, args...)
+	err.RetryAfter = retryAfter
+	return err
+}
+
 // BadRequestWithPayload returns a new user facing bad request error with payload.
 func BadRequestWithPayload(message string, values ...map[string]any) *Error {
 	return NewWithPayload(http.StatusBadRequest, message, values...)
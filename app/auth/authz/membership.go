@@ -17,6 +17,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -147,6 +148,11 @@ func (a *MembershipAuthorizer) Check(
 		return a.checkWithAccessPermissionMetadata(ctx, accessPermissionMetadata, spacePath, permission)
 	}
 
+	// checkReportMetadata only allows reporting status checks to a single repository
+	if checkReportMetadata, ok := session.Metadata.(*auth.CheckReportMetadata); ok {
+		return checkWithCheckReportMetadata(checkReportMetadata, scope, resource, permission), nil
+	}
+
 	// ensure we aren't bypassing unknown metadata with impact on authorization
 	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
@@ -231,3 +237,18 @@ func (a *MembershipAuthorizer) checkWithAccessPermissionMetadata(
 
 	return false, fmt.Errorf("no %s permission provided", requestedPermission)
 }
+
+// checkWithCheckReportMetadata checks access using the status check report grant provided in the metadata.
+func checkWithCheckReportMetadata(
+	checkReportMetadata *auth.CheckReportMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	requestedPermission enum.Permission,
+) bool {
+	if resource.Type != enum.ResourceTypeRepo || requestedPermission != enum.PermissionRepoReportCommitCheck {
+		return false
+	}
+
+	// repository paths are case-insensitive.
+	return strings.EqualFold(paths.Concatenate(scope.SpacePath, resource.Identifier), checkReportMetadata.RepoPath)
+}
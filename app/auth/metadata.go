@@ -58,3 +58,15 @@ type AccessPermissionMetadata struct {
 func (m *AccessPermissionMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// CheckReportMetadata contains an ephemeral grant to report status checks.
+// It only grants the permission to report status checks in a single namespace of a single repository.
+type CheckReportMetadata struct {
+	RepoID    int64
+	RepoPath  string
+	Namespace string
+}
+
+func (m *CheckReportMetadata) ImpactsAuthorization() bool {
+	return true
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const StatusChangedEvent events.EventType = "status-changed"
+
+type StatusChangedPayload struct {
+	RepoID      int64            `json:"repo_id"`
+	PrincipalID int64            `json:"principal_id"`
+	CheckID     int64            `json:"check_id"`
+	CommitSHA   string           `json:"commit_sha"`
+	Namespace   string           `json:"namespace,omitempty"`
+	Identifier  string           `json:"identifier"`
+	OldStatus   enum.CheckStatus `json:"old_status"`
+	NewStatus   enum.CheckStatus `json:"new_status"`
+	// Visibility is the visibility of the status check, it's empty for events of public status checks
+	// reported before the visibility got added to the events.
+	Visibility enum.CheckVisibility `json:"visibility,omitempty"`
+}
+
+func (r *Reporter) StatusChanged(ctx context.Context, payload *StatusChangedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, StatusChangedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send check status changed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported check status changed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterStatusChanged(fn events.HandlerFunc[*StatusChangedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, StatusChangedEvent, fn, opts...)
+}
+
+const SLABreachedEvent events.EventType = "sla-breached"
+
+type SLABreachedPayload struct {
+	RepoID     int64  `json:"repo_id"`
+	CheckID    int64  `json:"check_id"`
+	CommitSHA  string `json:"commit_sha"`
+	Identifier string `json:"identifier"`
+	Started    int64  `json:"started"`
+	Ended      int64  `json:"ended"`
+}
+
+func (r *Reporter) SLABreached(ctx context.Context, payload *SLABreachedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, SLABreachedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send check SLA breached event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported check SLA breached event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterSLABreached(fn events.HandlerFunc[*SLABreachedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, SLABreachedEvent, fn, opts...)
+}
+
+const ReplicateEvent events.EventType = "replicate"
+
+type ReplicatePayload struct {
+	RepoID     int64  `json:"repo_id"`
+	CommitSHA  string `json:"commit_sha"`
+	Identifier string `json:"identifier"`
+	Namespace  string `json:"namespace,omitempty"`
+	Updated    int64  `json:"updated"`
+	// Deleted is true if the status check got deleted, or all status checks of the repo if there's no identifier.
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+func (r *Reporter) Replicate(ctx context.Context, payload *ReplicatePayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReplicateEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send check replicate event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported check replicate event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReplicate(fn events.HandlerFunc[*ReplicatePayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReplicateEvent, fn, opts...)
+}
@@ -17,19 +17,22 @@ package checks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 // Write is a util function which writes execution and pipeline state to the
-// check store.
+// check store and records the change in the check audit store.
 func Write(
 	ctx context.Context,
 	checkStore store.CheckStore,
+	checkAuditStore store.CheckAuditStore,
 	execution *types.Execution,
 	pipeline *types.Pipeline,
 ) error {
@@ -57,15 +60,29 @@ func Write(
 		Status:     execution.Status.ConvertToCheckStatus(),
 		CommitSHA:  execution.After,
 		Metadata:   []byte("{}"),
+
+		FailureCategory: execution.Status.ConvertToCheckFailureCategory(),
 		Payload: types.CheckPayload{
 			Version: "1",
 			Kind:    enum.CheckPayloadKindPipeline,
 			Data:    data,
 		},
 	}
+	existing, err := checkStore.FindInNamespace(ctx, check.RepoID, check.CommitSHA,
+		types.CheckNamespaceDefault, check.Identifier)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("could not find existing check: %w", err)
+	}
 	err = checkStore.Upsert(ctx, check)
 	if err != nil {
 		return fmt.Errorf("could not upsert to check store: %w", err)
 	}
+	if check.Deduplicated {
+		return nil
+	}
+	err = checkAuditStore.Create(ctx, types.NewCheckAuditEntry(execution.CreatedBy, existing, check))
+	if err != nil {
+		return fmt.Errorf("could not create check audit entry: %w", err)
+	}
 	return nil
 }
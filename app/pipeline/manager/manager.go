@@ -136,6 +136,7 @@ type Manager struct {
 	Pipelines        store.PipelineStore
 	urlProvider      urlprovider.Provider
 	Checks           store.CheckStore
+	CheckAudits      store.CheckAuditStore
 	// Converter  store.ConvertService
 	SSEStreamer sse.Streamer
 	// Globals    store.GlobalSecretStore
@@ -168,6 +169,7 @@ func New(
 	logStore store.LogStore,
 	logStream livelog.LogStream,
 	checkStore store.CheckStore,
+	checkAuditStore store.CheckAuditStore,
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
 	secretStore store.SecretStore,
@@ -188,6 +190,7 @@ func New(
 		Logs:             logStore,
 		Logz:             logStream,
 		Checks:           checkStore,
+		CheckAudits:      checkAuditStore,
 		Repos:            repoStore,
 		Scheduler:        scheduler,
 		Secrets:          secretStore,
@@ -463,6 +466,7 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 	s := &setup{
 		Executions:  m.Executions,
 		Checks:      m.Checks,
+		CheckAudits: m.CheckAudits,
 		Pipelines:   m.Pipelines,
 		SSEStreamer: m.SSEStreamer,
 		Repos:       m.Repos,
@@ -480,6 +484,7 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Executions:  m.Executions,
 		Pipelines:   m.Pipelines,
 		Checks:      m.Checks,
+		CheckAudits: m.CheckAudits,
 		SSEStreamer: m.SSEStreamer,
 		Logs:        m.Logz,
 		Repos:       m.Repos,
@@ -32,6 +32,7 @@ import (
 type setup struct {
 	Executions  store.ExecutionStore
 	Checks      store.CheckStore
+	CheckAudits store.CheckAuditStore
 	SSEStreamer sse.Streamer
 	Pipelines   store.PipelineStore
 	Repos       store.RepoStore
@@ -98,7 +99,7 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 	// try to write to the checks store - if not, log an error and continue
-	err = checks.Write(ctx, s.Checks, execution, pipeline)
+	err = checks.Write(ctx, s.Checks, s.CheckAudits, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
 	}
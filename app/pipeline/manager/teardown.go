@@ -37,6 +37,7 @@ import (
 type teardown struct {
 	Executions  store.ExecutionStore
 	Checks      store.CheckStore
+	CheckAudits store.CheckAuditStore
 	Pipelines   store.PipelineStore
 	SSEStreamer sse.Streamer
 	Logs        livelog.LogStream
@@ -185,7 +186,7 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 	// try to write to the checks store - if not, log an error and continue
-	err = checks.Write(ctx, t.Checks, execution, pipeline)
+	err = checks.Write(ctx, t.Checks, t.CheckAudits, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
 	}
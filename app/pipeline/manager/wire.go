@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/services/checkreplication"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -47,7 +48,8 @@ func ProvideExecutionManager(
 	converterService converter.Service,
 	logStore store.LogStore,
 	logStream livelog.LogStream,
-	checkStore store.CheckStore,
+	checkStore *checkreplication.ReplicatedCheckStore,
+	checkAuditStore store.CheckAuditStore,
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
 	secretStore store.SecretStore,
@@ -58,7 +60,7 @@ func ProvideExecutionManager(
 	reporter *events.Reporter,
 ) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, converterService,
-		logStore, logStream, checkStore, repoStore, scheduler, secretStore,
+		logStore, logStream, checkStore, checkAuditStore, repoStore, scheduler, secretStore,
 		stageStore, stepStore, userStore, publicAccess, *reporter)
 }
 
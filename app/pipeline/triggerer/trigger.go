@@ -83,6 +83,7 @@ type Triggerer interface {
 type triggerer struct {
 	executionStore   store.ExecutionStore
 	checkStore       store.CheckStore
+	checkAuditStore  store.CheckAuditStore
 	stageStore       store.StageStore
 	tx               dbtx.Transactor
 	pipelineStore    store.PipelineStore
@@ -99,6 +100,7 @@ type triggerer struct {
 func New(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
+	checkAuditStore store.CheckAuditStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	tx dbtx.Transactor,
@@ -114,6 +116,7 @@ func New(
 	return &triggerer{
 		executionStore:   executionStore,
 		checkStore:       checkStore,
+		checkAuditStore:  checkAuditStore,
 		stageStore:       stageStore,
 		scheduler:        scheduler,
 		urlProvider:      urlProvider,
@@ -365,7 +368,7 @@ func (t *triggerer) Trigger(
 	}
 
 	// try to write to check store. log on failure but don't error out the execution
-	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	err = checks.Write(ctx, t.checkStore, t.checkAuditStore, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
@@ -588,7 +591,7 @@ func (t *triggerer) createExecutionWithError(
 	}
 
 	// try to write to check store, log on failure
-	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	err = checks.Write(ctx, t.checkStore, t.checkAuditStore, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: failed to update check")
 	}
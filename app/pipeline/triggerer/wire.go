@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/services/checkreplication"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -34,7 +35,8 @@ var WireSet = wire.NewSet(
 // ProvideTriggerer provides a triggerer which can execute builds.
 func ProvideTriggerer(
 	executionStore store.ExecutionStore,
-	checkStore store.CheckStore,
+	checkStore *checkreplication.ReplicatedCheckStore,
+	checkAuditStore store.CheckAuditStore,
 	stageStore store.StageStore,
 	tx dbtx.Transactor,
 	pipelineStore store.PipelineStore,
@@ -47,7 +49,7 @@ func ProvideTriggerer(
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
 ) Triggerer {
-	return New(executionStore, checkStore, stageStore, pipelineStore,
+	return New(executionStore, checkStore, checkAuditStore, stageStore, pipelineStore,
 		tx, repoStore, urlProvider, scheduler, fileService, converterService,
 		templateStore, pluginStore, publicAccess)
 }
@@ -101,8 +101,14 @@ var (
 	terminatedPathPrefixesAPI = []string{"/v1/spaces/", "/v1/repos/",
 		"/v1/secrets/", "/v1/connectors", "/v1/templates/step", "/v1/templates/stage", "/v1/gitspaces", "/v1/infraproviders",
 		"/v1/migrate/repos", "/v1/pipelines"}
+
+	// compressJSON gzip compresses JSON responses of endpoints that can return large lists,
+	// if the client accepts the compressed encoding.
+	compressJSON = middleware.Compress(gzipCompressionLevel, "application/json")
 )
 
+const gzipCompressionLevel = 5
+
 // NewAPIHandler returns a new APIHandler.
 func NewAPIHandler(
 	appCtx context.Context,
@@ -222,7 +228,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	capabilitiesCtrl *capabilities.Controller,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
 		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
 	setupConnectors(r, connectorCtrl)
@@ -233,7 +239,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, appCtx, userCtrl, checkCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -248,7 +254,7 @@ func setupSpaces(
 	spaceCtrl *space.Controller,
 	userGroupCtrl *usergroup.Controller,
 	webhookCtrl *webhook.Controller,
-
+	checkCtrl *check.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -281,6 +287,18 @@ func setupSpaces(
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
 			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
 
+			r.Get("/check-health", handlerspace.HandleCheckHealth(spaceCtrl))
+
+			r.Route("/check-policy", func(r chi.Router) {
+				r.Get("/", handlercheck.HandleSpacePolicyList(checkCtrl))
+				r.Put("/", handlercheck.HandleSpacePolicyUpdate(checkCtrl))
+			})
+
+			r.Route("/reserved-checks", func(r chi.Router) {
+				r.Get("/", handlercheck.HandleReservedCheckList(checkCtrl))
+				r.Put("/", handlercheck.HandleReservedCheckUpdate(checkCtrl))
+			})
+
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
 				r.Post("/", handlerspace.HandleMembershipAdd(spaceCtrl))
@@ -376,6 +394,7 @@ func setupRepos(r chi.Router,
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
+			r.Get("/forks", handlerrepo.HandleListForks(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
@@ -410,6 +429,7 @@ func setupRepos(r chi.Router,
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 					r.Get("/", handlerrepo.HandleGetCommit(repoCtrl))
 					r.Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+					r.Get("/checks/gate", handlercheck.HandleCheckGate(checkCtrl))
 				})
 			})
 
@@ -734,10 +754,35 @@ func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	r.Route("/checks", func(r chi.Router) {
 		r.Get("/recent", handlercheck.HandleCheckListRecent(checkCtrl))
+		r.Get("/recent/stats", handlercheck.HandleCheckListRecentStats(checkCtrl))
+		r.Get("/resource-usage", handlercheck.HandleCheckResourceUsage(checkCtrl))
+		r.Get("/sla-breaches", handlercheck.HandleCheckSLABreaches(checkCtrl))
+		r.Get("/payload-diff", handlercheck.HandleCheckPayloadDiff(checkCtrl))
+		r.Get("/rollup", handlercheck.HandleCheckRollup(checkCtrl))
+		r.Get("/authored", handlercheck.HandleCheckListAuthored(checkCtrl))
+		r.Route("/configs", func(r chi.Router) {
+			r.Get("/", handlercheck.HandleCheckConfigList(checkCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamCheckIdentifier), func(r chi.Router) {
+				r.Get("/", handlercheck.HandleCheckConfigFind(checkCtrl))
+				r.Put("/", handlercheck.HandleCheckConfigUpdate(checkCtrl))
+				r.Delete("/", handlercheck.HandleCheckConfigDelete(checkCtrl))
+			})
+		})
+		r.Route("/aliases", func(r chi.Router) {
+			r.Get("/", handlercheck.HandleCheckAliasList(checkCtrl))
+			r.Post("/", handlercheck.HandleCheckAliasCreate(checkCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamCheckIdentifier), handlercheck.HandleCheckAliasDelete(checkCtrl))
+		})
 		r.Route(fmt.Sprintf("/commits/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 			r.Put("/", handlercheck.HandleCheckReport(checkCtrl))
-			r.Get("/", handlercheck.HandleCheckList(checkCtrl))
+			r.With(compressJSON).Get("/", handlercheck.HandleCheckList(checkCtrl))
+			r.Get("/federated", handlercheck.HandleCheckListFederated(checkCtrl))
+			r.Get("/annotations", handlercheck.HandleCheckAnnotationList(checkCtrl))
+			r.Post("/move", handlercheck.HandleCheckMove(checkCtrl))
+			r.Post("/acknowledge", handlercheck.HandleCheckAcknowledge(checkCtrl))
 		})
+		r.Post(fmt.Sprintf("/ingest/{%s}", request.PathParamCheckSource), handlercheck.HandleCheckIngest(checkCtrl))
+		r.Post("/stream", handlercheck.HandleCheckReportStream(checkCtrl))
 	})
 }
 
@@ -818,7 +863,7 @@ func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 
 func setupSystem(r chi.Router, config *types.Config, sysCtrl *system.Controller) {
 	r.Route("/system", func(r chi.Router) {
-		r.Get("/health", handlersystem.HandleHealth)
+		r.Get("/health", handlersystem.HandleHealth(sysCtrl))
 		r.Get("/version", handlersystem.HandleVersion)
 		r.Get("/config", handlersystem.HandleGetConfig(config, sysCtrl))
 	})
@@ -868,9 +913,31 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, appCtx context.Context, userCtrl *user.Controller, checkCtrl *check.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
+		r.Route(fmt.Sprintf("/repos/{%s}/checks/recompute", request.PathParamRepoRef), func(r chi.Router) {
+			r.Post("/", handlercheck.HandleCheckRecompute(checkCtrl))
+			r.Get(fmt.Sprintf("/{%s}", request.PathParamCheckJobID), handlercheck.HandleCheckRecomputeProgress(checkCtrl))
+		})
+		r.Post(fmt.Sprintf("/repos/{%s}/checks/replay", request.PathParamRepoRef),
+			handlercheck.HandleCheckReplay(checkCtrl))
+		r.Post(fmt.Sprintf("/repos/{%s}/checks/verify-consistency", request.PathParamRepoRef),
+			handlercheck.HandleCheckVerifyConsistency(checkCtrl))
+		r.Get(fmt.Sprintf("/repos/{%s}/checks/leaderboard", request.PathParamRepoRef),
+			handlercheck.HandleCheckLeaderboard(checkCtrl))
+		r.Route(fmt.Sprintf("/repos/{%s}/checks/archives", request.PathParamRepoRef), func(r chi.Router) {
+			r.Get("/", handlercheck.HandleCheckArchiveList(checkCtrl))
+			r.Get(fmt.Sprintf("/{%s}/checks", request.PathParamCheckArchiveID),
+				handlercheck.HandleCheckArchiveRestore(checkCtrl))
+		})
+		r.Get("/audit/checks", handlercheck.HandleCheckAuditList(checkCtrl))
+		r.Get("/checks/stream", handlercheck.HandleCheckFeed(appCtx, checkCtrl))
+		r.Get("/checks/search", handlercheck.HandleCheckSearch(checkCtrl))
+		r.Get("/checks/scaling-hints", handlercheck.HandleCheckScalingHints(checkCtrl))
+		r.Get("/checks/scaling-hints/metrics", handlercheck.HandleCheckScalingMetrics(checkCtrl))
+		r.Get("/analytics/checks/volume", handlercheck.HandleCheckVolumeHistogram(checkCtrl))
+		r.Get("/analytics/checks/ingestion", handlercheck.HandleCheckOrgIngestionStats(checkCtrl))
 		r.Route("/users", func(r chi.Router) {
 			r.Get("/", users.HandleList(userCtrl))
 			r.Post("/", users.HandleCreate(userCtrl))
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func checkListHandler(count int) http.Handler {
+	checks := make([]types.Check, count)
+	for i := range checks {
+		checks[i] = types.Check{
+			ID:         int64(i + 1),
+			Created:    1700000000000,
+			Updated:    1700000060000,
+			Identifier: fmt.Sprintf("pipeline-%d", i),
+			Status:     enum.CheckStatusSuccess,
+			Summary:    "All steps of the pipeline completed successfully",
+			Link:       fmt.Sprintf("https://example.com/pipelines/%d/executions/42", i),
+			Metadata:   []byte("{}"),
+			Started:    1700000000000,
+			Ended:      1700000060000,
+			Payload: types.CheckPayload{
+				Kind: enum.CheckPayloadKindPipeline,
+				Data: []byte(`{"execution_number":42,"repo_id":1,"pipeline_id":7}`),
+			},
+			ReportedBy: &types.PrincipalInfo{ID: 1, UID: "admin", DisplayName: "Administrator"},
+		}
+	}
+
+	return compressJSON(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		render.JSON(w, http.StatusOK, checks)
+	}))
+}
+
+func serveCheckList(handler http.Handler, acceptEncoding string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/checks/commits/abc", nil)
+	if acceptEncoding != "" {
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestCompressJSON(t *testing.T) {
+	handler := checkListHandler(500)
+
+	plain := serveCheckList(handler, "")
+	if enc := plain.Header().Get("Content-Encoding"); enc != "" {
+		t.Fatalf("response without accepted encoding is encoded with %q", enc)
+	}
+
+	compressed := serveCheckList(handler, "gzip")
+	if enc := compressed.Header().Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", enc)
+	}
+
+	reader, err := gzip.NewReader(compressed.Body)
+	if err != nil {
+		t.Fatalf("failed to create gzip reader: %v", err)
+	}
+
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress response: %v", err)
+	}
+
+	if string(decompressed) != plain.Body.String() {
+		t.Error("decompressed response doesn't match the uncompressed response")
+	}
+
+	if compressed.Body.Len() >= plain.Body.Len()/5 {
+		t.Errorf("compressed response has %d bytes, uncompressed %d bytes", compressed.Body.Len(), plain.Body.Len())
+	}
+}
+
+func BenchmarkCompressJSON(b *testing.B) {
+	handler := checkListHandler(500)
+
+	for _, acceptEncoding := range []string{"", "gzip"} {
+		b.Run("accept-encoding="+acceptEncoding, func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				size = serveCheckList(handler, acceptEncoding).Body.Len()
+			}
+
+			b.ReportMetric(float64(size), "bytes/response")
+		})
+	}
+}
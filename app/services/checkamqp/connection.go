@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkamqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// connection is a connection to an AMQP broker that is re-established after it was lost.
+// The broker is dialed lazily, by the first publish after the connection was lost.
+type connection struct {
+	url         string
+	exchange    string
+	dialTimeout time.Duration
+
+	mx      sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+func newConnection(url string, exchange string, dialTimeout time.Duration) *connection {
+	return &connection{
+		url:         url,
+		exchange:    exchange,
+		dialTimeout: dialTimeout,
+	}
+}
+
+// Publish publishes the message to the exchange and waits until the broker confirmed it.
+// If the connection was lost, the message isn't published and the connection is re-established
+// by the next call, the caller is expected to retry.
+func (c *connection) Publish(ctx context.Context, routingKey string, msg amqp.Publishing) error {
+	channel, err := c.getChannel()
+	if err != nil {
+		return err
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, c.exchange, routingKey, false, false, msg)
+	if err != nil {
+		c.reset(channel)
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for the broker to confirm the message: %w", err)
+	}
+
+	if !acked {
+		return fmt.Errorf("broker rejected message %s", msg.MessageId)
+	}
+
+	return nil
+}
+
+// Close closes the connection to the broker.
+func (c *connection) Close() error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.closeLocked()
+}
+
+// getChannel returns the channel of the connection, the broker is dialed if the connection isn't open.
+func (c *connection) getChannel() (*amqp.Channel, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if c.channel != nil && !c.channel.IsClosed() && !c.conn.IsClosed() {
+		return c.channel, nil
+	}
+
+	_ = c.closeLocked()
+
+	conn, err := amqp.DialConfig(c.url, amqp.Config{
+		Dial:       amqp.DefaultDial(c.dialTimeout),
+		Properties: amqp.Table{"connection_name": "gitness-checks"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to amqp broker: %w", err)
+	}
+
+	channel, err := c.openChannel(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	c.conn = conn
+	c.channel = channel
+
+	return channel, nil
+}
+
+func (c *connection) openChannel(conn *amqp.Connection) (*amqp.Channel, error) {
+	channel, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open amqp channel: %w", err)
+	}
+
+	// publisher confirms make sure a message was accepted by the broker before the event is acknowledged.
+	if err = channel.Confirm(false); err != nil {
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	// the exchange is durable, so that it survives broker restarts together with the persistent messages.
+	err = channel.ExchangeDeclare(c.exchange, amqp.ExchangeTopic, true, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to declare exchange %q: %w", c.exchange, err)
+	}
+
+	return channel, nil
+}
+
+// reset closes the connection if it still uses the provided channel,
+// so that the next publish establishes a new connection.
+func (c *connection) reset(channel *amqp.Channel) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if c.channel == channel {
+		_ = c.closeLocked()
+	}
+}
+
+func (c *connection) closeLocked() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	if errors.Is(err, amqp.ErrClosed) {
+		err = nil
+	}
+
+	c.conn = nil
+	c.channel = nil
+
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkamqp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const contentTypeJSON = "application/json"
+
+// statusChangedMessage is the body of the messages published for status check status changes.
+type statusChangedMessage struct {
+	RepoID     int64  `json:"repo_id"`
+	CheckID    int64  `json:"check_id"`
+	CommitSHA  string `json:"commit_sha"`
+	Identifier string `json:"identifier"`
+	OldStatus  string `json:"old_status"`
+	NewStatus  string `json:"new_status"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+func (p *AMQPCheckPublisher) handleEventStatusChanged(
+	ctx context.Context,
+	event *events.Event[*checkevents.StatusChangedPayload],
+) error {
+	routingKey, msg, err := statusChangedPublishing(event)
+	if err != nil {
+		return events.NewDiscardEventError(err)
+	}
+
+	if err = p.conn.Publish(ctx, routingKey, msg); err != nil {
+		return fmt.Errorf("failed to publish status check status change to amqp broker: %w", err)
+	}
+
+	return nil
+}
+
+// statusChangedPublishing returns the routing key and the message of a status check status change.
+// The routing key has the format "gitness.checks.{repoID}.{status}".
+// The message ID is the ID of the event, so that consumers can detect messages published again after a retry,
+// and the correlation ID is the ID of the status check, so that they can correlate its status changes.
+func statusChangedPublishing(
+	event *events.Event[*checkevents.StatusChangedPayload],
+) (string, amqp.Publishing, error) {
+	body, err := json.Marshal(statusChangedMessage{
+		RepoID:     event.Payload.RepoID,
+		CheckID:    event.Payload.CheckID,
+		CommitSHA:  event.Payload.CommitSHA,
+		Identifier: event.Payload.Identifier,
+		OldStatus:  string(event.Payload.OldStatus),
+		NewStatus:  string(event.Payload.NewStatus),
+		Timestamp:  event.Timestamp.UnixMilli(),
+	})
+	if err != nil {
+		return "", amqp.Publishing{}, fmt.Errorf("failed to marshal status check status change: %w", err)
+	}
+
+	routingKey := fmt.Sprintf("gitness.checks.%d.%s", event.Payload.RepoID, event.Payload.NewStatus)
+
+	return routingKey, amqp.Publishing{
+		MessageId:     event.ID,
+		CorrelationId: strconv.FormatInt(event.Payload.CheckID, 10),
+		ContentType:   contentTypeJSON,
+		DeliveryMode:  amqp.Persistent,
+		Timestamp:     event.Timestamp,
+		Type:          string(checkevents.StatusChangedEvent),
+		AppId:         "gitness",
+		Body:          body,
+	}, nil
+}
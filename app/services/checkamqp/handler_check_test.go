@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkamqp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestStatusChangedPublishing(t *testing.T) {
+	timestamp := time.UnixMilli(1700000000000)
+	event := &events.Event[*checkevents.StatusChangedPayload]{
+		ID:        "1700000000000-0",
+		Timestamp: timestamp,
+		Payload: &checkevents.StatusChangedPayload{
+			RepoID:     42,
+			CheckID:    7,
+			CommitSHA:  "1234567890123456789012345678901234567890",
+			Identifier: "build",
+			OldStatus:  enum.CheckStatusRunning,
+			NewStatus:  enum.CheckStatusFailure,
+		},
+	}
+
+	routingKey, msg, err := statusChangedPublishing(event)
+	if err != nil {
+		t.Fatalf("statusChangedPublishing() error = %v", err)
+	}
+
+	if want := "gitness.checks.42.failure"; routingKey != want {
+		t.Errorf("routing key = %q, want %q", routingKey, want)
+	}
+
+	if msg.MessageId != event.ID {
+		t.Errorf("message ID = %q, want %q", msg.MessageId, event.ID)
+	}
+	if msg.CorrelationId != "7" {
+		t.Errorf("correlation ID = %q, want %q", msg.CorrelationId, "7")
+	}
+	if msg.ContentType != "application/json" {
+		t.Errorf("content type = %q, want %q", msg.ContentType, "application/json")
+	}
+	if msg.DeliveryMode != amqp.Persistent {
+		t.Errorf("delivery mode = %d, want persistent", msg.DeliveryMode)
+	}
+
+	var body statusChangedMessage
+	if err = json.Unmarshal(msg.Body, &body); err != nil {
+		t.Fatalf("failed to unmarshal message body: %v", err)
+	}
+
+	want := statusChangedMessage{
+		RepoID:     42,
+		CheckID:    7,
+		CommitSHA:  "1234567890123456789012345678901234567890",
+		Identifier: "build",
+		OldStatus:  "running",
+		NewStatus:  "failure",
+		Timestamp:  timestamp.UnixMilli(),
+	}
+	if body != want {
+		t.Errorf("message body = %+v, want %+v", body, want)
+	}
+}
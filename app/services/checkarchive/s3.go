@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkarchive
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// s3Storage stores the archive files in an S3 bucket.
+type s3Storage struct {
+	bucket  string
+	session *session.Session
+}
+
+func newS3Storage(bucket, endpoint string, pathStyle bool) (*s3Storage, error) {
+	disableSSL := false
+
+	if endpoint != "" {
+		disableSSL = !strings.HasPrefix(endpoint, "https://")
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		DisableSSL:       aws.Bool(disableSSL),
+		S3ForcePathStyle: aws.Bool(pathStyle),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Storage{
+		bucket:  bucket,
+		session: sess,
+	}, nil
+}
+
+func (s *s3Storage) Upload(ctx context.Context, key string, r io.Reader) error {
+	uploader := s3manager.NewUploader(s.session)
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:             aws.String("private"),
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		Body:            r,
+		ContentType:     aws.String("application/x-ndjson"),
+		ContentEncoding: aws.String("gzip"),
+	})
+	return err
+}
+
+func (s *s3Storage) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	svc := s3.New(s.session)
+	out, err := svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkarchive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "gitness:checks:archive"
+	jobCron        = "21 2 * * *" // At 02:21 every day.
+	jobMaxDuration = time.Hour
+
+	archiveBatchSize = 1000
+
+	// archivePeriod is the time range covered by a single archive file.
+	archivePeriod = 24 * time.Hour
+)
+
+// ErrNotConfigured is returned if the archive storage isn't configured.
+var ErrNotConfigured = errors.New("status check archive storage is not configured")
+
+type Config struct {
+	// Enabled enables the nightly archiving of old status check results.
+	Enabled bool
+	// RetentionTime is the duration after which status check results are moved to the archive.
+	RetentionTime time.Duration
+
+	Bucket    string
+	Prefix    string
+	Endpoint  string
+	PathStyle bool
+}
+
+// storage stores the archive files.
+type storage interface {
+	Upload(ctx context.Context, key string, r io.Reader) error
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// Archiver moves old status check results out of the database into gzipped JSONL files in S3.
+// Each file holds the status check results of a repository created during one day (UTC)
+// and is recorded in the check_archives table, so it can be restored for audits.
+type Archiver struct {
+	config       Config
+	scheduler    *job.Scheduler
+	tx           dbtx.Transactor
+	checkStore   store.CheckStore
+	archiveStore store.CheckArchiveStore
+	storage      storage
+}
+
+// Register schedules the recurring status check archive job.
+func (a *Archiver) Register(ctx context.Context) error {
+	if !a.config.Enabled {
+		return nil
+	}
+
+	err := a.scheduler.AddRecurring(ctx, jobType, jobType, jobCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for status check archiving: %w", err)
+	}
+
+	return nil
+}
+
+// Handle archives all status check results that weren't updated within the retention time.
+func (a *Archiver) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if a.storage == nil {
+		return "", ErrNotConfigured
+	}
+
+	before := time.Now().Add(-a.config.RetentionTime).UnixMilli()
+
+	var total int
+	for {
+		checks, err := a.checkStore.ListUpdatedBefore(ctx, before, archiveBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list status checks to archive: %w", err)
+		}
+
+		for _, group := range groupByPeriod(checks) {
+			if err := a.archive(ctx, group); err != nil {
+				return "", err
+			}
+
+			total += len(group.checks)
+		}
+
+		if len(checks) < archiveBatchSize {
+			break
+		}
+	}
+
+	result := "no status checks to archive"
+	if total > 0 {
+		result = fmt.Sprintf("archived %d status checks", total)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// Restore returns the status check results stored in an archive file.
+func (a *Archiver) Restore(ctx context.Context, archive *types.CheckArchive) ([]types.ArchivedCheck, error) {
+	if a.storage == nil {
+		return nil, ErrNotConfigured
+	}
+
+	rc, err := a.storage.Download(ctx, archive.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download status check archive: %w", err)
+	}
+
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	return readArchive(rc)
+}
+
+// archiveGroup holds the status checks of a repository created in the same archive period.
+type archiveGroup struct {
+	repoID      int64
+	periodStart time.Time
+	checks      []types.Check
+}
+
+// groupByPeriod groups the status checks by repository and archive period, preserving their order.
+func groupByPeriod(checks []types.Check) []*archiveGroup {
+	type groupKey struct {
+		repoID      int64
+		periodStart int64
+	}
+
+	var groups []*archiveGroup
+	index := make(map[groupKey]*archiveGroup)
+
+	for _, check := range checks {
+		periodStart := time.UnixMilli(check.Created).UTC().Truncate(archivePeriod)
+		key := groupKey{repoID: check.RepoID, periodStart: periodStart.UnixMilli()}
+
+		group, ok := index[key]
+		if !ok {
+			group = &archiveGroup{repoID: check.RepoID, periodStart: periodStart}
+			index[key] = group
+			groups = append(groups, group)
+		}
+
+		group.checks = append(group.checks, check)
+	}
+
+	return groups
+}
+
+// archive uploads the status checks of the group, records the archive file and deletes the status checks.
+func (a *Archiver) archive(ctx context.Context, group *archiveGroup) error {
+	count, err := a.archiveStore.CountByPeriod(ctx, group.repoID, group.periodStart.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to count existing status check archives: %w", err)
+	}
+
+	data, err := writeArchive(group.checks)
+	if err != nil {
+		return fmt.Errorf("failed to write status check archive: %w", err)
+	}
+
+	key := archiveKey(a.config.Prefix, group.repoID, group.periodStart, count)
+
+	if err = a.storage.Upload(ctx, key, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to upload status check archive: %w", err)
+	}
+
+	ids := make([]int64, len(group.checks))
+	for i := range group.checks {
+		ids[i] = group.checks[i].ID
+	}
+
+	return a.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := a.archiveStore.Create(ctx, &types.CheckArchive{
+			Created:     time.Now().UnixMilli(),
+			RepoID:      group.repoID,
+			Bucket:      a.config.Bucket,
+			Key:         key,
+			PeriodStart: group.periodStart.UnixMilli(),
+			PeriodEnd:   group.periodStart.Add(archivePeriod).UnixMilli(),
+			CheckCount:  len(group.checks),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create status check archive record: %w", err)
+		}
+
+		if _, err = a.checkStore.DeleteByIDs(ctx, ids); err != nil {
+			return fmt.Errorf("failed to delete archived status checks: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// archiveKey returns the object key of an archive file, e.g. checks/42/2024/01/31.jsonl.gz.
+// Status checks of the same period archived later, for example because they were reported late,
+// are stored in additional files with a sequence number suffix, e.g. checks/42/2024/01/31-1.jsonl.gz.
+func archiveKey(prefix string, repoID int64, periodStart time.Time, seq int) string {
+	name := periodStart.Format("02")
+	if seq > 0 {
+		name = fmt.Sprintf("%s-%d", name, seq)
+	}
+
+	return path.Join(prefix, "checks", fmt.Sprint(repoID), periodStart.Format("2006/01"), name+".jsonl.gz")
+}
+
+// writeArchive encodes the status checks as gzipped JSON lines.
+func writeArchive(checks []types.Check) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	enc := json.NewEncoder(zw)
+
+	for _, check := range checks {
+		err := enc.Encode(types.ArchivedCheck{
+			CommitSHA: check.CommitSHA,
+			CreatedBy: check.CreatedBy,
+			Check:     check,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode status check: %w", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress status checks: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readArchive decodes gzipped JSON lines of archived status checks.
+func readArchive(r io.Reader) ([]types.ArchivedCheck, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress status check archive: %w", err)
+	}
+
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	var checks []types.ArchivedCheck
+
+	dec := json.NewDecoder(bufio.NewReader(zr))
+	for {
+		var check types.ArchivedCheck
+		err := dec.Decode(&check)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode archived status check: %w", err)
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
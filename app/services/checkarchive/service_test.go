@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkarchive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type noTx struct{}
+
+func (noTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type memCheckStore struct {
+	store.CheckStore
+	checks []types.Check
+}
+
+func (s *memCheckStore) ListUpdatedBefore(_ context.Context, before int64, limit int) ([]types.Check, error) {
+	var result []types.Check
+	for _, check := range s.checks {
+		if check.Updated < before && len(result) < limit {
+			result = append(result, check)
+		}
+	}
+	return result, nil
+}
+
+func (s *memCheckStore) DeleteByIDs(_ context.Context, ids []int64) (int64, error) {
+	deleted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+
+	var kept []types.Check
+	for _, check := range s.checks {
+		if !deleted[check.ID] {
+			kept = append(kept, check)
+		}
+	}
+
+	n := int64(len(s.checks) - len(kept))
+	s.checks = kept
+
+	return n, nil
+}
+
+type memArchiveStore struct {
+	store.CheckArchiveStore
+	archives []*types.CheckArchive
+}
+
+func (s *memArchiveStore) CountByPeriod(_ context.Context, repoID int64, periodStart int64) (int, error) {
+	var count int
+	for _, archive := range s.archives {
+		if archive.RepoID == repoID && archive.PeriodStart == periodStart {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *memArchiveStore) Create(_ context.Context, archive *types.CheckArchive) error {
+	archive.ID = int64(len(s.archives) + 1)
+	s.archives = append(s.archives, archive)
+	return nil
+}
+
+type memStorage struct {
+	files map[string][]byte
+}
+
+func (s *memStorage) Upload(_ context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.files[key] = data
+	return nil
+}
+
+func (s *memStorage) Download(_ context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.files[key])), nil
+}
+
+func TestArchiver_Handle(t *testing.T) {
+	day := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	old := day.Add(10 * time.Hour).UnixMilli()
+	recent := time.Now().UnixMilli()
+
+	checkStore := &memCheckStore{
+		checks: []types.Check{
+			{ID: 1, RepoID: 42, CommitSHA: "abc", Identifier: "build", Status: enum.CheckStatusSuccess,
+				Created: old, Updated: old},
+			{ID: 2, RepoID: 42, CommitSHA: "abc", Identifier: "test", Status: enum.CheckStatusFailure,
+				Created: old + 1, Updated: old + 1},
+			{ID: 3, RepoID: 7, CommitSHA: "def", Identifier: "build", Status: enum.CheckStatusSuccess,
+				Created: old, Updated: old},
+			{ID: 4, RepoID: 42, CommitSHA: "ghi", Identifier: "build", Status: enum.CheckStatusRunning,
+				Created: recent, Updated: recent},
+			// created long ago, but still being updated.
+			{ID: 5, RepoID: 42, CommitSHA: "jkl", Identifier: "build", Status: enum.CheckStatusRunning,
+				Created: old, Updated: recent},
+		},
+	}
+	archiveStore := &memArchiveStore{
+		archives: []*types.CheckArchive{{RepoID: 7, PeriodStart: day.UnixMilli()}},
+	}
+	storage := &memStorage{files: map[string][]byte{}}
+
+	archiver := &Archiver{
+		config:       Config{RetentionTime: 24 * time.Hour, Bucket: "archive", Prefix: "gitness"},
+		tx:           noTx{},
+		checkStore:   checkStore,
+		archiveStore: archiveStore,
+		storage:      storage,
+	}
+
+	if _, err := archiver.Handle(context.Background(), "", nil); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if len(checkStore.checks) != 2 || checkStore.checks[0].ID != 4 || checkStore.checks[1].ID != 5 {
+		t.Errorf("remaining checks = %+v, want only the recently updated checks", checkStore.checks)
+	}
+
+	if _, ok := storage.files["gitness/checks/7/2024/01/31-1.jsonl.gz"]; !ok {
+		t.Errorf("expected a sequenced archive file for a period that was already archived, got %v", storage.files)
+	}
+
+	archive := archiveStore.archives[1]
+	if archive.Key != "gitness/checks/42/2024/01/31.jsonl.gz" || archive.CheckCount != 2 ||
+		archive.PeriodEnd-archive.PeriodStart != archivePeriod.Milliseconds() {
+		t.Fatalf("unexpected archive record %+v", archive)
+	}
+
+	restored, err := archiver.Restore(context.Background(), archive)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if len(restored) != 2 || restored[1].CommitSHA != "abc" || restored[1].Check.Identifier != "test" ||
+		restored[1].Check.Status != enum.CheckStatusFailure {
+		t.Errorf("Restore() = %+v", restored)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkarchive
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/services/checkreplication"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideArchiver,
+)
+
+func ProvideArchiver(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	tx dbtx.Transactor,
+	checkStore *checkreplication.ReplicatedCheckStore,
+	archiveStore store.CheckArchiveStore,
+) (*Archiver, error) {
+	archiver := &Archiver{
+		config:       config,
+		scheduler:    scheduler,
+		tx:           tx,
+		checkStore:   checkStore,
+		archiveStore: archiveStore,
+	}
+
+	if config.Bucket != "" {
+		s3, err := newS3Storage(config.Bucket, config.Endpoint, config.PathStyle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create status check archive storage: %w", err)
+		}
+
+		archiver.storage = s3
+	}
+
+	if config.Enabled && archiver.storage == nil {
+		return nil, fmt.Errorf("status check archiving requires an S3 bucket: %w", ErrNotConfigured)
+	}
+
+	if err := executor.Register(jobType, archiver); err != nil {
+		return nil, err
+	}
+
+	return archiver, nil
+}
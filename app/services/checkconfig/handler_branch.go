@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// ValidationCheckIdentifier is the identifier of the status check
+	// that reports the validation result of the configuration file.
+	ValidationCheckIdentifier = "gitness/config-validation"
+
+	branchRefPrefix = "refs/heads/"
+)
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.applyConfigFile(ctx, event.Payload.RepoID, event.Payload.PrincipalID, event.Payload.Ref, event.Payload.SHA)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.applyConfigFile(ctx, event.Payload.RepoID, event.Payload.PrincipalID, event.Payload.Ref,
+		event.Payload.NewSHA)
+}
+
+// applyConfigFile validates the configuration file of the pushed commit and reports the result as a status check.
+// Valid configuration files pushed to the default branch are stored as the status check configs of the repository.
+// Status checks that aren't listed in the file keep their configuration.
+func (s *Service) applyConfigFile(
+	ctx context.Context,
+	repoID int64,
+	principalID int64,
+	ref string,
+	commitSHA string,
+) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository in db: %w", err)
+	}
+
+	file, err := s.parser.Parse(ctx, repo, commitSHA)
+	if errors.Is(err, ErrFileNotFound) {
+		return nil
+	}
+	if errors.IsInvalidArgument(err) {
+		return s.reportValidation(ctx, repo, principalID, commitSHA, enum.CheckStatusFailure, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse status check configuration file: %w", err)
+	}
+
+	if strings.TrimPrefix(ref, branchRefPrefix) != repo.DefaultBranch {
+		return s.reportValidation(ctx, repo, principalID, commitSHA, enum.CheckStatusSuccess,
+			fmt.Sprintf("%s is valid.", FilePath))
+	}
+
+	now := time.Now().UnixMilli()
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, check := range file.Checks {
+			err := s.checkConfigStore.Upsert(ctx, &types.CheckConfig{
+				RepoID:      repo.ID,
+				Identifier:  check.Identifier,
+				CreatedBy:   principalID,
+				Created:     now,
+				Updated:     now,
+				RetryPolicy: *check.RetryPolicy,
+				SLA:         check.SLA,
+				URLTemplate: check.URLTemplate,
+				Required:    check.Required,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to upsert config of status check %q: %w", check.Identifier, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.reportValidation(ctx, repo, principalID, commitSHA, enum.CheckStatusSuccess,
+		fmt.Sprintf("Applied the configuration of %d status checks from %s.", len(file.Checks), FilePath))
+}
+
+func (s *Service) reportValidation(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	commitSHA string,
+	status enum.CheckStatus,
+	summary string,
+) error {
+	now := time.Now().UnixMilli()
+
+	check := &types.Check{
+		CreatedBy:  principalID,
+		Created:    now,
+		Updated:    now,
+		RepoID:     repo.ID,
+		CommitSHA:  commitSHA,
+		Identifier: ValidationCheckIdentifier,
+		Status:     status,
+		Summary:    summary,
+		Metadata:   []byte("{}"),
+		Payload:    types.CheckPayload{Kind: enum.CheckPayloadKindEmpty, Data: []byte("{}")},
+		Started:    now,
+		Ended:      now,
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		existingCheck, err := s.checkStore.FindInNamespace(ctx, repo.ID, commitSHA,
+			types.CheckNamespaceDefault, ValidationCheckIdentifier)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find existing status check configuration file validation: %w", err)
+		}
+
+		err = s.checkStore.Upsert(ctx, check)
+		if err != nil {
+			return fmt.Errorf("failed to report status check configuration file validation: %w", err)
+		}
+
+		// an identical report doesn't change the status check.
+		if check.Deduplicated {
+			return nil
+		}
+
+		err = s.checkAuditStore.Create(ctx, types.NewCheckAuditEntry(principalID, existingCheck, check))
+		if err != nil {
+			return fmt.Errorf("failed to create status check audit entry: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FilePath is the path of the declarative status check configuration file in the repository.
+	FilePath = ".gitness/checks.yaml"
+
+	// SchemaVersion is the only supported version of the configuration file schema.
+	SchemaVersion = 1
+
+	// maxFileSize is the size limit of the configuration file.
+	maxFileSize = 256 * 1024
+
+	// maxFileChecks is the maximum number of status checks that can be configured in the file.
+	maxFileChecks = 100
+)
+
+// ErrFileNotFound is returned if the commit doesn't contain the configuration file.
+var ErrFileNotFound = errors.New("status check configuration file not found")
+
+// File is the declarative status check configuration of a repository.
+type File struct {
+	// Version is the version of the schema the file adheres to.
+	Version int         `yaml:"version"`
+	Checks  []FileCheck `yaml:"checks"`
+}
+
+// FileCheck is the configuration of a single status check in the configuration file.
+type FileCheck struct {
+	Identifier  string             `yaml:"identifier"`
+	RetryPolicy *types.RetryPolicy `yaml:"retry_policy"`
+	SLA         *types.CheckSLA    `yaml:"sla"`
+	URLTemplate string             `yaml:"url_template"`
+	Required    *bool              `yaml:"required"`
+}
+
+// Parser reads and validates the status check configuration file of a repository.
+type Parser struct {
+	git git.Interface
+}
+
+func NewParser(git git.Interface) *Parser {
+	return &Parser{git: git}
+}
+
+// Parse returns the validated configuration file of the provided commit.
+// It returns ErrFileNotFound if there is no configuration file and an invalid argument error
+// if the configuration file is invalid.
+func (p *Parser) Parse(ctx context.Context, repo *types.Repository, commitSHA string) (*File, error) {
+	readParams := git.CreateReadParams(repo)
+
+	node, err := p.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+		Path:       FilePath,
+	})
+	if errors.IsNotFound(err) {
+		return nil, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check configuration file node: %w", err)
+	}
+
+	if node.Node.Mode != git.TreeNodeModeFile {
+		return nil, errors.InvalidArgument("%s must be a file", FilePath)
+	}
+
+	output, err := p.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check configuration file content: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	if output.Size > maxFileSize {
+		return nil, errors.InvalidArgument("%s exceeds the maximum supported size of %d bytes",
+			FilePath, maxFileSize)
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status check configuration file content: %w", err)
+	}
+
+	return ParseFile(content)
+}
+
+// ParseFile parses and validates the content of a configuration file.
+// Unknown fields are rejected, so that typos don't go unnoticed.
+func ParseFile(content []byte) (*File, error) {
+	file := &File{}
+
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+
+	if err := dec.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.InvalidArgument("%s is not valid: %s", FilePath, err)
+	}
+
+	if err := file.Sanitize(); err != nil {
+		return nil, errors.InvalidArgument("%s is not valid: %s", FilePath, err)
+	}
+
+	return file, nil
+}
+
+// Sanitize validates the configuration file and fills in the defaults of the unset fields.
+func (f *File) Sanitize() error {
+	if f.Version != SchemaVersion {
+		return fmt.Errorf("unsupported version %d, the supported version is %d", f.Version, SchemaVersion)
+	}
+
+	if len(f.Checks) > maxFileChecks {
+		return fmt.Errorf("at most %d status checks can be configured", maxFileChecks)
+	}
+
+	identifiers := make(map[string]struct{}, len(f.Checks))
+	for i := range f.Checks {
+		check := &f.Checks[i]
+
+		if !types.IsValidCheckIdentifier(check.Identifier) {
+			return fmt.Errorf("identifier %q of check #%d must match the regular expression: %s",
+				check.Identifier, i+1, types.CheckIdentifierRegexp)
+		}
+
+		if _, ok := identifiers[check.Identifier]; ok {
+			return fmt.Errorf("status check %q is configured more than once", check.Identifier)
+		}
+		identifiers[check.Identifier] = struct{}{}
+
+		if check.RetryPolicy == nil {
+			check.RetryPolicy = &types.RetryPolicy{}
+		}
+
+		if err := check.RetryPolicy.Sanitize(); err != nil {
+			return fmt.Errorf("retry policy of status check %q: %w", check.Identifier, err)
+		}
+
+		if check.URLTemplate != "" {
+			if err := types.ValidateCheckURLTemplate(check.URLTemplate); err != nil {
+				return fmt.Errorf("status check %q: %w", check.Identifier, err)
+			}
+		}
+
+		if check.SLA == nil {
+			continue
+		}
+
+		if err := check.SLA.Validate(); err != nil {
+			return fmt.Errorf("sla of status check %q: %w", check.Identifier, err)
+		}
+	}
+
+	return nil
+}
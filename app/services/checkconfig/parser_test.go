@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconfig
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseFile(t *testing.T) {
+	content := []byte(`
+version: 1
+checks:
+  - identifier: build
+    retry_policy:
+      max_attempts: 3
+      backoff_seconds: 30
+  - identifier: e2e
+    url_template: https://ci.example.com/builds/{{.ExternalID}}
+    sla:
+      max_duration_seconds: 1800
+      alert_threshold_fraction: 0.1
+`)
+
+	file, err := ParseFile(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(file.Checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(file.Checks))
+	}
+
+	build := file.Checks[0]
+	if build.RetryPolicy.MaxAttempts != 3 || build.RetryPolicy.BackoffSeconds != 30 || build.SLA != nil {
+		t.Errorf("unexpected config of build: %+v", build)
+	}
+	if len(build.RetryPolicy.RetryOnStatuses) != 2 ||
+		build.RetryPolicy.RetryOnStatuses[0] != enum.CheckStatusFailure {
+		t.Errorf("expected default retry statuses, got %v", build.RetryPolicy.RetryOnStatuses)
+	}
+
+	e2e := file.Checks[1]
+	if e2e.RetryPolicy.MaxAttempts != 1 || e2e.SLA == nil || e2e.SLA.MaxDurationSeconds != 1800 ||
+		e2e.URLTemplate != "https://ci.example.com/builds/{{.ExternalID}}" {
+		t.Errorf("unexpected config of e2e: %+v", e2e)
+	}
+}
+
+func TestParseFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "empty",
+			content: ``,
+		},
+		{
+			name:    "unsupported version",
+			content: "version: 2\n",
+		},
+		{
+			name:    "unknown field",
+			content: "version: 1\nauto_merge: true\n",
+		},
+		{
+			name:    "invalid identifier",
+			content: "version: 1\nchecks:\n  - identifier: 'build #1'\n",
+		},
+		{
+			name:    "duplicate identifier",
+			content: "version: 1\nchecks:\n  - identifier: build\n  - identifier: build\n",
+		},
+		{
+			name:    "invalid retry policy",
+			content: "version: 1\nchecks:\n  - identifier: build\n    retry_policy:\n      max_attempts: 20\n",
+		},
+		{
+			name:    "invalid sla",
+			content: "version: 1\nchecks:\n  - identifier: build\n    sla:\n      max_duration_seconds: 0\n",
+		},
+		{
+			name:    "invalid url template",
+			content: "version: 1\nchecks:\n  - identifier: build\n    url_template: 'https://ci/{{.ID'\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseFile([]byte(test.content))
+			if !errors.IsInvalidArgument(err) {
+				t.Errorf("expected invalid argument error, got %v", err)
+			}
+		})
+	}
+}
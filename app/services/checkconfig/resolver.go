@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// Resolver merges the status check configurations of a repository with the status check policies
+// of the spaces above it. The configuration of the repository takes precedence,
+// the policies of the spaces fill the gaps, the nearest space first.
+type Resolver struct {
+	checkConfigStore store.CheckConfigStore
+	spacePolicyStore store.SpaceCheckPolicyStore
+	spaceStore       store.SpaceStore
+}
+
+func NewResolver(
+	checkConfigStore store.CheckConfigStore,
+	spacePolicyStore store.SpaceCheckPolicyStore,
+	spaceStore store.SpaceStore,
+) *Resolver {
+	return &Resolver{
+		checkConfigStore: checkConfigStore,
+		spacePolicyStore: spacePolicyStore,
+		spaceStore:       spaceStore,
+	}
+}
+
+// AncestorSpaceIDs returns the IDs of the space and all its ancestors ordered by depth,
+// starting with the space itself and ending with the root space.
+func (r *Resolver) AncestorSpaceIDs(ctx context.Context, spaceID int64) ([]int64, error) {
+	ancestors, err := r.spaceStore.GetAncestorsData(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space ancestors: %w", err)
+	}
+
+	// the ancestors aren't returned in any particular order, so the parent chain is followed explicitly.
+	parents := make(map[int64]int64, len(ancestors))
+	for _, ancestor := range ancestors {
+		parents[ancestor.ID] = ancestor.ParentID
+	}
+
+	spaceIDs := make([]int64, 0, len(ancestors))
+	for id := spaceID; id != 0 && len(spaceIDs) < len(ancestors); id = parents[id] {
+		if _, ok := parents[id]; !ok {
+			break
+		}
+
+		spaceIDs = append(spaceIDs, id)
+	}
+
+	return spaceIDs, nil
+}
+
+// RequiredChecks returns the sorted identifiers of the status checks required to merge
+// pull requests of the repository.
+func (r *Resolver) RequiredChecks(ctx context.Context, repo *types.Repository) ([]string, error) {
+	configs, err := r.checkConfigStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check configs: %w", err)
+	}
+
+	// required holds the decision of the nearest level that sets it for each status check.
+	required := make(map[string]bool)
+	for _, config := range configs {
+		if config.Required != nil {
+			required[config.Identifier] = *config.Required
+		}
+	}
+
+	spaceIDs, err := r.AncestorSpaceIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spaceID := range spaceIDs {
+		policies, err := r.spacePolicyStore.List(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list space status check policies: %w", err)
+		}
+
+		for _, policy := range policies {
+			if _, ok := required[policy.Identifier]; ok || policy.Required == nil {
+				continue
+			}
+
+			required[policy.Identifier] = *policy.Required
+		}
+	}
+
+	identifiers := make([]string, 0, len(required))
+	for identifier, ok := range required {
+		if ok {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	sort.Strings(identifiers)
+
+	return identifiers, nil
+}
+
+// AreRequiredChecksPassing returns true if all status checks required for the repository succeeded.
+// Otherwise, it returns the identifiers of the required status checks that are missing or didn't succeed.
+// A renamed status check satisfies the requirement of its old identifier.
+func (r *Resolver) AreRequiredChecksPassing(
+	ctx context.Context,
+	repo *types.Repository,
+	checkResults []types.CheckResult,
+	checkAliases map[string]string,
+) (bool, []string, error) {
+	requiredIdentifiers, err := r.RequiredChecks(ctx, repo)
+	if err != nil {
+		return false, nil, err
+	}
+
+	results := make(map[string]types.CheckResult, len(checkResults))
+	for _, result := range checkResults {
+		results[result.Identifier] = result
+	}
+
+	var failing []string
+	for _, identifier := range requiredIdentifiers {
+		result, ok := results[identifier]
+		if newIdentifier, renamed := checkAliases[identifier]; renamed {
+			if renamedResult, reported := results[newIdentifier]; reported {
+				result, ok = renamedResult, true
+			}
+		}
+
+		if !ok || !result.IsSatisfied() {
+			failing = append(failing, identifier)
+		}
+	}
+
+	return len(failing) == 0, failing, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconfig
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type memCheckConfigStore struct {
+	store.CheckConfigStore
+	configs []*types.CheckConfig
+}
+
+func (s *memCheckConfigStore) List(context.Context, int64) ([]*types.CheckConfig, error) {
+	return s.configs, nil
+}
+
+type memSpacePolicyStore struct {
+	store.SpaceCheckPolicyStore
+	policies map[int64][]*types.SpaceCheckPolicy
+}
+
+func (s *memSpacePolicyStore) List(_ context.Context, spaceID int64) ([]*types.SpaceCheckPolicy, error) {
+	return s.policies[spaceID], nil
+}
+
+type memSpaceStore struct {
+	store.SpaceStore
+	ancestors []types.SpaceParentData
+}
+
+func (s *memSpaceStore) GetAncestorsData(context.Context, int64) ([]types.SpaceParentData, error) {
+	return s.ancestors, nil
+}
+
+func TestResolver_AreRequiredChecksPassing(t *testing.T) {
+	required := func(v bool) *bool { return &v }
+
+	// the repository is in space 3, which is in space 2, which is in the root space 1.
+	spaceStore := &memSpaceStore{ancestors: []types.SpaceParentData{
+		{ID: 1},
+		{ID: 3, ParentID: 2},
+		{ID: 2, ParentID: 1},
+	}}
+
+	policyStore := &memSpacePolicyStore{policies: map[int64][]*types.SpaceCheckPolicy{
+		1: {
+			{Identifier: "build", Required: required(true)},
+			{Identifier: "lint", Required: required(true)},
+			{Identifier: "e2e", Required: required(true)},
+			{Identifier: "sast", Required: required(true)},
+		},
+		2: {
+			{Identifier: "e2e", Required: required(false)},
+			{Identifier: "sast"},
+		},
+	}}
+
+	configStore := &memCheckConfigStore{configs: []*types.CheckConfig{
+		{Identifier: "lint", Required: required(false)},
+		{Identifier: "build"},
+		{Identifier: "deploy", Required: required(true)},
+	}}
+
+	resolver := NewResolver(configStore, policyStore, spaceStore)
+	repo := &types.Repository{ID: 42, ParentID: 3}
+
+	spaceIDs, err := resolver.AncestorSpaceIDs(context.Background(), repo.ParentID)
+	if err != nil {
+		t.Fatalf("AncestorSpaceIDs() error = %v", err)
+	}
+	if !slices.Equal(spaceIDs, []int64{3, 2, 1}) {
+		t.Errorf("AncestorSpaceIDs() = %v, want [3 2 1]", spaceIDs)
+	}
+
+	// lint is overridden by the repository, e2e by the nearer space,
+	// build and sast don't set it, so the root space fills the gap.
+	identifiers, err := resolver.RequiredChecks(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("RequiredChecks() error = %v", err)
+	}
+	if want := []string{"build", "deploy", "sast"}; !slices.Equal(identifiers, want) {
+		t.Errorf("RequiredChecks() = %v, want %v", identifiers, want)
+	}
+
+	passing, failing, err := resolver.AreRequiredChecksPassing(context.Background(), repo, []types.CheckResult{
+		{Identifier: "ci/build", Status: enum.CheckStatusSuccess},
+		{Identifier: "deploy", Status: enum.CheckStatusFailure},
+		{Identifier: "lint", Status: enum.CheckStatusFailure},
+	}, map[string]string{"build": "ci/build"})
+	if err != nil {
+		t.Fatalf("AreRequiredChecksPassing() error = %v", err)
+	}
+	if passing || !slices.Equal(failing, []string{"deploy", "sast"}) {
+		t.Errorf("AreRequiredChecksPassing() = %t, %v, want false, [deploy sast]", passing, failing)
+	}
+}
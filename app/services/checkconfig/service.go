@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+)
+
+const groupGitEvents = "gitness:checkconfig"
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service applies the status check configuration file of a repository whenever it's pushed.
+// The validation result of the file is reported as a status check of the pushed commit.
+type Service struct {
+	config           Config
+	parser           *Parser
+	tx               dbtx.Transactor
+	repoStore        store.RepoStore
+	checkStore       store.CheckStore
+	checkConfigStore store.CheckConfigStore
+	checkAuditStore  store.CheckAuditStore
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	parser *Parser,
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	checkStore store.CheckStore,
+	checkConfigStore store.CheckConfigStore,
+	checkAuditStore store.CheckAuditStore,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided status check config file service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:           config,
+		parser:           parser,
+		tx:               tx,
+		repoStore:        repoStore,
+		checkStore:       checkStore,
+		checkConfigStore: checkConfigStore,
+		checkAuditStore:  checkAuditStore,
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, groupGitEvents, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for status check config file: %w", err)
+	}
+
+	return service, nil
+}
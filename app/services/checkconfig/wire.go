@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconfig
+
+import (
+	"context"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/checkreplication"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideParser,
+	ProvideResolver,
+	ProvideService,
+)
+
+func ProvideParser(git git.Interface) *Parser {
+	return NewParser(git)
+}
+
+func ProvideResolver(
+	checkConfigStore store.CheckConfigStore,
+	spacePolicyStore store.SpaceCheckPolicyStore,
+	spaceStore store.SpaceStore,
+) *Resolver {
+	return NewResolver(checkConfigStore, spacePolicyStore, spaceStore)
+}
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	parser *Parser,
+	tx dbtx.Transactor,
+	repoStore store.RepoStore,
+	checkStore *checkreplication.ReplicatedCheckStore,
+	checkConfigStore store.CheckConfigStore,
+	checkAuditStore store.CheckAuditStore,
+) (*Service, error) {
+	return NewService(ctx, config, gitReaderFactory, parser, tx, repoStore, checkStore, checkConfigStore,
+		checkAuditStore)
+}
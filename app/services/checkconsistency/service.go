@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconsistency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// Service verifies that the cached status check summaries match the status checks in the database.
+type Service struct {
+	checkStore   store.CheckStore
+	summaryCache store.RepoCheckSummaryCache
+}
+
+func NewService(checkStore store.CheckStore, summaryCache store.RepoCheckSummaryCache) *Service {
+	return &Service{
+		checkStore:   checkStore,
+		summaryCache: summaryCache,
+	}
+}
+
+// VerifyConsistency compares the status check summaries of the commits of a repo maintained by the database
+// and the status check metrics of the repo in the cache of this instance
+// against summaries freshly aggregated from the status checks, and reports the differences.
+func (s *Service) VerifyConsistency(ctx context.Context, repoID int64) (*types.ConsistencyReport, error) {
+	cached, err := s.checkStore.ListSummaries(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check summaries: %w", err)
+	}
+
+	actual, err := s.checkStore.CountSummaries(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count status check summaries: %w", err)
+	}
+
+	report := &types.ConsistencyReport{
+		RepoID:          repoID,
+		CommitSummaries: []types.CheckSummaryDiscrepancy{},
+	}
+
+	commitSHAs := make(map[string]struct{}, len(actual))
+	for commitSHA := range cached {
+		commitSHAs[commitSHA] = struct{}{}
+	}
+	for commitSHA := range actual {
+		commitSHAs[commitSHA] = struct{}{}
+	}
+
+	report.CommitsVerified = len(commitSHAs)
+
+	for commitSHA := range commitSHAs {
+		cachedSummary, inCache := cached[commitSHA]
+		actualSummary, inDB := actual[commitSHA]
+
+		// a summary without any status checks left is equivalent to no summary.
+		if cachedSummary == actualSummary {
+			continue
+		}
+
+		discrepancy := types.CheckSummaryDiscrepancy{CommitSHA: commitSHA}
+		if inCache {
+			discrepancy.Cached = &cachedSummary
+		}
+		if inDB {
+			discrepancy.Actual = &actualSummary
+		}
+
+		report.CommitSummaries = append(report.CommitSummaries, discrepancy)
+	}
+
+	sort.Slice(report.CommitSummaries, func(i, j int) bool {
+		return report.CommitSummaries[i].CommitSHA < report.CommitSummaries[j].CommitSHA
+	})
+
+	cachedRepoSummary, err := s.summaryCache.Get(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached status check metrics: %w", err)
+	}
+
+	// the metrics are recalculated for the period of the cached ones, so only changes since they were cached count.
+	actualRepoSummary, err := s.checkStore.RepoCheckSummary(ctx, repoID, time.UnixMilli(cachedRepoSummary.Since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate status check metrics: %w", err)
+	}
+
+	if *cachedRepoSummary != *actualRepoSummary {
+		report.RepoSummary = &types.RepoCheckSummaryDiscrepancy{
+			Cached: cachedRepoSummary,
+			Actual: actualRepoSummary,
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkconsistency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type memCheckStore struct {
+	store.CheckStore
+	stored  map[string]types.CheckCountSummary
+	counted map[string]types.CheckCountSummary
+	repo    types.RepositoryCheckSummary
+}
+
+func (s *memCheckStore) ListSummaries(context.Context, int64) (map[string]types.CheckCountSummary, error) {
+	return s.stored, nil
+}
+
+func (s *memCheckStore) CountSummaries(context.Context, int64) (map[string]types.CheckCountSummary, error) {
+	return s.counted, nil
+}
+
+func (s *memCheckStore) RepoCheckSummary(
+	_ context.Context,
+	_ int64,
+	from time.Time,
+) (*types.RepositoryCheckSummary, error) {
+	summary := s.repo
+	summary.Since = from.UnixMilli()
+	return &summary, nil
+}
+
+type memSummaryCache struct {
+	summary types.RepositoryCheckSummary
+}
+
+func (c *memSummaryCache) Stats() (int64, int64) { return 0, 0 }
+
+func (c *memSummaryCache) Evict(context.Context, int64) {}
+
+func (c *memSummaryCache) Get(context.Context, int64) (*types.RepositoryCheckSummary, error) {
+	summary := c.summary
+	return &summary, nil
+}
+
+func TestService_VerifyConsistency(t *testing.T) {
+	since := time.Now().Add(-time.Hour).UnixMilli()
+
+	checkStore := &memCheckStore{
+		stored: map[string]types.CheckCountSummary{
+			"aaa": {Success: 2},
+			"bbb": {Success: 1, Failure: 1},
+			"ccc": {},
+		},
+		counted: map[string]types.CheckCountSummary{
+			"aaa": {Success: 2},
+			"bbb": {Success: 2},
+			"ddd": {Pending: 1},
+		},
+		repo: types.RepositoryCheckSummary{Total: 4, Completed: 3, Succeeded: 3, PassRate: 1},
+	}
+
+	cache := &memSummaryCache{
+		summary: types.RepositoryCheckSummary{Since: since, Total: 3, Completed: 3, Succeeded: 3, PassRate: 1},
+	}
+
+	report, err := NewService(checkStore, cache).VerifyConsistency(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("VerifyConsistency() error = %v", err)
+	}
+
+	if report.CommitsVerified != 4 {
+		t.Errorf("commits verified = %d, want 4", report.CommitsVerified)
+	}
+
+	if len(report.CommitSummaries) != 2 {
+		t.Fatalf("commit discrepancies = %+v, want bbb and ddd", report.CommitSummaries)
+	}
+
+	if d := report.CommitSummaries[0]; d.CommitSHA != "bbb" || d.Cached.Failure != 1 || d.Actual.Success != 2 {
+		t.Errorf("first discrepancy = %+v, want outdated summary of bbb", d)
+	}
+
+	if d := report.CommitSummaries[1]; d.CommitSHA != "ddd" || d.Cached != nil || d.Actual.Pending != 1 {
+		t.Errorf("second discrepancy = %+v, want missing summary of ddd", d)
+	}
+
+	if report.RepoSummary == nil || report.RepoSummary.Actual.Since != since || report.RepoSummary.Actual.Total != 4 {
+		t.Errorf("repo summary discrepancy = %+v, want metrics of the cached period", report.RepoSummary)
+	}
+
+	if report.Consistent() {
+		t.Error("report with discrepancies is consistent")
+	}
+}
+
+func TestService_VerifyConsistency_Consistent(t *testing.T) {
+	checkStore := &memCheckStore{
+		stored:  map[string]types.CheckCountSummary{"aaa": {Success: 1}},
+		counted: map[string]types.CheckCountSummary{"aaa": {Success: 1}},
+		repo:    types.RepositoryCheckSummary{Total: 1, Completed: 1, Succeeded: 1, PassRate: 1},
+	}
+
+	cache := &memSummaryCache{summary: checkStore.repo}
+
+	report, err := NewService(checkStore, cache).VerifyConsistency(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("VerifyConsistency() error = %v", err)
+	}
+
+	if !report.Consistent() {
+		t.Errorf("VerifyConsistency() = %+v, want consistent", report)
+	}
+}
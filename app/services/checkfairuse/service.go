@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkfairuse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// enforcementTopN is the number of organizations with the most status checks that are checked
+// against the fair-use limit. Organizations below the top ones can't exceed it before them.
+const enforcementTopN = 100
+
+// Config is the configuration of the status check fair-use enforcement.
+type Config struct {
+	// Window is the time range the status check reports of an organization are counted for.
+	Window time.Duration
+	// MaxChecks is the number of status checks an organization can report per window.
+	// A non-positive value disables the fair-use enforcement.
+	MaxChecks int64
+	// RefreshInterval is how often the organizations over the limit are recalculated.
+	RefreshInterval time.Duration
+}
+
+// Service provides the status check ingestion of organizations (root spaces)
+// and enforces the fair-use limit of status check reports per organization.
+// The organizations over the limit are recalculated periodically and kept in memory,
+// so the enforcement applies per instance.
+type Service struct {
+	analyticsStore store.CheckAnalyticsStore
+	config         Config
+
+	refreshGroup singleflight.Group
+
+	mx          sync.Mutex
+	refreshed   time.Time
+	overQuota   map[string]int64 // root space UID (lowercase) -> root space ID
+	overQuotaID map[int64]struct{}
+}
+
+// NewService returns a new fair-use service.
+func NewService(analyticsStore store.CheckAnalyticsStore, config Config) *Service {
+	return &Service{
+		analyticsStore: analyticsStore,
+		config:         config,
+		overQuota:      map[string]int64{},
+		overQuotaID:    map[int64]struct{}{},
+	}
+}
+
+// Enabled returns true if the fair-use limit is enforced.
+func (s *Service) Enabled() bool {
+	return s.config.MaxChecks > 0
+}
+
+// RetryAfter returns how long an organization over the limit has to wait before it's reevaluated.
+func (s *Service) RetryAfter() time.Duration {
+	return s.config.RefreshInterval
+}
+
+// Stats returns the status check ingestion of the topN organizations with the most status checks
+// in the provided time range. Organizations currently over the fair-use limit are marked.
+func (s *Service) Stats(ctx context.Context, from, to time.Time, topN int) ([]*types.OrgIngestionStat, error) {
+	stats, err := s.analyticsStore.OrgIngestionStats(ctx, from, to, topN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status check ingestion per organization: %w", err)
+	}
+
+	if !s.Enabled() {
+		return stats, nil
+	}
+
+	s.refresh(ctx, time.Now())
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for _, stat := range stats {
+		_, stat.OverQuota = s.overQuotaID[stat.SpaceID]
+	}
+
+	return stats, nil
+}
+
+// Allow returns false if the organization of the repository exceeds the fair-use limit.
+func (s *Service) Allow(ctx context.Context, repoPath string) bool {
+	if !s.Enabled() {
+		return true
+	}
+
+	s.refresh(ctx, time.Now())
+
+	rootUID := strings.ToLower(paths.Segments(repoPath)[0])
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	_, over := s.overQuota[rootUID]
+
+	return !over
+}
+
+// refresh recalculates the organizations over the limit if the refresh interval elapsed.
+// Concurrent callers share a single recalculation, which queries the database without holding the lock.
+func (s *Service) refresh(ctx context.Context, now time.Time) {
+	s.mx.Lock()
+	stale := now.Sub(s.refreshed) >= s.config.RefreshInterval
+	s.mx.Unlock()
+
+	if !stale {
+		return
+	}
+
+	_, _, _ = s.refreshGroup.Do("refresh", func() (any, error) {
+		s.recalculate(ctx, now)
+		return struct{}{}, nil
+	})
+}
+
+// recalculate loads the organizations over the limit and swaps them in.
+// On failure the previous state is kept, so status check reports don't fail because of the enforcement.
+func (s *Service) recalculate(ctx context.Context, now time.Time) {
+	stats, err := s.analyticsStore.OrgIngestionStats(ctx, now.Add(-s.config.Window), now, enforcementTopN)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to refresh status check fair-use limits")
+
+		s.mx.Lock()
+		s.refreshed = now
+		s.mx.Unlock()
+
+		return
+	}
+
+	overQuota := make(map[string]int64)
+	overQuotaID := make(map[int64]struct{})
+	for _, stat := range stats {
+		if stat.CheckCount <= s.config.MaxChecks {
+			break // sorted by the number of status checks
+		}
+
+		overQuota[strings.ToLower(stat.SpaceUID)] = stat.SpaceID
+		overQuotaID[stat.SpaceID] = struct{}{}
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.refreshed = now
+	s.overQuota = overQuota
+	s.overQuotaID = overQuotaID
+}
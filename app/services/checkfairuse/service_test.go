@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkfairuse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakeAnalyticsStore struct {
+	store.CheckAnalyticsStore
+	stats  []*types.OrgIngestionStat
+	err    error
+	calls  int
+	during func()
+}
+
+func (f *fakeAnalyticsStore) OrgIngestionStats(
+	context.Context,
+	time.Time,
+	time.Time,
+	int,
+) ([]*types.OrgIngestionStat, error) {
+	f.calls++
+	if f.during != nil {
+		f.during()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	stats := make([]*types.OrgIngestionStat, len(f.stats))
+	for i, stat := range f.stats {
+		s := *stat
+		stats[i] = &s
+	}
+
+	return stats, nil
+}
+
+func TestService_Allow(t *testing.T) {
+	analytics := &fakeAnalyticsStore{stats: []*types.OrgIngestionStat{
+		{SpaceID: 1, SpaceUID: "Heavy", CheckCount: 20},
+		{SpaceID: 2, SpaceUID: "light", CheckCount: 10},
+	}}
+	s := NewService(analytics, Config{Window: time.Hour, MaxChecks: 10, RefreshInterval: time.Hour})
+
+	ctx := context.Background()
+
+	if s.Allow(ctx, "heavy/sub/repo") {
+		t.Error("expected the organization over the limit to be rejected")
+	}
+	if !s.Allow(ctx, "light/repo") {
+		t.Error("expected the organization at the limit to be allowed")
+	}
+	if !s.Allow(ctx, "other/repo") {
+		t.Error("expected the organization without status checks to be allowed")
+	}
+	if analytics.calls != 1 {
+		t.Errorf("expected the limits to be refreshed once, got %d", analytics.calls)
+	}
+
+	stats, err := s.Stats(ctx, time.Now().Add(-time.Hour), time.Now(), 10)
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+	if !stats[0].OverQuota || stats[1].OverQuota {
+		t.Errorf("unexpected over quota marks: %v, %v", stats[0].OverQuota, stats[1].OverQuota)
+	}
+}
+
+func TestService_AllowFailsOpen(t *testing.T) {
+	analytics := &fakeAnalyticsStore{err: errors.New("db is down")}
+	s := NewService(analytics, Config{Window: time.Hour, MaxChecks: 10, RefreshInterval: time.Hour})
+
+	if !s.Allow(context.Background(), "heavy/repo") {
+		t.Error("expected status checks to be allowed when the limits can't be refreshed")
+	}
+}
+
+func TestService_Disabled(t *testing.T) {
+	analytics := &fakeAnalyticsStore{stats: []*types.OrgIngestionStat{
+		{SpaceID: 1, SpaceUID: "heavy", CheckCount: 20},
+	}}
+	s := NewService(analytics, Config{Window: time.Hour, RefreshInterval: time.Hour})
+
+	if !s.Allow(context.Background(), "heavy/repo") {
+		t.Error("expected status checks to be allowed when the fair-use limit is disabled")
+	}
+	if analytics.calls != 0 {
+		t.Errorf("expected no refresh when the fair-use limit is disabled, got %d", analytics.calls)
+	}
+}
+
+func TestService_RefreshWithoutLock(t *testing.T) {
+	analytics := &fakeAnalyticsStore{stats: []*types.OrgIngestionStat{
+		{SpaceID: 1, SpaceUID: "heavy", CheckCount: 20},
+	}}
+	s := NewService(analytics, Config{Window: time.Hour, MaxChecks: 10, RefreshInterval: time.Hour})
+
+	analytics.during = func() {
+		if !s.mx.TryLock() {
+			t.Error("expected the lock not to be held while querying the ingestion stats")
+			return
+		}
+		s.mx.Unlock()
+	}
+
+	if s.Allow(context.Background(), "heavy/repo") {
+		t.Error("expected the organization over the limit to be rejected")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkfederation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// remoteMaxChecks is the maximum number of status checks fetched from a remote instance for a commit.
+const remoteMaxChecks = 100
+
+// ResponseError is returned if a remote instance rejected the request.
+// The message is the response body of the remote instance, it's only meant to be logged.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("remote instance responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// errorMessage translates an error of a remote instance into a message that can be shown to clients.
+// Neither the response body nor the address of the remote instance are revealed.
+func errorMessage(err error) string {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		return "remote instance is unreachable"
+	}
+
+	switch {
+	case respErr.StatusCode == http.StatusUnauthorized || respErr.StatusCode == http.StatusForbidden:
+		return "access to the repository on the remote instance is denied"
+	case respErr.StatusCode == http.StatusNotFound:
+		return "repository not found on the remote instance"
+	case respErr.StatusCode >= http.StatusInternalServerError:
+		return "remote instance is unavailable"
+	default:
+		return "remote instance rejected the request"
+	}
+}
+
+// remoteClient fetches status checks from the REST API of a remote gitness instance.
+type remoteClient struct {
+	name       string
+	httpClient *http.Client
+	baseURL    string
+	token      string
+	spaces     []string
+}
+
+func newRemoteClient(remote Remote, timeout time.Duration) *remoteClient {
+	return &remoteClient{
+		name:       remote.Name,
+		httpClient: &http.Client{Timeout: timeout},
+		baseURL:    strings.TrimSuffix(remote.URL, "/"),
+		token:      remote.Token,
+		spaces:     remote.Spaces,
+	}
+}
+
+// federates returns true if the status checks of the repository are fetched from the remote instance.
+func (c *remoteClient) federates(repoPath string) bool {
+	for _, space := range c.spaces {
+		if strings.HasPrefix(repoPath, space+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ListChecks returns the status checks reported for the commit in the repository with the same path.
+func (c *remoteClient) ListChecks(ctx context.Context, repoPath, commitSHA string) ([]types.Check, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/repos/%s/checks/commits/%s?limit=%d",
+		c.baseURL, url.PathEscape(repoPath), url.PathEscape(commitSHA), remoteMaxChecks)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &ResponseError{
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(msg)),
+		}
+	}
+
+	checks := make([]types.Check, 0)
+	if err = json.NewDecoder(resp.Body).Decode(&checks); err != nil {
+		return nil, fmt.Errorf("failed to decode status checks: %w", err)
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkfederation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LocalInstance is the instance name of the status checks reported to this gitness instance.
+const LocalInstance = "local"
+
+// Remote is a remote gitness instance whose status checks are federated.
+// The token is a token of a service account of the remote instance. The status checks of the remote
+// instance are only fetched for the repositories in the spaces, so the token can't be used to read
+// the status checks of any other remote repository.
+type Remote struct {
+	Name   string
+	URL    string
+	Token  string
+	Spaces []string
+}
+
+type Config struct {
+	// Remotes lists the remote instances in the format "name|url|token|space1,space2".
+	Remotes  []string
+	CacheTTL time.Duration
+	Timeout  time.Duration
+}
+
+// ParseRemotes parses the remote instances from the config.
+func (c *Config) ParseRemotes() ([]Remote, error) {
+	remotes := make([]Remote, 0, len(c.Remotes))
+	names := map[string]struct{}{LocalInstance: {}}
+
+	for _, entry := range c.Remotes {
+		parts := strings.SplitN(strings.TrimSpace(entry), "|", 4)
+		if len(parts) != 4 || parts[0] == "" || parts[2] == "" || parts[3] == "" {
+			return nil, fmt.Errorf("remote instance must be in the format \"name|url|token|space1,space2\"")
+		}
+
+		remote := Remote{Name: parts[0], URL: parts[1], Token: parts[2]}
+
+		for _, space := range strings.Split(parts[3], ",") {
+			space = strings.Trim(strings.TrimSpace(space), "/")
+			if space == "" {
+				return nil, fmt.Errorf("invalid space of remote instance %q", remote.Name)
+			}
+			remote.Spaces = append(remote.Spaces, space)
+		}
+
+		if _, ok := names[remote.Name]; ok {
+			return nil, fmt.Errorf("duplicate remote instance name %q", remote.Name)
+		}
+		names[remote.Name] = struct{}{}
+
+		u, err := url.Parse(remote.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid url of remote instance %q", remote.Name)
+		}
+
+		remotes = append(remotes, remote)
+	}
+
+	return remotes, nil
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.CacheTTL < 0 {
+		return errors.New("config.CacheTTL can't be negative")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("config.Timeout has to be a positive duration")
+	}
+	return nil
+}
+
+type remoteChecksKey struct {
+	remote    string
+	repoPath  string
+	commitSHA string
+}
+
+// FederatedCheckStore lists status checks of a commit reported to this and to remote gitness instances.
+// The status checks of a remote instance are looked up in the repository with the same path.
+type FederatedCheckStore struct {
+	checkStore store.CheckStore
+	remotes    map[string]*remoteClient
+	names      []string
+	cache      cache.Cache[remoteChecksKey, []types.Check]
+}
+
+func NewFederatedCheckStore(config Config, checkStore store.CheckStore) (*FederatedCheckStore, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided status check federation config is invalid: %w", err)
+	}
+
+	remotes, err := config.ParseRemotes()
+	if err != nil {
+		return nil, fmt.Errorf("provided status check federation remotes are invalid: %w", err)
+	}
+
+	s := &FederatedCheckStore{
+		checkStore: checkStore,
+		remotes:    make(map[string]*remoteClient, len(remotes)),
+		names:      make([]string, len(remotes)),
+	}
+
+	for i, remote := range remotes {
+		s.remotes[remote.Name] = newRemoteClient(remote, config.Timeout)
+		s.names[i] = remote.Name
+	}
+
+	if config.CacheTTL > 0 {
+		s.cache = cache.New[remoteChecksKey, []types.Check](remoteGetter{s}, config.CacheTTL)
+	} else {
+		s.cache = cache.NewNoCache[remoteChecksKey, []types.Check](remoteGetter{s})
+	}
+
+	return s, nil
+}
+
+type remoteGetter struct {
+	s *FederatedCheckStore
+}
+
+func (g remoteGetter) Find(ctx context.Context, key remoteChecksKey) ([]types.Check, error) {
+	return g.s.remotes[key.remote].ListChecks(ctx, key.repoPath, key.commitSHA)
+}
+
+// List returns the status checks of the commit from the local and all remote instances
+// that federate the repository. The instances are queried concurrently. If querying some of the remote
+// instances failed, the status checks of the other instances are returned and the result is marked as partial.
+// Only the status checks with any of the visibilities are returned, nil returns all.
+// The remote status checks are filtered the same way, as the remote instance is queried with
+// its service account rather than with the caller's identity.
+func (s *FederatedCheckStore) List(
+	ctx context.Context,
+	repo *types.Repository,
+	commitSHA string,
+	visibilities []enum.CheckVisibility,
+) (*types.FederatedCheckList, error) {
+	checks, err := s.checkStore.List(ctx, repo.ID, commitSHA, types.CheckListOptions{
+		ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: remoteMaxChecks}},
+		Visibilities:    visibilities,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local status checks: %w", err)
+	}
+
+	remoteChecks := make([][]types.Check, len(s.names))
+	remoteErrs := make([]error, len(s.names))
+
+	var wg sync.WaitGroup
+	for i, name := range s.names {
+		if !s.remotes[name].federates(repo.Path) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			remoteChecks[i], remoteErrs[i] = s.cache.Get(ctx, remoteChecksKey{
+				remote:    name,
+				repoPath:  repo.Path,
+				commitSHA: commitSHA,
+			})
+		}()
+	}
+	wg.Wait()
+
+	result := &types.FederatedCheckList{
+		Checks: make([]types.FederatedCheck, 0, len(checks)),
+	}
+
+	for _, check := range checks {
+		result.Checks = append(result.Checks, types.FederatedCheck{Instance: LocalInstance, Check: check})
+	}
+
+	for i, name := range s.names {
+		if remoteErrs[i] != nil {
+			log.Ctx(ctx).Warn().Err(remoteErrs[i]).
+				Str("remote", name).
+				Msg("failed to fetch status checks from remote instance")
+
+			result.PartialError = true
+			result.Errors = append(result.Errors, types.FederatedCheckError{
+				Instance: name,
+				Message:  errorMessage(remoteErrs[i]),
+			})
+			continue
+		}
+
+		for _, check := range remoteChecks[i] {
+			if visibilities != nil && !slices.Contains(visibilities, check.Visibility) {
+				continue
+			}
+
+			result.Checks = append(result.Checks, types.FederatedCheck{Instance: name, Check: check})
+		}
+	}
+
+	return result, nil
+}
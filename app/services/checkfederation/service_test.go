@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkfederation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type localCheckStore struct {
+	store.CheckStore
+	checks []types.Check
+}
+
+func (s localCheckStore) List(context.Context, int64, string, types.CheckListOptions) ([]types.Check, error) {
+	return s.checks, nil
+}
+
+func TestFederatedCheckStore_List(t *testing.T) {
+	var calls atomic.Int32
+
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if path := r.URL.EscapedPath(); path != "/api/v1/repos/space%2Frepo/checks/commits/abc" {
+			t.Errorf("unexpected path: %s", path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected authorization header: %s", got)
+		}
+
+		_ = json.NewEncoder(w).Encode([]types.Check{
+			{Identifier: "remote-build", Visibility: enum.CheckVisibilityPublic},
+			{Identifier: "remote-deploy", Visibility: enum.CheckVisibilityPrivate},
+		})
+	}))
+	defer remote.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	s, err := NewFederatedCheckStore(Config{
+		Remotes: []string{
+			"ci|" + remote.URL + "|secret|space",
+			"broken|" + failing.URL + "|secret|space",
+			"other|" + failing.URL + "|secret|other-space",
+		},
+		CacheTTL: time.Minute,
+		Timeout:  time.Second,
+	}, localCheckStore{checks: []types.Check{{Identifier: "local-build"}}})
+	if err != nil {
+		t.Fatalf("failed to create store: %s", err)
+	}
+
+	repo := &types.Repository{ID: 1, Path: "space/repo"}
+
+	for i := 0; i < 2; i++ {
+		list, err := s.List(context.Background(), repo, "abc",
+			[]enum.CheckVisibility{enum.CheckVisibilityPublic, enum.CheckVisibilityRestricted})
+		if err != nil {
+			t.Fatalf("failed to list checks: %s", err)
+		}
+
+		if len(list.Checks) != 2 {
+			t.Fatalf("expected 2 checks, got %d", len(list.Checks))
+		}
+		if list.Checks[0].Instance != LocalInstance || list.Checks[0].Check.Identifier != "local-build" {
+			t.Errorf("unexpected local check: %+v", list.Checks[0])
+		}
+		if list.Checks[1].Instance != "ci" || list.Checks[1].Check.Identifier != "remote-build" {
+			t.Errorf("unexpected remote check: %+v", list.Checks[1])
+		}
+		if !list.PartialError || len(list.Errors) != 1 || list.Errors[0].Instance != "broken" {
+			t.Fatalf("expected partial error of the broken instance only, got %+v", list.Errors)
+		}
+		if msg := list.Errors[0].Message; msg != "remote instance is unavailable" {
+			t.Errorf("expected translated error message, got %q", msg)
+		}
+	}
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected remote checks to be cached, remote was called %d times", n)
+	}
+}
+
+func TestConfig_ParseRemotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		remotes []string
+		wantErr bool
+	}{
+		{name: "valid", remotes: []string{"ci|https://ci.example.com|token|space,other/space"}},
+		{name: "missing token", remotes: []string{"ci|https://ci.example.com"}, wantErr: true},
+		{name: "missing spaces", remotes: []string{"ci|https://ci.example.com|token"}, wantErr: true},
+		{name: "empty space", remotes: []string{"ci|https://ci.example.com|token|space,"}, wantErr: true},
+		{name: "invalid url", remotes: []string{"ci|ci.example.com|token|space"}, wantErr: true},
+		{name: "reserved name", remotes: []string{"local|https://ci.example.com|token|space"}, wantErr: true},
+		{
+			name:    "duplicate name",
+			remotes: []string{"ci|https://a.example.com|token|space", "ci|https://b.example.com|token|space"},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := Config{Remotes: test.remotes}
+			_, err := config.ParseRemotes()
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error=%t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
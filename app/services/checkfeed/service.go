@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkfeed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/pubsub"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	groupCheckEvents = "gitness:checkfeed"
+
+	pubsubNamespace = "checks"
+	pubsubTopicFeed = "feed"
+
+	// consumerBufferSize is the number of events buffered for a feed consumer.
+	// Events that don't fit into the buffer are dropped.
+	consumerBufferSize = 100
+)
+
+type Config struct {
+	EventReaderName string
+}
+
+// Filter limits the status check feed to the status checks of the repos in a space
+// and to the provided statuses. Zero values don't filter.
+type Filter struct {
+	SpaceID  int64
+	Statuses []enum.CheckStatus
+	// Visibilities are the visibilities of the status checks delivered to the consumer, nil delivers all.
+	Visibilities []enum.CheckVisibility
+}
+
+// Service publishes the status changes of all status checks of the instance to the status check feed.
+// The status changed events are consumed by a single instance and fanned out to the feed consumers
+// of all instances through the pubsub.
+type Service struct {
+	pubsub     pubsub.PubSub
+	repoStore  store.RepoStore
+	spaceStore store.SpaceStore
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pubsub pubsub.PubSub,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+) (*Service, error) {
+	if config.EventReaderName == "" {
+		return nil, errors.New("config.EventReaderName is required")
+	}
+
+	s := &Service{
+		pubsub:     pubsub,
+		repoStore:  repoStore,
+		spaceStore: spaceStore,
+	}
+
+	_, err := checkReaderFactory.Launch(ctx, groupCheckEvents, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 10 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterStatusChanged(s.handleEventStatusChanged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for check feed: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *Service) handleEventStatusChanged(
+	ctx context.Context,
+	event *events.Event[*checkevents.StatusChangedPayload],
+) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventError(fmt.Errorf("repo %d not found", event.Payload.RepoID))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get space ancestors of repo: %w", err)
+	}
+
+	visibility := event.Payload.Visibility
+	if visibility == "" {
+		visibility = enum.CheckVisibilityPublic
+	}
+
+	data, err := json.Marshal(types.CheckFeedEvent{
+		RepoID:      repo.ID,
+		RepoPath:    repo.Path,
+		SpaceIDs:    spaceIDs,
+		CommitSHA:   event.Payload.CommitSHA,
+		Identifier:  event.Payload.Identifier,
+		PrincipalID: event.Payload.PrincipalID,
+		OldStatus:   event.Payload.OldStatus,
+		NewStatus:   event.Payload.NewStatus,
+		Timestamp:   event.Timestamp.UnixMilli(),
+		Visibility:  visibility,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal check feed event: %w", err)
+	}
+
+	err = s.pubsub.Publish(ctx, pubsubTopicFeed, data, pubsub.WithPublishNamespace(pubsubNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to publish check feed event: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe streams the status check feed events that match the filter.
+// Events are dropped if the consumer doesn't keep up with the feed,
+// the number of dropped events is reported with the next delivered event.
+func (s *Service) Subscribe(
+	ctx context.Context,
+	filter Filter,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error) {
+	c := newConsumer(filter)
+	chErr := make(chan error)
+
+	consumer := s.pubsub.Subscribe(ctx, pubsubTopicFeed, c.handle, pubsub.WithChannelNamespace(pubsubNamespace))
+	cleanupFN := func(_ context.Context) error {
+		return consumer.Close()
+	}
+
+	return c.chEvent, chErr, cleanupFN
+}
+
+// consumer delivers the feed events matching its filter to a single feed consumer.
+// The pubsub calls handle sequentially, so the dropped events counter needs no synchronization.
+type consumer struct {
+	filter  Filter
+	chEvent chan *sse.Event
+	dropped int
+}
+
+func newConsumer(filter Filter) *consumer {
+	return &consumer{
+		filter:  filter,
+		chEvent: make(chan *sse.Event, consumerBufferSize),
+	}
+}
+
+func (c *consumer) handle(payload []byte) error {
+	feedEvent := types.CheckFeedEvent{}
+	if err := json.Unmarshal(payload, &feedEvent); err != nil {
+		return fmt.Errorf("failed to unmarshal check feed event: %w", err)
+	}
+
+	if !c.filter.matches(&feedEvent) {
+		return nil
+	}
+
+	feedEvent.EventsDropped = c.dropped
+
+	data, err := json.Marshal(feedEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal check feed event: %w", err)
+	}
+
+	select {
+	case c.chEvent <- &sse.Event{Type: enum.SSETypeCheckStatusChanged, Data: data}:
+		c.dropped = 0
+	default:
+		c.dropped++
+	}
+
+	return nil
+}
+
+func (f Filter) matches(e *types.CheckFeedEvent) bool {
+	if f.SpaceID != 0 && !slices.Contains(e.SpaceIDs, f.SpaceID) {
+		return false
+	}
+
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, e.NewStatus) {
+		return false
+	}
+
+	if f.Visibilities != nil && !slices.Contains(f.Visibilities, e.Visibility) {
+		return false
+	}
+
+	return true
+}
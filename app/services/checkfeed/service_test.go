@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkfeed
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestConsumer_Handle(t *testing.T) {
+	c := newConsumer(Filter{
+		SpaceID:  2,
+		Statuses: []enum.CheckStatus{enum.CheckStatusFailure},
+	})
+
+	publish := func(spaceIDs []int64, status enum.CheckStatus) {
+		t.Helper()
+
+		payload, err := json.Marshal(types.CheckFeedEvent{SpaceIDs: spaceIDs, NewStatus: status})
+		if err != nil {
+			t.Fatalf("failed to marshal event: %v", err)
+		}
+
+		if err = c.handle(payload); err != nil {
+			t.Fatalf("handle() error = %v", err)
+		}
+	}
+
+	publish([]int64{1}, enum.CheckStatusFailure)
+	publish([]int64{1, 2}, enum.CheckStatusSuccess)
+	if len(c.chEvent) != 0 {
+		t.Fatalf("expected filtered events to be skipped, got %d events", len(c.chEvent))
+	}
+
+	// fill the buffer of the consumer and overflow it.
+	for range consumerBufferSize + 3 {
+		publish([]int64{1, 2}, enum.CheckStatusFailure)
+	}
+
+	for range consumerBufferSize {
+		<-c.chEvent
+	}
+
+	publish([]int64{2}, enum.CheckStatusFailure)
+
+	event := <-c.chEvent
+	if event.Type != enum.SSETypeCheckStatusChanged {
+		t.Errorf("event type = %q, want %q", event.Type, enum.SSETypeCheckStatusChanged)
+	}
+
+	feedEvent := types.CheckFeedEvent{}
+	if err := json.Unmarshal(event.Data, &feedEvent); err != nil {
+		t.Fatalf("failed to unmarshal event: %v", err)
+	}
+
+	if feedEvent.EventsDropped != 3 {
+		t.Errorf("dropped events = %d, want 3", feedEvent.EventsDropped)
+	}
+}
+
+func TestFilter_MatchesVisibility(t *testing.T) {
+	filter := Filter{Visibilities: []enum.CheckVisibility{enum.CheckVisibilityPublic, enum.CheckVisibilityRestricted}}
+
+	tests := []struct {
+		visibility enum.CheckVisibility
+		want       bool
+	}{
+		{visibility: enum.CheckVisibilityPublic, want: true},
+		{visibility: enum.CheckVisibilityRestricted, want: true},
+		{visibility: enum.CheckVisibilityPrivate, want: false},
+	}
+
+	for _, test := range tests {
+		if got := filter.matches(&types.CheckFeedEvent{Visibility: test.visibility}); got != test.want {
+			t.Errorf("matches() of %s status check = %t, want %t", test.visibility, got, test.want)
+		}
+	}
+
+	if !(Filter{}).matches(&types.CheckFeedEvent{Visibility: enum.CheckVisibilityPrivate}) {
+		t.Errorf("matches() without visibility filter = false, want true")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkhead
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+const branchRefPrefix = "refs/heads/"
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.updateHead(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.SHA, event.Timestamp)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.updateHead(ctx, event.Payload.RepoID, event.Payload.Ref, event.Payload.NewSHA, event.Timestamp)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.updateHead(ctx, event.Payload.RepoID, event.Payload.Ref, "", event.Timestamp)
+}
+
+func (s *Service) handleEventDefaultBranchUpdated(ctx context.Context,
+	event *events.Event[*repoevents.DefaultBranchUpdatedPayload]) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository in db: %w", err)
+	}
+
+	return s.recordHead(ctx, repo, event.Timestamp)
+}
+
+// updateHead records the new head commit of a pushed branch if it's the default branch of the repo.
+// An empty commit SHA means the branch got deleted.
+func (s *Service) updateHead(ctx context.Context, repoID int64, ref, commitSHA string, at time.Time) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository in db: %w", err)
+	}
+
+	if strings.TrimPrefix(ref, branchRefPrefix) != repo.DefaultBranch {
+		return nil
+	}
+
+	if commitSHA == "" {
+		if err = s.repoHeadStore.Delete(ctx, repo.ID); err != nil {
+			return fmt.Errorf("failed to delete repo head: %w", err)
+		}
+
+		return nil
+	}
+
+	if err = s.repoHeadStore.Upsert(ctx, repo.ID, commitSHA, at.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to record repo head: %w", err)
+	}
+
+	return nil
+}
+
+// recordHead reads the head commit of the default branch of the repo from git and records it.
+func (s *Service) recordHead(ctx context.Context, repo *types.Repository, at time.Time) error {
+	branch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		return s.repoHeadStore.Delete(ctx, repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch of repo %q: %w", repo.Path, err)
+	}
+
+	if err = s.repoHeadStore.Upsert(ctx, repo.ID, branch.Branch.SHA.String(), at.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to record repo head: %w", err)
+	}
+
+	return nil
+}
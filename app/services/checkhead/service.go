@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkhead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	groupGitEvents  = "gitness:checkhead"
+	groupRepoEvents = "gitness:checkhead:repo"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service records the default branch head commit of repositories, which git alone can't provide
+// to database queries. The status check status of a repository is the status of its recorded head.
+type Service struct {
+	git           git.Interface
+	repoStore     store.RepoStore
+	repoHeadStore store.CheckRepoHeadStore
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	gitInterface git.Interface,
+	repoStore store.RepoStore,
+	repoHeadStore store.CheckRepoHeadStore,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided status check repo head service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		git:           gitInterface,
+		repoStore:     repoStore,
+		repoHeadStore: repoHeadStore,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := gitReaderFactory.Launch(ctx, groupGitEvents, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for status check repo heads: %w", err)
+	}
+
+	_, err = repoReaderFactory.Launch(ctx, groupRepoEvents, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterDefaultBranchUpdated(service.handleEventDefaultBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for status check repo heads: %w", err)
+	}
+
+	return service, nil
+}
+
+// Resolve makes sure the default branch head commit of each of the provided repos is recorded.
+// Heads that weren't recorded yet, for example of repos that weren't pushed to since the heads
+// are recorded, are read from git.
+func (s *Service) Resolve(ctx context.Context, repos []*types.Repository) error {
+	repoIDs := make([]int64, len(repos))
+	for i, repo := range repos {
+		repoIDs[i] = repo.ID
+	}
+
+	heads, err := s.repoHeadStore.Map(ctx, repoIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find recorded repo heads: %w", err)
+	}
+
+	for _, repo := range repos {
+		if _, ok := heads[repo.ID]; ok || repo.IsEmpty {
+			continue
+		}
+
+		if err = s.recordHead(ctx, repo, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkhealth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// recentWindow is the window of the recent failure rate.
+	recentWindow = 24 * time.Hour
+	// baselineWindow is the window, preceding the recent window, of the baseline failure rate.
+	baselineWindow = 7 * 24 * time.Hour
+
+	// minRecentChecks is the minimum number of status checks completed in the recent window
+	// for the health of a repository to be considered degraded.
+	minRecentChecks = 5
+	// degradationThreshold is the minimum increase of the failure rate, compared to the baseline,
+	// for the health of a repository to be considered degraded.
+	degradationThreshold = 0.2
+)
+
+// Service computes the status check health of repositories
+// by comparing their recent status check failure rate with the one of the preceding week.
+type Service struct {
+	analyticsStore store.CheckAnalyticsStore
+}
+
+func NewService(analyticsStore store.CheckAnalyticsStore) *Service {
+	return &Service{
+		analyticsStore: analyticsStore,
+	}
+}
+
+// HealthScore returns the status check health score of a repository, between 0 and 1.
+func (s *Service) HealthScore(ctx context.Context, repoID int64) (float64, error) {
+	health, err := s.Health(ctx, []int64{repoID})
+	if err != nil {
+		return 0, err
+	}
+
+	return health[repoID].Score, nil
+}
+
+// Health returns the status check health of the repositories.
+func (s *Service) Health(ctx context.Context, repoIDs []int64) (map[int64]types.CheckHealth, error) {
+	now := time.Now()
+	recentFrom := now.Add(-recentWindow)
+
+	recent, err := s.analyticsStore.FailureRates(ctx, repoIDs, recentFrom, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent status check failure rates: %w", err)
+	}
+
+	baseline, err := s.analyticsStore.FailureRates(ctx, repoIDs, recentFrom.Add(-baselineWindow), recentFrom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get baseline status check failure rates: %w", err)
+	}
+
+	health := make(map[int64]types.CheckHealth, len(repoIDs))
+	for _, repoID := range repoIDs {
+		health[repoID] = evaluate(recent[repoID], baseline[repoID])
+	}
+
+	return health, nil
+}
+
+// SpaceHealth returns the status check health of all repositories of the space and its descendant spaces.
+// The health of the space is evaluated from the failure rates of all its repositories combined.
+func (s *Service) SpaceHealth(ctx context.Context, spaceID int64) (types.CheckHealthRollup, error) {
+	now := time.Now()
+	recentFrom := now.Add(-recentWindow)
+
+	recent, err := s.analyticsStore.SpaceFailureRates(ctx, spaceID, recentFrom, now)
+	if err != nil {
+		return types.CheckHealthRollup{}, fmt.Errorf("failed to get recent status check failure rates: %w", err)
+	}
+
+	baseline, err := s.analyticsStore.SpaceFailureRates(ctx, spaceID, recentFrom.Add(-baselineWindow), recentFrom)
+	if err != nil {
+		return types.CheckHealthRollup{}, fmt.Errorf("failed to get baseline status check failure rates: %w", err)
+	}
+
+	var rollup types.CheckHealthRollup
+	var recentTotal, baselineTotal types.CheckFailureRate
+
+	for repoID, rate := range baseline {
+		if _, ok := recent[repoID]; !ok {
+			rollup.Repos++
+		}
+
+		baselineTotal.Completed += rate.Completed
+		baselineTotal.Failed += rate.Failed
+	}
+
+	for repoID, rate := range recent {
+		rollup.Repos++
+		if evaluate(rate, baseline[repoID]).Degraded {
+			rollup.DegradedRepos++
+		}
+
+		recentTotal.Completed += rate.Completed
+		recentTotal.Failed += rate.Failed
+	}
+
+	rollup.CheckHealth = evaluate(recentTotal, baselineTotal)
+
+	return rollup, nil
+}
+
+// evaluate returns the health from the recent and the baseline failure rates.
+// Without completed status checks in the recent window, the repository is considered healthy.
+func evaluate(recent, baseline types.CheckFailureRate) types.CheckHealth {
+	recentRate := recent.Rate()
+
+	return types.CheckHealth{
+		Score: 1 - recentRate,
+		Degraded: recent.Completed >= minRecentChecks &&
+			recentRate-baseline.Rate() >= degradationThreshold,
+	}
+}
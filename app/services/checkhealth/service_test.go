@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkhealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		recent   types.CheckFailureRate
+		baseline types.CheckFailureRate
+		want     types.CheckHealth
+	}{
+		{
+			name: "no-checks",
+			want: types.CheckHealth{Score: 1},
+		},
+		{
+			name:     "stable",
+			recent:   types.CheckFailureRate{Completed: 10, Failed: 1},
+			baseline: types.CheckFailureRate{Completed: 70, Failed: 7},
+			want:     types.CheckHealth{Score: 0.9},
+		},
+		{
+			name:     "degraded",
+			recent:   types.CheckFailureRate{Completed: 10, Failed: 5},
+			baseline: types.CheckFailureRate{Completed: 70, Failed: 7},
+			want:     types.CheckHealth{Score: 0.5, Degraded: true},
+		},
+		{
+			name:   "degraded-without-baseline",
+			recent: types.CheckFailureRate{Completed: 5, Failed: 2},
+			want:   types.CheckHealth{Score: 0.6, Degraded: true},
+		},
+		{
+			name:     "too-few-checks",
+			recent:   types.CheckFailureRate{Completed: 2, Failed: 2},
+			baseline: types.CheckFailureRate{Completed: 70},
+			want:     types.CheckHealth{Score: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := evaluate(test.recent, test.baseline); got != test.want {
+				t.Errorf("want=%+v got=%+v", test.want, got)
+			}
+		})
+	}
+}
+
+type fakeAnalyticsStore struct {
+	store.CheckAnalyticsStore
+	recent   map[int64]types.CheckFailureRate
+	baseline map[int64]types.CheckFailureRate
+}
+
+func (s fakeAnalyticsStore) SpaceFailureRates(
+	_ context.Context,
+	_ int64,
+	_, to time.Time,
+) (map[int64]types.CheckFailureRate, error) {
+	if time.Since(to) < time.Minute {
+		return s.recent, nil
+	}
+	return s.baseline, nil
+}
+
+func TestService_SpaceHealth(t *testing.T) {
+	s := NewService(fakeAnalyticsStore{
+		recent: map[int64]types.CheckFailureRate{
+			1: {Completed: 10, Failed: 8},
+			2: {Completed: 10},
+		},
+		baseline: map[int64]types.CheckFailureRate{
+			1: {Completed: 70, Failed: 7},
+			3: {Completed: 70, Failed: 7},
+		},
+	})
+
+	rollup, err := s.SpaceHealth(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("SpaceHealth() error = %v", err)
+	}
+
+	want := types.CheckHealthRollup{
+		CheckHealth:   types.CheckHealth{Score: 0.6, Degraded: true},
+		Repos:         3,
+		DegradedRepos: 1,
+	}
+	if rollup != want {
+		t.Errorf("SpaceHealth() = %+v, want %+v", rollup, want)
+	}
+}
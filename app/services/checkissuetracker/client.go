@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const requestTimeout = 30 * time.Second
+
+// errIssueNotFound is returned if the issue tracker doesn't know the referenced issue.
+var errIssueNotFound = errors.New("issue not found")
+
+// ResponseError is returned if the issue tracker rejected the request.
+type ResponseError struct {
+	Tracker    string
+	StatusCode int
+	Message    string
+	// Retryable is true if the request failed because of throttling or a server side problem.
+	Retryable bool
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s responded with status %d: %s", e.Tracker, e.StatusCode, e.Message)
+}
+
+// issueTracker updates the issues of a single issue tracker.
+type issueTracker interface {
+	// Comment adds a comment to the issue.
+	Comment(ctx context.Context, issueKey, body string) error
+	// Transition moves the issue to the workflow state with the provided name.
+	Transition(ctx context.Context, issueKey, state string) error
+}
+
+// doJSON sends the request with the JSON encoded body and decodes the JSON response into out (if not nil).
+func doJSON(
+	ctx context.Context,
+	httpClient *http.Client,
+	tracker string,
+	method, endpoint string,
+	header http.Header,
+	in, out any,
+) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s request: %w", tracker, err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", tracker, err)
+	}
+
+	req.Header = header.Clone()
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s request: %w", tracker, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errIssueNotFound
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &ResponseError{
+			Tracker:    tracker,
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(msg)),
+			Retryable: resp.StatusCode == http.StatusTooManyRequests ||
+				resp.StatusCode >= http.StatusInternalServerError,
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", tracker, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *CheckIssueTrackerIntegration) handleEventStatusChanged(
+	ctx context.Context,
+	event *events.Event[*checkevents.StatusChangedPayload],
+) error {
+	// only the transition of a status check to a failed state is propagated.
+	if !isFailed(event.Payload.NewStatus) || isFailed(event.Payload.OldStatus) {
+		return nil
+	}
+
+	tracker, transition, err := s.getRepoSettings(ctx, event.Payload.RepoID)
+	if err != nil {
+		return err
+	}
+
+	if tracker == nil {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventError(fmt.Errorf("repository %d not found", event.Payload.RepoID))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	// the status check is found by its ID, the identifier is only unique within its namespace.
+	check, err := s.checkStore.FindByID(ctx, event.Payload.CheckID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventError(fmt.Errorf("status check %q not found", event.Payload.Identifier))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find status check: %w", err)
+	}
+
+	// the status check might have been retried in the meantime.
+	if !isFailed(check.Status) {
+		return nil
+	}
+
+	commit, err := s.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   check.CommitSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get commit %s: %w", check.CommitSHA, err)
+	}
+
+	issueKeys := parseIssueRefs(commit.Commit.Title + "\n" + commit.Commit.Message)
+	if len(issueKeys) == 0 {
+		return nil
+	}
+
+	// the issues updated by a previous attempt to handle the event aren't updated again.
+	completed, err := s.issueUpdateStore.List(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list completed issue updates: %w", err)
+	}
+
+	body := commentBody(repo, check)
+
+	var retryErr error
+	for _, issueKey := range issueKeys {
+		err = s.updateIssue(ctx, tracker, event.ID, check.ID, issueKey, body, transition, completed[issueKey])
+
+		var respErr *ResponseError
+		switch {
+		case err == nil:
+		case errors.Is(err, errIssueNotFound):
+			// the reference might just look like an issue key.
+			log.Ctx(ctx).Debug().Msgf("issue %s referenced by commit %s not found", issueKey, check.CommitSHA)
+		case errors.As(err, &respErr) && respErr.Retryable:
+			retryErr = err
+		default:
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to update issue %s for failed status check %q",
+				issueKey, check.Identifier)
+		}
+	}
+
+	if retryErr != nil {
+		return fmt.Errorf("failed to update issues for failed status check: %w", retryErr)
+	}
+
+	return nil
+}
+
+// getRepoSettings returns the issue tracker and the issue transition configured for the repository.
+// The returned issue tracker is nil if failed status checks aren't propagated for the repository.
+func (s *CheckIssueTrackerIntegration) getRepoSettings(
+	ctx context.Context,
+	repoID int64,
+) (issueTracker, string, error) {
+	trackerType := settings.DefaultCheckIssueTracker
+	_, err := s.settings.RepoGet(ctx, repoID, settings.KeyCheckIssueTracker, &trackerType)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get status check issue tracker setting: %w", err)
+	}
+
+	var tracker issueTracker
+	switch trackerType {
+	case enum.IssueTrackerJira:
+		if s.jira != nil {
+			tracker = s.jira
+		}
+	case enum.IssueTrackerLinear:
+		if s.linear != nil {
+			tracker = s.linear
+		}
+	case enum.IssueTrackerNone:
+	}
+
+	if tracker == nil {
+		return nil, "", nil
+	}
+
+	transition := settings.DefaultCheckIssueTrackerTransition
+	_, err = s.settings.RepoGet(ctx, repoID, settings.KeyCheckIssueTrackerTransition, &transition)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get status check issue transition setting: %w", err)
+	}
+
+	return tracker, transition, nil
+}
+
+// updateIssue comments on the issue and transitions it, skipping the actions already completed for the event.
+// Each completed action is recorded, so that retrying the event only repeats the actions that failed.
+func (s *CheckIssueTrackerIntegration) updateIssue(
+	ctx context.Context,
+	tracker issueTracker,
+	eventID string,
+	checkID int64,
+	issueKey string,
+	body string,
+	transition string,
+	completed []enum.CheckIssueAction,
+) error {
+	if !slices.Contains(completed, enum.CheckIssueActionComment) {
+		if err := tracker.Comment(ctx, issueKey, body); err != nil {
+			return fmt.Errorf("failed to comment on issue %s: %w", issueKey, err)
+		}
+
+		err := s.issueUpdateStore.Create(ctx, checkID, eventID, issueKey, enum.CheckIssueActionComment)
+		if err != nil {
+			return fmt.Errorf("failed to record comment on issue %s: %w", issueKey, err)
+		}
+	}
+
+	if transition == "" || slices.Contains(completed, enum.CheckIssueActionTransition) {
+		return nil
+	}
+
+	if err := tracker.Transition(ctx, issueKey, transition); err != nil {
+		return fmt.Errorf("failed to transition issue %s to %q: %w", issueKey, transition, err)
+	}
+
+	err := s.issueUpdateStore.Create(ctx, checkID, eventID, issueKey, enum.CheckIssueActionTransition)
+	if err != nil {
+		return fmt.Errorf("failed to record transition of issue %s: %w", issueKey, err)
+	}
+
+	return nil
+}
+
+func commentBody(repo *types.Repository, check *types.Check) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Status check %q %s on commit %s of repository %s.",
+		check.Identifier, check.Status, check.CommitSHA, repo.Path)
+
+	if check.Summary != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(check.Summary)
+	}
+
+	if check.Link != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(check.Link)
+	}
+
+	return sb.String()
+}
+
+func isFailed(status enum.CheckStatus) bool {
+	return status == enum.CheckStatusFailure || status == enum.CheckStatusError
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeIssueTracker struct {
+	comments      []string
+	transitions   []string
+	transitionErr error
+}
+
+func (f *fakeIssueTracker) Comment(_ context.Context, issueKey, _ string) error {
+	f.comments = append(f.comments, issueKey)
+	return nil
+}
+
+func (f *fakeIssueTracker) Transition(_ context.Context, issueKey, _ string) error {
+	if f.transitionErr != nil {
+		return f.transitionErr
+	}
+	f.transitions = append(f.transitions, issueKey)
+	return nil
+}
+
+type fakeIssueUpdateStore struct {
+	store.CheckIssueUpdateStore
+	actions map[string][]enum.CheckIssueAction
+}
+
+func (f *fakeIssueUpdateStore) Create(
+	_ context.Context,
+	_ int64,
+	_ string,
+	issueKey string,
+	action enum.CheckIssueAction,
+) error {
+	f.actions[issueKey] = append(f.actions[issueKey], action)
+	return nil
+}
+
+func TestUpdateIssue_Retry(t *testing.T) {
+	ctx := context.Background()
+	updates := &fakeIssueUpdateStore{actions: map[string][]enum.CheckIssueAction{}}
+	s := &CheckIssueTrackerIntegration{issueUpdateStore: updates}
+
+	tracker := &fakeIssueTracker{
+		transitionErr: &ResponseError{StatusCode: 503, Retryable: true},
+	}
+
+	err := s.updateIssue(ctx, tracker, "event", 1, "ABC-1", "failed", "Reopened", updates.actions["ABC-1"])
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || !respErr.Retryable {
+		t.Fatalf("updateIssue() error = %v, want a retryable error", err)
+	}
+
+	tracker.transitionErr = nil
+
+	err = s.updateIssue(ctx, tracker, "event", 1, "ABC-1", "failed", "Reopened", updates.actions["ABC-1"])
+	if err != nil {
+		t.Fatalf("updateIssue() error = %v", err)
+	}
+
+	if want := []string{"ABC-1"}; !reflect.DeepEqual(tracker.comments, want) {
+		t.Errorf("comments = %v, want %v", tracker.comments, want)
+	}
+	if want := []string{"ABC-1"}; !reflect.DeepEqual(tracker.transitions, want) {
+		t.Errorf("transitions = %v, want %v", tracker.transitions, want)
+	}
+
+	want := []enum.CheckIssueAction{enum.CheckIssueActionComment, enum.CheckIssueActionTransition}
+	if !reflect.DeepEqual(updates.actions["ABC-1"], want) {
+		t.Errorf("recorded actions = %v, want %v", updates.actions["ABC-1"], want)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"regexp"
+)
+
+// maxIssueRefs is the maximum number of issues updated for a single failed status check.
+const maxIssueRefs = 10
+
+// issueRefRegexp matches issue keys of Jira and Linear, e.g. PROJ-123.
+var issueRefRegexp = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`)
+
+// parseIssueRefs returns the distinct issue keys referenced in the provided commit message,
+// in order of their first appearance.
+func parseIssueRefs(message string) []string {
+	matches := issueRefRegexp.FindAllString(message, -1)
+
+	refs := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, match := range matches {
+		if _, ok := seen[match]; ok {
+			continue
+		}
+
+		seen[match] = struct{}{}
+		refs = append(refs, match)
+
+		if len(refs) == maxIssueRefs {
+			break
+		}
+	}
+
+	return refs
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseIssueRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{
+			name:    "no references",
+			message: "fix typo in readme",
+			want:    []string{},
+		},
+		{
+			name:    "title and body",
+			message: "PROJ-123: fix login\n\nAlso touches ENG-7 and PROJ-123.",
+			want:    []string{"PROJ-123", "ENG-7"},
+		},
+		{
+			name:    "lowercase and partial keys are ignored",
+			message: "proj-1 P-2 XPROJ-3x UTF-8",
+			want:    []string{"UTF-8"},
+		},
+		{
+			name:    "enclosed references",
+			message: "[AB2-10] (CD-20)",
+			want:    []string{"AB2-10", "CD-20"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parseIssueRefs(test.message); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want refs %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseIssueRefs_Limit(t *testing.T) {
+	message := ""
+	for i := 1; i <= 2*maxIssueRefs; i++ {
+		message += fmt.Sprintf("PROJ-%d ", i)
+	}
+
+	refs := parseIssueRefs(message)
+	if len(refs) != maxIssueRefs {
+		t.Fatalf("want %d refs, got %d", maxIssueRefs, len(refs))
+	}
+	if refs[maxIssueRefs-1] != fmt.Sprintf("PROJ-%d", maxIssueRefs) {
+		t.Errorf("want refs in order of appearance, got %v", refs)
+	}
+}
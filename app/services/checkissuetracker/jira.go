@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const trackerJira = "jira"
+
+// jiraClient updates issues using the Jira REST API v2.
+type jiraClient struct {
+	httpClient *http.Client
+	baseURL    string
+	header     http.Header
+}
+
+// newJiraClient creates a Jira client. If the user is provided the token is used as API token
+// with basic authentication, otherwise it's used as a personal access token.
+func newJiraClient(baseURL, user, token string) *jiraClient {
+	header := http.Header{}
+	if user != "" {
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+token)))
+	} else {
+		header.Set("Authorization", "Bearer "+token)
+	}
+
+	return &jiraClient{
+		httpClient: &http.Client{Timeout: requestTimeout},
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/rest/api/2",
+		header:     header,
+	}
+}
+
+func (c *jiraClient) Comment(ctx context.Context, issueKey, body string) error {
+	in := struct {
+		Body string `json:"body"`
+	}{
+		Body: body,
+	}
+
+	endpoint := fmt.Sprintf("%s/issue/%s/comment", c.baseURL, url.PathEscape(issueKey))
+
+	return doJSON(ctx, c.httpClient, trackerJira, http.MethodPost, endpoint, c.header, in, nil)
+}
+
+func (c *jiraClient) Transition(ctx context.Context, issueKey, state string) error {
+	endpoint := fmt.Sprintf("%s/issue/%s/transitions", c.baseURL, url.PathEscape(issueKey))
+
+	var out struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+
+	err := doJSON(ctx, c.httpClient, trackerJira, http.MethodGet, endpoint, c.header, nil, &out)
+	if err != nil {
+		return err
+	}
+
+	// the transition is matched either by its own name or by the name of the target status.
+	var transitionID string
+	for _, t := range out.Transitions {
+		if strings.EqualFold(t.Name, state) || strings.EqualFold(t.To.Name, state) {
+			transitionID = t.ID
+			break
+		}
+	}
+
+	if transitionID == "" {
+		return fmt.Errorf("jira issue %s has no available transition to %q", issueKey, state)
+	}
+
+	in := struct {
+		Transition struct {
+			ID string `json:"id"`
+		} `json:"transition"`
+	}{}
+	in.Transition.ID = transitionID
+
+	return doJSON(ctx, c.httpClient, trackerJira, http.MethodPost, endpoint, c.header, in, nil)
+}
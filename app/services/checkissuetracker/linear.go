@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const trackerLinear = "linear"
+
+// linearClient updates issues using the Linear GraphQL API.
+type linearClient struct {
+	httpClient *http.Client
+	url        string
+	header     http.Header
+}
+
+func newLinearClient(url, token string) *linearClient {
+	header := http.Header{}
+	header.Set("Authorization", token)
+
+	return &linearClient{
+		httpClient: &http.Client{Timeout: requestTimeout},
+		url:        url,
+		header:     header,
+	}
+}
+
+type linearError struct {
+	Message string `json:"message"`
+}
+
+func (c *linearClient) query(ctx context.Context, query string, variables map[string]any, data any) error {
+	in := struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}{
+		Query:     query,
+		Variables: variables,
+	}
+
+	out := struct {
+		Data   any           `json:"data"`
+		Errors []linearError `json:"errors"`
+	}{
+		Data: data,
+	}
+
+	err := doJSON(ctx, c.httpClient, trackerLinear, http.MethodPost, c.url, c.header, in, &out)
+	if err != nil {
+		return err
+	}
+
+	if len(out.Errors) > 0 {
+		msgs := make([]string, len(out.Errors))
+		for i, e := range out.Errors {
+			msgs[i] = e.Message
+		}
+
+		// linear reports unknown issues as a GraphQL error instead of with a status code.
+		if strings.Contains(strings.ToLower(msgs[0]), "not found") {
+			return errIssueNotFound
+		}
+
+		return &ResponseError{
+			Tracker:    trackerLinear,
+			StatusCode: http.StatusOK,
+			Message:    strings.Join(msgs, "; "),
+		}
+	}
+
+	return nil
+}
+
+func (c *linearClient) Comment(ctx context.Context, issueKey, body string) error {
+	const query = `mutation($issueId: String!, $body: String!) {
+  commentCreate(input: {issueId: $issueId, body: $body}) { success }
+}`
+
+	var data struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
+		} `json:"commentCreate"`
+	}
+
+	err := c.query(ctx, query, map[string]any{"issueId": issueKey, "body": body}, &data)
+	if err != nil {
+		return err
+	}
+
+	if !data.CommentCreate.Success {
+		return fmt.Errorf("linear didn't create the comment on issue %s", issueKey)
+	}
+
+	return nil
+}
+
+func (c *linearClient) Transition(ctx context.Context, issueKey, state string) error {
+	const queryStates = `query($id: String!) {
+  issue(id: $id) { id team { states { nodes { id name } } } }
+}`
+
+	var issue struct {
+		Issue struct {
+			ID   string `json:"id"`
+			Team struct {
+				States struct {
+					Nodes []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"issue"`
+	}
+
+	err := c.query(ctx, queryStates, map[string]any{"id": issueKey}, &issue)
+	if err != nil {
+		return err
+	}
+
+	var stateID string
+	for _, s := range issue.Issue.Team.States.Nodes {
+		if strings.EqualFold(s.Name, state) {
+			stateID = s.ID
+			break
+		}
+	}
+
+	if stateID == "" {
+		return fmt.Errorf("linear team of issue %s has no workflow state %q", issueKey, state)
+	}
+
+	const queryUpdate = `mutation($id: String!, $stateId: String!) {
+  issueUpdate(id: $id, input: {stateId: $stateId}) { success }
+}`
+
+	var data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+
+	err = c.query(ctx, queryUpdate, map[string]any{"id": issue.Issue.ID, "stateId": stateID}, &data)
+	if err != nil {
+		return err
+	}
+
+	if !data.IssueUpdate.Success {
+		return fmt.Errorf("linear didn't update the state of issue %s", issueKey)
+	}
+
+	return nil
+}
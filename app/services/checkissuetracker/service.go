@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/stream"
+)
+
+const groupCheckEvents = "gitness:checkissuetracker"
+
+type Config struct {
+	Enabled         bool
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+
+	JiraURL   string
+	JiraUser  string
+	JiraToken string
+
+	LinearURL   string
+	LinearToken string
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if (c.JiraURL == "") != (c.JiraToken == "") {
+		return errors.New("config.JiraURL and config.JiraToken have to be provided together")
+	}
+	if c.LinearToken != "" && c.LinearURL == "" {
+		return errors.New("config.LinearURL is required")
+	}
+	if c.JiraToken == "" && c.LinearToken == "" {
+		return errors.New("at least one issue tracker has to be configured")
+	}
+	return nil
+}
+
+// CheckIssueTrackerIntegration propagates failed status checks to the Jira or Linear issues
+// referenced in the message of the commit the status check was reported for.
+type CheckIssueTrackerIntegration struct {
+	config           Config
+	checkStore       store.CheckStore
+	issueUpdateStore store.CheckIssueUpdateStore
+	repoStore        store.RepoStore
+	git              git.Interface
+	settings         *settings.Service
+	jira             *jiraClient
+	linear           *linearClient
+}
+
+func NewCheckIssueTrackerIntegration(
+	ctx context.Context,
+	config Config,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	checkStore store.CheckStore,
+	issueUpdateStore store.CheckIssueUpdateStore,
+	repoStore store.RepoStore,
+	git git.Interface,
+	settings *settings.Service,
+) (*CheckIssueTrackerIntegration, error) {
+	integration := &CheckIssueTrackerIntegration{
+		config:           config,
+		checkStore:       checkStore,
+		issueUpdateStore: issueUpdateStore,
+		repoStore:        repoStore,
+		git:              git,
+		settings:         settings,
+	}
+
+	if !config.Enabled {
+		return integration, nil
+	}
+
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided status check issue tracker config is invalid: %w", err)
+	}
+
+	if config.JiraToken != "" {
+		integration.jira = newJiraClient(config.JiraURL, config.JiraUser, config.JiraToken)
+	}
+	if config.LinearToken != "" {
+		integration.linear = newLinearClient(config.LinearURL, config.LinearToken)
+	}
+
+	_, err := checkReaderFactory.Launch(ctx, groupCheckEvents, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterStatusChanged(integration.handleEventStatusChanged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for status check issue tracker: %w", err)
+	}
+
+	return integration, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkissuetracker
+
+import (
+	"context"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideCheckIssueTrackerIntegration,
+)
+
+func ProvideCheckIssueTrackerIntegration(
+	ctx context.Context,
+	config Config,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	checkStore store.CheckStore,
+	issueUpdateStore store.CheckIssueUpdateStore,
+	repoStore store.RepoStore,
+	git git.Interface,
+	settings *settings.Service,
+) (*CheckIssueTrackerIntegration, error) {
+	return NewCheckIssueTrackerIntegration(ctx, config, checkReaderFactory, checkStore, issueUpdateStore,
+		repoStore, git, settings)
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+)
+
+const groupCheckRunEvents = "gitness:checkmirror:checks"
+
+type ChecksSyncConfig struct {
+	Enabled         bool
+	EventReaderName string
+	APIURL          string
+	// AppID is the ID of the GitHub App that owns the synced check runs.
+	AppID int64
+	// PrivateKey is the PEM encoded private key of the GitHub App.
+	PrivateKey  string
+	Concurrency int
+	MaxRetries  int
+	// MaxBackoff is the longest the sync service waits before retrying a throttled request.
+	MaxBackoff time.Duration
+}
+
+func (c *ChecksSyncConfig) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.APIURL == "" {
+		return errors.New("config.APIURL is required")
+	}
+	if c.AppID <= 0 {
+		return errors.New("config.AppID is required")
+	}
+	if c.PrivateKey == "" {
+		return errors.New("config.PrivateKey is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.MaxBackoff <= 0 {
+		return errors.New("config.MaxBackoff has to be a positive duration")
+	}
+	return nil
+}
+
+// GitHubChecksSyncService creates and updates GitHub check runs for the status check results
+// of the repositories configured as sync targets, authenticated as a GitHub App installation.
+type GitHubChecksSyncService struct {
+	config     ChecksSyncConfig
+	checkStore store.CheckStore
+	settings   *settings.Service
+	client     *githubClient
+	tokens     *githubAppTokens
+}
+
+func NewGitHubChecksSyncService(
+	ctx context.Context,
+	config ChecksSyncConfig,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	checkStore store.CheckStore,
+	settings *settings.Service,
+) (*GitHubChecksSyncService, error) {
+	service := &GitHubChecksSyncService{
+		config:     config,
+		checkStore: checkStore,
+		settings:   settings,
+	}
+
+	if !config.Enabled {
+		return service, nil
+	}
+
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided github checks sync config is invalid: %w", err)
+	}
+
+	service.client = newGithubClient(config.APIURL, "", config.MaxBackoff)
+
+	tokens, err := newGithubAppTokens(service.client, config.AppID, []byte(config.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github app token source: %w", err)
+	}
+
+	service.tokens = tokens
+
+	_, err = checkReaderFactory.Launch(ctx, groupCheckRunEvents, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterStatusChanged(service.handleEventStatusChanged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for github checks sync: %w", err)
+	}
+
+	return service, nil
+}
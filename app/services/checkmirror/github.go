@@ -0,0 +1,241 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	githubRequestTimeout = 30 * time.Second
+	githubMaxAttempts    = 5
+	githubInitialBackoff = time.Second
+
+	// githubMaxDescriptionLength is the maximum length of a commit status description accepted by GitHub.
+	githubMaxDescriptionLength = 140
+)
+
+// githubStatus is the request body of the GitHub create commit status API.
+type githubStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+// ResponseError is returned if GitHub rejected the request.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+	// Retryable is true if the request failed because of throttling or a server side problem.
+	Retryable bool
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("github responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+type githubClient struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+	maxBackoff time.Duration
+}
+
+func newGithubClient(baseURL, token string, maxBackoff time.Duration) *githubClient {
+	return &githubClient{
+		httpClient: &http.Client{Timeout: githubRequestTimeout},
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// CreateStatus creates a commit status for the provided commit SHA.
+func (c *githubClient) CreateStatus(
+	ctx context.Context,
+	owner, repo, commitSHA string,
+	status githubStatus,
+) error {
+	body, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal github commit status: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/statuses/%s",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(commitSHA))
+
+	return c.request(ctx, http.MethodPost, endpoint, c.token, body, nil)
+}
+
+// request sends the request authenticated with the token and decodes the response body into dst, if provided.
+// Throttled requests are retried after the delay requested by GitHub (capped to maxBackoff),
+// server errors are retried with exponential backoff.
+func (c *githubClient) request(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	token string,
+	body []byte,
+	dst any,
+) error {
+	backoff := githubInitialBackoff
+	for attempt := 1; ; attempt++ {
+		wait, err := c.send(ctx, method, endpoint, token, body, backoff, dst)
+		if err == nil {
+			return nil
+		}
+
+		var respErr *ResponseError
+		if !errors.As(err, &respErr) || !respErr.Retryable || attempt >= githubMaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(wait, c.maxBackoff)):
+		}
+
+		backoff *= 2
+	}
+}
+
+// send sends the request and, if the request failed, returns how long to wait before retrying it.
+func (c *githubClient) send(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	token string,
+	body []byte,
+	backoff time.Duration,
+	dst any,
+) (time.Duration, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create github request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send github request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if dst == nil {
+			return 0, nil
+		}
+
+		if err = json.NewDecoder(resp.Body).Decode(dst); err != nil {
+			return 0, fmt.Errorf("failed to decode github response: %w", err)
+		}
+
+		return 0, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(msg)),
+	}
+
+	switch {
+	case isRateLimited(resp):
+		respErr.Retryable = true
+		return rateLimitDelay(resp.Header, backoff), respErr
+	case resp.StatusCode >= http.StatusInternalServerError:
+		respErr.Retryable = true
+		return backoff, respErr
+	default:
+		return 0, respErr
+	}
+}
+
+// isRateLimited returns true if the response indicates that GitHub throttled the request,
+// either because the primary rate limit was exhausted or because a secondary rate limit was hit.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusForbidden &&
+		(resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != "")
+}
+
+func rateLimitDelay(header http.Header, backoff time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+			return wait
+		}
+	}
+
+	return backoff
+}
+
+// mapCheckStatus maps a status check status to the state of a GitHub commit status.
+func mapCheckStatus(status enum.CheckStatus) string {
+	switch status {
+	case enum.CheckStatusSuccess, enum.CheckStatusSkipped:
+		return "success"
+	case enum.CheckStatusFailure:
+		return "failure"
+	case enum.CheckStatusError:
+		return "error"
+	case enum.CheckStatusPending, enum.CheckStatusRunning:
+		return "pending"
+	default:
+		return "pending"
+	}
+}
+
+func truncateDescription(s string) string {
+	r := []rune(s)
+	if len(r) <= githubMaxDescriptionLength {
+		return s
+	}
+
+	return string(r[:githubMaxDescriptionLength-3]) + "..."
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// githubAppJWTLifetime is how long the JWTs authenticating as the GitHub App are valid (GitHub allows 10 minutes).
+	githubAppJWTLifetime = 9 * time.Minute
+
+	// githubAppClockDrift is how far the issue time of the JWTs is backdated to allow for clock drift.
+	githubAppClockDrift = time.Minute
+
+	// githubTokenRefreshMargin is how long before their expiry installation access tokens are refreshed.
+	githubTokenRefreshMargin = 5 * time.Minute
+)
+
+// githubInstallationToken is an access token of a GitHub App installation.
+type githubInstallationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// githubAppTokens provides the access tokens of the installations of a GitHub App.
+// The tokens are cached until shortly before they expire.
+type githubAppTokens struct {
+	client *githubClient
+	appID  int64
+	key    *rsa.PrivateKey
+
+	mx     sync.Mutex
+	tokens map[int64]githubInstallationToken
+}
+
+func newGithubAppTokens(client *githubClient, appID int64, privateKeyPEM []byte) (*githubAppTokens, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse github app private key: %w", err)
+	}
+
+	return &githubAppTokens{
+		client: client,
+		appID:  appID,
+		key:    key,
+		tokens: map[int64]githubInstallationToken{},
+	}, nil
+}
+
+// Get returns an access token of the installation, creating a new one if there is no valid cached token.
+func (t *githubAppTokens) Get(ctx context.Context, installationID int64) (string, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if token, ok := t.tokens[installationID]; ok && time.Until(token.ExpiresAt) > githubTokenRefreshMargin {
+		return token.Token, nil
+	}
+
+	appJWT, err := t.appJWT()
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/app/installations/%d/access_tokens", t.client.baseURL, installationID)
+
+	var token githubInstallationToken
+	if err = t.client.request(ctx, http.MethodPost, endpoint, appJWT, []byte("{}"), &token); err != nil {
+		return "", fmt.Errorf("failed to create github app installation access token: %w", err)
+	}
+
+	t.tokens[installationID] = token
+
+	return token.Token, nil
+}
+
+// appJWT returns a JWT authenticating as the GitHub App.
+func (t *githubAppTokens) appJWT() (string, error) {
+	now := time.Now()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.StandardClaims{
+		IssuedAt:  now.Add(-githubAppClockDrift).Unix(),
+		ExpiresAt: now.Add(githubAppJWTLifetime).Unix(),
+		Issuer:    strconv.FormatInt(t.appID, 10),
+	})
+
+	signed, err := token.SignedString(t.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign github app jwt: %w", err)
+	}
+
+	return signed, nil
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// githubMaxAnnotations is the maximum number of annotations GitHub accepts per check run request.
+	githubMaxAnnotations = 50
+
+	// githubMaxOutputTitleLength is the maximum length of the title of a check run output shown by the mirror.
+	githubMaxOutputTitleLength = 255
+
+	// githubMaxOutputTextLength is the maximum length of the summary and the text of a check run output
+	// accepted by GitHub.
+	githubMaxOutputTextLength = 65535
+)
+
+// githubCheckRun is the request body of the GitHub create and update check run APIs.
+type githubCheckRun struct {
+	Name        string                `json:"name"`
+	HeadSHA     string                `json:"head_sha,omitempty"`
+	DetailsURL  string                `json:"details_url,omitempty"`
+	ExternalID  string                `json:"external_id"`
+	Status      string                `json:"status"`
+	Conclusion  string                `json:"conclusion,omitempty"`
+	StartedAt   *time.Time            `json:"started_at,omitempty"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+	Output      *githubCheckRunOutput `json:"output,omitempty"`
+}
+
+type githubCheckRunOutput struct {
+	Title       string                     `json:"title"`
+	Summary     string                     `json:"summary"`
+	Text        string                     `json:"text,omitempty"`
+	Annotations []githubCheckRunAnnotation `json:"annotations,omitempty"`
+	Images      []githubCheckRunImage      `json:"images,omitempty"`
+}
+
+type githubCheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
+type githubCheckRunImage struct {
+	Alt      string `json:"alt"`
+	ImageURL string `json:"image_url"`
+}
+
+// githubCheckRunRef identifies an existing check run.
+type githubCheckRunRef struct {
+	ID         int64  `json:"id"`
+	ExternalID string `json:"external_id"`
+}
+
+// FindCheckRun returns the latest check run of the GitHub App with the name for the provided commit SHA,
+// or nil if there is none.
+func (c *githubClient) FindCheckRun(
+	ctx context.Context,
+	token string,
+	owner, repo, commitSHA string,
+	appID int64,
+	name string,
+) (*githubCheckRunRef, error) {
+	query := url.Values{}
+	query.Set("check_name", name)
+	query.Set("filter", "latest")
+	query.Set("app_id", strconv.FormatInt(appID, 10))
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/commits/%s/check-runs?%s",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(commitSHA), query.Encode())
+
+	var result struct {
+		CheckRuns []githubCheckRunRef `json:"check_runs"`
+	}
+	if err := c.request(ctx, http.MethodGet, endpoint, token, nil, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.CheckRuns) == 0 {
+		return nil, nil //nolint:nilnil // no check run is not an error
+	}
+
+	return &result.CheckRuns[0], nil
+}
+
+// CreateCheckRun creates a check run.
+func (c *githubClient) CreateCheckRun(ctx context.Context, token string, owner, repo string, run githubCheckRun) error {
+	body, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal github check run: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/check-runs", c.baseURL, url.PathEscape(owner), url.PathEscape(repo))
+
+	return c.request(ctx, http.MethodPost, endpoint, token, body, nil)
+}
+
+// UpdateCheckRun updates an existing check run.
+func (c *githubClient) UpdateCheckRun(
+	ctx context.Context,
+	token string,
+	owner, repo string,
+	id int64,
+	run githubCheckRun,
+) error {
+	// the commit of a check run can't be changed.
+	run.HeadSHA = ""
+
+	body, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal github check run: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/check-runs/%d",
+		c.baseURL, url.PathEscape(owner), url.PathEscape(repo), id)
+
+	return c.request(ctx, http.MethodPatch, endpoint, token, body, nil)
+}
+
+// mapCheckRun maps a status check with its annotations to a GitHub check run.
+// Annotations are only included once the status check completed, as GitHub appends the annotations
+// of every update of a check run instead of replacing them.
+func mapCheckRun(check *types.CheckWithAnnotations) githubCheckRun {
+	run := githubCheckRun{
+		Name:       check.Identifier,
+		HeadSHA:    check.CommitSHA,
+		DetailsURL: check.Link,
+		ExternalID: strconv.FormatInt(check.ID, 10),
+	}
+
+	switch check.Status {
+	case enum.CheckStatusPending:
+		run.Status = "queued"
+	case enum.CheckStatusRunning:
+		run.Status = "in_progress"
+	default:
+		run.Status = "completed"
+		run.Conclusion = mapCheckRunConclusion(check.Status)
+	}
+
+	if check.Started > 0 {
+		started := time.UnixMilli(check.Started).UTC()
+		run.StartedAt = &started
+	}
+	if run.Status == "completed" && check.Ended > 0 {
+		completed := time.UnixMilli(check.Ended).UTC()
+		run.CompletedAt = &completed
+	}
+
+	output := &githubCheckRunOutput{
+		Title:   truncate(firstLine(check.Summary), githubMaxOutputTitleLength),
+		Summary: truncate(check.Summary, githubMaxOutputTextLength),
+	}
+	if output.Title == "" {
+		output.Title = check.Identifier
+	}
+	if output.Summary == "" {
+		output.Summary = string(check.Status)
+	}
+
+	if text, ok := checkPayloadText(&check.Check); ok {
+		output.Text = truncate(text, githubMaxOutputTextLength)
+		if check.Payload.Kind == enum.CheckPayloadKindMarkdown {
+			output.Images = markdownImages(text)
+		}
+	}
+
+	if run.Status == "completed" {
+		for _, a := range check.Annotations {
+			if len(output.Annotations) == githubMaxAnnotations {
+				break
+			}
+			output.Annotations = append(output.Annotations, mapCheckRunAnnotation(a))
+		}
+	}
+
+	run.Output = output
+
+	return run
+}
+
+// mapCheckRunConclusion maps the status of a completed status check to the conclusion of a GitHub check run.
+func mapCheckRunConclusion(status enum.CheckStatus) string {
+	switch status {
+	case enum.CheckStatusSuccess:
+		return "success"
+	case enum.CheckStatusSkipped:
+		return "skipped"
+	case enum.CheckStatusFailure, enum.CheckStatusError:
+		return "failure"
+	default:
+		return "neutral"
+	}
+}
+
+func mapCheckRunAnnotation(a types.CheckAnnotation) githubCheckRunAnnotation {
+	level := "warning"
+	switch a.Level {
+	case enum.CheckAnnotationLevelNotice:
+		level = "notice"
+	case enum.CheckAnnotationLevelFailure:
+		level = "failure"
+	case enum.CheckAnnotationLevelWarning:
+	}
+
+	startLine := max(a.LineStart, 1)
+
+	return githubCheckRunAnnotation{
+		Path:            a.Path,
+		StartLine:       startLine,
+		EndLine:         max(a.LineEnd, startLine),
+		AnnotationLevel: level,
+		Title:           a.Title,
+		Message:         a.Message,
+	}
+}
+
+// checkPayloadText returns the details of status checks with a text payload.
+func checkPayloadText(check *types.Check) (string, bool) {
+	if check.Payload.Kind != enum.CheckPayloadKindMarkdown && check.Payload.Kind != enum.CheckPayloadKindRaw {
+		return "", false
+	}
+
+	payload, err := types.DecodePayload(check)
+	if err != nil {
+		return "", false
+	}
+
+	text, ok := payload.(*types.CheckPayloadText)
+	if !ok || text.Details == "" {
+		return "", false
+	}
+
+	return text.Details, true
+}
+
+// regexpMarkdownImage matches markdown images with an absolute http(s) URL, e.g. ![coverage](https://...).
+var regexpMarkdownImage = regexp.MustCompile(`!\[([^\]]*)\]\((https?://[^\s)]+)\)`)
+
+// markdownImages returns the images embedded in the markdown, so that GitHub shows them in the check run output.
+func markdownImages(markdown string) []githubCheckRunImage {
+	var images []githubCheckRunImage
+	for _, match := range regexpMarkdownImage.FindAllStringSubmatch(markdown, -1) {
+		images = append(images, githubCheckRunImage{Alt: match[1], ImageURL: match[2]})
+	}
+
+	return images
+}
+
+func firstLine(s string) string {
+	for i, r := range s {
+		if r == '\n' || r == '\r' {
+			return s[:i]
+		}
+	}
+
+	return s
+}
+
+func truncate(s string, maxLength int) string {
+	r := []rune(s)
+	if len(r) <= maxLength {
+		return s
+	}
+
+	return string(r[:maxLength-3]) + "..."
+}
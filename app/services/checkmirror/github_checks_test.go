@@ -0,0 +1,176 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMapCheckRun(t *testing.T) {
+	payload, _ := json.Marshal(types.CheckPayloadText{
+		Details: "## Coverage\n![coverage](https://example.com/coverage.svg)\n![local](/img.png)",
+	})
+
+	check := &types.CheckWithAnnotations{
+		Check: types.Check{
+			ID:         42,
+			CommitSHA:  "abc",
+			Identifier: "lint",
+			Status:     enum.CheckStatusError,
+			Summary:    "3 problems\nsee details",
+			Link:       "https://ci.example.com/42",
+			Started:    1000,
+			Ended:      2000,
+			Payload: types.CheckPayload{
+				Kind: enum.CheckPayloadKindMarkdown,
+				Data: payload,
+			},
+		},
+		Annotations: []types.CheckAnnotation{
+			{Path: "main.go", LineStart: 0, LineEnd: 0, Level: enum.CheckAnnotationLevelFailure, Message: "m"},
+		},
+	}
+
+	run := mapCheckRun(check)
+
+	if run.Name != "lint" || run.HeadSHA != "abc" || run.ExternalID != "42" ||
+		run.DetailsURL != "https://ci.example.com/42" {
+		t.Fatalf("unexpected check run identity: %+v", run)
+	}
+	if run.Status != "completed" || run.Conclusion != "failure" {
+		t.Errorf("expected completed with failure, got %q/%q", run.Status, run.Conclusion)
+	}
+	if run.StartedAt == nil || !run.StartedAt.Equal(time.UnixMilli(1000)) ||
+		run.CompletedAt == nil || !run.CompletedAt.Equal(time.UnixMilli(2000)) {
+		t.Errorf("unexpected timestamps: %v - %v", run.StartedAt, run.CompletedAt)
+	}
+	if run.Output.Title != "3 problems" || run.Output.Summary != check.Summary {
+		t.Errorf("unexpected output title/summary: %q/%q", run.Output.Title, run.Output.Summary)
+	}
+	if len(run.Output.Images) != 1 || run.Output.Images[0].ImageURL != "https://example.com/coverage.svg" ||
+		run.Output.Images[0].Alt != "coverage" {
+		t.Errorf("unexpected images: %+v", run.Output.Images)
+	}
+	if len(run.Output.Annotations) != 1 {
+		t.Fatalf("expected 1 annotation, got %d", len(run.Output.Annotations))
+	}
+	if a := run.Output.Annotations[0]; a.AnnotationLevel != "failure" || a.StartLine != 1 || a.EndLine != 1 {
+		t.Errorf("unexpected annotation: %+v", a)
+	}
+
+	check.Status = enum.CheckStatusRunning
+	run = mapCheckRun(check)
+	if run.Status != "in_progress" || run.Conclusion != "" || run.CompletedAt != nil {
+		t.Errorf("expected in progress check run, got %+v", run)
+	}
+	if len(run.Output.Annotations) != 0 {
+		t.Errorf("expected no annotations for running check, got %d", len(run.Output.Annotations))
+	}
+}
+
+func TestGitHubChecksSyncService_Sync(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var (
+		tokenRequests int
+		created       []githubCheckRun
+		updated       = map[string]githubCheckRun{}
+		runs          []githubCheckRunRef
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /app/installations/7/access_tokens", func(w http.ResponseWriter, _ *http.Request) {
+		tokenRequests++
+		_ = json.NewEncoder(w).Encode(githubInstallationToken{Token: "inst", ExpiresAt: time.Now().Add(time.Hour)})
+	})
+	mux.HandleFunc("GET /repos/o/r/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer inst" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("app_id") != "1" || r.URL.Query().Get("check_name") != "lint" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"check_runs": runs})
+	})
+	mux.HandleFunc("POST /repos/o/r/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		var run githubCheckRun
+		_ = json.NewDecoder(r.Body).Decode(&run)
+		created = append(created, run)
+		runs = []githubCheckRunRef{{ID: 99, ExternalID: run.ExternalID}}
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("PATCH /repos/o/r/check-runs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var run githubCheckRun
+		_ = json.NewDecoder(r.Body).Decode(&run)
+		updated[r.PathValue("id")] = run
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newGithubClient(server.URL, "", time.Second)
+	tokens, err := newGithubAppTokens(client, 1, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &GitHubChecksSyncService{
+		config: ChecksSyncConfig{AppID: 1},
+		client: client,
+		tokens: tokens,
+	}
+
+	check := &types.CheckWithAnnotations{Check: types.Check{
+		ID: 5, CommitSHA: "abc", Identifier: "lint", Status: enum.CheckStatusRunning,
+	}}
+
+	ctx := context.Background()
+	if err = s.sync(ctx, 7, "o", "r", check); err != nil {
+		t.Fatalf("failed to create check run: %v", err)
+	}
+
+	check.Status = enum.CheckStatusSuccess
+	if err = s.sync(ctx, 7, "o", "r", check); err != nil {
+		t.Fatalf("failed to update check run: %v", err)
+	}
+
+	if len(created) != 1 || created[0].Status != "in_progress" {
+		t.Errorf("expected one created in progress check run, got %+v", created)
+	}
+	if run, ok := updated["99"]; !ok || run.Conclusion != "success" || run.HeadSHA != "" {
+		t.Errorf("expected check run 99 to be updated to success, got %+v", updated)
+	}
+	if tokenRequests != 1 {
+		t.Errorf("expected the installation token to be cached, got %d token requests", tokenRequests)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (m *GithubStatusMirror) handleEventStatusChanged(
+	ctx context.Context,
+	event *events.Event[*checkevents.StatusChangedPayload],
+) error {
+	var target string
+	_, err := m.settings.RepoGet(ctx, event.Payload.RepoID, settings.KeyGithubStatusMirrorRepo, &target)
+	if err != nil {
+		return fmt.Errorf("failed to get github status mirror setting: %w", err)
+	}
+
+	if target == "" {
+		return nil
+	}
+
+	owner, repo, ok := strings.Cut(target, "/")
+	if !ok || owner == "" || repo == "" {
+		return events.NewDiscardEventError(fmt.Errorf("invalid github repository %q", target))
+	}
+
+	// always mirror the latest state of the status check, events might get processed out of order.
+	// the status check is found by its ID, the identifier is only unique within its namespace.
+	check, err := m.checkStore.FindByID(ctx, event.Payload.CheckID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return events.NewDiscardEventError(fmt.Errorf("status check %q not found", event.Payload.Identifier))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find status check: %w", err)
+	}
+
+	// the commit statuses are visible to everyone who can read the github repository.
+	if check.Visibility != enum.CheckVisibilityPublic {
+		return nil
+	}
+
+	err = m.client.CreateStatus(ctx, owner, repo, check.CommitSHA, githubStatus{
+		State:       mapCheckStatus(check.Status),
+		TargetURL:   check.Link,
+		Description: truncateDescription(check.Summary),
+		Context:     check.Identifier,
+	})
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) && !respErr.Retryable {
+		return events.NewDiscardEventError(fmt.Errorf("github rejected commit status: %w", err))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to mirror status check to github: %w", err)
+	}
+
+	return nil
+}
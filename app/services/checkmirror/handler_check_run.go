@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *GitHubChecksSyncService) handleEventStatusChanged(
+	ctx context.Context,
+	event *events.Event[*checkevents.StatusChangedPayload],
+) error {
+	var target string
+	_, err := s.settings.RepoGet(ctx, event.Payload.RepoID, settings.KeyGithubChecksSyncRepo, &target)
+	if err != nil {
+		return fmt.Errorf("failed to get github checks sync setting: %w", err)
+	}
+
+	if target == "" {
+		return nil
+	}
+
+	owner, repo, ok := strings.Cut(target, "/")
+	if !ok || owner == "" || repo == "" {
+		return events.NewDiscardEventError(fmt.Errorf("invalid github repository %q", target))
+	}
+
+	var installationID int64
+	_, err = s.settings.RepoGet(ctx, event.Payload.RepoID, settings.KeyGithubChecksInstallationID, &installationID)
+	if err != nil {
+		return fmt.Errorf("failed to get github checks installation setting: %w", err)
+	}
+
+	if installationID <= 0 {
+		return events.NewDiscardEventError(
+			fmt.Errorf("github app installation isn't configured for repository %q", target))
+	}
+
+	// always sync the latest state of the status check, events might get processed out of order.
+	check, err := s.findCheck(ctx, event.Payload)
+	if err != nil {
+		return err
+	}
+
+	// the check runs are visible to everyone who can read the github repository.
+	if check.Visibility != enum.CheckVisibilityPublic {
+		return nil
+	}
+
+	err = s.sync(ctx, installationID, owner, repo, check)
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) && !respErr.Retryable {
+		return events.NewDiscardEventError(fmt.Errorf("github rejected check run: %w", err))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sync status check to github: %w", err)
+	}
+
+	return nil
+}
+
+func (s *GitHubChecksSyncService) findCheck(
+	ctx context.Context,
+	payload *checkevents.StatusChangedPayload,
+) (*types.CheckWithAnnotations, error) {
+	checks, err := s.checkStore.ListWithAnnotations(ctx, payload.RepoID, payload.CommitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	for _, check := range checks {
+		if check.ID == payload.CheckID {
+			return check, nil
+		}
+	}
+
+	return nil, events.NewDiscardEventError(fmt.Errorf("status check %q not found", payload.Identifier))
+}
+
+// sync updates the check run created for the status check, or creates a new one if there is none yet.
+func (s *GitHubChecksSyncService) sync(
+	ctx context.Context,
+	installationID int64,
+	owner, repo string,
+	check *types.CheckWithAnnotations,
+) error {
+	token, err := s.tokens.Get(ctx, installationID)
+	if err != nil {
+		return err
+	}
+
+	run := mapCheckRun(check)
+
+	existing, err := s.client.FindCheckRun(ctx, token, owner, repo, check.CommitSHA, s.config.AppID, run.Name)
+	if err != nil {
+		return fmt.Errorf("failed to find github check run: %w", err)
+	}
+
+	if existing != nil && existing.ExternalID == strconv.FormatInt(check.ID, 10) {
+		return s.client.UpdateCheckRun(ctx, token, owner, repo, existing.ID, run)
+	}
+
+	return s.client.CreateCheckRun(ctx, token, owner, repo, run)
+}
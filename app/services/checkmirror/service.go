@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+)
+
+const groupCheckEvents = "gitness:checkmirror"
+
+type Config struct {
+	Enabled         bool
+	EventReaderName string
+	APIURL          string
+	Token           string
+	Concurrency     int
+	MaxRetries      int
+	// MaxBackoff is the longest the mirror waits before retrying a throttled request.
+	MaxBackoff time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.APIURL == "" {
+		return errors.New("config.APIURL is required")
+	}
+	if c.Token == "" {
+		return errors.New("config.Token is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.MaxBackoff <= 0 {
+		return errors.New("config.MaxBackoff has to be a positive duration")
+	}
+	return nil
+}
+
+// GithubStatusMirror propagates status check results to the GitHub commit status API
+// of the repositories configured as mirror targets.
+type GithubStatusMirror struct {
+	config     Config
+	checkStore store.CheckStore
+	settings   *settings.Service
+	client     *githubClient
+}
+
+func NewGithubStatusMirror(
+	ctx context.Context,
+	config Config,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	checkStore store.CheckStore,
+	settings *settings.Service,
+) (*GithubStatusMirror, error) {
+	mirror := &GithubStatusMirror{
+		config:     config,
+		checkStore: checkStore,
+		settings:   settings,
+	}
+
+	if !config.Enabled {
+		return mirror, nil
+	}
+
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided github status mirror config is invalid: %w", err)
+	}
+
+	mirror.client = newGithubClient(config.APIURL, config.Token, config.MaxBackoff)
+
+	_, err := checkReaderFactory.Launch(ctx, groupCheckEvents, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterStatusChanged(mirror.handleEventStatusChanged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for github status mirror: %w", err)
+	}
+
+	return mirror, nil
+}
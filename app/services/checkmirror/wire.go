@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkmirror
+
+import (
+	"context"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideGithubStatusMirror,
+	ProvideGitHubChecksSyncService,
+)
+
+func ProvideGithubStatusMirror(
+	ctx context.Context,
+	config Config,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	checkStore store.CheckStore,
+	settings *settings.Service,
+) (*GithubStatusMirror, error) {
+	return NewGithubStatusMirror(ctx, config, checkReaderFactory, checkStore, settings)
+}
+
+func ProvideGitHubChecksSyncService(
+	ctx context.Context,
+	config ChecksSyncConfig,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	checkStore store.CheckStore,
+	settings *settings.Service,
+) (*GitHubChecksSyncService, error) {
+	return NewGitHubChecksSyncService(ctx, config, checkReaderFactory, checkStore, settings)
+}
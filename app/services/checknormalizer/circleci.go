@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checknormalizer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	circleCIEventWorkflowCompleted = "workflow-completed"
+	circleCIEventJobCompleted      = "job-completed"
+)
+
+// circleCIEvent is the payload of the CircleCI workflow-completed and job-completed webhook events.
+type circleCIEvent struct {
+	Type     string `json:"type"`
+	Workflow struct {
+		Name      string `json:"name"`
+		URL       string `json:"url"`
+		Status    string `json:"status"`
+		CreatedAt string `json:"created_at"`
+		StoppedAt string `json:"stopped_at"`
+	} `json:"workflow"`
+	Pipeline struct {
+		Number int `json:"number"`
+		VCS    struct {
+			Revision string `json:"revision"`
+		} `json:"vcs"`
+	} `json:"pipeline"`
+	Job *struct {
+		Name      string `json:"name"`
+		Number    int    `json:"number"`
+		Status    string `json:"status"`
+		StartedAt string `json:"started_at"`
+		StoppedAt string `json:"stopped_at"`
+	} `json:"job"`
+}
+
+type circleCINormalizer struct{}
+
+func (circleCINormalizer) Normalize(raw []byte, source string) (*types.Check, error) {
+	event := circleCIEvent{}
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal CircleCI event: %w", err)
+	}
+
+	if event.Pipeline.VCS.Revision == "" {
+		return nil, errors.New("commit revision is missing in the CircleCI event")
+	}
+
+	check := &types.Check{
+		CommitSHA: event.Pipeline.VCS.Revision,
+		Link:      event.Workflow.URL,
+		Labels:    []string{source},
+	}
+
+	var err error
+
+	switch event.Type {
+	case circleCIEventWorkflowCompleted:
+		check.Identifier = checkIdentifier(source, event.Workflow.Name)
+		check.Status = circleCIStatus(event.Workflow.Status)
+		check.Summary = fmt.Sprintf("Workflow %s of pipeline #%d: %s",
+			event.Workflow.Name, event.Pipeline.Number, event.Workflow.Status)
+
+		if check.Started, err = parseTime(event.Workflow.CreatedAt); err != nil {
+			return nil, err
+		}
+		if check.Ended, err = parseTime(event.Workflow.StoppedAt); err != nil {
+			return nil, err
+		}
+	case circleCIEventJobCompleted:
+		if event.Job == nil {
+			return nil, errors.New("job is missing in the CircleCI job-completed event")
+		}
+
+		check.Identifier = checkIdentifier(source, event.Workflow.Name, event.Job.Name)
+		check.Status = circleCIStatus(event.Job.Status)
+		check.Summary = fmt.Sprintf("Job %s #%d: %s", event.Job.Name, event.Job.Number, event.Job.Status)
+
+		if check.Started, err = parseTime(event.Job.StartedAt); err != nil {
+			return nil, err
+		}
+		if check.Ended, err = parseTime(event.Job.StoppedAt); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported CircleCI event type %q", event.Type)
+	}
+
+	return check, nil
+}
+
+func circleCIStatus(status string) enum.CheckStatus {
+	switch status {
+	case "success":
+		return enum.CheckStatusSuccess
+	case "failed":
+		return enum.CheckStatusFailure
+	case "not_run":
+		return enum.CheckStatusSkipped
+	case "running", "on_hold":
+		return enum.CheckStatusRunning
+	default: // error, canceled, unauthorized
+		return enum.CheckStatusError
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checknormalizer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// jenkinsJob is the payload of the Jenkins notification plugin.
+type jenkinsJob struct {
+	Name  string `json:"name"`
+	Build struct {
+		FullURL string `json:"full_url"`
+		Number  int    `json:"number"`
+		Phase   string `json:"phase"`
+		Status  string `json:"status"`
+		SCM     struct {
+			Commit string `json:"commit"`
+		} `json:"scm"`
+	} `json:"build"`
+}
+
+type jenkinsNormalizer struct{}
+
+func (jenkinsNormalizer) Normalize(raw []byte, source string) (*types.Check, error) {
+	job := jenkinsJob{}
+	if err := json.Unmarshal(raw, &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Jenkins job notification: %w", err)
+	}
+
+	if job.Build.SCM.Commit == "" {
+		return nil, errors.New("commit is missing in the Jenkins job notification")
+	}
+
+	status := jenkinsStatus(job.Build.Phase, job.Build.Status)
+
+	summary := fmt.Sprintf("Build #%d: %s", job.Build.Number, job.Build.Phase)
+	if status.IsCompleted() {
+		summary = fmt.Sprintf("Build #%d: %s", job.Build.Number, job.Build.Status)
+	}
+
+	return &types.Check{
+		CommitSHA:  job.Build.SCM.Commit,
+		Identifier: checkIdentifier(source, job.Name),
+		Status:     status,
+		Summary:    summary,
+		Link:       job.Build.FullURL,
+		Labels:     []string{source},
+	}, nil
+}
+
+func jenkinsStatus(phase, status string) enum.CheckStatus {
+	switch phase {
+	case "QUEUED":
+		return enum.CheckStatusPending
+	case "STARTED":
+		return enum.CheckStatusRunning
+	}
+
+	switch status {
+	case "SUCCESS":
+		return enum.CheckStatusSuccess
+	case "FAILURE", "UNSTABLE":
+		return enum.CheckStatusFailure
+	case "NOT_BUILT":
+		return enum.CheckStatusSkipped
+	default: // ABORTED
+		return enum.CheckStatusError
+	}
+}
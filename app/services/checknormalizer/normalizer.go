@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checknormalizer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// Sources of the status check payloads supported by the normalizers.
+const (
+	SourceCircleCI = "circleci"
+	SourceTravis   = "travis"
+	SourceJenkins  = "jenkins"
+)
+
+// maxIdentifierLength is the maximum length of status check identifiers.
+const maxIdentifierLength = 127
+
+var ErrUnknownSource = errors.New("unknown status check payload source")
+
+// PayloadNormalizer converts a status check payload of a third-party CI system to a status check.
+// The returned status check has the commit SHA, identifier, status, summary, link and labels set,
+// and the start and end time if provided by the CI system.
+type PayloadNormalizer interface {
+	Normalize(raw []byte, source string) (*types.Check, error)
+}
+
+// Normalizers is a PayloadNormalizer that dispatches the payloads to the normalizer of their source.
+type Normalizers map[string]PayloadNormalizer
+
+// NewNormalizers returns the normalizers of all supported sources.
+func NewNormalizers() Normalizers {
+	return Normalizers{
+		SourceCircleCI: circleCINormalizer{},
+		SourceTravis:   travisNormalizer{},
+		SourceJenkins:  jenkinsNormalizer{},
+	}
+}
+
+// Normalize converts the payload with the normalizer of the source.
+func (n Normalizers) Normalize(raw []byte, source string) (*types.Check, error) {
+	normalizer, ok := n[source]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+	}
+
+	return normalizer.Normalize(raw, source)
+}
+
+// checkIdentifier returns a valid status check identifier composed of the source and the names
+// of the CI system, e.g. "circleci/build/test".
+func checkIdentifier(source string, names ...string) string {
+	identifier := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '-', r == '_', r == '.', r == '/':
+			return r
+		default:
+			return '-'
+		}
+	}, strings.Join(append([]string{source}, names...), "/"))
+
+	if len(identifier) > maxIdentifierLength {
+		identifier = identifier[:maxIdentifierLength]
+	}
+
+	return identifier
+}
+
+// parseTime converts an RFC 3339 timestamp to unix milliseconds. An empty timestamp is converted to zero.
+func parseTime(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q: %w", s, err)
+	}
+
+	return t.UnixMilli(), nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checknormalizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestNormalizers_Normalize(t *testing.T) {
+	tests := []struct {
+		source string
+		sample string
+	}{
+		{source: SourceCircleCI, sample: "circleci_workflow_completed"},
+		{source: SourceCircleCI, sample: "circleci_job_completed"},
+		{source: SourceTravis, sample: "travis"},
+		{source: SourceJenkins, sample: "jenkins"},
+	}
+
+	normalizers := NewNormalizers()
+
+	for _, tt := range tests {
+		t.Run(tt.sample, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join("testdata", tt.sample+".json"))
+			if err != nil {
+				t.Fatalf("failed to read sample payload: %v", err)
+			}
+
+			check, err := normalizers.Normalize(raw, tt.source)
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+
+			// the commit SHA isn't part of the JSON representation of status checks.
+			got, err := json.MarshalIndent(struct {
+				CommitSHA string       `json:"commit_sha"`
+				Check     *types.Check `json:"check"`
+			}{CommitSHA: check.CommitSHA, Check: check}, "", "  ")
+			if err != nil {
+				t.Fatalf("failed to marshal status check: %v", err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", tt.sample+".golden")
+			if *update {
+				if err = os.WriteFile(golden, got, 0o600); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("Normalize() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestNormalizers_NormalizeUnknownSource(t *testing.T) {
+	_, err := NewNormalizers().Normalize([]byte("{}"), "unknown")
+	if !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Normalize() error = %v, want %v", err, ErrUnknownSource)
+	}
+}
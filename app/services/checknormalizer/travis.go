@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checknormalizer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// travisBuild is the payload of the Travis CI build webhook notification.
+type travisBuild struct {
+	Number        string `json:"number"`
+	Type          string `json:"type"`
+	State         string `json:"state"`
+	StatusMessage string `json:"status_message"`
+	BuildURL      string `json:"build_url"`
+	Commit        string `json:"commit"`
+	StartedAt     string `json:"started_at"`
+	FinishedAt    string `json:"finished_at"`
+}
+
+type travisNormalizer struct{}
+
+func (travisNormalizer) Normalize(raw []byte, source string) (*types.Check, error) {
+	build := travisBuild{}
+	if err := json.Unmarshal(raw, &build); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Travis CI build: %w", err)
+	}
+
+	if build.Commit == "" {
+		return nil, errors.New("commit is missing in the Travis CI build")
+	}
+
+	started, err := parseTime(build.StartedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	ended, err := parseTime(build.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	buildType := build.Type
+	if buildType == "" {
+		buildType = "push"
+	}
+
+	return &types.Check{
+		CommitSHA:  build.Commit,
+		Identifier: checkIdentifier(source, buildType),
+		Status:     travisStatus(build.State),
+		Summary:    fmt.Sprintf("Build #%s: %s", build.Number, build.StatusMessage),
+		Link:       build.BuildURL,
+		Labels:     []string{source},
+		Started:    started,
+		Ended:      ended,
+	}, nil
+}
+
+func travisStatus(state string) enum.CheckStatus {
+	switch state {
+	case "created", "received", "queued":
+		return enum.CheckStatusPending
+	case "started":
+		return enum.CheckStatusRunning
+	case "passed":
+		return enum.CheckStatusSuccess
+	case "failed":
+		return enum.CheckStatusFailure
+	default: // errored, canceled
+		return enum.CheckStatusError
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type Config struct {
+	// WASMModulePath is the path of the WASM module status check payloads are processed with.
+	// Empty disables the processing.
+	WASMModulePath string
+	// Timeout is the time limit of processing a single payload.
+	Timeout time.Duration
+	// MemoryLimit is the maximum memory in bytes available to the WASM module.
+	MemoryLimit int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.WASMModulePath == "" {
+		return nil
+	}
+	if c.Timeout <= 0 {
+		return errors.New("config.Timeout has to be a positive duration")
+	}
+	if c.MemoryLimit < wasmPageSize {
+		return errors.New("config.MemoryLimit has to be at least 64 KiB")
+	}
+	return nil
+}
+
+// PayloadProcessor transforms the data of status check payloads before they're stored.
+type PayloadProcessor interface {
+	Process(ctx context.Context, data json.RawMessage) (json.RawMessage, error)
+}
+
+// nopProcessor is the PayloadProcessor used if no processing is configured, it returns the data as is.
+type nopProcessor struct{}
+
+func (nopProcessor) Process(_ context.Context, data json.RawMessage) (json.RawMessage, error) {
+	return data, nil
+}
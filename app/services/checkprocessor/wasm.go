@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	wasmPageSize = 64 * 1024
+
+	wasmExportAlloc   = "alloc"
+	wasmExportProcess = "process"
+)
+
+var _ PayloadProcessor = (*WASMPayloadProcessor)(nil)
+
+// WASMPayloadProcessor is a PayloadProcessor that transforms payload data with a user-supplied WASM module.
+//
+// The module has to export its memory and the following functions:
+//   - alloc(size i32) i32 returns the offset of a buffer of the size in the module memory,
+//     the payload data is written to it.
+//   - process(offset i32, size i32) i64 transforms the payload data in the buffer and returns
+//     the offset of the resulting JSON in the upper and its size in the lower 32 bits.
+//
+// Each payload is processed by a fresh instance of the module, which has no access to the file system,
+// network or environment and is stopped once the timeout or the memory limit are exceeded.
+type WASMPayloadProcessor struct {
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+	timeout time.Duration
+}
+
+// NewWASMPayloadProcessor compiles the WASM module and returns a processor that runs it.
+func NewWASMPayloadProcessor(
+	ctx context.Context,
+	module []byte,
+	timeout time.Duration,
+	memoryLimit int,
+) (*WASMPayloadProcessor, error) {
+	if memoryLimit < wasmPageSize {
+		return nil, fmt.Errorf("status check payload processor memory limit %d is below the WASM page size %d",
+			memoryLimit, wasmPageSize)
+	}
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(memoryLimit/wasmPageSize)).
+		WithCloseOnContextDone(true))
+
+	// modules compiled with common toolchains import WASI even if they don't use it.
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to instantiate WASI: %w", err)
+	}
+
+	compiled, err := runtime.CompileModule(ctx, module)
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to compile status check payload processor module: %w", err)
+	}
+
+	exports := compiled.ExportedFunctions()
+	for _, name := range []string{wasmExportAlloc, wasmExportProcess} {
+		if _, ok := exports[name]; !ok {
+			_ = runtime.Close(ctx)
+			return nil, fmt.Errorf("status check payload processor module doesn't export function %q", name)
+		}
+	}
+
+	if len(compiled.ExportedMemories()) == 0 {
+		_ = runtime.Close(ctx)
+		return nil, errors.New("status check payload processor module doesn't export its memory")
+	}
+
+	return &WASMPayloadProcessor{
+		runtime: runtime,
+		module:  compiled,
+		timeout: timeout,
+	}, nil
+}
+
+// Process transforms the payload data with the WASM module. The transformed data has to be valid JSON.
+func (p *WASMPayloadProcessor) Process(ctx context.Context, data json.RawMessage) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	mod, err := p.runtime.InstantiateModule(ctx, p.module, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate status check payload processor module: %w", err)
+	}
+	defer func() {
+		_ = mod.Close(ctx)
+	}()
+
+	results, err := mod.ExportedFunction(wasmExportAlloc).Call(ctx, uint64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate status check payload in processor module: %w", err)
+	}
+
+	offset := api.DecodeU32(results[0])
+	if !mod.Memory().Write(offset, data) {
+		return nil, errors.New("status check payload processor module allocated a buffer out of memory range")
+	}
+
+	results, err = mod.ExportedFunction(wasmExportProcess).Call(ctx, uint64(offset), uint64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to process status check payload: %w", err)
+	}
+
+	out, ok := mod.Memory().Read(uint32(results[0]>>32), uint32(results[0]))
+	if !ok {
+		return nil, errors.New("status check payload processor module returned a result out of memory range")
+	}
+
+	if !json.Valid(out) {
+		return nil, errors.New("status check payload processor module returned invalid JSON")
+	}
+
+	// the memory is released with the module instance.
+	return append(json.RawMessage(nil), out...), nil
+}
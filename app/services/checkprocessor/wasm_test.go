@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkprocessor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// wasmModule returns a module that exports one page of memory, alloc returning the offset 1024
+// and process with the provided body.
+func wasmModule(processBody ...byte) []byte {
+	module := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // magic and version
+		0x01, 0x0c, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e, // types
+		0x03, 0x03, 0x02, 0x00, 0x01, // functions
+		0x05, 0x03, 0x01, 0x00, 0x01, // memory
+		0x07, 0x1c, 0x03, // exports
+		0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+		0x05, 'a', 'l', 'l', 'o', 'c', 0x00, 0x00,
+		0x07, 'p', 'r', 'o', 'c', 'e', 's', 's', 0x00, 0x01,
+	}
+
+	code := []byte{0x02, 0x05, 0x00, 0x41, 0x80, 0x08, 0x0b, byte(len(processBody))}
+	code = append(code, processBody...)
+
+	module = append(module, 0x0a, byte(len(code)))
+	return append(module, code...)
+}
+
+var (
+	// wasmIdentity returns the payload as is.
+	wasmIdentity = wasmModule(0x00, 0x20, 0x00, 0xad, 0x42, 0x20, 0x86, 0x20, 0x01, 0xad, 0x84, 0x0b)
+	// wasmTruncate drops the last byte of the payload.
+	wasmTruncate = wasmModule(0x00, 0x20, 0x00, 0xad, 0x42, 0x20, 0x86, 0x20, 0x01, 0x41, 0x01, 0x6b, 0xad, 0x84, 0x0b)
+	// wasmLoop never returns.
+	wasmLoop = wasmModule(0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x00, 0x0b)
+)
+
+func TestWASMPayloadProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name    string
+		module  []byte
+		wantErr bool
+	}{
+		{name: "identity", module: wasmIdentity},
+		{name: "invalid json", module: wasmTruncate, wantErr: true},
+	}
+
+	ctx := context.Background()
+	data := json.RawMessage(`{"build":42}`)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := NewWASMPayloadProcessor(ctx, test.module, 100*time.Millisecond, wasmPageSize)
+			if err != nil {
+				t.Fatalf("NewWASMPayloadProcessor() error = %v", err)
+			}
+
+			got, err := p.Process(ctx, data)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("Process() = %s, want error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			if string(got) != string(data) {
+				t.Errorf("Process() = %s, want %s", got, data)
+			}
+		})
+	}
+}
+
+func TestWASMPayloadProcessor_Timeout(t *testing.T) {
+	ctx := context.Background()
+
+	p, err := NewWASMPayloadProcessor(ctx, wasmLoop, 50*time.Millisecond, wasmPageSize)
+	if err != nil {
+		t.Fatalf("NewWASMPayloadProcessor() error = %v", err)
+	}
+
+	start := time.Now()
+	if _, err = p.Process(ctx, json.RawMessage(`{}`)); err == nil {
+		t.Fatal("Process() of a module that never returns succeeded")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Process() took %s, want it stopped after the timeout", elapsed)
+	}
+}
+
+func TestNewWASMPayloadProcessor_MissingExport(t *testing.T) {
+	// the module of wasmModule without code, functions and exports.
+	module := wasmIdentity[:8]
+
+	_, err := NewWASMPayloadProcessor(context.Background(), module, time.Second, wasmPageSize)
+	if err == nil {
+		t.Errorf("NewWASMPayloadProcessor() error = %v, want missing export error", err)
+	}
+}
+
+func TestNewWASMPayloadProcessor_MissingMemoryExport(t *testing.T) {
+	// the module of wasmModule with the memory export dropped from the export section.
+	module := bytes.Replace(wasmIdentity,
+		[]byte{0x07, 0x1c, 0x03, 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00},
+		[]byte{0x07, 0x13, 0x02}, 1)
+
+	_, err := NewWASMPayloadProcessor(context.Background(), module, time.Second, wasmPageSize)
+	if err == nil {
+		t.Errorf("NewWASMPayloadProcessor() error = %v, want missing memory export error", err)
+	}
+}
+
+func TestNewWASMPayloadProcessor_MemoryLimitBelowPage(t *testing.T) {
+	_, err := NewWASMPayloadProcessor(context.Background(), wasmIdentity, time.Second, wasmPageSize-1)
+	if err == nil {
+		t.Errorf("NewWASMPayloadProcessor() error = %v, want memory limit error", err)
+	}
+}
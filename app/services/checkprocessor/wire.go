@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkprocessor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvidePayloadProcessor,
+)
+
+func ProvidePayloadProcessor(ctx context.Context, config Config) (PayloadProcessor, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided status check payload processor config is invalid: %w", err)
+	}
+
+	if config.WASMModulePath == "" {
+		return nopProcessor{}, nil
+	}
+
+	module, err := os.ReadFile(config.WASMModulePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status check payload processor module: %w", err)
+	}
+
+	return NewWASMPayloadProcessor(ctx, module, config.Timeout, config.MemoryLimit)
+}
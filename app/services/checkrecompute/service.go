@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkrecompute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	jobType        = "checks_recompute"
+	jobMaxDuration = 30 * time.Minute
+	jobIDPrefix    = "checks-recompute-"
+
+	pullReqPageSize = 100
+)
+
+// Input is the input of the status check recompute job.
+type Input struct {
+	RepoID int64 `json:"repo_id"`
+}
+
+// Result is the summary of the status check recompute job.
+type Result struct {
+	Total   int `json:"total"`
+	Changed int `json:"changed"`
+}
+
+// Service re-evaluates the required status checks of all open pull requests of a repository
+// and updates the pull requests whose required checks status changed.
+type Service struct {
+	scheduler           *job.Scheduler
+	repoStore           store.RepoStore
+	pullreqStore        store.PullReqStore
+	checkStore          store.CheckStore
+	checkAliasStore     store.CheckAliasStore
+	protectionManager   *protection.Manager
+	checkConfigResolver *checkconfig.Resolver
+}
+
+// Run starts a background job that recomputes the required status checks of all open pull requests
+// of the repository. It returns the ID of the job.
+func (s *Service) Run(ctx context.Context, repoID int64) (string, error) {
+	uid, err := job.UID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job UID: %w", err)
+	}
+
+	data, err := json.Marshal(Input{RepoID: repoID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	jobID := JobIDFromRepoID(repoID, uid)
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobID,
+		Type:       jobType,
+		MaxRetries: 0,
+		Timeout:    jobMaxDuration,
+		Data:       string(data),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to run status check recompute job: %w", err)
+	}
+
+	return jobID, nil
+}
+
+// Progress returns the progress of the status check recompute job of the repository.
+func (s *Service) Progress(ctx context.Context, repoID int64, jobID string) (job.Progress, error) {
+	if !strings.HasPrefix(jobID, JobIDFromRepoID(repoID, "")) {
+		return job.Progress{}, fmt.Errorf("job %q doesn't belong to repo %d: %w", jobID, repoID,
+			gitness_store.ErrResourceNotFound)
+	}
+
+	return s.scheduler.GetJobProgress(ctx, jobID)
+}
+
+// Handle is the job handler of the status check recompute job.
+func (s *Service) Handle(ctx context.Context, data string, fn job.ProgressReporter) (string, error) {
+	var input Input
+	if err := json.NewDecoder(strings.NewReader(data)).Decode(&input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	checkAliases, err := s.checkAliasStore.Map(ctx, repo.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get status check aliases: %w", err)
+	}
+
+	filter := &types.PullReqFilter{
+		Page:         1,
+		Size:         pullReqPageSize,
+		TargetRepoID: repo.ID,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	}
+
+	total, err := s.pullreqStore.Count(ctx, filter)
+	if err != nil {
+		return "", fmt.Errorf("failed to count open pull requests: %w", err)
+	}
+
+	var result Result
+	for {
+		pullReqs, err := s.pullreqStore.List(ctx, filter)
+		if err != nil {
+			return "", fmt.Errorf("failed to list open pull requests: %w", err)
+		}
+
+		for _, pr := range pullReqs {
+			checkResults, err := s.checkStore.ListResults(ctx, repo.ID, pr.SourceSHA)
+			if err != nil {
+				return "", fmt.Errorf("failed to list status checks of pull request %d: %w", pr.Number, err)
+			}
+
+			changed, err := s.Refresh(ctx, protectionRules, repo, pr, checkResults, checkAliases)
+			if err != nil {
+				return "", err
+			}
+
+			result.Total++
+			if changed {
+				result.Changed++
+			}
+		}
+
+		if total > 0 {
+			_ = fn(min(job.ProgressMax*result.Total/int(total), job.ProgressMax-1), "")
+		}
+
+		if len(pullReqs) < pullReqPageSize {
+			break
+		}
+
+		filter.Page++
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job result: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// Refresh re-evaluates the required status checks of the pull request and updates
+// its required checks status if it changed. It returns true if the pull request was updated.
+func (s *Service) Refresh(
+	ctx context.Context,
+	protectionRules protection.Protection,
+	repo *types.Repository,
+	pr *types.PullReq,
+	checkResults []types.CheckResult,
+	checkAliases map[string]string,
+) (bool, error) {
+	passing, err := s.requiredChecksPassing(ctx, protectionRules, repo, pr, checkResults, checkAliases)
+	if err != nil {
+		return false, err
+	}
+
+	if pr.RequiredChecksPassing != nil && *pr.RequiredChecksPassing == passing {
+		return false, nil
+	}
+
+	if err := s.pullreqStore.UpdateRequiredChecksPassing(ctx, pr.ID, passing); err != nil {
+		return false, fmt.Errorf("failed to update required checks status of pull request %d: %w", pr.Number, err)
+	}
+
+	return true, nil
+}
+
+// requiredChecksPassing returns true if all status checks required by the protection rules,
+// by the status check configuration of the repository and by the policies of the spaces above it
+// are satisfied for the latest commit of the pull request. Bypass permissions aren't taken into account.
+func (s *Service) requiredChecksPassing(
+	ctx context.Context,
+	protectionRules protection.Protection,
+	repo *types.Repository,
+	pr *types.PullReq,
+	checkResults []types.CheckResult,
+	checkAliases map[string]string,
+) (bool, error) {
+	reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Repo:    repo,
+		PullReq: pr,
+		ResolveCheckLabel: func(ctx context.Context, namespace, label string) ([]types.CheckResult, error) {
+			return s.checkStore.ListByLabel(ctx, repo.ID, pr.SourceSHA, namespace, label)
+		},
+		CheckAliases: checkAliases,
+		CheckResults: checkResults,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get required checks of pull request %d: %w", pr.Number, err)
+	}
+
+	results := make(map[string]types.CheckResult, len(checkResults))
+	for _, checkResult := range checkResults {
+		results[checkResult.Identifier] = checkResult
+	}
+
+	for _, ids := range []map[string]struct{}{reqChecks.RequiredIdentifiers, reqChecks.BypassableIdentifiers} {
+		for identifier := range ids {
+			if protection.IsFanInCheck(identifier) {
+				if protection.FanInCheckStatus(identifier, checkResults) != enum.CheckStatusSuccess {
+					return false, nil
+				}
+				continue
+			}
+
+			result, ok := results[identifier]
+			if !ok {
+				return false, nil
+			}
+
+			_, skippable := reqChecks.SkippableIdentifiers[identifier]
+			if !result.IsSatisfied() && (!skippable || result.Status != enum.CheckStatusSkipped) {
+				return false, nil
+			}
+		}
+	}
+
+	passing, _, err := s.checkConfigResolver.AreRequiredChecksPassing(ctx, repo, checkResults, checkAliases)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify required status checks of pull request %d: %w", pr.Number, err)
+	}
+
+	return passing, nil
+}
+
+// JobIDFromRepoID returns the ID of a status check recompute job of the repository.
+func JobIDFromRepoID(repoID int64, uid string) string {
+	return jobIDPrefix + strconv.FormatInt(repoID, 10) + "-" + uid
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkrecompute
+
+import (
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	checkStore store.CheckStore,
+	checkAliasStore store.CheckAliasStore,
+	protectionManager *protection.Manager,
+	checkConfigResolver *checkconfig.Resolver,
+) (*Service, error) {
+	service := &Service{
+		scheduler:           scheduler,
+		repoStore:           repoStore,
+		pullreqStore:        pullreqStore,
+		checkStore:          checkStore,
+		checkAliasStore:     checkAliasStore,
+		protectionManager:   protectionManager,
+		checkConfigResolver: checkConfigResolver,
+	}
+
+	err := executor.Register(jobType, service)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
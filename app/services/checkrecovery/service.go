@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkrecovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const auditPageSize = 500
+
+// Service restores status check results from the status check audit log, for example after the checks
+// table was restored from a database backup that is older than the audit log.
+type Service struct {
+	tx              dbtx.Transactor
+	checkStore      store.CheckStore
+	checkAuditStore store.CheckAuditStore
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	checkStore store.CheckStore,
+	checkAuditStore store.CheckAuditStore,
+) *Service {
+	return &Service{
+		tx:              tx,
+		checkStore:      checkStore,
+		checkAuditStore: checkAuditStore,
+	}
+}
+
+// Replay re-applies the status check reports of the repository recorded in the audit log since fromTime.
+// Reports that are not newer than the stored status check result are skipped, so the replay is idempotent.
+// Every re-applied report is recorded in the audit log as a change by the principal,
+// with the time of the original report. Status changes are reported as events of the original reporter.
+// Status checks that don't exist anymore are recreated without payload and metadata,
+// because these are not part of the audit log.
+func (s *Service) Replay(ctx context.Context, principalID, repoID int64, fromTime time.Time) error {
+	var applied, skipped int
+
+	for page := 1; ; page++ {
+		entries, err := s.checkAuditStore.ListAuditByRepo(ctx, repoID, fromTime, page, auditPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list status check audit log entries: %w", err)
+		}
+
+		for _, entry := range entries {
+			ok, err := s.apply(ctx, principalID, entry)
+			if err != nil {
+				return fmt.Errorf("failed to replay status check audit log entry %d: %w", entry.ID, err)
+			}
+
+			if ok {
+				applied++
+			} else {
+				skipped++
+			}
+		}
+
+		if len(entries) < auditPageSize {
+			break
+		}
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", repoID).
+		Time("from", fromTime).
+		Int("applied", applied).
+		Int("skipped", skipped).
+		Msg("replayed status check audit log")
+
+	return nil
+}
+
+// apply updates the status check result with the state recorded in the audit log entry.
+// It returns false if the stored status check result is already up-to-date.
+func (s *Service) apply(ctx context.Context, principalID int64, entry *types.CheckAuditEntry) (bool, error) {
+	var applied bool
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		check, err := s.checkStore.FindInNamespace(ctx,
+			entry.RepoID, entry.CommitSHA, entry.Namespace, entry.Identifier)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			check = types.Check{
+				CreatedBy:  entry.PrincipalID,
+				Created:    entry.Timestamp,
+				RepoID:     entry.RepoID,
+				CommitSHA:  entry.CommitSHA,
+				Namespace:  entry.Namespace,
+				Identifier: entry.Identifier,
+				Metadata:   []byte("{}"),
+				Payload:    types.CheckPayload{Data: []byte("{}")},
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to find status check: %w", err)
+		}
+
+		if check.ID != 0 && check.Updated >= entry.Timestamp {
+			return nil
+		}
+
+		before := check
+
+		// the creator of an existing status check isn't changed, it only attributes the status change.
+		check.CreatedBy = entry.PrincipalID
+		check.Updated = entry.Timestamp
+		check.Status = entry.After.Status
+		check.Summary = entry.After.Summary
+		check.Link = entry.After.Link
+		check.Started = entry.After.Started
+		check.Ended = entry.After.Ended
+
+		if err := s.checkStore.Upsert(ctx, &check); err != nil {
+			return fmt.Errorf("failed to upsert status check: %w", err)
+		}
+
+		applied = true
+
+		if check.Deduplicated {
+			return nil
+		}
+
+		err = s.checkAuditStore.Create(ctx, types.NewCheckAuditEntry(principalID, before, &check))
+		if err != nil {
+			return fmt.Errorf("failed to create status check audit entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return applied, nil
+}
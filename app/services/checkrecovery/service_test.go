@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkrecovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type noTx struct{}
+
+func (noTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type memCheckStore struct {
+	store.CheckStore
+	checks  map[string]types.Check
+	upserts int
+}
+
+// memCheckKey returns the key of a status check, status checks of the default namespace are keyed by identifier.
+func memCheckKey(namespace, identifier string) string {
+	if namespace == "" || namespace == types.CheckNamespaceDefault {
+		return identifier
+	}
+	return namespace + "/" + identifier
+}
+
+func (s *memCheckStore) FindInNamespace(
+	_ context.Context,
+	_ int64,
+	_ string,
+	namespace string,
+	identifier string,
+) (types.Check, error) {
+	check, ok := s.checks[memCheckKey(namespace, identifier)]
+	if !ok {
+		return types.Check{}, gitness_store.ErrResourceNotFound
+	}
+	return check, nil
+}
+
+func (s *memCheckStore) Upsert(_ context.Context, check *types.Check) error {
+	if check.ID == 0 {
+		check.ID = int64(len(s.checks) + 1)
+	}
+	s.checks[memCheckKey(check.Namespace, check.Identifier)] = *check
+	s.upserts++
+	return nil
+}
+
+type memCheckAuditStore struct {
+	store.CheckAuditStore
+	entries []*types.CheckAuditEntry
+}
+
+func (s *memCheckAuditStore) ListAuditByRepo(
+	_ context.Context,
+	_ int64,
+	from time.Time,
+	page, size int,
+) ([]*types.CheckAuditEntry, error) {
+	result := make([]*types.CheckAuditEntry, 0)
+	for _, entry := range s.entries {
+		if entry.Timestamp >= from.UnixMilli() {
+			result = append(result, entry)
+		}
+	}
+
+	start := min((page-1)*size, len(result))
+	return result[start:min(start+size, len(result))], nil
+}
+
+func (s *memCheckAuditStore) Create(_ context.Context, entry *types.CheckAuditEntry) error {
+	entry.ID = int64(len(s.entries) + 1)
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func TestService_Replay(t *testing.T) {
+	ctx := context.Background()
+
+	checkStore := &memCheckStore{checks: map[string]types.Check{
+		"build": {ID: 1, Identifier: "build", Updated: 100, Status: enum.CheckStatusRunning, Metadata: []byte(`{"a":1}`)},
+		"lint":  {ID: 2, Identifier: "lint", Updated: 500, Status: enum.CheckStatusFailure},
+	}}
+	auditStore := &memCheckAuditStore{entries: []*types.CheckAuditEntry{
+		{ID: 1, Timestamp: 200, Identifier: "build", After: types.CheckAuditState{Status: enum.CheckStatusSuccess}},
+		{ID: 2, Timestamp: 300, Identifier: "lint", After: types.CheckAuditState{Status: enum.CheckStatusSuccess}},
+		{ID: 3, Timestamp: 400, Identifier: "test", PrincipalID: 7,
+			After: types.CheckAuditState{Status: enum.CheckStatusError, Summary: "failed"}},
+		{ID: 4, Timestamp: 450, Namespace: "ci", Identifier: "build",
+			After: types.CheckAuditState{Status: enum.CheckStatusFailure}},
+	}}
+
+	s := NewService(noTx{}, checkStore, auditStore)
+
+	if err := s.Replay(ctx, 9, 1, time.UnixMilli(0)); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if build := checkStore.checks["build"]; build.Status != enum.CheckStatusSuccess || build.Updated != 200 ||
+		string(build.Metadata) != `{"a":1}` {
+		t.Errorf("expected build check to be updated keeping its metadata, got %+v", build)
+	}
+
+	if lint := checkStore.checks["lint"]; lint.Status != enum.CheckStatusFailure {
+		t.Errorf("expected newer lint check to be kept, got %+v", lint)
+	}
+
+	if test := checkStore.checks["test"]; test.Status != enum.CheckStatusError || test.Summary != "failed" ||
+		test.CreatedBy != 7 {
+		t.Errorf("expected test check to be recreated, got %+v", test)
+	}
+
+	if build := checkStore.checks["ci/build"]; build.Status != enum.CheckStatusFailure || build.Namespace != "ci" {
+		t.Errorf("expected namespaced build check to be recreated in its namespace, got %+v", build)
+	}
+
+	if checkStore.upserts != 3 {
+		t.Errorf("expected 3 upserts, got %d", checkStore.upserts)
+	}
+
+	if len(auditStore.entries) != 7 {
+		t.Fatalf("expected an audit log entry for every applied report, got %d entries", len(auditStore.entries))
+	}
+
+	for _, entry := range auditStore.entries[4:] {
+		if entry.PrincipalID != 9 {
+			t.Errorf("expected replayed report to be audited as a change by the principal, got %+v", entry)
+		}
+	}
+
+	if entry := auditStore.entries[4]; entry.Before == nil || entry.Before.Status != enum.CheckStatusRunning ||
+		entry.After.Status != enum.CheckStatusSuccess || entry.Timestamp != 200 {
+		t.Errorf("expected replayed build report to be audited with its previous state, got %+v", entry)
+	}
+
+	if err := s.Replay(ctx, 9, 1, time.UnixMilli(0)); err != nil {
+		t.Fatalf("second Replay() error = %v", err)
+	}
+
+	if checkStore.upserts != 3 || len(auditStore.entries) != 7 {
+		t.Errorf("expected repeated replay to be a no-op, got %d upserts and %d audit log entries",
+			checkStore.upserts, len(auditStore.entries))
+	}
+}
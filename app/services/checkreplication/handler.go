@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// commitTimeout is how long after an event a status check that doesn't exist in the primary
+// is considered to be not committed yet rather than deleted.
+const commitTimeout = 30 * time.Second
+
+// handleEventReplicate copies the current state of the status check from the primary database to the replica.
+// Status checks that don't exist in the primary anymore are deleted from the replica.
+func (s *ReplicatedCheckStore) handleEventReplicate(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReplicatePayload],
+) error {
+	payload := event.Payload
+
+	if payload.CommitSHA == "" && payload.Identifier == "" {
+		if err := s.replicateRepoDeletion(ctx, payload.RepoID); err != nil {
+			return err
+		}
+
+		return s.markReplicated(ctx, event.Timestamp)
+	}
+
+	// events published before the status check namespaces were introduced don't have one.
+	namespace := payload.Namespace
+	if namespace == "" {
+		namespace = types.CheckNamespaceDefault
+	}
+
+	check, err := s.CheckStore.FindInNamespace(ctx, payload.RepoID, payload.CommitSHA, namespace, payload.Identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		if !payload.Deleted && time.Since(event.Timestamp) < commitTimeout {
+			// the event might have been received before the change got committed to the primary, so it's retried
+			return fmt.Errorf("status check not found in primary")
+		}
+
+		// the status check got deleted or moved in the meantime
+		if err = s.replicateDeletion(ctx, payload.RepoID, payload.CommitSHA, namespace, payload.Identifier); err != nil {
+			return err
+		}
+
+		return s.markReplicated(ctx, event.Timestamp)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find status check in primary: %w", err)
+	}
+
+	if check.Updated < payload.Updated || payload.Deleted && check.Updated == payload.Updated {
+		// the event was received before the change got committed to the primary, so it's retried
+		return fmt.Errorf("status check in primary is older than the replicated change")
+	}
+
+	existing, err := s.replica.FindInNamespace(ctx, payload.RepoID, payload.CommitSHA, namespace, payload.Identifier)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find status check in replica: %w", err)
+	}
+
+	if err == nil && existing.Updated >= check.Updated {
+		// a newer change was already replicated
+		return s.markReplicated(ctx, event.Timestamp)
+	}
+
+	if err = s.replica.Upsert(ctx, &check); err != nil {
+		return fmt.Errorf("failed to upsert status check in replica: %w", err)
+	}
+
+	return s.markReplicated(ctx, event.Timestamp)
+}
+
+// replicateDeletion deletes the status check from the replica.
+func (s *ReplicatedCheckStore) replicateDeletion(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	identifier string,
+) error {
+	existing, err := s.replica.FindInNamespace(ctx, repoID, commitSHA, namespace, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find deleted status check in replica: %w", err)
+	}
+
+	if _, err = s.replica.DeleteByIDs(ctx, []int64{existing.ID}); err != nil {
+		return fmt.Errorf("failed to delete status check from replica: %w", err)
+	}
+
+	return nil
+}
+
+// replicateRepoDeletion deletes all status checks of the repo from the replica
+// once none of them are left in the primary.
+func (s *ReplicatedCheckStore) replicateRepoDeletion(ctx context.Context, repoID int64) error {
+	count, err := s.CheckStore.Count(ctx, repoID, "", types.CheckListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to count status checks of repo in primary: %w", err)
+	}
+
+	if count > 0 {
+		// the event was received before the deletion got committed to the primary, so it's retried
+		return fmt.Errorf("status checks of repo in primary aren't deleted yet")
+	}
+
+	if _, err = s.replica.DeleteByRepo(ctx, repoID); err != nil {
+		return fmt.Errorf("failed to delete status checks of repo from replica: %w", err)
+	}
+
+	return nil
+}
+
+// handleEventRepoDeleted deletes the status checks of the deleted repo.
+func (s *ReplicatedCheckStore) handleEventRepoDeleted(
+	ctx context.Context,
+	event *events.Event[*repoevents.DeletedPayload],
+) error {
+	if _, err := s.DeleteByRepo(ctx, event.Payload.RepoID); err != nil {
+		return fmt.Errorf("failed to delete status checks of deleted repo: %w", err)
+	}
+
+	return nil
+}
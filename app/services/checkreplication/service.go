@@ -0,0 +1,615 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	groupCheckEvents = "gitness:checkreplication"
+	groupRepoEvents  = "gitness:checkreplication:repo"
+
+	// watermarkRefreshInterval is how long the replication watermark of the region is cached,
+	// it's shared by all instances of the region and advanced by the one applying a change.
+	watermarkRefreshInterval = time.Second
+)
+
+// Replica is a read replica database of status check results.
+type Replica struct {
+	Region     string
+	Datasource string
+}
+
+type Config struct {
+	// Region is the region of this gitness instance.
+	Region string
+	// Replicas lists the read replicas in the format "region|datasource".
+	Replicas        []string
+	Driver          string
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+// ParseReplicas parses the read replicas from the config.
+func (c *Config) ParseReplicas() ([]Replica, error) {
+	replicas := make([]Replica, 0, len(c.Replicas))
+	regions := map[string]struct{}{}
+
+	for _, entry := range c.Replicas {
+		parts := strings.SplitN(strings.TrimSpace(entry), "|", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("replica must be in the format \"region|datasource\"")
+		}
+
+		replica := Replica{Region: parts[0], Datasource: parts[1]}
+
+		if _, ok := regions[replica.Region]; ok {
+			return nil, fmt.Errorf("duplicate replica region %q", replica.Region)
+		}
+		regions[replica.Region] = struct{}{}
+
+		replicas = append(replicas, replica)
+	}
+
+	return replicas, nil
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if len(c.Replicas) == 0 {
+		return nil
+	}
+	if c.Region == "" {
+		return errors.New("config.Region is required")
+	}
+	if c.Driver == "" {
+		return errors.New("config.Driver is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// ReplicatedCheckStore writes status check results to the primary database and replicates them
+// asynchronously, by publishing events, to the read replica of every region.
+// Status check results are read from the replica of the region of this instance,
+// the primary database is used if there's no replica or it didn't receive any changes yet.
+// All status check writes should go through the store, as it also publishes the status changed events
+// and evicts the status check metrics of the affected repositories from the summary cache.
+type ReplicatedCheckStore struct {
+	store.CheckStore
+
+	reporter     *checkevents.Reporter
+	summaryCache store.RepoCheckSummaryCache
+	enabled      bool
+	region       string
+
+	// replica is the read replica of the region of this instance.
+	replica store.CheckStore
+	// watermarks stores the time (unix millis) of the most recent change applied to the replica of every region.
+	watermarks store.CheckReplicationWatermarkStore
+
+	// watermark caches the watermark of the region, watermarkLoaded is the time (unix millis) it was loaded.
+	watermark       atomic.Int64
+	watermarkLoaded atomic.Int64
+}
+
+func NewReplicatedCheckStore(
+	primary store.CheckStore,
+	replica store.CheckStore,
+	watermarks store.CheckReplicationWatermarkStore,
+	region string,
+	reporter *checkevents.Reporter,
+	summaryCache store.RepoCheckSummaryCache,
+	enabled bool,
+) *ReplicatedCheckStore {
+	return &ReplicatedCheckStore{
+		CheckStore:   primary,
+		reporter:     reporter,
+		summaryCache: summaryCache,
+		enabled:      enabled,
+		region:       region,
+		replica:      replica,
+		watermarks:   watermarks,
+	}
+}
+
+// Upsert creates new or updates an existing status check result in the primary database,
+// publishes an event to replicate it to the read replicas and reports the change of its status.
+// The status change is attributed to the principal set as the creator of the provided status check.
+func (s *ReplicatedCheckStore) Upsert(ctx context.Context, check *types.Check) error {
+	principalID := check.CreatedBy
+
+	existing, err := s.findPrimary(ctx, check.RepoID, check.CommitSHA, check.Namespace, check.Identifier)
+	if err != nil {
+		return err
+	}
+
+	if err = s.CheckStore.Upsert(ctx, check); err != nil {
+		return err
+	}
+
+	if check.Deduplicated {
+		return nil
+	}
+
+	s.replicate(ctx, check)
+	s.reportStatusChanged(ctx, principalID, existing.Status, check)
+	s.evictSummary(ctx, check.RepoID)
+
+	return nil
+}
+
+// UpsertBatch creates new or updates existing status check results, schedules their replication
+// and reports the changes of their statuses.
+// The status changes are attributed to the principals set as the creators of the provided status checks.
+func (s *ReplicatedCheckStore) UpsertBatch(
+	ctx context.Context,
+	checks []*types.Check,
+	strategy enum.ConflictStrategy,
+) error {
+	principalIDs := make([]int64, len(checks))
+	existingStatuses := make([]enum.CheckStatus, len(checks))
+	for i, check := range checks {
+		existing, err := s.findPrimary(ctx, check.RepoID, check.CommitSHA, check.Namespace, check.Identifier)
+		if err != nil {
+			return err
+		}
+
+		principalIDs[i] = check.CreatedBy
+		existingStatuses[i] = existing.Status
+	}
+
+	if err := s.CheckStore.UpsertBatch(ctx, checks, strategy); err != nil {
+		return err
+	}
+
+	repoIDs := make(map[int64]struct{})
+	for i, check := range checks {
+		// status checks that weren't written don't have an ID.
+		if check.ID == 0 {
+			continue
+		}
+
+		s.replicate(ctx, check)
+		s.reportStatusChanged(ctx, principalIDs[i], existingStatuses[i], check)
+		repoIDs[check.RepoID] = struct{}{}
+	}
+
+	for repoID := range repoIDs {
+		s.evictSummary(ctx, repoID)
+	}
+
+	return nil
+}
+
+// Patch updates the status check result in the primary database, schedules its replication
+// and reports the change of its status. Status checks are only patched by the system,
+// so the status change is attributed to the system principal.
+func (s *ReplicatedCheckStore) Patch(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	identifier string,
+	patch types.CheckPatch,
+) error {
+	existing, err := s.findPrimary(ctx, repoID, commitSHA, types.CheckNamespaceDefault, identifier)
+	if err != nil {
+		return err
+	}
+
+	if err = s.CheckStore.Patch(ctx, repoID, commitSHA, identifier, patch); err != nil {
+		return err
+	}
+
+	// the patched status check is read back for the time of the change.
+	check, err := s.CheckStore.FindInNamespace(ctx, repoID, commitSHA, types.CheckNamespaceDefault, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find patched status check: %w", err)
+	}
+
+	s.replicate(ctx, &check)
+	s.reportStatusChanged(ctx, bootstrap.NewSystemServiceSession().Principal.ID, existing.Status, &check)
+	s.evictSummary(ctx, repoID)
+
+	return nil
+}
+
+// MoveBySHA moves all status check results of a commit to another commit, schedules the replication
+// of both their removal from the old commit and their addition to the new commit and reports the changes
+// of the statuses of the new commit. The status changes are attributed to the reporters of the moved status checks.
+func (s *ReplicatedCheckStore) MoveBySHA(ctx context.Context, repoID int64, oldSHA, newSHA string) (int, error) {
+	existing, err := s.CheckStore.ListBySHAs(ctx, repoID, []string{newSHA})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list status checks of the target commit: %w", err)
+	}
+
+	existingStatuses := make(map[checkKey]enum.CheckStatus, len(existing[newSHA]))
+	for _, check := range existing[newSHA] {
+		existingStatuses[newCheckKey(check.Namespace, check.Identifier)] = check.Status
+	}
+
+	moved, err := s.CheckStore.MoveBySHA(ctx, repoID, oldSHA, newSHA)
+	if err != nil || moved == 0 {
+		return moved, err
+	}
+
+	checks, err := s.CheckStore.ListBySHAs(ctx, repoID, []string{newSHA})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list moved status checks: %w", err)
+	}
+
+	for _, check := range checks[newSHA] {
+		s.reportStatusChanged(ctx, check.CreatedBy, existingStatuses[newCheckKey(check.Namespace, check.Identifier)],
+			check)
+
+		s.replicate(ctx, check)
+
+		removed := *check
+		removed.CommitSHA = oldSHA
+		s.replicateDeleted(ctx, &removed)
+	}
+
+	s.evictSummary(ctx, repoID)
+
+	return moved, nil
+}
+
+// DeleteByIDs deletes the status check results with the provided IDs and schedules the replication
+// of their deletion.
+func (s *ReplicatedCheckStore) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	// the replicas identify status checks by their unique key and the summary cache by their repo,
+	// so they're looked up before the deletion.
+	checks := make([]*types.Check, 0, len(ids))
+	for _, id := range ids {
+		check, err := s.CheckStore.FindByID(ctx, id)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to find deleted status check: %w", err)
+		}
+
+		checks = append(checks, check)
+	}
+
+	n, err := s.CheckStore.DeleteByIDs(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	repoIDs := make(map[int64]struct{})
+	for _, check := range checks {
+		s.replicateDeleted(ctx, check)
+		repoIDs[check.RepoID] = struct{}{}
+	}
+
+	for repoID := range repoIDs {
+		s.evictSummary(ctx, repoID)
+	}
+
+	return n, nil
+}
+
+// DeleteByRepo deletes all status check results of a repo and schedules the replication of their deletion.
+func (s *ReplicatedCheckStore) DeleteByRepo(ctx context.Context, repoID int64) (int64, error) {
+	n, err := s.CheckStore.DeleteByRepo(ctx, repoID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.replicateRepo(ctx, repoID)
+	s.evictSummary(ctx, repoID)
+
+	return n, nil
+}
+
+// DeleteOrphans deletes a batch of status check results of deleted repos and schedules the replication
+// of their deletion.
+func (s *ReplicatedCheckStore) DeleteOrphans(ctx context.Context, batchSize int) (int64, error) {
+	if !s.enabled {
+		return s.CheckStore.DeleteOrphans(ctx, batchSize)
+	}
+
+	repoIDs, err := s.CheckStore.ListOrphanRepoIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list repos of orphan status checks: %w", err)
+	}
+
+	n, err := s.CheckStore.DeleteOrphans(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// the deletion of the status checks of a repo is replicated once none are left in the primary.
+	for _, repoID := range repoIDs {
+		count, err := s.CheckStore.Count(ctx, repoID, "", types.CheckListOptions{})
+		if err != nil {
+			return 0, fmt.Errorf("failed to count status checks of orphan repo: %w", err)
+		}
+
+		if count == 0 {
+			s.replicateRepo(ctx, repoID)
+		}
+	}
+
+	return n, nil
+}
+
+// checkKey identifies a status check of a commit.
+type checkKey struct {
+	namespace  string
+	identifier string
+}
+
+func newCheckKey(namespace, identifier string) checkKey {
+	return checkKey{namespace: checkNamespace(namespace), identifier: identifier}
+}
+
+// checkNamespace returns the namespace of a status check, status checks without one are in the default namespace.
+func checkNamespace(namespace string) string {
+	if namespace == "" {
+		return types.CheckNamespaceDefault
+	}
+
+	return namespace
+}
+
+// findPrimary returns the status check result from the primary database,
+// or an empty status check if it doesn't exist yet.
+func (s *ReplicatedCheckStore) findPrimary(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	identifier string,
+) (types.Check, error) {
+	check, err := s.CheckStore.FindInNamespace(ctx, repoID, commitSHA, checkNamespace(namespace), identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return types.Check{}, nil
+	}
+	if err != nil {
+		return types.Check{}, fmt.Errorf("failed to find existing status check: %w", err)
+	}
+
+	return check, nil
+}
+
+// reportStatusChanged publishes the status changed event if the status of the status check changed.
+func (s *ReplicatedCheckStore) reportStatusChanged(
+	ctx context.Context,
+	principalID int64,
+	oldStatus enum.CheckStatus,
+	check *types.Check,
+) {
+	s.reporter.StatusChanged(ctx, statusChangedPayload(principalID, oldStatus, check))
+}
+
+// statusChangedPayload returns the payload of the status changed event of the status check,
+// or nil if its status didn't change.
+func statusChangedPayload(
+	principalID int64,
+	oldStatus enum.CheckStatus,
+	check *types.Check,
+) *checkevents.StatusChangedPayload {
+	if oldStatus == check.Status {
+		return nil
+	}
+
+	return &checkevents.StatusChangedPayload{
+		RepoID:      check.RepoID,
+		PrincipalID: principalID,
+		CheckID:     check.ID,
+		CommitSHA:   check.CommitSHA,
+		Namespace:   checkNamespace(check.Namespace),
+		Identifier:  check.Identifier,
+		OldStatus:   oldStatus,
+		NewStatus:   check.Status,
+		Visibility:  check.Visibility,
+	}
+}
+
+// replicate publishes an event to replicate the change of the status check to the read replicas.
+func (s *ReplicatedCheckStore) replicate(ctx context.Context, check *types.Check) {
+	if !s.enabled {
+		return
+	}
+
+	s.reporter.Replicate(ctx, &checkevents.ReplicatePayload{
+		RepoID:     check.RepoID,
+		CommitSHA:  check.CommitSHA,
+		Identifier: check.Identifier,
+		Namespace:  check.Namespace,
+		Updated:    check.Updated,
+	})
+}
+
+// replicateDeleted publishes an event to replicate the deletion of the status check to the read replicas.
+func (s *ReplicatedCheckStore) replicateDeleted(ctx context.Context, check *types.Check) {
+	if !s.enabled {
+		return
+	}
+
+	s.reporter.Replicate(ctx, &checkevents.ReplicatePayload{
+		RepoID:     check.RepoID,
+		CommitSHA:  check.CommitSHA,
+		Identifier: check.Identifier,
+		Namespace:  check.Namespace,
+		Updated:    check.Updated,
+		Deleted:    true,
+	})
+}
+
+// replicateRepo publishes an event to replicate the deletion of the status checks of a repo.
+func (s *ReplicatedCheckStore) replicateRepo(ctx context.Context, repoID int64) {
+	if !s.enabled {
+		return
+	}
+
+	s.reporter.Replicate(ctx, &checkevents.ReplicatePayload{
+		RepoID:  repoID,
+		Deleted: true,
+	})
+}
+
+// evictSummary evicts the status check metrics of the repo from the summary cache.
+func (s *ReplicatedCheckStore) evictSummary(ctx context.Context, repoID int64) {
+	if s.summaryCache == nil {
+		return
+	}
+
+	s.summaryCache.Evict(ctx, repoID)
+}
+
+// FindByIdentifier returns the status check result for the given unique key in the default namespace,
+// preferably from the read replica.
+func (s *ReplicatedCheckStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	identifier string,
+) (types.Check, error) {
+	return s.FindInNamespace(ctx, repoID, commitSHA, types.CheckNamespaceDefault, identifier)
+}
+
+// FindInNamespace returns the status check result for the given unique key, preferably from the read replica.
+func (s *ReplicatedCheckStore) FindInNamespace(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	identifier string,
+) (types.Check, error) {
+	staleAt, ok := s.readFromReplica(ctx)
+	if !ok {
+		return s.CheckStore.FindInNamespace(ctx, repoID, commitSHA, namespace, identifier)
+	}
+
+	check, err := s.replica.FindInNamespace(ctx, repoID, commitSHA, namespace, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the status check might not be replicated yet
+		return s.CheckStore.FindInNamespace(ctx, repoID, commitSHA, namespace, identifier)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find status check in replica, falling back to primary")
+		return s.CheckStore.FindInNamespace(ctx, repoID, commitSHA, namespace, identifier)
+	}
+
+	check.StaleAt = staleAt
+
+	return check, nil
+}
+
+// List returns a list of status check results for a specific commit in a repo, preferably from the read replica.
+func (s *ReplicatedCheckStore) List(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	opts types.CheckListOptions,
+) ([]types.Check, error) {
+	staleAt, ok := s.readFromReplica(ctx)
+	if !ok {
+		return s.CheckStore.List(ctx, repoID, commitSHA, opts)
+	}
+
+	checks, err := s.replica.List(ctx, repoID, commitSHA, opts)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list status checks from replica, falling back to primary")
+		return s.CheckStore.List(ctx, repoID, commitSHA, opts)
+	}
+
+	for i := range checks {
+		checks[i].StaleAt = staleAt
+	}
+
+	return checks, nil
+}
+
+// readFromReplica returns true and the replication time if the read replica should be used.
+// The primary database is used inside of transactions because the transaction is bound to it.
+func (s *ReplicatedCheckStore) readFromReplica(ctx context.Context) (int64, bool) {
+	if s.replica == nil || dbtx.GetTransaction(ctx) != nil {
+		return 0, false
+	}
+
+	replicatedUntil := s.loadWatermark(ctx)
+	if replicatedUntil == 0 {
+		return 0, false
+	}
+
+	return replicatedUntil, true
+}
+
+// loadWatermark returns the time (unix millis) of the most recent change applied to the replica.
+// The watermark is shared by all instances of the region, so it's cached only for a short time.
+func (s *ReplicatedCheckStore) loadWatermark(ctx context.Context) int64 {
+	now := time.Now().UnixMilli()
+	if now-s.watermarkLoaded.Load() < watermarkRefreshInterval.Milliseconds() {
+		return s.watermark.Load()
+	}
+
+	replicatedUntil, err := s.watermarks.Find(ctx, s.region)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to load status check replication watermark, using cached one")
+		return s.watermark.Load()
+	}
+
+	s.watermark.Store(replicatedUntil)
+	s.watermarkLoaded.Store(now)
+
+	return replicatedUntil
+}
+
+// markReplicated advances the watermark of the replica to the provided time.
+func (s *ReplicatedCheckStore) markReplicated(ctx context.Context, t time.Time) error {
+	millis := t.UnixMilli()
+
+	if err := s.watermarks.Advance(ctx, s.region, millis); err != nil {
+		return fmt.Errorf("failed to advance status check replication watermark: %w", err)
+	}
+
+	for {
+		current := s.watermark.Load()
+		if millis <= current || s.watermark.CompareAndSwap(current, millis) {
+			return nil
+		}
+	}
+}
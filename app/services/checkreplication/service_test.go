@@ -0,0 +1,346 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkreplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type memCheckStore struct {
+	store.CheckStore
+	checks map[string]types.Check
+}
+
+type memWatermarkStore struct {
+	watermarks map[string]int64
+}
+
+func (s *memWatermarkStore) Find(_ context.Context, region string) (int64, error) {
+	return s.watermarks[region], nil
+}
+
+func (s *memWatermarkStore) Advance(_ context.Context, region string, replicatedUntil int64) error {
+	if replicatedUntil > s.watermarks[region] {
+		s.watermarks[region] = replicatedUntil
+	}
+	return nil
+}
+
+func newMemCheckStore(checks ...types.Check) *memCheckStore {
+	s := &memCheckStore{checks: map[string]types.Check{}}
+	for _, check := range checks {
+		s.checks[check.Identifier] = check
+	}
+	return s
+}
+
+func (s *memCheckStore) FindInNamespace(
+	_ context.Context,
+	_ int64,
+	_ string,
+	_ string,
+	identifier string,
+) (types.Check, error) {
+	check, ok := s.checks[identifier]
+	if !ok {
+		return types.Check{}, gitness_store.ErrResourceNotFound
+	}
+	return check, nil
+}
+
+func (s *memCheckStore) FindByID(_ context.Context, id int64) (*types.Check, error) {
+	for _, check := range s.checks {
+		if check.ID == id {
+			return &check, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *memCheckStore) Upsert(_ context.Context, check *types.Check) error {
+	s.checks[check.Identifier] = *check
+	return nil
+}
+
+func (s *memCheckStore) DeleteByIDs(_ context.Context, ids []int64) (int64, error) {
+	var n int64
+	for identifier, check := range s.checks {
+		for _, id := range ids {
+			if check.ID == id {
+				delete(s.checks, identifier)
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+func (s *memCheckStore) DeleteByRepo(_ context.Context, repoID int64) (int64, error) {
+	var n int64
+	for identifier, check := range s.checks {
+		if check.RepoID == repoID {
+			delete(s.checks, identifier)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memCheckStore) Count(_ context.Context, repoID int64, _ string, _ types.CheckListOptions) (int, error) {
+	var n int
+	for _, check := range s.checks {
+		if check.RepoID == repoID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memCheckStore) List(context.Context, int64, string, types.CheckListOptions) ([]types.Check, error) {
+	checks := make([]types.Check, 0, len(s.checks))
+	for _, check := range s.checks {
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func replicateEvent(updated int64, timestamp time.Time) *events.Event[*checkevents.ReplicatePayload] {
+	return &events.Event[*checkevents.ReplicatePayload]{
+		Timestamp: timestamp,
+		Payload: &checkevents.ReplicatePayload{
+			RepoID:     1,
+			CommitSHA:  "abc",
+			Identifier: "build",
+			Updated:    updated,
+		},
+	}
+}
+
+func TestReplicatedCheckStore(t *testing.T) {
+	ctx := context.Background()
+
+	primary := newMemCheckStore(types.Check{Identifier: "build", Summary: "primary", Updated: 10})
+	replica := newMemCheckStore()
+
+	watermarks := &memWatermarkStore{watermarks: map[string]int64{}}
+
+	s := NewReplicatedCheckStore(primary, replica, watermarks, "eu-west", nil, nil, false)
+
+	// nothing replicated yet, so the primary is used
+	checks, err := s.List(ctx, 1, "abc", types.CheckListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list checks: %s", err)
+	}
+	if len(checks) != 1 || checks[0].StaleAt != 0 {
+		t.Fatalf("expected status check from primary, got %+v", checks)
+	}
+
+	// the change isn't committed to the primary yet
+	if err = s.handleEventReplicate(ctx, replicateEvent(20, time.UnixMilli(1000))); err == nil {
+		t.Fatalf("expected error for change not found in primary")
+	}
+
+	if err = s.handleEventReplicate(ctx, replicateEvent(10, time.UnixMilli(2000))); err != nil {
+		t.Fatalf("failed to replicate: %s", err)
+	}
+
+	// replica is updated, the primary gets changed afterward
+	primary.checks["build"] = types.Check{Identifier: "build", Summary: "changed", Updated: 30}
+
+	check, err := s.FindByIdentifier(ctx, 1, "abc", "build")
+	if err != nil {
+		t.Fatalf("failed to find check: %s", err)
+	}
+	if check.Summary != "primary" || check.StaleAt != 2000 {
+		t.Errorf("expected stale status check from replica, got %+v", check)
+	}
+
+	// status checks not yet in the replica are read from the primary
+	primary.checks["lint"] = types.Check{Identifier: "lint", Updated: 30}
+
+	check, err = s.FindByIdentifier(ctx, 1, "abc", "lint")
+	if err != nil {
+		t.Fatalf("failed to find check: %s", err)
+	}
+	if check.StaleAt != 0 {
+		t.Errorf("expected status check from primary, got %+v", check)
+	}
+
+	// an older event doesn't move the replication time back
+	if err = s.handleEventReplicate(ctx, replicateEvent(10, time.UnixMilli(1500))); err != nil {
+		t.Fatalf("failed to replicate: %s", err)
+	}
+
+	checks, err = s.List(ctx, 1, "abc", types.CheckListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list checks: %s", err)
+	}
+	if len(checks) != 1 || checks[0].StaleAt != 2000 {
+		t.Errorf("expected status checks from replica, got %+v", checks)
+	}
+
+	// the watermark is persisted for all instances of the region
+	if watermarks.watermarks["eu-west"] != 2000 {
+		t.Errorf("expected persisted watermark 2000, got %d", watermarks.watermarks["eu-west"])
+	}
+}
+
+func TestReplicatedCheckStore_Deletion(t *testing.T) {
+	ctx := context.Background()
+
+	primary := newMemCheckStore()
+	replica := newMemCheckStore(
+		types.Check{ID: 1, RepoID: 1, Identifier: "build", Updated: 10},
+		types.Check{ID: 2, RepoID: 1, Identifier: "lint", Updated: 10},
+	)
+	watermarks := &memWatermarkStore{watermarks: map[string]int64{}}
+
+	s := NewReplicatedCheckStore(primary, replica, watermarks, "eu-west", nil, nil, false)
+
+	// the deletion of the status check might not be committed to the primary yet
+	primary.checks["build"] = types.Check{ID: 1, RepoID: 1, Identifier: "build", Updated: 10}
+
+	event := replicateEvent(10, time.Now())
+	event.Payload.Deleted = true
+
+	if err := s.handleEventReplicate(ctx, event); err == nil {
+		t.Fatalf("expected error for deletion not committed to primary")
+	}
+
+	delete(primary.checks, "build")
+
+	if err := s.handleEventReplicate(ctx, event); err != nil {
+		t.Fatalf("failed to replicate deletion: %s", err)
+	}
+	if _, ok := replica.checks["build"]; ok {
+		t.Errorf("expected status check to be deleted from replica")
+	}
+
+	// status checks that don't exist in the primary are deleted only once the change is surely committed
+	if err := s.handleEventReplicate(ctx, replicateEvent(10, time.Now())); err == nil {
+		t.Fatalf("expected error for change not found in primary")
+	}
+
+	// all status checks of the repo are deleted
+	repoEvent := &events.Event[*checkevents.ReplicatePayload]{
+		Timestamp: time.Now(),
+		Payload:   &checkevents.ReplicatePayload{RepoID: 1, Deleted: true},
+	}
+
+	if err := s.handleEventReplicate(ctx, repoEvent); err != nil {
+		t.Fatalf("failed to replicate repo deletion: %s", err)
+	}
+	if len(replica.checks) != 0 {
+		t.Errorf("expected all status checks of repo to be deleted from replica, got %+v", replica.checks)
+	}
+	if watermarks.watermarks["eu-west"] != repoEvent.Timestamp.UnixMilli() {
+		t.Errorf("expected watermark to be advanced, got %d", watermarks.watermarks["eu-west"])
+	}
+}
+
+func TestStatusChangedPayload(t *testing.T) {
+	check := &types.Check{
+		ID:         3,
+		RepoID:     1,
+		CommitSHA:  "abc",
+		Identifier: "build",
+		Status:     enum.CheckStatusSuccess,
+	}
+
+	if payload := statusChangedPayload(2, enum.CheckStatusSuccess, check); payload != nil {
+		t.Errorf("expected no event for unchanged status, got %+v", payload)
+	}
+
+	payload := statusChangedPayload(2, enum.CheckStatusRunning, check)
+	if payload == nil {
+		t.Fatalf("expected event for changed status")
+	}
+
+	if payload.PrincipalID != 2 || payload.CheckID != 3 || payload.Namespace != types.CheckNamespaceDefault ||
+		payload.OldStatus != enum.CheckStatusRunning || payload.NewStatus != enum.CheckStatusSuccess {
+		t.Errorf("unexpected status changed event payload %+v", payload)
+	}
+}
+
+func TestConfig_ParseReplicas(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas []string
+		wantErr  bool
+	}{
+		{name: "valid", replicas: []string{"eu-west|postgres://replica/gitness"}},
+		{name: "missing datasource", replicas: []string{"eu-west"}, wantErr: true},
+		{name: "missing region", replicas: []string{"|postgres://replica/gitness"}, wantErr: true},
+		{
+			name:     "duplicate region",
+			replicas: []string{"eu-west|postgres://a/gitness", "eu-west|postgres://b/gitness"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := Config{Replicas: test.replicas}
+			_, err := config.ParseReplicas()
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error=%t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+type recordingSummaryCache struct {
+	store.RepoCheckSummaryCache
+	evicted []int64
+}
+
+func (c *recordingSummaryCache) Evict(_ context.Context, repoID int64) {
+	c.evicted = append(c.evicted, repoID)
+}
+
+func TestReplicatedCheckStore_EvictsSummary(t *testing.T) {
+	ctx := context.Background()
+
+	primary := newMemCheckStore(types.Check{ID: 1, RepoID: 1, Identifier: "build", Status: enum.CheckStatusPending})
+	summaryCache := &recordingSummaryCache{}
+
+	s := NewReplicatedCheckStore(primary, nil, nil, "eu-west", nil, summaryCache, false)
+
+	err := s.Upsert(ctx, &types.Check{ID: 1, RepoID: 1, Identifier: "build", Status: enum.CheckStatusPending})
+	if err != nil {
+		t.Fatalf("failed to upsert check: %s", err)
+	}
+
+	if _, err = s.DeleteByIDs(ctx, []int64{1}); err != nil {
+		t.Fatalf("failed to delete check: %s", err)
+	}
+
+	if _, err = s.DeleteByRepo(ctx, 2); err != nil {
+		t.Fatalf("failed to delete checks of repo: %s", err)
+	}
+
+	if len(summaryCache.evicted) != 3 ||
+		summaryCache.evicted[0] != 1 || summaryCache.evicted[1] != 1 || summaryCache.evicted[2] != 2 {
+		t.Errorf("expected the summaries of repos [1 1 2] to be evicted, got %v", summaryCache.evicted)
+	}
+}
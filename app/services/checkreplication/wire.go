@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkreplication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/store"
+	appdatabase "github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideReplicatedCheckStore,
+)
+
+// ProvideReplicatedCheckStore provides the replicated status check store.
+// It connects to the read replica of the region of this instance and
+// launches the event reader that replicates status check changes to it.
+func ProvideReplicatedCheckStore(
+	ctx context.Context,
+	config Config,
+	appConfig *types.Config,
+	checkStore store.CheckStore,
+	principalInfoCache store.PrincipalInfoCache,
+	summaryCache store.RepoCheckSummaryCache,
+	reporter *checkevents.Reporter,
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+) (*ReplicatedCheckStore, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided status check replication config is invalid: %w", err)
+	}
+
+	replicas, err := config.ParseReplicas()
+	if err != nil {
+		return nil, fmt.Errorf("provided status check replicas are invalid: %w", err)
+	}
+
+	var datasource string
+	for _, replica := range replicas {
+		if replica.Region == config.Region {
+			datasource = replica.Datasource
+		}
+	}
+
+	enabled := len(replicas) > 0
+
+	if datasource == "" {
+		s := NewReplicatedCheckStore(checkStore, nil, nil, config.Region, reporter, summaryCache, enabled)
+		if !enabled {
+			return s, nil
+		}
+
+		if err = launchRepoReader(ctx, config, s, repoReaderFactory); err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}
+
+	db, err := database.Connect(ctx, config.Driver, datasource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to status check replica of region %q: %w", config.Region, err)
+	}
+
+	replica, err := appdatabase.ProvideCheckStore(ctx, db, principalInfoCache, appConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create status check store of replica: %w", err)
+	}
+
+	watermarks, err := appdatabase.ProvideCheckReplicationWatermarkStore(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create status check replication watermark store of replica: %w", err)
+	}
+
+	s := NewReplicatedCheckStore(checkStore, replica, watermarks, config.Region, reporter, summaryCache,
+		enabled)
+
+	if err = launchRepoReader(ctx, config, s, repoReaderFactory); err != nil {
+		return nil, err
+	}
+
+	// every region has its own consumer group, so each change is applied once to each replica.
+	_, err = checkReaderFactory.Launch(ctx, groupCheckEvents+":"+config.Region, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterReplicate(s.handleEventReplicate)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for status check replication: %w", err)
+	}
+
+	return s, nil
+}
+
+// launchRepoReader launches the event reader that deletes the status checks of deleted repos.
+// All instances share the consumer group, so the status checks are deleted only once.
+func launchRepoReader(
+	ctx context.Context,
+	config Config,
+	s *ReplicatedCheckStore,
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+) error {
+	_, err := repoReaderFactory.Launch(ctx, groupRepoEvents, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterRepoDeleted(s.handleEventRepoDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to launch repo event reader for status check replication: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,364 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkretry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "gitness:checks:retry"
+	jobCron        = "*/5 * * * *" // Every 5 minutes.
+	jobMaxDuration = 4 * time.Minute
+
+	// retryWindow limits automatic retries to the status checks that completed recently.
+	retryWindow = 24 * time.Hour
+
+	repoPageSize = 100
+)
+
+// Service retries failed status checks according to the retry policy configured for them.
+// The retry policy configured for a status check in a repository takes precedence over
+// the policy inherited from the nearest parent space that has one for the same status check.
+// Only status checks reported by gitness pipelines can be retried.
+type Service struct {
+	scheduler        *job.Scheduler
+	checkConfigStore store.CheckConfigStore
+	spacePolicyStore store.SpaceCheckPolicyStore
+	configResolver   *checkconfig.Resolver
+	repoStore        store.RepoStore
+	checkStore       store.CheckStore
+	pipelineStore    store.PipelineStore
+	executionStore   store.ExecutionStore
+	triggerer        triggerer.Triggerer
+	checkAuditStore  store.CheckAuditStore
+	tx               dbtx.Transactor
+}
+
+// Register schedules the recurring status check retry job.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, jobCron, jobMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for status check retries: %w", err)
+	}
+
+	return nil
+}
+
+// Handle retries all status checks that qualify for a retry according to their retry policy.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	configs, err := s.checkConfigStore.ListRetryable(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list retryable status check configs: %w", err)
+	}
+
+	now := time.Now()
+
+	var total int
+	for _, config := range configs {
+		n, err := s.retryChecks(ctx, config.RepoID, config.Identifier, config.RetryPolicy, now)
+		if err != nil {
+			return "", err
+		}
+
+		total += n
+	}
+
+	policies, err := s.spacePolicyStore.ListRetryable(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list retryable space status check policies: %w", err)
+	}
+
+	for _, policy := range policies {
+		n, err := s.retrySpaceChecks(ctx, policy, now)
+		if err != nil {
+			return "", err
+		}
+
+		total += n
+	}
+
+	result := "no status checks to retry"
+	if total > 0 {
+		result = fmt.Sprintf("retried %d status checks", total)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// retrySpaceChecks retries the status checks of all repositories in the space (and its child spaces)
+// that inherit the space status check policy.
+func (s *Service) retrySpaceChecks(ctx context.Context, policy *types.SpaceCheckPolicy, now time.Time) (int, error) {
+	filter := &types.RepoFilter{
+		Page:      1,
+		Size:      repoPageSize,
+		Recursive: true,
+	}
+
+	var count int
+	for {
+		repos, err := s.repoStore.List(ctx, policy.SpaceID, filter)
+		if err != nil {
+			return count, fmt.Errorf("failed to list repositories of space: %w", err)
+		}
+
+		for _, repo := range repos {
+			inherited, err := s.inheritsPolicy(ctx, repo, policy)
+			if err != nil {
+				return count, err
+			}
+
+			if !inherited {
+				continue
+			}
+
+			n, err := s.retryChecks(ctx, repo.ID, policy.Identifier, policy.RetryPolicy, now)
+			if err != nil {
+				return count, err
+			}
+
+			count += n
+		}
+
+		if len(repos) < repoPageSize {
+			return count, nil
+		}
+
+		filter.Page++
+	}
+}
+
+// inheritsPolicy returns true if the space status check policy applies to the repository:
+// the repository has no configuration of the status check and no space between the repository
+// and the space of the policy has a policy for the same status check.
+func (s *Service) inheritsPolicy(
+	ctx context.Context,
+	repo *types.Repository,
+	policy *types.SpaceCheckPolicy,
+) (bool, error) {
+	_, err := s.checkConfigStore.Find(ctx, repo.ID, policy.Identifier)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, fmt.Errorf("failed to find status check config: %w", err)
+	}
+
+	// the spaces are checked from the parent space of the repository up to the space of the policy.
+	spaceIDs, err := s.configResolver.AncestorSpaceIDs(ctx, repo.ParentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get space ancestors of repository: %w", err)
+	}
+
+	for _, spaceID := range spaceIDs {
+		if spaceID == policy.SpaceID {
+			return true, nil
+		}
+
+		_, err = s.spacePolicyStore.Find(ctx, spaceID, policy.Identifier)
+		if err == nil {
+			return false, nil
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return false, fmt.Errorf("failed to find space status check policy: %w", err)
+		}
+	}
+
+	return false, nil
+}
+
+func (s *Service) retryChecks(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+	policy types.RetryPolicy,
+	now time.Time,
+) (int, error) {
+	if err := s.timeoutChecks(ctx, repoID, identifier, policy, now); err != nil {
+		return 0, err
+	}
+
+	checks, err := s.checkStore.ListRetryCandidates(ctx, repoID, types.CheckRetryCandidateOptions{
+		Identifier:    identifier,
+		Statuses:      policy.RetryOnStatuses,
+		PayloadKind:   enum.CheckPayloadKindPipeline,
+		MaxRetryCount: policy.MaxAttempts - 1,
+		UpdatedAfter:  now.Add(-retryWindow).UnixMilli(),
+		UpdatedBefore: now.Add(-time.Duration(policy.BackoffSeconds) * time.Second).UnixMilli(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list status checks to retry: %w", err)
+	}
+
+	var count int
+	for i := range checks {
+		check := &checks[i]
+		if !policy.ShouldRetry(check.Status, check.FailureCategory, check.RetryCount) {
+			continue
+		}
+
+		// the retry attempt is counted even if triggering it fails to avoid retrying broken pipelines forever.
+		if err := s.checkStore.IncrementRetryCount(ctx, check.ID); err != nil {
+			return count, fmt.Errorf("failed to increment status check retry count: %w", err)
+		}
+
+		if err := s.retryCheck(ctx, check); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", check.RepoID).
+				Str("commit_sha", check.CommitSHA).
+				Str("check", check.Identifier).
+				Msg("failed to retry status check")
+			continue
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// timeoutChecks marks the pipeline status checks that have been running for longer than
+// the timeout of the retry policy as erroneous with the timeout failure category,
+// which makes them candidates for a retry.
+func (s *Service) timeoutChecks(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+	policy types.RetryPolicy,
+	now time.Time,
+) error {
+	if policy.TimeoutSeconds <= 0 {
+		return nil
+	}
+
+	checks, err := s.checkStore.ListRetryCandidates(ctx, repoID, types.CheckRetryCandidateOptions{
+		Identifier:    identifier,
+		Statuses:      []enum.CheckStatus{enum.CheckStatusRunning},
+		PayloadKind:   enum.CheckPayloadKindPipeline,
+		MaxRetryCount: policy.MaxAttempts,
+		UpdatedAfter:  now.Add(-retryWindow).UnixMilli(),
+		UpdatedBefore: now.UnixMilli(),
+		StartedBefore: now.Add(-time.Duration(policy.TimeoutSeconds) * time.Second).UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list timed out status checks: %w", err)
+	}
+
+	status := enum.CheckStatusError
+	category := enum.CheckFailureCategoryTimeout
+	ended := now.UnixMilli()
+
+	principalID := bootstrap.NewSystemServiceSession().Principal.ID
+
+	for i := range checks {
+		check := &checks[i]
+
+		err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+			err := s.checkStore.Patch(ctx, repoID, check.CommitSHA, check.Identifier, types.CheckPatch{
+				Status:          &status,
+				Ended:           &ended,
+				FailureCategory: &category,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to mark status check as timed out: %w", err)
+			}
+
+			timedOut := *check
+			timedOut.Status = status
+			timedOut.Ended = ended
+			timedOut.FailureCategory = category
+			timedOut.Updated = ended
+
+			err = s.checkAuditStore.Create(ctx, types.NewCheckAuditEntry(principalID, *check, &timedOut))
+			if err != nil {
+				return fmt.Errorf("failed to create status check audit entry: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retryCheck triggers a new execution of the pipeline that reported the status check.
+func (s *Service) retryCheck(ctx context.Context, check *types.Check) error {
+	var payload types.CheckPayloadInternal
+	if err := json.Unmarshal(check.Payload.Data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal pipeline status check payload: %w", err)
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, payload.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := s.executionStore.FindByNumber(ctx, payload.PipelineID, payload.Number)
+	if err != nil {
+		return fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	hook := &triggerer.Hook{
+		Parent:       execution.Number,
+		Trigger:      execution.Trigger,
+		TriggeredBy:  execution.CreatedBy,
+		Action:       execution.Action,
+		Link:         execution.Link,
+		Timestamp:    execution.Timestamp,
+		Title:        execution.Title,
+		Message:      execution.Message,
+		Before:       execution.Before,
+		After:        execution.After,
+		Ref:          execution.Ref,
+		Fork:         execution.Fork,
+		Source:       execution.Source,
+		Target:       execution.Target,
+		AuthorLogin:  execution.Author,
+		AuthorName:   execution.AuthorName,
+		AuthorEmail:  execution.AuthorEmail,
+		AuthorAvatar: execution.AuthorAvatar,
+		Debug:        execution.Debug,
+		Cron:         execution.Cron,
+		Sender:       execution.Sender,
+		Params:       execution.Params,
+	}
+
+	if _, err = s.triggerer.Trigger(ctx, pipeline, hook); err != nil {
+		return fmt.Errorf("failed to trigger pipeline execution: %w", err)
+	}
+
+	return nil
+}
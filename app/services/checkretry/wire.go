@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkretry
+
+import (
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/checkreplication"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	checkConfigStore store.CheckConfigStore,
+	spacePolicyStore store.SpaceCheckPolicyStore,
+	configResolver *checkconfig.Resolver,
+	repoStore store.RepoStore,
+	checkStore *checkreplication.ReplicatedCheckStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	triggerer triggerer.Triggerer,
+	checkAuditStore store.CheckAuditStore,
+	tx dbtx.Transactor,
+) (*Service, error) {
+	service := &Service{
+		scheduler:        scheduler,
+		checkConfigStore: checkConfigStore,
+		spacePolicyStore: spacePolicyStore,
+		configResolver:   configResolver,
+		repoStore:        repoStore,
+		checkStore:       checkStore,
+		pipelineStore:    pipelineStore,
+		executionStore:   executionStore,
+		triggerer:        triggerer,
+		checkAuditStore:  checkAuditStore,
+		tx:               tx,
+	}
+
+	err := executor.Register(jobType, service)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
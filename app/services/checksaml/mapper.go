@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/dchest/uniuri"
+)
+
+const (
+	// AttributeCIRepo is the SAML attribute with the path of the repository the CI system reports status checks to.
+	AttributeCIRepo = "http://schemas.example.com/ci-repo"
+
+	// AttributeCIRole is the SAML attribute with the role of the CI system.
+	// It is used as the namespace of the status checks reported by the CI system.
+	AttributeCIRole = "http://schemas.example.com/ci-role"
+)
+
+var (
+	ErrMissingAttribute = errors.New("saml assertion is missing a required attribute")
+	ErrInvalidRole      = errors.New("saml ci role isn't a valid status check namespace")
+)
+
+// SAMLCheckPrincipalMapper maps the CI system identity encoded in the attributes of a SAML assertion
+// to a service account principal that can only report status checks in a single namespace of a single repository.
+//
+// The mapper doesn't parse or verify SAML assertions. The signature, audience and validity period
+// of the assertion must have been verified by the caller before the attributes are mapped.
+type SAMLCheckPrincipalMapper struct {
+	principalStore store.PrincipalStore
+	repoStore      store.RepoStore
+}
+
+func NewSAMLCheckPrincipalMapper(
+	principalStore store.PrincipalStore,
+	repoStore store.RepoStore,
+) *SAMLCheckPrincipalMapper {
+	return &SAMLCheckPrincipalMapper{
+		principalStore: principalStore,
+		repoStore:      repoStore,
+	}
+}
+
+// Map returns the session of the service account of the CI system with the verified SAML attributes.
+// The service account is created on first use and isn't a member of any space. Instead, the session grants
+// the permission to report status checks to the repository, restricted to the namespace of the CI role.
+func (m *SAMLCheckPrincipalMapper) Map(ctx context.Context, attributes map[string][]string) (*auth.Session, error) {
+	repoRef, err := attribute(attributes, AttributeCIRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := attribute(attributes, AttributeCIRole)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := strings.ToLower(role)
+	if namespace == types.CheckNamespaceDefault || !types.IsValidCheckNamespace(namespace) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+
+	repo, err := m.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository %q: %w", repoRef, err)
+	}
+
+	sa, err := m.findOrCreateServiceAccount(ctx, repo, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.Session{
+		Principal: *sa.ToPrincipal(),
+		Metadata: &auth.CheckReportMetadata{
+			RepoID:    repo.ID,
+			RepoPath:  repo.Path,
+			Namespace: namespace,
+		},
+	}, nil
+}
+
+func (m *SAMLCheckPrincipalMapper) findOrCreateServiceAccount(
+	ctx context.Context,
+	repo *types.Repository,
+	namespace string,
+) (*types.ServiceAccount, error) {
+	uid := fmt.Sprintf("sa-%s-%d-saml-%s", enum.ParentResourceTypeRepo, repo.ID, namespace)
+
+	sa, err := m.principalStore.FindServiceAccountByUID(ctx, uid)
+	if err == nil {
+		if sa.Blocked {
+			return nil, fmt.Errorf("service account %q is blocked", uid)
+		}
+		return sa, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find service account %q: %w", uid, err)
+	}
+
+	now := time.Now().UnixMilli()
+	sa = &types.ServiceAccount{
+		UID:         uid,
+		Email:       uid + "@saml.local",
+		DisplayName: fmt.Sprintf("CI %s (%s)", namespace, repo.Path),
+		Salt:        uniuri.NewLen(uniuri.UUIDLen),
+		Created:     now,
+		Updated:     now,
+		ParentType:  enum.ParentResourceTypeRepo,
+		ParentID:    repo.ID,
+	}
+
+	err = m.principalStore.CreateServiceAccount(ctx, sa)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// created concurrently by another request of the same CI system.
+		return m.principalStore.FindServiceAccountByUID(ctx, uid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account %q: %w", uid, err)
+	}
+
+	return sa, nil
+}
+
+// attribute returns the single non-empty value of the SAML attribute.
+func attribute(attributes map[string][]string, name string) (string, error) {
+	values := attributes[name]
+	if len(values) != 1 || strings.TrimSpace(values[0]) == "" {
+		return "", fmt.Errorf("%w: %s", ErrMissingAttribute, name)
+	}
+
+	return strings.TrimSpace(values[0]), nil
+}
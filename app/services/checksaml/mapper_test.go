@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksaml
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type memPrincipalStore struct {
+	store.PrincipalStore
+	accounts map[string]*types.ServiceAccount
+}
+
+func (s *memPrincipalStore) FindServiceAccountByUID(_ context.Context, uid string) (*types.ServiceAccount, error) {
+	sa, ok := s.accounts[uid]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return sa, nil
+}
+
+func (s *memPrincipalStore) CreateServiceAccount(_ context.Context, sa *types.ServiceAccount) error {
+	sa.ID = int64(len(s.accounts) + 100)
+	s.accounts[sa.UID] = sa
+	return nil
+}
+
+type memRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *memRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	if repoRef != s.repo.Path {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return s.repo, nil
+}
+
+func TestSAMLCheckPrincipalMapper_Map(t *testing.T) {
+	principals := &memPrincipalStore{accounts: map[string]*types.ServiceAccount{}}
+	repos := &memRepoStore{repo: &types.Repository{ID: 7, ParentID: 3, Path: "space/repo"}}
+
+	mapper := NewSAMLCheckPrincipalMapper(principals, repos)
+
+	ctx := context.Background()
+	attributes := map[string][]string{
+		AttributeCIRepo: {"space/repo"},
+		AttributeCIRole: {"Deploy"},
+	}
+
+	session, err := mapper.Map(ctx, attributes)
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+
+	md, ok := session.Metadata.(*auth.CheckReportMetadata)
+	if !ok || md.RepoID != 7 || md.RepoPath != "space/repo" || md.Namespace != "deploy" {
+		t.Errorf("Map() metadata = %+v, want repo space/repo and namespace deploy", session.Metadata)
+	}
+
+	if session.Principal.Type != enum.PrincipalTypeServiceAccount || session.Principal.UID != "sa-repo-7-saml-deploy" {
+		t.Errorf("Map() principal = %s (%s), want the saml service account", session.Principal.UID,
+			session.Principal.Type)
+	}
+
+	again, err := mapper.Map(ctx, attributes)
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+
+	if again.Principal.ID != session.Principal.ID || len(principals.accounts) != 1 {
+		t.Errorf("Map() created %d service accounts, want the existing one to be reused", len(principals.accounts))
+	}
+}
+
+func TestSAMLCheckPrincipalMapper_MapInvalid(t *testing.T) {
+	mapper := NewSAMLCheckPrincipalMapper(
+		&memPrincipalStore{accounts: map[string]*types.ServiceAccount{}},
+		&memRepoStore{repo: &types.Repository{ID: 7, ParentID: 3, Path: "space/repo"}},
+	)
+
+	tests := []struct {
+		name       string
+		attributes map[string][]string
+		wantErr    error
+	}{
+		{
+			name:       "missing repo",
+			attributes: map[string][]string{AttributeCIRole: {"deploy"}},
+			wantErr:    ErrMissingAttribute,
+		},
+		{
+			name:       "multiple roles",
+			attributes: map[string][]string{AttributeCIRepo: {"space/repo"}, AttributeCIRole: {"a", "b"}},
+			wantErr:    ErrMissingAttribute,
+		},
+		{
+			name:       "invalid role",
+			attributes: map[string][]string{AttributeCIRepo: {"space/repo"}, AttributeCIRole: {"not a namespace"}},
+			wantErr:    ErrInvalidRole,
+		},
+		{
+			name:       "default namespace role",
+			attributes: map[string][]string{AttributeCIRepo: {"space/repo"}, AttributeCIRole: {"default"}},
+			wantErr:    ErrInvalidRole,
+		},
+		{
+			name:       "unknown repo",
+			attributes: map[string][]string{AttributeCIRepo: {"space/other"}, AttributeCIRole: {"deploy"}},
+			wantErr:    gitness_store.ErrResourceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapper.Map(context.Background(), tt.attributes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Map() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkscaling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "gitness"
+	subsystem = "check"
+
+	// collectTimeout is how long the calculation of the scaling hints may take per scrape.
+	collectTimeout = 10 * time.Second
+)
+
+var (
+	descPending = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "queue_pending"),
+		"Number of status checks currently waiting to be started.",
+		nil, nil,
+	)
+	descQueueDepth = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "queue_depth_average"),
+		"Average number of status checks waiting to be started in the scaling hints window.",
+		nil, nil,
+	)
+	descBacklogGrowth = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "backlog_growth_per_hour"),
+		"Number of status checks per hour by which the backlog grew in the scaling hints window.",
+		nil, nil,
+	)
+	descP95Duration = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "duration_p95_seconds"),
+		"95th percentile of the duration of the status checks completed in the scaling hints window.",
+		[]string{"check_uid"}, nil,
+	)
+)
+
+// Service provides hints for the autoscaling of the CI infrastructure running the status checks,
+// calculated from the status checks of the recent past.
+type Service struct {
+	analyticsStore store.CheckAnalyticsStore
+	window         time.Duration
+	handler        http.Handler
+}
+
+func NewService(analyticsStore store.CheckAnalyticsStore, window time.Duration) *Service {
+	s := &Service{
+		analyticsStore: analyticsStore,
+		window:         window,
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&collector{service: s})
+
+	s.handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.HTTPErrorOnError,
+	})
+
+	return s
+}
+
+// Hints returns the scaling hints calculated from the status checks in the window until now.
+func (s *Service) Hints(ctx context.Context) (*types.CheckScalingHints, error) {
+	hints, err := s.analyticsStore.ScalingHints(ctx, time.Now().Add(-s.window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate status check scaling hints: %w", err)
+	}
+
+	return hints, nil
+}
+
+// MetricsHandler returns an HTTP handler exposing the scaling hints as Prometheus metrics,
+// for consumption by the Kubernetes HPA (through a metrics adapter) or external autoscalers.
+// The hints are calculated on every scrape.
+func (s *Service) MetricsHandler() http.Handler {
+	return s.handler
+}
+
+// collector collects the scaling hints as Prometheus metrics.
+type collector struct {
+	service *Service
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descPending
+	ch <- descQueueDepth
+	ch <- descBacklogGrowth
+	ch <- descP95Duration
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	hints, err := c.service.Hints(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(descPending, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(descPending, prometheus.GaugeValue, float64(hints.PendingChecks))
+	ch <- prometheus.MustNewConstMetric(descQueueDepth, prometheus.GaugeValue, hints.AvgQueueDepth)
+	ch <- prometheus.MustNewConstMetric(descBacklogGrowth, prometheus.GaugeValue, hints.BacklogGrowthRate)
+
+	for identifier, duration := range hints.P95Durations {
+		ch <- prometheus.MustNewConstMetric(descP95Duration, prometheus.GaugeValue,
+			(time.Duration(duration) * time.Millisecond).Seconds(), identifier)
+	}
+}
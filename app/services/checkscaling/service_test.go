@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkscaling
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type memAnalyticsStore struct {
+	store.CheckAnalyticsStore
+	hints *types.CheckScalingHints
+	err   error
+}
+
+func (s *memAnalyticsStore) ScalingHints(context.Context, time.Time) (*types.CheckScalingHints, error) {
+	return s.hints, s.err
+}
+
+func scrape(t *testing.T, s *Service) (int, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	return rec.Code, rec.Body.String()
+}
+
+func TestService_MetricsHandler(t *testing.T) {
+	s := NewService(&memAnalyticsStore{
+		hints: &types.CheckScalingHints{
+			PendingChecks:     4,
+			AvgQueueDepth:     2.5,
+			BacklogGrowthRate: -3,
+			P95Durations:      map[string]int64{"build": 90_000},
+		},
+	}, 15*time.Minute)
+
+	code, body := scrape(t, s)
+	if code != http.StatusOK {
+		t.Fatalf("scrape status = %d, want %d", code, http.StatusOK)
+	}
+
+	for _, want := range []string{
+		"gitness_check_queue_pending 4",
+		"gitness_check_queue_depth_average 2.5",
+		"gitness_check_backlog_growth_per_hour -3",
+		`gitness_check_duration_p95_seconds{check_uid="build"} 90`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape body doesn't contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestService_MetricsHandlerError(t *testing.T) {
+	s := NewService(&memAnalyticsStore{err: errors.New("database is down")}, 15*time.Minute)
+
+	if code, _ := scrape(t, s); code != http.StatusInternalServerError {
+		t.Errorf("scrape status = %d, want %d", code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksearch
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// ErrInvalidCursor is returned if the search cursor wasn't returned by a previous search.
+var ErrInvalidCursor = errors.New("invalid status check search cursor")
+
+// CheckSearchService searches the status checks of all repositories by the text of their summaries
+// combined with structured filters.
+type CheckSearchService struct {
+	checkStore store.CheckStore
+}
+
+func NewCheckSearchService(checkStore store.CheckStore) *CheckSearchService {
+	return &CheckSearchService{
+		checkStore: checkStore,
+	}
+}
+
+// Search returns a page of the status checks matching the query, newest first.
+// The next page is requested by repeating the query with the cursor of the result.
+func (s *CheckSearchService) Search(
+	ctx context.Context,
+	query types.CheckSearchQuery,
+) (*types.CheckSearchResult, error) {
+	if query.Limit <= 0 {
+		return nil, fmt.Errorf("invalid status check search limit: %d", query.Limit)
+	}
+
+	var after *types.CheckSearchCursor
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = &cursor
+	}
+
+	limit := query.Limit
+
+	// one more status check than requested is fetched to know if there's a next page.
+	query.Limit++
+
+	checks, err := s.checkStore.Search(ctx, query, after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search status checks: %w", err)
+	}
+
+	result := &types.CheckSearchResult{
+		Checks: checks,
+	}
+
+	if len(checks) > limit {
+		result.Checks = checks[:limit]
+
+		last := result.Checks[limit-1]
+		cursor := types.CheckSearchCursor{Updated: last.Updated, ID: last.ID}
+		if result.NextCursor, err = encodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func encodeCursor(cursor types.CheckSearchCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal status check search cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(s string) (types.CheckSearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return types.CheckSearchCursor{}, ErrInvalidCursor
+	}
+
+	var cursor types.CheckSearchCursor
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return types.CheckSearchCursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
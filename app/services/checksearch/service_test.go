@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// memCheckStore searches status checks that are already ordered newest first.
+type memCheckStore struct {
+	store.CheckStore
+	checks []types.Check
+}
+
+func (s *memCheckStore) Search(
+	_ context.Context,
+	query types.CheckSearchQuery,
+	after *types.CheckSearchCursor,
+) ([]types.Check, error) {
+	result := make([]types.Check, 0)
+	for _, c := range s.checks {
+		if after != nil && (c.Updated > after.Updated || c.Updated == after.Updated && c.ID >= after.ID) {
+			continue
+		}
+		if len(result) == query.Limit {
+			break
+		}
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
+func TestCheckSearchService_Search(t *testing.T) {
+	checkStore := &memCheckStore{
+		checks: []types.Check{
+			{ID: 5, Identifier: "build", Updated: 300},
+			{ID: 4, Identifier: "test", Updated: 200},
+			{ID: 3, Identifier: "lint", Updated: 200},
+			{ID: 2, Identifier: "deploy", Updated: 100},
+			{ID: 1, Identifier: "scan", Updated: 100},
+		},
+	}
+
+	s := NewCheckSearchService(checkStore)
+
+	var pages [][]string
+	query := types.CheckSearchQuery{Limit: 2}
+	for {
+		result, err := s.Search(context.Background(), query)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+
+		page := make([]string, len(result.Checks))
+		for i, c := range result.Checks {
+			page[i] = c.Identifier
+		}
+		pages = append(pages, page)
+
+		if result.NextCursor == "" {
+			break
+		}
+		query.Cursor = result.NextCursor
+	}
+
+	want := [][]string{{"build", "test"}, {"lint", "deploy"}, {"scan"}}
+	if len(pages) != len(want) {
+		t.Fatalf("got %d pages %v, want %d pages %v", len(pages), pages, len(want), want)
+	}
+	for i := range want {
+		if len(pages[i]) != len(want[i]) {
+			t.Fatalf("page %d = %v, want %v", i, pages[i], want[i])
+		}
+		for j := range want[i] {
+			if pages[i][j] != want[i][j] {
+				t.Errorf("page %d = %v, want %v", i, pages[i], want[i])
+			}
+		}
+	}
+}
+
+func TestCheckSearchService_SearchInvalidCursor(t *testing.T) {
+	s := NewCheckSearchService(&memCheckStore{})
+
+	for _, cursor := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, err := s.Search(context.Background(), types.CheckSearchQuery{Cursor: cursor, Limit: 10})
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Search() with cursor %q error = %v, want %v", cursor, err, ErrInvalidCursor)
+		}
+	}
+}
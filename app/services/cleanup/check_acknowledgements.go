@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCheckAcknowledgements        = "gitness:cleanup:check-acknowledgements"
+	jobCronCheckAcknowledgements        = "17 * * * *" // At minute 17 past every hour.
+	jobMaxDurationCheckAcknowledgements = 1 * time.Minute
+)
+
+type checkAcknowledgementsCleanupJob struct {
+	checkStore store.CheckStore
+}
+
+func newCheckAcknowledgementsCleanupJob(
+	checkStore store.CheckStore,
+) *checkAcknowledgementsCleanupJob {
+	return &checkAcknowledgementsCleanupJob{
+		checkStore: checkStore,
+	}
+}
+
+// Handle deletes the expired status check acknowledgements.
+// Expired acknowledgements are already ignored when evaluating merge eligibility, the job only purges them.
+func (j *checkAcknowledgementsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	log.Ctx(ctx).Info().Msg("start purging expired status check acknowledgements")
+
+	n, err := j.checkStore.DeleteExpiredAcknowledgements(ctx, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to delete expired status check acknowledgements: %w", err)
+	}
+
+	result := "no expired status check acknowledgements found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d status check acknowledgements", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
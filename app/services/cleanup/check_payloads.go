@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCheckPayloads        = "gitness:cleanup:check-payloads"
+	jobCronCheckPayloads        = "43 */6 * * *" // At minute 43 past every 6th hour.
+	jobMaxDurationCheckPayloads = 10 * time.Minute
+
+	checkPayloadsBatchSize = 100
+)
+
+type checkPayloadsCompressJob struct {
+	checkStore store.CheckStore
+}
+
+func newCheckPayloadsCompressJob(
+	checkStore store.CheckStore,
+) *checkPayloadsCompressJob {
+	return &checkPayloadsCompressJob{
+		checkStore: checkStore,
+	}
+}
+
+// Handle compresses large status check payloads that have been stored uncompressed,
+// e.g. because they were reported before the payload compression got enabled.
+func (j *checkPayloadsCompressJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	log.Ctx(ctx).Info().Msg("start compressing large status check payloads")
+
+	var total int
+	for {
+		n, err := j.checkStore.CompressPayloads(ctx, checkPayloadsBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to compress status check payloads: %w", err)
+		}
+
+		total += n
+
+		if n < checkPayloadsBatchSize {
+			break
+		}
+	}
+
+	result := "no uncompressed large status check payloads found"
+	if total > 0 {
+		result = fmt.Sprintf("compressed %d status check payloads", total)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeOrphanChecks        = "gitness:cleanup:orphan-checks"
+	jobMaxDurationOrphanChecks = 30 * time.Minute
+
+	orphanChecksBatchSize = 500
+)
+
+type orphanChecksCleanupJob struct {
+	dryRun     bool
+	checkStore store.CheckStore
+}
+
+func newOrphanChecksCleanupJob(
+	dryRun bool,
+	checkStore store.CheckStore,
+) *orphanChecksCleanupJob {
+	return &orphanChecksCleanupJob{
+		dryRun:     dryRun,
+		checkStore: checkStore,
+	}
+}
+
+// Handle deletes the status check results whose repository doesn't exist anymore.
+// The foreign key of the checks table normally deletes them together with the repository,
+// the job cleans up after databases that were modified without the foreign keys being enforced.
+func (j *orphanChecksCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	log.Ctx(ctx).Info().Msg("start looking for orphan status check results")
+
+	found, err := j.checkStore.CountOrphans(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to count orphan status check results: %w", err)
+	}
+
+	if found == 0 || j.dryRun {
+		result := fmt.Sprintf("found %d orphan status check results", found)
+		if found > 0 {
+			result += ", none deleted in dry-run mode"
+		}
+
+		log.Ctx(ctx).Info().Int64("found", found).Msg(result)
+
+		return result, nil
+	}
+
+	var removed int64
+	for {
+		n, err := j.checkStore.DeleteOrphans(ctx, orphanChecksBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to delete orphan status check results: %w", err)
+		}
+
+		removed += n
+
+		if n < orphanChecksBatchSize {
+			break
+		}
+	}
+
+	result := fmt.Sprintf("found %d and deleted %d orphan status check results", found, removed)
+
+	log.Ctx(ctx).Info().Int64("found", found).Int64("removed", removed).Msg(result)
+
+	return result, nil
+}
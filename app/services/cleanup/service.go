@@ -28,6 +28,10 @@ import (
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	// OrphanChecksCron is the schedule of the deletion of status check results of nonexistent repositories.
+	OrphanChecksCron string
+	// OrphanChecksDryRun only logs the number of orphan status check results instead of deleting them.
+	OrphanChecksDryRun bool
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +45,10 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.OrphanChecksCron == "" {
+		return errors.New("config.OrphanChecksCron has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +61,7 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	checkStore            store.CheckStore
 }
 
 func NewService(
@@ -63,6 +72,7 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	checkStore store.CheckStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +87,7 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		checkStore:            checkStore,
 	}, nil
 }
 
@@ -126,6 +137,40 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeCheckPayloads,
+		jobTypeCheckPayloads,
+		jobCronCheckPayloads,
+		jobMaxDurationCheckPayloads,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule check payloads compression job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeCheckAcknowledgements,
+		jobTypeCheckAcknowledgements,
+		jobCronCheckAcknowledgements,
+		jobMaxDurationCheckAcknowledgements,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule check acknowledgements cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeOrphanChecks,
+		jobTypeOrphanChecks,
+		s.config.OrphanChecksCron,
+		jobMaxDurationOrphanChecks,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule orphan checks cleanup job: %w", err)
+	}
+
 	return nil
 }
 
@@ -160,5 +205,34 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeCheckPayloads,
+		newCheckPayloadsCompressJob(
+			s.checkStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for check payloads compression: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeCheckAcknowledgements,
+		newCheckAcknowledgementsCleanupJob(
+			s.checkStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for check acknowledgements cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeOrphanChecks,
+		newOrphanChecksCleanupJob(
+			s.config.OrphanChecksDryRun,
+			s.checkStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for orphan checks cleanup: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// checkSLAAlertWindow is the time range of the SLA breach rate that is compared to the alert threshold.
+	checkSLAAlertWindow = 24 * time.Hour
+
+	subjectCheckSLABreached = "[%s] Status check %s breached its SLA"
+)
+
+type CheckSLABreachedPayload struct {
+	Repo              *types.Repository
+	Identifier        string
+	CommitSHA         string
+	Duration          time.Duration
+	MaxDuration       time.Duration
+	BreachRatePercent int
+	RepoURL           string
+}
+
+// notifyCheckSLABreached alerts the principal that configured the SLA of a status check
+// if the SLA breach rate of the status check reached the alert threshold of the SLA.
+func (s *Service) notifyCheckSLABreached(
+	ctx context.Context,
+	event *events.Event[*checkevents.SLABreachedPayload],
+) error {
+	payload, recipients, err := s.processCheckSLABreachedEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for status check %q: %w",
+			checkevents.SLABreachedEvent,
+			event.Payload.Identifier,
+			err,
+		)
+	}
+	if payload == nil {
+		return nil
+	}
+
+	if err = s.notificationClient.SendCheckSLABreached(ctx, recipients, payload); err != nil {
+		return fmt.Errorf(
+			"failed to send email for event %s for status check %q: %w",
+			checkevents.SLABreachedEvent,
+			event.Payload.Identifier,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) processCheckSLABreachedEvent(
+	ctx context.Context,
+	event *events.Event[*checkevents.SLABreachedPayload],
+) (*CheckSLABreachedPayload, []*types.PrincipalInfo, error) {
+	config, err := s.checkConfigStore.Find(ctx, event.Payload.RepoID, event.Payload.Identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find status check config: %w", err)
+	}
+
+	// the SLA might have been removed since the status check breached it.
+	if config.SLA == nil {
+		return nil, nil, nil
+	}
+
+	rates, err := s.checkAnalyticsStore.SLABreachRates(ctx, event.Payload.RepoID, types.CheckSLABreachOptions{
+		Identifier: event.Payload.Identifier,
+		From:       event.Timestamp.Add(-checkSLAAlertWindow),
+		To:         event.Timestamp.Add(time.Millisecond),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get status check SLA breach rate: %w", err)
+	}
+
+	var rate float64
+	if len(rates) > 0 {
+		rate = rates[0].Rate()
+	}
+
+	if rate < config.SLA.AlertThresholdFraction {
+		return nil, nil, nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch repo from repoStore: %w", err)
+	}
+
+	recipient, err := s.principalInfoCache.Get(ctx, config.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch status check config creator %d from principalInfoCache: %w",
+			config.CreatedBy, err)
+	}
+
+	return &CheckSLABreachedPayload{
+		Repo:              repo,
+		Identifier:        event.Payload.Identifier,
+		CommitSHA:         event.Payload.CommitSHA,
+		Duration:          time.Duration(event.Payload.Ended-event.Payload.Started) * time.Millisecond,
+		MaxDuration:       time.Duration(config.SLA.MaxDurationSeconds) * time.Second,
+		BreachRatePercent: int(rate * 100),
+		RepoURL:           s.urlProvider.GenerateUIRepoURL(ctx, repo.Path),
+	}, []*types.PrincipalInfo{recipient}, nil
+}
@@ -58,4 +58,9 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendCheckSLABreached(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *CheckSLABreachedPayload,
+	) error
 }
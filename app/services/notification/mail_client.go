@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/types"
@@ -32,6 +33,7 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateCheckSLABreached     = "check_sla_breached.html"
 )
 
 type MailClient struct {
@@ -169,6 +171,27 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendCheckSLABreached(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CheckSLABreachedPayload,
+) error {
+	body, err := GetHTMLBody(TemplateCheckSLABreached, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			checkevents.SLABreachedEvent, err)
+	}
+
+	email := mailer.Payload{
+		Body:         string(body),
+		Subject:      fmt.Sprintf(subjectCheckSLABreached, payload.Repo.Identifier, payload.Identifier),
+		RepoRef:      payload.Repo.Path,
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+	}
+
+	return m.Mailer.Send(ctx, email)
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
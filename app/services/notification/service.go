@@ -22,6 +22,7 @@ import (
 	"io/fs"
 	"path"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -31,9 +32,10 @@ import (
 )
 
 const (
-	eventReaderGroupName = "gitness:notification"
-	templatesDir         = "templates"
-	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+	eventReaderGroupName      = "gitness:notification"
+	checkEventReaderGroupName = "gitness:notification:checks"
+	templatesDir              = "templates"
+	subjectPullReqEvent       = "[%s] %s (PR #%d)"
 )
 
 var (
@@ -88,6 +90,7 @@ type Service struct {
 	config                Config
 	notificationClient    Client
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
+	checkReaderFactory    *events.ReaderFactory[*checkevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
 	principalInfoView     store.PrincipalInfoView
@@ -95,6 +98,8 @@ type Service struct {
 	pullReqReviewersStore store.PullReqReviewerStore
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
+	checkConfigStore      store.CheckConfigStore
+	checkAnalyticsStore   store.CheckAnalyticsStore
 	urlProvider           url.Provider
 }
 
@@ -103,6 +108,7 @@ func NewService(
 	config Config,
 	notificationClient Client,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -110,12 +116,15 @@ func NewService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	checkConfigStore store.CheckConfigStore,
+	checkAnalyticsStore store.CheckAnalyticsStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
 		prReaderFactory:       prReaderFactory,
+		checkReaderFactory:    checkReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
 		principalInfoView:     principalInfoView,
@@ -123,6 +132,8 @@ func NewService(
 		pullReqReviewersStore: pullReqReviewersStore,
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
+		checkConfigStore:      checkConfigStore,
+		checkAnalyticsStore:   checkAnalyticsStore,
 		urlProvider:           urlProvider,
 	}
 
@@ -153,6 +164,24 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = service.checkReaderFactory.Launch(
+		ctx,
+		checkEventReaderGroupName,
+		config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterSLABreached(service.notifyCheckSLABreached)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch event reader for %s: %w", checkEventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
@@ -17,6 +17,7 @@ package notification
 import (
 	"context"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
@@ -36,6 +37,7 @@ func ProvideNotificationService(
 	notificationClient Client,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -43,6 +45,8 @@ func ProvideNotificationService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	checkConfigStore store.CheckConfigStore,
+	checkAnalyticsStore store.CheckAnalyticsStore,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	return NewService(
@@ -50,6 +54,7 @@ func ProvideNotificationService(
 		pullReqConfig,
 		notificationClient,
 		prReaderFactory,
+		checkReaderFactory,
 		pullReqStore,
 		repoStore,
 		principalInfoView,
@@ -57,6 +62,8 @@ func ProvideNotificationService(
 		pullReqReviewersStore,
 		pullReqActivityStore,
 		spacePathStore,
+		checkConfigStore,
+		checkAnalyticsStore,
 		urlProvider,
 	)
 }
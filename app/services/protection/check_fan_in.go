@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// IsFanInCheck returns true if the required status check identifier is a wildcard pattern.
+// A fan-in status check is a virtual status check that aggregates all status checks matching the pattern.
+func IsFanInCheck(identifier string) bool {
+	return strings.ContainsAny(identifier, "*?[{")
+}
+
+// FanInCheckStatus computes the status of the fan-in status check with the provided pattern.
+// It's success if all status checks matching the pattern are satisfied, failure if any of them failed
+// or errored and pending otherwise, which includes the case when no status check matches the pattern yet.
+func FanInCheckStatus(pattern string, checkResults []types.CheckResult) enum.CheckStatus {
+	var matched bool
+	satisfied := true
+
+	for _, checkResult := range checkResults {
+		if !patternMatches(pattern, checkResult.Identifier) {
+			continue
+		}
+
+		matched = true
+
+		switch {
+		case checkResult.IsSatisfied():
+		case checkResult.Status.IsFailed():
+			return enum.CheckStatusFailure
+		default:
+			satisfied = false
+		}
+	}
+
+	if matched && satisfied {
+		return enum.CheckStatusSuccess
+	}
+
+	return enum.CheckStatusPending
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestFanInCheckStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		results []types.CheckResult
+		exp     enum.CheckStatus
+	}{
+		{
+			name:    "no-matches",
+			pattern: "unit/*",
+			results: []types.CheckResult{{Identifier: "lint", Status: enum.CheckStatusSuccess}},
+			exp:     enum.CheckStatusPending,
+		},
+		{
+			name:    "all-succeeded",
+			pattern: "unit/*",
+			results: []types.CheckResult{
+				{Identifier: "unit/api", Status: enum.CheckStatusSuccess},
+				{Identifier: "unit/store", Status: enum.CheckStatusSuccess},
+				{Identifier: "lint", Status: enum.CheckStatusFailure},
+			},
+			exp: enum.CheckStatusSuccess,
+		},
+		{
+			name:    "one-skipped",
+			pattern: "unit/*",
+			results: []types.CheckResult{
+				{Identifier: "unit/api", Status: enum.CheckStatusSuccess},
+				{Identifier: "unit/store", Status: enum.CheckStatusSkipped},
+			},
+			exp: enum.CheckStatusPending,
+		},
+		{
+			name:    "one-running",
+			pattern: "unit/*",
+			results: []types.CheckResult{
+				{Identifier: "unit/api", Status: enum.CheckStatusSuccess},
+				{Identifier: "unit/store", Status: enum.CheckStatusRunning},
+			},
+			exp: enum.CheckStatusPending,
+		},
+		{
+			name:    "one-failed",
+			pattern: "unit/*",
+			results: []types.CheckResult{
+				{Identifier: "unit/api", Status: enum.CheckStatusRunning},
+				{Identifier: "unit/store", Status: enum.CheckStatusError},
+			},
+			exp: enum.CheckStatusFailure,
+		},
+		{
+			name:    "nested-not-matched",
+			pattern: "unit/*",
+			results: []types.CheckResult{
+				{Identifier: "unit/api", Status: enum.CheckStatusSuccess},
+				{Identifier: "unit/api/slow", Status: enum.CheckStatusFailure},
+			},
+			exp: enum.CheckStatusSuccess,
+		},
+		{
+			name:    "nested-matched",
+			pattern: "unit/**",
+			results: []types.CheckResult{
+				{Identifier: "unit/api", Status: enum.CheckStatusSuccess},
+				{Identifier: "unit/api/slow", Status: enum.CheckStatusFailure},
+			},
+			exp: enum.CheckStatusFailure,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := FanInCheckStatus(test.pattern, test.results); got != test.exp {
+				t.Errorf("want=%s got=%s", test.exp, got)
+			}
+		})
+	}
+}
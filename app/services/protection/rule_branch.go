@@ -82,6 +82,7 @@ func (v *Branch) RequiredChecks(
 	return RequiredChecksOutput{
 		RequiredIdentifiers:   requiredIDs,
 		BypassableIdentifiers: bypassableIDs,
+		SkippableIdentifiers:  out.SkippableIdentifiers,
 	}, nil
 }
 
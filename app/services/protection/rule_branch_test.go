@@ -364,6 +364,59 @@ func TestBranch_RequiredChecks(t *testing.T) {
 				BypassableIdentifiers: nil,
 			},
 		},
+		{
+			name: "alias-reported",
+			branch: Branch{
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"abc"}},
+				},
+			},
+			in: RequiredChecksInput{
+				Actor:        user,
+				CheckAliases: map[string]string{"abc": "xyz"},
+				CheckResults: []types.CheckResult{
+					{Identifier: "abc", Status: enum.CheckStatusFailure},
+					{Identifier: "xyz", Status: enum.CheckStatusSuccess},
+				},
+			},
+			expOut: RequiredChecksOutput{
+				RequiredIdentifiers:   map[string]struct{}{"xyz": {}},
+				BypassableIdentifiers: nil,
+			},
+		},
+		{
+			name: "alias-fallback",
+			branch: Branch{
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"abc"}},
+				},
+			},
+			in: RequiredChecksInput{
+				Actor:        user,
+				CheckAliases: map[string]string{"abc": "xyz"},
+				CheckResults: []types.CheckResult{{Identifier: "abc", Status: enum.CheckStatusSuccess}},
+			},
+			expOut: RequiredChecksOutput{
+				RequiredIdentifiers:   map[string]struct{}{"abc": {}},
+				BypassableIdentifiers: nil,
+			},
+		},
+		{
+			name: "alias-not-reported",
+			branch: Branch{
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"abc"}},
+				},
+			},
+			in: RequiredChecksInput{
+				Actor:        user,
+				CheckAliases: map[string]string{"abc": "xyz"},
+			},
+			expOut: RequiredChecksOutput{
+				RequiredIdentifiers:   map[string]struct{}{"xyz": {}},
+				BypassableIdentifiers: nil,
+			},
+		},
 	}
 
 	ctx := context.Background()
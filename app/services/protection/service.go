@@ -145,6 +145,30 @@ func (m *Manager) ForRepository(ctx context.Context, repoID int64) (Protection,
 	}, nil
 }
 
+// IsBranchProtected returns true if any protection rule that isn't disabled applies to the branch of the repository.
+func (m *Manager) IsBranchProtected(ctx context.Context, repo *types.Repository, branchName string) (bool, error) {
+	ruleInfos, err := m.ruleStore.ListAllRepoRules(ctx, repo.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list rules for repository: %w", err)
+	}
+
+	for _, r := range ruleInfos {
+		if r.State == enum.RuleStateDisabled {
+			continue
+		}
+
+		matches, err := matchesName(r.Pattern, repo.DefaultBranch, branchName)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // GenerateErrorMessageForBlockingViolations generates an error message for a given slice of rule violations.
 // It simply takes the first blocking rule that has a violation and prints that, with indication if further
 // rules were violated.
@@ -74,6 +74,8 @@ func (s ruleSet) RequiredChecks(
 ) (RequiredChecksOutput, error) {
 	requiredIDMap := map[string]struct{}{}
 	bypassableIDMap := map[string]struct{}{}
+	skippableIDMap := map[string]struct{}{}
+	notSkippableIDMap := map[string]struct{}{}
 	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
 		func(_ *types.RuleInfoInternal, p Protection) error {
 			out, err := p.RequiredChecks(ctx, in)
@@ -81,6 +83,18 @@ func (s ruleSet) RequiredChecks(
 				return err
 			}
 
+			// a status check can only be skipped if every rule that requires it allows skipped status checks.
+			for _, ids := range []map[string]struct{}{out.RequiredIdentifiers, out.BypassableIdentifiers} {
+				for reqCheckID := range ids {
+					if _, ok := out.SkippableIdentifiers[reqCheckID]; !ok {
+						notSkippableIDMap[reqCheckID] = struct{}{}
+						delete(skippableIDMap, reqCheckID)
+					} else if _, ok := notSkippableIDMap[reqCheckID]; !ok {
+						skippableIDMap[reqCheckID] = struct{}{}
+					}
+				}
+			}
+
 			for reqCheckID := range out.RequiredIdentifiers {
 				requiredIDMap[reqCheckID] = struct{}{}
 				delete(bypassableIDMap, reqCheckID)
@@ -98,9 +112,14 @@ func (s ruleSet) RequiredChecks(
 		return RequiredChecksOutput{}, fmt.Errorf("failed to process each rule in ruleSet: %w", err)
 	}
 
+	if len(skippableIDMap) == 0 {
+		skippableIDMap = nil
+	}
+
 	return RequiredChecksOutput{
 		RequiredIdentifiers:   requiredIDMap,
 		BypassableIdentifiers: bypassableIDMap,
+		SkippableIdentifiers:  skippableIDMap,
 	}, nil
 }
 
@@ -434,6 +434,47 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 				BypassableIdentifiers: map[string]struct{}{"a": {}},
 			},
 		},
+		{
+			name: "skippable",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo: types.RuleInfo{
+						SpacePath:  "",
+						RepoPath:   "space/repo",
+						ID:         1,
+						Identifier: "rule1",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern: []byte(`{"default":true}`),
+					Definition: []byte(`{
+						"pullreq":{"status_checks":{"require_identifiers":["a", "b"],"allow_skipped":true}}
+					}`),
+				},
+				{
+					RuleInfo: types.RuleInfo{
+						SpacePath:  "space",
+						RepoPath:   "",
+						ID:         2,
+						Identifier: "rule2",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{"default":true}`),
+					Definition: []byte(`{"pullreq":{"status_checks":{"require_identifiers":["b","c"]}}}`),
+				},
+			},
+			input: RequiredChecksInput{
+				Actor:   &types.Principal{ID: 1},
+				Repo:    &types.Repository{ID: 1, DefaultBranch: "main"},
+				PullReq: &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "main"},
+			},
+			expOut: RequiredChecksOutput{
+				RequiredIdentifiers:   map[string]struct{}{"a": {}, "b": {}, "c": {}},
+				BypassableIdentifiers: map[string]struct{}{},
+				SkippableIdentifiers:  map[string]struct{}{"a": {}},
+			},
+		},
 	}
 
 	ctx := context.Background()
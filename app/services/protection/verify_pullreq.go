@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/harness/gitness/app/services/codeowners"
@@ -45,7 +46,14 @@ type (
 		Reviewers          []*types.PullReqReviewer
 		Method             enum.MergeMethod
 		CheckResults       []types.CheckResult
-		CodeOwners         *codeowners.Evaluation
+		// ResolveCheckLabel returns the status check results of a namespace with the label.
+		ResolveCheckLabel func(ctx context.Context, namespace, label string) ([]types.CheckResult, error)
+		// ResolveCheckNamespace returns the status check results of a namespace other than the default one,
+		// the results of the default namespace are provided as CheckResults.
+		ResolveCheckNamespace func(ctx context.Context, namespace string) ([]types.CheckResult, error)
+		// CheckAliases holds the new identifiers of renamed status checks by their old identifiers.
+		CheckAliases map[string]string
+		CodeOwners   *codeowners.Evaluation
 	}
 
 	MergeVerifyOutput struct {
@@ -65,11 +73,20 @@ type (
 		IsRepoOwner        bool
 		Repo               *types.Repository
 		PullReq            *types.PullReq
+		// ResolveCheckLabel returns the status check results of a namespace with the label.
+		ResolveCheckLabel func(ctx context.Context, namespace, label string) ([]types.CheckResult, error)
+		// CheckAliases holds the new identifiers of renamed status checks by their old identifiers.
+		CheckAliases map[string]string
+		// CheckResults holds the reported status checks, used to resolve renamed required status checks.
+		CheckResults []types.CheckResult
 	}
 
 	RequiredChecksOutput struct {
 		RequiredIdentifiers   map[string]struct{}
 		BypassableIdentifiers map[string]struct{}
+		// SkippableIdentifiers holds the required status checks that can be satisfied by a skipped status check,
+		// because all rules requiring them allow it.
+		SkippableIdentifiers map[string]struct{}
 	}
 )
 
@@ -96,11 +113,12 @@ const (
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
+	codePullReqStatusChecksReqLabels      = "pullreq.status_checks.required_labels"
 )
 
 //nolint:gocognit,gocyclo,cyclop // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
@@ -203,12 +221,31 @@ func (v *DefPullReq) MergeVerify(
 
 	// pullreq.status_checks
 
+	checkResults := in.CheckResults
+	if ns := v.StatusChecks.namespace(); ns != types.CheckNamespaceDefault {
+		var err error
+		checkResults, err = in.ResolveCheckNamespace(ctx, ns)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to resolve status checks of namespace %q: %w", ns, err)
+		}
+	}
+
 	var violatingStatusCheckIdentifiers []string
 	for _, requiredIdentifier := range v.StatusChecks.RequireIdentifiers {
+		// a fan-in status check succeeds once all status checks matching the pattern succeeded.
+		if IsFanInCheck(requiredIdentifier) {
+			if FanInCheckStatus(requiredIdentifier, checkResults) != enum.CheckStatusSuccess {
+				violatingStatusCheckIdentifiers = append(violatingStatusCheckIdentifiers, requiredIdentifier)
+			}
+			continue
+		}
+
+		identifier := resolveCheckAlias(requiredIdentifier, in.CheckAliases, checkResults)
+
 		var succeeded bool
-		for i := range in.CheckResults {
-			if in.CheckResults[i].Identifier == requiredIdentifier {
-				succeeded = in.CheckResults[i].Status == enum.CheckStatusSuccess
+		for i := range checkResults {
+			if checkResults[i].Identifier == identifier {
+				succeeded = v.StatusChecks.isSatisfied(checkResults[i])
 				break
 			}
 		}
@@ -218,6 +255,28 @@ func (v *DefPullReq) MergeVerify(
 		}
 	}
 
+	// every status check that has one of the required labels must succeed as well,
+	// and at least one status check has to be reported with each of the required labels.
+	var missingStatusCheckLabels []string
+	for _, requiredLabel := range v.StatusChecks.RequireLabels {
+		labeledCheckResults, err := in.ResolveCheckLabel(ctx, v.StatusChecks.namespace(), requiredLabel)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to resolve status checks with label %q: %w", requiredLabel, err)
+		}
+
+		if len(labeledCheckResults) == 0 {
+			missingStatusCheckLabels = append(missingStatusCheckLabels, requiredLabel)
+			continue
+		}
+
+		for _, checkResult := range labeledCheckResults {
+			if !v.StatusChecks.isSatisfied(checkResult) &&
+				!slices.Contains(violatingStatusCheckIdentifiers, checkResult.Identifier) {
+				violatingStatusCheckIdentifiers = append(violatingStatusCheckIdentifiers, checkResult.Identifier)
+			}
+		}
+	}
+
 	if len(violatingStatusCheckIdentifiers) > 0 {
 		violations.Addf(
 			codePullReqStatusChecksReqIdentifiers,
@@ -226,6 +285,14 @@ func (v *DefPullReq) MergeVerify(
 		)
 	}
 
+	if len(missingStatusCheckLabels) > 0 {
+		violations.Addf(
+			codePullReqStatusChecksReqLabels,
+			"Status checks with the following labels are required to be reported: %s",
+			strings.Join(missingStatusCheckLabels, ", "),
+		)
+	}
+
 	// pullreq.merge
 
 	out.AllowedMethods = enum.MergeMethods
@@ -259,16 +326,54 @@ func (v *DefPullReq) MergeVerify(
 }
 
 func (v *DefPullReq) RequiredChecks(
-	_ context.Context,
-	_ RequiredChecksInput,
+	ctx context.Context,
+	in RequiredChecksInput,
 ) (RequiredChecksOutput, error) {
 	m := make(map[string]struct{}, len(v.StatusChecks.RequireIdentifiers))
 	for _, id := range v.StatusChecks.RequireIdentifiers {
-		m[id] = struct{}{}
+		m[resolveCheckAlias(id, in.CheckAliases, in.CheckResults)] = struct{}{}
 	}
-	return RequiredChecksOutput{
+
+	for _, label := range v.StatusChecks.RequireLabels {
+		checkResults, err := in.ResolveCheckLabel(ctx, v.StatusChecks.namespace(), label)
+		if err != nil {
+			return RequiredChecksOutput{}, fmt.Errorf("failed to resolve status checks with label %q: %w", label, err)
+		}
+
+		for _, checkResult := range checkResults {
+			m[checkResult.Identifier] = struct{}{}
+		}
+	}
+
+	out := RequiredChecksOutput{
 		RequiredIdentifiers: m,
-	}, nil
+	}
+
+	if v.StatusChecks.AllowSkipped {
+		out.SkippableIdentifiers = maps.Clone(m)
+	}
+
+	return out, nil
+}
+
+// resolveCheckAlias returns the identifier the required status check is expected to be reported with.
+// A renamed status check satisfies the requirement of its old identifier,
+// the old identifier is only used if the status check wasn't reported with the new one.
+func resolveCheckAlias(identifier string, aliases map[string]string, results []types.CheckResult) string {
+	newIdentifier, ok := aliases[identifier]
+	if !ok {
+		return identifier
+	}
+
+	reported := func(identifier string) bool {
+		return slices.ContainsFunc(results, func(r types.CheckResult) bool { return r.Identifier == identifier })
+	}
+
+	if !reported(newIdentifier) && reported(identifier) {
+		return identifier
+	}
+
+	return newIdentifier
 }
 
 type DefApprovals struct {
@@ -300,6 +405,29 @@ func (DefComments) Sanitize() error {
 
 type DefStatusChecks struct {
 	RequireIdentifiers []string `json:"require_identifiers,omitempty"`
+	// RequireLabels requires all status checks reported with any of the labels to succeed.
+	RequireLabels []string `json:"require_labels,omitempty"`
+	// RequireNamespace is the namespace the required identifiers have to be reported in, e.g. "production".
+	// It prevents status checks of other environments from satisfying the requirement.
+	// Empty means the default namespace.
+	RequireNamespace string `json:"require_namespace,omitempty"`
+	// AllowSkipped lets skipped status checks (e.g. skipped because of a commit message directive)
+	// satisfy the requirement. By default only successful status checks do.
+	AllowSkipped bool `json:"allow_skipped,omitempty"`
+}
+
+// namespace returns the namespace the required status checks have to be reported in.
+func (c DefStatusChecks) namespace() string {
+	if c.RequireNamespace == "" {
+		return types.CheckNamespaceDefault
+	}
+
+	return c.RequireNamespace
+}
+
+// isSatisfied returns true if the status check result fulfills the status check requirement.
+func (c DefStatusChecks) isSatisfied(checkResult types.CheckResult) bool {
+	return checkResult.IsSatisfied() || c.AllowSkipped && checkResult.Status == enum.CheckStatusSkipped
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -342,6 +470,24 @@ func (c *DefStatusChecks) Sanitize() error {
 		return fmt.Errorf("required identifiers error: %w", err)
 	}
 
+	for _, identifier := range c.RequireIdentifiers {
+		if !IsFanInCheck(identifier) {
+			continue
+		}
+
+		if err := patternValidate(identifier); err != nil {
+			return fmt.Errorf("required identifier %q is not a valid fan-in pattern: %w", identifier, err)
+		}
+	}
+
+	if err := validateIdentifierSlice(c.RequireLabels); err != nil {
+		return fmt.Errorf("required labels error: %w", err)
+	}
+
+	if c.RequireNamespace != "" && !types.IsValidCheckNamespace(c.RequireNamespace) {
+		return fmt.Errorf("required namespace must match the regular expression: %s", types.CheckNamespaceRegexp)
+	}
+
 	return nil
 }
 
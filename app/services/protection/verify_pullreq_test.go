@@ -323,6 +323,245 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-alias-success",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"build"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "build", Status: enum.CheckStatusFailure},
+					{Identifier: "ci/build", Status: enum.CheckStatusSuccess},
+				},
+				CheckAliases: map[string]string{"build": "ci/build"},
+				Method:       enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-alias-fallback",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"build"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "build", Status: enum.CheckStatusFailure},
+				},
+				CheckAliases: map[string]string{"build": "ci/build"},
+				Method:       enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"build"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-fan-in-pending",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"unit/*"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "unit/api", Status: enum.CheckStatusSuccess},
+					{Identifier: "unit/store", Status: enum.CheckStatusRunning},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"unit/*"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-fan-in-success",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"unit/*"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "unit/api", Status: enum.CheckStatusSuccess},
+					{Identifier: "unit/store", Status: enum.CheckStatusSuccess},
+					{Identifier: "lint", Status: enum.CheckStatusFailure},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-label-fail",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireLabels: []string{"required"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusSuccess},
+					{Identifier: "check2", Status: enum.CheckStatusRunning},
+				},
+				ResolveCheckLabel: func(context.Context, string, string) ([]types.CheckResult, error) {
+					return []types.CheckResult{
+						{Identifier: "check1", Status: enum.CheckStatusSuccess},
+						{Identifier: "check2", Status: enum.CheckStatusRunning},
+					}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"check2"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-label-success",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireLabels: []string{"required"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusSuccess},
+					{Identifier: "check2", Status: enum.CheckStatusFailure},
+				},
+				ResolveCheckLabel: func(context.Context, string, string) ([]types.CheckResult, error) {
+					return []types.CheckResult{
+						{Identifier: "check1", Status: enum.CheckStatusSuccess},
+					}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqLabels,
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireLabels: []string{"required"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusSuccess},
+				},
+				ResolveCheckLabel: func(context.Context, string, string) ([]types.CheckResult, error) {
+					return []types.CheckResult{}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqLabels},
+			expParams: [][]any{{"required"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-label-namespace",
+			def: DefPullReq{StatusChecks: DefStatusChecks{
+				RequireLabels:    []string{"required"},
+				RequireNamespace: "production",
+			}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{},
+				ResolveCheckNamespace: func(context.Context, string) ([]types.CheckResult, error) {
+					return []types.CheckResult{}, nil
+				},
+				ResolveCheckLabel: func(_ context.Context, namespace, _ string) ([]types.CheckResult, error) {
+					if namespace != "production" {
+						return []types.CheckResult{}, nil
+					}
+					return []types.CheckResult{
+						{Identifier: "deploy", Status: enum.CheckStatusFailure},
+					}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"deploy"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-namespace-fail",
+			def: DefPullReq{StatusChecks: DefStatusChecks{
+				RequireIdentifiers: []string{"deploy"},
+				RequireNamespace:   "production",
+			}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "deploy", Status: enum.CheckStatusSuccess},
+				},
+				ResolveCheckNamespace: func(context.Context, string) ([]types.CheckResult, error) {
+					return []types.CheckResult{
+						{Identifier: "deploy", Status: enum.CheckStatusFailure},
+					}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"deploy"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-namespace-success",
+			def: DefPullReq{StatusChecks: DefStatusChecks{
+				RequireIdentifiers: []string{"deploy"},
+				RequireNamespace:   "production",
+			}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "deploy", Status: enum.CheckStatusFailure},
+				},
+				ResolveCheckNamespace: func(context.Context, string) ([]types.CheckResult, error) {
+					return []types.CheckResult{
+						{Identifier: "deploy", Status: enum.CheckStatusSuccess},
+					}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-acknowledged",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"check1", "check2"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusError, Acknowledged: true},
+					{Identifier: "check2", Status: enum.CheckStatusRunning, Acknowledged: true},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"check2"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-skipped",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"check1"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusSkipped},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
+			expParams: [][]any{{"check1"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-skipped-allowed",
+			def: DefPullReq{StatusChecks: DefStatusChecks{
+				RequireIdentifiers: []string{"check1"},
+				AllowSkipped:       true,
+			}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusSkipped},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 		{
 			name: codePullReqMergeStrategiesAllowed + "-fail",
 			def: DefPullReq{Merge: DefMerge{StrategiesAllowed: []enum.MergeMethod{
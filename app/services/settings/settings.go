@@ -14,6 +14,11 @@
 
 package settings
 
+import (
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
 type Key string
 
 var (
@@ -24,4 +29,29 @@ var (
 	DefaultFileSizeLimit             = int64(1e+8) // 100 MB
 	KeyInstallID                 Key = "install_id"
 	DefaultInstallID                 = string("")
+	// KeyGithubStatusMirrorRepo [string] is the GitHub repository (owner/repo) status checks are mirrored to.
+	KeyGithubStatusMirrorRepo     Key = "github_status_mirror_repo"
+	DefaultGithubStatusMirrorRepo     = string("")
+	// KeyGithubChecksSyncRepo [string] is the GitHub repository (owner/repo) status checks are synced to as check runs.
+	KeyGithubChecksSyncRepo     Key = "github_checks_sync_repo"
+	DefaultGithubChecksSyncRepo     = string("")
+	// KeyGithubChecksInstallationID [int64] is the installation of the GitHub App used to sync check runs.
+	KeyGithubChecksInstallationID     Key = "github_checks_installation_id"
+	DefaultGithubChecksInstallationID     = int64(0)
+	// KeyTagRequireChecksPassing [bool] blocks tag creation unless all status checks of the target commit passed.
+	KeyTagRequireChecksPassing     Key = "tag_require_checks_passing"
+	DefaultTagRequireChecksPassing     = false
+	// KeyMergeCheckTrailers [enum.CheckTrailerFormat] adds status check trailers to merge commit messages.
+	KeyMergeCheckTrailers     Key = "merge_check_trailers"
+	DefaultMergeCheckTrailers     = enum.CheckTrailerFormatNone
+	// KeyCheckIssueTracker [enum.IssueTracker] is the issue tracker failed status checks are propagated to.
+	KeyCheckIssueTracker     Key = "check_issue_tracker"
+	DefaultCheckIssueTracker     = enum.IssueTrackerNone
+	// KeyCheckIssueTrackerTransition [string] is the issue transition done when a status check fails.
+	// The referenced issues are only commented on if it's empty.
+	KeyCheckIssueTrackerTransition     Key = "check_issue_tracker_transition"
+	DefaultCheckIssueTrackerTransition     = string("")
+	// KeyMergeFreeze [types.MergeFreeze] defines the time windows in which pull requests can't be merged.
+	KeyMergeFreeze     Key = "merge_freeze"
+	DefaultMergeFreeze     = types.MergeFreeze{Bypass: enum.MergeFreezeBypassNone}
 )
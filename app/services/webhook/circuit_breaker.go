@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	// circuitClosed lets all requests through while counting the failures.
+	circuitClosed circuitState = iota
+	// circuitOpen rejects all requests until the endpoint had time to recover.
+	circuitOpen
+	// circuitHalfOpen lets a single probe request through to decide whether to close the circuit again.
+	circuitHalfOpen
+)
+
+// circuitBreakerConfig defines when the circuit of a webhook endpoint is opened and for how long.
+type circuitBreakerConfig struct {
+	// FailureRatio is the ratio of failed requests within the window that opens the circuit.
+	// Zero disables the circuit breaker.
+	FailureRatio float64
+	// MinRequests is the number of requests within the window required before the circuit can be opened.
+	MinRequests int
+	// Window is the duration over which the requests and failures are counted.
+	Window time.Duration
+	// OpenTimeout is the duration the circuit stays open before a probe request is let through.
+	OpenTimeout time.Duration
+}
+
+// circuitBreakers tracks the failures of the webhook endpoints in memory, keyed by the URL,
+// and stops sending requests to endpoints that keep failing (5xx responses or no response at all).
+type circuitBreakers struct {
+	config circuitBreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state       circuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+}
+
+func newCircuitBreakers(config circuitBreakerConfig) *circuitBreakers {
+	return &circuitBreakers{
+		config:   config,
+		now:      time.Now,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// allow returns whether a request to the endpoint can be sent. If so, the outcome of the request
+// has to be reported using the returned function.
+func (b *circuitBreakers) allow(url string) (func(failed bool), bool) {
+	if b.config.FailureRatio <= 0 {
+		return func(bool) {}, true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+
+	c, ok := b.circuits[url]
+	if !ok {
+		c = &circuit{state: circuitClosed, windowStart: now}
+		b.circuits[url] = c
+	}
+
+	switch c.state {
+	case circuitOpen:
+		if now.Sub(c.openedAt) < b.config.OpenTimeout {
+			return nil, false
+		}
+		c.state = circuitHalfOpen
+	case circuitHalfOpen:
+		// the probe request is still running.
+		return nil, false
+	case circuitClosed:
+		if now.Sub(c.windowStart) >= b.config.Window {
+			c.windowStart = now
+			c.requests = 0
+			c.failures = 0
+		}
+	}
+
+	probe := c.state == circuitHalfOpen
+
+	return func(failed bool) {
+		b.report(c, probe, failed)
+	}, true
+}
+
+func (b *circuitBreakers) report(c *circuit, probe bool, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+
+	if probe {
+		if failed {
+			c.state = circuitOpen
+			c.openedAt = now
+			return
+		}
+
+		c.state = circuitClosed
+		c.windowStart = now
+		c.requests = 0
+		c.failures = 0
+		return
+	}
+
+	// the circuit might have been opened by another request in the meantime.
+	if c.state != circuitClosed {
+		return
+	}
+
+	c.requests++
+	if failed {
+		c.failures++
+	}
+
+	if c.requests >= b.config.MinRequests && float64(c.failures) >= b.config.FailureRatio*float64(c.requests) {
+		c.state = circuitOpen
+		c.openedAt = now
+	}
+}
+
+// state returns the current state of the circuit of the endpoint.
+func (b *circuitBreakers) state(url string) circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[url]
+	if !ok {
+		return circuitClosed
+	}
+
+	return c.state
+}
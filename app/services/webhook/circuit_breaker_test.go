@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	const url = "https://example.com/hook"
+
+	now := time.Unix(1700000000, 0)
+	b := newCircuitBreakers(circuitBreakerConfig{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		Window:       time.Minute,
+		OpenTimeout:  30 * time.Second,
+	})
+	b.now = func() time.Time { return now }
+
+	send := func(failed bool) bool {
+		report, ok := b.allow(url)
+		if ok {
+			report(failed)
+		}
+		return ok
+	}
+
+	// not enough requests yet to open the circuit
+	for _, failed := range []bool{true, true, true} {
+		if !send(failed) {
+			t.Fatalf("request rejected by closed circuit")
+		}
+	}
+
+	// failures of an old window don't count
+	now = now.Add(time.Minute)
+	for _, failed := range []bool{false, false, true} {
+		send(failed)
+	}
+	if state := b.state(url); state != circuitClosed {
+		t.Fatalf("expected closed circuit, got %d", state)
+	}
+
+	// the fourth request reaches the minimum, two of four requests failed
+	send(true)
+	if state := b.state(url); state != circuitOpen {
+		t.Fatalf("expected open circuit, got %d", state)
+	}
+
+	if send(false) {
+		t.Fatalf("request allowed by open circuit")
+	}
+
+	// a single probe request is let through after the timeout
+	now = now.Add(30 * time.Second)
+	report, ok := b.allow(url)
+	if !ok {
+		t.Fatalf("probe request rejected")
+	}
+	if _, ok = b.allow(url); ok {
+		t.Fatalf("second request allowed while probing")
+	}
+
+	// a failed probe opens the circuit again
+	report(true)
+	if state := b.state(url); state != circuitOpen {
+		t.Fatalf("expected open circuit after failed probe, got %d", state)
+	}
+
+	// a successful probe closes it
+	now = now.Add(30 * time.Second)
+	if !send(false) {
+		t.Fatalf("probe request rejected")
+	}
+	if state := b.state(url); state != circuitClosed {
+		t.Fatalf("expected closed circuit after successful probe, got %d", state)
+	}
+
+	// other endpoints aren't affected
+	if state := b.state("https://example.com/other"); state != circuitClosed {
+		t.Fatalf("expected closed circuit for other endpoint, got %d", state)
+	}
+}
+
+func TestCircuitBreakers_Disabled(t *testing.T) {
+	b := newCircuitBreakers(circuitBreakerConfig{})
+
+	for range 100 {
+		report, ok := b.allow("https://example.com/hook")
+		if !ok {
+			t.Fatalf("request rejected by disabled circuit breaker")
+		}
+		report(true)
+	}
+}
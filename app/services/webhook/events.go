@@ -130,6 +130,22 @@ func (s *Service) findRepositoryForEvent(ctx context.Context, repoID int64) (*ty
 	return repo, nil
 }
 
+// findCheckForEvent finds the status check for the provided checkID.
+func (s *Service) findCheckForEvent(ctx context.Context, checkID int64) (*types.Check, error) {
+	check, err := s.checkStore.FindByID(ctx, checkID)
+
+	if err != nil && errors.Is(err, store.ErrResourceNotFound) {
+		// not found error is unrecoverable - most likely a racing condition of check being deleted by now
+		return nil, events.NewDiscardEventErrorf("check with id '%d' doesn't exist anymore", checkID)
+	}
+	if err != nil {
+		// all other errors we return and force the event to be reprocessed
+		return nil, fmt.Errorf("failed to get check for id '%d': %w", checkID, err)
+	}
+
+	return check, nil
+}
+
 // findPullReqForEvent finds the pullrequest for the provided prID.
 func (s *Service) findPullReqForEvent(ctx context.Context, prID int64) (*types.PullReq, error) {
 	pr, err := s.pullreqStore.Find(ctx, prID)
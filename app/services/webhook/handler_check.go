@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckPayload describes the body of the status check created and status changed triggers.
+type CheckPayload struct {
+	BaseSegment
+	CheckSegment
+}
+
+// handleEventCheckStatusChanged handles status changed events for status checks and triggers
+// check created webhooks for newly reported status checks and check status changed webhooks otherwise.
+// Webhooks are only triggered for public status checks, the receivers aren't authorized to see the others.
+func (s *Service) handleEventCheckStatusChanged(ctx context.Context,
+	event *events.Event[*checkevents.StatusChangedPayload]) error {
+	trigger := enum.WebhookTriggerCheckStatusChanged
+	if event.Payload.OldStatus == "" {
+		trigger = enum.WebhookTriggerCheckCreated
+	}
+
+	check, err := s.findCheckForEvent(ctx, event.Payload.CheckID)
+	if err != nil {
+		return err
+	}
+
+	if check.Visibility != enum.CheckVisibilityPublic {
+		return nil
+	}
+
+	return s.triggerForEventWithRepo(ctx, trigger,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &CheckPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   trigger,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				CheckSegment: CheckSegment{
+					SHA:       event.Payload.CommitSHA,
+					Check:     check,
+					OldStatus: event.Payload.OldStatus,
+				},
+			}, nil
+		})
+}
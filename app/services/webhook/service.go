@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/store"
@@ -51,6 +52,16 @@ type Config struct {
 
 	// InternalWebhooksURL specifies the internal webhook URL which will be used if webhook is marked internal
 	InternalWebhooksURL string
+
+	// CircuitBreakerFailureRatio is the ratio of failed requests to a webhook endpoint that stops
+	// the delivery to it for CircuitBreakerOpenTimeout. Zero disables the circuit breaker.
+	CircuitBreakerFailureRatio float64
+	// CircuitBreakerMinRequests is the number of requests required before the delivery can be stopped.
+	CircuitBreakerMinRequests int
+	// CircuitBreakerWindow is the duration over which the failed requests are counted.
+	CircuitBreakerWindow time.Duration
+	// CircuitBreakerOpenTimeout is the duration after which a stopped delivery is probed again.
+	CircuitBreakerOpenTimeout time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -69,6 +80,14 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.CircuitBreakerFailureRatio < 0 || c.CircuitBreakerFailureRatio > 1 {
+		return errors.New("config.CircuitBreakerFailureRatio has to be between 0 and 1")
+	}
+	if c.CircuitBreakerFailureRatio > 0 &&
+		(c.CircuitBreakerMinRequests < 1 || c.CircuitBreakerWindow <= 0 || c.CircuitBreakerOpenTimeout <= 0) {
+		return errors.New("config.CircuitBreakerMinRequests, config.CircuitBreakerWindow and " +
+			"config.CircuitBreakerOpenTimeout have to be positive if the circuit breaker is enabled")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
@@ -92,6 +111,7 @@ type Service struct {
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
 	labelStore            store.LabelStore
+	checkStore            store.CheckStore
 	encrypter             encrypt.Encrypter
 
 	secureHTTPClient   *http.Client
@@ -100,6 +120,8 @@ type Service struct {
 	secureHTTPClientInternal   *http.Client
 	insecureHTTPClientInternal *http.Client
 
+	circuitBreakers *circuitBreakers
+
 	config Config
 }
 
@@ -109,6 +131,7 @@ func NewService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -120,7 +143,7 @@ func NewService(
 	git git.Interface,
 	encrypter encrypt.Encrypter,
 	labelStore store.LabelStore,
-
+	checkStore store.CheckStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -144,9 +167,17 @@ func NewService(
 		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
 		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
 
+		circuitBreakers: newCircuitBreakers(circuitBreakerConfig{
+			FailureRatio: config.CircuitBreakerFailureRatio,
+			MinRequests:  config.CircuitBreakerMinRequests,
+			Window:       config.CircuitBreakerWindow,
+			OpenTimeout:  config.CircuitBreakerOpenTimeout,
+		}),
+
 		config: config,
 
 		labelStore: labelStore,
+		checkStore: checkStore,
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -201,5 +232,24 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = checkReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterStatusChanged(service.handleEventCheckStatusChanged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// FuzzWebhookSignature feeds arbitrary bodies and secrets to the webhook signature generation
+// and verifies the signature the way a receiver of the webhook would.
+func FuzzWebhookSignature(f *testing.F) {
+	f.Add([]byte(`{"trigger":"pullreq_checks_completed","repo":{"id":1},"sha":"a1b2c3"}`), []byte("s3cr3t"))
+	f.Add([]byte(`{"check":{"identifier":"ci/github-actions","status":"failure"}}`), []byte(""))
+	f.Add([]byte{}, []byte("a-key-longer-than-the-sha256-block-size-of-sixty-four-bytes-for-hmac"))
+
+	f.Fuzz(func(t *testing.T, body, secret []byte) {
+		signature, err := generateHMACSHA256(body, secret)
+		if err != nil {
+			t.Fatalf("failed to generate signature: %v", err)
+		}
+
+		mac, err := hex.DecodeString(signature)
+		if err != nil {
+			t.Fatalf("signature %q isn't hex encoded: %v", signature, err)
+		}
+
+		h := hmac.New(sha256.New, secret)
+		h.Write(body)
+		if !hmac.Equal(mac, h.Sum(nil)) {
+			t.Errorf("signature %q doesn't verify against the body", signature)
+		}
+
+		// a receiver must reject the signature for any other body.
+		h = hmac.New(sha256.New, secret)
+		h.Write(append(body, '\n'))
+		if hmac.Equal(mac, h.Sum(nil)) {
+			t.Errorf("signature %q verifies against a modified body", signature)
+		}
+	})
+}
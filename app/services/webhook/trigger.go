@@ -229,6 +229,14 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		return &execution, err
 	}
 
+	// stop sending requests to an endpoint that keeps failing until it had the time to recover
+	reportOutcome, ok := s.circuitBreakers.allow(execution.Request.URL)
+	if !ok {
+		execution.Error = "delivery is paused because the endpoint failed repeatedly"
+		execution.Result = enum.WebhookExecutionResultCircuitOpen
+		return &execution, errCircuitOpen
+	}
+
 	// Execute HTTP Request (insecure if requested)
 	var resp *http.Response
 	switch {
@@ -242,6 +250,9 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		resp, err = s.secureHTTPClient.Do(req)
 	}
 
+	// server errors and requests without response count as failures of the endpoint
+	reportOutcome(err != nil || resp.StatusCode >= http.StatusInternalServerError)
+
 	// always close the body!
 	if resp != nil && resp.Body != nil {
 		defer func() {
@@ -75,6 +75,13 @@ type PullReqSegment struct {
 	PullReq PullReqInfo `json:"pull_req"`
 }
 
+// CheckSegment contains details for all status check related payloads for webhooks.
+type CheckSegment struct {
+	SHA       string           `json:"sha"`
+	Check     *types.Check     `json:"check"`
+	OldStatus enum.CheckStatus `json:"old_status,omitempty"`
+}
+
 // PullReqCommentSegment contains details for all pull req comment related payloads for webhooks.
 type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
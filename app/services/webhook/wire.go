@@ -17,6 +17,7 @@ package webhook
 import (
 	"context"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/store"
@@ -40,6 +41,7 @@ func ProvideService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -51,6 +53,7 @@ func ProvideService(
 	git git.Interface,
 	encrypter encrypt.Encrypter,
 	labelStore store.LabelStore,
+	checkStore store.CheckStore,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -58,6 +61,7 @@ func ProvideService(
 		tx,
 		gitReaderFactory,
 		prReaderFactory,
+		checkReaderFactory,
 		webhookStore,
 		webhookExecutionStore,
 		spaceStore, repoStore,
@@ -68,5 +72,6 @@ func ProvideService(
 		git,
 		encrypter,
 		labelStore,
+		checkStore,
 	)
 }
@@ -15,6 +15,12 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/checkamqp"
+	"github.com/harness/gitness/app/services/checkarchive"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/checkissuetracker"
+	"github.com/harness/gitness/app/services/checkmirror"
+	"github.com/harness/gitness/app/services/checkretry"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspaceevent"
@@ -48,6 +54,13 @@ type Services struct {
 	Cleanup               *cleanup.Service
 	Notification          *notification.Service
 	Keywordsearch         *keywordsearch.Service
+	GithubStatusMirror    *checkmirror.GithubStatusMirror
+	GithubChecksSync      *checkmirror.GitHubChecksSyncService
+	CheckAMQPPublisher    *checkamqp.AMQPCheckPublisher
+	CheckIssueTracker     *checkissuetracker.CheckIssueTrackerIntegration
+	CheckRetry            *checkretry.Service
+	CheckArchiver         *checkarchive.Archiver
+	CheckConfig           *checkconfig.Service
 	GitspaceService       *GitspaceServices
 	Instrumentation       instrument.Service
 	instrumentConsumer    instrument.Consumer
@@ -86,6 +99,13 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	githubStatusMirror *checkmirror.GithubStatusMirror,
+	githubChecksSync *checkmirror.GitHubChecksSyncService,
+	checkAMQPPublisher *checkamqp.AMQPCheckPublisher,
+	checkIssueTracker *checkissuetracker.CheckIssueTrackerIntegration,
+	checkRetrySvc *checkretry.Service,
+	checkArchiver *checkarchive.Archiver,
+	checkConfigSvc *checkconfig.Service,
 	gitspaceSvc *GitspaceServices,
 	instrumentation instrument.Service,
 	instrumentConsumer instrument.Consumer,
@@ -102,6 +122,13 @@ func ProvideServices(
 		Cleanup:               cleanupSvc,
 		Notification:          notificationSvc,
 		Keywordsearch:         keywordsearchSvc,
+		GithubStatusMirror:    githubStatusMirror,
+		GithubChecksSync:      githubChecksSync,
+		CheckAMQPPublisher:    checkAMQPPublisher,
+		CheckIssueTracker:     checkIssueTracker,
+		CheckRetry:            checkRetrySvc,
+		CheckArchiver:         checkArchiver,
+		CheckConfig:           checkConfigSvc,
 		GitspaceService:       gitspaceSvc,
 		Instrumentation:       instrumentation,
 		instrumentConsumer:    instrumentConsumer,
@@ -15,6 +15,8 @@
 package store
 
 import (
+	"context"
+
 	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
 )
@@ -29,6 +31,14 @@ type (
 	// RepoGitInfoCache caches repository IDs to values GitUID.
 	RepoGitInfoCache cache.Cache[int64, *types.RepositoryGitInfo]
 
+	// RepoCheckSummaryCache caches repository IDs to their status check metrics.
+	RepoCheckSummaryCache cache.EvictableCache[int64, *types.RepositoryCheckSummary]
+
 	// InfraProviderResourceCache caches infraprovider resourceIDs to infraprovider resource.
 	InfraProviderResourceCache cache.ExtendedCache[int64, *types.InfraProviderResource]
+
+	// PrincipalInfoEvictor evicts principals from the principal info caches of all instances.
+	PrincipalInfoEvictor interface {
+		Evict(ctx context.Context, principalID int64) error
+	}
 )
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// repoCheckSummaryL1Duration is the in-process cache duration of the status check metrics
+	// when they are shared through Redis.
+	repoCheckSummaryL1Duration = time.Minute
+	repoCheckSummaryL2Duration = 5 * time.Minute
+)
+
+// RepoCheckSummaryPeriod is the period of time the status check metrics of repositories are calculated for.
+const RepoCheckSummaryPeriod = 30 * 24 * time.Hour
+
+// repoCheckSummaryGetter calculates the status check metrics of a repository over the last RepoCheckSummaryPeriod.
+type repoCheckSummaryGetter struct {
+	checkStore store.CheckStore
+}
+
+func (g repoCheckSummaryGetter) Find(ctx context.Context, repoID int64) (*types.RepositoryCheckSummary, error) {
+	return g.checkStore.RepoCheckSummary(ctx, repoID, time.Now().Add(-RepoCheckSummaryPeriod))
+}
+
+func repoCheckSummaryKey(repoID int64) string {
+	return "check_summary:" + strconv.FormatInt(repoID, 10)
+}
+
+// repoCheckSummaryCodec encodes the status check metrics of repositories stored in Redis as JSON.
+type repoCheckSummaryCodec struct{}
+
+func (repoCheckSummaryCodec) Encode(summary *types.RepositoryCheckSummary) string {
+	data, _ := json.Marshal(summary)
+	return string(data)
+}
+
+func (repoCheckSummaryCodec) Decode(encoded string) (*types.RepositoryCheckSummary, error) {
+	summary := &types.RepositoryCheckSummary{}
+	if err := json.Unmarshal([]byte(encoded), summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	principalInfoCacheMaxAge = 30 * time.Second
+
+	pubsubNamespace            = "cache"
+	pubsubTopicPrincipalUpdate = "principal_updated"
+)
+
+// PrincipalUpdatedEvent is published when the principal info of a principal changes.
+type PrincipalUpdatedEvent struct {
+	PrincipalID int64 `json:"principal_id"`
+}
+
+// NewPrincipalInfoCache returns a principal info cache that evicts the principals
+// for which a PrincipalUpdatedEvent is published by any instance.
+func NewPrincipalInfoCache(
+	ctx context.Context,
+	getter store.PrincipalInfoView,
+	bus pubsub.PubSub,
+) store.PrincipalInfoCache {
+	c := cache.NewExtended[int64, *types.PrincipalInfo](getter, principalInfoCacheMaxAge)
+
+	_ = bus.Subscribe(ctx, pubsubTopicPrincipalUpdate, func(payload []byte) error {
+		event := PrincipalUpdatedEvent{}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to unmarshal principal updated event")
+			return nil
+		}
+
+		c.Evict(ctx, event.PrincipalID)
+
+		return nil
+	}, pubsub.WithChannelNamespace(pubsubNamespace))
+
+	return c
+}
+
+// NewPrincipalInfoEvictor returns a store.PrincipalInfoEvictor that publishes PrincipalUpdatedEvent
+// to evict a principal from the principal info caches of all instances.
+func NewPrincipalInfoEvictor(bus pubsub.Publisher) store.PrincipalInfoEvictor {
+	return principalInfoEvictor{bus: bus}
+}
+
+type principalInfoEvictor struct {
+	bus pubsub.Publisher
+}
+
+func (e principalInfoEvictor) Evict(ctx context.Context, principalID int64) error {
+	payload, err := json.Marshal(PrincipalUpdatedEvent{PrincipalID: principalID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal principal updated event: %w", err)
+	}
+
+	err = e.bus.Publish(ctx, pubsubTopicPrincipalUpdate, payload, pubsub.WithPublishNamespace(pubsubNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to publish principal updated event: %w", err)
+	}
+
+	return nil
+}
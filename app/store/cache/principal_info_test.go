@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+)
+
+type countingPrincipalInfoView struct {
+	store.PrincipalInfoView
+	calls atomic.Int32
+}
+
+func (v *countingPrincipalInfoView) Find(_ context.Context, id int64) (*types.PrincipalInfo, error) {
+	v.calls.Add(1)
+	return &types.PrincipalInfo{ID: id}, nil
+}
+
+func TestPrincipalInfoCache_EvictOnPrincipalUpdated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := pubsub.NewInMemory(pubsub.WithSendTimeout(time.Second))
+	view := &countingPrincipalInfoView{}
+
+	pCache := NewPrincipalInfoCache(ctx, view, bus)
+	evictor := NewPrincipalInfoEvictor(bus)
+
+	if _, err := pCache.Get(ctx, 1); err != nil {
+		t.Fatalf("failed to get principal info: %v", err)
+	}
+	if _, err := pCache.Get(ctx, 1); err != nil {
+		t.Fatalf("failed to get principal info: %v", err)
+	}
+	if calls := view.calls.Load(); calls != 1 {
+		t.Fatalf("expected principal info to be cached, got %d calls", calls)
+	}
+
+	// the subscriber is started and the events are delivered asynchronously.
+	deadline := time.Now().Add(5 * time.Second)
+	for view.calls.Load() == 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("principal info wasn't evicted from the cache")
+		}
+
+		if err := evictor.Evict(ctx, 1); err != nil {
+			t.Fatalf("failed to evict principal info: %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		if _, err := pCache.Get(ctx, 1); err != nil {
+			t.Fatalf("failed to get principal info: %v", err)
+		}
+	}
+}
@@ -15,26 +15,40 @@
 package cache
 
 import (
+	"context"
 	"time"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/types"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
 )
 
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvidePrincipalInfoCache,
+	ProvidePrincipalInfoEvictor,
 	ProvidePathCache,
 	ProvideRepoGitInfoCache,
+	ProvideRepoCheckSummaryCache,
 	ProvideInfraProviderResourceCache,
 )
 
 // ProvidePrincipalInfoCache provides a cache for storing types.PrincipalInfo objects.
-func ProvidePrincipalInfoCache(getter store.PrincipalInfoView) store.PrincipalInfoCache {
-	return cache.NewExtended[int64, *types.PrincipalInfo](getter, 30*time.Second)
+func ProvidePrincipalInfoCache(
+	ctx context.Context,
+	getter store.PrincipalInfoView,
+	bus pubsub.PubSub,
+) store.PrincipalInfoCache {
+	return NewPrincipalInfoCache(ctx, getter, bus)
+}
+
+// ProvidePrincipalInfoEvictor provides an evictor of principals from the principal info caches of all instances.
+func ProvidePrincipalInfoEvictor(bus pubsub.PubSub) store.PrincipalInfoEvictor {
+	return NewPrincipalInfoEvictor(bus)
 }
 
 // ProvidePathCache provides a cache for storing routing paths and their types.SpacePath objects.
@@ -50,6 +64,26 @@ func ProvideRepoGitInfoCache(getter store.RepoGitInfoView) store.RepoGitInfoCach
 	return cache.New[int64, *types.RepositoryGitInfo](getter, 15*time.Minute)
 }
 
+// ProvideRepoCheckSummaryCache provides a cache for storing the status check metrics of repositories.
+// The metrics are cached separately from the rest of the repository summary as they change more often.
+// With the shared summary cache enabled, the in-process cache is backed by Redis.
+func ProvideRepoCheckSummaryCache(
+	config *types.Config,
+	redisClient redis.UniversalClient,
+	checkStore store.CheckStore,
+) store.RepoCheckSummaryCache {
+	getter := repoCheckSummaryGetter{checkStore: checkStore}
+
+	if !config.Checks.SharedSummaryCache {
+		return cache.New[int64, *types.RepositoryCheckSummary](getter, repoCheckSummaryL2Duration)
+	}
+
+	l2 := cache.NewRedis[int64, *types.RepositoryCheckSummary](redisClient, getter, repoCheckSummaryKey,
+		repoCheckSummaryCodec{}, repoCheckSummaryL2Duration)
+
+	return cache.NewTwoLevel[int64, *types.RepositoryCheckSummary](l2, repoCheckSummaryL1Duration)
+}
+
 // ProvideInfraProviderResourceCache provides a cache for storing types.InfraProviderResource objects.
 func ProvideInfraProviderResourceCache(getter store.InfraProviderResourceView) store.InfraProviderResourceCache {
 	return cache.NewExtended[int64, *types.InfraProviderResource](getter, 5*time.Minute)
@@ -18,6 +18,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"time"
 
 	"github.com/harness/gitness/git/sha"
@@ -276,6 +277,9 @@ type (
 		// List returns a list of repos in a space. With "DeletedBeforeOrAt" filter, lists deleted repos.
 		List(ctx context.Context, parentID int64, opts *types.RepoFilter) ([]*types.Repository, error)
 
+		// ListForks returns a list of active forks of a repo.
+		ListForks(ctx context.Context, repoID int64, filter *types.RepoFilter) ([]*types.Repository, error)
+
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
 	}
@@ -395,6 +399,9 @@ type (
 		// for all prs with target branch pointing to targetBranch.
 		ResetMergeCheckStatus(ctx context.Context, targetRepo int64, targetBranch string) error
 
+		// UpdateRequiredChecksPassing sets whether all status checks required for merging the pull request succeeded.
+		UpdateRequiredChecksPassing(ctx context.Context, id int64, passing bool) error
+
 		// Delete the pull request.
 		Delete(ctx context.Context, id int64) error
 
@@ -409,6 +416,9 @@ type (
 
 		// ListOpenByBranchName returns open pull requests for each branch.
 		ListOpenByBranchName(ctx context.Context, repoID int64, branchNames []string) (map[string][]*types.PullReq, error)
+
+		// ListOpenBySourceSHA returns the open pull requests of the target repository at the source commit.
+		ListOpenBySourceSHA(ctx context.Context, repoID int64, sourceSHA string) ([]*types.PullReq, error)
 	}
 
 	PullReqActivityStore interface {
@@ -620,30 +630,432 @@ type (
 	}
 
 	CheckStore interface {
-		// FindByIdentifier returns status check result for given unique key.
+		// FindByIdentifier returns status check result for given unique key in the default namespace.
 		FindByIdentifier(ctx context.Context, repoID int64, commitSHA string, identifier string) (types.Check, error)
 
+		// FindInNamespace returns status check result for given unique key.
+		FindInNamespace(
+			ctx context.Context,
+			repoID int64,
+			commitSHA string,
+			namespace string,
+			identifier string,
+		) (types.Check, error)
+
+		// FindByID returns the status check result with the provided ID, including the reporting principal.
+		FindByID(ctx context.Context, checkID int64) (*types.Check, error)
+
+		// FindAt returns the state of the status check as it was at the provided time,
+		// reconstructed from the status check audit log.
+		FindAt(
+			ctx context.Context,
+			repoID int64,
+			commitSHA string,
+			namespace string,
+			identifier string,
+			at time.Time,
+		) (*types.Check, error)
+
 		// Upsert creates new or updates an existing status check result.
+		// An existing status check result with the same status, summary and payload is left untouched,
+		// the check is then replaced with it and marked as deduplicated.
 		Upsert(ctx context.Context, check *types.Check) error
 
+		// UpsertWithCAS creates new or updates an existing status check result only if the existing
+		// status check has the expected status. Returns false if the existing status check has a different status.
+		UpsertWithCAS(ctx context.Context, check *types.Check, expectedStatus enum.CheckStatus) (bool, error)
+
+		// Patch updates only the status check fields that are set in the patch.
+		Patch(ctx context.Context, repoID int64, commitSHA string, identifier string, patch types.CheckPatch) error
+
+		// UpsertBatch creates new or updates existing status check results,
+		// resolving conflicts with existing status check results using the provided strategy.
+		UpsertBatch(ctx context.Context, checks []*types.Check, strategy enum.ConflictStrategy) error
+
+		// CompressPayloads compresses up to batchSize stored payloads that exceed the compression threshold,
+		// but were stored uncompressed. It returns the number of compressed payloads.
+		CompressPayloads(ctx context.Context, batchSize int) (int, error)
+
+		// CountOrphans counts the status check results whose repository doesn't exist anymore.
+		CountOrphans(ctx context.Context) (int64, error)
+
+		// ListOrphanRepoIDs returns the IDs of the repositories that don't exist anymore
+		// but still have status check results.
+		ListOrphanRepoIDs(ctx context.Context) ([]int64, error)
+
+		// DeleteOrphans deletes up to batchSize status check results whose repository doesn't exist anymore.
+		// It returns the number of deleted status check results.
+		DeleteOrphans(ctx context.Context, batchSize int) (int64, error)
+
+		// RotateEncryptionKey re-encrypts up to batchSize stored payloads and metadata
+		// that aren't encrypted with the active encryption key. It returns the number of processed status checks.
+		RotateEncryptionKey(ctx context.Context, batchSize int) (int, error)
+
 		// Count counts status check results for a specific commit in a repo.
+		// If the commit SHA is empty, status check results of all commits in the repo are counted.
 		Count(ctx context.Context, repoID int64, commitSHA string, opts types.CheckListOptions) (int, error)
 
 		// List returns a list of status check results for a specific commit in a repo.
+		// If the commit SHA is empty, status check results of all commits in the repo are listed.
 		List(ctx context.Context, repoID int64, commitSHA string, opts types.CheckListOptions) ([]types.Check, error)
 
+		// ListWithAnnotations returns all status check results for a specific commit in a repo,
+		// each with all of its annotations.
+		ListWithAnnotations(ctx context.Context, repoID int64, commitSHA string) ([]*types.CheckWithAnnotations, error)
+
+		// ListRetryCandidates returns a list of completed status checks in a repo that qualify for an automatic retry.
+		ListRetryCandidates(ctx context.Context, repoID int64, opts types.CheckRetryCandidateOptions) ([]types.Check, error)
+
+		// ListUpdatedBefore returns the least recently updated status checks not updated since the provided time.
+		ListUpdatedBefore(ctx context.Context, before int64, limit int) ([]types.Check, error)
+
+		// DeleteByIDs deletes the status checks with the provided IDs. It returns the number of deleted status checks.
+		DeleteByIDs(ctx context.Context, ids []int64) (int64, error)
+
+		// DeleteByRepo deletes all status checks of a repo. It returns the number of deleted status checks.
+		DeleteByRepo(ctx context.Context, repoID int64) (int64, error)
+
+		// IncrementRetryCount increments the number of automatic retries of a status check.
+		IncrementRetryCount(ctx context.Context, checkID int64) error
+
+		// MoveBySHA moves all status checks of a commit to another commit, e.g. after the commit got amended.
+		// It returns the number of moved status checks.
+		MoveBySHA(ctx context.Context, repoID int64, oldSHA, newSHA string) (int, error)
+
 		// ListRecent returns a list of recently executed status checks in a repository.
 		ListRecent(ctx context.Context, repoID int64, opts types.CheckRecentOptions) ([]string, error)
 
+		// ListRecentStats returns the recently executed status checks in a repository with their flake score.
+		ListRecentStats(
+			ctx context.Context,
+			repoID int64,
+			opts types.CheckRecentOptions,
+		) ([]types.CheckIdentifierStats, error)
+
 		// ListResults returns a list of status check results for a specific commit in a repo.
 		ListResults(ctx context.Context, repoID int64, commitSHA string) ([]types.CheckResult, error)
 
+		// ListResultsInNamespace returns a list of status check results of a namespace for a specific commit in a repo.
+		ListResultsInNamespace(
+			ctx context.Context,
+			repoID int64,
+			commitSHA string,
+			namespace string,
+		) ([]types.CheckResult, error)
+
+		// Acknowledge marks the failure of the status check run last updated at checkUpdated as known
+		// until expiresAt. The acknowledgement doesn't apply to later runs of the status check.
+		// An existing acknowledgement of the status check is replaced.
+		Acknowledge(
+			ctx context.Context,
+			checkID int64,
+			checkUpdated int64,
+			actor int64,
+			reason string,
+			expiresAt time.Time,
+		) error
+
+		// DeleteExpiredAcknowledgements deletes the status check acknowledgements that expired before the provided time.
+		DeleteExpiredAcknowledgements(ctx context.Context, before time.Time) (int64, error)
+
+		// ListForMergeGate returns the status checks with the required identifiers for a specific commit in a repo,
+		// with the same precedence of the status checks reported in the repo itself as ListResults.
+		ListForMergeGate(ctx context.Context, repoID int64, commitSHA string, requiredUIDs []string) ([]*types.Check, error)
+
+		// ListByLabel returns a list of status check results of a namespace with the provided label
+		// for a specific commit in a repo. Status checks reported in other repos that target the repo are included.
+		ListByLabel(
+			ctx context.Context,
+			repoID int64,
+			commitSHA string,
+			namespace string,
+			label string,
+		) ([]types.CheckResult, error)
+
 		// ResultSummary returns a list of status check result summaries for the provided list of commits in a repo.
+		// Only the status checks with any of the visibilities are counted, nil counts all status checks.
 		ResultSummary(
 			ctx context.Context,
 			repoID int64,
 			commitSHAs []string,
+			visibilities []enum.CheckVisibility,
 		) (map[sha.SHA]types.CheckCountSummary, error)
+
+		// ResourceUsageSummary returns the resource usage of the status checks in a repo updated in the provided
+		// time range, aggregated per status check identifier. Status checks without resource usage are ignored.
+		ResourceUsageSummary(
+			ctx context.Context,
+			repoID int64,
+			opts types.CheckResourceUsageOptions,
+		) ([]types.CheckResourceUsageSummary, error)
+
+		// ListBySHAs returns the status check results of the provided commits in a repo, mapped by commit SHA.
+		ListBySHAs(ctx context.Context, repoID int64, shas []string) (map[string][]*types.Check, error)
+
+		// Search returns the status checks matching the search query, newest first.
+		// The status checks after the cursor are returned if it's provided.
+		Search(
+			ctx context.Context,
+			query types.CheckSearchQuery,
+			after *types.CheckSearchCursor,
+		) ([]types.Check, error)
+
+		// FilterCommitSHAs returns those of the provided commits in a repo that have a status check result
+		// matching the identifier and the status. An empty identifier or status matches any.
+		// Only the status checks with any of the visibilities are considered, nil considers all.
+		FilterCommitSHAs(
+			ctx context.Context,
+			repoID int64,
+			commitSHAs []string,
+			identifier string,
+			status enum.CheckStatus,
+			visibilities []enum.CheckVisibility,
+		) ([]string, error)
+
+		// HeadResultSummary returns the status check result summary of the recorded default branch head commit
+		// for each of the provided repos.
+		HeadResultSummary(ctx context.Context, repoIDs []int64) (map[int64]types.CheckCountSummary, error)
+
+		// ListSummaries returns the status check summaries of all commits of a repo
+		// as maintained by the database, keyed by commit SHA.
+		ListSummaries(ctx context.Context, repoID int64) (map[string]types.CheckCountSummary, error)
+
+		// CountSummaries returns the status check summaries of all commits of a repo
+		// aggregated from its status checks, keyed by commit SHA.
+		CountSummaries(ctx context.Context, repoID int64) (map[string]types.CheckCountSummary, error)
+
+		// RepoCheckSummary returns the status check metrics of a repo calculated from the status checks
+		// created since the provided time.
+		RepoCheckSummary(ctx context.Context, repoID int64, from time.Time) (*types.RepositoryCheckSummary, error)
+
+		// Ping verifies that the checks table can be queried.
+		Ping(ctx context.Context) error
+	}
+
+	CheckAuditStore interface {
+		// Create creates a new status check audit log entry.
+		Create(ctx context.Context, entry *types.CheckAuditEntry) error
+
+		// Find returns the status check audit log entry with the provided ID, including the recorded payload.
+		Find(ctx context.Context, id int64) (*types.CheckAuditEntry, error)
+
+		// ListAuditByPrincipal returns the status check audit log entries of a principal
+		// recorded in the provided time range, most recent first.
+		ListAuditByPrincipal(
+			ctx context.Context,
+			principalID int64,
+			from, to time.Time,
+			page, size int,
+		) ([]*types.CheckAuditEntry, error)
+
+		// ListAuditByRepo returns the status check audit log entries of a repo
+		// recorded since the provided time, oldest first.
+		ListAuditByRepo(
+			ctx context.Context,
+			repoID int64,
+			from time.Time,
+			page, size int,
+		) ([]*types.CheckAuditEntry, error)
+
+		// ListHistory returns a page of the status check audit log entries of a repo, oldest first.
+		ListHistory(ctx context.Context, filter types.CheckHistoryFilter) ([]*types.CheckAuditEntry, error)
+
+		// ExportCSV streams all status check audit log entries of a repo matching the filter
+		// to the writer as CSV, oldest first. The pagination of the filter is ignored.
+		ExportCSV(ctx context.Context, w io.Writer, filter types.CheckHistoryFilter) error
+	}
+
+	CheckAnnotationStore interface {
+		// Replace replaces all annotations of a status check with the provided ones.
+		Replace(ctx context.Context, check *types.Check, annotations []*types.CheckAnnotation) error
+
+		// ListByPaths returns the status check annotations of a commit attached to any of the provided paths.
+		// Only the annotations of status checks with any of the visibilities are listed, nil lists all.
+		ListByPaths(
+			ctx context.Context,
+			repoID int64,
+			commitSHA string,
+			paths []string,
+			visibilities []enum.CheckVisibility,
+		) ([]types.CheckAnnotation, error)
+
+		// ListByLineRange returns the status check annotations of a commit attached to the path
+		// that cover any line of the provided (inclusive) line range.
+		// Only the annotations of status checks with any of the visibilities are listed, nil lists all.
+		ListByLineRange(
+			ctx context.Context,
+			repoID int64,
+			commitSHA string,
+			path string,
+			lineStart int,
+			lineEnd int,
+			visibilities []enum.CheckVisibility,
+		) ([]types.CheckAnnotation, error)
+	}
+
+	CheckConfigStore interface {
+		// Find returns the configuration of a status check in a repo.
+		Find(ctx context.Context, repoID int64, identifier string) (*types.CheckConfig, error)
+
+		// Upsert creates new or updates an existing status check configuration.
+		Upsert(ctx context.Context, config *types.CheckConfig) error
+
+		// Delete deletes the configuration of a status check in a repo.
+		Delete(ctx context.Context, repoID int64, identifier string) error
+
+		// List returns all status check configurations of a repo.
+		List(ctx context.Context, repoID int64) ([]*types.CheckConfig, error)
+
+		// ListRetryable returns all status check configurations that allow automatic retries.
+		ListRetryable(ctx context.Context) ([]*types.CheckConfig, error)
+	}
+
+	SpaceCheckPolicyStore interface {
+		// Find returns the policy of a status check in a space.
+		Find(ctx context.Context, spaceID int64, identifier string) (*types.SpaceCheckPolicy, error)
+
+		// List returns all status check policies of a space.
+		List(ctx context.Context, spaceID int64) ([]*types.SpaceCheckPolicy, error)
+
+		// Replace replaces all status check policies of a space with the provided ones.
+		Replace(ctx context.Context, spaceID int64, policies []*types.SpaceCheckPolicy) error
+
+		// ListRetryable returns all status check policies that allow automatic retries.
+		ListRetryable(ctx context.Context) ([]*types.SpaceCheckPolicy, error)
+	}
+
+	CheckAnalyticsStore interface {
+		// LeaderboardByPrincipal returns the principals that reported the most status checks in a repo
+		// in the provided time range, sorted by the number of reported status checks in descending order.
+		LeaderboardByPrincipal(
+			ctx context.Context,
+			repoID int64,
+			from, to time.Time,
+			limit int,
+		) ([]*types.CheckLeaderEntry, error)
+
+		// VolumeHistogram returns the number of status checks reported in a repo in the provided time range,
+		// bucketed by the provided interval. Buckets without status checks are included with a zero count.
+		VolumeHistogram(
+			ctx context.Context,
+			repoID int64,
+			from, to time.Time,
+			bucketMinutes int,
+		) ([]*types.CheckVolumePoint, error)
+
+		// FailureRates returns the number of completed and failed status checks of the repos,
+		// with the failed ones grouped by failure category, that completed in the provided time range.
+		// Repos without completed status checks are omitted.
+		FailureRates(
+			ctx context.Context,
+			repoIDs []int64,
+			from, to time.Time,
+		) (map[int64]types.CheckFailureRate, error)
+
+		// SpaceFailureRates returns the number of completed and failed status checks of the active repos
+		// in the space and all its descendant spaces, with the failed ones grouped by failure category,
+		// that completed in the provided time range. Repos without completed status checks are omitted.
+		SpaceFailureRates(
+			ctx context.Context,
+			spaceID int64,
+			from, to time.Time,
+		) (map[int64]types.CheckFailureRate, error)
+
+		// SLABreachRates returns the number of status checks of a repo that completed in the provided time range
+		// and how many of them breached their SLA, per status check identifier.
+		SLABreachRates(
+			ctx context.Context,
+			repoID int64,
+			opts types.CheckSLABreachOptions,
+		) ([]types.CheckSLABreachRate, error)
+
+		// ScalingHints returns the status check queue and duration metrics of all repos
+		// calculated from the provided time until now.
+		ScalingHints(ctx context.Context, since time.Time) (*types.CheckScalingHints, error)
+
+		// OrgIngestionStats returns the number of status checks reported in the provided time range
+		// and the size of their payloads for the topN organizations (root spaces) with the most status checks,
+		// sorted by the number of status checks in descending order.
+		OrgIngestionStats(ctx context.Context, from, to time.Time, topN int) ([]*types.OrgIngestionStat, error)
+	}
+
+	CheckAliasStore interface {
+		// List returns all status check identifier aliases of a repo.
+		List(ctx context.Context, repoID int64) ([]*types.CheckAlias, error)
+
+		// Map returns the new status check identifiers of a repo by their old identifiers.
+		Map(ctx context.Context, repoID int64) (map[string]string, error)
+
+		// Create creates a new status check identifier alias.
+		Create(ctx context.Context, alias *types.CheckAlias) error
+
+		// Retarget changes the new identifier of all aliases of a repo that point to the provided identifier.
+		Retarget(ctx context.Context, repoID int64, fromIdentifier, toIdentifier string, updated int64) error
+
+		// Delete deletes the alias of an old status check identifier of a repo.
+		Delete(ctx context.Context, repoID int64, oldIdentifier string) error
+	}
+
+	CheckReplicationWatermarkStore interface {
+		// Find returns the time (unix millis) of the most recent change replicated to the replica of the region.
+		// It returns zero if nothing got replicated yet.
+		Find(ctx context.Context, region string) (int64, error)
+
+		// Advance moves the watermark of the region forward to the provided time (unix millis).
+		// The watermark is never moved back, older times are ignored.
+		Advance(ctx context.Context, region string, replicatedUntil int64) error
+	}
+
+	CheckRepoHeadStore interface {
+		// Map returns the recorded default branch head commit of each of the provided repos.
+		// Repos without a recorded head are omitted from the result.
+		Map(ctx context.Context, repoIDs []int64) (map[int64]string, error)
+
+		// Upsert records the default branch head commit of a repo as of the provided time (unix millis).
+		// Heads older than the recorded one are ignored.
+		Upsert(ctx context.Context, repoID int64, commitSHA string, updated int64) error
+
+		// Delete removes the recorded default branch head commit of a repo.
+		Delete(ctx context.Context, repoID int64) error
+	}
+
+	CheckIssueUpdateStore interface {
+		// List returns the actions completed on the issues for a status check event, by issue key.
+		List(ctx context.Context, eventID string) (map[string][]enum.CheckIssueAction, error)
+
+		// Create records an action completed on an issue for a status check event.
+		Create(
+			ctx context.Context,
+			checkID int64,
+			eventID string,
+			issueKey string,
+			action enum.CheckIssueAction,
+		) error
+	}
+
+	CheckArchiveStore interface {
+		// Find returns the status check archive with the provided ID.
+		Find(ctx context.Context, id int64) (*types.CheckArchive, error)
+
+		// List returns the status check archives of a repo, the most recent period first.
+		List(ctx context.Context, repoID int64, page, size int) ([]*types.CheckArchive, error)
+
+		// CountByPeriod returns the number of status check archives of a repo that start at the provided time.
+		CountByPeriod(ctx context.Context, repoID int64, periodStart int64) (int, error)
+
+		// Create creates a new status check archive record.
+		Create(ctx context.Context, archive *types.CheckArchive) error
+	}
+
+	ReservedCheckStore interface {
+		// List returns all reserved status check identifiers of a space.
+		List(ctx context.Context, spaceID int64) ([]*types.ReservedCheck, error)
+
+		// ListByIdentifier returns the reservations of a status check identifier in any of the spaces.
+		ListByIdentifier(ctx context.Context, spaceIDs []int64, identifier string) ([]*types.ReservedCheck, error)
+
+		// Replace replaces all reserved status check identifiers of a space with the provided ones.
+		Replace(ctx context.Context, spaceID int64, reservations []*types.ReservedCheck) error
 	}
 
 	GitspaceConfigStore interface {
@@ -15,39 +15,86 @@
 package database
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
 )
 
 var _ store.CheckStore = (*CheckStore)(nil)
 
+// CheckStoreMinMigrationVersion is the oldest database migration version containing
+// all tables and columns used by the CheckStore.
+const CheckStoreMinMigrationVersion = "0123_alter_checks_add_encryption_key_ids"
+
 // NewCheckStore returns a new CheckStore.
+// Payloads and metadata are encrypted with the active key of the keyRing, nil disables the encryption.
+// Payloads larger than payloadCompressionThreshold bytes are stored compressed, zero disables the compression.
+// Queries that take longer than slowQueryThreshold are logged, zero disables the logging.
+// ListBySHAs accepts at most listBySHAsLimit commits, zero uses DefaultCheckListBySHAsLimit.
 func NewCheckStore(
 	db *sqlx.DB,
 	pCache store.PrincipalInfoCache,
+	keyRing *encrypt.KeyRing,
+	payloadCompressionThreshold int,
+	slowQueryThreshold time.Duration,
+	listBySHAsLimit int,
 ) *CheckStore {
+	if listBySHAsLimit <= 0 {
+		listBySHAsLimit = DefaultCheckListBySHAsLimit
+	}
+
 	return &CheckStore{
-		db:     db,
-		pCache: pCache,
+		db:                          db,
+		pCache:                      pCache,
+		keyRing:                     keyRing,
+		payloadCompressionThreshold: payloadCompressionThreshold,
+		slowQueryThreshold:          slowQueryThreshold,
+		listBySHAsLimit:             listBySHAsLimit,
 	}
 }
 
+// DefaultCheckListBySHAsLimit is the default maximum number of commits accepted by CheckStore.ListBySHAs.
+const DefaultCheckListBySHAsLimit = 100
+
 // CheckStore implements store.CheckStore backed by a relational database.
 type CheckStore struct {
-	db     *sqlx.DB
-	pCache store.PrincipalInfoCache
+	db                          *sqlx.DB
+	pCache                      store.PrincipalInfoCache
+	keyRing                     *encrypt.KeyRing
+	payloadCompressionThreshold int
+	slowQueryThreshold          time.Duration
+	listBySHAsLimit             int
+}
+
+// getAccessor returns the database accessor of the context with slow query logging.
+func (s *CheckStore) getAccessor(ctx context.Context) dbtx.Accessor {
+	return newExplainAccessor(newSlowQueryAccessor(dbtx.GetAccessor(ctx, s.db), s.slowQueryThreshold))
 }
 
 const (
@@ -59,15 +106,35 @@ const (
 		,check_repo_id
 		,check_commit_sha
 		,check_uid
+		,check_namespace
 		,check_status
 		,check_summary
 		,check_link
 		,check_payload
+		,check_payload_compressed
+		,check_payload_encryption_key_id
 		,check_metadata
+		,check_metadata_encryption_key_id
+		,check_resource_usage
 		,check_payload_kind
 		,check_payload_version
+		,check_payload_steps
+		,check_labels
 		,check_started
-		,check_ended`
+		,check_ended
+		,check_retry_count
+		,check_target_repo_id
+		,check_failure_category
+		,check_sla_breached
+		,check_content_hash
+		,check_visibility`
+
+	// checkAcknowledgedColumn selects whether the current run of a status check has an unexpired acknowledgement,
+	// the current time in milliseconds is its only argument.
+	checkAcknowledgedColumn = `EXISTS (SELECT 1 FROM check_acknowledgements
+		WHERE check_acknowledgement_check_id = check_id
+		AND check_acknowledgement_check_updated = check_updated
+		AND check_acknowledgement_expires > ?) AS check_acknowledged`
 
 	//nolint:goconst
 	checkSelectBase = `
@@ -83,40 +150,146 @@ type check struct {
 	RepoID         int64                 `db:"check_repo_id"`
 	CommitSHA      string                `db:"check_commit_sha"`
 	Identifier     string                `db:"check_uid"`
+	Namespace      string                `db:"check_namespace"`
 	Status         enum.CheckStatus      `db:"check_status"`
 	Summary        string                `db:"check_summary"`
 	Link           string                `db:"check_link"`
 	Payload        json.RawMessage       `db:"check_payload"`
+	Compressed     bool                  `db:"check_payload_compressed"`
+	PayloadKeyID   string                `db:"check_payload_encryption_key_id"`
 	Metadata       json.RawMessage       `db:"check_metadata"`
+	MetadataKeyID  string                `db:"check_metadata_encryption_key_id"`
+	ResourceUsage  *sqlxtypes.JSONText   `db:"check_resource_usage"`
 	PayloadKind    enum.CheckPayloadKind `db:"check_payload_kind"`
 	PayloadVersion string                `db:"check_payload_version"`
+	PayloadSteps   sqlxtypes.JSONText    `db:"check_payload_steps"`
+	Labels         sqlxtypes.JSONText    `db:"check_labels"`
 	Started        int64                 `db:"check_started"`
 	Ended          int64                 `db:"check_ended"`
+	RetryCount     int                   `db:"check_retry_count"`
+	TargetRepoID   null.Int              `db:"check_target_repo_id"`
+
+	FailureCategory enum.CheckFailureCategory `db:"check_failure_category"`
+	SLABreached     bool                      `db:"check_sla_breached"`
+	ContentHash     string                    `db:"check_content_hash"`
+	Visibility      enum.CheckVisibility      `db:"check_visibility"`
 }
 
-// FindByIdentifier returns status check result for given unique key.
+// FindByIdentifier returns status check result for given unique key in the default namespace.
 func (s *CheckStore) FindByIdentifier(
 	ctx context.Context,
 	repoID int64,
 	commitSHA string,
 	identifier string,
+) (types.Check, error) {
+	return s.FindInNamespace(ctx, repoID, commitSHA, types.CheckNamespaceDefault, identifier)
+}
+
+// FindInNamespace returns status check result for given unique key.
+func (s *CheckStore) FindInNamespace(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	identifier string,
 ) (types.Check, error) {
 	const sqlQuery = checkSelectBase + `
-		WHERE check_repo_id = $1 AND check_uid = $2 AND check_commit_sha = $3`
+		WHERE check_repo_id = $1 AND check_uid = $2 AND check_commit_sha = $3 AND check_namespace = $4`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := s.getAccessor(ctx)
 
 	dst := new(check)
-	if err := db.GetContext(ctx, dst, sqlQuery, repoID, identifier, commitSHA); err != nil {
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, identifier, commitSHA, namespace); err != nil {
 		return types.Check{}, database.ProcessSQLErrorf(ctx, err, "Failed to find check")
 	}
 
-	return mapCheck(dst), nil
+	return s.mapCheck(dst)
 }
 
-// Upsert creates new or updates an existing status check result.
-func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
-	const sqlQuery = `
+// FindByID returns the status check result with the provided ID, including the reporting principal.
+func (s *CheckStore) FindByID(ctx context.Context, checkID int64) (*types.Check, error) {
+	const sqlQuery = checkSelectBase + `
+		WHERE check_id = $1`
+
+	db := s.getAccessor(ctx)
+
+	dst := new(check)
+	if err := db.GetContext(ctx, dst, sqlQuery, checkID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find check by id")
+	}
+
+	c, err := s.mapCheck(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	reportedBy, err := s.pCache.Get(ctx, c.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal info of status check reporter: %w", err)
+	}
+
+	c.ReportedBy = reportedBy
+
+	return &c, nil
+}
+
+// FindAt returns the state of the status check as it was at the provided time.
+// The state is reconstructed from the most recent audit log entry recorded at or before the time,
+// the rest of the status check is taken from its current version.
+func (s *CheckStore) FindAt(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	identifier string,
+	at time.Time,
+) (*types.Check, error) {
+	namespace = checkNamespace(namespace)
+
+	stmt := database.Builder.
+		Select(checkAuditColumns).
+		From("check_audits").
+		Where("check_audit_repo_id = ?", repoID).
+		Where("check_audit_commit_sha = ?", commitSHA).
+		Where("check_audit_namespace = ?", namespace).
+		Where("check_audit_check_uid = ?", identifier).
+		Where("check_audit_timestamp <= ?", at.UnixMilli()).
+		OrderBy("check_audit_timestamp DESC", "check_audit_id DESC").
+		Limit(1)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	dst := new(checkAudit)
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find status check history entry")
+	}
+
+	entry, err := mapCheckAudit(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.FindInNamespace(ctx, repoID, commitSHA, namespace, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Status = entry.After.Status
+	c.Summary = entry.After.Summary
+	c.Link = entry.After.Link
+	c.Started = entry.After.Started
+	c.Ended = entry.After.Ended
+	c.Updated = entry.Timestamp
+
+	return &c, nil
+}
+
+const checkUpsertBase = `
 	INSERT INTO checks (
 		 check_created_by
 		,check_created
@@ -124,15 +297,27 @@ func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 		,check_repo_id
 		,check_commit_sha
 		,check_uid
+		,check_namespace
 		,check_status
 		,check_summary
 		,check_link
 		,check_payload
+		,check_payload_compressed
+		,check_payload_encryption_key_id
 		,check_metadata
+		,check_metadata_encryption_key_id
+		,check_resource_usage
 		,check_payload_kind
 		,check_payload_version
+		,check_payload_steps
+		,check_labels
 		,check_started
 		,check_ended
+		,check_target_repo_id
+		,check_failure_category
+		,check_sla_breached
+		,check_content_hash
+		,check_visibility
 	) VALUES (
 		 :check_created_by
 		,:check_created
@@ -140,297 +325,2139 @@ func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 		,:check_repo_id
 		,:check_commit_sha
 		,:check_uid
+		,:check_namespace
 		,:check_status
 		,:check_summary
 		,:check_link
 		,:check_payload
+		,:check_payload_compressed
+		,:check_payload_encryption_key_id
 		,:check_metadata
+		,:check_metadata_encryption_key_id
+		,:check_resource_usage
 		,:check_payload_kind
 		,:check_payload_version
+		,:check_payload_steps
+		,:check_labels
 		,:check_started
 		,:check_ended
+		,:check_target_repo_id
+		,:check_failure_category
+		,:check_sla_breached
+		,:check_content_hash
+		,:check_visibility
 	)
-	ON CONFLICT (check_repo_id, check_commit_sha, check_uid) DO
+	ON CONFLICT (check_repo_id, check_commit_sha, check_namespace, check_uid) DO
 	UPDATE SET
 		 check_updated = :check_updated
 		,check_status = :check_status
 		,check_summary = :check_summary
 		,check_link = :check_link
 		,check_payload = :check_payload
+		,check_payload_compressed = :check_payload_compressed
+		,check_payload_encryption_key_id = :check_payload_encryption_key_id
 		,check_metadata = :check_metadata
+		,check_metadata_encryption_key_id = :check_metadata_encryption_key_id
+		,check_resource_usage = :check_resource_usage
 		,check_payload_kind = :check_payload_kind
 		,check_payload_version = :check_payload_version
+		,check_payload_steps = :check_payload_steps
+		,check_labels = :check_labels
 	    	,check_started = :check_started
 	    	,check_ended = :check_ended
+		,check_target_repo_id = :check_target_repo_id
+		,check_failure_category = :check_failure_category
+		,check_sla_breached = :check_sla_breached
+		,check_content_hash = :check_content_hash
+		,check_visibility = :check_visibility`
+
+const checkUpsertReturning = `
 	RETURNING check_id, check_created_by, check_created`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+// Upsert creates new or updates an existing status check result.
+// An existing status check result with the same content (status, summary and payload) is left untouched,
+// the provided check is then replaced with the existing status check result and marked as deduplicated.
+func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
+	const sqlQuery = checkUpsertBase + `
+	WHERE checks.check_content_hash <> :check_content_hash` + checkUpsertReturning
+
+	db := s.getAccessor(ctx)
 
-	query, arg, err := db.BindNamed(sqlQuery, mapInternalCheck(check))
+	dbCheck, err := s.mapInternalCheck(check)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbCheck)
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check object")
 	}
 
-	if err = db.QueryRowContext(ctx, query, arg...).Scan(&check.ID, &check.CreatedBy, &check.Created); err != nil {
+	err = db.QueryRowContext(ctx, query, arg...).Scan(&check.ID, &check.CreatedBy, &check.Created)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.deduplicate(ctx, dbCheck.Namespace, check)
+	}
+	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
 	}
 
-	return nil
+	return s.updateUIDStats(ctx, check.RepoID, dbCheck.Namespace, check.Identifier, check.Status)
 }
 
-// Count counts status check results for a specific commit in a repo.
-func (s *CheckStore) Count(ctx context.Context,
-	repoID int64,
-	commitSHA string,
-	opts types.CheckListOptions,
-) (int, error) {
-	stmt := database.Builder.
-		Select("count(*)").
-		From("checks").
-		Where("check_repo_id = ?", repoID).
-		Where("check_commit_sha = ?", commitSHA)
+// checkFlakeScoreAlpha is the weight of the latest run in the exponentially weighted moving average
+// of the status changes of a status check.
+const checkFlakeScoreAlpha = 0.2
 
-	stmt = s.applyOpts(stmt, opts.Query)
+// updateUIDStats updates the flake score of the status check identifier in the namespace of the repo
+// with a completed run.
+// The score is an exponentially weighted moving average of how often the status of a completed run
+// differs from the status of the previous completed run. Runs that haven't completed yet are ignored.
+func (s *CheckStore) updateUIDStats(
+	ctx context.Context,
+	repoID int64,
+	namespace string,
+	identifier string,
+	status enum.CheckStatus,
+) error {
+	if !status.IsCompleted() {
+		return nil
+	}
 
-	sql, args, err := stmt.ToSql()
+	const sqlQuery = `
+	INSERT INTO check_uid_stats (
+		 check_uid_stats_repo_id
+		,check_uid_stats_namespace
+		,check_uid_stats_uid
+		,check_uid_stats_last_status
+		,check_uid_stats_flake_score
+		,check_uid_stats_updated
+	) VALUES (?, ?, ?, ?, 0, ?)
+	ON CONFLICT (check_uid_stats_repo_id, check_uid_stats_namespace, check_uid_stats_uid) DO UPDATE SET
+		 check_uid_stats_flake_score = ? * (CASE
+			WHEN check_uid_stats.check_uid_stats_last_status <> EXCLUDED.check_uid_stats_last_status THEN 1
+			ELSE 0 END) + (1 - ?) * check_uid_stats.check_uid_stats_flake_score
+		,check_uid_stats_last_status = EXCLUDED.check_uid_stats_last_status
+		,check_uid_stats_updated = EXCLUDED.check_uid_stats_updated`
+
+	db := s.getAccessor(ctx)
+
+	_, err := db.ExecContext(ctx, s.db.Rebind(sqlQuery),
+		repoID, namespace, identifier, status, time.Now().UnixMilli(), checkFlakeScoreAlpha, checkFlakeScoreAlpha)
 	if err != nil {
-		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update status check identifier stats")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	return nil
+}
 
-	var count int
-	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+// deduplicate replaces the check with the stored status check result that has the same content.
+func (s *CheckStore) deduplicate(ctx context.Context, namespace string, check *types.Check) error {
+	existing, err := s.FindInNamespace(ctx, check.RepoID, check.CommitSHA, namespace, check.Identifier)
 	if err != nil {
-		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to execute count status checks query")
+		return fmt.Errorf("failed to find deduplicated status check: %w", err)
 	}
 
-	return count, nil
+	reportedBy, err := s.pCache.Get(ctx, existing.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get principal of deduplicated status check: %w", err)
+	}
+
+	*check = existing
+	check.ReportedBy = reportedBy
+	check.Deduplicated = true
+
+	return nil
 }
 
-// List returns a list of status check results for a specific commit in a repo.
-func (s *CheckStore) List(ctx context.Context,
-	repoID int64,
-	commitSHA string,
-	opts types.CheckListOptions,
-) ([]types.Check, error) {
-	stmt := database.Builder.
-		Select(checkColumns).
-		From("checks").
-		Where("check_repo_id = ?", repoID).
-		Where("check_commit_sha = ?", commitSHA)
+// checkCAS holds the status check and its expected status for the compare-and-swap upsert.
+type checkCAS struct {
+	*check
+	ExpectedStatus enum.CheckStatus `db:"expected_status"`
+}
 
-	stmt = s.applyOpts(stmt, opts.Query)
+// UpsertWithCAS creates a new status check result or updates an existing one only if its
+// status is the expected status. It returns false without an error if the existing status check
+// has a different status, which allows a status transition to be done exactly once.
+func (s *CheckStore) UpsertWithCAS(
+	ctx context.Context,
+	check *types.Check,
+	expectedStatus enum.CheckStatus,
+) (bool, error) {
+	const sqlQuery = checkUpsertBase + `
+	WHERE checks.check_status = :expected_status` + checkUpsertReturning
 
-	stmt = stmt.
-		Limit(database.Limit(opts.Size)).
-		Offset(database.Offset(opts.Page, opts.Size)).
-		OrderBy("check_updated desc")
+	db := s.getAccessor(ctx)
 
-	sql, args, err := stmt.ToSql()
+	dbCheck, err := s.mapInternalCheck(check)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+		return false, err
 	}
 
-	dst := make([]*check, 0)
-
-	db := dbtx.GetAccessor(ctx, s.db)
+	arg := &checkCAS{
+		check:          dbCheck,
+		ExpectedStatus: expectedStatus,
+	}
 
-	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
-		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks query")
+	query, args, err := db.BindNamed(sqlQuery, arg)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to bind status check object")
 	}
 
-	result, err := s.mapSliceCheck(ctx, dst)
+	err = db.QueryRowContext(ctx, query, args...).Scan(&check.ID, &check.CreatedBy, &check.Created)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
 	if err != nil {
-		return nil, err
+		return false, database.ProcessSQLErrorf(ctx, err, "Upsert with compare-and-swap query failed")
 	}
 
-	return result, nil
+	if err = s.updateUIDStats(ctx, check.RepoID, dbCheck.Namespace, check.Identifier, check.Status); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
-// ListRecent returns a list of recently executed status checks in a repository.
-func (s *CheckStore) ListRecent(ctx context.Context,
+// Patch updates only the status check fields that are set in the patch.
+func (s *CheckStore) Patch(
+	ctx context.Context,
 	repoID int64,
-	opts types.CheckRecentOptions,
-) ([]string, error) {
+	commitSHA string,
+	identifier string,
+	patch types.CheckPatch,
+) error {
 	stmt := database.Builder.
-		Select("distinct check_uid").
-		From("checks").
+		Update("checks").
+		Set("check_updated", time.Now().UnixMilli()).
+		// the patched status check might not match its content hash anymore.
+		Set("check_content_hash", "").
 		Where("check_repo_id = ?", repoID).
-		Where("check_created > ?", opts.Since)
+		Where("check_commit_sha = ?", commitSHA).
+		Where("check_uid = ?", identifier).
+		Where("check_namespace = ?", types.CheckNamespaceDefault)
 
-	stmt = s.applyOpts(stmt, opts.Query)
+	if patch.Status != nil {
+		stmt = stmt.Set("check_status", *patch.Status)
+	}
+	if patch.Summary != nil {
+		stmt = stmt.Set("check_summary", *patch.Summary)
+	}
+	if patch.Link != nil {
+		stmt = stmt.Set("check_link", *patch.Link)
+	}
+	if patch.Metadata != nil {
+		metadata, metadataKeyID, err := s.encryptCheckData(*patch.Metadata)
+		if err != nil {
+			return err
+		}
 
-	stmt = stmt.OrderBy("check_uid")
+		stmt = stmt.Set("check_metadata", metadata).
+			Set("check_metadata_encryption_key_id", metadataKeyID)
+	}
+	if patch.Started != nil {
+		stmt = stmt.Set("check_started", *patch.Started)
+	}
+	if patch.Ended != nil {
+		stmt = stmt.Set("check_ended", *patch.Ended)
+	}
+	if patch.FailureCategory != nil {
+		stmt = stmt.Set("check_failure_category", *patch.FailureCategory)
+	}
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert list recent status checks query to sql: %w", err)
+		return fmt.Errorf("failed to convert patch status check query to sql: %w", err)
 	}
 
-	dst := make([]string, 0)
+	db := s.getAccessor(ctx)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to patch status check")
+	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
 
-	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
-		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list recent status checks query")
+	if count == 0 {
+		return fmt.Errorf("status check %q not patched: %w", identifier, gitness_store.ErrResourceNotFound)
 	}
 
-	return dst, nil
+	if patch.Status == nil {
+		return nil
+	}
+
+	return s.updateUIDStats(ctx, repoID, types.CheckNamespaceDefault, identifier, *patch.Status)
 }
 
-// ListResults returns a list of status check results for a specific commit in a repo.
-func (s *CheckStore) ListResults(ctx context.Context,
-	repoID int64,
-	commitSHA string,
-) ([]types.CheckResult, error) {
-	const checkColumns = "check_uid, check_status"
-	stmt := database.Builder.
-		Select(checkColumns).
-		From("checks").
-		Where("check_repo_id = ?", repoID).
-		Where("check_commit_sha = ?", commitSHA)
+// UpsertBatch creates new or updates existing status check results in a single query.
+// Conflicts with existing status check results are resolved using the provided strategy.
+// Only the status checks that got written have their ID, CreatedBy and Created fields updated.
+func (s *CheckStore) UpsertBatch(
+	ctx context.Context,
+	checks []*types.Check,
+	strategy enum.ConflictStrategy,
+) error {
+	type checkKey struct {
+		repoID     int64
+		commitSHA  string
+		namespace  string
+		identifier string
+	}
 
-	sql, args, err := stmt.ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	// postgres refuses to update the same row twice in a single statement, so the last one wins.
+	checkMap := make(map[checkKey]*types.Check, len(checks))
+	keys := make([]checkKey, 0, len(checks))
+	for _, c := range checks {
+		namespace := checkNamespace(c.Namespace)
+		key := checkKey{repoID: c.RepoID, commitSHA: c.CommitSHA, namespace: namespace, identifier: c.Identifier}
+		if _, ok := checkMap[key]; !ok {
+			keys = append(keys, key)
+		}
+		checkMap[key] = c
 	}
 
-	result := make([]types.CheckResult, 0)
+	if len(keys) == 0 {
+		return nil
+	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	stmt := database.Builder.
+		Insert("checks").
+		Columns(
+			"check_created_by",
+			"check_created",
+			"check_updated",
+			"check_repo_id",
+			"check_commit_sha",
+			"check_uid",
+			"check_namespace",
+			"check_status",
+			"check_summary",
+			"check_link",
+			"check_payload",
+			"check_payload_compressed",
+			"check_payload_encryption_key_id",
+			"check_metadata",
+			"check_metadata_encryption_key_id",
+			"check_resource_usage",
+			"check_payload_kind",
+			"check_payload_version",
+			"check_payload_steps",
+			"check_labels",
+			"check_started",
+			"check_ended",
+			"check_target_repo_id",
+			"check_failure_category",
+			"check_sla_breached",
+			"check_content_hash",
+			"check_visibility",
+		)
+
+	for _, key := range keys {
+		c, err := s.mapInternalCheck(checkMap[key])
+		if err != nil {
+			return err
+		}
 
-	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
-		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks results query")
+		stmt = stmt.Values(
+			c.CreatedBy,
+			c.Created,
+			c.Updated,
+			c.RepoID,
+			c.CommitSHA,
+			c.Identifier,
+			c.Namespace,
+			c.Status,
+			c.Summary,
+			c.Link,
+			c.Payload,
+			c.Compressed,
+			c.PayloadKeyID,
+			c.Metadata,
+			c.MetadataKeyID,
+			c.ResourceUsage,
+			c.PayloadKind,
+			c.PayloadVersion,
+			c.PayloadSteps,
+			c.Labels,
+			c.Started,
+			c.Ended,
+			c.TargetRepoID,
+			c.FailureCategory,
+			c.SLABreached,
+			c.ContentHash,
+			c.Visibility,
+		)
 	}
 
-	return result, nil
-}
-
-// ResultSummary returns a list of status check result summaries for the provided list of commits in a repo.
-func (s *CheckStore) ResultSummary(ctx context.Context,
-	repoID int64,
-	commitSHAs []string,
-) (map[sha.SHA]types.CheckCountSummary, error) {
-	const selectColumns = `
-			check_commit_sha,
-			COUNT(*) FILTER (WHERE check_status = 'pending') as "count_pending",
-			COUNT(*) FILTER (WHERE check_status = 'running') as "count_running",
-			COUNT(*) FILTER (WHERE check_status = 'success') as "count_success",
-			COUNT(*) FILTER (WHERE check_status = 'failure') as "count_failure",
-			COUNT(*) FILTER (WHERE check_status = 'error') as "count_error"`
+	const updateSet = `
+	UPDATE SET
+		 check_updated = EXCLUDED.check_updated
+		,check_status = EXCLUDED.check_status
+		,check_summary = EXCLUDED.check_summary
+		,check_link = EXCLUDED.check_link
+		,check_payload = EXCLUDED.check_payload
+		,check_payload_compressed = EXCLUDED.check_payload_compressed
+		,check_payload_encryption_key_id = EXCLUDED.check_payload_encryption_key_id
+		,check_metadata = EXCLUDED.check_metadata
+		,check_metadata_encryption_key_id = EXCLUDED.check_metadata_encryption_key_id
+		,check_resource_usage = EXCLUDED.check_resource_usage
+		,check_payload_kind = EXCLUDED.check_payload_kind
+		,check_payload_version = EXCLUDED.check_payload_version
+		,check_payload_steps = EXCLUDED.check_payload_steps
+		,check_labels = EXCLUDED.check_labels
+		,check_started = EXCLUDED.check_started
+		,check_ended = EXCLUDED.check_ended
+		,check_target_repo_id = EXCLUDED.check_target_repo_id
+		,check_failure_category = EXCLUDED.check_failure_category
+		,check_sla_breached = EXCLUDED.check_sla_breached
+		,check_content_hash = EXCLUDED.check_content_hash
+		,check_visibility = EXCLUDED.check_visibility`
+
+	stmt = stmt.Suffix(`ON CONFLICT (check_repo_id, check_commit_sha, check_namespace, check_uid) DO`)
+
+	switch strategy {
+	case enum.ConflictStrategyOverwrite:
+		stmt = stmt.Suffix(updateSet)
+	case enum.ConflictStrategyIgnore:
+		stmt = stmt.Suffix(`NOTHING`)
+	case enum.ConflictStrategyTerminalWins:
+		stmt = stmt.Suffix(updateSet+`
+	WHERE checks.check_status NOT IN (?,?,?,?) OR EXCLUDED.check_status IN (?,?,?,?)`,
+			enum.CheckStatusSuccess, enum.CheckStatusFailure, enum.CheckStatusError, enum.CheckStatusSkipped,
+			enum.CheckStatusSuccess, enum.CheckStatusFailure, enum.CheckStatusError, enum.CheckStatusSkipped)
+	default:
+		return fmt.Errorf("status check conflict strategy %q is not supported", strategy)
+	}
 
-	stmt := database.Builder.
-		Select(selectColumns).
-		From("checks").
-		Where("check_repo_id = ?", repoID).
-		Where(squirrel.Eq{"check_commit_sha": commitSHAs}).
-		GroupBy("check_commit_sha")
+	stmt = stmt.Suffix(`RETURNING check_id, check_created_by, check_created, check_repo_id, check_commit_sha, check_namespace, check_uid`)
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+		return fmt.Errorf("failed to convert query to sql: %w", err)
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := s.getAccessor(ctx)
 
-	rows, err := db.QueryxContext(ctx, sql, args...)
+	rows, err := db.QueryContext(ctx, sql, args...)
 	if err != nil {
-		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute status check summary query")
+		return database.ProcessSQLErrorf(ctx, err, "Batch upsert query failed")
 	}
 
 	defer func() {
 		_ = rows.Close()
 	}()
 
-	result := make(map[sha.SHA]types.CheckCountSummary)
-
+	written := make([]*types.Check, 0, len(checkMap))
 	for rows.Next() {
-		var commitSHAStr string
-		var countPending int
-		var countRunning int
-		var countSuccess int
-		var countFailure int
-		var countError int
-		err := rows.Scan(&commitSHAStr, &countPending, &countRunning, &countSuccess, &countFailure, &countError)
-		if err != nil {
-			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan values of status check summary query")
-		}
-
-		commitSHA, err := sha.New(commitSHAStr)
+		var id, createdBy, created int64
+		var key checkKey
+		err = rows.Scan(&id, &createdBy, &created, &key.repoID, &key.commitSHA, &key.namespace, &key.identifier)
 		if err != nil {
-			return nil, fmt.Errorf("invalid commit SHA read from DB: %s", commitSHAStr)
+			return database.ProcessSQLErrorf(ctx, err, "Failed to scan batch upsert result")
 		}
 
-		result[commitSHA] = types.CheckCountSummary{
-			Pending: countPending,
-			Running: countRunning,
-			Success: countSuccess,
-			Failure: countFailure,
-			Error:   countError,
+		if c, ok := checkMap[key]; ok {
+			c.ID = id
+			c.CreatedBy = createdBy
+			c.Created = created
+			written = append(written, c)
 		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status chek summary")
+		return database.ProcessSQLErrorf(ctx, err, "Failed to read batch upsert result")
 	}
 
-	return result, nil
-}
+	_ = rows.Close()
 
-func (*CheckStore) applyOpts(stmt squirrel.SelectBuilder, query string) squirrel.SelectBuilder {
-	if query != "" {
-		stmt = stmt.Where("LOWER(check_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(query)))
+	// only the status checks that were inserted or updated are returned, ignored conflicts don't count as runs.
+	for _, c := range written {
+		if err := s.updateUIDStats(ctx, c.RepoID, checkNamespace(c.Namespace), c.Identifier, c.Status); err != nil {
+			return err
+		}
 	}
 
-	return stmt
+	return nil
 }
 
-func mapInternalCheck(c *types.Check) *check {
-	m := &check{
-		ID:             c.ID,
-		CreatedBy:      c.CreatedBy,
-		Created:        c.Created,
-		Updated:        c.Updated,
-		RepoID:         c.RepoID,
-		CommitSHA:      c.CommitSHA,
-		Identifier:     c.Identifier,
-		Status:         c.Status,
-		Summary:        c.Summary,
-		Link:           c.Link,
-		Payload:        c.Payload.Data,
-		Metadata:       c.Metadata,
-		PayloadKind:    c.Payload.Kind,
-		PayloadVersion: c.Payload.Version,
-		Started:        c.Started,
-		Ended:          c.Ended,
+// CompressPayloads compresses up to batchSize stored payloads that exceed the compression threshold,
+// but were stored uncompressed. It returns the number of compressed payloads.
+func (s *CheckStore) CompressPayloads(ctx context.Context, batchSize int) (int, error) {
+	if s.payloadCompressionThreshold <= 0 {
+		return 0, nil
 	}
 
-	return m
-}
+	sizeExpr := "octet_length(check_payload::text)"
+	if s.db.DriverName() == SqliteDriverName {
+		sizeExpr = "length(check_payload)"
+	}
 
-func mapCheck(c *check) types.Check {
-	return types.Check{
-		ID:         c.ID,
-		CreatedBy:  c.CreatedBy,
-		Created:    c.Created,
-		Updated:    c.Updated,
-		RepoID:     c.RepoID,
+	stmt := database.Builder.
+		Select("check_id, check_payload, check_payload_encryption_key_id").
+		From("checks").
+		Where("check_payload_compressed = ?", false).
+		Where(sizeExpr+" > ?", s.payloadCompressionThreshold).
+		Limit(uint64(batchSize)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	dst := make([]*check, 0, batchSize)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to select uncompressed status check payloads")
+	}
+
+	const sqlUpdate = `
+	UPDATE checks
+	SET
+		 check_payload = $1
+		,check_payload_encryption_key_id = $2
+		,check_payload_compressed = TRUE
+	WHERE check_id = $3 AND check_payload_compressed = FALSE`
+
+	for _, c := range dst {
+		payload, err := s.decryptCheckData(c.Payload, c.PayloadKeyID)
+		if err != nil {
+			return 0, err
+		}
+
+		if payload, err = compressCheckPayload(payload); err != nil {
+			return 0, err
+		}
+
+		payload, payloadKeyID, err := s.encryptCheckData(payload)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err = db.ExecContext(ctx, sqlUpdate, payload, payloadKeyID, c.ID); err != nil {
+			return 0, database.ProcessSQLErrorf(ctx, err, "Failed to store compressed status check payload")
+		}
+	}
+
+	return len(dst), nil
+}
+
+// CountOrphans counts the status check results whose repository doesn't exist anymore.
+func (s *CheckStore) CountOrphans(ctx context.Context) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM checks
+	LEFT JOIN repositories ON repo_id = check_repo_id
+	WHERE repo_id IS NULL`
+
+	db := s.getAccessor(ctx)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count orphan status check results")
+	}
+
+	return count, nil
+}
+
+// ListOrphanRepoIDs returns the IDs of the repositories that don't exist anymore
+// but still have status check results.
+func (s *CheckStore) ListOrphanRepoIDs(ctx context.Context) ([]int64, error) {
+	const sqlQuery = `
+	SELECT DISTINCT check_repo_id
+	FROM checks
+	LEFT JOIN repositories ON repo_id = check_repo_id
+	WHERE repo_id IS NULL
+	ORDER BY check_repo_id`
+
+	db := s.getAccessor(ctx)
+
+	dst := make([]int64, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repos of orphan status check results")
+	}
+
+	return dst, nil
+}
+
+// DeleteOrphans deletes up to batchSize status check results whose repository doesn't exist anymore.
+// It returns the number of deleted status check results.
+func (s *CheckStore) DeleteOrphans(ctx context.Context, batchSize int) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM checks
+	WHERE check_id IN (
+		SELECT check_id
+		FROM checks
+		LEFT JOIN repositories ON repo_id = check_repo_id
+		WHERE repo_id IS NULL
+		LIMIT $1
+	)`
+
+	db := s.getAccessor(ctx)
+
+	result, err := db.ExecContext(ctx, sqlQuery, batchSize)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete orphan status check results")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted orphan status check results")
+	}
+
+	return n, nil
+}
+
+// Count counts status check results for a specific commit in a repo.
+// If the commit SHA is empty, status check results of all commits in the repo are counted.
+func (s *CheckStore) Count(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	opts types.CheckListOptions,
+) (int, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("checks").
+		Where("check_repo_id = ?", repoID)
+
+	stmt = s.applyListOpts(stmt, commitSHA, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	var count int
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to execute count status checks query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of status check results for a specific commit in a repo.
+// If the commit SHA is empty, status check results of all commits in the repo are listed.
+func (s *CheckStore) List(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	opts types.CheckListOptions,
+) ([]types.Check, error) {
+	stmt := database.Builder.
+		Select(checkColumns).
+		From("checks").
+		Where("check_repo_id = ?", repoID)
+
+	stmt = s.applyListOpts(stmt, commitSHA, opts)
+
+	stmt = stmt.
+		Limit(database.Limit(opts.Size)).
+		Offset(database.Offset(opts.Page, opts.Size))
+
+	stmt = applyCheckListSort(stmt, opts)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]*check, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks query")
+	}
+
+	result, err := s.mapSliceCheck(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListBySHAs returns the status check results of the provided commits in a repo, mapped by commit SHA.
+// The results of all commits are loaded with a single query. Commits without status checks are omitted.
+func (s *CheckStore) ListBySHAs(ctx context.Context,
+	repoID int64,
+	shas []string,
+) (map[string][]*types.Check, error) {
+	if len(shas) == 0 {
+		return map[string][]*types.Check{}, nil
+	}
+
+	if len(shas) > s.listBySHAsLimit {
+		return nil, fmt.Errorf("too many commits: %d, at most %d commits are allowed", len(shas), s.listBySHAsLimit)
+	}
+
+	const sqlQuery = checkSelectBase + `
+	WHERE check_repo_id = ? AND check_commit_sha IN (?)
+	ORDER BY check_commit_sha, check_id`
+
+	sql, args, err := sqlx.In(sqlQuery, repoID, shas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand list status checks by commits query: %w", err)
+	}
+
+	sql = s.db.Rebind(sql)
+
+	dst := make([]*check, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks by commits query")
+	}
+
+	checks, err := s.mapSliceCheck(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]*types.Check)
+	for i := range checks {
+		result[checks[i].CommitSHA] = append(result[checks[i].CommitSHA], &checks[i])
+	}
+
+	return result, nil
+}
+
+// checkSearchTextConfig is the PostgreSQL text search configuration used to search the status check summaries.
+// It must match the configuration of the full-text index of the status check summaries.
+const checkSearchTextConfig = "english"
+
+// Search returns the status checks matching the search query, newest first.
+// The status checks after the cursor are returned if it's provided.
+// PostgreSQL matches the text using full-text search on the summaries, SQLite requires each word of the text
+// to be contained in the summary.
+func (s *CheckStore) Search(ctx context.Context,
+	query types.CheckSearchQuery,
+	after *types.CheckSearchCursor,
+) ([]types.Check, error) {
+	stmt := database.Builder.
+		Select(checkColumns).
+		From("checks")
+
+	if query.Text != "" {
+		switch s.db.DriverName() {
+		case SqliteDriverName:
+			for _, word := range strings.Fields(strings.ToLower(query.Text)) {
+				stmt = stmt.Where("LOWER(check_summary) LIKE ?", "%"+word+"%")
+			}
+		default:
+			stmt = stmt.Where("to_tsvector('"+checkSearchTextConfig+"', check_summary) @@ "+
+				"plainto_tsquery('"+checkSearchTextConfig+"', ?)", query.Text)
+		}
+	}
+
+	if query.RepoID > 0 {
+		stmt = stmt.Where("check_repo_id = ?", query.RepoID)
+	}
+
+	if query.Visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": query.Visibilities})
+	}
+
+	if len(query.Statuses) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"check_status": query.Statuses})
+	}
+
+	if !query.From.IsZero() {
+		stmt = stmt.Where("check_updated >= ?", query.From.UnixMilli())
+	}
+
+	if !query.To.IsZero() {
+		stmt = stmt.Where("check_updated < ?", query.To.UnixMilli())
+	}
+
+	if after != nil {
+		stmt = stmt.Where("(check_updated < ? OR (check_updated = ? AND check_id < ?))",
+			after.Updated, after.Updated, after.ID)
+	}
+
+	stmt = stmt.
+		OrderBy("check_updated DESC", "check_id DESC").
+		Limit(database.Limit(query.Limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]*check, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute search status checks query")
+	}
+
+	return s.mapSliceCheck(ctx, dst)
+}
+
+// checkWithAnnotation is a row of the status checks joined with their annotations.
+// The annotation columns are null for status checks without annotations.
+type checkWithAnnotation struct {
+	check
+	AnnotationID        null.Int    `db:"check_annotation_id"`
+	AnnotationPath      null.String `db:"check_annotation_path"`
+	AnnotationLineStart null.Int    `db:"check_annotation_line_start"`
+	AnnotationLineEnd   null.Int    `db:"check_annotation_line_end"`
+	AnnotationLevel     null.String `db:"check_annotation_level"`
+	AnnotationTitle     null.String `db:"check_annotation_title"`
+	AnnotationMessage   null.String `db:"check_annotation_message"`
+}
+
+// ListWithAnnotations returns all status check results for a specific commit in a repo with their annotations.
+// The annotations are loaded with the status checks in a single query and grouped by status check.
+func (s *CheckStore) ListWithAnnotations(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]*types.CheckWithAnnotations, error) {
+	const sqlQuery = `
+	SELECT` + checkColumns + `
+		,check_annotation_id
+		,check_annotation_path
+		,check_annotation_line_start
+		,check_annotation_line_end
+		,check_annotation_level
+		,check_annotation_title
+		,check_annotation_message
+	FROM checks
+	LEFT JOIN check_annotations ON check_annotation_check_id = check_id
+	WHERE check_repo_id = $1 AND check_commit_sha = $2
+	ORDER BY check_updated DESC, check_id, check_annotation_path, check_annotation_line_start, check_annotation_id`
+
+	db := s.getAccessor(ctx)
+
+	rows := make([]*checkWithAnnotation, 0)
+	if err := db.SelectContext(ctx, &rows, sqlQuery, repoID, commitSHA); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status checks with annotations")
+	}
+
+	// the rows of a status check are adjacent, so the checks are collected in the order of the query.
+	dst := make([]*check, 0)
+	annotations := make(map[int64][]types.CheckAnnotation)
+	for _, row := range rows {
+		if len(dst) == 0 || dst[len(dst)-1].ID != row.ID {
+			c := row.check
+			dst = append(dst, &c)
+			annotations[row.ID] = []types.CheckAnnotation{}
+		}
+
+		if !row.AnnotationID.Valid {
+			continue
+		}
+
+		annotations[row.ID] = append(annotations[row.ID], types.CheckAnnotation{
+			ID:              row.AnnotationID.Int64,
+			CheckID:         row.ID,
+			CheckIdentifier: row.Identifier,
+			Path:            row.AnnotationPath.String,
+			LineStart:       int(row.AnnotationLineStart.Int64),
+			LineEnd:         int(row.AnnotationLineEnd.Int64),
+			Level:           enum.CheckAnnotationLevel(row.AnnotationLevel.String),
+			Title:           row.AnnotationTitle.String,
+			Message:         row.AnnotationMessage.String,
+		})
+	}
+
+	checks, err := s.mapSliceCheck(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.CheckWithAnnotations, len(checks))
+	for i := range checks {
+		result[i] = &types.CheckWithAnnotations{
+			Check:       checks[i],
+			Annotations: annotations[checks[i].ID],
+		}
+	}
+
+	return result, nil
+}
+
+// ListRetryCandidates returns a list of completed status checks in a repo that qualify for an automatic retry.
+func (s *CheckStore) ListRetryCandidates(ctx context.Context,
+	repoID int64,
+	opts types.CheckRetryCandidateOptions,
+) ([]types.Check, error) {
+	if len(opts.Statuses) == 0 {
+		return []types.Check{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(checkColumns).
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_uid = ?", opts.Identifier).
+		Where(squirrel.Eq{"check_status": opts.Statuses}).
+		Where("check_retry_count < ?", opts.MaxRetryCount).
+		Where("check_updated > ?", opts.UpdatedAfter).
+		Where("check_updated <= ?", opts.UpdatedBefore)
+
+	if opts.StartedBefore > 0 {
+		stmt = stmt.Where("CASE WHEN check_started > 0 THEN check_started ELSE check_created END <= ?",
+			opts.StartedBefore)
+	}
+
+	if opts.PayloadKind != "" {
+		stmt = stmt.Where("check_payload_kind = ?", opts.PayloadKind)
+	}
+
+	stmt = stmt.OrderBy("check_updated asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]*check, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list retry candidates query")
+	}
+
+	result := make([]types.Check, len(dst))
+	for i, c := range dst {
+		if result[i], err = s.mapCheck(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ListUpdatedBefore returns the least recently updated status checks not updated since the provided time.
+func (s *CheckStore) ListUpdatedBefore(ctx context.Context, before int64, limit int) ([]types.Check, error) {
+	stmt := database.Builder.
+		Select(checkColumns).
+		From("checks").
+		Where("check_updated < ?", before).
+		OrderBy("check_updated asc", "check_id asc").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]*check, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks updated before query")
+	}
+
+	result := make([]types.Check, len(dst))
+	for i, c := range dst {
+		if result[i], err = s.mapCheck(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteByIDs deletes the status checks with the provided IDs. It returns the number of deleted status checks.
+func (s *CheckStore) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	stmt := database.Builder.
+		Delete("checks").
+		Where(squirrel.Eq{"check_id": ids})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete status checks")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted status checks")
+	}
+
+	return n, nil
+}
+
+// DeleteByRepo deletes all status checks of a repo. It returns the number of deleted status checks.
+func (s *CheckStore) DeleteByRepo(ctx context.Context, repoID int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM checks
+	WHERE check_repo_id = $1`
+
+	db := s.getAccessor(ctx)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete status checks of repo")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted status checks")
+	}
+
+	return n, nil
+}
+
+// IncrementRetryCount increments the number of automatic retries of a status check.
+func (s *CheckStore) IncrementRetryCount(ctx context.Context, checkID int64) error {
+	const sqlQuery = `
+	UPDATE checks
+	SET check_retry_count = check_retry_count + 1
+	WHERE check_id = $1`
+
+	db := s.getAccessor(ctx)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, checkID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to increment status check retry count")
+	}
+
+	return nil
+}
+
+// MoveBySHA moves all status checks of a commit to another commit, e.g. after the commit got amended.
+// The annotations of the status checks are moved with them, and the status check summaries
+// of both commits are recalculated. It should be called within a transaction, so that either all
+// or none of the status checks are moved.
+// It fails with store.ErrDuplicate if the other commit already has a status check with the same identifier.
+func (s *CheckStore) MoveBySHA(ctx context.Context, repoID int64, oldSHA, newSHA string) (int, error) {
+	const sqlQuery = `
+	UPDATE checks
+	SET check_commit_sha = $1
+	WHERE check_repo_id = $2 AND check_commit_sha = $3`
+
+	const sqlQueryAnnotations = `
+	UPDATE check_annotations
+	SET check_annotation_commit_sha = $1
+	WHERE check_annotation_repo_id = $2 AND check_annotation_commit_sha = $3`
+
+	db := s.getAccessor(ctx)
+
+	result, err := db.ExecContext(ctx, sqlQuery, newSHA, repoID, oldSHA)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to move status checks to another commit")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of moved status checks")
+	}
+
+	if n == 0 {
+		return 0, nil
+	}
+
+	if _, err = db.ExecContext(ctx, sqlQueryAnnotations, newSHA, repoID, oldSHA); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to move status check annotations to another commit")
+	}
+
+	// the summary triggers don't fire when only the commit of a status check changes.
+	for _, commitSHA := range []string{oldSHA, newSHA} {
+		if err = s.recalculateSummary(ctx, repoID, commitSHA); err != nil {
+			return 0, err
+		}
+	}
+
+	return int(n), nil
+}
+
+// recalculateSummary replaces the status check summary of a commit with one calculated from its status checks.
+// The summary is removed if the commit has no status checks.
+func (s *CheckStore) recalculateSummary(ctx context.Context, repoID int64, commitSHA string) error {
+	const sqlQueryDelete = `
+	DELETE FROM check_summaries
+	WHERE check_summary_repo_id = $1 AND check_summary_commit_sha = $2`
+
+	const sqlQueryInsert = `
+	INSERT INTO check_summaries (
+	 check_summary_repo_id
+	,check_summary_commit_sha
+	,check_summary_pending
+	,check_summary_running
+	,check_summary_success
+	,check_summary_failure
+	,check_summary_error
+	,check_summary_skipped
+	)
+	SELECT
+	 check_repo_id
+	,check_commit_sha
+	,COUNT(*) FILTER (WHERE check_status = 'pending')
+	,COUNT(*) FILTER (WHERE check_status = 'running')
+	,COUNT(*) FILTER (WHERE check_status = 'success')
+	,COUNT(*) FILTER (WHERE check_status = 'failure')
+	,COUNT(*) FILTER (WHERE check_status = 'error')
+	,COUNT(*) FILTER (WHERE check_status = 'skipped')
+	FROM checks
+	WHERE check_repo_id = $1 AND check_commit_sha = $2
+	GROUP BY check_repo_id, check_commit_sha`
+
+	db := s.getAccessor(ctx)
+
+	if _, err := db.ExecContext(ctx, sqlQueryDelete, repoID, commitSHA); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete status check summary")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQueryInsert, repoID, commitSHA); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to recalculate status check summary")
+	}
+
+	return nil
+}
+
+// ListRecent returns a list of recently executed status checks in a repository.
+func (s *CheckStore) ListRecent(ctx context.Context,
+	repoID int64,
+	opts types.CheckRecentOptions,
+) ([]string, error) {
+	stmt := database.Builder.
+		Select("distinct check_uid").
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_created > ?", opts.Since)
+
+	stmt = s.applyOpts(stmt, opts.Query)
+
+	if opts.Visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": opts.Visibilities})
+	}
+
+	stmt = stmt.OrderBy("check_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list recent status checks query to sql: %w", err)
+	}
+
+	dst := make([]string, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list recent status checks query")
+	}
+
+	return dst, nil
+}
+
+// ListRecentStats returns the recently executed status checks in a repository with their flake score.
+// Status checks without a completed run have a flake score of zero.
+func (s *CheckStore) ListRecentStats(ctx context.Context,
+	repoID int64,
+	opts types.CheckRecentOptions,
+) ([]types.CheckIdentifierStats, error) {
+	stmt := database.Builder.
+		Select(
+			"check_namespace",
+			"check_uid",
+			"COALESCE(MAX(check_uid_stats_flake_score), 0) AS check_uid_stats_flake_score",
+		).
+		From("checks").
+		LeftJoin("check_uid_stats ON check_uid_stats_repo_id = check_repo_id"+
+			" AND check_uid_stats_namespace = check_namespace AND check_uid_stats_uid = check_uid").
+		Where("check_repo_id = ?", repoID).
+		Where("check_created > ?", opts.Since)
+
+	stmt = s.applyOpts(stmt, opts.Query)
+
+	if opts.Visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": opts.Visibilities})
+	}
+
+	stmt = stmt.
+		GroupBy("check_namespace", "check_uid").
+		OrderBy("check_uid", "check_namespace")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list recent status check stats query to sql: %w", err)
+	}
+
+	dst := make([]types.CheckIdentifierStats, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list recent status check stats query")
+	}
+
+	return dst, nil
+}
+
+// ListResults returns a list of status check results for a specific commit in a repo.
+// Status checks reported in other repos that target the repo are included as well,
+// but a status check reported in the repo itself takes precedence over one with the same identifier from another repo.
+// Only status checks in the default namespace are considered.
+func (s *CheckStore) ListResults(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]types.CheckResult, error) {
+	return s.ListResultsInNamespace(ctx, repoID, commitSHA, types.CheckNamespaceDefault)
+}
+
+// ListResultsInNamespace returns a list of status check results of a namespace for a specific commit in a repo,
+// with the same precedence of the status checks reported in the repo itself as ListResults.
+func (s *CheckStore) ListResultsInNamespace(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+) ([]types.CheckResult, error) {
+	return s.listResults(ctx, repoID, commitSHA, namespace, "")
+}
+
+// listResults returns the status check results of a namespace for a specific commit in a repo,
+// optionally only those with the provided label. Status checks reported in other repos that target the repo
+// are included as well, but a status check reported in the repo itself takes precedence over one
+// with the same identifier from another repo.
+func (s *CheckStore) listResults(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	label string,
+) ([]types.CheckResult, error) {
+	stmt := database.Builder.
+		Select("check_uid", "check_status").
+		Column(checkAcknowledgedColumn, time.Now().UnixMilli()).
+		From("checks").
+		Where("check_commit_sha = ?", commitSHA).
+		Where("(check_repo_id = ? OR check_target_repo_id = ?)", repoID, repoID).
+		Where("check_namespace = ?", namespace)
+
+	if label != "" {
+		switch s.db.DriverName() {
+		case SqliteDriverName:
+			stmt = stmt.Where(`EXISTS (SELECT 1 FROM json_each(check_labels) WHERE json_each.value = ?)`, label)
+		default:
+			stmt = stmt.Where(`EXISTS (SELECT 1 FROM json_array_elements_text(check_labels) AS label
+				WHERE label = ?)`, label)
+		}
+	}
+
+	stmt = stmt.
+		OrderBy("check_uid").
+		OrderByClause("CASE WHEN check_repo_id = ? THEN 0 ELSE 1 END", repoID).
+		OrderBy("check_updated DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]types.CheckResult, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks results query")
+	}
+
+	// the results are ordered by the identifier with the preferred result first.
+	result := make([]types.CheckResult, 0, len(dst))
+	for _, r := range dst {
+		if len(result) > 0 && result[len(result)-1].Identifier == r.Identifier {
+			continue
+		}
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// ListForMergeGate returns the status checks with the required identifiers for a specific commit in a repo,
+// with the same precedence of the status checks reported in the repo itself as ListResults.
+// The required identifiers and the precedence are both applied by a single query,
+// so the merge gate doesn't have to load and filter all status checks of the commit.
+func (s *CheckStore) ListForMergeGate(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	requiredUIDs []string,
+) ([]*types.Check, error) {
+	if len(requiredUIDs) == 0 {
+		return []*types.Check{}, nil
+	}
+
+	var (
+		uidCondition string
+		uidArgs      []any
+	)
+
+	switch s.db.DriverName() {
+	case SqliteDriverName:
+		uidCondition = "check_uid IN (?" + strings.Repeat(",?", len(requiredUIDs)-1) + ")"
+		for _, uid := range requiredUIDs {
+			uidArgs = append(uidArgs, uid)
+		}
+	default:
+		uidCondition = "check_uid = ANY(?)"
+		uidArgs = []any{pq.Array(requiredUIDs)}
+	}
+
+	sqlQuery := `
+	SELECT` + checkColumns + `
+	FROM (
+		SELECT` + checkColumns + `
+			,ROW_NUMBER() OVER (
+				PARTITION BY check_uid
+				ORDER BY CASE WHEN check_repo_id = ? THEN 0 ELSE 1 END, check_updated DESC
+			) AS check_rank
+		FROM checks
+		WHERE check_commit_sha = ?
+			AND (check_repo_id = ? OR check_target_repo_id = ?)
+			AND check_namespace = ?
+			AND ` + uidCondition + `
+	) ranked_checks
+	WHERE check_rank = 1
+	ORDER BY check_uid`
+
+	args := append([]any{repoID, commitSHA, repoID, repoID, types.CheckNamespaceDefault}, uidArgs...)
+
+	dst := make([]*check, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err := db.SelectContext(ctx, &dst, s.db.Rebind(sqlQuery), args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list status checks for merge gate query")
+	}
+
+	checks, err := s.mapSliceCheck(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.Check, len(checks))
+	for i := range checks {
+		result[i] = &checks[i]
+	}
+
+	return result, nil
+}
+
+// Acknowledge marks the failure of the status check run last updated at checkUpdated as known
+// until expiresAt. The acknowledgement doesn't apply to later runs of the status check.
+// An existing acknowledgement of the status check is replaced.
+func (s *CheckStore) Acknowledge(
+	ctx context.Context,
+	checkID int64,
+	checkUpdated int64,
+	actor int64,
+	reason string,
+	expiresAt time.Time,
+) error {
+	const sqlQuery = `
+	INSERT INTO check_acknowledgements (
+		 check_acknowledgement_check_id
+		,check_acknowledgement_check_updated
+		,check_acknowledgement_created_by
+		,check_acknowledgement_created
+		,check_acknowledgement_reason
+		,check_acknowledgement_expires
+	) VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (check_acknowledgement_check_id) DO UPDATE SET
+		 check_acknowledgement_check_updated = EXCLUDED.check_acknowledgement_check_updated
+		,check_acknowledgement_created_by = EXCLUDED.check_acknowledgement_created_by
+		,check_acknowledgement_created = EXCLUDED.check_acknowledgement_created
+		,check_acknowledgement_reason = EXCLUDED.check_acknowledgement_reason
+		,check_acknowledgement_expires = EXCLUDED.check_acknowledgement_expires`
+
+	db := s.getAccessor(ctx)
+
+	_, err := db.ExecContext(ctx, sqlQuery,
+		checkID, checkUpdated, actor, time.Now().UnixMilli(), reason, expiresAt.UnixMilli())
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to acknowledge status check")
+	}
+
+	return nil
+}
+
+// DeleteExpiredAcknowledgements deletes the status check acknowledgements that expired before the provided time.
+func (s *CheckStore) DeleteExpiredAcknowledgements(ctx context.Context, before time.Time) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM check_acknowledgements
+	WHERE check_acknowledgement_expires <= $1`
+
+	db := s.getAccessor(ctx)
+
+	result, err := db.ExecContext(ctx, sqlQuery, before.UnixMilli())
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete expired status check acknowledgements")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted status check acknowledgements")
+	}
+
+	return n, nil
+}
+
+// ListByLabel returns a list of status check results of a namespace with the provided label
+// for a specific commit in a repo, with the same precedence of the status checks reported in the repo itself
+// as ListResults.
+func (s *CheckStore) ListByLabel(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	label string,
+) ([]types.CheckResult, error) {
+	return s.listResults(ctx, repoID, commitSHA, namespace, label)
+}
+
+// ResultSummary returns a list of status check result summaries for the provided list of commits in a repo.
+// Only the status checks with any of the visibilities are counted, nil counts all status checks.
+func (s *CheckStore) ResultSummary(ctx context.Context,
+	repoID int64,
+	commitSHAs []string,
+	visibilities []enum.CheckVisibility,
+) (map[sha.SHA]types.CheckCountSummary, error) {
+	const selectColumns = `
+			check_summary_commit_sha,
+			check_summary_pending,
+			check_summary_running,
+			check_summary_success,
+			check_summary_failure,
+			check_summary_error,
+			check_summary_skipped`
+
+	const selectVisibleColumns = `
+			check_commit_sha,
+			COUNT(*) FILTER (WHERE check_status = 'pending'),
+			COUNT(*) FILTER (WHERE check_status = 'running'),
+			COUNT(*) FILTER (WHERE check_status = 'success'),
+			COUNT(*) FILTER (WHERE check_status = 'failure'),
+			COUNT(*) FILTER (WHERE check_status = 'error'),
+			COUNT(*) FILTER (WHERE check_status = 'skipped')`
+
+	// check_summaries is maintained by database triggers on the checks table.
+	stmt := database.Builder.
+		Select(selectColumns).
+		From("check_summaries").
+		Where("check_summary_repo_id = ?", repoID).
+		Where(squirrel.Eq{"check_summary_commit_sha": commitSHAs})
+
+	// check_summaries counts the status checks of all visibilities, so the visible ones are counted directly.
+	if visibilities != nil {
+		stmt = database.Builder.
+			Select(selectVisibleColumns).
+			From("checks").
+			Where("check_repo_id = ?", repoID).
+			Where(squirrel.Eq{"check_commit_sha": commitSHAs}).
+			Where(squirrel.Eq{"check_visibility": visibilities}).
+			GroupBy("check_commit_sha")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	rows, err := db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute status check summary query")
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[sha.SHA]types.CheckCountSummary)
+
+	for rows.Next() {
+		var commitSHAStr string
+		var countPending int
+		var countRunning int
+		var countSuccess int
+		var countFailure int
+		var countError int
+		var countSkipped int
+		err := rows.Scan(&commitSHAStr,
+			&countPending, &countRunning, &countSuccess, &countFailure, &countError, &countSkipped)
+		if err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan values of status check summary query")
+		}
+
+		commitSHA, err := sha.New(commitSHAStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit SHA read from DB: %s", commitSHAStr)
+		}
+
+		result[commitSHA] = types.CheckCountSummary{
+			Pending: countPending,
+			Running: countRunning,
+			Success: countSuccess,
+			Failure: countFailure,
+			Error:   countError,
+			Skipped: countSkipped,
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status chek summary")
+	}
+
+	if err := s.addFailureCategories(ctx, repoID, commitSHAs, visibilities, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// addFailureCategories adds the number of failed and erroneous status checks by failure category
+// to the status check summaries of the commits.
+func (s *CheckStore) addFailureCategories(
+	ctx context.Context,
+	repoID int64,
+	commitSHAs []string,
+	visibilities []enum.CheckVisibility,
+	summaries map[sha.SHA]types.CheckCountSummary,
+) error {
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Select("check_commit_sha", "check_failure_category", "count(*)").
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where(squirrel.Eq{"check_commit_sha": commitSHAs}).
+		Where(squirrel.Eq{"check_status": []enum.CheckStatus{enum.CheckStatusFailure, enum.CheckStatusError}}).
+		Where("check_failure_category <> ''").
+		GroupBy("check_commit_sha", "check_failure_category")
+
+	if visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": visibilities})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to execute status check failure category query")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var commitSHAStr string
+		var category enum.CheckFailureCategory
+		var count int
+		if err = rows.Scan(&commitSHAStr, &category, &count); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to scan status check failure category")
+		}
+
+		commitSHA, err := sha.New(commitSHAStr)
+		if err != nil {
+			return fmt.Errorf("invalid commit SHA read from DB: %s", commitSHAStr)
+		}
+
+		summary, ok := summaries[commitSHA]
+		if !ok {
+			continue
+		}
+
+		summary.FailureCategories.Add(category, count)
+		summaries[commitSHA] = summary
+	}
+
+	if err = rows.Err(); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to read status check failure categories")
+	}
+
+	return nil
+}
+
+func (*CheckStore) applyOpts(stmt squirrel.SelectBuilder, query string) squirrel.SelectBuilder {
+	if query != "" {
+		stmt = stmt.Where("LOWER(check_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(query)))
+	}
+
+	return stmt
+}
+
+// ResourceUsageSummary returns the resource usage of the status checks in a repo updated in the provided
+// time range, aggregated per status check identifier. Status checks without resource usage are ignored.
+func (s *CheckStore) ResourceUsageSummary(
+	ctx context.Context,
+	repoID int64,
+	opts types.CheckResourceUsageOptions,
+) ([]types.CheckResourceUsageSummary, error) {
+	var cpuSeconds, memoryMB, networkBytes string
+
+	switch s.db.DriverName() {
+	case SqliteDriverName:
+		cpuSeconds = `json_extract(check_resource_usage, '$.cpu_seconds')`
+		memoryMB = `json_extract(check_resource_usage, '$.memory_mb')`
+		networkBytes = `json_extract(check_resource_usage, '$.network_bytes')`
+	default:
+		cpuSeconds = `(check_resource_usage->>'cpu_seconds')::double precision`
+		memoryMB = `(check_resource_usage->>'memory_mb')::integer`
+		networkBytes = `(check_resource_usage->>'network_bytes')::bigint`
+	}
+
+	stmt := database.Builder.
+		Select(
+			"check_uid",
+			"count(*) AS runs",
+			"coalesce(sum("+cpuSeconds+"), 0) AS cpu_seconds",
+			"coalesce(max("+memoryMB+"), 0) AS max_memory_mb",
+			"coalesce(sum("+networkBytes+"), 0) AS network_bytes",
+		).
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_updated >= ?", opts.From.UnixMilli()).
+		Where("check_updated < ?", opts.To.UnixMilli()).
+		Where("check_resource_usage IS NOT NULL").
+		GroupBy("check_uid").
+		OrderBy("check_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	result := make([]types.CheckResourceUsageSummary, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute status check resource usage query")
+	}
+
+	return result, nil
+}
+
+// FilterCommitSHAs returns those of the provided commits in a repo that have a status check result
+// matching the identifier and the status. An empty identifier or status matches any.
+// Only the status checks with any of the visibilities are considered, nil considers all.
+func (s *CheckStore) FilterCommitSHAs(
+	ctx context.Context,
+	repoID int64,
+	commitSHAs []string,
+	identifier string,
+	status enum.CheckStatus,
+	visibilities []enum.CheckVisibility,
+) ([]string, error) {
+	if len(commitSHAs) == 0 {
+		return []string{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("distinct check_commit_sha").
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where(squirrel.Eq{"check_commit_sha": commitSHAs})
+
+	if identifier != "" {
+		stmt = stmt.Where("check_uid = ?", identifier)
+	}
+
+	if status != "" {
+		stmt = stmt.Where("check_status = ?", status)
+	}
+
+	if visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": visibilities})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]string, 0)
+
+	db := s.getAccessor(ctx)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute filter commits by status checks query")
+	}
+
+	return dst, nil
+}
+
+// HeadResultSummary returns the status check result summary of the recorded default branch head commit
+// for each of the provided repos. Repos without a recorded head or without status checks on it
+// are omitted from the result.
+func (s *CheckStore) HeadResultSummary(ctx context.Context,
+	repoIDs []int64,
+) (map[int64]types.CheckCountSummary, error) {
+	const selectColumns = `
+			repo_id,
+			check_summary_pending,
+			check_summary_running,
+			check_summary_success,
+			check_summary_failure,
+			check_summary_error,
+			check_summary_skipped`
+
+	stmt := database.Builder.
+		Select(selectColumns).
+		From("repositories").
+		Join("check_repo_heads ON check_repo_head_repo_id = repo_id").
+		Join("check_summaries ON check_summary_repo_id = repo_id" +
+			" AND check_summary_commit_sha = check_repo_head_commit_sha").
+		Where(squirrel.Eq{"repo_id": repoIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	rows, err := db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute head status check summary query")
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[int64]types.CheckCountSummary, len(repoIDs))
+
+	for rows.Next() {
+		var repoID int64
+		var summary types.CheckCountSummary
+		err := rows.Scan(&repoID, &summary.Pending, &summary.Running, &summary.Success,
+			&summary.Failure, &summary.Error, &summary.Skipped)
+		if err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan values of head status check summary")
+		}
+
+		result[repoID] = summary
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read head status check summary")
+	}
+
+	return result, nil
+}
+
+// ListSummaries returns the status check summaries of all commits of a repo
+// as maintained by the database triggers on the checks table, keyed by commit SHA.
+func (s *CheckStore) ListSummaries(ctx context.Context, repoID int64) (map[string]types.CheckCountSummary, error) {
+	stmt := database.Builder.
+		Select(`
+			check_summary_commit_sha,
+			check_summary_pending,
+			check_summary_running,
+			check_summary_success,
+			check_summary_failure,
+			check_summary_error,
+			check_summary_skipped`).
+		From("check_summaries").
+		Where("check_summary_repo_id = ?", repoID)
+
+	return s.querySummaries(ctx, stmt)
+}
+
+// CountSummaries returns the status check summaries of all commits of a repo
+// aggregated from its status checks, keyed by commit SHA.
+func (s *CheckStore) CountSummaries(ctx context.Context, repoID int64) (map[string]types.CheckCountSummary, error) {
+	stmt := database.Builder.
+		Select("check_commit_sha")
+
+	for _, status := range []enum.CheckStatus{
+		enum.CheckStatusPending,
+		enum.CheckStatusRunning,
+		enum.CheckStatusSuccess,
+		enum.CheckStatusFailure,
+		enum.CheckStatusError,
+		enum.CheckStatusSkipped,
+	} {
+		stmt = stmt.Column("sum(CASE WHEN check_status = ? THEN 1 ELSE 0 END)", status)
+	}
+
+	stmt = stmt.
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		GroupBy("check_commit_sha")
+
+	return s.querySummaries(ctx, stmt)
+}
+
+func (s *CheckStore) querySummaries(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) (map[string]types.CheckCountSummary, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute status check summaries query")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[string]types.CheckCountSummary)
+
+	for rows.Next() {
+		var commitSHA string
+		var summary types.CheckCountSummary
+		err = rows.Scan(&commitSHA,
+			&summary.Pending, &summary.Running, &summary.Success, &summary.Failure, &summary.Error, &summary.Skipped)
+		if err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan status check summary")
+		}
+
+		result[commitSHA] = summary
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status check summaries")
+	}
+
+	return result, nil
+}
+
+// RepoCheckSummary returns the status check metrics of a repo calculated from the status checks created
+// since the provided time. The mean time to green is the average duration of the successful status checks,
+// measured from their start, or their creation if the start time wasn't reported.
+func (s *CheckStore) RepoCheckSummary(
+	ctx context.Context,
+	repoID int64,
+	from time.Time,
+) (*types.RepositoryCheckSummary, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		Column("COALESCE(sum(CASE WHEN check_status IN (?, ?, ?) THEN 1 ELSE 0 END), 0)",
+			enum.CheckStatusSuccess, enum.CheckStatusFailure, enum.CheckStatusError).
+		Column("COALESCE(sum(CASE WHEN check_status = ? THEN 1 ELSE 0 END), 0)",
+			enum.CheckStatusSuccess).
+		Column(`COALESCE(avg(CASE WHEN check_status = ? AND check_ended > 0
+			THEN check_ended - CASE WHEN check_started > 0 THEN check_started ELSE check_created END END), 0)`,
+			enum.CheckStatusSuccess).
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_created >= ?", from.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	summary := &types.RepositoryCheckSummary{Since: from.UnixMilli()}
+
+	var meanTimeToGreen float64
+	if err = db.QueryRowContext(ctx, sql, args...).
+		Scan(&summary.Total, &summary.Completed, &summary.Succeeded, &meanTimeToGreen); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute repo status check summary query")
+	}
+
+	summary.MeanTimeToGreen = int64(meanTimeToGreen)
+	if summary.Completed > 0 {
+		summary.PassRate = float64(summary.Succeeded) / float64(summary.Completed)
+	}
+
+	return summary, nil
+}
+
+// Ping verifies that the checks table can be queried.
+func (s *CheckStore) Ping(ctx context.Context) error {
+	db := s.getAccessor(ctx)
+
+	if _, err := db.ExecContext(ctx, `SELECT 1 FROM checks LIMIT 1`); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to ping status checks table")
+	}
+
+	return nil
+}
+
+func (s *CheckStore) applyListOpts(
+	stmt squirrel.SelectBuilder,
+	commitSHA string,
+	opts types.CheckListOptions,
+) squirrel.SelectBuilder {
+	if commitSHA != "" {
+		stmt = stmt.Where("check_commit_sha = ?", commitSHA)
+	}
+
+	stmt = s.applyOpts(stmt, opts.Query)
+
+	if opts.Visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": opts.Visibilities})
+	}
+
+	if opts.Namespace != "" {
+		stmt = stmt.Where("check_namespace = ?", opts.Namespace)
+	}
+
+	if opts.StepName != "" {
+		switch s.db.DriverName() {
+		case SqliteDriverName:
+			stmt = stmt.Where(`EXISTS (SELECT 1 FROM json_each(check_payload_steps)
+				WHERE json_extract(json_each.value, '$.name') = ?)`, opts.StepName)
+		default:
+			stmt = stmt.Where(`EXISTS (SELECT 1 FROM json_array_elements(check_payload_steps) AS step
+				WHERE step->>'name' = ?)`, opts.StepName)
+		}
+	}
+
+	return stmt
+}
+
+// checkStatusSeverityOrder ranks status check statuses by severity, failed status checks are the most severe.
+const checkStatusSeverityOrder = `CASE check_status
+	WHEN 'failure' THEN 5
+	WHEN 'error' THEN 4
+	WHEN 'running' THEN 3
+	WHEN 'pending' THEN 2
+	WHEN 'success' THEN 1
+	ELSE 0 END`
+
+// checkDurationOrder is the execution duration of completed status checks, zero for all others.
+const checkDurationOrder = `CASE WHEN check_started > 0 AND check_ended >= check_started
+	THEN check_ended - check_started ELSE 0 END`
+
+// applyCheckListSort orders the status checks by the requested attribute, by default the most recently updated first.
+// Identifiers are sorted ascending by default. Status checks with equal values are ordered by the most recently updated.
+func applyCheckListSort(stmt squirrel.SelectBuilder, opts types.CheckListOptions) squirrel.SelectBuilder {
+	order := opts.Order
+	if order == enum.OrderDefault {
+		order = enum.OrderDesc
+		if opts.Sort == enum.CheckSortIdentifier {
+			order = enum.OrderAsc
+		}
+	}
+
+	switch opts.Sort {
+	case enum.CheckSortIdentifier:
+		stmt = stmt.OrderBy("check_uid " + order.String())
+	case enum.CheckSortStatus:
+		stmt = stmt.OrderBy(checkStatusSeverityOrder + " " + order.String())
+	case enum.CheckSortDuration:
+		stmt = stmt.OrderBy(checkDurationOrder + " " + order.String())
+	case enum.CheckSortUpdated:
+		return stmt.OrderBy("check_updated "+order.String(), "check_id "+order.String())
+	}
+
+	return stmt.OrderBy("check_updated desc", "check_id desc")
+}
+
+func (s *CheckStore) mapInternalCheck(c *types.Check) (*check, error) {
+	steps := c.Payload.Steps
+	if steps == nil {
+		steps = []types.CheckStep{}
+	}
+
+	labels := c.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	// the resource usage is stored unencrypted, so that it can still be aggregated by the database.
+	var resourceUsage *sqlxtypes.JSONText
+	if c.ResourceUsage != nil {
+		usage := EncodeToSQLXJSON(c.ResourceUsage)
+		resourceUsage = &usage
+	}
+
+	metadata, metadataKeyID, err := s.encryptCheckData(c.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := checkNamespace(c.Namespace)
+
+	visibility := c.Visibility
+	if visibility == "" {
+		visibility = enum.CheckVisibilityPublic
+	}
+
+	m := &check{
+		ID:             c.ID,
+		CreatedBy:      c.CreatedBy,
+		Created:        c.Created,
+		Updated:        c.Updated,
+		RepoID:         c.RepoID,
+		CommitSHA:      c.CommitSHA,
+		Identifier:     c.Identifier,
+		Namespace:      namespace,
+		Status:         c.Status,
+		Summary:        c.Summary,
+		Link:           c.Link,
+		Payload:        c.Payload.Data,
+		Metadata:       metadata,
+		MetadataKeyID:  metadataKeyID,
+		ResourceUsage:  resourceUsage,
+		PayloadKind:    c.Payload.Kind,
+		PayloadVersion: c.Payload.Version,
+		PayloadSteps:   EncodeToSQLXJSON(steps),
+		Labels:         EncodeToSQLXJSON(labels),
+		Started:        c.Started,
+		Ended:          c.Ended,
+		TargetRepoID:   null.IntFromPtr(c.TargetRepoID),
+
+		FailureCategory: c.FailureCategory,
+		SLABreached:     c.SLABreached,
+		Visibility:      visibility,
+	}
+
+	// the content hash covers the metadata before the encryption, which isn't deterministic.
+	m.ContentHash = checkContentHash(m, c.Metadata)
+
+	if s.payloadCompressionThreshold > 0 && len(m.Payload) > s.payloadCompressionThreshold {
+		payload, err := compressCheckPayload(m.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		m.Payload = payload
+		m.Compressed = true
+	}
+
+	if m.Payload, m.PayloadKeyID, err = s.encryptCheckData(m.Payload); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// checkContentHash returns the SHA-256 hash of all fields of the status check provided by the reporter.
+// It has to be computed before the payload gets compressed or encrypted, so the metadata is passed unencrypted.
+func checkContentHash(c *check, metadata []byte) string {
+	var targetRepoID string
+	if c.TargetRepoID.Valid {
+		targetRepoID = strconv.FormatInt(c.TargetRepoID.Int64, 10)
+	}
+
+	h := sha256.New()
+	for _, part := range [][]byte{
+		[]byte(c.Status),
+		[]byte(c.Summary),
+		[]byte(c.Link),
+		[]byte(c.PayloadKind),
+		[]byte(c.PayloadVersion),
+		c.Payload,
+		c.PayloadSteps,
+		c.Labels,
+		[]byte(strconv.FormatInt(c.Started, 10)),
+		[]byte(strconv.FormatInt(c.Ended, 10)),
+		metadata,
+		checkResourceUsageHashPart(c.ResourceUsage),
+		[]byte(targetRepoID),
+		[]byte(c.Visibility),
+	} {
+		// the length prefix keeps the parts from running into each other.
+		_ = binary.Write(h, binary.BigEndian, uint64(len(part)))
+		h.Write(part)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func checkResourceUsageHashPart(usage *sqlxtypes.JSONText) []byte {
+	if usage == nil {
+		return nil
+	}
+
+	return *usage
+}
+
+func (s *CheckStore) mapCheck(c *check) (types.Check, error) {
+	var steps []types.CheckStep
+	if err := c.PayloadSteps.Unmarshal(&steps); err != nil {
+		return types.Check{}, fmt.Errorf("failed to unmarshal status check steps: %w", err)
+	}
+
+	var labels []string
+	if err := c.Labels.Unmarshal(&labels); err != nil {
+		return types.Check{}, fmt.Errorf("failed to unmarshal status check labels: %w", err)
+	}
+
+	var resourceUsage *types.CheckResourceUsage
+	if c.ResourceUsage != nil {
+		if err := c.ResourceUsage.Unmarshal(&resourceUsage); err != nil {
+			return types.Check{}, fmt.Errorf("failed to unmarshal status check resource usage: %w", err)
+		}
+	}
+
+	metadata, err := s.decryptCheckData(c.Metadata, c.MetadataKeyID)
+	if err != nil {
+		return types.Check{}, err
+	}
+
+	payload, err := s.decryptCheckData(c.Payload, c.PayloadKeyID)
+	if err != nil {
+		return types.Check{}, err
+	}
+
+	if c.Compressed {
+		if payload, err = decompressCheckPayload(payload); err != nil {
+			return types.Check{}, err
+		}
+	}
+
+	return types.Check{
+		ID:         c.ID,
+		CreatedBy:  c.CreatedBy,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		RepoID:     c.RepoID,
 		CommitSHA:  c.CommitSHA,
 		Identifier: c.Identifier,
+		Namespace:  c.Namespace,
 		Status:     c.Status,
 		Summary:    c.Summary,
 		Link:       c.Link,
-		Metadata:   c.Metadata,
+		Metadata:   metadata,
 		Payload: types.CheckPayload{
 			Version: c.PayloadVersion,
 			Kind:    c.PayloadKind,
-			Data:    c.Payload,
+			Data:    payload,
+			Steps:   steps,
 		},
-		ReportedBy: nil,
-		Started:    c.Started,
-		Ended:      c.Ended,
-	}
+		ReportedBy:    nil,
+		Started:       c.Started,
+		Ended:         c.Ended,
+		Labels:        labels,
+		RetryCount:    c.RetryCount,
+		TargetRepoID:  c.TargetRepoID.Ptr(),
+		ResourceUsage: resourceUsage,
+
+		FailureCategory: c.FailureCategory,
+		SLABreached:     c.SLABreached,
+		Visibility:      c.Visibility,
+	}, nil
 }
 
 func (s *CheckStore) mapSliceCheck(ctx context.Context, checks []*check) ([]types.Check, error) {
@@ -449,7 +2476,10 @@ func (s *CheckStore) mapSliceCheck(ctx context.Context, checks []*check) ([]type
 	// attach the principal infos back to the slice items
 	m := make([]types.Check, len(checks))
 	for i, c := range checks {
-		m[i] = mapCheck(c)
+		m[i], err = s.mapCheck(c)
+		if err != nil {
+			return nil, err
+		}
 		if reportedBy, ok := infoMap[c.CreatedBy]; ok {
 			m[i].ReportedBy = reportedBy
 		}
@@ -457,3 +2487,57 @@ func (s *CheckStore) mapSliceCheck(ctx context.Context, checks []*check) ([]type
 
 	return m, nil
 }
+
+// compressCheckPayload gzips the payload and encodes it as a base64 JSON string,
+// so that the compressed payload is still a valid value of the JSON column.
+func compressCheckPayload(payload json.RawMessage) (json.RawMessage, error) {
+	buf := &bytes.Buffer{}
+
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, fmt.Errorf("failed to compress status check payload: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish status check payload compression: %w", err)
+	}
+
+	compressed, err := json.Marshal(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode compressed status check payload: %w", err)
+	}
+
+	return compressed, nil
+}
+
+// decompressCheckPayload reverses compressCheckPayload.
+func decompressCheckPayload(payload json.RawMessage) (json.RawMessage, error) {
+	var compressed []byte
+	if err := json.Unmarshal(payload, &compressed); err != nil {
+		return nil, fmt.Errorf("failed to decode compressed status check payload: %w", err)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open compressed status check payload: %w", err)
+	}
+
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	decompressed, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress status check payload: %w", err)
+	}
+
+	return decompressed, nil
+}
+
+// checkNamespace returns the namespace a status check with the provided namespace is stored in.
+func checkNamespace(namespace string) string {
+	if namespace == "" {
+		return types.CheckNamespaceDefault
+	}
+	return namespace
+}
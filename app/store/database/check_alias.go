@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckAliasStore = (*CheckAliasStore)(nil)
+
+// NewCheckAliasStore returns a new CheckAliasStore.
+func NewCheckAliasStore(db *sqlx.DB) *CheckAliasStore {
+	return &CheckAliasStore{
+		db: db,
+	}
+}
+
+// CheckAliasStore implements store.CheckAliasStore backed by a relational database.
+type CheckAliasStore struct {
+	db *sqlx.DB
+}
+
+const (
+	checkAliasColumns = `
+		 check_uid_alias_id
+		,check_uid_alias_created_by
+		,check_uid_alias_created
+		,check_uid_alias_updated
+		,check_uid_alias_repo_id
+		,check_uid_alias_old_uid
+		,check_uid_alias_new_uid`
+
+	checkAliasSelectBase = `
+	SELECT` + checkAliasColumns + `
+	FROM check_uid_aliases`
+)
+
+type checkAlias struct {
+	ID            int64  `db:"check_uid_alias_id"`
+	CreatedBy     int64  `db:"check_uid_alias_created_by"`
+	Created       int64  `db:"check_uid_alias_created"`
+	Updated       int64  `db:"check_uid_alias_updated"`
+	RepoID        int64  `db:"check_uid_alias_repo_id"`
+	OldIdentifier string `db:"check_uid_alias_old_uid"`
+	NewIdentifier string `db:"check_uid_alias_new_uid"`
+}
+
+// List returns all status check identifier aliases of a repo.
+func (s *CheckAliasStore) List(ctx context.Context, repoID int64) ([]*types.CheckAlias, error) {
+	const sqlQuery = checkAliasSelectBase + `
+	WHERE check_uid_alias_repo_id = $1
+	ORDER BY check_uid_alias_old_uid`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkAlias, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check aliases")
+	}
+
+	result := make([]*types.CheckAlias, len(dst))
+	for i, a := range dst {
+		result[i] = mapCheckAlias(a)
+	}
+
+	return result, nil
+}
+
+// Map returns the new status check identifiers of a repo by their old identifiers.
+func (s *CheckAliasStore) Map(ctx context.Context, repoID int64) (map[string]string, error) {
+	aliases, err := s.List(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		m[alias.OldIdentifier] = alias.NewIdentifier
+	}
+
+	return m, nil
+}
+
+// Create creates a new status check identifier alias.
+func (s *CheckAliasStore) Create(ctx context.Context, alias *types.CheckAlias) error {
+	const sqlQuery = `
+	INSERT INTO check_uid_aliases (
+		 check_uid_alias_created_by
+		,check_uid_alias_created
+		,check_uid_alias_updated
+		,check_uid_alias_repo_id
+		,check_uid_alias_old_uid
+		,check_uid_alias_new_uid
+	) VALUES (
+		 :check_uid_alias_created_by
+		,:check_uid_alias_created
+		,:check_uid_alias_updated
+		,:check_uid_alias_repo_id
+		,:check_uid_alias_old_uid
+		,:check_uid_alias_new_uid
+	)
+	RETURNING check_uid_alias_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalCheckAlias(alias))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check alias object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&alias.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert status check alias query failed")
+	}
+
+	return nil
+}
+
+// Retarget changes the new identifier of all aliases of a repo that point to the provided identifier.
+func (s *CheckAliasStore) Retarget(
+	ctx context.Context,
+	repoID int64,
+	fromIdentifier, toIdentifier string,
+	updated int64,
+) error {
+	const sqlQuery = `
+	UPDATE check_uid_aliases
+	SET
+		 check_uid_alias_new_uid = $1
+		,check_uid_alias_updated = $2
+	WHERE check_uid_alias_repo_id = $3 AND check_uid_alias_new_uid = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, toIdentifier, updated, repoID, fromIdentifier); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to retarget status check aliases")
+	}
+
+	return nil
+}
+
+// Delete deletes the alias of an old status check identifier of a repo.
+func (s *CheckAliasStore) Delete(ctx context.Context, repoID int64, oldIdentifier string) error {
+	const sqlQuery = `
+	DELETE FROM check_uid_aliases
+	WHERE check_uid_alias_repo_id = $1 AND check_uid_alias_old_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID, oldIdentifier)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete status check alias")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted status check aliases")
+	}
+
+	if n == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func mapInternalCheckAlias(a *types.CheckAlias) *checkAlias {
+	return &checkAlias{
+		ID:            a.ID,
+		CreatedBy:     a.CreatedBy,
+		Created:       a.Created,
+		Updated:       a.Updated,
+		RepoID:        a.RepoID,
+		OldIdentifier: a.OldIdentifier,
+		NewIdentifier: a.NewIdentifier,
+	}
+}
+
+func mapCheckAlias(a *checkAlias) *types.CheckAlias {
+	return &types.CheckAlias{
+		ID:            a.ID,
+		CreatedBy:     a.CreatedBy,
+		Created:       a.Created,
+		Updated:       a.Updated,
+		RepoID:        a.RepoID,
+		OldIdentifier: a.OldIdentifier,
+		NewIdentifier: a.NewIdentifier,
+	}
+}
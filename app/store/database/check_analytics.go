@@ -0,0 +1,544 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckAnalyticsStore = (*CheckAnalyticsStore)(nil)
+
+// NewCheckAnalyticsStore returns a new CheckAnalyticsStore.
+func NewCheckAnalyticsStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *CheckAnalyticsStore {
+	return &CheckAnalyticsStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// CheckAnalyticsStore implements store.CheckAnalyticsStore backed by a relational database.
+type CheckAnalyticsStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+// LeaderboardByPrincipal returns the principals that reported the most status checks in a repo
+// in the provided time range, sorted by the number of reported status checks in descending order.
+func (s *CheckAnalyticsStore) LeaderboardByPrincipal(
+	ctx context.Context,
+	repoID int64,
+	from, to time.Time,
+	limit int,
+) ([]*types.CheckLeaderEntry, error) {
+	stmt := database.Builder.
+		Select("check_created_by, count(*)").
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_created >= ?", from.UnixMilli()).
+		Where("check_created < ?", to.UnixMilli()).
+		GroupBy("check_created_by").
+		OrderBy("count(*) DESC", "check_created_by").
+		Limit(uint64(limit)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to query status check leaderboard")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	entries := make([]*types.CheckLeaderEntry, 0, limit)
+	principalIDs := make([]int64, 0, limit)
+	for rows.Next() {
+		entry := &types.CheckLeaderEntry{}
+		if err = rows.Scan(&entry.PrincipalID, &entry.CheckCount); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan status check leaderboard entry")
+		}
+
+		entries = append(entries, entry)
+		principalIDs = append(principalIDs, entry.PrincipalID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status check leaderboard")
+	}
+
+	infoMap, err := s.pCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load status check principal reporters: %w", err)
+	}
+
+	for _, entry := range entries {
+		entry.Principal = infoMap[entry.PrincipalID]
+	}
+
+	return entries, nil
+}
+
+// VolumeHistogram returns the number of status checks reported in a repo in the provided time range,
+// bucketed by the provided interval. Buckets without status checks are included with a zero count.
+func (s *CheckAnalyticsStore) VolumeHistogram(
+	ctx context.Context,
+	repoID int64,
+	from, to time.Time,
+	bucketMinutes int,
+) ([]*types.CheckVolumePoint, error) {
+	if bucketMinutes <= 0 {
+		return nil, fmt.Errorf("bucket size must be positive, got %d minutes", bucketMinutes)
+	}
+
+	bucketSize := (time.Duration(bucketMinutes) * time.Minute).Milliseconds()
+	start := from.UnixMilli()
+	end := to.UnixMilli()
+
+	// creation times are stored in unix millis, so the buckets are computed with integer division
+	// relative to the start of the range, which works the same on all supported databases.
+	bucketExpr := "(check_created - ?) / ?"
+
+	stmt := database.Builder.
+		Select().
+		Column(bucketExpr, start, bucketSize).
+		Column("count(*)").
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_created >= ?", start).
+		Where("check_created < ?", end).
+		GroupBy("1").
+		OrderBy("1")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to query status check volume histogram")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	counts := make(map[int64]int64)
+	for rows.Next() {
+		var bucket, count int64
+		if err = rows.Scan(&bucket, &count); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan status check volume histogram")
+		}
+
+		counts[bucket] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status check volume histogram")
+	}
+
+	points := make([]*types.CheckVolumePoint, 0)
+	for bucket, ts := int64(0), start; ts < end; bucket, ts = bucket+1, ts+bucketSize {
+		points = append(points, &types.CheckVolumePoint{
+			Timestamp:  ts,
+			CheckCount: counts[bucket],
+		})
+	}
+
+	return points, nil
+}
+
+// FailureRates returns the number of completed and failed status checks of the repos
+// that completed in the provided time range. Repos without completed status checks are omitted.
+func (s *CheckAnalyticsStore) FailureRates(
+	ctx context.Context,
+	repoIDs []int64,
+	from, to time.Time,
+) (map[int64]types.CheckFailureRate, error) {
+	if len(repoIDs) == 0 {
+		return map[int64]types.CheckFailureRate{}, nil
+	}
+
+	stmt := failureRatesQuery(from, to).
+		Where(squirrel.Eq{"check_repo_id": repoIDs})
+
+	return s.readFailureRates(ctx, stmt)
+}
+
+// SpaceFailureRates returns the number of completed and failed status checks of the active repos
+// in the space and all its descendant spaces that completed in the provided time range.
+// Repos without completed status checks are omitted.
+func (s *CheckAnalyticsStore) SpaceFailureRates(
+	ctx context.Context,
+	spaceID int64,
+	from, to time.Time,
+) (map[int64]types.CheckFailureRate, error) {
+	stmt := failureRatesQuery(from, to).
+		Prefix(`
+		WITH RECURSIVE space_descendants(space_descendant_id) AS (
+			SELECT space_id FROM spaces WHERE space_id = ?
+
+			UNION
+
+			SELECT space_id FROM spaces
+			JOIN space_descendants ON space_descendant_id = space_parent_id
+		)`, spaceID).
+		Join("repositories ON repo_id = check_repo_id").
+		Join("space_descendants ON space_descendant_id = repo_parent_id").
+		Where("repo_deleted IS NULL")
+
+	return s.readFailureRates(ctx, stmt)
+}
+
+// failureRatesQuery selects the number of completed and failed status checks
+// that completed in the provided time range per repo and failure category.
+func failureRatesQuery(from, to time.Time) squirrel.SelectBuilder {
+	return database.Builder.
+		Select("check_repo_id, check_failure_category, count(*)").
+		Column("sum(CASE WHEN check_status IN (?, ?) THEN 1 ELSE 0 END)",
+			enum.CheckStatusFailure, enum.CheckStatusError).
+		From("checks").
+		Where(squirrel.Eq{"check_status": []enum.CheckStatus{
+			enum.CheckStatusSuccess,
+			enum.CheckStatusFailure,
+			enum.CheckStatusError,
+		}}).
+		Where("check_ended >= ?", from.UnixMilli()).
+		Where("check_ended < ?", to.UnixMilli()).
+		GroupBy("check_repo_id", "check_failure_category")
+}
+
+func (s *CheckAnalyticsStore) readFailureRates(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) (map[int64]types.CheckFailureRate, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	rates := make(map[int64]types.CheckFailureRate)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to query status check failure rates")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var repoID int64
+		var category enum.CheckFailureCategory
+		var completed, failed int64
+		if err = rows.Scan(&repoID, &category, &completed, &failed); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan status check failure rate")
+		}
+
+		rate := rates[repoID]
+		rate.Completed += completed
+		rate.Failed += failed
+
+		if category != "" && failed > 0 {
+			if rate.Categories == nil {
+				rate.Categories = make(map[enum.CheckFailureCategory]int64)
+			}
+			rate.Categories[category] += failed
+		}
+
+		rates[repoID] = rate
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status check failure rates")
+	}
+
+	return rates, nil
+}
+
+// SLABreachRates returns the number of status checks of a repo that completed in the provided time range
+// and how many of them breached their SLA, per status check identifier.
+func (s *CheckAnalyticsStore) SLABreachRates(
+	ctx context.Context,
+	repoID int64,
+	opts types.CheckSLABreachOptions,
+) ([]types.CheckSLABreachRate, error) {
+	stmt := database.Builder.
+		Select("check_uid, count(*)").
+		Column("sum(CASE WHEN check_sla_breached THEN 1 ELSE 0 END)").
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_ended >= ?", opts.From.UnixMilli()).
+		Where("check_ended < ?", opts.To.UnixMilli()).
+		GroupBy("check_uid").
+		OrderBy("check_uid")
+
+	if opts.Identifier != "" {
+		stmt = stmt.Where("check_uid = ?", opts.Identifier)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to query status check SLA breach rates")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	rates := make([]types.CheckSLABreachRate, 0)
+	for rows.Next() {
+		var rate types.CheckSLABreachRate
+		if err = rows.Scan(&rate.Identifier, &rate.Completed, &rate.Breached); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan status check SLA breach rate")
+		}
+
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status check SLA breach rates")
+	}
+
+	return rates, nil
+}
+
+// checkScalingDurationPercentile is the percentile of the status check durations reported by ScalingHints.
+const checkScalingDurationPercentile = 0.95
+
+// ScalingHints returns the status check queue and duration metrics of all repos
+// calculated from the provided time until now.
+// A status check is queued from its creation until it's started. Status checks reported as completed
+// without being started never queue.
+func (s *CheckAnalyticsStore) ScalingHints(ctx context.Context, since time.Time) (*types.CheckScalingHints, error) {
+	now := time.Now().UnixMilli()
+	from := since.UnixMilli()
+
+	if from >= now {
+		return nil, fmt.Errorf("scaling hints start time %d must be before now", from)
+	}
+
+	hints := &types.CheckScalingHints{
+		Since:        from,
+		P95Durations: map[string]int64{},
+	}
+
+	if err := s.scalingQueue(ctx, hints, from, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.scalingDurations(ctx, hints, from); err != nil {
+		return nil, err
+	}
+
+	return hints, nil
+}
+
+// scalingQueue calculates the queue metrics of the scaling hints.
+func (s *CheckAnalyticsStore) scalingQueue(ctx context.Context, hints *types.CheckScalingHints, from, now int64) error {
+	const queued = `(check_started >= ? AND check_started >= check_created)
+		OR (check_started = 0 AND check_status = ?)`
+
+	stmt := database.Builder.
+		Select().
+		Column("COALESCE(SUM(CASE WHEN check_started = 0 AND check_status = ? THEN 1 ELSE 0 END), 0)",
+			enum.CheckStatusPending).
+		Column(`COALESCE(SUM(CASE WHEN `+queued+`
+			THEN (CASE WHEN check_started > 0 THEN check_started ELSE ? END)
+				- (CASE WHEN check_created > ? THEN check_created ELSE ? END)
+			ELSE 0 END), 0)`,
+			from, enum.CheckStatusPending, now, from, from).
+		Column("COALESCE(SUM(CASE WHEN check_created >= ? THEN 1 ELSE 0 END), 0)", from).
+		Column(`COALESCE(SUM(CASE WHEN check_started >= ?
+			OR (check_created >= ? AND check_started = 0 AND check_status <> ?)
+			THEN 1 ELSE 0 END), 0)`,
+			from, from, enum.CheckStatusPending).
+		From("checks").
+		Where("check_created < ?", now).
+		Where("(check_created >= ? OR "+queued+")", from, from, enum.CheckStatusPending)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var queueTime, enqueued, dequeued int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&hints.PendingChecks, &queueTime, &enqueued,
+		&dequeued); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to query status check queue metrics")
+	}
+
+	window := float64(now - from)
+	hints.AvgQueueDepth = float64(queueTime) / window
+	hints.BacklogGrowthRate = float64(enqueued-dequeued) / (window / float64(time.Hour.Milliseconds()))
+
+	return nil
+}
+
+// scalingDurations calculates the duration percentiles of the scaling hints.
+// The durations are sorted by the database, the percentile is picked using the nearest-rank method.
+func (s *CheckAnalyticsStore) scalingDurations(ctx context.Context, hints *types.CheckScalingHints, from int64) error {
+	stmt := database.Builder.
+		Select("check_uid", "check_ended - check_started").
+		From("checks").
+		Where("check_ended >= ?", from).
+		Where("check_started > 0").
+		Where("check_ended >= check_started").
+		OrderBy("check_uid", "check_ended - check_started")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to query status check durations")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var identifier string
+	var durations []int64
+
+	flush := func() {
+		if len(durations) == 0 {
+			return
+		}
+		rank := int(math.Ceil(checkScalingDurationPercentile * float64(len(durations))))
+		hints.P95Durations[identifier] = durations[rank-1]
+	}
+
+	for rows.Next() {
+		var uid string
+		var duration int64
+		if err = rows.Scan(&uid, &duration); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to scan status check duration")
+		}
+
+		if uid != identifier {
+			flush()
+			identifier = uid
+			durations = durations[:0]
+		}
+
+		durations = append(durations, duration)
+	}
+
+	if err = rows.Err(); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to read status check durations")
+	}
+
+	flush()
+
+	return nil
+}
+
+// OrgIngestionStats returns the number of status checks reported in the provided time range
+// and the size of their payloads for the topN organizations (root spaces) with the most status checks,
+// sorted by the number of status checks in descending order.
+func (s *CheckAnalyticsStore) OrgIngestionStats(
+	ctx context.Context,
+	from, to time.Time,
+	topN int,
+) ([]*types.OrgIngestionStat, error) {
+	sizeExpr := "octet_length(check_payload::text)"
+	if s.db.DriverName() == SqliteDriverName {
+		sizeExpr = "length(check_payload)"
+	}
+
+	sqlQuery := `
+	WITH RECURSIVE
+		space_roots(root_id, root_uid, space_id) AS (
+			SELECT space_id, space_uid, space_id
+			FROM spaces
+			WHERE space_parent_id IS NULL
+
+			UNION
+
+			SELECT r.root_id, r.root_uid, s.space_id
+			FROM spaces s
+			JOIN space_roots r ON s.space_parent_id = r.space_id
+		)
+	SELECT
+		 space_roots.root_id
+		,space_roots.root_uid
+		,count(*)
+		,COALESCE(SUM(` + sizeExpr + `), 0)
+	FROM checks
+	JOIN repositories ON repo_id = check_repo_id
+	JOIN space_roots ON space_roots.space_id = repo_parent_id
+	WHERE check_created >= ? AND check_created < ?
+	GROUP BY space_roots.root_id, space_roots.root_uid
+	ORDER BY count(*) DESC, space_roots.root_id
+	LIMIT ?`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, s.db.Rebind(sqlQuery), from.UnixMilli(), to.UnixMilli(), topN)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to query status check ingestion per organization")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	stats := make([]*types.OrgIngestionStat, 0, topN)
+	for rows.Next() {
+		stat := &types.OrgIngestionStat{}
+		if err = rows.Scan(&stat.SpaceID, &stat.SpaceUID, &stat.CheckCount, &stat.ByteSize); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan status check ingestion of organization")
+		}
+
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read status check ingestion per organization")
+	}
+
+	return stats, nil
+}
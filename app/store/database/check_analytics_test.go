@@ -0,0 +1,438 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCheckAnalyticsStore_LeaderboardByPrincipal(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	const botID = int64(2)
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+	if err := principalStore.CreateUser(ctx, &types.User{ID: botID, UID: "ci_bot", Email: "ci_bot@example.com"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	report := func(principalID int64, identifier string) {
+		t.Helper()
+
+		check := newCheck(repoID, identifier, enum.CheckStatusSuccess)
+		check.CreatedBy = principalID
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", identifier, err)
+		}
+	}
+
+	report(userID, "lint")
+	report(botID, "build")
+	report(botID, "test")
+
+	pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+	analyticsStore := database.NewCheckAnalyticsStore(db, pCache)
+
+	now := time.Now()
+
+	entries, err := analyticsStore.LeaderboardByPrincipal(ctx, repoID, now.Add(-time.Hour), now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("LeaderboardByPrincipal() error = %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("LeaderboardByPrincipal() returned %d entries, want 2", len(entries))
+	}
+
+	if entries[0].PrincipalID != botID || entries[0].CheckCount != 2 ||
+		entries[1].PrincipalID != userID || entries[1].CheckCount != 1 {
+		t.Errorf("LeaderboardByPrincipal() = [%+v %+v], want the bot first", *entries[0], *entries[1])
+	}
+
+	if entries[0].Principal == nil || entries[0].Principal.UID != "ci_bot" {
+		t.Errorf("LeaderboardByPrincipal() principal = %+v, want ci_bot", entries[0].Principal)
+	}
+
+	entries, err = analyticsStore.LeaderboardByPrincipal(ctx, repoID, now.Add(-time.Hour), now.Add(time.Hour), 1)
+	if err != nil {
+		t.Fatalf("LeaderboardByPrincipal() error = %v", err)
+	}
+
+	if len(entries) != 1 || entries[0].PrincipalID != botID {
+		t.Errorf("LeaderboardByPrincipal() with limit 1 returned %d entries", len(entries))
+	}
+
+	entries, err = analyticsStore.LeaderboardByPrincipal(ctx, repoID, now.Add(time.Hour), now.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("LeaderboardByPrincipal() error = %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("LeaderboardByPrincipal() outside of the time range returned %d entries", len(entries))
+	}
+}
+
+func TestCheckAnalyticsStore_VolumeHistogram(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	from := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+
+	report := func(identifier string, created time.Time) {
+		t.Helper()
+
+		check := newCheck(repoID, identifier, enum.CheckStatusSuccess)
+		check.Created = created.UnixMilli()
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", identifier, err)
+		}
+	}
+
+	report("build", from.Add(10*time.Minute))
+	report("test", from.Add(59*time.Minute))
+	report("lint", from.Add(2*time.Hour))
+	report("e2e", from.Add(-time.Minute))
+	report("deploy", from.Add(3*time.Hour))
+
+	analyticsStore := database.NewCheckAnalyticsStore(db, nil)
+
+	points, err := analyticsStore.VolumeHistogram(ctx, repoID, from, from.Add(3*time.Hour), 60)
+	if err != nil {
+		t.Fatalf("VolumeHistogram() error = %v", err)
+	}
+
+	want := []*types.CheckVolumePoint{
+		{Timestamp: from.UnixMilli(), CheckCount: 2},
+		{Timestamp: from.Add(time.Hour).UnixMilli(), CheckCount: 0},
+		{Timestamp: from.Add(2 * time.Hour).UnixMilli(), CheckCount: 1},
+	}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("VolumeHistogram() = %+v, want %+v", points, want)
+	}
+
+	points, err = analyticsStore.VolumeHistogram(ctx, repoID, from, from.Add(3*time.Hour), 120)
+	if err != nil {
+		t.Fatalf("VolumeHistogram() error = %v", err)
+	}
+
+	if len(points) != 2 || points[0].CheckCount != 2 || points[1].CheckCount != 1 {
+		t.Errorf("VolumeHistogram() with 2 hour buckets = %+v, want counts [2 1]", points)
+	}
+}
+
+func TestCheckAnalyticsStore_FailureRates(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	now := time.Now()
+
+	report := func(identifier string, status enum.CheckStatus, ended time.Time) {
+		t.Helper()
+
+		check := newCheck(repoID, identifier, status)
+		check.Ended = ended.UnixMilli()
+		check.FailureCategory = status.DefaultFailureCategory()
+		if identifier == "lint" {
+			check.FailureCategory = enum.CheckFailureCategoryTimeout
+		}
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", identifier, err)
+		}
+	}
+
+	report("build", enum.CheckStatusSuccess, now.Add(-time.Hour))
+	report("test", enum.CheckStatusFailure, now.Add(-time.Hour))
+	report("lint", enum.CheckStatusError, now.Add(-2*time.Hour))
+	report("deploy", enum.CheckStatusRunning, now.Add(-time.Hour))
+	report("e2e", enum.CheckStatusFailure, now.Add(-48*time.Hour))
+
+	analyticsStore := database.NewCheckAnalyticsStore(db, nil)
+
+	rates, err := analyticsStore.FailureRates(ctx, []int64{repoID, repoID + 1}, now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("FailureRates() error = %v", err)
+	}
+
+	want := types.CheckFailureRate{
+		Completed: 3,
+		Failed:    2,
+		Categories: map[enum.CheckFailureCategory]int64{
+			enum.CheckFailureCategoryCodeFailure: 1,
+			enum.CheckFailureCategoryTimeout:     1,
+		},
+	}
+	if got := rates[repoID]; !reflect.DeepEqual(got, want) {
+		t.Errorf("FailureRates() = %+v, want %+v", got, want)
+	}
+
+	if _, ok := rates[repoID+1]; ok {
+		t.Errorf("FailureRates() returned a failure rate for a repo without status checks")
+	}
+}
+
+func TestCheckAnalyticsStore_SpaceFailureRates(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+	_, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	// repo 2 is in a subspace of the space of the repo, repo 3 is in another root space
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 3, 0)
+	createRepo(ctx, t, repoStore, 2, 2, 0)
+	createRepo(ctx, t, repoStore, 3, 3, 0)
+
+	now := time.Now()
+
+	for _, id := range []int64{repoID, 2, 3} {
+		check := newCheck(id, "build", enum.CheckStatusFailure)
+		check.Ended = now.Add(-time.Hour).UnixMilli()
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check: %v", err)
+		}
+	}
+
+	analyticsStore := database.NewCheckAnalyticsStore(db, nil)
+
+	rates, err := analyticsStore.SpaceFailureRates(ctx, 1, now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("SpaceFailureRates() error = %v", err)
+	}
+
+	if len(rates) != 2 || rates[repoID].Failed != 1 || rates[2].Failed != 1 {
+		t.Errorf("SpaceFailureRates() = %+v, want the repos of the space and its subspace", rates)
+	}
+
+	rates, err = analyticsStore.SpaceFailureRates(ctx, 2, now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("SpaceFailureRates() error = %v", err)
+	}
+
+	if len(rates) != 1 || rates[2].Completed != 1 {
+		t.Errorf("SpaceFailureRates() = %+v, want the repo of the subspace only", rates)
+	}
+}
+
+func TestCheckAnalyticsStore_SLABreachRates(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	now := time.Now()
+
+	report := func(identifier, commitSHA string, breached bool, ended time.Time) {
+		t.Helper()
+
+		check := newCheck(repoID, identifier, enum.CheckStatusSuccess)
+		check.CommitSHA = commitSHA
+		check.Ended = ended.UnixMilli()
+		check.SLABreached = breached
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", identifier, err)
+		}
+	}
+
+	report("build", testCommitSHA, true, now.Add(-time.Hour))
+	report("build", "1111111111111111111111111111111111111111", false, now.Add(-2*time.Hour))
+	report("test", testCommitSHA, false, now.Add(-time.Hour))
+	report("lint", testCommitSHA, true, now.Add(-48*time.Hour))
+
+	analyticsStore := database.NewCheckAnalyticsStore(db, nil)
+
+	rates, err := analyticsStore.SLABreachRates(ctx, repoID, types.CheckSLABreachOptions{
+		From: now.Add(-24 * time.Hour),
+		To:   now,
+	})
+	if err != nil {
+		t.Fatalf("SLABreachRates() error = %v", err)
+	}
+
+	want := []types.CheckSLABreachRate{
+		{Identifier: "build", Completed: 2, Breached: 1},
+		{Identifier: "test", Completed: 1, Breached: 0},
+	}
+	if !reflect.DeepEqual(rates, want) {
+		t.Errorf("SLABreachRates() = %+v, want %+v", rates, want)
+	}
+
+	rates, err = analyticsStore.SLABreachRates(ctx, repoID, types.CheckSLABreachOptions{
+		Identifier: "build",
+		From:       now.Add(-24 * time.Hour),
+		To:         now,
+	})
+	if err != nil {
+		t.Fatalf("SLABreachRates() error = %v", err)
+	}
+
+	if len(rates) != 1 || rates[0].Rate() != 0.5 {
+		t.Errorf("SLABreachRates() of build = %+v, want a breach rate of 0.5", rates)
+	}
+}
+
+func TestCheckAnalyticsStore_ScalingHints(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	const otherCommitSHA = "1111111111111111111111111111111111111111"
+
+	now := time.Now()
+	minutesAgo := func(minutes int) int64 {
+		return now.Add(-time.Duration(minutes) * time.Minute).UnixMilli()
+	}
+
+	for _, c := range []struct {
+		identifier string
+		commitSHA  string
+		status     enum.CheckStatus
+		created    int64
+		started    int64
+		ended      int64
+	}{
+		// queued for 10 minutes, ran for 10 minutes.
+		{"build", testCommitSHA, enum.CheckStatusSuccess, minutesAgo(30), minutesAgo(20), minutesAgo(10)},
+		// queued for 1 minute, ran for 1 minute.
+		{"build", otherCommitSHA, enum.CheckStatusFailure, minutesAgo(30), minutesAgo(29), minutesAgo(28)},
+		// still queued, for 15 minutes so far.
+		{"test", testCommitSHA, enum.CheckStatusPending, minutesAgo(15), 0, 0},
+		// reported as completed without being started before the window.
+		{"lint", testCommitSHA, enum.CheckStatusSuccess, minutesAgo(120), 0, minutesAgo(120)},
+	} {
+		check := newCheck(repoID, c.identifier, c.status)
+		check.CommitSHA = c.commitSHA
+		check.Created = c.created
+		check.Updated = c.created
+		check.Started = c.started
+		check.Ended = c.ended
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", c.identifier, err)
+		}
+	}
+
+	pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+	analyticsStore := database.NewCheckAnalyticsStore(db, pCache)
+
+	hints, err := analyticsStore.ScalingHints(ctx, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ScalingHints() error = %v", err)
+	}
+
+	if hints.PendingChecks != 1 {
+		t.Errorf("ScalingHints() pending = %d, want 1", hints.PendingChecks)
+	}
+
+	// 26 minutes of queueing in the window of an hour.
+	if wantDepth := 26.0 / 60; math.Abs(hints.AvgQueueDepth-wantDepth) > 0.001 {
+		t.Errorf("ScalingHints() queue depth = %f, want %f", hints.AvgQueueDepth, wantDepth)
+	}
+
+	// three status checks were queued in the window and two were started.
+	if math.Abs(hints.BacklogGrowthRate-1) > 0.001 {
+		t.Errorf("ScalingHints() backlog growth = %f, want 1", hints.BacklogGrowthRate)
+	}
+
+	wantDurations := map[string]int64{"build": (10 * time.Minute).Milliseconds()}
+	if !reflect.DeepEqual(hints.P95Durations, wantDurations) {
+		t.Errorf("ScalingHints() p95 durations = %v, want %v", hints.P95Durations, wantDurations)
+	}
+}
+
+func TestCheckAnalyticsStore_OrgIngestionStats(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	_, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 3, 2)
+	createRepo(ctx, t, repoStore, 2, 3, 0)
+	createRepo(ctx, t, repoStore, 3, 2, 0)
+
+	now := time.Now()
+
+	for _, c := range []struct {
+		repoID     int64
+		identifier string
+		created    time.Time
+	}{
+		{repoID: repoID, identifier: "build", created: now.Add(-time.Minute)},
+		{repoID: 2, identifier: "build", created: now.Add(-time.Minute)},
+		{repoID: 2, identifier: "lint", created: now.Add(-time.Minute)},
+		{repoID: 3, identifier: "build", created: now.Add(-time.Minute)},
+		{repoID: 3, identifier: "lint", created: now.Add(-2 * time.Hour)}, // outside the time range
+	} {
+		check := newCheck(c.repoID, c.identifier, enum.CheckStatusSuccess)
+		check.Created = c.created.UnixMilli()
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check: %v", err)
+		}
+	}
+
+	pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+	analyticsStore := database.NewCheckAnalyticsStore(db, pCache)
+
+	stats, err := analyticsStore.OrgIngestionStats(ctx, now.Add(-time.Hour), now, 10)
+	if err != nil {
+		t.Fatalf("OrgIngestionStats() error = %v", err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("OrgIngestionStats() returned %d organizations, want 2", len(stats))
+	}
+
+	if stats[0].SpaceID != 2 || stats[0].SpaceUID != "space_2" || stats[0].CheckCount != 3 || stats[0].ByteSize <= 0 {
+		t.Errorf("OrgIngestionStats()[0] = %+v, want space_2 with 3 status checks", stats[0])
+	}
+
+	if stats[1].SpaceID != 1 || stats[1].CheckCount != 1 {
+		t.Errorf("OrgIngestionStats()[1] = %+v, want space_1 with 1 status check", stats[1])
+	}
+
+	stats, err = analyticsStore.OrgIngestionStats(ctx, now.Add(-time.Hour), now, 1)
+	if err != nil {
+		t.Fatalf("OrgIngestionStats() error = %v", err)
+	}
+
+	if len(stats) != 1 || stats[0].SpaceID != 2 {
+		t.Errorf("OrgIngestionStats() with top 1 = %+v, want only space_2", stats)
+	}
+}
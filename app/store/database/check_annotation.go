@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckAnnotationStore = (*CheckAnnotationStore)(nil)
+
+// NewCheckAnnotationStore returns a new CheckAnnotationStore.
+func NewCheckAnnotationStore(db *sqlx.DB) *CheckAnnotationStore {
+	return &CheckAnnotationStore{
+		db: db,
+	}
+}
+
+// CheckAnnotationStore implements store.CheckAnnotationStore backed by a relational database.
+type CheckAnnotationStore struct {
+	db *sqlx.DB
+}
+
+const (
+	checkAnnotationColumns = `
+		 check_annotation_id
+		,check_annotation_check_id
+		,check_annotation_repo_id
+		,check_annotation_commit_sha
+		,check_annotation_path
+		,check_annotation_line_start
+		,check_annotation_line_end
+		,check_annotation_level
+		,check_annotation_title
+		,check_annotation_message`
+)
+
+type checkAnnotation struct {
+	ID              int64                     `db:"check_annotation_id"`
+	CheckID         int64                     `db:"check_annotation_check_id"`
+	RepoID          int64                     `db:"check_annotation_repo_id"`
+	CommitSHA       string                    `db:"check_annotation_commit_sha"`
+	Path            string                    `db:"check_annotation_path"`
+	LineStart       int                       `db:"check_annotation_line_start"`
+	LineEnd         int                       `db:"check_annotation_line_end"`
+	Level           enum.CheckAnnotationLevel `db:"check_annotation_level"`
+	Title           string                    `db:"check_annotation_title"`
+	Message         string                    `db:"check_annotation_message"`
+	CheckIdentifier string                    `db:"check_uid"`
+}
+
+// Replace replaces all annotations of a status check with the provided ones.
+func (s *CheckAnnotationStore) Replace(
+	ctx context.Context,
+	check *types.Check,
+	annotations []*types.CheckAnnotation,
+) error {
+	const sqlDelete = `DELETE FROM check_annotations WHERE check_annotation_check_id = $1`
+
+	const sqlInsert = `
+	INSERT INTO check_annotations (
+		 check_annotation_check_id
+		,check_annotation_repo_id
+		,check_annotation_commit_sha
+		,check_annotation_path
+		,check_annotation_line_start
+		,check_annotation_line_end
+		,check_annotation_level
+		,check_annotation_title
+		,check_annotation_message
+	) VALUES (
+		 :check_annotation_check_id
+		,:check_annotation_repo_id
+		,:check_annotation_commit_sha
+		,:check_annotation_path
+		,:check_annotation_line_start
+		,:check_annotation_line_end
+		,:check_annotation_level
+		,:check_annotation_title
+		,:check_annotation_message
+	)
+	RETURNING check_annotation_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlDelete, check.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete status check annotations")
+	}
+
+	for _, annotation := range annotations {
+		annotation.CheckID = check.ID
+		annotation.CheckIdentifier = check.Identifier
+
+		query, arg, err := db.BindNamed(sqlInsert, mapInternalCheckAnnotation(check, annotation))
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check annotation object")
+		}
+
+		if err = db.QueryRowContext(ctx, query, arg...).Scan(&annotation.ID); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Insert status check annotation query failed")
+		}
+	}
+
+	return nil
+}
+
+// ListByPaths returns the status check annotations of a commit attached to any of the provided paths.
+func (s *CheckAnnotationStore) ListByPaths(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	paths []string,
+	visibilities []enum.CheckVisibility,
+) ([]types.CheckAnnotation, error) {
+	if len(paths) == 0 {
+		return []types.CheckAnnotation{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(checkAnnotationColumns+", check_uid").
+		From("check_annotations").
+		InnerJoin("checks ON check_id = check_annotation_check_id").
+		Where("check_annotation_repo_id = ?", repoID).
+		Where("check_annotation_commit_sha = ?", commitSHA).
+		Where(squirrel.Eq{"check_annotation_path": paths}).
+		OrderBy("check_annotation_path", "check_annotation_line_start", "check_annotation_id")
+
+	if visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": visibilities})
+	}
+
+	return s.list(ctx, stmt)
+}
+
+// ListByLineRange returns the status check annotations of a commit attached to the path
+// that cover any line of the provided (inclusive) line range.
+func (s *CheckAnnotationStore) ListByLineRange(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	path string,
+	lineStart int,
+	lineEnd int,
+	visibilities []enum.CheckVisibility,
+) ([]types.CheckAnnotation, error) {
+	// same as types.CheckAnnotation.Overlaps, the annotations spanning the whole range are included as well.
+	stmt := database.Builder.
+		Select(checkAnnotationColumns+", check_uid").
+		From("check_annotations").
+		InnerJoin("checks ON check_id = check_annotation_check_id").
+		Where("check_annotation_repo_id = ?", repoID).
+		Where("check_annotation_commit_sha = ?", commitSHA).
+		Where("check_annotation_path = ?", path).
+		Where("check_annotation_line_start <= ?", lineEnd).
+		Where("check_annotation_line_end >= ?", lineStart).
+		OrderBy("check_annotation_line_start", "check_annotation_id")
+
+	if visibilities != nil {
+		stmt = stmt.Where(squirrel.Eq{"check_visibility": visibilities})
+	}
+
+	return s.list(ctx, stmt)
+}
+
+func (s *CheckAnnotationStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]types.CheckAnnotation, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkAnnotation, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check annotations")
+	}
+
+	result := make([]types.CheckAnnotation, len(dst))
+	for i, a := range dst {
+		result[i] = mapCheckAnnotation(a)
+	}
+
+	return result, nil
+}
+
+func mapInternalCheckAnnotation(check *types.Check, a *types.CheckAnnotation) *checkAnnotation {
+	return &checkAnnotation{
+		ID:              a.ID,
+		CheckID:         check.ID,
+		RepoID:          check.RepoID,
+		CommitSHA:       check.CommitSHA,
+		Path:            a.Path,
+		LineStart:       a.LineStart,
+		LineEnd:         a.LineEnd,
+		Level:           a.Level,
+		Title:           a.Title,
+		Message:         a.Message,
+		CheckIdentifier: check.Identifier,
+	}
+}
+
+func mapCheckAnnotation(a *checkAnnotation) types.CheckAnnotation {
+	return types.CheckAnnotation{
+		ID:              a.ID,
+		CheckID:         a.CheckID,
+		CheckIdentifier: a.CheckIdentifier,
+		Path:            a.Path,
+		LineStart:       a.LineStart,
+		LineEnd:         a.LineEnd,
+		Level:           a.Level,
+		Title:           a.Title,
+		Message:         a.Message,
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCheckAnnotationStore_ListByLineRange(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+	annotationStore := database.NewCheckAnnotationStore(db)
+
+	check := upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusFailure)
+
+	annotations := []*types.CheckAnnotation{
+		{Path: "main.go", LineStart: 1, LineEnd: 3, Level: enum.CheckAnnotationLevelWarning, Title: "before"},
+		{Path: "main.go", LineStart: 8, LineEnd: 12, Level: enum.CheckAnnotationLevelWarning, Title: "start"},
+		{Path: "main.go", LineStart: 12, LineEnd: 14, Level: enum.CheckAnnotationLevelFailure, Title: "inside"},
+		{Path: "main.go", LineStart: 5, LineEnd: 30, Level: enum.CheckAnnotationLevelNotice, Title: "spanning"},
+		{Path: "main.go", LineStart: 21, LineEnd: 21, Level: enum.CheckAnnotationLevelNotice, Title: "after"},
+		{Path: "util.go", LineStart: 12, LineEnd: 12, Level: enum.CheckAnnotationLevelFailure, Title: "other file"},
+	}
+	if err := annotationStore.Replace(ctx, check, annotations); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	found, err := annotationStore.ListByLineRange(ctx, repoID, testCommitSHA, "main.go", 10, 20, nil)
+	if err != nil {
+		t.Fatalf("ListByLineRange() error = %v", err)
+	}
+
+	titles := make([]string, len(found))
+	for i, annotation := range found {
+		titles[i] = annotation.Title
+		if annotation.CheckIdentifier != "lint" {
+			t.Errorf("annotation %q has check identifier %q, want %q", annotation.Title, annotation.CheckIdentifier, "lint")
+		}
+	}
+
+	if want := []string{"spanning", "start", "inside"}; !slices.Equal(titles, want) {
+		t.Errorf("ListByLineRange() = %v, want %v", titles, want)
+	}
+
+	// the annotations of status checks that aren't visible are left out.
+	private := newCheck(repoID, "security", enum.CheckStatusFailure)
+	private.Visibility = enum.CheckVisibilityPrivate
+	if err = checkStore.Upsert(ctx, private); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	err = annotationStore.Replace(ctx, private, []*types.CheckAnnotation{
+		{Path: "main.go", LineStart: 15, LineEnd: 15, Level: enum.CheckAnnotationLevelFailure, Title: "secret"},
+	})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	for _, test := range []struct {
+		visibilities []enum.CheckVisibility
+		want         int
+	}{
+		{visibilities: nil, want: 4},
+		{visibilities: []enum.CheckVisibility{enum.CheckVisibilityPublic, enum.CheckVisibilityRestricted}, want: 3},
+	} {
+		found, err = annotationStore.ListByLineRange(ctx, repoID, testCommitSHA, "main.go", 10, 20, test.visibilities)
+		if err != nil {
+			t.Fatalf("ListByLineRange() error = %v", err)
+		}
+
+		if len(found) != test.want {
+			t.Errorf("ListByLineRange() visible to %v = %d annotations, want %d", test.visibilities, len(found), test.want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckArchiveStore = (*CheckArchiveStore)(nil)
+
+// NewCheckArchiveStore returns a new CheckArchiveStore.
+func NewCheckArchiveStore(db *sqlx.DB) *CheckArchiveStore {
+	return &CheckArchiveStore{
+		db: db,
+	}
+}
+
+// CheckArchiveStore implements store.CheckArchiveStore backed by a relational database.
+type CheckArchiveStore struct {
+	db *sqlx.DB
+}
+
+const (
+	checkArchiveColumns = `
+		 check_archive_id
+		,check_archive_created
+		,check_archive_repo_id
+		,check_archive_bucket
+		,check_archive_key
+		,check_archive_period_start
+		,check_archive_period_end
+		,check_archive_check_count`
+
+	checkArchiveSelectBase = `
+	SELECT` + checkArchiveColumns + `
+	FROM check_archives`
+)
+
+type checkArchive struct {
+	ID          int64  `db:"check_archive_id"`
+	Created     int64  `db:"check_archive_created"`
+	RepoID      int64  `db:"check_archive_repo_id"`
+	Bucket      string `db:"check_archive_bucket"`
+	Key         string `db:"check_archive_key"`
+	PeriodStart int64  `db:"check_archive_period_start"`
+	PeriodEnd   int64  `db:"check_archive_period_end"`
+	CheckCount  int    `db:"check_archive_check_count"`
+}
+
+// Find returns the status check archive with the provided ID.
+func (s *CheckArchiveStore) Find(ctx context.Context, id int64) (*types.CheckArchive, error) {
+	const sqlQuery = checkArchiveSelectBase + `
+	WHERE check_archive_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &checkArchive{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find status check archive")
+	}
+
+	return mapCheckArchive(dst), nil
+}
+
+// List returns the status check archives of a repo, the most recent period first.
+func (s *CheckArchiveStore) List(
+	ctx context.Context,
+	repoID int64,
+	page, size int,
+) ([]*types.CheckArchive, error) {
+	stmt := database.Builder.
+		Select(checkArchiveColumns).
+		From("check_archives").
+		Where("check_archive_repo_id = ?", repoID).
+		OrderBy("check_archive_period_start DESC", "check_archive_id DESC").
+		Limit(database.Limit(size)).
+		Offset(database.Offset(page, size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkArchive, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check archives")
+	}
+
+	result := make([]*types.CheckArchive, len(dst))
+	for i, a := range dst {
+		result[i] = mapCheckArchive(a)
+	}
+
+	return result, nil
+}
+
+// CountByPeriod returns the number of status check archives of a repo that start at the provided time.
+func (s *CheckArchiveStore) CountByPeriod(ctx context.Context, repoID int64, periodStart int64) (int, error) {
+	const sqlQuery = `
+	SELECT COUNT(*)
+	FROM check_archives
+	WHERE check_archive_repo_id = $1 AND check_archive_period_start = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID, periodStart).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count status check archives")
+	}
+
+	return count, nil
+}
+
+// Create creates a new status check archive record.
+func (s *CheckArchiveStore) Create(ctx context.Context, archive *types.CheckArchive) error {
+	const sqlQuery = `
+	INSERT INTO check_archives (
+		 check_archive_created
+		,check_archive_repo_id
+		,check_archive_bucket
+		,check_archive_key
+		,check_archive_period_start
+		,check_archive_period_end
+		,check_archive_check_count
+	) VALUES (
+		 :check_archive_created
+		,:check_archive_repo_id
+		,:check_archive_bucket
+		,:check_archive_key
+		,:check_archive_period_start
+		,:check_archive_period_end
+		,:check_archive_check_count
+	)
+	RETURNING check_archive_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalCheckArchive(archive))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check archive object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&archive.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert status check archive query failed")
+	}
+
+	return nil
+}
+
+func mapInternalCheckArchive(a *types.CheckArchive) *checkArchive {
+	return &checkArchive{
+		ID:          a.ID,
+		Created:     a.Created,
+		RepoID:      a.RepoID,
+		Bucket:      a.Bucket,
+		Key:         a.Key,
+		PeriodStart: a.PeriodStart,
+		PeriodEnd:   a.PeriodEnd,
+		CheckCount:  a.CheckCount,
+	}
+}
+
+func mapCheckArchive(a *checkArchive) *types.CheckArchive {
+	return &types.CheckArchive{
+		ID:          a.ID,
+		Created:     a.Created,
+		RepoID:      a.RepoID,
+		Bucket:      a.Bucket,
+		Key:         a.Key,
+		PeriodStart: a.PeriodStart,
+		PeriodEnd:   a.PeriodEnd,
+		CheckCount:  a.CheckCount,
+	}
+}
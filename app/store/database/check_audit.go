@@ -0,0 +1,438 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.CheckAuditStore = (*CheckAuditStore)(nil)
+
+// NewCheckAuditStore returns a new CheckAuditStore.
+// The recorded payloads are encrypted with the active key of the keyRing, nil disables the encryption.
+func NewCheckAuditStore(db *sqlx.DB, keyRing *encrypt.KeyRing) *CheckAuditStore {
+	return &CheckAuditStore{
+		db:      db,
+		keyRing: keyRing,
+	}
+}
+
+// CheckAuditStore implements store.CheckAuditStore backed by a relational database.
+type CheckAuditStore struct {
+	db      *sqlx.DB
+	keyRing *encrypt.KeyRing
+}
+
+const (
+	checkAuditColumns = `
+		 check_audit_id
+		,check_audit_principal_id
+		,check_audit_timestamp
+		,check_audit_repo_id
+		,check_audit_commit_sha
+		,check_audit_namespace
+		,check_audit_check_uid
+		,check_audit_before
+		,check_audit_after`
+)
+
+type checkAudit struct {
+	ID          int64               `db:"check_audit_id"`
+	PrincipalID int64               `db:"check_audit_principal_id"`
+	Timestamp   int64               `db:"check_audit_timestamp"`
+	RepoID      int64               `db:"check_audit_repo_id"`
+	CommitSHA   string              `db:"check_audit_commit_sha"`
+	Namespace   string              `db:"check_audit_namespace"`
+	Identifier  string              `db:"check_audit_check_uid"`
+	Before      *sqlxtypes.JSONText `db:"check_audit_before"`
+	After       sqlxtypes.JSONText  `db:"check_audit_after"`
+}
+
+type checkAuditWithPayload struct {
+	checkAudit
+	Payload      *sqlxtypes.JSONText `db:"check_audit_payload"`
+	PayloadKeyID string              `db:"check_audit_payload_encryption_key_id"`
+}
+
+// Create creates a new status check audit log entry.
+func (s *CheckAuditStore) Create(ctx context.Context, entry *types.CheckAuditEntry) error {
+	const sqlQuery = `
+	INSERT INTO check_audits (
+		 check_audit_principal_id
+		,check_audit_timestamp
+		,check_audit_repo_id
+		,check_audit_commit_sha
+		,check_audit_namespace
+		,check_audit_check_uid
+		,check_audit_before
+		,check_audit_after
+		,check_audit_payload
+		,check_audit_payload_encryption_key_id
+	) VALUES (
+		 :check_audit_principal_id
+		,:check_audit_timestamp
+		,:check_audit_repo_id
+		,:check_audit_commit_sha
+		,:check_audit_namespace
+		,:check_audit_check_uid
+		,:check_audit_before
+		,:check_audit_after
+		,:check_audit_payload
+		,:check_audit_payload_encryption_key_id
+	)
+	RETURNING check_audit_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	a := &checkAuditWithPayload{checkAudit: *mapInternalCheckAudit(entry)}
+	if entry.Payload != nil {
+		payload := *entry.Payload
+
+		var err error
+		if payload.Data, a.PayloadKeyID, err = encryptCheckData(s.keyRing, payload.Data); err != nil {
+			return err
+		}
+
+		encoded := EncodeToSQLXJSON(payload)
+		a.Payload = &encoded
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, a)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check audit object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert status check audit query failed")
+	}
+
+	return nil
+}
+
+// Find returns the status check audit log entry with the provided ID, including the recorded payload.
+func (s *CheckAuditStore) Find(ctx context.Context, id int64) (*types.CheckAuditEntry, error) {
+	const sqlQuery = `SELECT` + checkAuditColumns + `
+		,check_audit_payload
+		,check_audit_payload_encryption_key_id
+	FROM check_audits
+	WHERE check_audit_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(checkAuditWithPayload)
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find status check audit entry")
+	}
+
+	entry, err := mapCheckAudit(&dst.checkAudit)
+	if err != nil {
+		return nil, err
+	}
+
+	if dst.Payload != nil {
+		entry.Payload = new(types.CheckPayload)
+		if err = dst.Payload.Unmarshal(entry.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal status check audit payload: %w", err)
+		}
+
+		if entry.Payload.Data, err = decryptCheckData(s.keyRing, entry.Payload.Data, dst.PayloadKeyID); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+// ListAuditByPrincipal returns the status check audit log entries of a principal
+// recorded in the provided time range, most recent first.
+func (s *CheckAuditStore) ListAuditByPrincipal(
+	ctx context.Context,
+	principalID int64,
+	from, to time.Time,
+	page, size int,
+) ([]*types.CheckAuditEntry, error) {
+	stmt := database.Builder.
+		Select(checkAuditColumns).
+		From("check_audits").
+		Where("check_audit_principal_id = ?", principalID).
+		Where("check_audit_timestamp >= ?", from.UnixMilli()).
+		Where("check_audit_timestamp < ?", to.UnixMilli()).
+		OrderBy("check_audit_timestamp DESC", "check_audit_id DESC").
+		Limit(database.Limit(size)).
+		Offset(database.Offset(page, size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkAudit, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check audit entries")
+	}
+
+	result := make([]*types.CheckAuditEntry, len(dst))
+	for i, a := range dst {
+		if result[i], err = mapCheckAudit(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ListAuditByRepo returns the status check audit log entries of a repo recorded since the provided time,
+// oldest first.
+func (s *CheckAuditStore) ListAuditByRepo(
+	ctx context.Context,
+	repoID int64,
+	from time.Time,
+	page, size int,
+) ([]*types.CheckAuditEntry, error) {
+	stmt := database.Builder.
+		Select(checkAuditColumns).
+		From("check_audits").
+		Where("check_audit_repo_id = ?", repoID).
+		Where("check_audit_timestamp >= ?", from.UnixMilli()).
+		OrderBy("check_audit_timestamp ASC", "check_audit_id ASC").
+		Limit(database.Limit(size)).
+		Offset(database.Offset(page, size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkAudit, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check audit entries of repo")
+	}
+
+	result := make([]*types.CheckAuditEntry, len(dst))
+	for i, a := range dst {
+		if result[i], err = mapCheckAudit(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ListHistory returns a page of the status check audit log entries of a repo, oldest first.
+// The entries after the cursor of the filter are returned if it's set, otherwise the page of the filter.
+func (s *CheckAuditStore) ListHistory(
+	ctx context.Context,
+	filter types.CheckHistoryFilter,
+) ([]*types.CheckAuditEntry, error) {
+	stmt := checkHistoryStmt(filter).
+		Limit(database.Limit(filter.Size))
+
+	if filter.AfterID == 0 {
+		stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkAudit, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check history")
+	}
+
+	result := make([]*types.CheckAuditEntry, len(dst))
+	for i, a := range dst {
+		if result[i], err = mapCheckAudit(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ExportCSV streams all status check audit log entries of a repo matching the filter
+// to the writer as CSV, oldest first. The entries are written as they're read from the database,
+// so the size of the history doesn't affect the memory usage.
+func (s *CheckAuditStore) ExportCSV(ctx context.Context, w io.Writer, filter types.CheckHistoryFilter) error {
+	sql, args, err := checkHistoryStmt(filter).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to query status check history")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	cw := csv.NewWriter(w)
+
+	if err = cw.Write(checkHistoryCSVHeader); err != nil {
+		return fmt.Errorf("failed to write status check history header: %w", err)
+	}
+
+	for rows.Next() {
+		a := &checkAudit{}
+		if err = rows.StructScan(a); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to scan status check history entry")
+		}
+
+		var entry *types.CheckAuditEntry
+		if entry, err = mapCheckAudit(a); err != nil {
+			return err
+		}
+
+		if err = cw.Write(checkHistoryCSVRecord(entry)); err != nil {
+			return fmt.Errorf("failed to write status check history entry: %w", err)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to read status check history")
+	}
+
+	cw.Flush()
+	if err = cw.Error(); err != nil {
+		return fmt.Errorf("failed to flush status check history: %w", err)
+	}
+
+	return nil
+}
+
+func checkHistoryStmt(filter types.CheckHistoryFilter) squirrel.SelectBuilder {
+	stmt := database.Builder.
+		Select(checkAuditColumns).
+		From("check_audits").
+		Where("check_audit_repo_id = ?", filter.RepoID).
+		OrderBy("check_audit_id ASC")
+
+	if !filter.From.IsZero() {
+		stmt = stmt.Where("check_audit_timestamp >= ?", filter.From.UnixMilli())
+	}
+	if !filter.To.IsZero() {
+		stmt = stmt.Where("check_audit_timestamp < ?", filter.To.UnixMilli())
+	}
+	if filter.AfterID > 0 {
+		stmt = stmt.Where("check_audit_id > ?", filter.AfterID)
+	}
+
+	return stmt
+}
+
+var checkHistoryCSVHeader = []string{
+	"id",
+	"timestamp",
+	"principal_id",
+	"commit_sha",
+	"identifier",
+	"previous_status",
+	"status",
+	"summary",
+	"link",
+	"started",
+	"ended",
+}
+
+func checkHistoryCSVRecord(e *types.CheckAuditEntry) []string {
+	var previousStatus string
+	if e.Before != nil {
+		previousStatus = string(e.Before.Status)
+	}
+
+	return []string{
+		strconv.FormatInt(e.ID, 10),
+		strconv.FormatInt(e.Timestamp, 10),
+		strconv.FormatInt(e.PrincipalID, 10),
+		e.CommitSHA,
+		e.Identifier,
+		previousStatus,
+		string(e.After.Status),
+		e.After.Summary,
+		e.After.Link,
+		strconv.FormatInt(e.After.Started, 10),
+		strconv.FormatInt(e.After.Ended, 10),
+	}
+}
+
+func mapInternalCheckAudit(e *types.CheckAuditEntry) *checkAudit {
+	a := &checkAudit{
+		ID:          e.ID,
+		PrincipalID: e.PrincipalID,
+		Timestamp:   e.Timestamp,
+		RepoID:      e.RepoID,
+		CommitSHA:   e.CommitSHA,
+		Namespace:   checkNamespace(e.Namespace),
+		Identifier:  e.Identifier,
+		After:       EncodeToSQLXJSON(e.After),
+	}
+
+	if e.Before != nil {
+		before := EncodeToSQLXJSON(e.Before)
+		a.Before = &before
+	}
+
+	return a
+}
+
+func mapCheckAudit(a *checkAudit) (*types.CheckAuditEntry, error) {
+	e := &types.CheckAuditEntry{
+		ID:          a.ID,
+		PrincipalID: a.PrincipalID,
+		Timestamp:   a.Timestamp,
+		RepoID:      a.RepoID,
+		CommitSHA:   a.CommitSHA,
+		Namespace:   a.Namespace,
+		Identifier:  a.Identifier,
+	}
+
+	if err := a.After.Unmarshal(&e.After); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status check audit state: %w", err)
+	}
+
+	if a.Before != nil {
+		e.Before = new(types.CheckAuditState)
+		if err := a.Before.Unmarshal(e.Before); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal previous status check audit state: %w", err)
+		}
+	}
+
+	return e, nil
+}
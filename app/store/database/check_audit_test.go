@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCheckAuditStore_ListHistory(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	auditStore := database.NewCheckAuditStore(db, nil)
+
+	now := time.Now()
+	for i := range 5 {
+		err := auditStore.Create(ctx, &types.CheckAuditEntry{
+			PrincipalID: userID,
+			Timestamp:   now.Add(time.Duration(i-5) * time.Minute).UnixMilli(),
+			RepoID:      repoID,
+			CommitSHA:   testCommitSHA,
+			Identifier:  fmt.Sprintf("check-%d", i),
+			After:       types.CheckAuditState{Status: enum.CheckStatusSuccess, Summary: "done, ok"},
+		})
+		if err != nil {
+			t.Fatalf("failed to create audit entry: %v", err)
+		}
+	}
+
+	filter := types.CheckHistoryFilter{
+		Pagination: types.Pagination{Size: 2},
+		RepoID:     repoID,
+	}
+
+	var identifiers []string
+	for {
+		entries, err := auditStore.ListHistory(ctx, filter)
+		if err != nil {
+			t.Fatalf("ListHistory() error = %v", err)
+		}
+
+		for _, entry := range entries {
+			identifiers = append(identifiers, entry.Identifier)
+		}
+
+		if len(entries) < filter.Size {
+			break
+		}
+
+		filter.AfterID = entries[len(entries)-1].ID
+	}
+
+	want := "[check-0 check-1 check-2 check-3 check-4]"
+	if got := fmt.Sprint(identifiers); got != want {
+		t.Errorf("ListHistory() pages = %s, want %s", got, want)
+	}
+
+	buf := &bytes.Buffer{}
+	err := auditStore.ExportCSV(ctx, buf, types.CheckHistoryFilter{
+		RepoID: repoID,
+		From:   now.Add(-3 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("ExportCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read exported csv: %v", err)
+	}
+
+	if len(records) != 4 {
+		t.Fatalf("ExportCSV() wrote %d records, want header and 3 entries", len(records))
+	}
+
+	if records[1][4] != "check-2" || records[1][7] != "done, ok" {
+		t.Errorf("ExportCSV() first entry = %v", records[1])
+	}
+}
+
+func TestCheckStore_FindAt(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	auditStore := database.NewCheckAuditStore(db, nil)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusFailure)
+
+	now := time.Now()
+	for i, status := range []enum.CheckStatus{
+		enum.CheckStatusPending,
+		enum.CheckStatusRunning,
+		enum.CheckStatusFailure,
+	} {
+		err := auditStore.Create(ctx, &types.CheckAuditEntry{
+			PrincipalID: userID,
+			Timestamp:   now.Add(time.Duration(i-3) * time.Hour).UnixMilli(),
+			RepoID:      repoID,
+			CommitSHA:   testCommitSHA,
+			Identifier:  "build",
+			After:       types.CheckAuditState{Status: status},
+		})
+		if err != nil {
+			t.Fatalf("failed to create audit entry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want enum.CheckStatus
+	}{
+		{name: "first report", at: now.Add(-3 * time.Hour), want: enum.CheckStatusPending},
+		{name: "between reports", at: now.Add(-90 * time.Minute), want: enum.CheckStatusRunning},
+		{name: "now", at: now, want: enum.CheckStatusFailure},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := checkStore.FindAt(ctx, repoID, testCommitSHA, "", "build", test.at)
+			if err != nil {
+				t.Fatalf("FindAt() error = %v", err)
+			}
+
+			if c.Status != test.want {
+				t.Errorf("FindAt() status = %s, want %s", c.Status, test.want)
+			}
+		})
+	}
+
+	_, err := checkStore.FindAt(ctx, repoID, testCommitSHA, "", "build", now.Add(-4*time.Hour))
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("FindAt() before the first report error = %v, want not found", err)
+	}
+
+	// the history of a status check with the same identifier in another namespace is separate.
+	namespaced := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	namespaced.Namespace = "ci"
+	if err = checkStore.Upsert(ctx, namespaced); err != nil {
+		t.Fatalf("failed to upsert namespaced check: %v", err)
+	}
+
+	err = auditStore.Create(ctx, &types.CheckAuditEntry{
+		PrincipalID: userID,
+		Timestamp:   now.UnixMilli(),
+		RepoID:      repoID,
+		CommitSHA:   testCommitSHA,
+		Namespace:   "ci",
+		Identifier:  "build",
+		After:       types.CheckAuditState{Status: enum.CheckStatusSuccess},
+	})
+	if err != nil {
+		t.Fatalf("failed to create audit entry: %v", err)
+	}
+
+	c, err := checkStore.FindAt(ctx, repoID, testCommitSHA, "", "build", now)
+	if err != nil {
+		t.Fatalf("FindAt() error = %v", err)
+	}
+	if c.Status != enum.CheckStatusFailure || c.ID == namespaced.ID {
+		t.Errorf("FindAt() in the default namespace = %s (id %d), want the default namespace check", c.Status, c.ID)
+	}
+
+	c, err = checkStore.FindAt(ctx, repoID, testCommitSHA, "ci", "build", now)
+	if err != nil {
+		t.Fatalf("FindAt() in namespace error = %v", err)
+	}
+	if c.Status != enum.CheckStatusSuccess || c.ID != namespaced.ID {
+		t.Errorf("FindAt() in namespace = %s (id %d), want the namespaced check", c.Status, c.ID)
+	}
+}
+
+func TestCheckAuditStore_FindPayload(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	keyRing, err := encrypt.ParseKeyRing([]string{"k1:01234567890123456789012345678901"}, "k1")
+	if err != nil {
+		t.Fatalf("failed to create key ring: %v", err)
+	}
+
+	auditStore := database.NewCheckAuditStore(db, keyRing)
+
+	payload := types.CheckPayload{
+		Kind:    enum.CheckPayloadKindRaw,
+		Version: "1",
+		Data:    []byte(`{"tests":{"passed":3}}`),
+	}
+
+	withPayload := &types.CheckAuditEntry{
+		PrincipalID: userID,
+		Timestamp:   time.Now().UnixMilli(),
+		RepoID:      repoID,
+		CommitSHA:   testCommitSHA,
+		Identifier:  "build",
+		After:       types.CheckAuditState{Status: enum.CheckStatusSuccess},
+		Payload:     &payload,
+	}
+	if err = auditStore.Create(ctx, withPayload); err != nil {
+		t.Fatalf("failed to create audit entry: %v", err)
+	}
+
+	if string(payload.Data) != `{"tests":{"passed":3}}` {
+		t.Errorf("Create() modified the payload of the entry: %s", payload.Data)
+	}
+
+	var stored string
+	err = db.QueryRowContext(ctx,
+		`SELECT check_audit_payload FROM check_audits WHERE check_audit_id = $1`, withPayload.ID).Scan(&stored)
+	if err != nil {
+		t.Fatalf("failed to read stored payload: %v", err)
+	}
+	if bytes.Contains([]byte(stored), []byte("passed")) {
+		t.Errorf("stored payload isn't encrypted: %s", stored)
+	}
+
+	entry, err := auditStore.Find(ctx, withPayload.ID)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if entry.Payload == nil || string(entry.Payload.Data) != `{"tests":{"passed":3}}` {
+		t.Errorf("Find() payload = %+v, want the decrypted payload", entry.Payload)
+	}
+
+	withoutPayload := &types.CheckAuditEntry{
+		PrincipalID: userID,
+		Timestamp:   time.Now().UnixMilli(),
+		RepoID:      repoID,
+		CommitSHA:   testCommitSHA,
+		Identifier:  "build",
+		After:       types.CheckAuditState{Status: enum.CheckStatusFailure},
+	}
+	if err = auditStore.Create(ctx, withoutPayload); err != nil {
+		t.Fatalf("failed to create audit entry: %v", err)
+	}
+
+	entry, err = auditStore.Find(ctx, withoutPayload.ID)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if entry.Payload != nil {
+		t.Errorf("Find() payload = %+v, want none", entry.Payload)
+	}
+
+	_, err = auditStore.Find(ctx, withoutPayload.ID+1)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Find() of a missing entry error = %v, want not found", err)
+	}
+}
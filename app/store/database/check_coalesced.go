@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var _ store.CheckStore = (*CoalescedCheckStore)(nil)
+
+// NewCoalescedCheckStore returns a new CoalescedCheckStore.
+func NewCoalescedCheckStore(checkStore store.CheckStore) *CoalescedCheckStore {
+	return &CoalescedCheckStore{
+		CheckStore: checkStore,
+	}
+}
+
+// CoalescedCheckStore deduplicates concurrent identical status check list queries:
+// while a query is running, callers requesting the same list wait for it and share its result.
+// This keeps page loads of busy pull requests from running the same query many times at once.
+type CoalescedCheckStore struct {
+	store.CheckStore
+	list singleflight.Group
+}
+
+// List returns a list of status check results for a specific commit in a repo.
+// Concurrent calls with the same arguments are served by a single database query.
+func (s *CoalescedCheckStore) List(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	opts types.CheckListOptions,
+) ([]types.Check, error) {
+	// queries running in a transaction might see uncommitted changes, so they can't be shared.
+	if dbtx.GetTransaction(ctx) != nil {
+		return s.CheckStore.List(ctx, repoID, commitSHA, opts)
+	}
+
+	key := fmt.Sprintf("%d:%s:%+v", repoID, commitSHA, opts)
+
+	// the query isn't canceled if only the caller that started it goes away, other callers might be waiting for it.
+	ch := s.list.DoChan(key, func() (any, error) {
+		return s.CheckStore.List(context.WithoutCancel(ctx), repoID, commitSHA, opts)
+	})
+
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-ch:
+	}
+
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	// every caller gets its own copy, as the callers are free to modify the returned status checks.
+	checks, _ := res.Val.([]types.Check)
+
+	return slices.Clone(checks), nil
+}
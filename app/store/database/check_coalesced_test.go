@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+type blockingCheckStore struct {
+	store.CheckStore
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (s *blockingCheckStore) List(context.Context, int64, string, types.CheckListOptions) ([]types.Check, error) {
+	s.calls.Add(1)
+	<-s.release
+	return []types.Check{{Identifier: "build"}}, nil
+}
+
+func TestCoalescedCheckStore_List(t *testing.T) {
+	const callers = 100
+
+	ctx := context.Background()
+	inner := &blockingCheckStore{release: make(chan struct{})}
+	checkStore := database.NewCoalescedCheckStore(inner)
+
+	results := make([][]types.Check, callers)
+
+	wg := sync.WaitGroup{}
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks, err := checkStore.List(ctx, 1, testCommitSHA, types.CheckListOptions{})
+			if err != nil {
+				t.Errorf("List() error = %v", err)
+				return
+			}
+			results[i] = checks
+		}()
+	}
+
+	// give all callers the time to join the running query.
+	time.Sleep(100 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("%d concurrent List() calls ran %d queries, want 1", callers, calls)
+	}
+
+	results[0][0].Identifier = "changed"
+	if results[1][0].Identifier != "build" {
+		t.Errorf("callers must not share the returned status checks")
+	}
+
+	// a list query that isn't concurrent with another runs again.
+	if _, err := checkStore.List(ctx, 1, testCommitSHA, types.CheckListOptions{}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if calls := inner.calls.Load(); calls != 2 {
+		t.Errorf("sequential List() calls ran %d queries, want 2", calls)
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.CheckConfigStore = (*CheckConfigStore)(nil)
+
+// NewCheckConfigStore returns a new CheckConfigStore.
+func NewCheckConfigStore(db *sqlx.DB) *CheckConfigStore {
+	return &CheckConfigStore{
+		db: db,
+	}
+}
+
+// CheckConfigStore implements store.CheckConfigStore backed by a relational database.
+type CheckConfigStore struct {
+	db *sqlx.DB
+}
+
+const (
+	checkConfigColumns = `
+		 check_config_id
+		,check_config_created_by
+		,check_config_created
+		,check_config_updated
+		,check_config_repo_id
+		,check_config_uid
+		,check_config_retry_policy
+		,check_config_sla
+		,check_config_url_template
+		,check_config_required`
+
+	checkConfigSelectBase = `
+	SELECT` + checkConfigColumns + `
+	FROM check_configs`
+)
+
+type checkConfig struct {
+	ID          int64               `db:"check_config_id"`
+	CreatedBy   int64               `db:"check_config_created_by"`
+	Created     int64               `db:"check_config_created"`
+	Updated     int64               `db:"check_config_updated"`
+	RepoID      int64               `db:"check_config_repo_id"`
+	Identifier  string              `db:"check_config_uid"`
+	RetryPolicy sqlxtypes.JSONText  `db:"check_config_retry_policy"`
+	SLA         *sqlxtypes.JSONText `db:"check_config_sla"`
+	URLTemplate string              `db:"check_config_url_template"`
+	Required    null.Bool           `db:"check_config_required"`
+}
+
+// Find returns the configuration of a status check in a repo.
+func (s *CheckConfigStore) Find(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.CheckConfig, error) {
+	const sqlQuery = checkConfigSelectBase + `
+	WHERE check_config_repo_id = $1 AND check_config_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &checkConfig{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find status check config")
+	}
+
+	return mapCheckConfig(dst)
+}
+
+// Upsert creates new or updates an existing status check configuration.
+func (s *CheckConfigStore) Upsert(ctx context.Context, config *types.CheckConfig) error {
+	const sqlQuery = `
+	INSERT INTO check_configs (
+		 check_config_created_by
+		,check_config_created
+		,check_config_updated
+		,check_config_repo_id
+		,check_config_uid
+		,check_config_retry_policy
+		,check_config_sla
+		,check_config_url_template
+		,check_config_required
+	) VALUES (
+		 :check_config_created_by
+		,:check_config_created
+		,:check_config_updated
+		,:check_config_repo_id
+		,:check_config_uid
+		,:check_config_retry_policy
+		,:check_config_sla
+		,:check_config_url_template
+		,:check_config_required
+	)
+	ON CONFLICT (check_config_repo_id, check_config_uid) DO
+	UPDATE SET
+		 check_config_updated = :check_config_updated
+		,check_config_retry_policy = :check_config_retry_policy
+		,check_config_sla = :check_config_sla
+		,check_config_url_template = :check_config_url_template
+		,check_config_required = :check_config_required
+	RETURNING check_config_id, check_config_created_by, check_config_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalCheckConfig(config))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check config object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&config.ID, &config.CreatedBy, &config.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert status check config query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the configuration of a status check in a repo.
+func (s *CheckConfigStore) Delete(ctx context.Context, repoID int64, identifier string) error {
+	const sqlQuery = `
+	DELETE FROM check_configs
+	WHERE check_config_repo_id = $1 AND check_config_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, identifier); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete status check config")
+	}
+
+	return nil
+}
+
+// List returns all status check configurations of a repo.
+func (s *CheckConfigStore) List(ctx context.Context, repoID int64) ([]*types.CheckConfig, error) {
+	const sqlQuery = checkConfigSelectBase + `
+	WHERE check_config_repo_id = $1
+	ORDER BY check_config_uid`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkConfig, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check configs")
+	}
+
+	return mapCheckConfigs(dst)
+}
+
+// ListRetryable returns all status check configurations that allow automatic retries.
+func (s *CheckConfigStore) ListRetryable(ctx context.Context) ([]*types.CheckConfig, error) {
+	sqlQuery := checkConfigSelectBase + `
+	WHERE (check_config_retry_policy->>'max_attempts')::int > 1
+		OR (check_config_retry_policy->>'timeout_seconds')::int > 0`
+	if s.db.DriverName() == SqliteDriverName {
+		sqlQuery = checkConfigSelectBase + `
+	WHERE json_extract(check_config_retry_policy, '$.max_attempts') > 1
+		OR json_extract(check_config_retry_policy, '$.timeout_seconds') > 0`
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*checkConfig, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list retryable status check configs")
+	}
+
+	return mapCheckConfigs(dst)
+}
+
+func mapInternalCheckConfig(c *types.CheckConfig) *checkConfig {
+	config := &checkConfig{
+		ID:          c.ID,
+		CreatedBy:   c.CreatedBy,
+		Created:     c.Created,
+		Updated:     c.Updated,
+		RepoID:      c.RepoID,
+		Identifier:  c.Identifier,
+		RetryPolicy: EncodeToSQLXJSON(c.RetryPolicy),
+		URLTemplate: c.URLTemplate,
+		Required:    null.BoolFromPtr(c.Required),
+	}
+
+	if c.SLA != nil {
+		sla := EncodeToSQLXJSON(c.SLA)
+		config.SLA = &sla
+	}
+
+	return config
+}
+
+func mapCheckConfig(c *checkConfig) (*types.CheckConfig, error) {
+	var retryPolicy types.RetryPolicy
+	if err := c.RetryPolicy.Unmarshal(&retryPolicy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal status check retry policy: %w", err)
+	}
+
+	config := &types.CheckConfig{
+		ID:          c.ID,
+		CreatedBy:   c.CreatedBy,
+		Created:     c.Created,
+		Updated:     c.Updated,
+		RepoID:      c.RepoID,
+		Identifier:  c.Identifier,
+		RetryPolicy: retryPolicy,
+		URLTemplate: c.URLTemplate,
+		Required:    c.Required.Ptr(),
+	}
+
+	if c.SLA != nil {
+		config.SLA = new(types.CheckSLA)
+		if err := c.SLA.Unmarshal(config.SLA); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal status check SLA: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+func mapCheckConfigs(configs []*checkConfig) ([]*types.CheckConfig, error) {
+	result := make([]*types.CheckConfig, len(configs))
+	for i, c := range configs {
+		var err error
+		if result[i], err = mapCheckConfig(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
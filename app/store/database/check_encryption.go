@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/store/database"
+)
+
+// encryptedCheckData is the stored form of encrypted status check payloads and metadata.
+// It's a JSON object, so that the encrypted data is still a valid value of the JSON columns.
+// The ID of the key is stored in the encryption key ID column that accompanies the data,
+// the stored data itself is never inspected to find out whether it's encrypted.
+type encryptedCheckData struct {
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// activeEncryptionKeyID returns the ID of the key used to encrypt status check data,
+// or an empty string if the encryption is disabled.
+func (s *CheckStore) activeEncryptionKeyID() string {
+	return activeEncryptionKeyID(s.keyRing)
+}
+
+// encryptCheckData encrypts the status check data with the active key, if the encryption is enabled.
+func (s *CheckStore) encryptCheckData(data json.RawMessage) (json.RawMessage, string, error) {
+	return encryptCheckData(s.keyRing, data)
+}
+
+// decryptCheckData reverses encryptCheckData.
+func (s *CheckStore) decryptCheckData(data json.RawMessage, keyID string) (json.RawMessage, error) {
+	return decryptCheckData(s.keyRing, data, keyID)
+}
+
+func activeEncryptionKeyID(keyRing *encrypt.KeyRing) string {
+	if keyRing == nil {
+		return ""
+	}
+
+	return keyRing.ActiveKeyID()
+}
+
+// encryptCheckData encrypts the status check data with the active key of the key ring
+// and returns the encrypted data with the ID of the key, which has to be stored with it.
+// A nil key ring or one without an active key disables the encryption, the key ID is then empty.
+func encryptCheckData(keyRing *encrypt.KeyRing, data json.RawMessage) (json.RawMessage, string, error) {
+	if len(data) == 0 || activeEncryptionKeyID(keyRing) == "" {
+		return data, "", nil
+	}
+
+	keyID, ciphertext, err := keyRing.Encrypt(string(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt status check data: %w", err)
+	}
+
+	encrypted, err := json.Marshal(encryptedCheckData{
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode encrypted status check data: %w", err)
+	}
+
+	return encrypted, keyID, nil
+}
+
+// decryptCheckData reverses encryptCheckData. Data stored without a key ID isn't encrypted
+// and is returned unchanged, whatever it looks like.
+func decryptCheckData(keyRing *encrypt.KeyRing, data json.RawMessage, keyID string) (json.RawMessage, error) {
+	if keyID == "" {
+		return data, nil
+	}
+
+	if keyRing == nil {
+		return nil, errors.New("status check data is encrypted, but no encryption keys are configured")
+	}
+
+	encrypted := encryptedCheckData{}
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, fmt.Errorf("failed to decode encrypted status check data: %w", err)
+	}
+
+	plaintext, err := keyRing.Decrypt(keyID, encrypted.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt status check data: %w", err)
+	}
+
+	return json.RawMessage(plaintext), nil
+}
+
+// RotateEncryptionKey re-encrypts with the active key up to batchSize status check payloads and metadata
+// that are encrypted with a different key or not encrypted at all. If the encryption is disabled,
+// the encrypted payloads and metadata are decrypted instead.
+// It returns the number of processed status checks.
+func (s *CheckStore) RotateEncryptionKey(ctx context.Context, batchSize int) (int, error) {
+	activeKeyID := s.activeEncryptionKeyID()
+
+	stmt := database.Builder.
+		Select(`check_id, check_updated, check_payload, check_payload_encryption_key_id,
+			check_metadata, check_metadata_encryption_key_id`).
+		From("checks").
+		Where("(check_payload_encryption_key_id <> ? OR check_metadata_encryption_key_id <> ?)",
+			activeKeyID, activeKeyID).
+		OrderBy("check_id").
+		Limit(uint64(batchSize)) //nolint:gosec
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := s.getAccessor(ctx)
+
+	dst := make([]*check, 0, batchSize)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to select status checks for encryption key rotation")
+	}
+
+	// status checks updated in the meantime are already stored with the active key.
+	const sqlUpdate = `
+	UPDATE checks
+	SET
+		 check_payload = $1
+		,check_payload_encryption_key_id = $2
+		,check_metadata = $3
+		,check_metadata_encryption_key_id = $4
+	WHERE check_id = $5 AND check_updated = $6`
+
+	for _, c := range dst {
+		payload, payloadKeyID, err := s.reencryptCheckData(c.Payload, c.PayloadKeyID)
+		if err != nil {
+			return 0, err
+		}
+
+		metadata, metadataKeyID, err := s.reencryptCheckData(c.Metadata, c.MetadataKeyID)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = db.ExecContext(ctx, sqlUpdate, payload, payloadKeyID, metadata, metadataKeyID, c.ID, c.Updated)
+		if err != nil {
+			return 0, database.ProcessSQLErrorf(ctx, err, "Failed to store re-encrypted status check data")
+		}
+	}
+
+	return len(dst), nil
+}
+
+func (s *CheckStore) reencryptCheckData(data json.RawMessage, keyID string) (json.RawMessage, string, error) {
+	data, err := s.decryptCheckData(data, keyID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.encryptCheckData(data)
+}
@@ -0,0 +1,460 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.CheckStore = (*EventSourcedCheckStore)(nil)
+
+// EventSourcedCheckStoreMinMigrationVersion is the oldest database migration version containing
+// all tables used by the EventSourcedCheckStore.
+const EventSourcedCheckStoreMinMigrationVersion = "0096_create_table_check_events"
+
+// NewEventSourcedCheckStore returns a new EventSourcedCheckStore.
+func NewEventSourcedCheckStore(db *sqlx.DB, checkStore *CheckStore) *EventSourcedCheckStore {
+	return &EventSourcedCheckStore{
+		CheckStore: checkStore,
+		db:         db,
+	}
+}
+
+// EventSourcedCheckStore records every change of a status check as an immutable event in the check_events table.
+// The checks table is maintained as the materialized current state and serves all reads,
+// while Materialize rebuilds the state of a repository from the event log alone.
+// The store doesn't start transactions, so writes should be done in a transaction
+// to keep the event log consistent with the materialized state.
+type EventSourcedCheckStore struct {
+	*CheckStore
+	db *sqlx.DB
+}
+
+const checkEventColumns = `
+	 check_event_id
+	,check_event_repo_id
+	,check_event_type
+	,check_event_payload
+	,check_event_timestamp`
+
+type checkEvent struct {
+	ID        int64               `db:"check_event_id"`
+	RepoID    int64               `db:"check_event_repo_id"`
+	Type      enum.CheckEventType `db:"check_event_type"`
+	Payload   sqlxtypes.JSONText  `db:"check_event_payload"`
+	Timestamp int64               `db:"check_event_timestamp"`
+}
+
+type checkEventSnapshot struct {
+	RepoID  int64              `db:"check_event_snapshot_repo_id"`
+	EventID int64              `db:"check_event_snapshot_event_id"`
+	State   sqlxtypes.JSONText `db:"check_event_snapshot_state"`
+	Created int64              `db:"check_event_snapshot_created"`
+}
+
+// Upsert creates new or updates an existing status check result and records the change in the event log.
+func (s *EventSourcedCheckStore) Upsert(ctx context.Context, check *types.Check) error {
+	if err := s.CheckStore.Upsert(ctx, check); err != nil {
+		return err
+	}
+
+	if check.Deduplicated {
+		return nil
+	}
+
+	return s.recordByKey(ctx, enum.CheckEventTypeReported,
+		check.RepoID, check.CommitSHA, check.Namespace, check.Identifier)
+}
+
+// UpsertWithCAS creates new or updates an existing status check result if the existing status check
+// has the expected status and records the change in the event log.
+func (s *EventSourcedCheckStore) UpsertWithCAS(
+	ctx context.Context,
+	check *types.Check,
+	expectedStatus enum.CheckStatus,
+) (bool, error) {
+	ok, err := s.CheckStore.UpsertWithCAS(ctx, check, expectedStatus)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	return true, s.recordByKey(ctx, enum.CheckEventTypeReported,
+		check.RepoID, check.CommitSHA, check.Namespace, check.Identifier)
+}
+
+// Patch updates only the status check fields that are set in the patch and records the change in the event log.
+func (s *EventSourcedCheckStore) Patch(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	identifier string,
+	patch types.CheckPatch,
+) error {
+	if err := s.CheckStore.Patch(ctx, repoID, commitSHA, identifier, patch); err != nil {
+		return err
+	}
+
+	return s.recordByKey(ctx, enum.CheckEventTypePatched, repoID, commitSHA, types.CheckNamespaceDefault, identifier)
+}
+
+// UpsertBatch creates new or updates existing status check results and records the changes in the event log.
+// Status checks left unchanged by the conflict strategy are recorded too, because their state is unchanged.
+func (s *EventSourcedCheckStore) UpsertBatch(
+	ctx context.Context,
+	checks []*types.Check,
+	strategy enum.ConflictStrategy,
+) error {
+	if err := s.CheckStore.UpsertBatch(ctx, checks, strategy); err != nil {
+		return err
+	}
+
+	for _, c := range checks {
+		err := s.recordByKey(ctx, enum.CheckEventTypeReported, c.RepoID, c.CommitSHA, c.Namespace, c.Identifier)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IncrementRetryCount increments the number of automatic retries of a status check
+// and records the change in the event log.
+func (s *EventSourcedCheckStore) IncrementRetryCount(ctx context.Context, checkID int64) error {
+	if err := s.CheckStore.IncrementRetryCount(ctx, checkID); err != nil {
+		return err
+	}
+
+	const sqlQuery = checkSelectBase + `
+		WHERE check_id = $1`
+
+	dst := new(check)
+	if err := s.getAccessor(ctx).GetContext(ctx, dst, sqlQuery, checkID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to find check")
+	}
+
+	return s.record(ctx, enum.CheckEventTypeRetryIncremented, dst)
+}
+
+// MoveBySHA moves all status checks of a commit to another commit and records the changes in the event log.
+// Status checks that the other commit already had are recorded too, because their state is unchanged.
+func (s *EventSourcedCheckStore) MoveBySHA(ctx context.Context, repoID int64, oldSHA, newSHA string) (int, error) {
+	moved, err := s.CheckStore.MoveBySHA(ctx, repoID, oldSHA, newSHA)
+	if err != nil || moved == 0 {
+		return moved, err
+	}
+
+	const sqlQuery = checkSelectBase + `
+		WHERE check_repo_id = $1 AND check_commit_sha = $2`
+
+	dst := make([]*check, 0, moved)
+	if err = s.getAccessor(ctx).SelectContext(ctx, &dst, sqlQuery, repoID, newSHA); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to list moved checks")
+	}
+
+	for _, c := range dst {
+		if err = s.record(ctx, enum.CheckEventTypeMoved, c); err != nil {
+			return 0, err
+		}
+	}
+
+	return moved, nil
+}
+
+// DeleteByIDs deletes the status checks with the provided IDs and records their deletion in the event log.
+func (s *EventSourcedCheckStore) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	stmt := database.Builder.
+		Select(checkColumns).
+		From("checks").
+		Where(squirrel.Eq{"check_id": ids})
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]*check, 0, len(ids))
+	if err = s.getAccessor(ctx).SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to list deleted checks")
+	}
+
+	n, err := s.CheckStore.DeleteByIDs(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range dst {
+		if err = s.record(ctx, enum.CheckEventTypeDeleted, c); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// DeleteByRepo deletes all status checks of a repo together with its event log,
+// because the materialized state of the repo is empty.
+func (s *EventSourcedCheckStore) DeleteByRepo(ctx context.Context, repoID int64) (int64, error) {
+	n, err := s.CheckStore.DeleteByRepo(ctx, repoID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = s.deleteLog(ctx, "= $1", repoID); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// DeleteOrphans deletes up to batchSize status check results whose repository doesn't exist anymore
+// together with the event logs of such repositories. The deletions can't be recorded as events,
+// because the event log references the repository.
+func (s *EventSourcedCheckStore) DeleteOrphans(ctx context.Context, batchSize int) (int64, error) {
+	n, err := s.CheckStore.DeleteOrphans(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = s.deleteLog(ctx, "NOT IN (SELECT repo_id FROM repositories)"); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// deleteLog deletes the events and snapshots of the repositories matching the condition.
+func (s *EventSourcedCheckStore) deleteLog(ctx context.Context, repoCondition string, args ...any) error {
+	db := s.getAccessor(ctx)
+
+	_, err := db.ExecContext(ctx, `DELETE FROM check_events WHERE check_event_repo_id `+repoCondition, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete status check events")
+	}
+
+	_, err = db.ExecContext(ctx,
+		`DELETE FROM check_event_snapshots WHERE check_event_snapshot_repo_id `+repoCondition, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete status check snapshots")
+	}
+
+	return nil
+}
+
+// recordByKey appends the current state of the status check to the event log.
+func (s *EventSourcedCheckStore) recordByKey(
+	ctx context.Context,
+	eventType enum.CheckEventType,
+	repoID int64,
+	commitSHA string,
+	namespace string,
+	identifier string,
+) error {
+	const sqlQuery = checkSelectBase + `
+		WHERE check_repo_id = $1 AND check_uid = $2 AND check_commit_sha = $3 AND check_namespace = $4`
+
+	dst := new(check)
+	err := s.getAccessor(ctx).GetContext(ctx, dst, sqlQuery, repoID, identifier, commitSHA, checkNamespace(namespace))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to find check")
+	}
+
+	return s.record(ctx, eventType, dst)
+}
+
+func (s *EventSourcedCheckStore) record(ctx context.Context, eventType enum.CheckEventType, c *check) error {
+	const sqlQuery = `
+	INSERT INTO check_events (
+		 check_event_repo_id
+		,check_event_type
+		,check_event_payload
+		,check_event_timestamp
+	) VALUES (
+		 :check_event_repo_id
+		,:check_event_type
+		,:check_event_payload
+		,:check_event_timestamp
+	)`
+
+	event := &checkEvent{
+		RepoID:    c.RepoID,
+		Type:      eventType,
+		Payload:   EncodeToSQLXJSON(c),
+		Timestamp: time.Now().UnixMilli(),
+	}
+
+	query, arg, err := s.db.BindNamed(sqlQuery, event)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check event object")
+	}
+
+	if _, err = s.getAccessor(ctx).ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert status check event")
+	}
+
+	return nil
+}
+
+// Materialize returns the current state of all status checks of the repository,
+// rebuilt from the latest snapshot and the events recorded after it.
+func (s *EventSourcedCheckStore) Materialize(ctx context.Context, repoID int64) ([]types.Check, error) {
+	state, _, err := s.materialize(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Check, len(state))
+	for i, c := range state {
+		if result[i], err = s.mapCheck(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Snapshot persists the materialized state of the status checks of the repository,
+// so that following materializations only need to replay the events recorded after it.
+func (s *EventSourcedCheckStore) Snapshot(ctx context.Context, repoID int64) error {
+	state, lastEventID, err := s.materialize(ctx, repoID)
+	if err != nil {
+		return err
+	}
+
+	const sqlQuery = `
+	INSERT INTO check_event_snapshots (
+		 check_event_snapshot_repo_id
+		,check_event_snapshot_event_id
+		,check_event_snapshot_state
+		,check_event_snapshot_created
+	) VALUES (
+		 :check_event_snapshot_repo_id
+		,:check_event_snapshot_event_id
+		,:check_event_snapshot_state
+		,:check_event_snapshot_created
+	)
+	ON CONFLICT (check_event_snapshot_repo_id) DO
+	UPDATE SET
+		 check_event_snapshot_event_id = :check_event_snapshot_event_id
+		,check_event_snapshot_state = :check_event_snapshot_state
+		,check_event_snapshot_created = :check_event_snapshot_created`
+
+	snapshot := &checkEventSnapshot{
+		RepoID:  repoID,
+		EventID: lastEventID,
+		State:   EncodeToSQLXJSON(state),
+		Created: time.Now().UnixMilli(),
+	}
+
+	query, arg, err := s.db.BindNamed(sqlQuery, snapshot)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind status check snapshot object")
+	}
+
+	if _, err = s.getAccessor(ctx).ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert status check snapshot")
+	}
+
+	return nil
+}
+
+// materialize replays the events of the repository recorded after the latest snapshot.
+// It returns the status checks ordered by ID and the ID of the last applied event.
+func (s *EventSourcedCheckStore) materialize(ctx context.Context, repoID int64) ([]*check, int64, error) {
+	db := s.getAccessor(ctx)
+
+	const snapshotQuery = `
+	SELECT
+		 check_event_snapshot_repo_id
+		,check_event_snapshot_event_id
+		,check_event_snapshot_state
+		,check_event_snapshot_created
+	FROM check_event_snapshots
+	WHERE check_event_snapshot_repo_id = $1`
+
+	var state []*check
+	var lastEventID int64
+
+	snapshot := new(checkEventSnapshot)
+	err := db.GetContext(ctx, snapshot, snapshotQuery, repoID)
+	switch {
+	case err == nil:
+		if err = snapshot.State.Unmarshal(&state); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal status check snapshot: %w", err)
+		}
+		lastEventID = snapshot.EventID
+	case errors.Is(err, sql.ErrNoRows):
+	default:
+		return nil, 0, database.ProcessSQLErrorf(ctx, err, "Failed to find status check snapshot")
+	}
+
+	checks := make(map[int64]*check, len(state))
+	for _, c := range state {
+		checks[c.ID] = c
+	}
+
+	const eventsQuery = `
+	SELECT` + checkEventColumns + `
+	FROM check_events
+	WHERE check_event_repo_id = $1 AND check_event_id > $2
+	ORDER BY check_event_id`
+
+	events := make([]*checkEvent, 0)
+	if err = db.SelectContext(ctx, &events, eventsQuery, repoID, lastEventID); err != nil {
+		return nil, 0, database.ProcessSQLErrorf(ctx, err, "Failed to list status check events")
+	}
+
+	for _, event := range events {
+		c := new(check)
+		if err = event.Payload.Unmarshal(c); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal status check event %d: %w", event.ID, err)
+		}
+
+		if event.Type == enum.CheckEventTypeDeleted {
+			delete(checks, c.ID)
+		} else {
+			checks[c.ID] = c
+		}
+		lastEventID = event.ID
+	}
+
+	state = make([]*check, 0, len(checks))
+	for _, c := range checks {
+		state = append(state, c)
+	}
+
+	slices.SortFunc(state, func(a, b *check) int { return cmp.Compare(a.ID, b.ID) })
+
+	return state, lastEventID, nil
+}
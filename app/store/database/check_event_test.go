@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestEventSourcedCheckStore_DeleteByRepo(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+	eventStore := database.NewEventSourcedCheckStore(db, checkStore)
+
+	if err := eventStore.Upsert(ctx, newCheck(repoID, "build", enum.CheckStatusSuccess)); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	if err := eventStore.Snapshot(ctx, repoID); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	if _, err := eventStore.DeleteByRepo(ctx, repoID); err != nil {
+		t.Fatalf("DeleteByRepo() error = %v", err)
+	}
+
+	materialized, err := eventStore.Materialize(ctx, repoID)
+	if err != nil {
+		t.Fatalf("Materialize() error = %v", err)
+	}
+
+	if len(materialized) != 0 {
+		t.Errorf("Materialize() = %+v, want no status checks", materialized)
+	}
+}
+
+func TestEventSourcedCheckStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+	eventStore := database.NewEventSourcedCheckStore(db, checkStore)
+
+	build := newCheck(repoID, "build", enum.CheckStatusRunning)
+	if err := eventStore.Upsert(ctx, build); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	status := enum.CheckStatusFailure
+	if err := eventStore.Patch(ctx, repoID, testCommitSHA, "build", types.CheckPatch{Status: &status}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	if err := eventStore.IncrementRetryCount(ctx, build.ID); err != nil {
+		t.Fatalf("IncrementRetryCount() error = %v", err)
+	}
+
+	err := eventStore.UpsertBatch(ctx, []*types.Check{
+		newCheck(repoID, "lint", enum.CheckStatusSuccess),
+		newCheck(repoID, "build", enum.CheckStatusPending),
+	}, enum.ConflictStrategyTerminalWins)
+	if err != nil {
+		t.Fatalf("UpsertBatch() error = %v", err)
+	}
+
+	verify := func(step string) {
+		t.Helper()
+
+		stored, err := eventStore.List(ctx, repoID, testCommitSHA, types.CheckListOptions{})
+		if err != nil {
+			t.Fatalf("%s: List() error = %v", step, err)
+		}
+
+		materialized, err := eventStore.Materialize(ctx, repoID)
+		if err != nil {
+			t.Fatalf("%s: Materialize() error = %v", step, err)
+		}
+
+		if len(materialized) != len(stored) {
+			t.Fatalf("%s: Materialize() returned %d checks, want %d", step, len(materialized), len(stored))
+		}
+
+		want := make(map[string]types.Check, len(stored))
+		for _, c := range stored {
+			want[c.Identifier] = c
+		}
+
+		for _, got := range materialized {
+			c := want[got.Identifier]
+			if got.ID != c.ID || got.Status != c.Status || got.RetryCount != c.RetryCount || got.Updated != c.Updated {
+				t.Errorf("%s: materialized check %+v doesn't match stored check %+v", step, got, c)
+			}
+		}
+	}
+
+	verify("before snapshot")
+
+	stored, err := eventStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if stored.Status != enum.CheckStatusFailure || stored.RetryCount != 1 {
+		t.Errorf("FindByIdentifier() = %+v, want failed build with one retry", stored)
+	}
+
+	if err = eventStore.Snapshot(ctx, repoID); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	// the snapshot must contain the complete state, so the events it covers are no longer needed.
+	if _, err = db.ExecContext(ctx, `DELETE FROM check_events`); err != nil {
+		t.Fatalf("failed to delete events: %v", err)
+	}
+
+	if err = eventStore.Upsert(ctx, newCheck(repoID, "test", enum.CheckStatusSuccess)); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	verify("after snapshot")
+
+	if err = eventStore.Snapshot(ctx, repoID); err != nil {
+		t.Fatalf("second Snapshot() error = %v", err)
+	}
+
+	verify("after second snapshot")
+
+	lint, err := eventStore.FindByIdentifier(ctx, repoID, testCommitSHA, "lint")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if _, err = eventStore.DeleteByIDs(ctx, []int64{lint.ID}); err != nil {
+		t.Fatalf("DeleteByIDs() error = %v", err)
+	}
+
+	verify("after delete")
+
+	const amendedCommitSHA = "1111111111111111111111111111111111111111"
+	if _, err = eventStore.MoveBySHA(ctx, repoID, testCommitSHA, amendedCommitSHA); err != nil {
+		t.Fatalf("MoveBySHA() error = %v", err)
+	}
+
+	materialized, err := eventStore.Materialize(ctx, repoID)
+	if err != nil {
+		t.Fatalf("Materialize() error = %v", err)
+	}
+
+	if len(materialized) != 2 {
+		t.Errorf("Materialize() returned %d checks, want 2", len(materialized))
+	}
+
+	for _, c := range materialized {
+		if c.CommitSHA != amendedCommitSHA {
+			t.Errorf("materialized check %q has commit %s, want %s", c.Identifier, c.CommitSHA, amendedCommitSHA)
+		}
+	}
+}
+
+func TestEventSourcedCheckStore_Namespaces(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+	eventStore := database.NewEventSourcedCheckStore(db, checkStore)
+
+	build := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	if err := eventStore.Upsert(ctx, build); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	namespaced := newCheck(repoID, "build", enum.CheckStatusFailure)
+	namespaced.Namespace = "ci"
+	if err := eventStore.Upsert(ctx, namespaced); err != nil {
+		t.Fatalf("Upsert() in namespace error = %v", err)
+	}
+
+	materialized, err := eventStore.Materialize(ctx, repoID)
+	if err != nil {
+		t.Fatalf("Materialize() error = %v", err)
+	}
+
+	statuses := make(map[int64]enum.CheckStatus, len(materialized))
+	for _, c := range materialized {
+		statuses[c.ID] = c.Status
+	}
+
+	if len(statuses) != 2 || statuses[build.ID] != enum.CheckStatusSuccess ||
+		statuses[namespaced.ID] != enum.CheckStatusFailure {
+		t.Errorf("Materialize() = %v, want the status checks of both namespaces", statuses)
+	}
+}
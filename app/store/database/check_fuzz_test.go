@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"encoding/json"
+	"testing"
+
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+// FuzzMapCheck feeds arbitrary stored column values to the status check row mapping.
+// The seed corpus mimics rows written for payloads of common CI providers.
+func FuzzMapCheck(f *testing.F) {
+	f.Add(
+		[]byte(`[{"name":"checkout","status":"success","duration":1200},{"name":"test","status":"failure","log":"FAIL"}]`),
+		[]byte(`["ci","github-actions"]`),
+		[]byte(`{"runner":"ubuntu-latest","resource_usage":{"cpu_seconds":12.5,"memory_mb":512,"network_bytes":1024}}`),
+		[]byte(`{"details":"### Build\n:white_check_mark: all jobs passed"}`),
+		false,
+	)
+	f.Add(
+		[]byte(`null`),
+		[]byte(`null`),
+		[]byte(`{"jenkins_build":"117"}`),
+		fuzzCompressCheckPayload(f, []byte(`{"tests":120,"failures":2,"failed_tests":["TestLogin"]}`)),
+		true,
+	)
+	f.Add([]byte(`[]`), []byte(`[]`), []byte(`{"resource_usage":"x"}`), []byte(`"H4sI"`), true)
+	f.Add([]byte(`{`), []byte(`[1]`), []byte(`{"ciphertext":1}`), []byte(`{"ciphertext":"AAEC"}`), false)
+
+	s := &CheckStore{}
+
+	f.Fuzz(func(t *testing.T, steps, labels, metadata, payload []byte, compressed bool) {
+		c := &check{
+			PayloadSteps: sqlxtypes.JSONText(steps),
+			Labels:       sqlxtypes.JSONText(labels),
+			Metadata:     json.RawMessage(metadata),
+			Payload:      json.RawMessage(payload),
+			Compressed:   compressed,
+		}
+
+		// only failing on panics, malformed rows are expected to be rejected with an error.
+		_, _ = s.mapCheck(c)
+	})
+}
+
+// FuzzDecompressCheckPayload feeds arbitrary data to the decompression of stored status check payloads.
+func FuzzDecompressCheckPayload(f *testing.F) {
+	f.Add(fuzzCompressCheckPayload(f, []byte(`{"details":"Finished: SUCCESS"}`)))
+	f.Add([]byte(`"bm90IGd6aXA="`))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		_, _ = decompressCheckPayload(payload)
+	})
+}
+
+// fuzzCompressCheckPayload returns the payload the way the status check store persists it when compressed.
+func fuzzCompressCheckPayload(f *testing.F, payload []byte) []byte {
+	compressed, err := compressCheckPayload(payload)
+	if err != nil {
+		f.Fatalf("failed to compress payload: %v", err)
+	}
+
+	return compressed
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckIssueUpdateStore = (*CheckIssueUpdateStore)(nil)
+
+// NewCheckIssueUpdateStore returns a new CheckIssueUpdateStore.
+func NewCheckIssueUpdateStore(db *sqlx.DB) *CheckIssueUpdateStore {
+	return &CheckIssueUpdateStore{
+		db: db,
+	}
+}
+
+// CheckIssueUpdateStore implements store.CheckIssueUpdateStore backed by a relational database.
+type CheckIssueUpdateStore struct {
+	db *sqlx.DB
+}
+
+type checkIssueUpdate struct {
+	IssueKey string                `db:"check_issue_update_issue_key"`
+	Action   enum.CheckIssueAction `db:"check_issue_update_action"`
+}
+
+// List returns the actions completed on the issues for a status check event, by issue key.
+func (s *CheckIssueUpdateStore) List(
+	ctx context.Context,
+	eventID string,
+) (map[string][]enum.CheckIssueAction, error) {
+	const sqlQuery = `
+	SELECT
+		 check_issue_update_issue_key
+		,check_issue_update_action
+	FROM check_issue_updates
+	WHERE check_issue_update_event_id = $1
+	ORDER BY check_issue_update_issue_key, check_issue_update_action`
+
+	dst := make([]checkIssueUpdate, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err := db.SelectContext(ctx, &dst, sqlQuery, eventID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list status check issue updates")
+	}
+
+	actions := make(map[string][]enum.CheckIssueAction)
+	for _, update := range dst {
+		actions[update.IssueKey] = append(actions[update.IssueKey], update.Action)
+	}
+
+	return actions, nil
+}
+
+// Create records an action completed on an issue for a status check event.
+func (s *CheckIssueUpdateStore) Create(
+	ctx context.Context,
+	checkID int64,
+	eventID string,
+	issueKey string,
+	action enum.CheckIssueAction,
+) error {
+	const sqlQuery = `
+	INSERT INTO check_issue_updates (
+		 check_issue_update_event_id
+		,check_issue_update_issue_key
+		,check_issue_update_action
+		,check_issue_update_check_id
+		,check_issue_update_created
+	) VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	_, err := db.ExecContext(ctx, sqlQuery, eventID, issueKey, action, checkID, time.Now().UnixMilli())
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create status check issue update")
+	}
+
+	return nil
+}
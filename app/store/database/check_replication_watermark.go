@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckReplicationWatermarkStore = (*CheckReplicationWatermarkStore)(nil)
+
+// CheckReplicationWatermarkStoreMinMigrationVersion is the oldest database migration version
+// containing the table used by the CheckReplicationWatermarkStore.
+const CheckReplicationWatermarkStoreMinMigrationVersion = "0113_create_table_check_replication_watermarks"
+
+// NewCheckReplicationWatermarkStore returns a new CheckReplicationWatermarkStore.
+func NewCheckReplicationWatermarkStore(db *sqlx.DB) *CheckReplicationWatermarkStore {
+	return &CheckReplicationWatermarkStore{
+		db: db,
+	}
+}
+
+// CheckReplicationWatermarkStore implements store.CheckReplicationWatermarkStore backed by a relational database.
+type CheckReplicationWatermarkStore struct {
+	db *sqlx.DB
+}
+
+// Find returns the time (unix millis) of the most recent change replicated to the replica of the region.
+// It returns zero if nothing got replicated yet.
+func (s *CheckReplicationWatermarkStore) Find(ctx context.Context, region string) (int64, error) {
+	const sqlQuery = `
+	SELECT COALESCE(MAX(check_watermark_replicated_until), 0)
+	FROM check_replication_watermarks
+	WHERE check_watermark_region = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var replicatedUntil int64
+	if err := db.QueryRowContext(ctx, sqlQuery, region).Scan(&replicatedUntil); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to find status check replication watermark")
+	}
+
+	return replicatedUntil, nil
+}
+
+// Advance moves the watermark of the region forward to the provided time (unix millis).
+// The watermark is never moved back, older times are ignored.
+func (s *CheckReplicationWatermarkStore) Advance(ctx context.Context, region string, replicatedUntil int64) error {
+	const sqlQuery = `
+	INSERT INTO check_replication_watermarks (
+		 check_watermark_region
+		,check_watermark_replicated_until
+	) VALUES ($1, $2)
+	ON CONFLICT (check_watermark_region) DO
+	UPDATE SET check_watermark_replicated_until = EXCLUDED.check_watermark_replicated_until
+	WHERE check_replication_watermarks.check_watermark_replicated_until < EXCLUDED.check_watermark_replicated_until`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, region, replicatedUntil); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to advance status check replication watermark")
+	}
+
+	return nil
+}
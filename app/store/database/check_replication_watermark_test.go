@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+)
+
+func TestCheckReplicationWatermarkStore_Advance(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	watermarkStore := database.NewCheckReplicationWatermarkStore(db)
+
+	replicatedUntil, err := watermarkStore.Find(ctx, "eu-west")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if replicatedUntil != 0 {
+		t.Errorf("Find() = %d, want 0", replicatedUntil)
+	}
+
+	for _, advance := range []int64{2000, 1000} {
+		if err = watermarkStore.Advance(ctx, "eu-west", advance); err != nil {
+			t.Fatalf("Advance() error = %v", err)
+		}
+	}
+	if err = watermarkStore.Advance(ctx, "us-east", 500); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+
+	// an older time doesn't move the watermark back.
+	replicatedUntil, err = watermarkStore.Find(ctx, "eu-west")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if replicatedUntil != 2000 {
+		t.Errorf("Find() = %d, want 2000", replicatedUntil)
+	}
+}
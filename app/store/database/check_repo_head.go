@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckRepoHeadStore = (*CheckRepoHeadStore)(nil)
+
+// NewCheckRepoHeadStore returns a new CheckRepoHeadStore.
+func NewCheckRepoHeadStore(db *sqlx.DB) *CheckRepoHeadStore {
+	return &CheckRepoHeadStore{
+		db: db,
+	}
+}
+
+// CheckRepoHeadStore implements store.CheckRepoHeadStore backed by a relational database.
+type CheckRepoHeadStore struct {
+	db *sqlx.DB
+}
+
+type checkRepoHead struct {
+	RepoID    int64  `db:"check_repo_head_repo_id"`
+	CommitSHA string `db:"check_repo_head_commit_sha"`
+}
+
+// Map returns the recorded default branch head commit of each of the provided repos.
+// Repos without a recorded head are omitted from the result.
+func (s *CheckRepoHeadStore) Map(ctx context.Context, repoIDs []int64) (map[int64]string, error) {
+	if len(repoIDs) == 0 {
+		return map[int64]string{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("check_repo_head_repo_id", "check_repo_head_commit_sha").
+		From("check_repo_heads").
+		Where(squirrel.Eq{"check_repo_head_repo_id": repoIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	dst := make([]checkRepoHead, 0, len(repoIDs))
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list repo heads query")
+	}
+
+	heads := make(map[int64]string, len(dst))
+	for _, head := range dst {
+		heads[head.RepoID] = head.CommitSHA
+	}
+
+	return heads, nil
+}
+
+// Upsert records the default branch head commit of a repo as of the provided time (unix millis).
+// Heads older than the recorded one are ignored.
+func (s *CheckRepoHeadStore) Upsert(ctx context.Context, repoID int64, commitSHA string, updated int64) error {
+	const sqlQuery = `
+	INSERT INTO check_repo_heads (
+		 check_repo_head_repo_id
+		,check_repo_head_commit_sha
+		,check_repo_head_updated
+	) VALUES ($1, $2, $3)
+	ON CONFLICT (check_repo_head_repo_id) DO
+	UPDATE SET
+		 check_repo_head_commit_sha = EXCLUDED.check_repo_head_commit_sha
+		,check_repo_head_updated = EXCLUDED.check_repo_head_updated
+	WHERE check_repo_heads.check_repo_head_updated <= EXCLUDED.check_repo_head_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, commitSHA, updated); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert repo head")
+	}
+
+	return nil
+}
+
+// Delete removes the recorded default branch head commit of a repo.
+func (s *CheckRepoHeadStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+	DELETE FROM check_repo_heads
+	WHERE check_repo_head_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repo head")
+	}
+
+	return nil
+}
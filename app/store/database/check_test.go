@@ -0,0 +1,2842 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+)
+
+const testCommitSHA = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+func setupCheckStore(ctx context.Context, t testing.TB, db *sqlx.DB) (*database.CheckStore, int64) {
+	t.Helper()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	repoID := int64(1)
+	createRepo(ctx, t, repoStore, repoID, 1, 0)
+
+	pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+
+	return database.NewCheckStore(db, pCache, nil, 0, 0, 0), repoID
+}
+
+func newCheck(repoID int64, identifier string, status enum.CheckStatus, steps ...types.CheckStep) *types.Check {
+	now := time.Now().UnixMilli()
+	return &types.Check{
+		CreatedBy:  userID,
+		Created:    now,
+		Updated:    now,
+		RepoID:     repoID,
+		CommitSHA:  testCommitSHA,
+		Identifier: identifier,
+		Status:     status,
+		Metadata:   []byte("{}"),
+		Payload: types.CheckPayload{
+			Data:  []byte("{}"),
+			Steps: steps,
+		},
+	}
+}
+
+func upsertCheck(
+	ctx context.Context,
+	t *testing.T,
+	checkStore *database.CheckStore,
+	repoID int64,
+	identifier string,
+	status enum.CheckStatus,
+	steps ...types.CheckStep,
+) *types.Check {
+	t.Helper()
+
+	check := newCheck(repoID, identifier, status, steps...)
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check %q: %v", identifier, err)
+	}
+
+	return check
+}
+
+func TestProvideCheckStore_MigrationVersion(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	config := &types.Config{}
+
+	if _, err := database.ProvideCheckStore(ctx, db, nil, config); err != nil {
+		t.Fatalf("ProvideCheckStore() on migrated database error = %v", err)
+	}
+
+	// the event sourced store requires the schema of the status check store as well.
+	if err := migrate.To(ctx, db, database.EventSourcedCheckStoreMinMigrationVersion); err != nil {
+		t.Fatalf("failed to migrate database down: %v", err)
+	}
+
+	eventSourcedConfig := &types.Config{}
+	eventSourcedConfig.Checks.EventSourcing = true
+
+	_, err := database.ProvideCheckStore(ctx, db, nil, eventSourcedConfig)
+	if err == nil || !strings.Contains(err.Error(), database.CheckStoreMinMigrationVersion) {
+		t.Errorf("ProvideCheckStore() with event sourcing on outdated database error = %v, want outdated schema error",
+			err)
+	}
+
+	if err := migrate.To(ctx, db, "0091_create_table_check_audits"); err != nil {
+		t.Fatalf("failed to migrate database down: %v", err)
+	}
+
+	_, err = database.ProvideCheckStore(ctx, db, nil, config)
+	if err == nil || !strings.Contains(err.Error(), database.CheckStoreMinMigrationVersion) {
+		t.Errorf("ProvideCheckStore() on outdated database error = %v, want outdated schema error", err)
+	}
+}
+
+func TestCheckStore_StatusConstraint(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	build := upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusRunning)
+
+	const sqlQuery = `UPDATE checks SET check_status = $1 WHERE check_id = $2`
+
+	if _, err := db.ExecContext(ctx, sqlQuery, enum.CheckStatusSkipped, build.ID); err != nil {
+		t.Errorf("updating to a valid status error = %v", err)
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQuery, "cancelled", build.ID); err == nil {
+		t.Error("updating to an invalid status expected an error")
+	}
+
+	check := newCheck(repoID, "lint", "unknown")
+	if err := checkStore.Upsert(ctx, check); err == nil {
+		t.Error("Upsert() with an invalid status expected an error")
+	}
+}
+
+func TestCheckStore_FindByID(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	build := upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+
+	check, err := checkStore.FindByID(ctx, build.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+
+	if check.Identifier != "build" || check.RepoID != repoID || check.CommitSHA != testCommitSHA {
+		t.Errorf("FindByID() = %+v, want the build check", check)
+	}
+
+	if check.ReportedBy == nil || check.ReportedBy.ID != userID {
+		t.Errorf("FindByID() reported by = %+v, want principal %d", check.ReportedBy, userID)
+	}
+
+	if _, err = checkStore.FindByID(ctx, build.ID+1); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("FindByID() of unknown check error = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+}
+
+func TestCheckStore_ListStepName(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess,
+		types.CheckStep{Name: "compile", Status: enum.CheckStatusSuccess, Duration: 1200},
+		types.CheckStep{Name: "lint", Status: enum.CheckStatusSuccess})
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure,
+		types.CheckStep{Name: "unit", Status: enum.CheckStatusFailure, Log: "1 test failed"})
+	upsertCheck(ctx, t, checkStore, repoID, "deploy", enum.CheckStatusPending)
+
+	tests := []struct {
+		name     string
+		stepName string
+		want     []string
+	}{
+		{name: "no filter", stepName: "", want: []string{"build", "deploy", "test"}},
+		{name: "matching step", stepName: "unit", want: []string{"test"}},
+		{name: "no matching step", stepName: "e2e", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := types.CheckListOptions{StepName: tt.stepName}
+
+			checks, err := checkStore.List(ctx, repoID, testCommitSHA, opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			count, err := checkStore.Count(ctx, repoID, testCommitSHA, opts)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+
+			if count != len(tt.want) {
+				t.Errorf("Count() = %d, want %d", count, len(tt.want))
+			}
+
+			got := make(map[string]types.Check, len(checks))
+			for _, c := range checks {
+				got[c.Identifier] = c
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("List() returned %d checks, want %d", len(got), len(tt.want))
+			}
+
+			for _, identifier := range tt.want {
+				if _, ok := got[identifier]; !ok {
+					t.Errorf("List() is missing check %q", identifier)
+				}
+			}
+		})
+	}
+
+	check, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if len(check.Payload.Steps) != 2 || check.Payload.Steps[0].Name != "compile" ||
+		check.Payload.Steps[0].Duration != 1200 {
+		t.Errorf("FindByIdentifier() returned unexpected steps: %+v", check.Payload.Steps)
+	}
+}
+
+func TestCheckStore_ListVisibility(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	for identifier, visibility := range map[string]enum.CheckVisibility{
+		"lint":     enum.CheckVisibilityRestricted,
+		"security": enum.CheckVisibilityPrivate,
+	} {
+		check := newCheck(repoID, identifier, enum.CheckStatusSuccess)
+		check.Visibility = visibility
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", identifier, err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		visibilities []enum.CheckVisibility
+		want         []string
+	}{
+		{name: "no filter", want: []string{"build", "lint", "security"}},
+		{
+			name:         "viewer",
+			visibilities: []enum.CheckVisibility{enum.CheckVisibilityPublic},
+			want:         []string{"build"},
+		},
+		{
+			name:         "contributor",
+			visibilities: []enum.CheckVisibility{enum.CheckVisibilityPublic, enum.CheckVisibilityRestricted},
+			want:         []string{"build", "lint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := types.CheckListOptions{Visibilities: tt.visibilities}
+
+			checks, err := checkStore.List(ctx, repoID, testCommitSHA, opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			count, err := checkStore.Count(ctx, repoID, testCommitSHA, opts)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+
+			if count != len(tt.want) {
+				t.Errorf("Count() = %d, want %d", count, len(tt.want))
+			}
+
+			got := make([]string, len(checks))
+			for i, c := range checks {
+				got[i] = c.Identifier
+			}
+
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+
+			recent, err := checkStore.ListRecent(ctx, repoID, types.CheckRecentOptions{Visibilities: tt.visibilities})
+			if err != nil {
+				t.Fatalf("ListRecent() error = %v", err)
+			}
+
+			if !slices.Equal(recent, tt.want) {
+				t.Errorf("ListRecent() = %v, want %v", recent, tt.want)
+			}
+
+			summaries, err := checkStore.ResultSummary(ctx, repoID, []string{testCommitSHA}, tt.visibilities)
+			if err != nil {
+				t.Fatalf("ResultSummary() error = %v", err)
+			}
+
+			if success := summaries[sha.Must(testCommitSHA)].Success; success != len(tt.want) {
+				t.Errorf("ResultSummary() success = %d, want %d", success, len(tt.want))
+			}
+
+			found, err := checkStore.Search(ctx, types.CheckSearchQuery{
+				RepoID:       repoID,
+				Visibilities: tt.visibilities,
+				Limit:        10,
+			}, nil)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			if len(found) != len(tt.want) {
+				t.Errorf("Search() found %d status checks, want %d", len(found), len(tt.want))
+			}
+		})
+	}
+
+	// only the private status check fails, so the commit matches the filter only if it's visible.
+	failed := newCheck(repoID, "security", enum.CheckStatusFailure)
+	failed.Visibility = enum.CheckVisibilityPrivate
+	if err := checkStore.Upsert(ctx, failed); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	matching, err := checkStore.FilterCommitSHAs(ctx, repoID, []string{testCommitSHA}, "", enum.CheckStatusFailure,
+		[]enum.CheckVisibility{enum.CheckVisibilityPublic, enum.CheckVisibilityRestricted})
+	if err != nil {
+		t.Fatalf("FilterCommitSHAs() error = %v", err)
+	}
+
+	if len(matching) != 0 {
+		t.Errorf("FilterCommitSHAs() = %v, want no commits matching by invisible status checks", matching)
+	}
+
+	check, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if check.Visibility != enum.CheckVisibilityPublic {
+		t.Errorf("FindByIdentifier() visibility = %q, want %q", check.Visibility, enum.CheckVisibilityPublic)
+	}
+}
+
+func TestCheckStore_ListSort(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	for _, c := range []struct {
+		identifier string
+		status     enum.CheckStatus
+		updated    int64
+		duration   int64
+	}{
+		{identifier: "build", status: enum.CheckStatusSuccess, updated: 3000, duration: 500},
+		{identifier: "test", status: enum.CheckStatusFailure, updated: 1000, duration: 900},
+		{identifier: "deploy", status: enum.CheckStatusPending, updated: 4000},
+		{identifier: "lint", status: enum.CheckStatusError, updated: 2000, duration: 100},
+	} {
+		check := newCheck(repoID, c.identifier, c.status)
+		check.Updated = c.updated
+		if c.duration > 0 {
+			check.Started = 1000
+			check.Ended = 1000 + c.duration
+		}
+
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		sort  enum.CheckSort
+		order enum.Order
+		want  []string
+	}{
+		{name: "default", want: []string{"deploy", "build", "lint", "test"}},
+		{name: "updated asc", sort: enum.CheckSortUpdated, order: enum.OrderAsc,
+			want: []string{"test", "lint", "build", "deploy"}},
+		{name: "identifier", sort: enum.CheckSortIdentifier, want: []string{"build", "deploy", "lint", "test"}},
+		{name: "identifier desc", sort: enum.CheckSortIdentifier, order: enum.OrderDesc,
+			want: []string{"test", "lint", "deploy", "build"}},
+		{name: "status", sort: enum.CheckSortStatus, want: []string{"test", "lint", "deploy", "build"}},
+		{name: "status asc", sort: enum.CheckSortStatus, order: enum.OrderAsc,
+			want: []string{"build", "deploy", "lint", "test"}},
+		{name: "duration", sort: enum.CheckSortDuration, want: []string{"test", "build", "lint", "deploy"}},
+		{name: "duration asc", sort: enum.CheckSortDuration, order: enum.OrderAsc,
+			want: []string{"deploy", "lint", "build", "test"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, err := checkStore.List(ctx, repoID, testCommitSHA, types.CheckListOptions{
+				Sort:  tt.sort,
+				Order: tt.order,
+			})
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			got := make([]string, len(checks))
+			for i, c := range checks {
+				got[i] = c.Identifier
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckStore_SlowQueryLog(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Nanosecond)
+
+	// every query takes longer than a nanosecond, so all of them must be logged
+	checkStore := database.NewCheckStore(db, pCache, nil, 0, time.Nanosecond, 0)
+
+	buf := &bytes.Buffer{}
+	ctx = zerolog.New(buf).WithContext(ctx)
+
+	check := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	check.Payload.Data = []byte(`{"secret":"value"}`)
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	logged := buf.String()
+	if !strings.Contains(logged, `"level":"warn"`) || !strings.Contains(logged, "slow database query") ||
+		!strings.Contains(logged, "INSERT INTO checks") || !strings.Contains(logged, `"stack":`) {
+		t.Fatalf("slow query log = %s, want a warning with the query and stack trace", logged)
+	}
+
+	if strings.Contains(logged, "secret") || !strings.Contains(logged, " bytes>") {
+		t.Errorf("slow query log = %s, want binary parameters replaced with their size", logged)
+	}
+}
+
+func TestCheckStore_Patch(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	check := newCheck(repoID, "build", enum.CheckStatusRunning)
+	check.Summary = "building"
+	check.Link = "https://ci.example.com/1"
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	status := enum.CheckStatusError
+	ended := time.Now().UnixMilli()
+	category := enum.CheckFailureCategoryTimeout
+	err := checkStore.Patch(ctx, repoID, testCommitSHA, "build", types.CheckPatch{
+		Status:          &status,
+		Ended:           &ended,
+		FailureCategory: &category,
+	})
+	if err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	found, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if found.Status != status || found.Ended != ended || found.FailureCategory != category {
+		t.Errorf("patched fields: status=%q ended=%d category=%q, want status=%q ended=%d category=%q",
+			found.Status, found.Ended, found.FailureCategory, status, ended, category)
+	}
+
+	if found.Summary != check.Summary || found.Link != check.Link {
+		t.Errorf("unpatched fields changed: summary=%q link=%q", found.Summary, found.Link)
+	}
+
+	err = checkStore.Patch(ctx, repoID, testCommitSHA, "unknown", types.CheckPatch{Status: &status})
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Patch() of unknown check error = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+}
+
+func TestCheckStore_UpsertWithCAS(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	ok, err := checkStore.UpsertWithCAS(ctx, newCheck(repoID, "build", enum.CheckStatusPending), enum.CheckStatusPending)
+	if err != nil || !ok {
+		t.Fatalf("UpsertWithCAS() of new check = %t, %v, want true", ok, err)
+	}
+
+	ok, err = checkStore.UpsertWithCAS(ctx, newCheck(repoID, "build", enum.CheckStatusRunning), enum.CheckStatusPending)
+	if err != nil || !ok {
+		t.Fatalf("UpsertWithCAS() with expected status = %t, %v, want true", ok, err)
+	}
+
+	check := newCheck(repoID, "build", enum.CheckStatusRunning)
+	check.Summary = "second agent"
+	ok, err = checkStore.UpsertWithCAS(ctx, check, enum.CheckStatusPending)
+	if err != nil || ok {
+		t.Fatalf("UpsertWithCAS() with unexpected status = %t, %v, want false", ok, err)
+	}
+
+	found, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if found.Status != enum.CheckStatusRunning || found.Summary == check.Summary {
+		t.Errorf("check after failed swap: status=%q summary=%q", found.Status, found.Summary)
+	}
+}
+
+func TestCheckStore_UpsertDeduplicated(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	first := upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusRunning)
+	if first.Deduplicated {
+		t.Fatalf("new check must not be deduplicated")
+	}
+
+	retried := newCheck(repoID, "build", enum.CheckStatusRunning)
+	retried.Updated = first.Updated + 1000
+	if err := checkStore.Upsert(ctx, retried); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	if !retried.Deduplicated || retried.ID != first.ID || retried.Updated != first.Updated {
+		t.Errorf("identical check: deduplicated=%t id=%d updated=%d, want true, %d, %d",
+			retried.Deduplicated, retried.ID, retried.Updated, first.ID, first.Updated)
+	}
+
+	if retried.ReportedBy == nil || retried.ReportedBy.ID != userID {
+		t.Errorf("deduplicated check has unexpected reporter: %+v", retried.ReportedBy)
+	}
+
+	changed := newCheck(repoID, "build", enum.CheckStatusRunning)
+	changed.Summary = "50% done"
+	if err := checkStore.Upsert(ctx, changed); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	if changed.Deduplicated {
+		t.Errorf("check with a different summary must not be deduplicated")
+	}
+
+	// the patched check doesn't match the content hash anymore.
+	status := enum.CheckStatusSuccess
+	if err := checkStore.Patch(ctx, repoID, testCommitSHA, "build", types.CheckPatch{Status: &status}); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+
+	reverted := newCheck(repoID, "build", enum.CheckStatusRunning)
+	reverted.Summary = changed.Summary
+	if err := checkStore.Upsert(ctx, reverted); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	found, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if reverted.Deduplicated || found.Status != enum.CheckStatusRunning {
+		t.Errorf("check after patch: deduplicated=%t status=%q, want false, %q",
+			reverted.Deduplicated, found.Status, enum.CheckStatusRunning)
+	}
+}
+
+func TestCheckStore_UpsertDeduplicatedFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(check *types.Check)
+	}{
+		{name: "link", change: func(check *types.Check) { check.Link = "https://ci.example.com/1" }},
+		{name: "labels", change: func(check *types.Check) { check.Labels = []string{"nightly"} }},
+		{name: "started", change: func(check *types.Check) { check.Started = 1000 }},
+		{name: "ended", change: func(check *types.Check) { check.Ended = 2000 }},
+		{name: "metadata", change: func(check *types.Check) { check.Metadata = []byte(`{"runner":"linux"}`) }},
+		{name: "target repo", change: func(check *types.Check) { check.TargetRepoID = &check.RepoID }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, teardown := setupDB(t)
+			defer teardown()
+
+			ctx := context.Background()
+			checkStore, repoID := setupCheckStore(ctx, t, db)
+
+			upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusRunning)
+
+			changed := newCheck(repoID, "build", enum.CheckStatusRunning)
+			tt.change(changed)
+			if err := checkStore.Upsert(ctx, changed); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+
+			if changed.Deduplicated {
+				t.Errorf("check with a different %s must not be deduplicated", tt.name)
+			}
+
+			retried := newCheck(repoID, "build", enum.CheckStatusRunning)
+			tt.change(retried)
+			if err := checkStore.Upsert(ctx, retried); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+
+			if !retried.Deduplicated {
+				t.Errorf("identical check with %s must be deduplicated", tt.name)
+			}
+		})
+	}
+}
+
+func TestCheckStore_Namespace(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusFailure)
+
+	namespaced := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	namespaced.Namespace = "team-a"
+	if err := checkStore.Upsert(ctx, namespaced); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	found, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if found.Namespace != types.CheckNamespaceDefault || found.Status != enum.CheckStatusFailure {
+		t.Errorf("default namespace check: namespace=%q status=%q, want %q, %q",
+			found.Namespace, found.Status, types.CheckNamespaceDefault, enum.CheckStatusFailure)
+	}
+
+	found, err = checkStore.FindInNamespace(ctx, repoID, testCommitSHA, "team-a", "build")
+	if err != nil {
+		t.Fatalf("FindInNamespace() error = %v", err)
+	}
+
+	if found.ID != namespaced.ID || found.Status != enum.CheckStatusSuccess {
+		t.Errorf("namespaced check: id=%d status=%q, want %d, %q",
+			found.ID, found.Status, namespaced.ID, enum.CheckStatusSuccess)
+	}
+
+	checks, err := checkStore.List(ctx, repoID, testCommitSHA, types.CheckListOptions{Namespace: "team-a"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(checks) != 1 || checks[0].ID != namespaced.ID {
+		t.Errorf("List() in namespace returned %d checks, want only the namespaced one", len(checks))
+	}
+
+	// namespaced status checks don't count towards the required status checks.
+	results, err := checkStore.ListResults(ctx, repoID, testCommitSHA)
+	if err != nil {
+		t.Fatalf("ListResults() error = %v", err)
+	}
+
+	if len(results) != 1 || results[0].Status != enum.CheckStatusFailure {
+		t.Errorf("ListResults() = %+v, want only the default namespace check", results)
+	}
+}
+
+func TestCheckStore_UpsertBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy enum.ConflictStrategy
+		want     map[string]enum.CheckStatus
+	}{
+		{
+			name:     "overwrite",
+			strategy: enum.ConflictStrategyOverwrite,
+			want: map[string]enum.CheckStatus{
+				"build":  enum.CheckStatusRunning,
+				"test":   enum.CheckStatusFailure,
+				"deploy": enum.CheckStatusSuccess,
+			},
+		},
+		{
+			name:     "ignore",
+			strategy: enum.ConflictStrategyIgnore,
+			want: map[string]enum.CheckStatus{
+				"build":  enum.CheckStatusSuccess,
+				"test":   enum.CheckStatusPending,
+				"deploy": enum.CheckStatusSuccess,
+			},
+		},
+		{
+			name:     "terminal wins",
+			strategy: enum.ConflictStrategyTerminalWins,
+			want: map[string]enum.CheckStatus{
+				"build":  enum.CheckStatusSuccess,
+				"test":   enum.CheckStatusFailure,
+				"deploy": enum.CheckStatusSuccess,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, teardown := setupDB(t)
+			defer teardown()
+
+			ctx := context.Background()
+			checkStore, repoID := setupCheckStore(ctx, t, db)
+
+			upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+			upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusPending)
+
+			batch := []*types.Check{
+				newCheck(repoID, "build", enum.CheckStatusRunning),
+				newCheck(repoID, "test", enum.CheckStatusFailure),
+				newCheck(repoID, "deploy", enum.CheckStatusSuccess),
+			}
+			batch[2].TargetRepoID = &repoID
+
+			if err := checkStore.UpsertBatch(ctx, batch, tt.strategy); err != nil {
+				t.Fatalf("UpsertBatch() error = %v", err)
+			}
+
+			if batch[2].ID == 0 {
+				t.Errorf("UpsertBatch() didn't set the ID of the inserted check")
+			}
+
+			deploy, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "deploy")
+			if err != nil {
+				t.Fatalf("FindByIdentifier() error = %v", err)
+			}
+			if deploy.TargetRepoID == nil || *deploy.TargetRepoID != repoID {
+				t.Errorf("UpsertBatch() didn't store the target repo, got %v", deploy.TargetRepoID)
+			}
+
+			for identifier, want := range tt.want {
+				check, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, identifier)
+				if err != nil {
+					t.Fatalf("FindByIdentifier(%q) error = %v", identifier, err)
+				}
+
+				if check.Status != want {
+					t.Errorf("check %q has status %q, want %q", identifier, check.Status, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckStore_Count(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+
+	otherCommitCheck := newCheck(repoID, "build", enum.CheckStatusRunning)
+	otherCommitCheck.CommitSHA = "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+	if err := checkStore.Upsert(ctx, otherCommitCheck); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		commitSHA string
+		opts      types.CheckListOptions
+		exp       int
+	}{
+		{name: "commit", commitSHA: testCommitSHA, exp: 2},
+		{name: "commit-query", commitSHA: testCommitSHA, opts: types.CheckListOptions{
+			ListQueryFilter: types.ListQueryFilter{Query: "tes"},
+		}, exp: 1},
+		{name: "repo", exp: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count, err := checkStore.Count(ctx, repoID, test.commitSHA, test.opts)
+			if err != nil {
+				t.Fatalf("Count() error = %v", err)
+			}
+
+			if count != test.exp {
+				t.Errorf("Count() = %d, want %d", count, test.exp)
+			}
+		})
+	}
+}
+
+func TestCheckStore_DeleteOrphans(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	_, _, _, repoStore := setupStores(t, db)
+	orphanRepoID := int64(2)
+	createRepo(ctx, t, repoStore, orphanRepoID, 1, 0)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, orphanRepoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, orphanRepoID, "test", enum.CheckStatusFailure)
+
+	// the foreign key would delete the status checks together with the repository.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatalf("failed to disable foreign keys: %v", err)
+	}
+	if _, err = conn.ExecContext(ctx, `DELETE FROM repositories WHERE repo_id = $1`, orphanRepoID); err != nil {
+		t.Fatalf("failed to delete repository: %v", err)
+	}
+
+	count, err := checkStore.CountOrphans(ctx)
+	if err != nil {
+		t.Fatalf("CountOrphans() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("CountOrphans() = %d, want 2", count)
+	}
+
+	repoIDs, err := checkStore.ListOrphanRepoIDs(ctx)
+	if err != nil {
+		t.Fatalf("ListOrphanRepoIDs() error = %v", err)
+	}
+	if len(repoIDs) != 1 || repoIDs[0] != orphanRepoID {
+		t.Errorf("ListOrphanRepoIDs() = %v, want [%d]", repoIDs, orphanRepoID)
+	}
+
+	for _, want := range []int64{1, 1, 0} {
+		n, err := checkStore.DeleteOrphans(ctx, 1)
+		if err != nil {
+			t.Fatalf("DeleteOrphans() error = %v", err)
+		}
+		if n != want {
+			t.Errorf("DeleteOrphans() = %d, want %d", n, want)
+		}
+	}
+
+	remaining, err := checkStore.Count(ctx, repoID, "", types.CheckListOptions{})
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("Count() = %d, want 1", remaining)
+	}
+}
+
+func TestCheckStore_DeleteByRepo(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	_, _, _, repoStore := setupStores(t, db)
+	otherRepoID := int64(2)
+	createRepo(ctx, t, repoStore, otherRepoID, 1, 0)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+	upsertCheck(ctx, t, checkStore, otherRepoID, "build", enum.CheckStatusSuccess)
+
+	n, err := checkStore.DeleteByRepo(ctx, repoID)
+	if err != nil {
+		t.Fatalf("DeleteByRepo() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("DeleteByRepo() = %d, want 2", n)
+	}
+
+	remaining, err := checkStore.Count(ctx, otherRepoID, "", types.CheckListOptions{})
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("Count() = %d, want 1", remaining)
+	}
+}
+
+func TestCheckStore_ListByLabel(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	for identifier, labels := range map[string][]string{
+		"build":  {"required"},
+		"test":   {"nightly", "required"},
+		"deploy": {"nightly"},
+	} {
+		check := newCheck(repoID, identifier, enum.CheckStatusSuccess)
+		check.Labels = labels
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", identifier, err)
+		}
+	}
+
+	// status checks in other namespaces aren't included.
+	namespaced := newCheck(repoID, "lint", enum.CheckStatusSuccess)
+	namespaced.Namespace = "team-a"
+	namespaced.Labels = []string{"required"}
+	if err := checkStore.Upsert(ctx, namespaced); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	// status checks reported in other repos for the repo are included.
+	_, _, _, repoStore := setupStores(t, db)
+	otherRepoID := repoID + 1
+	createRepo(ctx, t, repoStore, otherRepoID, 1, 0)
+
+	forked := newCheck(otherRepoID, "e2e", enum.CheckStatusRunning)
+	forked.TargetRepoID = &repoID
+	forked.Labels = []string{"required"}
+	if err := checkStore.Upsert(ctx, forked); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	results, err := checkStore.ListByLabel(ctx, repoID, testCommitSHA, types.CheckNamespaceDefault, "required")
+	if err != nil {
+		t.Fatalf("ListByLabel() error = %v", err)
+	}
+
+	if len(results) != 3 ||
+		results[0].Identifier != "build" || results[1].Identifier != "e2e" || results[2].Identifier != "test" {
+		t.Errorf("ListByLabel() = %+v, want checks build, e2e and test", results)
+	}
+
+	results, err = checkStore.ListByLabel(ctx, repoID, testCommitSHA, "team-a", "required")
+	if err != nil {
+		t.Fatalf("ListByLabel() error = %v", err)
+	}
+
+	if len(results) != 1 || results[0].Identifier != "lint" {
+		t.Errorf("ListByLabel() = %+v, want check lint", results)
+	}
+}
+
+func TestCheckStore_Acknowledge(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	build := upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusFailure)
+	lint := upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusFailure)
+	test := upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+
+	now := time.Now()
+	err := checkStore.Acknowledge(ctx, build.ID, build.Updated, userID, "flaky runner", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+	err = checkStore.Acknowledge(ctx, lint.ID, lint.Updated, userID, "expired", now.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+	err = checkStore.Acknowledge(ctx, test.ID, test.Updated, userID, "flaky test", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+
+	// the acknowledgement doesn't apply to a new run of the status check.
+	rerun := newCheck(repoID, "test", enum.CheckStatusFailure)
+	rerun.Summary = "rerun"
+	rerun.Updated = test.Updated + 1
+	if err = checkStore.Upsert(ctx, rerun); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	results, err := checkStore.ListResults(ctx, repoID, testCommitSHA)
+	if err != nil {
+		t.Fatalf("ListResults() error = %v", err)
+	}
+
+	want := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusFailure, Acknowledged: true},
+		{Identifier: "lint", Status: enum.CheckStatusFailure},
+		{Identifier: "test", Status: enum.CheckStatusFailure},
+	}
+	if !slices.Equal(results, want) {
+		t.Errorf("ListResults() = %v, want %v", results, want)
+	}
+
+	// acknowledging again replaces the existing acknowledgement.
+	err = checkStore.Acknowledge(ctx, build.ID, build.Updated, userID, "still flaky", now.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("Acknowledge() error = %v", err)
+	}
+
+	n, err := checkStore.DeleteExpiredAcknowledgements(ctx, now)
+	if err != nil {
+		t.Fatalf("DeleteExpiredAcknowledgements() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("DeleteExpiredAcknowledgements() = %d, want 2", n)
+	}
+}
+
+func TestCheckStore_ResultSummary(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	commitSHA := sha.Must(testCommitSHA)
+
+	summary := func() types.CheckCountSummary {
+		t.Helper()
+
+		result, err := checkStore.ResultSummary(ctx, repoID, []string{testCommitSHA}, nil)
+		if err != nil {
+			t.Fatalf("ResultSummary() error = %v", err)
+		}
+
+		return result[commitSHA]
+	}
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusRunning)
+
+	if got, want := summary(), (types.CheckCountSummary{Running: 1, Success: 1}); got != want {
+		t.Errorf("summary after insert = %+v, want %+v", got, want)
+	}
+
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+
+	if got, want := summary(), (types.CheckCountSummary{Success: 1, Failure: 1}); got != want {
+		t.Errorf("summary after update = %+v, want %+v", got, want)
+	}
+
+	if _, err := db.ExecContext(ctx, `DELETE FROM checks WHERE check_uid = 'build'`); err != nil {
+		t.Fatalf("failed to delete check: %v", err)
+	}
+
+	if got, want := summary(), (types.CheckCountSummary{Failure: 1}); got != want {
+		t.Errorf("summary after delete = %+v, want %+v", got, want)
+	}
+
+	if _, err := db.ExecContext(ctx, `DELETE FROM checks`); err != nil {
+		t.Fatalf("failed to delete checks: %v", err)
+	}
+
+	result, err := checkStore.ResultSummary(ctx, repoID, []string{testCommitSHA}, nil)
+	if err != nil {
+		t.Fatalf("ResultSummary() error = %v", err)
+	}
+
+	if len(result) != 0 {
+		t.Errorf("summary after deleting all checks = %+v, want none", result)
+	}
+}
+
+func TestCheckStore_ResultSummaryFailureCategories(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	for _, c := range []struct {
+		identifier string
+		status     enum.CheckStatus
+		category   enum.CheckFailureCategory
+	}{
+		{"build", enum.CheckStatusFailure, enum.CheckFailureCategoryCodeFailure},
+		{"test", enum.CheckStatusFailure, enum.CheckFailureCategoryCodeFailure},
+		{"lint", enum.CheckStatusError, enum.CheckFailureCategoryTimeout},
+		{"deploy", enum.CheckStatusSuccess, ""},
+	} {
+		check := newCheck(repoID, c.identifier, c.status)
+		check.FailureCategory = c.category
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", c.identifier, err)
+		}
+	}
+
+	result, err := checkStore.ResultSummary(ctx, repoID, []string{testCommitSHA}, nil)
+	if err != nil {
+		t.Fatalf("ResultSummary() error = %v", err)
+	}
+
+	want := types.CheckCountSummary{
+		Success: 1,
+		Failure: 2,
+		Error:   1,
+		FailureCategories: types.CheckFailureCategoryCounts{
+			CodeFailure: 2,
+			Timeout:     1,
+		},
+	}
+	if got := result[sha.Must(testCommitSHA)]; got != want {
+		t.Errorf("ResultSummary() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCheckStore_ResourceUsage(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	upsertUsage := func(identifier, commitSHA string, usage *types.CheckResourceUsage) {
+		t.Helper()
+
+		check := newCheck(repoID, identifier, enum.CheckStatusSuccess)
+		check.CommitSHA = commitSHA
+		check.Metadata = []byte(`{"runner":"linux"}`)
+		check.ResourceUsage = usage
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", identifier, err)
+		}
+	}
+
+	const otherCommitSHA = "1111111111111111111111111111111111111111"
+
+	upsertUsage("build", testCommitSHA, &types.CheckResourceUsage{CPUSeconds: 1.5, MemoryMB: 512, NetworkBytes: 100})
+	upsertUsage("build", otherCommitSHA, &types.CheckResourceUsage{CPUSeconds: 2, MemoryMB: 1024, NetworkBytes: 50})
+	upsertUsage("lint", testCommitSHA, nil)
+
+	check, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if check.ResourceUsage == nil || *check.ResourceUsage != (types.CheckResourceUsage{
+		CPUSeconds: 1.5, MemoryMB: 512, NetworkBytes: 100,
+	}) {
+		t.Errorf("FindByIdentifier() resource usage = %+v", check.ResourceUsage)
+	}
+
+	if string(check.Metadata) != `{"runner":"linux"}` {
+		t.Errorf("FindByIdentifier() metadata = %s, want the metadata without resource usage", check.Metadata)
+	}
+
+	now := time.Now()
+	summary, err := checkStore.ResourceUsageSummary(ctx, repoID, types.CheckResourceUsageOptions{
+		From: now.Add(-time.Hour),
+		To:   now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ResourceUsageSummary() error = %v", err)
+	}
+
+	want := []types.CheckResourceUsageSummary{
+		{Identifier: "build", Runs: 2, CPUSeconds: 3.5, MaxMemoryMB: 1024, NetworkBytes: 150},
+	}
+	if !slices.Equal(summary, want) {
+		t.Errorf("ResourceUsageSummary() = %+v, want %+v", summary, want)
+	}
+
+	// the reporter's metadata is stored as provided, whatever its shape and keys.
+	for _, metadata := range []string{`["linux","arm64"]`, `{"runner":"linux","resource_usage":"self-hosted"}`} {
+		in := newCheck(repoID, "test", enum.CheckStatusSuccess)
+		in.Metadata = []byte(metadata)
+		in.ResourceUsage = &types.CheckResourceUsage{CPUSeconds: 1}
+		if err = checkStore.Upsert(ctx, in); err != nil {
+			t.Fatalf("failed to upsert check with metadata %s: %v", metadata, err)
+		}
+
+		out, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "test")
+		if err != nil {
+			t.Fatalf("FindByIdentifier() error = %v", err)
+		}
+
+		if string(out.Metadata) != metadata {
+			t.Errorf("FindByIdentifier() metadata = %s, want %s", out.Metadata, metadata)
+		}
+		if out.ResourceUsage == nil || out.ResourceUsage.CPUSeconds != 1 {
+			t.Errorf("FindByIdentifier() resource usage = %+v", out.ResourceUsage)
+		}
+	}
+}
+
+func TestCheckStore_FilterCommitSHAs(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	const otherCommitSHA = "1111111111111111111111111111111111111111"
+	const uncheckedCommitSHA = "2222222222222222222222222222222222222222"
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusFailure)
+	upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusSuccess)
+
+	check := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	check.CommitSHA = otherCommitSHA
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	commitSHAs := []string{testCommitSHA, otherCommitSHA, uncheckedCommitSHA}
+
+	tests := []struct {
+		name       string
+		identifier string
+		status     enum.CheckStatus
+		want       []string
+	}{
+		{
+			name:       "identifier and status",
+			identifier: "build",
+			status:     enum.CheckStatusFailure,
+			want:       []string{testCommitSHA},
+		},
+		{name: "identifier", identifier: "build", want: []string{otherCommitSHA, testCommitSHA}},
+		{name: "status", status: enum.CheckStatusSuccess, want: []string{otherCommitSHA, testCommitSHA}},
+		{name: "no match", identifier: "lint", status: enum.CheckStatusFailure, want: []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := checkStore.FilterCommitSHAs(ctx, repoID, commitSHAs, test.identifier, test.status, nil)
+			if err != nil {
+				t.Fatalf("FilterCommitSHAs() error = %v", err)
+			}
+
+			slices.Sort(got)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("FilterCommitSHAs() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckStore_ListBySHAs(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	const otherCommitSHA = "1111111111111111111111111111111111111111"
+	const uncheckedCommitSHA = "2222222222222222222222222222222222222222"
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusFailure)
+	upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusSuccess)
+
+	check := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	check.CommitSHA = otherCommitSHA
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	got, err := checkStore.ListBySHAs(ctx, repoID, []string{testCommitSHA, otherCommitSHA, uncheckedCommitSHA})
+	if err != nil {
+		t.Fatalf("ListBySHAs() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("ListBySHAs() returned %d commits, want 2", len(got))
+	}
+
+	if checks := got[testCommitSHA]; len(checks) != 2 {
+		t.Errorf("ListBySHAs() returned %d checks of %s, want 2", len(checks), testCommitSHA)
+	}
+
+	if checks := got[otherCommitSHA]; len(checks) != 1 || checks[0].Status != enum.CheckStatusSuccess {
+		t.Errorf("ListBySHAs() returned unexpected checks of %s: %v", otherCommitSHA, checks)
+	}
+
+	if _, ok := got[uncheckedCommitSHA]; ok {
+		t.Errorf("ListBySHAs() returned checks of the unchecked commit %s", uncheckedCommitSHA)
+	}
+
+	tooMany := make([]string, database.DefaultCheckListBySHAsLimit+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("%040d", i)
+	}
+
+	if _, err = checkStore.ListBySHAs(ctx, repoID, tooMany); err == nil {
+		t.Errorf("ListBySHAs() expected an error for %d commits", len(tooMany))
+	}
+}
+
+func TestCheckStore_MoveBySHA(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	const amendedCommitSHA = "1111111111111111111111111111111111111111"
+	const conflictCommitSHA = "2222222222222222222222222222222222222222"
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	lint := upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusFailure)
+
+	annotationStore := database.NewCheckAnnotationStore(db)
+	annotations := []*types.CheckAnnotation{
+		{Path: "main.go", LineStart: 1, LineEnd: 1, Level: enum.CheckAnnotationLevelFailure, Title: "unused"},
+	}
+	if err := annotationStore.Replace(ctx, lint, annotations); err != nil {
+		t.Fatalf("failed to replace annotations: %v", err)
+	}
+
+	check := newCheck(repoID, "lint", enum.CheckStatusSuccess)
+	check.CommitSHA = conflictCommitSHA
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	_, err := checkStore.MoveBySHA(ctx, repoID, testCommitSHA, conflictCommitSHA)
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Fatalf("MoveBySHA() to a commit with the same status checks error = %v, want %v",
+			err, gitness_store.ErrDuplicate)
+	}
+
+	moved, err := checkStore.MoveBySHA(ctx, repoID, testCommitSHA, amendedCommitSHA)
+	if err != nil {
+		t.Fatalf("MoveBySHA() error = %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("MoveBySHA() moved %d status checks, want 2", moved)
+	}
+
+	results, err := checkStore.ListResults(ctx, repoID, testCommitSHA)
+	if err != nil {
+		t.Fatalf("failed to list results: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no status checks on the old commit, got %d", len(results))
+	}
+
+	results, err = checkStore.ListResults(ctx, repoID, amendedCommitSHA)
+	if err != nil {
+		t.Fatalf("failed to list results: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected 2 status checks on the amended commit, got %d", len(results))
+	}
+
+	summaries, err := checkStore.ResultSummary(ctx, repoID, []string{testCommitSHA, amendedCommitSHA}, nil)
+	if err != nil {
+		t.Fatalf("failed to get summaries: %v", err)
+	}
+	if summary, ok := summaries[sha.Must(testCommitSHA)]; ok {
+		t.Errorf("expected no summary of the old commit, got %+v", summary)
+	}
+	if got, want := summaries[sha.Must(amendedCommitSHA)], (types.CheckCountSummary{Success: 1, Failure: 1}); got != want {
+		t.Errorf("summary of the amended commit = %+v, want %+v", got, want)
+	}
+
+	found, err := annotationStore.ListByPaths(ctx, repoID, amendedCommitSHA, []string{"main.go"}, nil)
+	if err != nil {
+		t.Fatalf("failed to list annotations: %v", err)
+	}
+	if len(found) != 1 {
+		t.Errorf("expected the annotation to be moved to the amended commit, got %d annotations", len(found))
+	}
+
+	moved, err = checkStore.MoveBySHA(ctx, repoID, testCommitSHA, amendedCommitSHA)
+	if err != nil {
+		t.Fatalf("MoveBySHA() error = %v", err)
+	}
+	if moved != 0 {
+		t.Errorf("MoveBySHA() of a commit without status checks moved %d status checks", moved)
+	}
+}
+
+func TestCheckStore_HeadResultSummary(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	const oldCommitSHA = "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+
+	oldCheck := newCheck(repoID, "build", enum.CheckStatusFailure)
+	oldCheck.CommitSHA = oldCommitSHA
+	if err := checkStore.Upsert(ctx, oldCheck); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusSkipped)
+
+	result, err := checkStore.HeadResultSummary(ctx, []int64{repoID})
+	if err != nil {
+		t.Fatalf("HeadResultSummary() error = %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("HeadResultSummary() without a recorded head = %+v, want empty", result)
+	}
+
+	repoHeadStore := database.NewCheckRepoHeadStore(db)
+	if err = repoHeadStore.Upsert(ctx, repoID, testCommitSHA, time.Now().UnixMilli()); err != nil {
+		t.Fatalf("failed to record repo head: %v", err)
+	}
+
+	result, err = checkStore.HeadResultSummary(ctx, []int64{repoID, repoID + 1})
+	if err != nil {
+		t.Fatalf("HeadResultSummary() error = %v", err)
+	}
+
+	want := map[int64]types.CheckCountSummary{repoID: {Success: 1, Skipped: 1}}
+	if len(result) != len(want) || result[repoID] != want[repoID] {
+		t.Errorf("HeadResultSummary() = %+v, want %+v", result, want)
+	}
+
+	if status := result[repoID].Status(); status != enum.CheckStatusSuccess {
+		t.Errorf("Status() = %q, want %q", status, enum.CheckStatusSuccess)
+	}
+
+	// the status of the head is reported even if other commits got checked more recently
+	if err = repoHeadStore.Upsert(ctx, repoID, oldCommitSHA, time.Now().UnixMilli()); err != nil {
+		t.Fatalf("failed to record repo head: %v", err)
+	}
+
+	result, err = checkStore.HeadResultSummary(ctx, []int64{repoID})
+	if err != nil {
+		t.Fatalf("HeadResultSummary() error = %v", err)
+	}
+
+	if status := result[repoID].Status(); status != enum.CheckStatusFailure {
+		t.Errorf("Status() = %q, want %q", status, enum.CheckStatusFailure)
+	}
+}
+
+func TestCheckRepoHeadStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	repoHeadStore := database.NewCheckRepoHeadStore(db)
+
+	const newCommitSHA = "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"
+
+	now := time.Now().UnixMilli()
+	if err := repoHeadStore.Upsert(ctx, repoID, newCommitSHA, now); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	// heads recorded out of order must not replace a newer head
+	if err := repoHeadStore.Upsert(ctx, repoID, testCommitSHA, now-1); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	heads, err := repoHeadStore.Map(ctx, []int64{repoID, repoID + 1})
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+	if len(heads) != 1 || heads[repoID] != newCommitSHA {
+		t.Errorf("Map() = %v, want the newer head of repo %d only", heads, repoID)
+	}
+
+	if err = repoHeadStore.Delete(ctx, repoID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	heads, err = repoHeadStore.Map(ctx, []int64{repoID})
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+	if len(heads) != 0 {
+		t.Errorf("Map() after Delete() = %v, want empty", heads)
+	}
+}
+
+func TestCheckIssueUpdateStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	check := upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+
+	issueUpdateStore := database.NewCheckIssueUpdateStore(db)
+
+	for _, action := range []enum.CheckIssueAction{
+		enum.CheckIssueActionComment,
+		enum.CheckIssueActionComment, // recording an action twice is ignored
+		enum.CheckIssueActionTransition,
+	} {
+		if err := issueUpdateStore.Create(ctx, check.ID, "event-1", "ABC-1", action); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := issueUpdateStore.Create(ctx, check.ID, "event-2", "ABC-2", enum.CheckIssueActionComment); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	actions, err := issueUpdateStore.List(ctx, "event-1")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := map[string][]enum.CheckIssueAction{
+		"ABC-1": {enum.CheckIssueActionComment, enum.CheckIssueActionTransition},
+	}
+	if len(actions) != 1 || !slices.Equal(actions["ABC-1"], want["ABC-1"]) {
+		t.Errorf("List() = %v, want %v", actions, want)
+	}
+}
+
+func TestCheckStore_ListRetryCandidates(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	check := upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+	upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusFailure)
+	upsertCheck(ctx, t, checkStore, repoID, "test-e2e", enum.CheckStatusError)
+
+	opts := types.CheckRetryCandidateOptions{
+		Identifier:    "test",
+		Statuses:      []enum.CheckStatus{enum.CheckStatusFailure, enum.CheckStatusError},
+		MaxRetryCount: 2,
+		UpdatedAfter:  time.Now().Add(-time.Hour).UnixMilli(),
+		UpdatedBefore: time.Now().UnixMilli(),
+	}
+
+	for i := 0; i <= opts.MaxRetryCount; i++ {
+		checks, err := checkStore.ListRetryCandidates(ctx, repoID, opts)
+		if err != nil {
+			t.Fatalf("ListRetryCandidates() error = %v", err)
+		}
+
+		if i == opts.MaxRetryCount {
+			if len(checks) != 0 {
+				t.Fatalf("ListRetryCandidates() after %d retries = %+v, want none", i, checks)
+			}
+			break
+		}
+
+		if len(checks) != 1 || checks[0].ID != check.ID || checks[0].RetryCount != i {
+			t.Fatalf("ListRetryCandidates() after %d retries = %+v, want check %d", i, checks, check.ID)
+		}
+
+		if err = checkStore.IncrementRetryCount(ctx, check.ID); err != nil {
+			t.Fatalf("IncrementRetryCount() error = %v", err)
+		}
+	}
+
+	// reporting the status of the retried check must keep its retry count.
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusPending)
+
+	found, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "test")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+
+	if found.RetryCount != opts.MaxRetryCount {
+		t.Errorf("RetryCount = %d, want %d", found.RetryCount, opts.MaxRetryCount)
+	}
+}
+
+func TestCheckConfigStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	configStore := database.NewCheckConfigStore(db)
+
+	for identifier, maxAttempts := range map[string]int{"lint": 1, "test": 3} {
+		now := time.Now().UnixMilli()
+		required := identifier == "test"
+		err := configStore.Upsert(ctx, &types.CheckConfig{
+			RepoID:     repoID,
+			Identifier: identifier,
+			CreatedBy:  userID,
+			Created:    now,
+			Updated:    now,
+			Required:   &required,
+			RetryPolicy: types.RetryPolicy{
+				MaxAttempts:     maxAttempts,
+				BackoffSeconds:  30,
+				RetryOnStatuses: []enum.CheckStatus{enum.CheckStatusFailure},
+			},
+			URLTemplate: "https://ci.example.com/" + identifier + "/{{.ExternalID}}",
+		})
+		if err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+
+	config, err := configStore.Find(ctx, repoID, "test")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	if config.RetryPolicy.MaxAttempts != 3 || config.RetryPolicy.BackoffSeconds != 30 ||
+		len(config.RetryPolicy.RetryOnStatuses) != 1 {
+		t.Errorf("Find() retry policy = %+v", config.RetryPolicy)
+	}
+
+	if config.URLTemplate != "https://ci.example.com/test/{{.ExternalID}}" {
+		t.Errorf("Find() URL template = %q", config.URLTemplate)
+	}
+
+	if config.Required == nil || !*config.Required {
+		t.Errorf("Find() required = %v, want true", config.Required)
+	}
+
+	retryable, err := configStore.ListRetryable(ctx)
+	if err != nil {
+		t.Fatalf("ListRetryable() error = %v", err)
+	}
+
+	if len(retryable) != 1 || retryable[0].Identifier != "test" {
+		t.Errorf("ListRetryable() = %+v, want only the test config", retryable)
+	}
+
+	if err = configStore.Delete(ctx, repoID, "test"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	configs, err := configStore.List(ctx, repoID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(configs) != 1 || configs[0].Identifier != "lint" {
+		t.Errorf("List() = %+v, want only the lint config", configs)
+	}
+}
+
+func TestCheckAliasStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	aliasStore := database.NewCheckAliasStore(db)
+
+	newAlias := func(oldIdentifier, newIdentifier string) *types.CheckAlias {
+		now := time.Now().UnixMilli()
+		return &types.CheckAlias{
+			RepoID:        repoID,
+			OldIdentifier: oldIdentifier,
+			NewIdentifier: newIdentifier,
+			CreatedBy:     userID,
+			Created:       now,
+			Updated:       now,
+		}
+	}
+
+	if err := aliasStore.Create(ctx, newAlias("build", "ci/build")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	err := aliasStore.Create(ctx, newAlias("build", "ci/other"))
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Fatalf("Create() error = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+
+	if err = aliasStore.Retarget(ctx, repoID, "ci/build", "ci/compile", time.Now().UnixMilli()); err != nil {
+		t.Fatalf("Retarget() error = %v", err)
+	}
+
+	aliases, err := aliasStore.Map(ctx, repoID)
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+
+	if len(aliases) != 1 || aliases["build"] != "ci/compile" {
+		t.Errorf("Map() = %v, want build -> ci/compile", aliases)
+	}
+
+	if err = aliasStore.Delete(ctx, repoID, "build"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	err = aliasStore.Delete(ctx, repoID, "build")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Delete() error = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+}
+
+func TestCheckStore_ArchiveCandidates(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	build := upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusPending)
+	test := upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusSuccess)
+
+	// the status check created first is updated last, so it's not archived first.
+	build = newCheck(repoID, "build", enum.CheckStatusSuccess)
+	build.Updated += time.Second.Milliseconds()
+	if err := checkStore.Upsert(ctx, build); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	checks, err := checkStore.ListUpdatedBefore(ctx, time.Now().Add(time.Minute).UnixMilli(), 1)
+	if err != nil {
+		t.Fatalf("ListUpdatedBefore() error = %v", err)
+	}
+
+	if len(checks) != 1 || checks[0].ID != test.ID {
+		t.Fatalf("ListUpdatedBefore() = %+v, want only the least recently updated check", checks)
+	}
+
+	n, err := checkStore.DeleteByIDs(ctx, []int64{build.ID})
+	if err != nil {
+		t.Fatalf("DeleteByIDs() error = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("DeleteByIDs() = %d, want 1", n)
+	}
+
+	archiveStore := database.NewCheckArchiveStore(db)
+
+	archive := &types.CheckArchive{
+		Created:     time.Now().UnixMilli(),
+		RepoID:      repoID,
+		Bucket:      "archive",
+		Key:         "checks/1/2024/01/31.jsonl.gz",
+		PeriodStart: 1706659200000,
+		PeriodEnd:   1706745600000,
+		CheckCount:  1,
+	}
+	if err = archiveStore.Create(ctx, archive); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	count, err := archiveStore.CountByPeriod(ctx, repoID, archive.PeriodStart)
+	if err != nil {
+		t.Fatalf("CountByPeriod() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountByPeriod() = %d, want 1", count)
+	}
+
+	found, err := archiveStore.Find(ctx, archive.ID)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if *found != *archive {
+		t.Errorf("Find() = %+v, want %+v", found, archive)
+	}
+
+	archives, err := archiveStore.List(ctx, repoID, 1, 10)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(archives) != 1 {
+		t.Errorf("List() = %+v, want one archive", archives)
+	}
+}
+
+func TestSpaceCheckPolicyStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	setupCheckStore(ctx, t, db)
+
+	const spaceID = 1
+
+	policyStore := database.NewSpaceCheckPolicyStore(db)
+
+	newPolicy := func(identifier string, maxAttempts int) *types.SpaceCheckPolicy {
+		now := time.Now().UnixMilli()
+		return &types.SpaceCheckPolicy{
+			Identifier: identifier,
+			CreatedBy:  userID,
+			Created:    now,
+			Updated:    now,
+			RetryPolicy: types.RetryPolicy{
+				MaxAttempts:     maxAttempts,
+				RetryOnStatuses: []enum.CheckStatus{enum.CheckStatusFailure},
+			},
+		}
+	}
+
+	required := true
+	lint := newPolicy("lint", 2)
+	lint.Required = &required
+
+	err := policyStore.Replace(ctx, spaceID, []*types.SpaceCheckPolicy{lint, newPolicy("test", 3)})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	// replacing must remove the policies that aren't provided anymore
+	build := newPolicy("build", 1)
+	build.Required = &required
+
+	err = policyStore.Replace(ctx, spaceID, []*types.SpaceCheckPolicy{build, newPolicy("test", 4)})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	policies, err := policyStore.List(ctx, spaceID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(policies) != 2 || policies[0].Identifier != "build" || policies[1].Identifier != "test" {
+		t.Fatalf("List() = %+v, want the build and test policies", policies)
+	}
+
+	if policies[0].Required == nil || !*policies[0].Required || policies[1].Required != nil {
+		t.Errorf("List() required = %v, %v, want only the build policy required",
+			policies[0].Required, policies[1].Required)
+	}
+
+	policy, err := policyStore.Find(ctx, spaceID, "test")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	if policy.SpaceID != spaceID || policy.RetryPolicy.MaxAttempts != 4 {
+		t.Errorf("Find() = %+v, want the replaced test policy", policy)
+	}
+
+	if _, err = policyStore.Find(ctx, spaceID, "lint"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Find() of a removed policy error = %v, want not found", err)
+	}
+
+	retryable, err := policyStore.ListRetryable(ctx)
+	if err != nil {
+		t.Fatalf("ListRetryable() error = %v", err)
+	}
+
+	if len(retryable) != 1 || retryable[0].Identifier != "test" {
+		t.Errorf("ListRetryable() = %+v, want only the test policy", retryable)
+	}
+}
+
+func TestCheckAuditStore_ListAuditByPrincipal(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	auditStore := database.NewCheckAuditStore(db, nil)
+
+	now := time.Now()
+	entries := []*types.CheckAuditEntry{
+		{Timestamp: now.Add(-40 * 24 * time.Hour).UnixMilli(), After: types.CheckAuditState{Status: enum.CheckStatusPending}},
+		{Timestamp: now.Add(-time.Hour).UnixMilli(), After: types.CheckAuditState{Status: enum.CheckStatusRunning}},
+		{
+			Timestamp: now.Add(-time.Minute).UnixMilli(),
+			Before:    &types.CheckAuditState{Status: enum.CheckStatusRunning},
+			After:     types.CheckAuditState{Status: enum.CheckStatusSuccess},
+		},
+	}
+	for _, entry := range entries {
+		entry.PrincipalID = userID
+		entry.RepoID = repoID
+		entry.CommitSHA = testCommitSHA
+		entry.Identifier = "build"
+		if err := auditStore.Create(ctx, entry); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	result, err := auditStore.ListAuditByPrincipal(ctx, userID, now.Add(-30*24*time.Hour), now, 1, 10)
+	if err != nil {
+		t.Fatalf("ListAuditByPrincipal() error = %v", err)
+	}
+
+	if len(result) != 2 || result[0].ID != entries[2].ID || result[1].ID != entries[1].ID {
+		t.Fatalf("ListAuditByPrincipal() = %+v, want the two most recent entries", result)
+	}
+
+	if result[0].Before == nil || result[0].Before.Status != enum.CheckStatusRunning || result[1].Before != nil {
+		t.Errorf("ListAuditByPrincipal() returned unexpected previous states: %+v, %+v",
+			result[0].Before, result[1].Before)
+	}
+
+	result, err = auditStore.ListAuditByPrincipal(ctx, userID+1, now.Add(-30*24*time.Hour), now, 1, 10)
+	if err != nil {
+		t.Fatalf("ListAuditByPrincipal() error = %v", err)
+	}
+
+	if len(result) != 0 {
+		t.Errorf("ListAuditByPrincipal() of another principal = %+v, want none", result)
+	}
+}
+
+func TestCheckAuditStore_ListAuditByRepo(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	_, repoID := setupCheckStore(ctx, t, db)
+
+	auditStore := database.NewCheckAuditStore(db, nil)
+
+	now := time.Now()
+	entries := []*types.CheckAuditEntry{
+		{Timestamp: now.Add(-time.Minute).UnixMilli(), After: types.CheckAuditState{Status: enum.CheckStatusSuccess}},
+		{Timestamp: now.Add(-2 * time.Hour).UnixMilli(), After: types.CheckAuditState{Status: enum.CheckStatusPending}},
+		{Timestamp: now.Add(-time.Hour).UnixMilli(), After: types.CheckAuditState{Status: enum.CheckStatusRunning}},
+	}
+	for _, entry := range entries {
+		entry.PrincipalID = userID
+		entry.RepoID = repoID
+		entry.CommitSHA = testCommitSHA
+		entry.Identifier = "build"
+		if err := auditStore.Create(ctx, entry); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	result, err := auditStore.ListAuditByRepo(ctx, repoID, now.Add(-90*time.Minute), 1, 10)
+	if err != nil {
+		t.Fatalf("ListAuditByRepo() error = %v", err)
+	}
+
+	if len(result) != 2 || result[0].ID != entries[2].ID || result[1].ID != entries[0].ID {
+		t.Fatalf("ListAuditByRepo() = %+v, want the two entries since the start time, oldest first", result)
+	}
+
+	result, err = auditStore.ListAuditByRepo(ctx, repoID+1, now.Add(-90*time.Minute), 1, 10)
+	if err != nil {
+		t.Fatalf("ListAuditByRepo() error = %v", err)
+	}
+
+	if len(result) != 0 {
+		t.Errorf("ListAuditByRepo() of another repo = %+v, want none", result)
+	}
+}
+
+func TestCheckAnnotationStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	annotationStore := database.NewCheckAnnotationStore(db)
+
+	lint := upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusFailure)
+	build := upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+
+	err := annotationStore.Replace(ctx, lint, []*types.CheckAnnotation{
+		{Path: "main.go", LineStart: 3, LineEnd: 5, Level: enum.CheckAnnotationLevelWarning, Message: "unused"},
+		{Path: "README.md", LineStart: 1, LineEnd: 1, Level: enum.CheckAnnotationLevelNotice, Message: "typo"},
+	})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	err = annotationStore.Replace(ctx, build, []*types.CheckAnnotation{
+		{Path: "main.go", LineStart: 1, LineEnd: 1, Level: enum.CheckAnnotationLevelFailure, Message: "broken"},
+	})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	// replacing must remove the previous annotations of the status check
+	err = annotationStore.Replace(ctx, lint, []*types.CheckAnnotation{
+		{Path: "main.go", LineStart: 10, LineEnd: 12, Level: enum.CheckAnnotationLevelWarning, Message: "shadowed"},
+	})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	result, err := annotationStore.ListByPaths(ctx, repoID, testCommitSHA, []string{"main.go", "README.md"}, nil)
+	if err != nil {
+		t.Fatalf("ListByPaths() error = %v", err)
+	}
+
+	if len(result) != 2 ||
+		result[0].CheckIdentifier != "build" || result[0].Message != "broken" ||
+		result[1].CheckIdentifier != "lint" || result[1].Message != "shadowed" {
+		t.Fatalf("ListByPaths() = %+v, want the annotations of build and the latest of lint", result)
+	}
+
+	if !result[1].Overlaps(12, 20) || result[1].Overlaps(1, 9) {
+		t.Errorf("Overlaps() returned unexpected result for %+v", result[1])
+	}
+
+	result, err = annotationStore.ListByPaths(ctx, repoID, testCommitSHA, nil, nil)
+	if err != nil {
+		t.Fatalf("ListByPaths() error = %v", err)
+	}
+
+	if len(result) != 0 {
+		t.Errorf("ListByPaths() without paths = %+v, want none", result)
+	}
+}
+
+func TestCheckStore_ListWithAnnotations(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	annotationStore := database.NewCheckAnnotationStore(db)
+
+	lint := upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusFailure)
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+
+	err := annotationStore.Replace(ctx, lint, []*types.CheckAnnotation{
+		{Path: "main.go", LineStart: 3, LineEnd: 5, Level: enum.CheckAnnotationLevelWarning, Message: "unused"},
+		{Path: "README.md", LineStart: 1, LineEnd: 1, Level: enum.CheckAnnotationLevelNotice, Message: "typo"},
+	})
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	result, err := checkStore.ListWithAnnotations(ctx, repoID, testCommitSHA)
+	if err != nil {
+		t.Fatalf("ListWithAnnotations() error = %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("ListWithAnnotations() returned %d status checks, want 2", len(result))
+	}
+
+	annotations := make(map[string][]types.CheckAnnotation)
+	for _, c := range result {
+		if c.ReportedBy == nil {
+			t.Errorf("status check %q has no reporter", c.Identifier)
+		}
+		annotations[c.Identifier] = c.Annotations
+	}
+
+	if got := annotations["build"]; got == nil || len(got) != 0 {
+		t.Errorf("annotations of build = %+v, want an empty list", got)
+	}
+
+	got := annotations["lint"]
+	if len(got) != 2 ||
+		got[0].Path != "README.md" || got[0].Message != "typo" || got[0].CheckIdentifier != "lint" ||
+		got[1].Path != "main.go" || got[1].LineStart != 3 || got[1].Level != enum.CheckAnnotationLevelWarning {
+		t.Errorf("annotations of lint = %+v, want both annotations ordered by path", got)
+	}
+}
+
+func TestCheckStore_ListResultsCrossRepo(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	_, _, _, repoStore := setupStores(t, db)
+	otherRepoID := repoID + 1
+	createRepo(ctx, t, repoStore, otherRepoID, 1, 0)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+
+	for identifier, status := range map[string]enum.CheckStatus{
+		"build": enum.CheckStatusFailure, // shadowed by the status check reported in the repo itself
+		"e2e":   enum.CheckStatusSuccess,
+	} {
+		check := newCheck(otherRepoID, identifier, status)
+		check.TargetRepoID = &repoID
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check: %v", err)
+		}
+	}
+
+	// not targeting the repo, must be ignored.
+	upsertCheck(ctx, t, checkStore, otherRepoID, "lint", enum.CheckStatusFailure)
+
+	results, err := checkStore.ListResults(ctx, repoID, testCommitSHA)
+	if err != nil {
+		t.Fatalf("ListResults() error = %v", err)
+	}
+
+	want := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusSuccess},
+		{Identifier: "e2e", Status: enum.CheckStatusSuccess},
+	}
+	if !slices.Equal(results, want) {
+		t.Errorf("ListResults() = %+v, want %+v", results, want)
+	}
+
+	// deleting the target repo keeps the status checks reported for it, without the target.
+	if _, err = db.ExecContext(ctx, db.Rebind(`DELETE FROM repositories WHERE repo_id = ?`), repoID); err != nil {
+		t.Fatalf("failed to delete target repo: %v", err)
+	}
+
+	check, err := checkStore.FindByIdentifier(ctx, otherRepoID, testCommitSHA, "e2e")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() error = %v", err)
+	}
+	if check.TargetRepoID != nil {
+		t.Errorf("FindByIdentifier() target repo = %d, want none after the target repo got deleted",
+			*check.TargetRepoID)
+	}
+}
+
+func TestCheckStore_ListForMergeGate(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	_, _, _, repoStore := setupStores(t, db)
+	otherRepoID := repoID + 1
+	createRepo(ctx, t, repoStore, otherRepoID, 1, 0)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusFailure) // not required
+
+	for identifier, status := range map[string]enum.CheckStatus{
+		"build": enum.CheckStatusFailure, // shadowed by the status check reported in the repo itself
+		"e2e":   enum.CheckStatusRunning,
+	} {
+		check := newCheck(otherRepoID, identifier, status)
+		check.TargetRepoID = &repoID
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check: %v", err)
+		}
+	}
+
+	checks, err := checkStore.ListForMergeGate(ctx, repoID, testCommitSHA, []string{"e2e", "build", "deploy"})
+	if err != nil {
+		t.Fatalf("ListForMergeGate() error = %v", err)
+	}
+
+	got := make([]types.CheckResult, len(checks))
+	for i, check := range checks {
+		got[i] = types.CheckResult{Identifier: check.Identifier, Status: check.Status}
+	}
+
+	want := []types.CheckResult{
+		{Identifier: "build", Status: enum.CheckStatusSuccess},
+		{Identifier: "e2e", Status: enum.CheckStatusRunning},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ListForMergeGate() = %+v, want %+v", got, want)
+	}
+
+	checks, err = checkStore.ListForMergeGate(ctx, repoID, testCommitSHA, nil)
+	if err != nil {
+		t.Fatalf("ListForMergeGate() error = %v", err)
+	}
+
+	if len(checks) != 0 {
+		t.Errorf("ListForMergeGate() without required checks returned %d checks, want none", len(checks))
+	}
+}
+
+func TestCheckStore_FlakeScore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	// a running status check doesn't count as a run.
+	statuses := []enum.CheckStatus{
+		enum.CheckStatusSuccess,
+		enum.CheckStatusRunning,
+		enum.CheckStatusFailure,
+		enum.CheckStatusFailure,
+		enum.CheckStatusSuccess,
+	}
+	for i, status := range statuses {
+		check := newCheck(repoID, "build", status)
+		check.CommitSHA = fmt.Sprintf("%040d", i)
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check: %v", err)
+		}
+	}
+
+	lint := newCheck(repoID, "lint", enum.CheckStatusPending)
+	if err := checkStore.UpsertBatch(ctx, []*types.Check{lint}, enum.ConflictStrategyOverwrite); err != nil {
+		t.Fatalf("failed to upsert checks: %v", err)
+	}
+
+	// patched and compare-and-swapped status changes count as runs too.
+	failure := enum.CheckStatusFailure
+	if err := checkStore.Patch(ctx, repoID, testCommitSHA, "lint", types.CheckPatch{Status: &failure}); err != nil {
+		t.Fatalf("failed to patch check: %v", err)
+	}
+
+	lint.Status = enum.CheckStatusSuccess
+	if ok, err := checkStore.UpsertWithCAS(ctx, lint, enum.CheckStatusFailure); err != nil || !ok {
+		t.Fatalf("UpsertWithCAS() = %v, %v, want true", ok, err)
+	}
+
+	// the same identifier in another namespace has its own score.
+	nightly := newCheck(repoID, "build", enum.CheckStatusFailure)
+	nightly.Namespace = "nightly"
+	if err := checkStore.Upsert(ctx, nightly); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	stats, err := checkStore.ListRecentStats(ctx, repoID, types.CheckRecentOptions{})
+	if err != nil {
+		t.Fatalf("ListRecentStats() error = %v", err)
+	}
+
+	if len(stats) != 3 ||
+		stats[0].Identifier != "build" || stats[0].Namespace != types.CheckNamespaceDefault ||
+		stats[1].Identifier != "build" || stats[1].Namespace != "nightly" ||
+		stats[2].Identifier != "lint" {
+		t.Fatalf("ListRecentStats() = %+v, want build, nightly build and lint", stats)
+	}
+
+	// success -> failure (0.2), failure -> failure (0.16), failure -> success (0.2 + 0.8*0.16).
+	if want := 0.328; math.Abs(stats[0].FlakeScore-want) > 1e-9 {
+		t.Errorf("flake score of build = %v, want %v", stats[0].FlakeScore, want)
+	}
+
+	if stats[1].FlakeScore != 0 {
+		t.Errorf("flake score of nightly build = %v, want 0", stats[1].FlakeScore)
+	}
+
+	// failure -> success (0.2).
+	if want := 0.2; math.Abs(stats[2].FlakeScore-want) > 1e-9 {
+		t.Errorf("flake score of lint = %v, want %v", stats[2].FlakeScore, want)
+	}
+}
+
+func largeCheckPayload(size int) []byte {
+	return []byte(`{"log":"` + strings.Repeat("test passed\\n", size/13) + `"}`)
+}
+
+func TestCheckStore_PayloadCompression(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	uncompressedStore, repoID := setupCheckStore(ctx, t, db)
+
+	payload := largeCheckPayload(4096)
+
+	// reported before the compression got enabled
+	check := newCheck(repoID, "legacy", enum.CheckStatusSuccess)
+	check.Payload.Data = payload
+	if err := uncompressedStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+	checkStore := database.NewCheckStore(db, pCache, nil, 1024, 0, 0)
+
+	check = newCheck(repoID, "build", enum.CheckStatusSuccess)
+	check.Payload.Data = payload
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	n, err := checkStore.CompressPayloads(ctx, 10)
+	if err != nil {
+		t.Fatalf("CompressPayloads() error = %v", err)
+	}
+
+	if n != 1 {
+		t.Errorf("CompressPayloads() = %d, want 1", n)
+	}
+
+	var storedSize int
+	err = db.QueryRowContext(ctx, `SELECT max(length(check_payload)) FROM checks`).Scan(&storedSize)
+	if err != nil {
+		t.Fatalf("failed to query stored payload size: %v", err)
+	}
+
+	if storedSize >= len(payload) {
+		t.Errorf("stored payload size is %d bytes, expected less than %d", storedSize, len(payload))
+	}
+
+	for _, identifier := range []string{"legacy", "build"} {
+		found, err := uncompressedStore.FindByIdentifier(ctx, repoID, testCommitSHA, identifier)
+		if err != nil {
+			t.Fatalf("FindByIdentifier() error = %v", err)
+		}
+
+		if string(found.Payload.Data) != string(payload) {
+			t.Errorf("check %q has unexpected payload", identifier)
+		}
+	}
+}
+
+func TestCheckStore_Encryption(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	plainStore, repoID := setupCheckStore(ctx, t, db)
+
+	const (
+		key1 = "k1:01234567890123456789012345678901"
+		key2 = "k2:abcdefghijklmnopqrstuvwxyz012345"
+	)
+
+	newStore := func(activeKeyID string, keys ...string) *database.CheckStore {
+		t.Helper()
+
+		keyRing, err := encrypt.ParseKeyRing(keys, activeKeyID)
+		if err != nil {
+			t.Fatalf("failed to create key ring: %v", err)
+		}
+
+		pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+		return database.NewCheckStore(db, pCache, keyRing, 1024, 0, 0)
+	}
+
+	payload := largeCheckPayload(4096)
+	metadata := []byte(`{"runner":"linux"}`)
+	usage := &types.CheckResourceUsage{CPUSeconds: 1.5, MemoryMB: 512, NetworkBytes: 100}
+
+	// reported before the encryption got enabled
+	legacy := newCheck(repoID, "legacy", enum.CheckStatusSuccess)
+	legacy.Payload.Data = []byte(`{"secret":"legacy"}`)
+	if err := plainStore.Upsert(ctx, legacy); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	checkStore := newStore("k1", key1)
+
+	check := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	check.Payload.Data = payload
+	check.Metadata = metadata
+	check.ResourceUsage = usage
+	if err := checkStore.Upsert(ctx, check); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	assertStored := func(identifier, keyID string) {
+		t.Helper()
+
+		var storedPayload, storedMetadata, payloadKeyID, metadataKeyID string
+		err := db.QueryRowContext(ctx, `SELECT check_payload, check_metadata,
+			check_payload_encryption_key_id, check_metadata_encryption_key_id FROM checks WHERE check_uid = $1`,
+			identifier).Scan(&storedPayload, &storedMetadata, &payloadKeyID, &metadataKeyID)
+		if err != nil {
+			t.Fatalf("failed to query stored check data: %v", err)
+		}
+
+		if payloadKeyID != keyID || metadataKeyID != keyID {
+			t.Errorf("check %q is stored with encryption keys %q and %q, want %q",
+				identifier, payloadKeyID, metadataKeyID, keyID)
+		}
+
+		for _, stored := range []string{storedPayload, storedMetadata} {
+			encrypted := strings.HasPrefix(stored, `{"ciphertext":"`)
+			if keyID != "" && !encrypted {
+				t.Errorf("check %q data %s isn't encrypted with key %q", identifier, stored, keyID)
+			}
+			if strings.Contains(stored, "secret") || strings.Contains(stored, "runner") {
+				t.Errorf("check %q data %s is stored in plain text", identifier, stored)
+			}
+		}
+	}
+
+	assertStored("build", "k1")
+
+	assertFound := func(checkStore *database.CheckStore) {
+		t.Helper()
+
+		found, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build")
+		if err != nil {
+			t.Fatalf("FindByIdentifier() error = %v", err)
+		}
+
+		if !bytes.Equal(found.Payload.Data, payload) {
+			t.Errorf("FindByIdentifier() returned unexpected payload")
+		}
+		if !bytes.Equal(found.Metadata, metadata) {
+			t.Errorf("FindByIdentifier() metadata = %s, want %s", found.Metadata, metadata)
+		}
+		if found.ResourceUsage == nil || *found.ResourceUsage != *usage {
+			t.Errorf("FindByIdentifier() resource usage = %+v, want %+v", found.ResourceUsage, usage)
+		}
+
+		found, err = checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "legacy")
+		if err != nil {
+			t.Fatalf("FindByIdentifier() error = %v", err)
+		}
+
+		if !bytes.Equal(found.Payload.Data, legacy.Payload.Data) {
+			t.Errorf("FindByIdentifier() payload = %s, want %s", found.Payload.Data, legacy.Payload.Data)
+		}
+	}
+
+	assertFound(checkStore)
+
+	// the resource usage stays in plain text, so that it can be aggregated
+	now := time.Now()
+	summary, err := checkStore.ResourceUsageSummary(ctx, repoID, types.CheckResourceUsageOptions{
+		From: now.Add(-time.Hour),
+		To:   now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ResourceUsageSummary() error = %v", err)
+	}
+
+	if len(summary) != 1 || summary[0].CPUSeconds != usage.CPUSeconds {
+		t.Errorf("ResourceUsageSummary() = %+v", summary)
+	}
+
+	if _, err = plainStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build"); err == nil {
+		t.Errorf("FindByIdentifier() without encryption keys succeeded, want error")
+	}
+
+	rotatedStore := newStore("k2", key1, key2)
+
+	n, err := rotatedStore.RotateEncryptionKey(ctx, 10)
+	if err != nil {
+		t.Fatalf("RotateEncryptionKey() error = %v", err)
+	}
+
+	if n != 2 {
+		t.Errorf("RotateEncryptionKey() = %d, want 2", n)
+	}
+
+	if n, err = rotatedStore.RotateEncryptionKey(ctx, 10); err != nil || n != 0 {
+		t.Errorf("RotateEncryptionKey() = %d, %v, want nothing left to rotate", n, err)
+	}
+
+	assertStored("build", "k2")
+	assertStored("legacy", "k2")
+
+	assertFound(newStore("k2", key2))
+
+	// rotating with the encryption disabled decrypts the data
+	n, err = newStore("", key2).RotateEncryptionKey(ctx, 10)
+	if err != nil {
+		t.Fatalf("RotateEncryptionKey() error = %v", err)
+	}
+
+	if n != 2 {
+		t.Errorf("RotateEncryptionKey() = %d, want 2", n)
+	}
+
+	assertFound(plainStore)
+
+	// plain data that looks like encrypted data isn't mistaken for it.
+	lookalike := newCheck(repoID, "lookalike", enum.CheckStatusSuccess)
+	lookalike.Payload.Data = []byte(`{"ciphertext":"AAEC"}`)
+	if err = plainStore.Upsert(ctx, lookalike); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	found, err := newStore("k2", key2).FindByIdentifier(ctx, repoID, testCommitSHA, "lookalike")
+	if err != nil {
+		t.Fatalf("FindByIdentifier() of plain data looking encrypted error = %v", err)
+	}
+	if !bytes.Equal(found.Payload.Data, lookalike.Payload.Data) {
+		t.Errorf("FindByIdentifier() payload = %s, want %s", found.Payload.Data, lookalike.Payload.Data)
+	}
+
+	if n, err = newStore("", key2).RotateEncryptionKey(ctx, 10); err != nil || n != 0 {
+		t.Errorf("RotateEncryptionKey() = %d, %v, want plain data looking encrypted to be left alone", n, err)
+	}
+}
+
+func BenchmarkCheckStore_LargePayload(b *testing.B) {
+	for _, threshold := range []int{0, 1024} {
+		b.Run(fmt.Sprintf("threshold=%d", threshold), func(b *testing.B) {
+			db, teardown := setupDB(b)
+			defer teardown()
+
+			ctx := context.Background()
+			_, repoID := setupCheckStore(ctx, b, db)
+
+			pCache := cache.NewExtended[int64, *types.PrincipalInfo](database.NewPrincipalInfoView(db), time.Minute)
+			checkStore := database.NewCheckStore(db, pCache, nil, threshold, 0, 0)
+
+			check := newCheck(repoID, "build", enum.CheckStatusSuccess)
+			check.Payload.Data = largeCheckPayload(1 << 20)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := checkStore.Upsert(ctx, check); err != nil {
+					b.Fatalf("failed to upsert check: %v", err)
+				}
+
+				if _, err := checkStore.FindByIdentifier(ctx, repoID, testCommitSHA, "build"); err != nil {
+					b.Fatalf("failed to find check: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkCheckStore_ListResults measures the query loading the status check results of a commit
+// for the merge gate with and without the index covering its commit, namespace and repo predicate.
+// Part of the results are reported on a fork for the target repo, so both branches of the predicate match.
+func BenchmarkCheckStore_ListResults(b *testing.B) {
+	const (
+		commits         = 50
+		checksPerCommit = 200
+		forkChecks      = 20
+	)
+
+	db, teardown := setupDB(b)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, b, db)
+
+	_, _, _, repoStore := setupStores(b, db)
+
+	forkRepoID := repoID + 1
+	createRepo(ctx, b, repoStore, forkRepoID, 1, 0)
+
+	for i := 0; i < commits; i++ {
+		checks := make([]*types.Check, 0, checksPerCommit+forkChecks)
+		for j := 0; j < checksPerCommit; j++ {
+			status := enum.CheckStatusSuccess
+			if j%20 == 0 {
+				status = enum.CheckStatusFailure
+			}
+
+			check := newCheck(repoID, fmt.Sprintf("check-%d", j), status)
+			check.CommitSHA = fmt.Sprintf("%040d", i)
+			checks = append(checks, check)
+		}
+
+		for j := 0; j < forkChecks; j++ {
+			check := newCheck(forkRepoID, fmt.Sprintf("fork-check-%d", j), enum.CheckStatusSuccess)
+			check.CommitSHA = fmt.Sprintf("%040d", i)
+			check.TargetRepoID = &repoID
+			checks = append(checks, check)
+		}
+
+		if err := checkStore.UpsertBatch(ctx, checks, enum.ConflictStrategyOverwrite); err != nil {
+			b.Fatalf("failed to upsert checks: %v", err)
+		}
+	}
+
+	commitSHA := fmt.Sprintf("%040d", commits/2)
+
+	run := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			results, err := checkStore.ListResults(ctx, repoID, commitSHA)
+			if err != nil {
+				b.Fatalf("failed to list check results: %v", err)
+			}
+
+			if len(results) != checksPerCommit+forkChecks {
+				b.Fatalf("expected %d check results, got %d", checksPerCommit+forkChecks, len(results))
+			}
+		}
+	}
+
+	b.Run("index", run)
+
+	if _, err := db.ExecContext(ctx, "DROP INDEX checks_commit_sha_namespace_repo_ids"); err != nil {
+		b.Fatalf("failed to drop index: %v", err)
+	}
+
+	b.Run("no-index", run)
+}
+
+func TestCheckStore_RepoCheckSummary(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	now := time.Now().UnixMilli()
+
+	build := newCheck(repoID, "build", enum.CheckStatusSuccess)
+	build.Started = now - 60_000
+	build.Ended = now
+	if err := checkStore.Upsert(ctx, build); err != nil {
+		t.Fatalf("failed to upsert check: %v", err)
+	}
+
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+	upsertCheck(ctx, t, checkStore, repoID, "lint", enum.CheckStatusPending)
+
+	summary, err := checkStore.RepoCheckSummary(ctx, repoID, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("RepoCheckSummary() error = %v", err)
+	}
+
+	if summary.Total != 3 || summary.Completed != 2 || summary.Succeeded != 1 {
+		t.Errorf("RepoCheckSummary() counts = %d/%d/%d, want 3/2/1",
+			summary.Total, summary.Completed, summary.Succeeded)
+	}
+
+	if summary.PassRate != 0.5 {
+		t.Errorf("RepoCheckSummary() pass rate = %v, want 0.5", summary.PassRate)
+	}
+
+	if summary.MeanTimeToGreen != 60_000 {
+		t.Errorf("RepoCheckSummary() mean time to green = %d, want 60000", summary.MeanTimeToGreen)
+	}
+
+	summary, err = checkStore.RepoCheckSummary(ctx, repoID, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("RepoCheckSummary() error = %v", err)
+	}
+
+	if summary.Total != 0 || summary.PassRate != 0 || summary.MeanTimeToGreen != 0 {
+		t.Errorf("RepoCheckSummary() without status checks = %+v, want zero metrics", summary)
+	}
+}
+
+func TestCheckStore_Summaries(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	upsertCheck(ctx, t, checkStore, repoID, "build", enum.CheckStatusSuccess)
+	upsertCheck(ctx, t, checkStore, repoID, "test", enum.CheckStatusFailure)
+
+	want := map[string]types.CheckCountSummary{
+		testCommitSHA: {Success: 1, Failure: 1},
+	}
+
+	stored, err := checkStore.ListSummaries(ctx, repoID)
+	if err != nil {
+		t.Fatalf("ListSummaries() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(stored, want) {
+		t.Errorf("ListSummaries() = %v, want %v", stored, want)
+	}
+
+	counted, err := checkStore.CountSummaries(ctx, repoID)
+	if err != nil {
+		t.Fatalf("CountSummaries() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(counted, want) {
+		t.Errorf("CountSummaries() = %v, want %v", counted, want)
+	}
+}
+
+func TestCheckStore_Search(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, t, db)
+
+	now := time.Now().UnixMilli()
+	checks := map[string]*types.Check{}
+	for i, c := range []struct {
+		identifier string
+		status     enum.CheckStatus
+		summary    string
+	}{
+		{"build", enum.CheckStatusFailure, "Connection timeout while fetching dependencies"},
+		{"test", enum.CheckStatusFailure, "3 tests failed: TIMEOUT in TestUpload"},
+		{"lint", enum.CheckStatusSuccess, "No issues found"},
+		{"deploy", enum.CheckStatusError, "Deployment timeout exceeded"},
+	} {
+		check := newCheck(repoID, c.identifier, c.status)
+		check.Summary = c.summary
+		check.Updated = now - int64(i)*1000
+		if err := checkStore.Upsert(ctx, check); err != nil {
+			t.Fatalf("failed to upsert check %q: %v", c.identifier, err)
+		}
+		checks[c.identifier] = check
+	}
+
+	tests := []struct {
+		name  string
+		query types.CheckSearchQuery
+		after string
+		want  []string
+	}{
+		{name: "text", query: types.CheckSearchQuery{Text: "timeout"}, want: []string{"build", "test", "deploy"}},
+		{name: "all words", query: types.CheckSearchQuery{Text: "timeout dependencies"}, want: []string{"build"}},
+		{
+			name:  "text and status",
+			query: types.CheckSearchQuery{Text: "timeout", Statuses: []enum.CheckStatus{enum.CheckStatusFailure}},
+			want:  []string{"build", "test"},
+		},
+		{
+			name:  "time range",
+			query: types.CheckSearchQuery{From: time.UnixMilli(now - 2500), To: time.UnixMilli(now)},
+			want:  []string{"test", "lint"},
+		},
+		{name: "other repo", query: types.CheckSearchQuery{RepoID: repoID + 1}, want: []string{}},
+		{name: "limit", query: types.CheckSearchQuery{Limit: 2}, want: []string{"build", "test"}},
+		{name: "after cursor", query: types.CheckSearchQuery{Limit: 2}, after: "test", want: []string{"lint", "deploy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var after *types.CheckSearchCursor
+			if tt.after != "" {
+				after = &types.CheckSearchCursor{Updated: checks[tt.after].Updated, ID: checks[tt.after].ID}
+			}
+
+			result, err := checkStore.Search(ctx, tt.query, after)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			got := make([]string, len(result))
+			for i, c := range result {
+				got[i] = c.Identifier
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// BenchmarkCheckStore_ListForMergeGate compares loading only the required status checks of a commit
+// with listing all status checks of the commit and filtering the required ones.
+func BenchmarkCheckStore_ListForMergeGate(b *testing.B) {
+	const (
+		checksPerCommit = 200
+		requiredChecks  = 10
+	)
+
+	db, teardown := setupDB(b)
+	defer teardown()
+
+	ctx := context.Background()
+	checkStore, repoID := setupCheckStore(ctx, b, db)
+
+	checks := make([]*types.Check, checksPerCommit)
+	for i := range checks {
+		checks[i] = newCheck(repoID, fmt.Sprintf("check-%d", i), enum.CheckStatusSuccess)
+	}
+
+	if err := checkStore.UpsertBatch(ctx, checks, enum.ConflictStrategyOverwrite); err != nil {
+		b.Fatalf("failed to upsert checks: %v", err)
+	}
+
+	required := make([]string, requiredChecks)
+	for i := range required {
+		required[i] = fmt.Sprintf("check-%d", i*checksPerCommit/requiredChecks)
+	}
+
+	b.Run("list_then_filter", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			all, err := checkStore.List(ctx, repoID, testCommitSHA, types.CheckListOptions{
+				ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Size: checksPerCommit}},
+			})
+			if err != nil {
+				b.Fatalf("failed to list checks: %v", err)
+			}
+
+			found := 0
+			for _, check := range all {
+				if slices.Contains(required, check.Identifier) {
+					found++
+				}
+			}
+
+			if found != requiredChecks {
+				b.Fatalf("found %d required checks, want %d", found, requiredChecks)
+			}
+		}
+	})
+
+	b.Run("merge_gate_query", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			found, err := checkStore.ListForMergeGate(ctx, repoID, testCommitSHA, required)
+			if err != nil {
+				b.Fatalf("failed to list checks for merge gate: %v", err)
+			}
+
+			if len(found) != requiredChecks {
+				b.Fatalf("found %d required checks, want %d", len(found), requiredChecks)
+			}
+		}
+	})
+}
@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !debugsql
+// +build !debugsql
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+// newExplainAccessor returns the accessor as is, query plans are only logged in builds with the debugsql build tag.
+func newExplainAccessor(accessor dbtx.Accessor) dbtx.Accessor {
+	return accessor
+}
+
+// ExplainIfDebug is a no-op, query plans are only logged in builds with the debugsql build tag.
+func ExplainIfDebug(context.Context, dbtx.Accessor, string, []any) {}
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build debugsql
+// +build debugsql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// debugSQL is true if the query plans of the status check store queries should be logged.
+// It's only available in builds with the debugsql build tag and enabled with DEBUG_SQL=true.
+var debugSQL, _ = strconv.ParseBool(os.Getenv("DEBUG_SQL"))
+
+// explainAccessor wraps a dbtx.Accessor and logs the query plan of every select query.
+type explainAccessor struct {
+	dbtx.Accessor
+}
+
+// newExplainAccessor returns the accessor wrapped with query plan logging if DEBUG_SQL is enabled.
+func newExplainAccessor(accessor dbtx.Accessor) dbtx.Accessor {
+	if !debugSQL {
+		return accessor
+	}
+
+	return &explainAccessor{Accessor: accessor}
+}
+
+func (a *explainAccessor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ExplainIfDebug(ctx, a.Accessor, query, args)
+	return a.Accessor.QueryContext(ctx, query, args...)
+}
+
+func (a *explainAccessor) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	ExplainIfDebug(ctx, a.Accessor, query, args)
+	return a.Accessor.QueryxContext(ctx, query, args...)
+}
+
+func (a *explainAccessor) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	ExplainIfDebug(ctx, a.Accessor, query, args)
+	return a.Accessor.QueryRowxContext(ctx, query, args...)
+}
+
+func (a *explainAccessor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ExplainIfDebug(ctx, a.Accessor, query, args)
+	return a.Accessor.QueryRowContext(ctx, query, args...)
+}
+
+func (a *explainAccessor) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	ExplainIfDebug(ctx, a.Accessor, query, args)
+	return a.Accessor.GetContext(ctx, dest, query, args...)
+}
+
+func (a *explainAccessor) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	ExplainIfDebug(ctx, a.Accessor, query, args)
+	return a.Accessor.SelectContext(ctx, dest, query, args...)
+}
+
+// ExplainIfDebug runs EXPLAIN ANALYZE for the query and logs the query plan at debug level.
+// Only select queries on PostgreSQL are explained, because EXPLAIN ANALYZE executes the query.
+// It's a no-op unless built with the debugsql build tag and DEBUG_SQL=true is set.
+func ExplainIfDebug(ctx context.Context, db dbtx.Accessor, query string, args []any) {
+	if !debugSQL || db.DriverName() != "postgres" {
+		return
+	}
+
+	trimmed := strings.ToUpper(strings.TrimSpace(query))
+	if !strings.HasPrefix(trimmed, "SELECT") && !strings.HasPrefix(trimmed, "WITH") {
+		return
+	}
+
+	var lines []string
+	if err := db.SelectContext(ctx, &lines, "EXPLAIN ANALYZE "+query, args...); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("sql", query).Msg("failed to explain database query")
+		return
+	}
+
+	log.Ctx(ctx).Debug().
+		Str("sql", query).
+		Strs("args", sanitizeQueryArgs(args)).
+		Str("plan", strings.Join(lines, "\n")).
+		Msg("database query plan")
+}
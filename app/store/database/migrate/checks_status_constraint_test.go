@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"embed"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var checkStatusListRegexp = regexp.MustCompile(`check_status\s+(?:NOT\s+)?IN\s*\(([^)]*)\)`)
+
+// TestChecksStatusConstraint ensures that the latest migration restricting the status of status checks
+// allows exactly the values of enum.CheckStatus - a change of the enum requires a new migration.
+func TestChecksStatusConstraint(t *testing.T) {
+	statuses, _ := enum.GetAllCheckStatuses()
+	expected := make([]string, len(statuses))
+	for i, status := range statuses {
+		expected[i] = string(status)
+	}
+	sort.Strings(expected)
+
+	for _, test := range []struct {
+		name string
+		fsys embed.FS
+		dir  string
+	}{
+		{name: postgresDriverName, fsys: Postgres, dir: postgresSourceDir},
+		{name: sqliteDriverName, fsys: sqlite, dir: sqliteSourceDir},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			files, err := fs.Glob(test.fsys, test.dir+"/*.up.sql")
+			require.NoError(t, err)
+			sort.Strings(files)
+
+			var latest string
+			for _, file := range files {
+				data, err := fs.ReadFile(test.fsys, file)
+				require.NoError(t, err)
+				if strings.Contains(string(data), "checks_status_valid") {
+					latest = string(data)
+				}
+			}
+			require.NotEmpty(t, latest, "no migration creates the checks_status_valid constraint")
+
+			matches := checkStatusListRegexp.FindAllStringSubmatch(latest, -1)
+			require.NotEmpty(t, matches)
+
+			for _, match := range matches {
+				var actual []string
+				for _, value := range strings.Split(match[1], ",") {
+					actual = append(actual, strings.Trim(strings.TrimSpace(value), "'"))
+				}
+				sort.Strings(actual)
+
+				assert.Equal(t, expected, actual)
+			}
+		})
+	}
+}
@@ -100,6 +100,25 @@ func Current(ctx context.Context, db *sqlx.DB) (string, error) {
 	return version, nil
 }
 
+// EnsureMinVersion returns an error if the current version of the database is older than the provided version.
+// Migration versions are prefixed with a zero padded sequence number, so they are compared lexicographically.
+func EnsureMinVersion(ctx context.Context, db *sqlx.DB, minVersion string) error {
+	version, err := Current(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if version < minVersion {
+		if version == "" {
+			version = "none"
+		}
+		return fmt.Errorf("database schema is outdated: current migration version is %q, "+
+			"but at least %q is required; please run the database migrations", version, minVersion)
+	}
+
+	return nil
+}
+
 func getMigrator(db *sqlx.DB) (migrate.Options, error) {
 	before := func(ctx context.Context, _ *sql.Tx, version string) error {
 		ctx = log.Ctx(ctx).With().
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS check_summaries_delete_trigger ON checks;
+DROP TRIGGER IF EXISTS check_summaries_update_trigger ON checks;
+DROP TRIGGER IF EXISTS check_summaries_insert_trigger ON checks;
+DROP FUNCTION IF EXISTS check_summaries_delete();
+DROP FUNCTION IF EXISTS check_summaries_upsert();
+DROP TABLE check_summaries;
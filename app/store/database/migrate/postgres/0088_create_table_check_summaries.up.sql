@@ -0,0 +1,136 @@
+CREATE TABLE check_summaries (
+ check_summary_repo_id INTEGER NOT NULL
+,check_summary_commit_sha TEXT NOT NULL
+,check_summary_pending INTEGER NOT NULL DEFAULT 0
+,check_summary_running INTEGER NOT NULL DEFAULT 0
+,check_summary_success INTEGER NOT NULL DEFAULT 0
+,check_summary_failure INTEGER NOT NULL DEFAULT 0
+,check_summary_error INTEGER NOT NULL DEFAULT 0
+,check_summary_skipped INTEGER NOT NULL DEFAULT 0
+,CONSTRAINT pk_check_summaries PRIMARY KEY (check_summary_repo_id, check_summary_commit_sha)
+,CONSTRAINT fk_check_summary_repo_id FOREIGN KEY (check_summary_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+INSERT INTO check_summaries (
+ check_summary_repo_id
+,check_summary_commit_sha
+,check_summary_pending
+,check_summary_running
+,check_summary_success
+,check_summary_failure
+,check_summary_error
+,check_summary_skipped
+)
+SELECT
+ check_repo_id
+,check_commit_sha
+,COUNT(*) FILTER (WHERE check_status = 'pending')
+,COUNT(*) FILTER (WHERE check_status = 'running')
+,COUNT(*) FILTER (WHERE check_status = 'success')
+,COUNT(*) FILTER (WHERE check_status = 'failure')
+,COUNT(*) FILTER (WHERE check_status = 'error')
+,COUNT(*) FILTER (WHERE check_status = 'skipped')
+FROM checks
+GROUP BY check_repo_id, check_commit_sha;
+
+-- check_summaries_upsert recalculates the summary of a commit after a status check got inserted or updated.
+CREATE OR REPLACE FUNCTION check_summaries_upsert()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    INSERT INTO check_summaries (
+     check_summary_repo_id
+    ,check_summary_commit_sha
+    ,check_summary_pending
+    ,check_summary_running
+    ,check_summary_success
+    ,check_summary_failure
+    ,check_summary_error
+    ,check_summary_skipped
+    )
+    SELECT
+     check_repo_id
+    ,check_commit_sha
+    ,COUNT(*) FILTER (WHERE check_status = 'pending')
+    ,COUNT(*) FILTER (WHERE check_status = 'running')
+    ,COUNT(*) FILTER (WHERE check_status = 'success')
+    ,COUNT(*) FILTER (WHERE check_status = 'failure')
+    ,COUNT(*) FILTER (WHERE check_status = 'error')
+    ,COUNT(*) FILTER (WHERE check_status = 'skipped')
+    FROM checks
+    WHERE check_repo_id = NEW.check_repo_id AND check_commit_sha = NEW.check_commit_sha
+    GROUP BY check_repo_id, check_commit_sha
+    ON CONFLICT (check_summary_repo_id, check_summary_commit_sha) DO UPDATE
+    SET
+     check_summary_pending = EXCLUDED.check_summary_pending
+    ,check_summary_running = EXCLUDED.check_summary_running
+    ,check_summary_success = EXCLUDED.check_summary_success
+    ,check_summary_failure = EXCLUDED.check_summary_failure
+    ,check_summary_error = EXCLUDED.check_summary_error
+    ,check_summary_skipped = EXCLUDED.check_summary_skipped;
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+-- check_summaries_delete recalculates the summary of a commit after a status check got deleted.
+-- It never inserts new summaries, because the repository itself might be getting deleted.
+CREATE OR REPLACE FUNCTION check_summaries_delete()
+    RETURNS TRIGGER
+AS
+$$
+BEGIN
+    UPDATE check_summaries
+    SET
+     check_summary_pending = s.count_pending
+    ,check_summary_running = s.count_running
+    ,check_summary_success = s.count_success
+    ,check_summary_failure = s.count_failure
+    ,check_summary_error = s.count_error
+    ,check_summary_skipped = s.count_skipped
+    FROM (
+        SELECT
+         COUNT(*) FILTER (WHERE check_status = 'pending') AS count_pending
+        ,COUNT(*) FILTER (WHERE check_status = 'running') AS count_running
+        ,COUNT(*) FILTER (WHERE check_status = 'success') AS count_success
+        ,COUNT(*) FILTER (WHERE check_status = 'failure') AS count_failure
+        ,COUNT(*) FILTER (WHERE check_status = 'error') AS count_error
+        ,COUNT(*) FILTER (WHERE check_status = 'skipped') AS count_skipped
+        FROM checks
+        WHERE check_repo_id = OLD.check_repo_id AND check_commit_sha = OLD.check_commit_sha
+    ) AS s
+    WHERE check_summary_repo_id = OLD.check_repo_id AND check_summary_commit_sha = OLD.check_commit_sha;
+
+    DELETE FROM check_summaries
+    WHERE check_summary_repo_id = OLD.check_repo_id
+      AND check_summary_commit_sha = OLD.check_commit_sha
+      AND NOT EXISTS (
+        SELECT 1 FROM checks
+        WHERE check_repo_id = OLD.check_repo_id AND check_commit_sha = OLD.check_commit_sha
+      );
+    RETURN NULL;
+END;
+$$
+    LANGUAGE plpgsql;
+
+CREATE TRIGGER check_summaries_insert_trigger
+    AFTER INSERT
+    ON checks
+    FOR EACH ROW
+EXECUTE PROCEDURE check_summaries_upsert();
+
+CREATE TRIGGER check_summaries_update_trigger
+    AFTER UPDATE OF check_status
+    ON checks
+    FOR EACH ROW
+EXECUTE PROCEDURE check_summaries_upsert();
+
+CREATE TRIGGER check_summaries_delete_trigger
+    AFTER DELETE
+    ON checks
+    FOR EACH ROW
+EXECUTE PROCEDURE check_summaries_delete();
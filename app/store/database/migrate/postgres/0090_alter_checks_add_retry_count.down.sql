@@ -0,0 +1 @@
+ALTER TABLE checks DROP COLUMN check_retry_count;
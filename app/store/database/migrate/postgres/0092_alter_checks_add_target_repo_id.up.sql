@@ -0,0 +1,9 @@
+ALTER TABLE checks
+    ADD COLUMN check_target_repo_id INTEGER
+        CONSTRAINT fk_check_target_repo_id REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL;
+
+CREATE INDEX checks_target_repo_id_commit_sha
+    ON checks(check_target_repo_id, check_commit_sha)
+    WHERE check_target_repo_id IS NOT NULL;
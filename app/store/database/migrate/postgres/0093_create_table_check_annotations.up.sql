@@ -0,0 +1,26 @@
+CREATE TABLE check_annotations (
+ check_annotation_id SERIAL PRIMARY KEY
+,check_annotation_check_id INTEGER NOT NULL
+,check_annotation_repo_id INTEGER NOT NULL
+,check_annotation_commit_sha TEXT NOT NULL
+,check_annotation_path TEXT NOT NULL
+,check_annotation_line_start INTEGER NOT NULL
+,check_annotation_line_end INTEGER NOT NULL
+,check_annotation_level TEXT NOT NULL
+,check_annotation_title TEXT NOT NULL
+,check_annotation_message TEXT NOT NULL
+,CONSTRAINT fk_check_annotation_check_id FOREIGN KEY (check_annotation_check_id)
+    REFERENCES checks (check_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_check_annotation_repo_id FOREIGN KEY (check_annotation_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX check_annotations_repo_id_commit_sha_path
+    ON check_annotations(check_annotation_repo_id, check_annotation_commit_sha, check_annotation_path);
+
+CREATE INDEX check_annotations_check_id
+    ON check_annotations(check_annotation_check_id);
@@ -0,0 +1,20 @@
+CREATE TABLE space_check_policies (
+ space_check_policy_id SERIAL PRIMARY KEY
+,space_check_policy_created_by INTEGER NOT NULL
+,space_check_policy_created BIGINT NOT NULL
+,space_check_policy_updated BIGINT NOT NULL
+,space_check_policy_space_id INTEGER NOT NULL
+,space_check_policy_uid TEXT NOT NULL
+,space_check_policy_retry_policy JSON NOT NULL
+,CONSTRAINT fk_space_check_policy_created_by FOREIGN KEY (space_check_policy_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_space_check_policy_space_id FOREIGN KEY (space_check_policy_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX space_check_policies_space_id_uid
+    ON space_check_policies(space_check_policy_space_id, space_check_policy_uid);
@@ -0,0 +1 @@
+DROP INDEX check_audits_repo_id_timestamp;
@@ -0,0 +1,25 @@
+CREATE TABLE check_events (
+ check_event_id SERIAL PRIMARY KEY
+,check_event_repo_id INTEGER NOT NULL
+,check_event_type TEXT NOT NULL
+,check_event_payload JSON NOT NULL
+,check_event_timestamp BIGINT NOT NULL
+,CONSTRAINT fk_check_event_repo_id FOREIGN KEY (check_event_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX check_events_repo_id_id
+    ON check_events(check_event_repo_id, check_event_id);
+
+CREATE TABLE check_event_snapshots (
+ check_event_snapshot_repo_id INTEGER PRIMARY KEY
+,check_event_snapshot_event_id INTEGER NOT NULL
+,check_event_snapshot_state JSON NOT NULL
+,check_event_snapshot_created BIGINT NOT NULL
+,CONSTRAINT fk_check_event_snapshot_repo_id FOREIGN KEY (check_event_snapshot_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
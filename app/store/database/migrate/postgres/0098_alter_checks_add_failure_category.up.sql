@@ -0,0 +1,2 @@
+ALTER TABLE checks
+    ADD COLUMN check_failure_category TEXT NOT NULL DEFAULT '';
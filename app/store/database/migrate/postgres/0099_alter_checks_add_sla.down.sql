@@ -0,0 +1,2 @@
+ALTER TABLE check_configs DROP COLUMN check_config_sla;
+ALTER TABLE checks DROP COLUMN check_sla_breached;
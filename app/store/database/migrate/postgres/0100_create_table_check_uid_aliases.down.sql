@@ -0,0 +1 @@
+DROP TABLE check_uid_aliases;
@@ -0,0 +1,20 @@
+CREATE TABLE check_uid_aliases (
+ check_uid_alias_id SERIAL PRIMARY KEY
+,check_uid_alias_created_by INTEGER NOT NULL
+,check_uid_alias_created BIGINT NOT NULL
+,check_uid_alias_updated BIGINT NOT NULL
+,check_uid_alias_repo_id INTEGER NOT NULL
+,check_uid_alias_old_uid TEXT NOT NULL
+,check_uid_alias_new_uid TEXT NOT NULL
+,CONSTRAINT fk_check_uid_alias_created_by FOREIGN KEY (check_uid_alias_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_check_uid_alias_repo_id FOREIGN KEY (check_uid_alias_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX check_uid_aliases_repo_id_old_uid
+    ON check_uid_aliases(check_uid_alias_repo_id, check_uid_alias_old_uid);
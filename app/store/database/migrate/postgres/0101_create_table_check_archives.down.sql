@@ -0,0 +1,2 @@
+DROP INDEX checks_created;
+DROP TABLE check_archives;
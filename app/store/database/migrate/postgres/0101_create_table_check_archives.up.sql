@@ -0,0 +1,20 @@
+CREATE TABLE check_archives (
+ check_archive_id SERIAL PRIMARY KEY
+,check_archive_created BIGINT NOT NULL
+,check_archive_repo_id INTEGER NOT NULL
+,check_archive_bucket TEXT NOT NULL
+,check_archive_key TEXT NOT NULL
+,check_archive_period_start BIGINT NOT NULL
+,check_archive_period_end BIGINT NOT NULL
+,check_archive_check_count INTEGER NOT NULL
+,CONSTRAINT fk_check_archive_repo_id FOREIGN KEY (check_archive_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX check_archives_repo_id_period_start
+    ON check_archives(check_archive_repo_id, check_archive_period_start);
+
+CREATE INDEX checks_created
+    ON checks(check_created);
@@ -0,0 +1,2 @@
+ALTER TABLE checks
+    ADD COLUMN check_content_hash TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+DROP INDEX checks_commit_sha_namespace_repo_ids;
+
+DROP INDEX checks_repo_id_commit_sha_namespace_uid;
+
+DELETE FROM checks WHERE check_namespace <> 'default';
+
+CREATE UNIQUE INDEX checks_repo_id_commit_sha_uid
+    ON checks(check_repo_id, check_commit_sha, check_uid);
+
+ALTER TABLE checks DROP COLUMN check_namespace;
@@ -0,0 +1,10 @@
+ALTER TABLE checks
+    ADD COLUMN check_namespace VARCHAR(100) NOT NULL DEFAULT 'default';
+
+DROP INDEX checks_repo_id_commit_sha_uid;
+
+CREATE UNIQUE INDEX checks_repo_id_commit_sha_namespace_uid
+    ON checks(check_repo_id, check_commit_sha, check_namespace, check_uid);
+
+CREATE INDEX checks_commit_sha_namespace_repo_ids
+    ON checks(check_commit_sha, check_namespace, check_repo_id, check_target_repo_id);
@@ -0,0 +1,23 @@
+CREATE TABLE check_acknowledgements (
+ check_acknowledgement_id SERIAL PRIMARY KEY
+,check_acknowledgement_check_id INTEGER NOT NULL
+,check_acknowledgement_check_updated BIGINT NOT NULL
+,check_acknowledgement_created_by INTEGER NOT NULL
+,check_acknowledgement_created BIGINT NOT NULL
+,check_acknowledgement_reason TEXT NOT NULL
+,check_acknowledgement_expires BIGINT NOT NULL
+,CONSTRAINT fk_check_acknowledgement_check_id FOREIGN KEY (check_acknowledgement_check_id)
+    REFERENCES checks (check_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_check_acknowledgement_created_by FOREIGN KEY (check_acknowledgement_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX check_acknowledgements_check_id
+    ON check_acknowledgements(check_acknowledgement_check_id);
+
+CREATE INDEX check_acknowledgements_expires
+    ON check_acknowledgements(check_acknowledgement_expires);
@@ -0,0 +1,2 @@
+ALTER TABLE checks
+    ADD COLUMN check_visibility TEXT NOT NULL DEFAULT 'public';
@@ -0,0 +1 @@
+ALTER TABLE checks DROP CONSTRAINT checks_status_valid;
@@ -0,0 +1,2 @@
+ALTER TABLE checks ADD CONSTRAINT checks_status_valid
+CHECK (check_status IN ('pending', 'running', 'success', 'failure', 'error', 'skipped'));
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS checks_summary_search;
@@ -0,0 +1,2 @@
+CREATE INDEX checks_summary_search
+    ON checks USING GIN (to_tsvector('english', check_summary));
@@ -0,0 +1 @@
+ALTER TABLE check_configs DROP COLUMN check_config_url_template;
@@ -0,0 +1,2 @@
+ALTER TABLE check_configs
+    ADD COLUMN check_config_url_template TEXT NOT NULL DEFAULT '';
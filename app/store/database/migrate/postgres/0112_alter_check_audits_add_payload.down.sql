@@ -0,0 +1 @@
+ALTER TABLE check_audits DROP COLUMN check_audit_payload;
@@ -0,0 +1,4 @@
+CREATE TABLE check_replication_watermarks (
+ check_watermark_region TEXT PRIMARY KEY
+,check_watermark_replicated_until BIGINT NOT NULL
+);
@@ -0,0 +1,9 @@
+CREATE TABLE check_repo_heads (
+ check_repo_head_repo_id INTEGER PRIMARY KEY
+,check_repo_head_commit_sha TEXT NOT NULL
+,check_repo_head_updated BIGINT NOT NULL
+,CONSTRAINT fk_check_repo_head_repo_id FOREIGN KEY (check_repo_head_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
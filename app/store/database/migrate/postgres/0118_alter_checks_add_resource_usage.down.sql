@@ -0,0 +1,7 @@
+UPDATE checks
+SET check_metadata = (check_metadata::jsonb || jsonb_build_object('resource_usage', check_resource_usage::jsonb))::json
+WHERE check_resource_usage IS NOT NULL
+    AND json_typeof(check_metadata) = 'object';
+
+ALTER TABLE checks
+    DROP COLUMN check_resource_usage;
@@ -0,0 +1,9 @@
+ALTER TABLE checks
+    ADD COLUMN check_resource_usage JSON;
+
+UPDATE checks
+SET
+     check_resource_usage = check_metadata->'resource_usage'
+    ,check_metadata = (check_metadata::jsonb - 'resource_usage')::json
+WHERE json_typeof(check_metadata) = 'object'
+    AND json_typeof(check_metadata->'resource_usage') = 'object';
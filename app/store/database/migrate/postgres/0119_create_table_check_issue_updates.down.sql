@@ -0,0 +1 @@
+DROP TABLE check_issue_updates;
@@ -0,0 +1,11 @@
+DELETE FROM check_uid_stats
+WHERE check_uid_stats_namespace <> 'default';
+
+ALTER TABLE check_uid_stats
+    DROP CONSTRAINT pk_check_uid_stats;
+
+ALTER TABLE check_uid_stats
+    ADD CONSTRAINT pk_check_uid_stats PRIMARY KEY (check_uid_stats_repo_id, check_uid_stats_uid);
+
+ALTER TABLE check_uid_stats
+    DROP COLUMN check_uid_stats_namespace;
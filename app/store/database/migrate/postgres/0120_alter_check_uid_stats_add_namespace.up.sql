@@ -0,0 +1,9 @@
+ALTER TABLE check_uid_stats
+    ADD COLUMN check_uid_stats_namespace TEXT NOT NULL DEFAULT 'default';
+
+ALTER TABLE check_uid_stats
+    DROP CONSTRAINT pk_check_uid_stats;
+
+ALTER TABLE check_uid_stats
+    ADD CONSTRAINT pk_check_uid_stats
+    PRIMARY KEY (check_uid_stats_repo_id, check_uid_stats_namespace, check_uid_stats_uid);
@@ -0,0 +1,6 @@
+ALTER TABLE check_audits
+    DROP COLUMN check_audit_payload_encryption_key_id;
+
+ALTER TABLE checks
+    DROP COLUMN check_payload_encryption_key_id,
+    DROP COLUMN check_metadata_encryption_key_id;
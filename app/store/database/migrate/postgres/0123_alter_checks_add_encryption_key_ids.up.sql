@@ -0,0 +1,6 @@
+ALTER TABLE checks
+    ADD COLUMN check_payload_encryption_key_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN check_metadata_encryption_key_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE check_audits
+    ADD COLUMN check_audit_payload_encryption_key_id TEXT NOT NULL DEFAULT '';
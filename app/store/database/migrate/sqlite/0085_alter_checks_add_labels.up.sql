@@ -0,0 +1,2 @@
+ALTER TABLE checks
+    ADD COLUMN check_labels TEXT NOT NULL DEFAULT '[]';
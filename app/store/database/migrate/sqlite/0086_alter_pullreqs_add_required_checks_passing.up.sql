@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_required_checks_passing BOOLEAN;
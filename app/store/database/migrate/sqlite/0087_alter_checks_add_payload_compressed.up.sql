@@ -0,0 +1,2 @@
+ALTER TABLE checks
+    ADD COLUMN check_payload_compressed BOOLEAN NOT NULL DEFAULT FALSE;
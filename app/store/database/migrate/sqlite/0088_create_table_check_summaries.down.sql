@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS check_summaries_delete_trigger;
+DROP TRIGGER IF EXISTS check_summaries_update_trigger;
+DROP TRIGGER IF EXISTS check_summaries_insert_trigger;
+DROP TABLE check_summaries;
@@ -0,0 +1,118 @@
+CREATE TABLE check_summaries (
+ check_summary_repo_id INTEGER NOT NULL
+,check_summary_commit_sha TEXT NOT NULL
+,check_summary_pending INTEGER NOT NULL DEFAULT 0
+,check_summary_running INTEGER NOT NULL DEFAULT 0
+,check_summary_success INTEGER NOT NULL DEFAULT 0
+,check_summary_failure INTEGER NOT NULL DEFAULT 0
+,check_summary_error INTEGER NOT NULL DEFAULT 0
+,check_summary_skipped INTEGER NOT NULL DEFAULT 0
+,CONSTRAINT pk_check_summaries PRIMARY KEY (check_summary_repo_id, check_summary_commit_sha)
+,CONSTRAINT fk_check_summary_repo_id FOREIGN KEY (check_summary_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+INSERT INTO check_summaries (
+ check_summary_repo_id
+,check_summary_commit_sha
+,check_summary_pending
+,check_summary_running
+,check_summary_success
+,check_summary_failure
+,check_summary_error
+,check_summary_skipped
+)
+SELECT
+ check_repo_id
+,check_commit_sha
+,COUNT(*) FILTER (WHERE check_status = 'pending')
+,COUNT(*) FILTER (WHERE check_status = 'running')
+,COUNT(*) FILTER (WHERE check_status = 'success')
+,COUNT(*) FILTER (WHERE check_status = 'failure')
+,COUNT(*) FILTER (WHERE check_status = 'error')
+,COUNT(*) FILTER (WHERE check_status = 'skipped')
+FROM checks
+GROUP BY check_repo_id, check_commit_sha;
+
+CREATE TRIGGER check_summaries_insert_trigger
+    AFTER INSERT
+    ON checks
+BEGIN
+    INSERT INTO check_summaries (
+     check_summary_repo_id
+    ,check_summary_commit_sha
+    ,check_summary_pending
+    ,check_summary_running
+    ,check_summary_success
+    ,check_summary_failure
+    ,check_summary_error
+    ,check_summary_skipped
+    )
+    SELECT
+     check_repo_id
+    ,check_commit_sha
+    ,COUNT(*) FILTER (WHERE check_status = 'pending')
+    ,COUNT(*) FILTER (WHERE check_status = 'running')
+    ,COUNT(*) FILTER (WHERE check_status = 'success')
+    ,COUNT(*) FILTER (WHERE check_status = 'failure')
+    ,COUNT(*) FILTER (WHERE check_status = 'error')
+    ,COUNT(*) FILTER (WHERE check_status = 'skipped')
+    FROM checks
+    WHERE check_repo_id = NEW.check_repo_id AND check_commit_sha = NEW.check_commit_sha
+    GROUP BY check_repo_id, check_commit_sha
+    ON CONFLICT (check_summary_repo_id, check_summary_commit_sha) DO UPDATE
+    SET
+     check_summary_pending = EXCLUDED.check_summary_pending
+    ,check_summary_running = EXCLUDED.check_summary_running
+    ,check_summary_success = EXCLUDED.check_summary_success
+    ,check_summary_failure = EXCLUDED.check_summary_failure
+    ,check_summary_error = EXCLUDED.check_summary_error
+    ,check_summary_skipped = EXCLUDED.check_summary_skipped;
+END;
+
+CREATE TRIGGER check_summaries_update_trigger
+    AFTER UPDATE OF check_status
+    ON checks
+BEGIN
+    UPDATE check_summaries
+    SET
+     check_summary_pending = check_summary_pending
+        - (OLD.check_status = 'pending') + (NEW.check_status = 'pending')
+    ,check_summary_running = check_summary_running
+        - (OLD.check_status = 'running') + (NEW.check_status = 'running')
+    ,check_summary_success = check_summary_success
+        - (OLD.check_status = 'success') + (NEW.check_status = 'success')
+    ,check_summary_failure = check_summary_failure
+        - (OLD.check_status = 'failure') + (NEW.check_status = 'failure')
+    ,check_summary_error = check_summary_error
+        - (OLD.check_status = 'error') + (NEW.check_status = 'error')
+    ,check_summary_skipped = check_summary_skipped
+        - (OLD.check_status = 'skipped') + (NEW.check_status = 'skipped')
+    WHERE check_summary_repo_id = NEW.check_repo_id AND check_summary_commit_sha = NEW.check_commit_sha;
+END;
+
+-- the delete trigger never inserts new summaries, because the repository itself might be getting deleted.
+CREATE TRIGGER check_summaries_delete_trigger
+    AFTER DELETE
+    ON checks
+BEGIN
+    UPDATE check_summaries
+    SET
+     check_summary_pending = check_summary_pending - (OLD.check_status = 'pending')
+    ,check_summary_running = check_summary_running - (OLD.check_status = 'running')
+    ,check_summary_success = check_summary_success - (OLD.check_status = 'success')
+    ,check_summary_failure = check_summary_failure - (OLD.check_status = 'failure')
+    ,check_summary_error = check_summary_error - (OLD.check_status = 'error')
+    ,check_summary_skipped = check_summary_skipped - (OLD.check_status = 'skipped')
+    WHERE check_summary_repo_id = OLD.check_repo_id AND check_summary_commit_sha = OLD.check_commit_sha;
+
+    DELETE FROM check_summaries
+    WHERE check_summary_repo_id = OLD.check_repo_id
+      AND check_summary_commit_sha = OLD.check_commit_sha
+      AND NOT EXISTS (
+        SELECT 1 FROM checks
+        WHERE check_repo_id = OLD.check_repo_id AND check_commit_sha = OLD.check_commit_sha
+      );
+END;
@@ -0,0 +1,20 @@
+CREATE TABLE check_configs (
+ check_config_id INTEGER PRIMARY KEY AUTOINCREMENT
+,check_config_created_by INTEGER NOT NULL
+,check_config_created BIGINT NOT NULL
+,check_config_updated BIGINT NOT NULL
+,check_config_repo_id INTEGER NOT NULL
+,check_config_uid TEXT NOT NULL
+,check_config_retry_policy TEXT NOT NULL
+,CONSTRAINT fk_check_config_created_by FOREIGN KEY (check_config_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_check_config_repo_id FOREIGN KEY (check_config_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX check_configs_repo_id_uid
+    ON check_configs(check_config_repo_id, check_config_uid);
@@ -0,0 +1,2 @@
+ALTER TABLE checks
+    ADD COLUMN check_retry_count INTEGER NOT NULL DEFAULT 0;
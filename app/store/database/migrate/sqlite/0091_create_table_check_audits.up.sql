@@ -0,0 +1,21 @@
+CREATE TABLE check_audits (
+ check_audit_id INTEGER PRIMARY KEY AUTOINCREMENT
+,check_audit_principal_id INTEGER NOT NULL
+,check_audit_timestamp BIGINT NOT NULL
+,check_audit_repo_id INTEGER NOT NULL
+,check_audit_commit_sha TEXT NOT NULL
+,check_audit_check_uid TEXT NOT NULL
+,check_audit_before TEXT
+,check_audit_after TEXT NOT NULL
+,CONSTRAINT fk_check_audit_principal_id FOREIGN KEY (check_audit_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_check_audit_repo_id FOREIGN KEY (check_audit_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX check_audits_principal_id_timestamp
+    ON check_audits(check_audit_principal_id, check_audit_timestamp);
@@ -0,0 +1,2 @@
+DROP INDEX checks_target_repo_id_commit_sha;
+ALTER TABLE checks DROP COLUMN check_target_repo_id;
@@ -0,0 +1,6 @@
+ALTER TABLE checks
+    ADD COLUMN check_target_repo_id INTEGER;
+
+CREATE INDEX checks_target_repo_id_commit_sha
+    ON checks(check_target_repo_id, check_commit_sha)
+    WHERE check_target_repo_id IS NOT NULL;
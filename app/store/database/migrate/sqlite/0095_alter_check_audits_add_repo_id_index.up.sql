@@ -0,0 +1,2 @@
+CREATE INDEX check_audits_repo_id_timestamp
+    ON check_audits(check_audit_repo_id, check_audit_timestamp);
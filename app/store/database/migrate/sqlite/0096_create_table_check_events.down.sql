@@ -0,0 +1,2 @@
+DROP TABLE check_event_snapshots;
+DROP TABLE check_events;
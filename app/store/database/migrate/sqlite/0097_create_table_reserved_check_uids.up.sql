@@ -0,0 +1,20 @@
+CREATE TABLE reserved_check_uids (
+ reserved_check_id INTEGER PRIMARY KEY AUTOINCREMENT
+,reserved_check_created_by INTEGER NOT NULL
+,reserved_check_created BIGINT NOT NULL
+,reserved_check_updated BIGINT NOT NULL
+,reserved_check_space_id INTEGER NOT NULL
+,reserved_check_uid TEXT NOT NULL
+,reserved_check_reporters TEXT NOT NULL
+,CONSTRAINT fk_reserved_check_created_by FOREIGN KEY (reserved_check_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_reserved_check_space_id FOREIGN KEY (reserved_check_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX reserved_check_uids_space_id_uid
+    ON reserved_check_uids(reserved_check_space_id, reserved_check_uid);
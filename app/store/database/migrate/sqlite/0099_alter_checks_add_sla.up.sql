@@ -0,0 +1,4 @@
+ALTER TABLE checks
+    ADD COLUMN check_sla_breached BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE check_configs
+    ADD COLUMN check_config_sla TEXT;
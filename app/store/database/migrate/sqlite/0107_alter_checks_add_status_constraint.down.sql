@@ -0,0 +1,2 @@
+DROP TRIGGER checks_status_valid_insert;
+DROP TRIGGER checks_status_valid_update;
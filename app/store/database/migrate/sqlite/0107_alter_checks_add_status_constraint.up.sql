@@ -0,0 +1,15 @@
+CREATE TRIGGER checks_status_valid_insert
+BEFORE INSERT ON checks
+FOR EACH ROW
+WHEN NEW.check_status NOT IN ('pending', 'running', 'success', 'failure', 'error', 'skipped')
+BEGIN
+    SELECT RAISE(ABORT, 'invalid check_status');
+END;
+
+CREATE TRIGGER checks_status_valid_update
+BEFORE UPDATE OF check_status ON checks
+FOR EACH ROW
+WHEN NEW.check_status NOT IN ('pending', 'running', 'success', 'failure', 'error', 'skipped')
+BEGIN
+    SELECT RAISE(ABORT, 'invalid check_status');
+END;
@@ -0,0 +1 @@
+-- sqlite doesn't have a full-text index on the status check summaries.
@@ -0,0 +1,2 @@
+-- sqlite doesn't support full-text search on regular tables,
+-- the status check summaries are searched with a table scan instead.
@@ -0,0 +1,12 @@
+CREATE TABLE check_uid_stats (
+ check_uid_stats_repo_id INTEGER NOT NULL
+,check_uid_stats_uid TEXT NOT NULL
+,check_uid_stats_last_status TEXT NOT NULL
+,check_uid_stats_flake_score FLOAT NOT NULL DEFAULT 0
+,check_uid_stats_updated BIGINT NOT NULL
+,CONSTRAINT pk_check_uid_stats PRIMARY KEY (check_uid_stats_repo_id, check_uid_stats_uid)
+,CONSTRAINT fk_check_uid_stats_repo_id FOREIGN KEY (check_uid_stats_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE check_audits
+    ADD COLUMN check_audit_namespace VARCHAR(100) NOT NULL DEFAULT 'default';
@@ -0,0 +1,2 @@
+ALTER TABLE check_audits
+    ADD COLUMN check_audit_payload TEXT;
@@ -0,0 +1 @@
+DROP INDEX checks_updated;
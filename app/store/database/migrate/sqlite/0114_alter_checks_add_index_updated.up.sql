@@ -0,0 +1,2 @@
+CREATE INDEX checks_updated
+    ON checks(check_updated);
@@ -0,0 +1,2 @@
+ALTER TABLE space_check_policies DROP COLUMN space_check_policy_required;
+ALTER TABLE check_configs DROP COLUMN check_config_required;
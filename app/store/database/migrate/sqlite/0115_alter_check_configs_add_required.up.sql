@@ -0,0 +1,4 @@
+ALTER TABLE check_configs
+    ADD COLUMN check_config_required BOOLEAN;
+ALTER TABLE space_check_policies
+    ADD COLUMN space_check_policy_required BOOLEAN;
@@ -0,0 +1 @@
+DROP INDEX checks_repo_id_ended;
@@ -0,0 +1,2 @@
+CREATE INDEX checks_repo_id_ended
+    ON checks(check_repo_id, check_ended);
@@ -0,0 +1,7 @@
+UPDATE checks
+SET check_metadata = json_set(check_metadata, '$.resource_usage', json(check_resource_usage))
+WHERE check_resource_usage IS NOT NULL
+    AND CASE WHEN json_valid(check_metadata) THEN json_type(check_metadata) = 'object' ELSE 0 END;
+
+ALTER TABLE checks
+    DROP COLUMN check_resource_usage;
@@ -0,0 +1,10 @@
+ALTER TABLE checks
+    ADD COLUMN check_resource_usage TEXT;
+
+UPDATE checks
+SET
+     check_resource_usage = json_extract(check_metadata, '$.resource_usage')
+    ,check_metadata = json_remove(check_metadata, '$.resource_usage')
+WHERE CASE WHEN json_valid(check_metadata)
+    THEN json_type(check_metadata, '$.resource_usage') = 'object'
+    ELSE 0 END;
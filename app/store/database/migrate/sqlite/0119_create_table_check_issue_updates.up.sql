@@ -0,0 +1,18 @@
+CREATE TABLE check_issue_updates (
+ check_issue_update_event_id TEXT NOT NULL
+,check_issue_update_issue_key TEXT NOT NULL
+,check_issue_update_action TEXT NOT NULL
+,check_issue_update_check_id INTEGER NOT NULL
+,check_issue_update_created BIGINT NOT NULL
+,CONSTRAINT pk_check_issue_updates PRIMARY KEY (
+    check_issue_update_event_id
+   ,check_issue_update_issue_key
+   ,check_issue_update_action)
+,CONSTRAINT fk_check_issue_update_check_id FOREIGN KEY (check_issue_update_check_id)
+    REFERENCES checks (check_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX check_issue_updates_check_id
+    ON check_issue_updates(check_issue_update_check_id);
@@ -0,0 +1,32 @@
+CREATE TABLE check_uid_stats_temp (
+ check_uid_stats_repo_id INTEGER NOT NULL
+,check_uid_stats_namespace TEXT NOT NULL DEFAULT 'default'
+,check_uid_stats_uid TEXT NOT NULL
+,check_uid_stats_last_status TEXT NOT NULL
+,check_uid_stats_flake_score FLOAT NOT NULL DEFAULT 0
+,check_uid_stats_updated BIGINT NOT NULL
+,CONSTRAINT pk_check_uid_stats PRIMARY KEY (check_uid_stats_repo_id, check_uid_stats_namespace, check_uid_stats_uid)
+,CONSTRAINT fk_check_uid_stats_repo_id FOREIGN KEY (check_uid_stats_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+INSERT INTO check_uid_stats_temp (
+     check_uid_stats_repo_id
+    ,check_uid_stats_uid
+    ,check_uid_stats_last_status
+    ,check_uid_stats_flake_score
+    ,check_uid_stats_updated
+)
+SELECT
+     check_uid_stats_repo_id
+    ,check_uid_stats_uid
+    ,check_uid_stats_last_status
+    ,check_uid_stats_flake_score
+    ,check_uid_stats_updated
+FROM check_uid_stats;
+
+DROP TABLE check_uid_stats;
+
+ALTER TABLE check_uid_stats_temp RENAME TO check_uid_stats;
@@ -0,0 +1,26 @@
+DROP INDEX checks_target_repo_id_commit_sha;
+DROP INDEX checks_commit_sha_namespace_repo_ids;
+
+ALTER TABLE checks
+    RENAME COLUMN check_target_repo_id TO check_target_repo_id_old;
+
+ALTER TABLE checks
+    ADD COLUMN check_target_repo_id INTEGER
+        CONSTRAINT fk_check_target_repo_id REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE SET NULL;
+
+-- target repos deleted in the meantime are cleared, like the foreign key would have done.
+UPDATE checks
+SET check_target_repo_id = check_target_repo_id_old
+WHERE check_target_repo_id_old IN (SELECT repo_id FROM repositories);
+
+ALTER TABLE checks
+    DROP COLUMN check_target_repo_id_old;
+
+CREATE INDEX checks_target_repo_id_commit_sha
+    ON checks(check_target_repo_id, check_commit_sha)
+    WHERE check_target_repo_id IS NOT NULL;
+
+CREATE INDEX checks_commit_sha_namespace_repo_ids
+    ON checks(check_commit_sha, check_namespace, check_repo_id, check_target_repo_id);
@@ -94,6 +94,8 @@ type pullReq struct {
 	RebaseCheckStatus enum.MergeCheckStatus `db:"pullreq_rebase_check_status"`
 	RebaseConflicts   null.String           `db:"pullreq_rebase_conflicts,omitempty"`
 
+	RequiredChecksPassing null.Bool `db:"pullreq_required_checks_passing"`
+
 	CommitCount null.Int `db:"pullreq_commit_count"`
 	FileCount   null.Int `db:"pullreq_file_count"`
 	Additions   null.Int `db:"pullreq_additions"`
@@ -131,6 +133,7 @@ const (
 		,pullreq_merge_conflicts
 		,pullreq_rebase_check_status
 		,pullreq_rebase_conflicts
+		,pullreq_required_checks_passing
 		,pullreq_commit_count
 		,pullreq_file_count
 		,pullreq_additions
@@ -425,6 +428,28 @@ func (s *PullReqStore) ResetMergeCheckStatus(
 	return nil
 }
 
+// UpdateRequiredChecksPassing sets whether all status checks required for merging the pull request succeeded.
+func (s *PullReqStore) UpdateRequiredChecksPassing(ctx context.Context, id int64, passing bool) error {
+	const query = `
+	UPDATE pullreqs
+	SET
+		 pullreq_updated = $1
+		,pullreq_version = pullreq_version + 1
+		,pullreq_required_checks_passing = $2
+	WHERE pullreq_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	now := time.Now().UnixMilli()
+
+	_, err := db.ExecContext(ctx, query, now, passing, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update required checks status of pull request")
+	}
+
+	return nil
+}
+
 // Delete the pull request.
 func (s *PullReqStore) Delete(ctx context.Context, id int64) error {
 	const pullReqDelete = `DELETE FROM pullreqs WHERE pullreq_id = $1`
@@ -589,6 +614,42 @@ func (s *PullReqStore) ListOpenByBranchName(
 	return prMap, nil
 }
 
+// ListOpenBySourceSHA returns the open pull requests of the target repository at the source commit.
+func (s *PullReqStore) ListOpenBySourceSHA(
+	ctx context.Context,
+	repoID int64,
+	sourceSHA string,
+) ([]*types.PullReq, error) {
+	stmt := database.Builder.
+		Select(pullReqColumnsNoDescription).
+		From("pullreqs").
+		Where("pullreq_target_repo_id = ?", repoID).
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		Where("pullreq_source_sha = ?", sourceSHA).
+		OrderBy("pullreq_number")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReq, 0)
+
+	err = db.SelectContext(ctx, &dst, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to fetch list of PRs by source SHA")
+	}
+
+	result, err := s.mapSlicePullReq(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (s *PullReqStore) listQuery(opts *types.PullReqFilter) squirrel.SelectBuilder {
 	var stmt squirrel.SelectBuilder
 
@@ -761,38 +822,39 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 	}
 
 	return &types.PullReq{
-		ID:                pr.ID,
-		Version:           pr.Version,
-		Number:            pr.Number,
-		CreatedBy:         pr.CreatedBy,
-		Created:           pr.Created,
-		Updated:           pr.Updated,
-		Edited:            pr.Edited, // TODO: When we remove the DB column, make Edited equal to Updated
-		Closed:            pr.Closed.Ptr(),
-		State:             pr.State,
-		IsDraft:           pr.IsDraft,
-		CommentCount:      pr.CommentCount,
-		UnresolvedCount:   pr.UnresolvedCount,
-		Title:             pr.Title,
-		Description:       pr.Description,
-		SourceRepoID:      pr.SourceRepoID,
-		SourceBranch:      pr.SourceBranch,
-		SourceSHA:         pr.SourceSHA,
-		TargetRepoID:      pr.TargetRepoID,
-		TargetBranch:      pr.TargetBranch,
-		ActivitySeq:       pr.ActivitySeq,
-		MergedBy:          pr.MergedBy.Ptr(),
-		Merged:            pr.Merged.Ptr(),
-		MergeMethod:       (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
-		MergeCheckStatus:  pr.MergeCheckStatus,
-		MergeTargetSHA:    pr.MergeTargetSHA.Ptr(),
-		MergeBaseSHA:      pr.MergeBaseSHA,
-		MergeSHA:          pr.MergeSHA.Ptr(),
-		MergeConflicts:    mergeConflicts,
-		RebaseCheckStatus: pr.RebaseCheckStatus,
-		RebaseConflicts:   rebaseConflicts,
-		Author:            types.PrincipalInfo{},
-		Merger:            nil,
+		ID:                    pr.ID,
+		Version:               pr.Version,
+		Number:                pr.Number,
+		CreatedBy:             pr.CreatedBy,
+		Created:               pr.Created,
+		Updated:               pr.Updated,
+		Edited:                pr.Edited, // TODO: When we remove the DB column, make Edited equal to Updated
+		Closed:                pr.Closed.Ptr(),
+		State:                 pr.State,
+		IsDraft:               pr.IsDraft,
+		CommentCount:          pr.CommentCount,
+		UnresolvedCount:       pr.UnresolvedCount,
+		Title:                 pr.Title,
+		Description:           pr.Description,
+		SourceRepoID:          pr.SourceRepoID,
+		SourceBranch:          pr.SourceBranch,
+		SourceSHA:             pr.SourceSHA,
+		TargetRepoID:          pr.TargetRepoID,
+		TargetBranch:          pr.TargetBranch,
+		ActivitySeq:           pr.ActivitySeq,
+		MergedBy:              pr.MergedBy.Ptr(),
+		Merged:                pr.Merged.Ptr(),
+		MergeMethod:           (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
+		MergeCheckStatus:      pr.MergeCheckStatus,
+		MergeTargetSHA:        pr.MergeTargetSHA.Ptr(),
+		MergeBaseSHA:          pr.MergeBaseSHA,
+		MergeSHA:              pr.MergeSHA.Ptr(),
+		MergeConflicts:        mergeConflicts,
+		RebaseCheckStatus:     pr.RebaseCheckStatus,
+		RebaseConflicts:       rebaseConflicts,
+		RequiredChecksPassing: pr.RequiredChecksPassing.Ptr(),
+		Author:                types.PrincipalInfo{},
+		Merger:                nil,
 		Stats: types.PullReqStats{
 			Conversations:   pr.CommentCount,
 			UnresolvedCount: pr.UnresolvedCount,
@@ -810,40 +872,41 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 	mergeConflicts := strings.Join(pr.MergeConflicts, "\n")
 	rebaseConflicts := strings.Join(pr.RebaseConflicts, "\n")
 	m := &pullReq{
-		ID:                pr.ID,
-		Version:           pr.Version,
-		Number:            pr.Number,
-		CreatedBy:         pr.CreatedBy,
-		Created:           pr.Created,
-		Updated:           pr.Updated,
-		Edited:            pr.Edited, // TODO: When we remove the DB column, make Edited equal to Updated
-		Closed:            null.IntFromPtr(pr.Closed),
-		State:             pr.State,
-		IsDraft:           pr.IsDraft,
-		CommentCount:      pr.CommentCount,
-		UnresolvedCount:   pr.UnresolvedCount,
-		Title:             pr.Title,
-		Description:       pr.Description,
-		SourceRepoID:      pr.SourceRepoID,
-		SourceBranch:      pr.SourceBranch,
-		SourceSHA:         pr.SourceSHA,
-		TargetRepoID:      pr.TargetRepoID,
-		TargetBranch:      pr.TargetBranch,
-		ActivitySeq:       pr.ActivitySeq,
-		MergedBy:          null.IntFromPtr(pr.MergedBy),
-		Merged:            null.IntFromPtr(pr.Merged),
-		MergeMethod:       null.StringFromPtr((*string)(pr.MergeMethod)),
-		MergeCheckStatus:  pr.MergeCheckStatus,
-		MergeTargetSHA:    null.StringFromPtr(pr.MergeTargetSHA),
-		MergeBaseSHA:      pr.MergeBaseSHA,
-		MergeSHA:          null.StringFromPtr(pr.MergeSHA),
-		MergeConflicts:    null.NewString(mergeConflicts, mergeConflicts != ""),
-		RebaseCheckStatus: pr.RebaseCheckStatus,
-		RebaseConflicts:   null.NewString(rebaseConflicts, rebaseConflicts != ""),
-		CommitCount:       null.IntFromPtr(pr.Stats.Commits),
-		FileCount:         null.IntFromPtr(pr.Stats.FilesChanged),
-		Additions:         null.IntFromPtr(pr.Stats.Additions),
-		Deletions:         null.IntFromPtr(pr.Stats.Deletions),
+		ID:                    pr.ID,
+		Version:               pr.Version,
+		Number:                pr.Number,
+		CreatedBy:             pr.CreatedBy,
+		Created:               pr.Created,
+		Updated:               pr.Updated,
+		Edited:                pr.Edited, // TODO: When we remove the DB column, make Edited equal to Updated
+		Closed:                null.IntFromPtr(pr.Closed),
+		State:                 pr.State,
+		IsDraft:               pr.IsDraft,
+		CommentCount:          pr.CommentCount,
+		UnresolvedCount:       pr.UnresolvedCount,
+		Title:                 pr.Title,
+		Description:           pr.Description,
+		SourceRepoID:          pr.SourceRepoID,
+		SourceBranch:          pr.SourceBranch,
+		SourceSHA:             pr.SourceSHA,
+		TargetRepoID:          pr.TargetRepoID,
+		TargetBranch:          pr.TargetBranch,
+		ActivitySeq:           pr.ActivitySeq,
+		MergedBy:              null.IntFromPtr(pr.MergedBy),
+		Merged:                null.IntFromPtr(pr.Merged),
+		MergeMethod:           null.StringFromPtr((*string)(pr.MergeMethod)),
+		MergeCheckStatus:      pr.MergeCheckStatus,
+		MergeTargetSHA:        null.StringFromPtr(pr.MergeTargetSHA),
+		MergeBaseSHA:          pr.MergeBaseSHA,
+		MergeSHA:              null.StringFromPtr(pr.MergeSHA),
+		MergeConflicts:        null.NewString(mergeConflicts, mergeConflicts != ""),
+		RebaseCheckStatus:     pr.RebaseCheckStatus,
+		RebaseConflicts:       null.NewString(rebaseConflicts, rebaseConflicts != ""),
+		RequiredChecksPassing: null.BoolFromPtr(pr.RequiredChecksPassing),
+		CommitCount:           null.IntFromPtr(pr.Stats.Commits),
+		FileCount:             null.IntFromPtr(pr.Stats.FilesChanged),
+		Additions:             null.IntFromPtr(pr.Stats.Additions),
+		Deletions:             null.IntFromPtr(pr.Stats.Deletions),
 	}
 
 	return m
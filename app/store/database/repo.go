@@ -686,6 +686,35 @@ func (s *RepoStore) list(
 	return s.mapToRepos(ctx, dst)
 }
 
+// ListForks returns a list of active forks of a repo.
+func (s *RepoStore) ListForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, filter)
+	stmt = applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing fork list query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 func (s *RepoStore) listAll(
 	ctx context.Context,
 	parentID int64,
@@ -876,6 +905,14 @@ func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squ
 	} else {
 		stmt = stmt.Where("repo_deleted IS NULL")
 	}
+	if filter.FailingChecks {
+		stmt = stmt.Where(`EXISTS (
+			SELECT 1 FROM check_repo_heads
+			JOIN check_summaries ON check_summary_repo_id = check_repo_head_repo_id
+				AND check_summary_commit_sha = check_repo_head_commit_sha
+			WHERE check_repo_head_repo_id = repo_id
+			AND check_summary_failure + check_summary_error > 0)`)
+	}
 	return stmt
 }
 
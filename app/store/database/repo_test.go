@@ -177,9 +177,48 @@ func TestDatabase_ListAll(t *testing.T) {
 	}
 }
 
+func TestDatabase_ListForks(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	_, repoID := setupCheckStore(ctx, t, db)
+	_, _, _, repoStore := setupStores(t, db)
+
+	for _, forkID := range []int64{2, 3} {
+		fork := types.Repository{
+			Identifier: "fork_" + strconv.FormatInt(forkID, 10),
+			ID:         forkID,
+			ParentID:   1,
+			GitUID:     "fork_" + strconv.FormatInt(forkID, 10),
+			ForkID:     repoID,
+		}
+		if err := repoStore.Create(ctx, &fork); err != nil {
+			t.Fatalf("failed to create fork %v", err)
+		}
+	}
+
+	forks, err := repoStore.ListForks(ctx, repoID, &types.RepoFilter{})
+	if err != nil {
+		t.Fatalf("failed to list forks %v", err)
+	}
+	if len(forks) != 2 {
+		t.Fatalf("len(forks) = %v, want %v", len(forks), 2)
+	}
+
+	forks, err = repoStore.ListForks(ctx, repoID, &types.RepoFilter{Query: "fork_3"})
+	if err != nil {
+		t.Fatalf("failed to list forks %v", err)
+	}
+	if len(forks) != 1 || forks[0].ID != 3 {
+		t.Errorf("forks = %v, want only fork 3", forks)
+	}
+}
+
 func createRepo(
 	ctx context.Context,
-	t *testing.T,
+	t testing.TB,
 	repoStore *database.RepoStore,
 	id int64,
 	spaceID int64,
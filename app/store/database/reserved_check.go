@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.ReservedCheckStore = (*ReservedCheckStore)(nil)
+
+// NewReservedCheckStore returns a new ReservedCheckStore.
+func NewReservedCheckStore(db *sqlx.DB) *ReservedCheckStore {
+	return &ReservedCheckStore{
+		db: db,
+	}
+}
+
+// ReservedCheckStore implements store.ReservedCheckStore backed by a relational database.
+type ReservedCheckStore struct {
+	db *sqlx.DB
+}
+
+const (
+	reservedCheckColumns = `
+		 reserved_check_id
+		,reserved_check_created_by
+		,reserved_check_created
+		,reserved_check_updated
+		,reserved_check_space_id
+		,reserved_check_uid
+		,reserved_check_reporters`
+
+	reservedCheckSelectBase = `
+	SELECT` + reservedCheckColumns + `
+	FROM reserved_check_uids`
+)
+
+type reservedCheck struct {
+	ID         int64              `db:"reserved_check_id"`
+	CreatedBy  int64              `db:"reserved_check_created_by"`
+	Created    int64              `db:"reserved_check_created"`
+	Updated    int64              `db:"reserved_check_updated"`
+	SpaceID    int64              `db:"reserved_check_space_id"`
+	Identifier string             `db:"reserved_check_uid"`
+	Reporters  sqlxtypes.JSONText `db:"reserved_check_reporters"`
+}
+
+// List returns all reserved status check identifiers of a space.
+func (s *ReservedCheckStore) List(ctx context.Context, spaceID int64) ([]*types.ReservedCheck, error) {
+	const sqlQuery = reservedCheckSelectBase + `
+	WHERE reserved_check_space_id = $1
+	ORDER BY reserved_check_uid`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*reservedCheck, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list reserved status checks")
+	}
+
+	return mapReservedChecks(dst)
+}
+
+// ListByIdentifier returns the reservations of a status check identifier in any of the spaces.
+func (s *ReservedCheckStore) ListByIdentifier(
+	ctx context.Context,
+	spaceIDs []int64,
+	identifier string,
+) ([]*types.ReservedCheck, error) {
+	if len(spaceIDs) == 0 {
+		return []*types.ReservedCheck{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(reservedCheckColumns).
+		From("reserved_check_uids").
+		Where(squirrel.Eq{"reserved_check_space_id": spaceIDs}).
+		Where("reserved_check_uid = ?", identifier)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*reservedCheck, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list reservations of status check")
+	}
+
+	return mapReservedChecks(dst)
+}
+
+// Replace replaces all reserved status check identifiers of a space with the provided ones.
+func (s *ReservedCheckStore) Replace(
+	ctx context.Context,
+	spaceID int64,
+	reservations []*types.ReservedCheck,
+) error {
+	const sqlDelete = `
+	DELETE FROM reserved_check_uids
+	WHERE reserved_check_space_id = $1`
+
+	const sqlInsert = `
+	INSERT INTO reserved_check_uids (
+		 reserved_check_created_by
+		,reserved_check_created
+		,reserved_check_updated
+		,reserved_check_space_id
+		,reserved_check_uid
+		,reserved_check_reporters
+	) VALUES (
+		 :reserved_check_created_by
+		,:reserved_check_created
+		,:reserved_check_updated
+		,:reserved_check_space_id
+		,:reserved_check_uid
+		,:reserved_check_reporters
+	)
+	RETURNING reserved_check_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlDelete, spaceID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete reserved status checks")
+	}
+
+	for _, reservation := range reservations {
+		reservation.SpaceID = spaceID
+
+		query, arg, err := db.BindNamed(sqlInsert, mapInternalReservedCheck(reservation))
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to bind reserved status check object")
+		}
+
+		if err = db.QueryRowContext(ctx, query, arg...).Scan(&reservation.ID); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Insert reserved status check query failed")
+		}
+	}
+
+	return nil
+}
+
+func mapInternalReservedCheck(r *types.ReservedCheck) *reservedCheck {
+	reporters := r.Reporters
+	if reporters == nil {
+		reporters = []int64{}
+	}
+
+	return &reservedCheck{
+		ID:         r.ID,
+		CreatedBy:  r.CreatedBy,
+		Created:    r.Created,
+		Updated:    r.Updated,
+		SpaceID:    r.SpaceID,
+		Identifier: r.Identifier,
+		Reporters:  EncodeToSQLXJSON(reporters),
+	}
+}
+
+func mapReservedCheck(r *reservedCheck) (*types.ReservedCheck, error) {
+	var reporters []int64
+	if err := r.Reporters.Unmarshal(&reporters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reporters of reserved status check: %w", err)
+	}
+
+	return &types.ReservedCheck{
+		ID:         r.ID,
+		CreatedBy:  r.CreatedBy,
+		Created:    r.Created,
+		Updated:    r.Updated,
+		SpaceID:    r.SpaceID,
+		Identifier: r.Identifier,
+		Reporters:  reporters,
+	}, nil
+}
+
+func mapReservedChecks(reservations []*reservedCheck) ([]*types.ReservedCheck, error) {
+	m := make([]*types.ReservedCheck, len(reservations))
+	for i, r := range reservations {
+		var err error
+		if m[i], err = mapReservedCheck(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
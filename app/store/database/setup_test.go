@@ -47,7 +47,7 @@ func New(dsn string) (*sqlx.DB, error) {
 	return db, nil
 }
 
-func setupDB(t *testing.T) (*sqlx.DB, func()) {
+func setupDB(t testing.TB) (*sqlx.DB, func()) {
 	t.Helper()
 	db, err := New(":memory:")
 	if err != nil {
@@ -63,7 +63,7 @@ func setupDB(t *testing.T) (*sqlx.DB, func()) {
 	}
 }
 
-func setupStores(t *testing.T, db *sqlx.DB) (
+func setupStores(t testing.TB, db *sqlx.DB) (
 	*database.PrincipalStore,
 	*database.SpaceStore,
 	store.SpacePathStore,
@@ -85,7 +85,7 @@ func setupStores(t *testing.T, db *sqlx.DB) (
 
 func createUser(
 	ctx context.Context,
-	t *testing.T,
+	t testing.TB,
 	principalStore *database.PrincipalStore,
 ) {
 	t.Helper()
@@ -99,7 +99,7 @@ func createUser(
 
 func createSpace(
 	ctx context.Context,
-	t *testing.T,
+	t testing.TB,
 	spaceStore *database.SpaceStore,
 	spacePathStore store.SpacePathStore,
 	userID int64,
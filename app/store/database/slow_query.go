@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"time"
+
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+// maxLoggedArgLength is the maximum length of a bound string parameter included in the slow query log.
+const maxLoggedArgLength = 64
+
+// slowQueryAccessor wraps a dbtx.Accessor and logs all queries that take longer than the threshold.
+type slowQueryAccessor struct {
+	dbtx.Accessor
+	threshold time.Duration
+}
+
+// newSlowQueryAccessor returns the accessor wrapped with slow query logging.
+// The accessor is returned as is if the threshold is zero.
+func newSlowQueryAccessor(accessor dbtx.Accessor, threshold time.Duration) dbtx.Accessor {
+	if threshold <= 0 {
+		return accessor
+	}
+
+	return &slowQueryAccessor{
+		Accessor:  accessor,
+		threshold: threshold,
+	}
+}
+
+func (a *slowQueryAccessor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	defer a.logSlowQuery(ctx, time.Now(), query, args)
+	return a.Accessor.ExecContext(ctx, query, args...)
+}
+
+func (a *slowQueryAccessor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	defer a.logSlowQuery(ctx, time.Now(), query, args)
+	return a.Accessor.QueryContext(ctx, query, args...)
+}
+
+func (a *slowQueryAccessor) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	defer a.logSlowQuery(ctx, time.Now(), query, args)
+	return a.Accessor.QueryxContext(ctx, query, args...)
+}
+
+func (a *slowQueryAccessor) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	defer a.logSlowQuery(ctx, time.Now(), query, args)
+	return a.Accessor.QueryRowxContext(ctx, query, args...)
+}
+
+func (a *slowQueryAccessor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	defer a.logSlowQuery(ctx, time.Now(), query, args)
+	return a.Accessor.QueryRowContext(ctx, query, args...)
+}
+
+func (a *slowQueryAccessor) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	defer a.logSlowQuery(ctx, time.Now(), query, args)
+	return a.Accessor.GetContext(ctx, dest, query, args...)
+}
+
+func (a *slowQueryAccessor) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	defer a.logSlowQuery(ctx, time.Now(), query, args)
+	return a.Accessor.SelectContext(ctx, dest, query, args...)
+}
+
+func (a *slowQueryAccessor) logSlowQuery(ctx context.Context, start time.Time, query string, args []any) {
+	duration := time.Since(start)
+	if duration < a.threshold {
+		return
+	}
+
+	log.Ctx(ctx).Warn().
+		Dur("duration", duration).
+		Dur("threshold", a.threshold).
+		Str("sql", query).
+		Strs("args", sanitizeQueryArgs(args)).
+		Str("stack", string(debug.Stack())).
+		Msg("slow database query")
+}
+
+// sanitizeQueryArgs converts the bound query parameters for logging.
+// Binary values (e.g. status check payloads) are replaced with their size and long strings are truncated.
+func sanitizeQueryArgs(args []any) []string {
+	result := make([]string, len(args))
+	for i, arg := range args {
+		// like database/sql, nil pointers are bound as NULL without calling their Value method.
+		if v := reflect.ValueOf(arg); v.Kind() == reflect.Pointer && v.IsNil() {
+			arg = nil
+		}
+
+		if valuer, ok := arg.(driver.Valuer); ok {
+			value, err := valuer.Value()
+			if err != nil {
+				result[i] = "<invalid>"
+				continue
+			}
+			arg = value
+		}
+
+		// binary and raw JSON values (e.g. json.RawMessage) are byte slices of different named types.
+		if v := reflect.ValueOf(arg); v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			result[i] = fmt.Sprintf("<%d bytes>", v.Len())
+			continue
+		}
+
+		switch v := arg.(type) {
+		case nil:
+			result[i] = "NULL"
+		case string:
+			if len(v) > maxLoggedArgLength {
+				v = v[:maxLoggedArgLength] + "..."
+			}
+			result[i] = v
+		default:
+			result[i] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.SpaceCheckPolicyStore = (*SpaceCheckPolicyStore)(nil)
+
+// NewSpaceCheckPolicyStore returns a new SpaceCheckPolicyStore.
+func NewSpaceCheckPolicyStore(db *sqlx.DB) *SpaceCheckPolicyStore {
+	return &SpaceCheckPolicyStore{
+		db: db,
+	}
+}
+
+// SpaceCheckPolicyStore implements store.SpaceCheckPolicyStore backed by a relational database.
+type SpaceCheckPolicyStore struct {
+	db *sqlx.DB
+}
+
+const (
+	spaceCheckPolicyColumns = `
+		 space_check_policy_id
+		,space_check_policy_created_by
+		,space_check_policy_created
+		,space_check_policy_updated
+		,space_check_policy_space_id
+		,space_check_policy_uid
+		,space_check_policy_retry_policy
+		,space_check_policy_required`
+
+	spaceCheckPolicySelectBase = `
+	SELECT` + spaceCheckPolicyColumns + `
+	FROM space_check_policies`
+)
+
+type spaceCheckPolicy struct {
+	ID          int64              `db:"space_check_policy_id"`
+	CreatedBy   int64              `db:"space_check_policy_created_by"`
+	Created     int64              `db:"space_check_policy_created"`
+	Updated     int64              `db:"space_check_policy_updated"`
+	SpaceID     int64              `db:"space_check_policy_space_id"`
+	Identifier  string             `db:"space_check_policy_uid"`
+	RetryPolicy sqlxtypes.JSONText `db:"space_check_policy_retry_policy"`
+	Required    null.Bool          `db:"space_check_policy_required"`
+}
+
+// Find returns the policy of a status check in a space.
+func (s *SpaceCheckPolicyStore) Find(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.SpaceCheckPolicy, error) {
+	const sqlQuery = spaceCheckPolicySelectBase + `
+	WHERE space_check_policy_space_id = $1 AND space_check_policy_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &spaceCheckPolicy{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find space status check policy")
+	}
+
+	return mapSpaceCheckPolicy(dst)
+}
+
+// List returns all status check policies of a space.
+func (s *SpaceCheckPolicyStore) List(ctx context.Context, spaceID int64) ([]*types.SpaceCheckPolicy, error) {
+	const sqlQuery = spaceCheckPolicySelectBase + `
+	WHERE space_check_policy_space_id = $1
+	ORDER BY space_check_policy_uid`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*spaceCheckPolicy, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list space status check policies")
+	}
+
+	return mapSpaceCheckPolicies(dst)
+}
+
+// Replace replaces all status check policies of a space with the provided ones.
+func (s *SpaceCheckPolicyStore) Replace(
+	ctx context.Context,
+	spaceID int64,
+	policies []*types.SpaceCheckPolicy,
+) error {
+	const sqlDelete = `
+	DELETE FROM space_check_policies
+	WHERE space_check_policy_space_id = $1`
+
+	const sqlInsert = `
+	INSERT INTO space_check_policies (
+		 space_check_policy_created_by
+		,space_check_policy_created
+		,space_check_policy_updated
+		,space_check_policy_space_id
+		,space_check_policy_uid
+		,space_check_policy_retry_policy
+		,space_check_policy_required
+	) VALUES (
+		 :space_check_policy_created_by
+		,:space_check_policy_created
+		,:space_check_policy_updated
+		,:space_check_policy_space_id
+		,:space_check_policy_uid
+		,:space_check_policy_retry_policy
+		,:space_check_policy_required
+	)
+	RETURNING space_check_policy_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlDelete, spaceID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete space status check policies")
+	}
+
+	for _, policy := range policies {
+		policy.SpaceID = spaceID
+
+		query, arg, err := db.BindNamed(sqlInsert, mapInternalSpaceCheckPolicy(policy))
+		if err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to bind space status check policy object")
+		}
+
+		if err = db.QueryRowContext(ctx, query, arg...).Scan(&policy.ID); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Insert space status check policy query failed")
+		}
+	}
+
+	return nil
+}
+
+// ListRetryable returns all status check policies that allow automatic retries.
+func (s *SpaceCheckPolicyStore) ListRetryable(ctx context.Context) ([]*types.SpaceCheckPolicy, error) {
+	sqlQuery := spaceCheckPolicySelectBase + `
+	WHERE (space_check_policy_retry_policy->>'max_attempts')::int > 1
+		OR (space_check_policy_retry_policy->>'timeout_seconds')::int > 0`
+	if s.db.DriverName() == SqliteDriverName {
+		sqlQuery = spaceCheckPolicySelectBase + `
+	WHERE json_extract(space_check_policy_retry_policy, '$.max_attempts') > 1
+		OR json_extract(space_check_policy_retry_policy, '$.timeout_seconds') > 0`
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*spaceCheckPolicy, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list retryable space status check policies")
+	}
+
+	return mapSpaceCheckPolicies(dst)
+}
+
+func mapInternalSpaceCheckPolicy(p *types.SpaceCheckPolicy) *spaceCheckPolicy {
+	return &spaceCheckPolicy{
+		ID:          p.ID,
+		CreatedBy:   p.CreatedBy,
+		Created:     p.Created,
+		Updated:     p.Updated,
+		SpaceID:     p.SpaceID,
+		Identifier:  p.Identifier,
+		RetryPolicy: EncodeToSQLXJSON(p.RetryPolicy),
+		Required:    null.BoolFromPtr(p.Required),
+	}
+}
+
+func mapSpaceCheckPolicy(p *spaceCheckPolicy) (*types.SpaceCheckPolicy, error) {
+	var retryPolicy types.RetryPolicy
+	if err := p.RetryPolicy.Unmarshal(&retryPolicy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal space status check retry policy: %w", err)
+	}
+
+	return &types.SpaceCheckPolicy{
+		ID:          p.ID,
+		CreatedBy:   p.CreatedBy,
+		Created:     p.Created,
+		Updated:     p.Updated,
+		SpaceID:     p.SpaceID,
+		Identifier:  p.Identifier,
+		RetryPolicy: retryPolicy,
+		Required:    p.Required.Ptr(),
+	}, nil
+}
+
+func mapSpaceCheckPolicies(policies []*spaceCheckPolicy) ([]*types.SpaceCheckPolicy, error) {
+	result := make([]*types.SpaceCheckPolicy, len(policies))
+	for i, p := range policies {
+		var err error
+		if result[i], err = mapSpaceCheckPolicy(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -16,11 +16,14 @@ package database
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 	"github.com/jmoiron/sqlx"
@@ -58,6 +61,16 @@ var WireSet = wire.NewSet(
 	ProvideSettingsStore,
 	ProvidePublicAccessStore,
 	ProvideCheckStore,
+	ProvideCheckConfigStore,
+	ProvideCheckRepoHeadStore,
+	ProvideCheckIssueUpdateStore,
+	ProvideCheckAuditStore,
+	ProvideCheckAnnotationStore,
+	ProvideSpaceCheckPolicyStore,
+	ProvideReservedCheckStore,
+	ProvideCheckAliasStore,
+	ProvideCheckArchiveStore,
+	ProvideCheckAnalyticsStore,
 	ProvideConnectorStore,
 	ProvideTemplateStore,
 	ProvideTriggerStore,
@@ -298,11 +311,113 @@ func ProvideWebhookExecutionStore(db *sqlx.DB) store.WebhookExecutionStore {
 }
 
 // ProvideCheckStore provides a status check result store.
+// If event sourcing is enabled, the store records all status check changes in the status check event log.
+// Concurrent identical list queries are served by a single database query if list query coalescing is enabled.
+// It fails if the database schema is older than required by the status check store.
 func ProvideCheckStore(
+	ctx context.Context,
 	db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
-) store.CheckStore {
-	return NewCheckStore(db, principalInfoCache)
+	config *types.Config,
+) (store.CheckStore, error) {
+	if err := migrate.EnsureMinVersion(ctx, db, CheckStoreMinMigrationVersion); err != nil {
+		return nil, fmt.Errorf("failed to verify database schema of the status check store: %w", err)
+	}
+
+	if config.Checks.EventSourcing {
+		err := migrate.EnsureMinVersion(ctx, db, EventSourcedCheckStoreMinMigrationVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify database schema of the status check event log: %w", err)
+		}
+	}
+
+	keyRing, err := encrypt.ParseKeyRing(config.Checks.EncryptionKeys, config.Checks.EncryptionKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load status check encryption keys: %w", err)
+	}
+
+	checkStore := NewCheckStore(db, principalInfoCache, keyRing, config.Checks.PayloadCompressionThreshold,
+		config.Checks.SlowQueryThreshold, config.Checks.ListBySHAsLimit)
+
+	var result store.CheckStore = checkStore
+	if config.Checks.EventSourcing {
+		result = NewEventSourcedCheckStore(db, checkStore)
+	}
+
+	if config.Checks.CoalesceListQueries {
+		result = NewCoalescedCheckStore(result)
+	}
+
+	return result, nil
+}
+
+// ProvideCheckReplicationWatermarkStore provides the store of the status check replication watermarks
+// of a read replica.
+func ProvideCheckReplicationWatermarkStore(
+	ctx context.Context,
+	db *sqlx.DB,
+) (store.CheckReplicationWatermarkStore, error) {
+	if err := migrate.EnsureMinVersion(ctx, db, CheckReplicationWatermarkStoreMinMigrationVersion); err != nil {
+		return nil, fmt.Errorf("failed to verify database schema of the status check replication watermarks: %w", err)
+	}
+
+	return NewCheckReplicationWatermarkStore(db), nil
+}
+
+// ProvideCheckConfigStore provides a status check configuration store.
+func ProvideCheckConfigStore(db *sqlx.DB) store.CheckConfigStore {
+	return NewCheckConfigStore(db)
+}
+
+// ProvideCheckRepoHeadStore provides a store of the default branch heads of repos used by status check summaries.
+func ProvideCheckRepoHeadStore(db *sqlx.DB) store.CheckRepoHeadStore {
+	return NewCheckRepoHeadStore(db)
+}
+
+// ProvideCheckIssueUpdateStore provides a store of the issue updates made for failed status checks.
+func ProvideCheckIssueUpdateStore(db *sqlx.DB) store.CheckIssueUpdateStore {
+	return NewCheckIssueUpdateStore(db)
+}
+
+// ProvideCheckAuditStore provides a status check audit log store.
+// The recorded payloads are encrypted with the status check encryption keys.
+func ProvideCheckAuditStore(db *sqlx.DB, config *types.Config) (store.CheckAuditStore, error) {
+	keyRing, err := encrypt.ParseKeyRing(config.Checks.EncryptionKeys, config.Checks.EncryptionKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load status check encryption keys: %w", err)
+	}
+
+	return NewCheckAuditStore(db, keyRing), nil
+}
+
+// ProvideCheckAnnotationStore provides a status check annotation store.
+func ProvideCheckAnnotationStore(db *sqlx.DB) store.CheckAnnotationStore {
+	return NewCheckAnnotationStore(db)
+}
+
+// ProvideSpaceCheckPolicyStore provides a space status check policy store.
+func ProvideSpaceCheckPolicyStore(db *sqlx.DB) store.SpaceCheckPolicyStore {
+	return NewSpaceCheckPolicyStore(db)
+}
+
+// ProvideCheckAnalyticsStore provides a status check analytics store.
+func ProvideCheckAnalyticsStore(db *sqlx.DB, principalInfoCache store.PrincipalInfoCache) store.CheckAnalyticsStore {
+	return NewCheckAnalyticsStore(db, principalInfoCache)
+}
+
+// ProvideCheckAliasStore provides a status check identifier alias store.
+func ProvideCheckAliasStore(db *sqlx.DB) store.CheckAliasStore {
+	return NewCheckAliasStore(db)
+}
+
+// ProvideCheckArchiveStore provides a status check archive store.
+func ProvideCheckArchiveStore(db *sqlx.DB) store.CheckArchiveStore {
+	return NewCheckArchiveStore(db)
+}
+
+// ProvideReservedCheckStore provides a reserved status check identifier store.
+func ProvideReservedCheckStore(db *sqlx.DB) store.ReservedCheckStore {
+	return NewReservedCheckStore(db)
 }
 
 // ProvideSettingsStore provides a settings store.
@@ -24,10 +24,17 @@ type Cache[K any, V any] interface {
 	Get(ctx context.Context, key K) (V, error)
 }
 
+// EvictableCache is an extension of the simple cache abstraction that allows removing cached objects.
+type EvictableCache[K any, V any] interface {
+	Cache[K, V]
+	Evict(ctx context.Context, key K)
+}
+
 // ExtendedCache is an extension of the simple cache abstraction that adds mapping functionality.
 type ExtendedCache[K comparable, V Identifiable[K]] interface {
 	Cache[K, V]
 	Map(ctx context.Context, keys []K) (map[K]V, error)
+	Evict(ctx context.Context, key K)
 }
 
 type Identifiable[K comparable] interface {
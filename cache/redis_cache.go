@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
 )
 
 type Redis[K any, V any] struct {
@@ -100,3 +101,11 @@ func (c *Redis[K, V]) Get(ctx context.Context, key K) (V, error) {
 
 	return item, nil
 }
+
+// Evict removes the object with the provided key from the cache.
+// A failed removal is only logged, the object then expires with the duration of the cache.
+func (c *Redis[K, V]) Evict(ctx context.Context, key K) {
+	if err := c.client.Del(ctx, c.keyEncoder(key)).Err(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to evict object from redis cache")
+	}
+}
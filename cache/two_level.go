@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// TwoLevel is a cache with a small in-process first level (L1) in front of a shared second level (L2),
+// e.g. a Redis cache shared by all instances. A miss of the L1 falls through to the L2, which falls through
+// to its getter, and the found object is stored in both levels.
+// The L1 should use a short duration, as objects evicted on one instance stay in the L1 of the others.
+type TwoLevel[K comparable, V any] struct {
+	l1 *TTLCache[K, V]
+	l2 EvictableCache[K, V]
+}
+
+// NewTwoLevel creates a new TwoLevel cache that stores objects of the l2 cache in-process for l1Duration.
+func NewTwoLevel[K comparable, V any](l2 EvictableCache[K, V], l1Duration time.Duration) *TwoLevel[K, V] {
+	return &TwoLevel[K, V]{
+		l1: New[K, V](cacheGetter[K, V]{cache: l2}, l1Duration),
+		l2: l2,
+	}
+}
+
+// Stop stops the internal purger of stale elements of the L1.
+func (c *TwoLevel[K, V]) Stop() {
+	c.l1.Stop()
+}
+
+// Stats returns number of cache hits of both levels and misses of the L2.
+func (c *TwoLevel[K, V]) Stats() (int64, int64) {
+	l1Hit, _ := c.l1.Stats()
+	l2Hit, l2Miss := c.l2.Stats()
+	return l1Hit + l2Hit, l2Miss
+}
+
+// Get implements the cache.Cache interface.
+func (c *TwoLevel[K, V]) Get(ctx context.Context, key K) (V, error) {
+	return c.l1.Get(ctx, key)
+}
+
+// Evict removes the object with the provided key from both levels.
+// The object is removed only from the L1 of this instance.
+func (c *TwoLevel[K, V]) Evict(ctx context.Context, key K) {
+	c.l1.Evict(ctx, key)
+	c.l2.Evict(ctx, key)
+}
+
+// cacheGetter adapts a cache to the Getter interface, so that it can serve as the getter of another cache.
+type cacheGetter[K any, V any] struct {
+	cache Cache[K, V]
+}
+
+func (g cacheGetter[K, V]) Find(ctx context.Context, key K) (V, error) {
+	return g.cache.Get(ctx, key)
+}
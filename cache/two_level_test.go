@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// mapCache is a second level cache backed by a map that counts the lookups of its getter.
+type mapCache struct {
+	data  map[int]string
+	hits  int64
+	miss  int64
+	finds int
+}
+
+func (c *mapCache) Stats() (int64, int64) {
+	return c.hits, c.miss
+}
+
+func (c *mapCache) Get(_ context.Context, key int) (string, error) {
+	if v, ok := c.data[key]; ok {
+		c.hits++
+		return v, nil
+	}
+
+	c.miss++
+	c.finds++
+	c.data[key] = "value"
+
+	return "value", nil
+}
+
+func (c *mapCache) Evict(_ context.Context, key int) {
+	delete(c.data, key)
+}
+
+func TestTwoLevel(t *testing.T) {
+	ctx := context.Background()
+
+	l2 := &mapCache{data: map[int]string{}}
+	c := NewTwoLevel[int, string](l2, time.Minute)
+	defer c.Stop()
+
+	for range 3 {
+		v, err := c.Get(ctx, 1)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if v != "value" {
+			t.Errorf("Get() = %q, want %q", v, "value")
+		}
+	}
+
+	if l2.finds != 1 || l2.hits != 0 {
+		t.Errorf("second level served %d finds and %d hits, want 1 find and no hits", l2.finds, l2.hits)
+	}
+
+	if hit, miss := c.Stats(); hit != 2 || miss != 1 {
+		t.Errorf("Stats() = %d, %d, want 2, 1", hit, miss)
+	}
+
+	// another instance shares the second level.
+	other := NewTwoLevel[int, string](l2, time.Minute)
+	defer other.Stop()
+
+	if _, err := other.Get(ctx, 1); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if l2.finds != 1 || l2.hits != 1 {
+		t.Errorf("second level served %d finds and %d hits, want 1 find and 1 hit", l2.finds, l2.hits)
+	}
+
+	c.Evict(ctx, 1)
+
+	if _, err := c.Get(ctx, 1); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if l2.finds != 2 {
+		t.Errorf("second level served %d finds after eviction, want 2", l2.finds)
+	}
+}
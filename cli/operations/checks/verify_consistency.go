@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type verifyConsistencyCommand struct {
+	repoRef string
+}
+
+func (c *verifyConsistencyCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	report, err := provide.Client().CheckVerifyConsistency(ctx, c.repoRef)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(report); err != nil {
+		return err
+	}
+
+	if !report.Consistent() {
+		count := len(report.CommitSummaries)
+		if report.RepoSummary != nil {
+			count++
+		}
+		return fmt.Errorf("found %d inconsistent status check summaries", count)
+	}
+
+	return nil
+}
+
+// helper function registers the status check consistency verification command.
+func registerVerifyConsistency(app *kingpin.CmdClause) {
+	c := &verifyConsistencyCommand{}
+
+	cmd := app.Command("verify-consistency",
+		"compares the cached status check summaries of a repository against its status checks").
+		Action(c.run)
+
+	cmd.Arg("repo", "repository reference").
+		Required().
+		StringVar(&c.repoRef)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
@@ -33,9 +34,19 @@ func Register(app *kingpin.Application) {
 	cmd := app.Command("migrate", "database migration tool")
 	registerCurrent(cmd)
 	registerTo(cmd)
+	registerRotateCheckKeys(cmd)
 }
 
 func getDB(ctx context.Context, envfile string) (*sqlx.DB, error) {
+	config, err := getConfig(envfile)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect(ctx, config)
+}
+
+func getConfig(envfile string) (*types.Config, error) {
 	_ = godotenv.Load(envfile)
 
 	config, err := server.LoadConfig()
@@ -43,6 +54,10 @@ func getDB(ctx context.Context, envfile string) (*sqlx.DB, error) {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	return config, nil
+}
+
+func connect(ctx context.Context, config *types.Config) (*sqlx.DB, error) {
 	db, err := database.Connect(ctx, config.Database.Driver, config.Database.Datasource)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create database handle: %w", err)
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/encrypt"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type commandRotateCheckKeys struct {
+	envfile   string
+	batchSize int
+}
+
+func (c *commandRotateCheckKeys) run(_ *kingpin.ParseContext) error {
+	if c.batchSize <= 0 {
+		return errors.New("batch size must be positive")
+	}
+
+	ctx := setupLoggingContext(context.Background())
+
+	config, err := getConfig(c.envfile)
+	if err != nil {
+		return err
+	}
+
+	keyRing, err := encrypt.ParseKeyRing(config.Checks.EncryptionKeys, config.Checks.EncryptionKeyID)
+	if err != nil {
+		return fmt.Errorf("failed to load status check encryption keys: %w", err)
+	}
+
+	db, err := connect(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	// principal infos aren't needed to re-encrypt the stored data.
+	checkStore := database.NewCheckStore(db, nil, keyRing, config.Checks.PayloadCompressionThreshold, 0,
+		config.Checks.ListBySHAsLimit)
+
+	total := 0
+	for {
+		n, err := checkStore.RotateEncryptionKey(ctx, c.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to rotate status check encryption key: %w", err)
+		}
+
+		total += n
+
+		if n < c.batchSize {
+			break
+		}
+	}
+
+	log.Ctx(ctx).Info().Msgf("rotated encryption key of %d status checks to %q", total, keyRing.ActiveKeyID())
+
+	return nil
+}
+
+func registerRotateCheckKeys(app *kingpin.CmdClause) {
+	c := &commandRotateCheckKeys{}
+
+	cmd := app.Command("rotate-check-keys",
+		"re-encrypts the status check payloads and metadata with the active encryption key").
+		Action(c.run)
+
+	cmd.Flag("batch-size", "number of status checks re-encrypted at once").
+		Default("100").
+		IntVar(&c.batchSize)
+
+	cmd.Arg("envfile", "load the environment variable file").
+		Default("").
+		StringVar(&c.envfile)
+}
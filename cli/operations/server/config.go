@@ -25,6 +25,16 @@ import (
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
 	"github.com/harness/gitness/app/gitspace/orchestrator/ide"
+	"github.com/harness/gitness/app/services/checkamqp"
+	"github.com/harness/gitness/app/services/checkarchive"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/checkfederation"
+	"github.com/harness/gitness/app/services/checkfeed"
+	"github.com/harness/gitness/app/services/checkhead"
+	"github.com/harness/gitness/app/services/checkissuetracker"
+	"github.com/harness/gitness/app/services/checkmirror"
+	"github.com/harness/gitness/app/services/checkprocessor"
+	"github.com/harness/gitness/app/services/checkreplication"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/gitspaceevent"
@@ -332,6 +342,11 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
 		InternalWebhooksURL: config.Webhook.InternalWebhooksURL,
+
+		CircuitBreakerFailureRatio: config.Webhook.CircuitBreakerFailureRatio,
+		CircuitBreakerMinRequests:  config.Webhook.CircuitBreakerMinRequests,
+		CircuitBreakerWindow:       config.Webhook.CircuitBreakerWindow,
+		CircuitBreakerOpenTimeout:  config.Webhook.CircuitBreakerOpenTimeout,
 	}
 }
 
@@ -383,6 +398,8 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		OrphanChecksCron:                 config.Checks.OrphanCleanupCron,
+		OrphanChecksDryRun:               config.Checks.OrphanCleanupDryRun,
 	}
 }
 
@@ -393,6 +410,128 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 	}
 }
 
+// ProvideGithubStatusMirrorConfig loads the github status mirror config from the main config.
+func ProvideGithubStatusMirrorConfig(config *types.Config) checkmirror.Config {
+	return checkmirror.Config{
+		Enabled:         config.GithubStatusMirror.Enabled,
+		EventReaderName: config.InstanceID,
+		APIURL:          config.GithubStatusMirror.APIURL,
+		Token:           config.GithubStatusMirror.Token,
+		Concurrency:     config.GithubStatusMirror.Concurrency,
+		MaxRetries:      config.GithubStatusMirror.MaxRetries,
+		MaxBackoff:      config.GithubStatusMirror.MaxBackoff,
+	}
+}
+
+// ProvideGithubChecksSyncConfig loads the github checks sync config from the main config.
+func ProvideGithubChecksSyncConfig(config *types.Config) checkmirror.ChecksSyncConfig {
+	return checkmirror.ChecksSyncConfig{
+		Enabled:         config.GithubChecksSync.Enabled,
+		EventReaderName: config.InstanceID,
+		APIURL:          config.GithubChecksSync.APIURL,
+		AppID:           config.GithubChecksSync.AppID,
+		PrivateKey:      config.GithubChecksSync.PrivateKey,
+		Concurrency:     config.GithubChecksSync.Concurrency,
+		MaxRetries:      config.GithubChecksSync.MaxRetries,
+		MaxBackoff:      config.GithubChecksSync.MaxBackoff,
+	}
+}
+
+// ProvideCheckAMQPPublisherConfig loads the amqp check publisher config from the main config.
+func ProvideCheckAMQPPublisherConfig(config *types.Config) checkamqp.Config {
+	return checkamqp.Config{
+		Enabled:         config.CheckAMQPPublisher.Enabled,
+		EventReaderName: config.InstanceID,
+		URL:             config.CheckAMQPPublisher.URL,
+		Exchange:        config.CheckAMQPPublisher.Exchange,
+		Concurrency:     config.CheckAMQPPublisher.Concurrency,
+		MaxRetries:      config.CheckAMQPPublisher.MaxRetries,
+		DialTimeout:     config.CheckAMQPPublisher.DialTimeout,
+	}
+}
+
+// ProvideCheckIssueTrackerConfig loads the status check issue tracker config from the main config.
+func ProvideCheckIssueTrackerConfig(config *types.Config) checkissuetracker.Config {
+	return checkissuetracker.Config{
+		Enabled:         config.CheckIssueTracker.Enabled,
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.CheckIssueTracker.Concurrency,
+		MaxRetries:      config.CheckIssueTracker.MaxRetries,
+		JiraURL:         config.CheckIssueTracker.JiraURL,
+		JiraUser:        config.CheckIssueTracker.JiraUser,
+		JiraToken:       config.CheckIssueTracker.JiraToken,
+		LinearURL:       config.CheckIssueTracker.LinearURL,
+		LinearToken:     config.CheckIssueTracker.LinearToken,
+	}
+}
+
+// ProvideCheckArchiveConfig loads the status check archive config from the main config.
+func ProvideCheckArchiveConfig(config *types.Config) checkarchive.Config {
+	return checkarchive.Config{
+		Enabled:       config.CheckArchive.Enabled,
+		RetentionTime: config.CheckArchive.RetentionTime,
+		Bucket:        config.CheckArchive.Bucket,
+		Prefix:        config.CheckArchive.Prefix,
+		Endpoint:      config.CheckArchive.Endpoint,
+		PathStyle:     config.CheckArchive.PathStyle,
+	}
+}
+
+// ProvideCheckFeedConfig loads the status check feed config from the main config.
+func ProvideCheckFeedConfig(config *types.Config) checkfeed.Config {
+	return checkfeed.Config{
+		EventReaderName: config.InstanceID,
+	}
+}
+
+// ProvideCheckConfigFileConfig loads the status check config file service config from the main config.
+func ProvideCheckConfigFileConfig(config *types.Config) checkconfig.Config {
+	return checkconfig.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.CheckConfigFile.Concurrency,
+		MaxRetries:      config.CheckConfigFile.MaxRetries,
+	}
+}
+
+// ProvideCheckRepoHeadConfig loads the status check repo head service config from the main config.
+func ProvideCheckRepoHeadConfig(config *types.Config) checkhead.Config {
+	return checkhead.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.CheckRepoHead.Concurrency,
+		MaxRetries:      config.CheckRepoHead.MaxRetries,
+	}
+}
+
+// ProvideChecksFederationConfig loads the status checks federation config from the main config.
+func ProvideChecksFederationConfig(config *types.Config) checkfederation.Config {
+	return checkfederation.Config{
+		Remotes:  config.ChecksFederation.Remotes,
+		CacheTTL: config.ChecksFederation.CacheTTL,
+		Timeout:  config.ChecksFederation.Timeout,
+	}
+}
+
+// ProvideChecksPayloadProcessorConfig loads the status check payload processor config from the main config.
+func ProvideChecksPayloadProcessorConfig(config *types.Config) checkprocessor.Config {
+	return checkprocessor.Config{
+		WASMModulePath: config.ChecksPayloadProcessor.WASMModulePath,
+		Timeout:        config.ChecksPayloadProcessor.Timeout,
+		MemoryLimit:    config.ChecksPayloadProcessor.MemoryLimit,
+	}
+}
+
+// ProvideChecksReplicationConfig loads the status checks replication config from the main config.
+func ProvideChecksReplicationConfig(config *types.Config) checkreplication.Config {
+	return checkreplication.Config{
+		Region:          config.ChecksReplication.Region,
+		Replicas:        config.ChecksReplication.Replicas,
+		Driver:          config.Database.Driver,
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.ChecksReplication.Concurrency,
+		MaxRetries:      config.ChecksReplication.MaxRetries,
+	}
+}
+
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	return keywordsearch.Config{
@@ -105,6 +105,16 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.CheckRetry.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register status check retry service")
+			return err
+		}
+
+		if err := system.services.CheckArchiver.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register status check archiver")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -143,6 +143,14 @@ func (c *HTTPClient) UserDelete(ctx context.Context, key string) error {
 	return err
 }
 
+// CheckVerifyConsistency verifies the cached status check summaries of a repository.
+func (c *HTTPClient) CheckVerifyConsistency(ctx context.Context, repoRef string) (*types.ConsistencyReport, error) {
+	out := new(types.ConsistencyReport)
+	uri := fmt.Sprintf("%s/api/v1/admin/repos/%s/checks/verify-consistency", c.base, url.PathEscape(repoRef))
+	err := c.post(ctx, uri, false, nil, out)
+	return out, err
+}
+
 //
 // http request helper functions
 //
@@ -49,6 +49,9 @@ type Client interface {
 
 	// UserCreatePAT creates a new PAT for the user.
 	UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// CheckVerifyConsistency verifies the cached status check summaries of a repository.
+	CheckVerifyConsistency(ctx context.Context, repoRef string) (*types.ConsistencyReport, error)
 }
 
 // remoteError store the error payload returned
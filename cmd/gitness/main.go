@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/cli"
 	"github.com/harness/gitness/cli/operations/account"
+	"github.com/harness/gitness/cli/operations/checks"
 	"github.com/harness/gitness/cli/operations/hooks"
 	"github.com/harness/gitness/cli/operations/migrate"
 	"github.com/harness/gitness/cli/operations/server"
@@ -44,6 +45,7 @@ func main() {
 
 	user.Register(app)
 	users.Register(app)
+	checks.Register(app)
 
 	account.RegisterLogin(app)
 	account.RegisterRegister(app)
@@ -54,6 +54,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	connectorservice "github.com/harness/gitness/app/connector"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	gitspaceevents "github.com/harness/gitness/app/events/gitspace"
 	gitspaceinfraevents "github.com/harness/gitness/app/events/gitspaceinfra"
@@ -83,6 +84,25 @@ import (
 	"github.com/harness/gitness/app/services"
 	aiagentservice "github.com/harness/gitness/app/services/aiagent"
 	capabilitiesservice "github.com/harness/gitness/app/services/capabilities"
+	"github.com/harness/gitness/app/services/checkamqp"
+	"github.com/harness/gitness/app/services/checkarchive"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/checkconsistency"
+	"github.com/harness/gitness/app/services/checkfairuse"
+	"github.com/harness/gitness/app/services/checkfederation"
+	"github.com/harness/gitness/app/services/checkfeed"
+	"github.com/harness/gitness/app/services/checkhead"
+	"github.com/harness/gitness/app/services/checkhealth"
+	"github.com/harness/gitness/app/services/checkissuetracker"
+	"github.com/harness/gitness/app/services/checkmirror"
+	"github.com/harness/gitness/app/services/checknormalizer"
+	"github.com/harness/gitness/app/services/checkprocessor"
+	"github.com/harness/gitness/app/services/checkrecompute"
+	"github.com/harness/gitness/app/services/checkrecovery"
+	"github.com/harness/gitness/app/services/checkreplication"
+	"github.com/harness/gitness/app/services/checkretry"
+	"github.com/harness/gitness/app/services/checkscaling"
+	"github.com/harness/gitness/app/services/checksearch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
@@ -184,6 +204,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
+		checkevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
 		cliserver.ProvideGitConfig,
@@ -244,6 +265,36 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
+		cliserver.ProvideGithubStatusMirrorConfig,
+		cliserver.ProvideGithubChecksSyncConfig,
+		checkmirror.WireSet,
+		cliserver.ProvideCheckAMQPPublisherConfig,
+		checkamqp.WireSet,
+		cliserver.ProvideCheckIssueTrackerConfig,
+		checkissuetracker.WireSet,
+		cliserver.ProvideCheckArchiveConfig,
+		checkarchive.WireSet,
+		checkhealth.WireSet,
+		cliserver.ProvideCheckFeedConfig,
+		checkfeed.WireSet,
+		checkrecompute.WireSet,
+		checkrecovery.WireSet,
+		checkretry.WireSet,
+		cliserver.ProvideChecksFederationConfig,
+		checkfederation.WireSet,
+		cliserver.ProvideChecksReplicationConfig,
+		checkreplication.WireSet,
+		cliserver.ProvideCheckConfigFileConfig,
+		checkconfig.WireSet,
+		cliserver.ProvideCheckRepoHeadConfig,
+		checkhead.WireSet,
+		checknormalizer.WireSet,
+		cliserver.ProvideChecksPayloadProcessorConfig,
+		checkprocessor.WireSet,
+		checkconsistency.WireSet,
+		checksearch.WireSet,
+		checkscaling.WireSet,
+		checkfairuse.WireSet,
 		settings.WireSet,
 		systemsvc.WireSet,
 		usergroup.WireSet,
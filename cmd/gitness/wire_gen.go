@@ -43,12 +43,13 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/connector"
-	events7 "github.com/harness/gitness/app/events/git"
-	events3 "github.com/harness/gitness/app/events/gitspace"
-	events4 "github.com/harness/gitness/app/events/gitspaceinfra"
-	events5 "github.com/harness/gitness/app/events/pipeline"
-	events6 "github.com/harness/gitness/app/events/pullreq"
-	events2 "github.com/harness/gitness/app/events/repo"
+	events4 "github.com/harness/gitness/app/events/check"
+	events2 "github.com/harness/gitness/app/events/git"
+	events5 "github.com/harness/gitness/app/events/gitspace"
+	events6 "github.com/harness/gitness/app/events/gitspaceinfra"
+	events7 "github.com/harness/gitness/app/events/pipeline"
+	events8 "github.com/harness/gitness/app/events/pullreq"
+	events3 "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
@@ -72,6 +73,25 @@ import (
 	"github.com/harness/gitness/app/services"
 	"github.com/harness/gitness/app/services/aiagent"
 	"github.com/harness/gitness/app/services/capabilities"
+	"github.com/harness/gitness/app/services/checkamqp"
+	"github.com/harness/gitness/app/services/checkarchive"
+	"github.com/harness/gitness/app/services/checkconfig"
+	"github.com/harness/gitness/app/services/checkconsistency"
+	"github.com/harness/gitness/app/services/checkfairuse"
+	"github.com/harness/gitness/app/services/checkfederation"
+	"github.com/harness/gitness/app/services/checkfeed"
+	"github.com/harness/gitness/app/services/checkhead"
+	"github.com/harness/gitness/app/services/checkhealth"
+	"github.com/harness/gitness/app/services/checkissuetracker"
+	"github.com/harness/gitness/app/services/checkmirror"
+	"github.com/harness/gitness/app/services/checknormalizer"
+	"github.com/harness/gitness/app/services/checkprocessor"
+	"github.com/harness/gitness/app/services/checkrecompute"
+	"github.com/harness/gitness/app/services/checkrecovery"
+	"github.com/harness/gitness/app/services/checkreplication"
+	"github.com/harness/gitness/app/services/checkretry"
+	"github.com/harness/gitness/app/services/checkscaling"
+	"github.com/harness/gitness/app/services/checksearch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
@@ -152,7 +172,13 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	spacePathCache := cache.ProvidePathCache(spacePathStore, spacePathTransformation)
 	spaceStore := database.ProvideSpaceStore(db, spacePathCache, spacePathStore)
 	principalInfoView := database.ProvidePrincipalInfoView(db)
-	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
+	pubsubConfig := server.ProvidePubsubConfig(config)
+	universalClient, err := server.ProvideRedis(config)
+	if err != nil {
+		return nil, err
+	}
+	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
+	principalInfoCache := cache.ProvidePrincipalInfoCache(ctx, principalInfoView, pubSub)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
 	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
 	publicAccessStore := database.ProvidePublicAccessStore(db)
@@ -163,8 +189,9 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
 	publicKeyStore := database.ProvidePublicKeyStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore)
-	serviceController := service.NewController(principalUID, authorizer, principalStore)
+	principalInfoEvictor := cache.ProvidePrincipalInfoEvictor(pubSub)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, principalInfoEvictor)
+	serviceController := service.NewController(principalUID, authorizer, principalStore, principalInfoEvictor)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
 	provider, err := url.ProvideURLProvider(config)
@@ -174,19 +201,28 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pipelineStore := database.ProvidePipelineStore(db)
 	executionStore := database.ProvideExecutionStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
-	checkStore := database.ProvideCheckStore(db, principalInfoCache)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
-	settingsStore := database.ProvideSettingsStore(db)
-	settingsService := settings.ProvideService(settingsStore)
-	protectionManager, err := protection.ProvideManager(ruleStore)
+	checkStore, err := database.ProvideCheckStore(ctx, db, principalInfoCache, config)
 	if err != nil {
 		return nil, err
 	}
-	typesConfig := server.ProvideGitConfig(config)
-	universalClient, err := server.ProvideRedis(config)
+	checkAnnotationStore := database.ProvideCheckAnnotationStore(db)
+	checkAnalyticsStore := database.ProvideCheckAnalyticsStore(db, principalInfoCache)
+	checkhealthService := checkhealth.ProvideService(checkAnalyticsStore)
+	checkheadConfig := server.ProvideCheckRepoHeadConfig(config)
+	eventsConfig := server.ProvideEventsConfig(config)
+	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
+	if err != nil {
+		return nil, err
+	}
+	readerFactory, err := events2.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
+	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	typesConfig := server.ProvideGitConfig(config)
 	cacheCache, err := api.ProvideLastCommitCache(typesConfig, universalClient)
 	if err != nil {
 		return nil, err
@@ -201,14 +237,25 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
+	checkRepoHeadStore := database.ProvideCheckRepoHeadStore(db)
+	checkheadService, err := checkhead.ProvideService(ctx, checkheadConfig, readerFactory, eventsReaderFactory, gitInterface, repoStore, checkRepoHeadStore)
+	if err != nil {
+		return nil, err
+	}
+	repoCheckSummaryCache := cache.ProvideRepoCheckSummaryCache(config, universalClient, checkStore)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	settingsStore := database.ProvideSettingsStore(db)
+	settingsService := settings.ProvideService(settingsStore)
+	protectionManager, err := protection.ProvideManager(ruleStore)
+	if err != nil {
+		return nil, err
+	}
 	triggerStore := database.ProvideTriggerStore(db)
 	encrypter, err := encrypt.ProvideEncrypter(config)
 	if err != nil {
 		return nil, err
 	}
 	jobStore := database.ProvideJobStore(db)
-	pubsubConfig := server.ProvidePubsubConfig(config)
-	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
 	executor := job.ProvideExecutor(jobStore, pubSub)
 	lockConfig := server.ProvideLockConfig(config)
 	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
@@ -228,12 +275,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
 	usergroupResolver := usergroup.ProvideUserGroupResolver()
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
-	eventsConfig := server.ProvideEventsConfig(config)
-	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
-	if err != nil {
-		return nil, err
-	}
-	reporter, err := events2.ProvideReporter(eventsSystem)
+	reporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
@@ -251,8 +293,25 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	instrumentService := instrument.ProvideService()
 	userGroupStore := database.ProvideUserGroupStore(db)
 	searchService := usergroup.ProvideSearchService()
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, searchService)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, checkAnnotationStore, checkhealthService, checkheadService, repoCheckSummaryCache, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, searchService)
 	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService, auditService)
+	checkreplicationConfig := server.ProvideChecksReplicationConfig(config)
+	eventsReporter, err := events4.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	readerFactory2, err := events4.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	replicatedCheckStore, err := checkreplication.ProvideReplicatedCheckStore(ctx, checkreplicationConfig, config, checkStore, principalInfoCache, repoCheckSummaryCache, eventsReporter, readerFactory2, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	checkAuditStore, err := database.ProvideCheckAuditStore(db, config)
+	if err != nil {
+		return nil, err
+	}
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
 	if err != nil {
@@ -265,8 +324,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	converterService := converter.ProvideService(fileService, publicaccessService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, replicatedCheckStore, checkAuditStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, replicatedCheckStore, checkAuditStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
 	logStore := logs.ProvideLogStore(db, config)
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
@@ -284,7 +343,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	infraProviderResourceCache := cache.ProvideInfraProviderResourceCache(infraProviderResourceView)
 	gitspaceConfigStore := database.ProvideGitspaceConfigStore(db, principalInfoCache, infraProviderResourceCache)
 	gitspaceInstanceStore := database.ProvideGitspaceInstanceStore(db)
-	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	reporter2, err := events5.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
@@ -297,11 +356,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	dockerClientFactory := infraprovider.ProvideDockerClientFactory(dockerConfig)
-	reporter2, err := events4.ProvideReporter(eventsSystem)
+	reporter3, err := events6.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	dockerProvider := infraprovider.ProvideDockerProvider(dockerConfig, dockerClientFactory, reporter2)
+	dockerProvider := infraprovider.ProvideDockerProvider(dockerConfig, dockerClientFactory, reporter3)
 	factory := infraprovider.ProvideFactory(dockerProvider)
 	infraproviderService := infraprovider2.ProvideInfraProvider(transactor, infraProviderResourceStore, infraProviderConfigStore, infraProviderTemplateStore, factory, spaceStore)
 	gitnessSCM := scm.ProvideGitnessSCM(repoStore, gitInterface, tokenStore, principalStore, provider)
@@ -322,14 +381,14 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	vsCodeWeb := ide.ProvideVSCodeWebService(vsCodeWebConfig)
 	passwordResolver := secret.ProvidePasswordResolver()
 	resolverFactory := secret.ProvideResolverFactory(passwordResolver)
-	orchestratorOrchestrator := orchestrator.ProvideOrchestrator(scmSCM, infraProviderResourceStore, infraProvisioner, containerOrchestrator, eventsReporter, orchestratorConfig, vsCode, vsCodeWeb, resolverFactory)
-	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, eventsReporter, gitspaceEventStore, spaceStore, infraproviderService, orchestratorOrchestrator, scmSCM)
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService)
-	reporter3, err := events5.ProvideReporter(eventsSystem)
+	orchestratorOrchestrator := orchestrator.ProvideOrchestrator(scmSCM, infraProviderResourceStore, infraProvisioner, containerOrchestrator, reporter2, orchestratorConfig, vsCode, vsCodeWeb, resolverFactory)
+	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, reporter2, gitspaceEventStore, spaceStore, infraproviderService, orchestratorOrchestrator, scmSCM)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, checkStore, checkhealthService, checkheadService, principalStore, repoController, membershipStore, listService, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService)
+	reporter4, err := events7.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore, reporter3)
+	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore, reporter4)
 	secretController := secret2.ProvideController(encrypter, secretStore, authorizer, spaceStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
 	scmService := connector.ProvideSCMConnectorHandler(secretStore)
@@ -343,35 +402,39 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	userGroupReviewersStore := database.ProvideUserGroupReviewerStore(db, principalInfoCache, userGroupStore)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	reporter4, err := events6.ProvideReporter(eventsSystem)
+	checkAliasStore := database.ProvideCheckAliasStore(db)
+	checkConfigStore := database.ProvideCheckConfigStore(db)
+	spaceCheckPolicyStore := database.ProvideSpaceCheckPolicyStore(db)
+	checkconfigResolver := checkconfig.ProvideResolver(checkConfigStore, spaceCheckPolicyStore, spaceStore)
+	checkrecomputeService, err := checkrecompute.ProvideService(jobScheduler, executor, repoStore, pullReqStore, checkStore, checkAliasStore, protectionManager, checkconfigResolver)
 	if err != nil {
 		return nil, err
 	}
-	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events7.ProvideReaderFactory(eventsSystem)
+	reporter5, err := events8.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	eventsReaderFactory, err := events6.ProvideReaderFactory(eventsSystem)
+	migrator := codecomments.ProvideMigrator(gitInterface)
+	readerFactory3, err := events8.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter4, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, readerFactory3, reporter5, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
 	if err != nil {
 		return nil, err
 	}
 	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, transactor, mutexManager)
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, reporter4, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, searchService)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, checkAliasStore, checkconfigResolver, checkrecomputeService, gitInterface, reporter5, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, settingsService, instrumentService, searchService)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, labelStore)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, readerFactory3, readerFactory2, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, labelStore, checkStore)
 	if err != nil {
 		return nil, err
 	}
 	preprocessor := webhook2.ProvidePreprocessor()
 	webhookController := webhook2.ProvideController(authorizer, spaceStore, repoStore, webhookService, encrypter, preprocessor)
-	reporter5, err := events7.ProvideReporter(eventsSystem)
+	reporter6, err := events2.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
@@ -387,13 +450,42 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter5, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter6, reporter, gitInterface, pullReqStore, replicatedCheckStore, checkAliasStore, checkAuditStore, transactor, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
 	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
 	principalController := principal.ProvideController(principalStore, authorizer)
 	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, authorizer, searchService)
+	reservedCheckStore := database.ProvideReservedCheckStore(db)
 	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
+	checkfederationConfig := server.ProvideChecksFederationConfig(config)
+	federatedCheckStore, err := checkfederation.ProvideFederatedCheckStore(checkfederationConfig, checkStore)
+	if err != nil {
+		return nil, err
+	}
+	checkrecoveryService := checkrecovery.ProvideService(transactor, replicatedCheckStore, checkAuditStore)
+	payloadNormalizer := checknormalizer.ProvidePayloadNormalizer()
+	checkfeedConfig := server.ProvideCheckFeedConfig(config)
+	checkfeedService, err := checkfeed.ProvideService(ctx, checkfeedConfig, readerFactory2, pubSub, repoStore, spaceStore)
+	if err != nil {
+		return nil, err
+	}
+	checkArchiveStore := database.ProvideCheckArchiveStore(db)
+	checkarchiveConfig := server.ProvideCheckArchiveConfig(config)
+	archiver, err := checkarchive.ProvideArchiver(checkarchiveConfig, jobScheduler, executor, transactor, replicatedCheckStore, checkArchiveStore)
+	if err != nil {
+		return nil, err
+	}
+	checkprocessorConfig := server.ProvideChecksPayloadProcessorConfig(config)
+	payloadProcessor, err := checkprocessor.ProvidePayloadProcessor(ctx, checkprocessorConfig)
+	if err != nil {
+		return nil, err
+	}
+	reportRateLimiter := check2.ProvideReportRateLimiter(config)
+	checkconsistencyService := checkconsistency.ProvideService(checkStore, repoCheckSummaryCache)
+	checkSearchService := checksearch.ProvideCheckSearchService(checkStore)
+	checkscalingService := checkscaling.ProvideService(config, checkAnalyticsStore)
+	checkfairuseService := checkfairuse.ProvideService(config, checkAnalyticsStore)
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, spaceStore, checkStore, checkConfigStore, checkAuditStore, checkAnnotationStore, spaceCheckPolicyStore, reservedCheckStore, checkAliasStore, gitInterface, v, eventsReporter, checkrecomputeService, federatedCheckStore, checkrecoveryService, payloadNormalizer, checkAnalyticsStore, checkfeedService, checkArchiveStore, archiver, replicatedCheckStore, payloadProcessor, reportRateLimiter, checkconsistencyService, checkSearchService, checkscalingService, checkfairuseService, pullReqStore, protectionManager, searchService)
+	systemController := system.NewController(principalStore, checkStore, config)
 	blobConfig, err := server.ProvideBlobStoreConfig(config)
 	if err != nil {
 		return nil, err
@@ -404,7 +496,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController, repoStore, checkStore, gitInterface)
 	infraproviderController := infraprovider3.ProvideController(authorizer, spaceStore, infraproviderService)
 	limiterGitspace := limiter.ProvideGitspaceLimiter()
 	gitspaceController := gitspace2.ProvideController(transactor, authorizer, infraproviderService, gitspaceConfigStore, gitspaceInstanceStore, spaceStore, gitspaceEventStore, statefulLogger, scmSCM, repoStore, gitspaceService, limiterGitspace)
@@ -467,7 +559,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	serverServer := server2.ProvideServer(config, routerRouter)
 	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalInfoCache)
 	sshServer := ssh.ProvideServer(config, publickeyService, repoController)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter3)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, replicatedCheckStore, checkAuditStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter4)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
@@ -476,7 +568,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
 	triggerConfig := server.ProvideTriggerConfig(config)
-	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
+	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, readerFactory3)
 	if err != nil {
 		return nil, err
 	}
@@ -489,45 +581,72 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	repoService, err := repo2.ProvideService(ctx, config, reporter, readerFactory2, repoStore, provider, gitInterface, lockerLocker)
+	repoService, err := repo2.ProvideService(ctx, config, reporter, eventsReaderFactory, repoStore, provider, gitInterface, lockerLocker)
 	if err != nil {
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, replicatedCheckStore)
 	if err != nil {
 		return nil, err
 	}
 	mailerMailer := mailer.ProvideMailClient(config)
 	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, readerFactory3, readerFactory2, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, checkConfigStore, checkAnalyticsStore, provider)
 	if err != nil {
 		return nil, err
 	}
 	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
-	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, readerFactory2, repoStore, indexer)
+	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, eventsReaderFactory, repoStore, indexer)
+	if err != nil {
+		return nil, err
+	}
+	checkmirrorConfig := server.ProvideGithubStatusMirrorConfig(config)
+	githubStatusMirror, err := checkmirror.ProvideGithubStatusMirror(ctx, checkmirrorConfig, readerFactory2, checkStore, settingsService)
+	if err != nil {
+		return nil, err
+	}
+	checksSyncConfig := server.ProvideGithubChecksSyncConfig(config)
+	gitHubChecksSyncService, err := checkmirror.ProvideGitHubChecksSyncService(ctx, checksSyncConfig, readerFactory2, checkStore, settingsService)
+	if err != nil {
+		return nil, err
+	}
+	checkamqpConfig := server.ProvideCheckAMQPPublisherConfig(config)
+	amqpCheckPublisher, err := checkamqp.ProvideAMQPCheckPublisher(ctx, checkamqpConfig, readerFactory2)
+	if err != nil {
+		return nil, err
+	}
+	checkissuetrackerConfig := server.ProvideCheckIssueTrackerConfig(config)
+	checkIssueUpdateStore := database.ProvideCheckIssueUpdateStore(db)
+	checkIssueTrackerIntegration, err := checkissuetracker.ProvideCheckIssueTrackerIntegration(ctx, checkissuetrackerConfig, readerFactory2, checkStore, checkIssueUpdateStore, repoStore, gitInterface, settingsService)
+	if err != nil {
+		return nil, err
+	}
+	checkretryService, err := checkretry.ProvideService(jobScheduler, executor, checkConfigStore, spaceCheckPolicyStore, checkconfigResolver, repoStore, replicatedCheckStore, pipelineStore, executionStore, triggererTriggerer, checkAuditStore, transactor)
+	if err != nil {
+		return nil, err
+	}
+	checkconfigConfig := server.ProvideCheckConfigFileConfig(config)
+	parser := checkconfig.ProvideParser(gitInterface)
+	checkconfigService, err := checkconfig.ProvideService(ctx, checkconfigConfig, readerFactory, parser, transactor, repoStore, replicatedCheckStore, checkConfigStore, checkAuditStore)
 	if err != nil {
 		return nil, err
 	}
 	gitspaceeventConfig := server.ProvideGitspaceEventConfig(config)
-	readerFactory3, err := events3.ProvideReaderFactory(eventsSystem)
+	readerFactory4, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory3, gitspaceEventStore)
+	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory4, gitspaceEventStore)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory4, err := events4.ProvideReaderFactory(eventsSystem)
+	readerFactory5, err := events6.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory4, orchestratorOrchestrator, gitspaceService, eventsReporter)
+	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory5, orchestratorOrchestrator, gitspaceService, reporter2)
 	if err != nil {
 		return nil, err
 	}
@@ -540,7 +659,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, githubStatusMirror, gitHubChecksSyncService, amqpCheckPublisher, checkIssueTrackerIntegration, checkretryService, archiver, checkconfigService, gitspaceServices, instrumentService, consumer, repositoryCount)
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errNoActiveKey = errors.New("no active encryption key")
+
+// KeyRing holds a set of encryption keys identified by their IDs.
+// New data is encrypted with the active key, while data encrypted with
+// any of the keys of the ring can be decrypted, which allows key rotation.
+type KeyRing struct {
+	encrypters  map[string]Encrypter
+	activeKeyID string
+}
+
+// NewKeyRing returns a new KeyRing for the keys mapped by their IDs.
+// An empty activeKeyID means that the key ring can only be used for decryption.
+func NewKeyRing(keys map[string]string, activeKeyID string) (*KeyRing, error) {
+	encrypters := make(map[string]Encrypter, len(keys))
+	for id, key := range keys {
+		if id == "" {
+			return nil, errors.New("encryption key ID must not be empty")
+		}
+
+		e, err := New(key, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", id, err)
+		}
+
+		encrypters[id] = e
+	}
+
+	if _, ok := encrypters[activeKeyID]; activeKeyID != "" && !ok {
+		return nil, fmt.Errorf("active encryption key %q not found", activeKeyID)
+	}
+
+	return &KeyRing{
+		encrypters:  encrypters,
+		activeKeyID: activeKeyID,
+	}, nil
+}
+
+// ParseKeyRing returns a new KeyRing for the keys in the format "id:key".
+func ParseKeyRing(keys []string, activeKeyID string) (*KeyRing, error) {
+	m := make(map[string]string, len(keys))
+	for _, k := range keys {
+		id, key, ok := strings.Cut(k, ":")
+		if !ok {
+			return nil, errors.New("encryption key must be in the format \"id:key\"")
+		}
+
+		if _, exists := m[id]; exists {
+			return nil, fmt.Errorf("duplicate encryption key %q", id)
+		}
+
+		m[id] = key
+	}
+
+	return NewKeyRing(m, activeKeyID)
+}
+
+// ActiveKeyID returns the ID of the key used for encryption, or an empty string if there is none.
+func (r *KeyRing) ActiveKeyID() string {
+	return r.activeKeyID
+}
+
+// Encrypt encrypts the plaintext with the active key and returns the ID of the key with the ciphertext.
+func (r *KeyRing) Encrypt(plaintext string) (string, []byte, error) {
+	if r.activeKeyID == "" {
+		return "", nil, errNoActiveKey
+	}
+
+	ciphertext, err := r.encrypters[r.activeKeyID].Encrypt(plaintext)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return r.activeKeyID, ciphertext, nil
+}
+
+// Decrypt decrypts the ciphertext with the key of the provided ID.
+func (r *KeyRing) Decrypt(keyID string, ciphertext []byte) (string, error) {
+	e, ok := r.encrypters[keyID]
+	if !ok {
+		return "", fmt.Errorf("encryption key %q not found", keyID)
+	}
+
+	return e.Decrypt(ciphertext)
+}
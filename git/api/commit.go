@@ -71,6 +71,7 @@ type CommitFilter struct {
 	Since     int64
 	Until     int64
 	Committer string
+	Author    string
 }
 
 // CommitDivergenceRequest contains the refs for which the converging commits should be counted.
@@ -178,6 +179,9 @@ func (g *Git) listCommitSHAs(
 	if filter.Committer != "" {
 		cmd.Add(command.WithFlag("--committer", filter.Committer))
 	}
+	if filter.Author != "" {
+		cmd.Add(command.WithFlag("--author", filter.Author))
+	}
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
 	if err != nil {
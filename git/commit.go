@@ -117,6 +117,10 @@ type ListCommitsParams struct {
 	// Committer allows to filter for commits based on the committer - Optional, ignored if string is empty.
 	Committer string
 
+	// Author allows to filter for commits based on the author - Optional, ignored if string is empty.
+	// Same as for the committer, the value is a regular expression matched against "name <email>".
+	Author string
+
 	// IncludeStats allows to include information about inserted, deletions and status for changed files.
 	IncludeStats bool
 }
@@ -162,6 +166,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 			Since:     params.Since,
 			Until:     params.Until,
 			Committer: params.Committer,
+			Author:    params.Author,
 		},
 	)
 	if err != nil {
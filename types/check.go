@@ -16,10 +16,40 @@ package types
 
 import (
 	"encoding/json"
+	"regexp"
+	"slices"
+	"time"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types/enum"
 )
 
+// CheckIdentifierRegexp is the regular expression status check identifiers have to match.
+// TODO: Can we drop the '$' - depends on whether harness allows it.
+const CheckIdentifierRegexp = "^[0-9a-zA-Z-_.$/]{1,127}$"
+
+var checkIdentifierMatcher = regexp.MustCompile(CheckIdentifierRegexp)
+
+// IsValidCheckIdentifier returns true if the status check identifier matches CheckIdentifierRegexp.
+func IsValidCheckIdentifier(identifier string) bool {
+	return checkIdentifierMatcher.MatchString(identifier)
+}
+
+const (
+	// CheckNamespaceDefault is the namespace of status checks that are reported without a namespace.
+	CheckNamespaceDefault = "default"
+
+	// CheckNamespaceRegexp is the regular expression status check namespaces have to match.
+	CheckNamespaceRegexp = "^[0-9a-zA-Z-_.]{1,100}$"
+)
+
+var checkNamespaceMatcher = regexp.MustCompile(CheckNamespaceRegexp)
+
+// IsValidCheckNamespace returns true if the status check namespace matches CheckNamespaceRegexp.
+func IsValidCheckNamespace(namespace string) bool {
+	return checkNamespaceMatcher.MatchString(namespace)
+}
+
 type Check struct {
 	ID         int64            `json:"id"`
 	CreatedBy  int64            `json:"-"` // clients will use "reported_by"
@@ -28,15 +58,42 @@ type Check struct {
 	RepoID     int64            `json:"-"` // status checks are always returned for a commit in a repository
 	CommitSHA  string           `json:"-"` // status checks are always returned for a commit in a repository
 	Identifier string           `json:"identifier"`
+	Namespace  string           `json:"namespace,omitempty"`
 	Status     enum.CheckStatus `json:"status"`
 	Summary    string           `json:"summary,omitempty"`
 	Link       string           `json:"link,omitempty"`
 	Metadata   json.RawMessage  `json:"metadata"`
 	Started    int64            `json:"started,omitempty"`
 	Ended      int64            `json:"ended,omitempty"`
+	Labels     []string         `json:"labels,omitempty"`
+	RetryCount int              `json:"retry_count,omitempty"`
+
+	// FailureCategory is the kind of problem the status check represents if it didn't succeed.
+	FailureCategory enum.CheckFailureCategory `json:"failure_category,omitempty"`
+
+	// SLABreached is true if the status check took longer to complete than allowed by the SLA of its configuration.
+	SLABreached bool `json:"sla_breached,omitempty"`
+
+	// Visibility limits who can see the status check, e.g. for status checks with security scan results.
+	Visibility enum.CheckVisibility `json:"visibility,omitempty"`
+
+	// TargetRepoID is set if the status check logically belongs to the evaluation
+	// of the same commit in another repository.
+	TargetRepoID *int64 `json:"target_repo_id,omitempty"`
+
+	// ResourceUsage holds the resources consumed by the status check run, if reported.
+	ResourceUsage *CheckResourceUsage `json:"resource_usage,omitempty"`
 
 	Payload    CheckPayload   `json:"payload"`
 	ReportedBy *PrincipalInfo `json:"reported_by,omitempty"`
+
+	// StaleAt is set if the status check was read from a replica. The replica contains
+	// all status check changes reported until this time (unix millis), later changes might be missing.
+	StaleAt int64 `json:"stale_at,omitempty"`
+
+	// Deduplicated is true if the reported status check had the same status, summary and payload
+	// as the stored one, which was returned instead and left untouched.
+	Deduplicated bool `json:"deduplicated,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -55,6 +112,14 @@ func (c Check) MarshalJSON() ([]byte, error) {
 type CheckResult struct {
 	Identifier string           `json:"identifier" db:"check_uid"`
 	Status     enum.CheckStatus `json:"status" db:"check_status"`
+	// Acknowledged is true if the status check has an unexpired acknowledgement.
+	Acknowledged bool `json:"acknowledged,omitempty" db:"check_acknowledged"`
+}
+
+// IsSatisfied returns true if the status check result fulfills a status check requirement.
+// Acknowledged failures are neutral and don't prevent the requirement from being fulfilled.
+func (s CheckResult) IsSatisfied() bool {
+	return s.Status.IsSatisfied() || s.Acknowledged && s.Status.IsFailed()
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -74,17 +139,669 @@ type CheckPayload struct {
 	Version string                `json:"version"`
 	Kind    enum.CheckPayloadKind `json:"kind"`
 	Data    json.RawMessage       `json:"data"`
+	Steps   []CheckStep           `json:"steps,omitempty"`
+}
+
+// CheckPayloadDiff holds the structural difference between the payloads of two status check reports,
+// identified by their audit log entries.
+type CheckPayloadDiff struct {
+	FromEntryID int64                   `json:"from_entry_id"`
+	ToEntryID   int64                   `json:"to_entry_id"`
+	Added       []CheckPayloadFieldDiff `json:"added"`
+	Removed     []CheckPayloadFieldDiff `json:"removed"`
+	Changed     []CheckPayloadFieldDiff `json:"changed"`
+}
+
+// CheckPayloadFieldDiff holds a single difference of two status check payloads.
+type CheckPayloadFieldDiff struct {
+	// Path is the JSON pointer (RFC 6901) of the field in the payload.
+	Path string          `json:"path"`
+	Old  json.RawMessage `json:"old,omitempty"`
+	New  json.RawMessage `json:"new,omitempty"`
+}
+
+// CheckStep holds the result of a single step executed as part of a status check.
+type CheckStep struct {
+	Name     string           `json:"name"`
+	Status   enum.CheckStatus `json:"status"`
+	Duration int64            `json:"duration,omitempty"` // in milliseconds
+	Log      string           `json:"log,omitempty"`
+}
+
+// CheckResourceUsage holds the resources consumed by a status check run.
+type CheckResourceUsage struct {
+	CPUSeconds   float64 `json:"cpu_seconds"`
+	MemoryMB     int     `json:"memory_mb"`
+	NetworkBytes int64   `json:"network_bytes"`
+}
+
+// CheckResourceUsageSummary holds the aggregated resource usage of all runs of a status check.
+type CheckResourceUsageSummary struct {
+	Identifier   string  `json:"identifier" db:"check_uid"`
+	Runs         int     `json:"runs" db:"runs"`
+	CPUSeconds   float64 `json:"cpu_seconds" db:"cpu_seconds"`
+	MaxMemoryMB  int     `json:"max_memory_mb" db:"max_memory_mb"`
+	NetworkBytes int64   `json:"network_bytes" db:"network_bytes"`
+}
+
+// CheckResourceUsageOptions holds the status check resource usage query parameters.
+type CheckResourceUsageOptions struct {
+	From time.Time
+	To   time.Time
+}
+
+// CheckLeaderEntry holds the number of status checks reported by a principal.
+type CheckLeaderEntry struct {
+	PrincipalID int64          `json:"principal_id"`
+	Principal   *PrincipalInfo `json:"principal"`
+	CheckCount  int64          `json:"check_count"`
+}
+
+// CheckLeaderboardOptions holds the status check leaderboard query parameters.
+type CheckLeaderboardOptions struct {
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+// OrgIngestionStatsOptions holds the status check ingestion per organization query parameters.
+type OrgIngestionStatsOptions struct {
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+// CheckGateOptions holds the parameters of waiting for the required status checks of a commit.
+type CheckGateOptions struct {
+	RequiredIdentifiers []string
+	Timeout             time.Duration
+}
+
+// CheckGateResult holds the outcome of waiting for the required status checks of a commit.
+type CheckGateResult struct {
+	Status enum.CheckGateStatus `json:"status"`
+	// Checks holds the latest results of the required status checks.
+	// Status checks that weren't reported yet have an empty status.
+	Checks []CheckResult `json:"checks"`
+}
+
+// AuthorCommitChecks holds the status check results of a commit shown in the activity feed of its author.
+type AuthorCommitChecks struct {
+	SHA      string    `json:"sha"`
+	Title    string    `json:"title"`
+	Authored time.Time `json:"authored"`
+	// Status is the worst status of the status checks of the commit, empty if none were reported.
+	Status  enum.CheckStatus  `json:"status,omitempty"`
+	Summary CheckCountSummary `json:"summary"`
+}
+
+// CheckRollup holds the aggregated status check results of a range of commits.
+type CheckRollup struct {
+	CommitCount int `json:"commit_count"`
+	// Summary holds the number of status checks per status of all commits in the range.
+	Summary CheckCountSummary `json:"summary"`
+	// WorstStatus is the worst status of all status checks in the range.
+	// It's empty if no status checks were reported for any of the commits.
+	WorstStatus enum.CheckStatus `json:"worst_status"`
+	// FailingCommits lists the commits of the range with failed status checks, newest first.
+	FailingCommits []string `json:"failing_commits"`
+}
+
+// CheckVolumePoint holds the number of status checks reported in a time bucket.
+type CheckVolumePoint struct {
+	// Timestamp is the start of the time bucket (in Unix time millis).
+	Timestamp  int64 `json:"timestamp"`
+	CheckCount int64 `json:"check_count"`
+}
+
+// CheckVolumeOptions holds the status check volume histogram query parameters.
+type CheckVolumeOptions struct {
+	RepoRef       string
+	From          time.Time
+	To            time.Time
+	BucketMinutes int
+}
+
+// CheckSearchQuery holds the status check search parameters.
+type CheckSearchQuery struct {
+	// Text is matched against the summaries of the status checks using full-text search.
+	Text     string
+	RepoRef  string
+	RepoID   int64
+	Statuses []enum.CheckStatus
+	From     time.Time
+	To       time.Time
+	// Visibilities filters the status checks to the ones with any of the visibilities. Nil searches all.
+	Visibilities []enum.CheckVisibility
+	// Cursor is the opaque position after which the search continues, as returned with the previous results.
+	Cursor string
+	Limit  int
+}
+
+// CheckSearchCursor is the position of a status check in the search results,
+// which are ordered by the time of the last update, newest first.
+type CheckSearchCursor struct {
+	Updated int64 `json:"updated"`
+	ID      int64 `json:"id"`
+}
+
+// CheckSearchResult holds a page of status checks matching a search.
+type CheckSearchResult struct {
+	Checks []Check `json:"checks"`
+	// NextCursor continues the search after the returned status checks. It's empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// CheckFailureRate holds the number of completed and failed status checks of a repository.
+type CheckFailureRate struct {
+	Completed int64 `json:"completed"`
+	Failed    int64 `json:"failed"`
+	// Categories holds the number of failed status checks by failure category.
+	// Failed status checks without a failure category aren't included.
+	Categories map[enum.CheckFailureCategory]int64 `json:"categories,omitempty"`
+}
+
+// Rate returns the ratio of failed to completed status checks, or zero if no status check completed.
+func (r CheckFailureRate) Rate() float64 {
+	if r.Completed == 0 {
+		return 0
+	}
+
+	return float64(r.Failed) / float64(r.Completed)
+}
+
+// CheckHealth holds the status check health of a repository.
+type CheckHealth struct {
+	// Score is the ratio of successful status checks completed in the recent window, between 0 and 1.
+	Score float64 `json:"score"`
+	// Degraded is true if the recent failure rate is significantly higher than the baseline failure rate.
+	Degraded bool `json:"degraded"`
+}
+
+// CheckHealthRollup holds the status check health of all repositories of a space and its descendant spaces.
+type CheckHealthRollup struct {
+	CheckHealth
+	// Repos is the number of repositories with status checks completed in the recent or the baseline window.
+	Repos int `json:"repos"`
+	// DegradedRepos is the number of repositories whose health is degraded.
+	DegradedRepos int `json:"degraded_repos"`
+}
+
+// CheckAnnotation is a message a status check attached to a range of lines of a file.
+type CheckAnnotation struct {
+	ID              int64                     `json:"-"`
+	CheckID         int64                     `json:"-"`
+	CheckIdentifier string                    `json:"check_identifier"`
+	Path            string                    `json:"path"`
+	LineStart       int                       `json:"line_start"`
+	LineEnd         int                       `json:"line_end"`
+	Level           enum.CheckAnnotationLevel `json:"level"`
+	Title           string                    `json:"title,omitempty"`
+	Message         string                    `json:"message"`
+}
+
+// CheckWithAnnotations is a status check together with all of its annotations.
+type CheckWithAnnotations struct {
+	Check
+	Annotations []CheckAnnotation `json:"annotations"`
+}
+
+// MarshalJSON is required because the embedded Check implements json.Marshaler.
+func (c CheckWithAnnotations) MarshalJSON() ([]byte, error) {
+	type alias Check
+	return json.Marshal(&struct {
+		alias
+		UID         string            `json:"uid"`
+		Annotations []CheckAnnotation `json:"annotations"`
+	}{
+		alias:       (alias)(c.Check),
+		UID:         c.Identifier,
+		Annotations: c.Annotations,
+	})
+}
+
+// Overlaps returns true if the annotation covers any line of the provided (inclusive) line range.
+func (a CheckAnnotation) Overlaps(lineStart, lineEnd int) bool {
+	return a.LineStart <= lineEnd && a.LineEnd >= lineStart
+}
+
+// CheckPatch holds the status check fields that should be updated, nil fields are left unchanged.
+type CheckPatch struct {
+	Status          *enum.CheckStatus
+	Summary         *string
+	Link            *string
+	Metadata        *json.RawMessage
+	Started         *int64
+	Ended           *int64
+	FailureCategory *enum.CheckFailureCategory
+}
+
+// CheckAuditState holds the state of a status check recorded in the audit log.
+type CheckAuditState struct {
+	Status     enum.CheckStatus     `json:"status"`
+	Summary    string               `json:"summary,omitempty"`
+	Link       string               `json:"link,omitempty"`
+	Started    int64                `json:"started,omitempty"`
+	Ended      int64                `json:"ended,omitempty"`
+	Visibility enum.CheckVisibility `json:"visibility,omitempty"`
+}
+
+// CheckAuditEntry is an audit log entry of a status check report.
+// Before is nil if the report created the status check.
+type CheckAuditEntry struct {
+	ID          int64            `json:"id"`
+	PrincipalID int64            `json:"principal_id"`
+	Timestamp   int64            `json:"timestamp"`
+	RepoID      int64            `json:"repo_id"`
+	CommitSHA   string           `json:"commit_sha"`
+	Namespace   string           `json:"namespace"`
+	Identifier  string           `json:"identifier"`
+	Before      *CheckAuditState `json:"before"`
+	After       CheckAuditState  `json:"after"`
+	// Payload is the payload of the status check after the report. It's only loaded with a single entry
+	// and missing for entries recorded before the payloads got recorded.
+	Payload *CheckPayload `json:"payload,omitempty"`
+}
+
+// NewCheckAuditEntry returns the audit log entry of a change of a status check by the principal.
+// A before state without an ID means the change created the status check.
+func NewCheckAuditEntry(principalID int64, before Check, after *Check) *CheckAuditEntry {
+	payload := after.Payload
+
+	entry := &CheckAuditEntry{
+		PrincipalID: principalID,
+		Timestamp:   after.Updated,
+		RepoID:      after.RepoID,
+		CommitSHA:   after.CommitSHA,
+		Namespace:   after.Namespace,
+		Identifier:  after.Identifier,
+		After:       NewCheckAuditState(after),
+		Payload:     &payload,
+	}
+
+	if before.ID != 0 {
+		state := NewCheckAuditState(&before)
+		entry.Before = &state
+	}
+
+	return entry
+}
+
+// NewCheckAuditState returns the state of the status check recorded in the audit log.
+func NewCheckAuditState(c *Check) CheckAuditState {
+	return CheckAuditState{
+		Status:     c.Status,
+		Summary:    c.Summary,
+		Link:       c.Link,
+		Started:    c.Started,
+		Ended:      c.Ended,
+		Visibility: c.Visibility,
+	}
+}
+
+// CheckAuditListOptions holds the status check audit log query parameters.
+type CheckAuditListOptions struct {
+	PrincipalID int64
+	From        time.Time
+	To          time.Time
+	Page        int
+	Size        int
+}
+
+// CheckHistoryFilter holds the status check history query parameters of a repo.
+// The history is ordered from the oldest to the most recent audit log entry.
+// If AfterID is set, the entries recorded after the entry with the ID are returned
+// and the page is ignored, which keeps paging through large histories efficient.
+type CheckHistoryFilter struct {
+	Pagination
+	RepoID  int64
+	From    time.Time
+	To      time.Time
+	AfterID int64
+}
+
+// CheckFeedOptions holds the instance wide status check feed query parameters.
+type CheckFeedOptions struct {
+	SpaceRef string
+	Statuses []enum.CheckStatus
+}
+
+// CheckFeedEvent is a status change of a status check delivered to the instance wide status check feed.
+type CheckFeedEvent struct {
+	RepoID      int64            `json:"repo_id"`
+	RepoPath    string           `json:"repo_path"`
+	SpaceIDs    []int64          `json:"space_ids"`
+	CommitSHA   string           `json:"commit_sha"`
+	Identifier  string           `json:"identifier"`
+	PrincipalID int64            `json:"principal_id"`
+	OldStatus   enum.CheckStatus `json:"old_status"`
+	NewStatus   enum.CheckStatus `json:"new_status"`
+	Timestamp   int64            `json:"timestamp"`
+
+	// Visibility is the visibility of the status check, the feed only delivers it to the consumers allowed to see it.
+	Visibility enum.CheckVisibility `json:"visibility"`
+
+	// EventsDropped is the number of events that were dropped before this one
+	// because the consumer didn't keep up with the feed.
+	EventsDropped int `json:"events_dropped,omitempty"`
 }
 
 // CheckListOptions holds list status checks query parameters.
 type CheckListOptions struct {
 	ListQueryFilter
+
+	// StepName filters the status checks to the ones that contain a step with the provided name.
+	StepName string
+
+	// Namespace filters the status checks to the ones of the namespace. Empty lists all namespaces.
+	Namespace string
+
+	// Visibilities filters the status checks to the ones with any of the visibilities. Nil lists all.
+	Visibilities []enum.CheckVisibility
+
+	Sort  enum.CheckSort
+	Order enum.Order
+}
+
+// CheckUpsertResponse is the result of a status check reported over a status check report stream.
+type CheckUpsertResponse struct {
+	// Seq is the position of the report in the stream, starting with 1.
+	Seq        int    `json:"seq"`
+	ID         int64  `json:"id,omitempty"`
+	CommitSHA  string `json:"commit_sha"`
+	Identifier string `json:"identifier"`
+	// Error describes why the status check was rejected, the other reports of the stream are still processed.
+	Error string `json:"error,omitempty"`
+}
+
+// CheckRetryCandidateOptions holds the parameters for listing status checks that qualify for a retry.
+type CheckRetryCandidateOptions struct {
+	Identifier    string
+	Statuses      []enum.CheckStatus
+	PayloadKind   enum.CheckPayloadKind
+	MaxRetryCount int
+	UpdatedAfter  int64
+	UpdatedBefore int64
+	// StartedBefore limits the status checks to those started before the time, zero disables the limit.
+	// Status checks without a reported start time are considered started when they were created.
+	StartedBefore int64
+}
+
+const (
+	retryPolicyMaxAttempts    = 10
+	retryPolicyMaxBackoffSecs = 24 * 60 * 60
+	retryPolicyMaxTimeoutSecs = 24 * 60 * 60
+	slaMaxDurationSecs        = 7 * 24 * 60 * 60
+)
+
+// RetryPolicy defines how a failed status check gets automatically retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the check is executed, including the first attempt.
+	MaxAttempts int `json:"max_attempts" yaml:"max_attempts"`
+	// BackoffSeconds is the minimum time to wait after the check completed before it's retried.
+	BackoffSeconds int `json:"backoff_seconds" yaml:"backoff_seconds"`
+	// RetryOnStatuses lists the completed check statuses that trigger a retry.
+	RetryOnStatuses []enum.CheckStatus `json:"retry_on_statuses" yaml:"retry_on_statuses"`
+	// TimeoutSeconds is the time after which a running check is considered timed out, measured from its start.
+	// Timed out checks are marked as erroneous with the timeout failure category. Zero disables the timeout.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty" yaml:"timeout_seconds"`
+}
+
+// Sanitize validates the retry policy and fills in the defaults of the unset fields.
+func (p *RetryPolicy) Sanitize() error {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = 1
+	}
+
+	if p.MaxAttempts < 1 || p.MaxAttempts > retryPolicyMaxAttempts {
+		return errors.InvalidArgument("Max attempts must be between 1 and %d", retryPolicyMaxAttempts)
+	}
+
+	if p.BackoffSeconds < 0 || p.BackoffSeconds > retryPolicyMaxBackoffSecs {
+		return errors.InvalidArgument("Backoff seconds must be between 0 and %d", retryPolicyMaxBackoffSecs)
+	}
+
+	if p.TimeoutSeconds < 0 || p.TimeoutSeconds > retryPolicyMaxTimeoutSecs {
+		return errors.InvalidArgument("Timeout seconds must be between 0 and %d", retryPolicyMaxTimeoutSecs)
+	}
+
+	if len(p.RetryOnStatuses) == 0 {
+		p.RetryOnStatuses = []enum.CheckStatus{enum.CheckStatusFailure, enum.CheckStatusError}
+	}
+
+	statuses := make([]enum.CheckStatus, 0, len(p.RetryOnStatuses))
+	for _, s := range p.RetryOnStatuses {
+		status, ok := s.Sanitize()
+		if !ok || !status.IsCompleted() || status.IsSatisfied() || status == enum.CheckStatusSkipped {
+			return errors.InvalidArgument("Status %q can't be retried", s)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	p.RetryOnStatuses = statuses
+
+	return nil
+}
+
+// ShouldRetry returns true if a check that completed with the provided status and failure category
+// and has already been retried retryCount times should be retried again.
+// Cancelled checks are never retried, because they were stopped on purpose.
+func (p RetryPolicy) ShouldRetry(status enum.CheckStatus, category enum.CheckFailureCategory, retryCount int) bool {
+	return retryCount+1 < p.MaxAttempts && slices.Contains(p.RetryOnStatuses, status) &&
+		category != enum.CheckFailureCategoryCancelled
+}
+
+// CheckConfig holds the configuration of a status check in a repository.
+type CheckConfig struct {
+	ID          int64       `json:"-"`
+	RepoID      int64       `json:"-"`
+	Identifier  string      `json:"identifier"`
+	CreatedBy   int64       `json:"-"`
+	Created     int64       `json:"created"`
+	Updated     int64       `json:"updated"`
+	RetryPolicy RetryPolicy `json:"retry_policy"`
+	SLA         *CheckSLA   `json:"sla,omitempty"`
+	// URLTemplate generates the links of the status checks from their metadata, see RenderCheckURLTemplate.
+	URLTemplate string `json:"url_template,omitempty"`
+	// Required makes the status check required to merge pull requests, overriding the space policies.
+	// If it's not set, the status check is required if the nearest space policy that sets it requires it.
+	Required *bool `json:"required,omitempty"`
+}
+
+// CheckSLA defines how long a status check is allowed to take to complete.
+type CheckSLA struct {
+	// MaxDurationSeconds is the longest time between the start and the end of the check that meets the SLA.
+	MaxDurationSeconds int `json:"max_duration_seconds" yaml:"max_duration_seconds"`
+	// AlertThresholdFraction is the SLA breach rate of the check in the alerting window
+	// that triggers an alert when the check breaches the SLA. Zero alerts on every breach.
+	AlertThresholdFraction float64 `json:"alert_threshold_fraction" yaml:"alert_threshold_fraction"`
+}
+
+// Validate returns an error if the SLA is out of the supported bounds.
+func (sla *CheckSLA) Validate() error {
+	if sla.MaxDurationSeconds < 1 || sla.MaxDurationSeconds > slaMaxDurationSecs {
+		return errors.InvalidArgument("SLA max duration seconds must be between 1 and %d", slaMaxDurationSecs)
+	}
+
+	if sla.AlertThresholdFraction < 0 || sla.AlertThresholdFraction > 1 {
+		return errors.InvalidArgument("SLA alert threshold fraction must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// IsBreached returns true if a check that started and ended at the provided times (in unix millis)
+// took longer than allowed by the SLA. Checks without a start or an end time can't breach the SLA.
+func (sla *CheckSLA) IsBreached(started, ended int64) bool {
+	if sla == nil || started == 0 || ended == 0 {
+		return false
+	}
+
+	return ended-started > int64(sla.MaxDurationSeconds)*1000
+}
+
+// CheckScalingHints holds the status check metrics of all repositories informing the autoscaling
+// of the CI infrastructure running the status checks.
+type CheckScalingHints struct {
+	// Since is the start of the time range the hints are calculated for (in Unix time millis).
+	Since int64 `json:"since"`
+	// PendingChecks is the number of status checks currently waiting to be started.
+	PendingChecks int64 `json:"pending_checks"`
+	// AvgQueueDepth is the average number of status checks waiting to be started in the time range.
+	AvgQueueDepth float64 `json:"avg_queue_depth"`
+	// P95Durations is the 95th percentile of the duration in milliseconds of the status checks
+	// that completed in the time range, by status check identifier.
+	P95Durations map[string]int64 `json:"p95_durations"`
+	// BacklogGrowthRate is the number of status checks per hour by which the backlog of waiting status checks
+	// grew in the time range. It's negative if the backlog shrank.
+	BacklogGrowthRate float64 `json:"backlog_growth_rate"`
+}
+
+// OrgIngestionStat holds the status check ingestion of an organization (root space) in a time range.
+type OrgIngestionStat struct {
+	SpaceID  int64  `json:"space_id"`
+	SpaceUID string `json:"space_uid"`
+	// CheckCount is the number of status checks reported in the repositories of the organization.
+	CheckCount int64 `json:"check_count"`
+	// ByteSize is the stored size of the payloads of the reported status checks.
+	ByteSize int64 `json:"byte_size"`
+	// OverQuota is true if the organization exceeds the fair-use limit of status check reports.
+	OverQuota bool `json:"over_quota,omitempty"`
+}
+
+// CheckSLABreachRate holds the number of completed status checks with an identifier
+// and how many of them breached the SLA.
+type CheckSLABreachRate struct {
+	Identifier string `json:"identifier"`
+	Completed  int64  `json:"completed"`
+	Breached   int64  `json:"breached"`
+}
+
+// Rate returns the ratio of breached to completed status checks, or zero if no status check completed.
+func (r CheckSLABreachRate) Rate() float64 {
+	if r.Completed == 0 {
+		return 0
+	}
+
+	return float64(r.Breached) / float64(r.Completed)
+}
+
+// CheckSLABreachOptions holds the status check SLA breach rate query parameters.
+// The breach rates are limited to the status check with the identifier if it's set.
+type CheckSLABreachOptions struct {
+	Identifier string
+	From       time.Time
+	To         time.Time
+}
+
+// SpaceCheckPolicy holds the default configuration of a status check for all repositories in a space.
+// It applies to the repositories that don't have their own configuration of the status check
+// and that aren't in a child space with a policy for the same status check.
+type SpaceCheckPolicy struct {
+	ID          int64       `json:"-"`
+	SpaceID     int64       `json:"-"`
+	Identifier  string      `json:"identifier"`
+	CreatedBy   int64       `json:"-"`
+	Created     int64       `json:"created"`
+	Updated     int64       `json:"updated"`
+	RetryPolicy RetryPolicy `json:"retry_policy"`
+	// Required makes the status check required to merge pull requests of the repositories in the space,
+	// unless a repository or a child space sets it differently.
+	Required *bool `json:"required,omitempty"`
+}
+
+// ReservedCheck reserves a status check identifier in a space, including all its child spaces,
+// so that only the listed principals can report status checks with the identifier.
+type ReservedCheck struct {
+	ID         int64   `json:"-"`
+	SpaceID    int64   `json:"-"`
+	Identifier string  `json:"identifier"`
+	Reporters  []int64 `json:"reporters"`
+	CreatedBy  int64   `json:"-"`
+	Created    int64   `json:"created"`
+	Updated    int64   `json:"updated"`
+}
+
+// CheckAlias maps a renamed status check identifier of a repository to its new identifier,
+// so that protection rules requiring the old identifier are satisfied by the renamed status check.
+type CheckAlias struct {
+	ID            int64  `json:"-"`
+	RepoID        int64  `json:"-"`
+	OldIdentifier string `json:"old_identifier"`
+	NewIdentifier string `json:"new_identifier"`
+	CreatedBy     int64  `json:"-"`
+	Created       int64  `json:"created"`
+	Updated       int64  `json:"updated"`
+}
+
+// CheckAcknowledgement marks the failure of a status check as known (e.g. caused by broken infrastructure),
+// so that the failure doesn't block merging until the acknowledgement expires.
+type CheckAcknowledgement struct {
+	CheckID        int64  `json:"check_id"`
+	Identifier     string `json:"identifier"`
+	AcknowledgedBy int64  `json:"acknowledged_by"`
+	Reason         string `json:"reason"`
+	Expires        int64  `json:"expires"`
+}
+
+// CheckArchive holds the metadata of a file of archived status check results.
+// A file holds the status check results of a repository created during one day (UTC).
+type CheckArchive struct {
+	ID          int64  `json:"id"`
+	Created     int64  `json:"created"`
+	RepoID      int64  `json:"-"`
+	Bucket      string `json:"bucket"`
+	Key         string `json:"key"`
+	PeriodStart int64  `json:"period_start"`
+	PeriodEnd   int64  `json:"period_end"`
+	CheckCount  int    `json:"check_count"`
+}
+
+// ArchivedCheck is a status check result as stored in a status check archive file.
+type ArchivedCheck struct {
+	CommitSHA string `json:"commit_sha"`
+	CreatedBy int64  `json:"created_by"`
+	Check     Check  `json:"check"`
+}
+
+// FederatedCheck is a status check reported to a gitness instance.
+type FederatedCheck struct {
+	Instance string `json:"instance"`
+	Check    Check  `json:"check"`
+}
+
+// FederatedCheckError describes why the status checks of a gitness instance couldn't be fetched.
+type FederatedCheckError struct {
+	Instance string `json:"instance"`
+	Message  string `json:"message"`
+}
+
+// FederatedCheckList holds the status checks of a commit collected from the local and all remote gitness instances.
+// PartialError is set if the status checks of some instances are missing.
+type FederatedCheckList struct {
+	Checks       []FederatedCheck      `json:"checks"`
+	PartialError bool                  `json:"partial_error"`
+	Errors       []FederatedCheckError `json:"errors,omitempty"`
 }
 
 // CheckRecentOptions holds list recent status check query parameters.
 type CheckRecentOptions struct {
 	Query string
 	Since int64
+
+	// Visibilities filters the status checks to the ones with any of the visibilities. Nil lists all.
+	Visibilities []enum.CheckVisibility
+}
+
+// CheckIdentifierStats holds the statistics of the runs of a status check identifier
+// in a namespace of a repository.
+type CheckIdentifierStats struct {
+	Namespace  string `json:"namespace" db:"check_namespace"`
+	Identifier string `json:"identifier" db:"check_uid"`
+	// FlakeScore is the exponentially weighted moving average of status changes between completed runs,
+	// from 0 (the status never changes) to 1 (the status changes with every run).
+	FlakeScore float64 `json:"flake_score" db:"check_uid_stats_flake_score"`
 }
 
 type CheckPayloadText struct {
@@ -100,14 +817,50 @@ type CheckPayloadInternal struct {
 }
 
 type PullReqChecks struct {
-	CommitSHA string         `json:"commit_sha"`
-	Checks    []PullReqCheck `json:"checks"`
+	CommitSHA string `json:"commit_sha"`
+	// MergeBaseSHA is the commit on the target branch the status checks are compared with.
+	MergeBaseSHA string         `json:"merge_base_sha,omitempty"`
+	Checks       []PullReqCheck `json:"checks"`
 }
 
 type PullReqCheck struct {
 	Required   bool  `json:"required"`
 	Bypassable bool  `json:"bypassable"`
 	Check      Check `json:"check"`
+	// BaseStatus is the status of the same status check on the merge base commit,
+	// which tells if a failure was introduced by the pull request. It's empty if it wasn't reported there.
+	BaseStatus enum.CheckStatus `json:"base_status,omitempty"`
+}
+
+// ConsistencyReport lists the cached status check summaries of a repository
+// that differ from the summaries freshly aggregated from its status checks.
+type ConsistencyReport struct {
+	RepoID int64 `json:"repo_id"`
+	// CommitsVerified is the number of commits whose status check summaries were compared.
+	CommitsVerified int                       `json:"commits_verified"`
+	CommitSummaries []CheckSummaryDiscrepancy `json:"commit_summaries"`
+	// RepoSummary is set if the cached status check metrics of the repository are outdated.
+	RepoSummary *RepoCheckSummaryDiscrepancy `json:"repo_summary,omitempty"`
+}
+
+// Consistent returns true if no discrepancies were found.
+func (r *ConsistencyReport) Consistent() bool {
+	return len(r.CommitSummaries) == 0 && r.RepoSummary == nil
+}
+
+// CheckSummaryDiscrepancy is a stored status check summary of a commit that differs from its status checks.
+// Cached is nil if no summary is stored, Actual is nil if the commit has no status checks.
+type CheckSummaryDiscrepancy struct {
+	CommitSHA string             `json:"commit_sha"`
+	Cached    *CheckCountSummary `json:"cached"`
+	Actual    *CheckCountSummary `json:"actual"`
+}
+
+// RepoCheckSummaryDiscrepancy holds the cached status check metrics of a repository
+// and the metrics freshly calculated for the same period.
+type RepoCheckSummaryDiscrepancy struct {
+	Cached *RepositoryCheckSummary `json:"cached"`
+	Actual *RepositoryCheckSummary `json:"actual"`
 }
 
 type CheckCountSummary struct {
@@ -116,4 +869,52 @@ type CheckCountSummary struct {
 	Success int `json:"success"`
 	Failure int `json:"failure"`
 	Error   int `json:"error"`
+	Skipped int `json:"skipped"`
+
+	// FailureCategories holds the number of failed and erroneous status checks by failure category.
+	FailureCategories CheckFailureCategoryCounts `json:"failure_categories"`
+}
+
+// CheckFailureCategoryCounts holds the number of status checks per failure category.
+// Status checks without a failure category aren't counted.
+type CheckFailureCategoryCounts struct {
+	CodeFailure  int `json:"code_failure"`
+	InfraFailure int `json:"infra_failure"`
+	Timeout      int `json:"timeout"`
+	Cancelled    int `json:"cancelled"`
+}
+
+// Add adds n status checks with the failure category to the counts.
+func (c *CheckFailureCategoryCounts) Add(category enum.CheckFailureCategory, n int) {
+	switch category {
+	case enum.CheckFailureCategoryCodeFailure:
+		c.CodeFailure += n
+	case enum.CheckFailureCategoryInfraFailure:
+		c.InfraFailure += n
+	case enum.CheckFailureCategoryTimeout:
+		c.Timeout += n
+	case enum.CheckFailureCategoryCancelled:
+		c.Cancelled += n
+	}
+}
+
+// Status returns the overall status of the summarized status checks.
+// Failed status checks take precedence over erroneous ones, which take precedence over unfinished ones.
+func (s CheckCountSummary) Status() enum.CheckStatus {
+	switch {
+	case s.Failure > 0:
+		return enum.CheckStatusFailure
+	case s.Error > 0:
+		return enum.CheckStatusError
+	case s.Running > 0:
+		return enum.CheckStatusRunning
+	case s.Pending > 0:
+		return enum.CheckStatusPending
+	case s.Success > 0:
+		return enum.CheckStatusSuccess
+	case s.Skipped > 0:
+		return enum.CheckStatusSkipped
+	default:
+		return ""
+	}
 }
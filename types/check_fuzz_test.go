@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// FuzzDecodePayload feeds arbitrary payload data of every registered payload kind to DecodePayload.
+// The seed corpus mimics payloads reported by common CI providers.
+func FuzzDecodePayload(f *testing.F) {
+	seeds := []struct {
+		kind enum.CheckPayloadKind
+		data string
+	}{
+		// GitHub Actions job summary.
+		{enum.CheckPayloadKindMarkdown, `{"details":"### Build\n| job | result |\n|---|---|\n| test | :white_check_mark: |"}`},
+		// Jenkins console output.
+		{enum.CheckPayloadKindRaw, `{"details":"Started by user admin\n[Pipeline] stage\nFinished: SUCCESS"}`},
+		// Harness CI pipeline execution.
+		{enum.CheckPayloadKindPipeline, `{"execution_number":42,"repo_id":7,"pipeline_id":3}`},
+		// GitLab CI JUnit report.
+		{enum.CheckPayloadKindJUnit, `{"tests":120,"failures":2,"errors":0,"skipped":3,"duration":53210,` +
+			`"failed_tests":["pkg/api.TestLogin","pkg/api.TestLogout"]}`},
+		// SonarQube quality gate webhook.
+		{enum.CheckPayloadKindSonar, `{"project_key":"gitness","quality_gate":"ERROR","bugs":1,"vulnerabilities":0,` +
+			`"code_smells":12,"coverage":81.4,"dashboard_url":"https://sonar.example.com/dashboard?id=gitness"}`},
+		{enum.CheckPayloadKindEmpty, ``},
+		{enum.CheckPayloadKindJUnit, `{"tests":"many"}`},
+		{enum.CheckPayloadKindSonar, `null`},
+	}
+	for _, seed := range seeds {
+		f.Add(string(seed.kind), []byte(seed.data))
+	}
+
+	f.Fuzz(func(t *testing.T, kind string, data []byte) {
+		check := &Check{
+			Payload: CheckPayload{
+				Kind: enum.CheckPayloadKind(kind),
+				Data: json.RawMessage(data),
+			},
+		}
+
+		payload, err := DecodePayload(check)
+		if err != nil {
+			if payload != nil {
+				t.Errorf("expected no payload on error, got %T", payload)
+			}
+			return
+		}
+
+		if check.Payload.Kind != enum.CheckPayloadKindEmpty && payload == nil {
+			t.Errorf("expected payload of kind %q, got nil", kind)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// checkPayloadFactoryMethod is an alias for a function that creates a new instance of a status check payload.
+// NOTE: this is used to create new payload instances on the fly (to avoid reflection).
+type checkPayloadFactoryMethod func() any
+
+var (
+	checkPayloadKindsMu sync.RWMutex
+
+	// checkPayloadKinds contains the payload factory methods for all status check payload kinds with payload data.
+	checkPayloadKinds = map[enum.CheckPayloadKind]checkPayloadFactoryMethod{}
+)
+
+func init() {
+	RegisterPayloadKind(enum.CheckPayloadKindRaw, func() any { return &CheckPayloadText{} })
+	RegisterPayloadKind(enum.CheckPayloadKindMarkdown, func() any { return &CheckPayloadText{} })
+	RegisterPayloadKind(enum.CheckPayloadKindPipeline, func() any { return &CheckPayloadInternal{} })
+	RegisterPayloadKind(enum.CheckPayloadKindJUnit, func() any { return &JUnitPayload{} })
+	RegisterPayloadKind(enum.CheckPayloadKindSonar, func() any { return &SonarPayload{} })
+}
+
+// RegisterPayloadKind registers the payload type of a status check payload kind.
+// The factory method must return a pointer to a new instance of the payload type.
+// It's meant to be called during initialization (e.g. by plugins) and panics if the kind is already registered.
+func RegisterPayloadKind(kind enum.CheckPayloadKind, factory func() any) {
+	checkPayloadKindsMu.Lock()
+	defer checkPayloadKindsMu.Unlock()
+
+	if factory == nil {
+		panic(fmt.Sprintf("status check payload kind %q registered without a factory method", kind))
+	}
+
+	if _, ok := checkPayloadKinds[kind]; ok {
+		panic(fmt.Sprintf("status check payload kind %q is already registered", kind))
+	}
+
+	checkPayloadKinds[kind] = factory
+}
+
+// RegisteredPayloadKinds returns all status check payload kinds with a registered payload type.
+func RegisteredPayloadKinds() []enum.CheckPayloadKind {
+	checkPayloadKindsMu.RLock()
+	defer checkPayloadKindsMu.RUnlock()
+
+	kinds := make([]enum.CheckPayloadKind, 0, len(checkPayloadKinds))
+	for kind := range checkPayloadKinds {
+		kinds = append(kinds, kind)
+	}
+
+	return kinds
+}
+
+// NewPayload returns a new instance of the payload type registered for the status check payload kind.
+func NewPayload(kind enum.CheckPayloadKind) (any, error) {
+	checkPayloadKindsMu.RLock()
+	factory, ok := checkPayloadKinds[kind]
+	checkPayloadKindsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("status check payload kind %q doesn't have a registered payload type", kind)
+	}
+
+	return factory(), nil
+}
+
+// DecodePayload deserializes the payload data of the status check into the type registered for its payload kind.
+// It returns nil for the payload kind without payload data.
+func DecodePayload(check *Check) (any, error) {
+	if check.Payload.Kind == enum.CheckPayloadKindEmpty {
+		return nil, nil //nolint:nilnil // the empty payload kind has no payload data
+	}
+
+	payload, err := NewPayload(check.Payload.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(check.Payload.Data, payload); err != nil {
+		return nil, fmt.Errorf("failed to decode status check payload of kind %q: %w", check.Payload.Kind, err)
+	}
+
+	return payload, nil
+}
+
+// JUnitPayload is the payload of status checks reporting JUnit test results.
+type JUnitPayload struct {
+	Tests       int      `json:"tests"`
+	Failures    int      `json:"failures"`
+	Errors      int      `json:"errors"`
+	Skipped     int      `json:"skipped"`
+	Duration    int64    `json:"duration,omitempty"` // in milliseconds
+	FailedTests []string `json:"failed_tests,omitempty"`
+}
+
+// SonarPayload is the payload of status checks reporting SonarQube analysis results.
+type SonarPayload struct {
+	ProjectKey      string  `json:"project_key"`
+	QualityGate     string  `json:"quality_gate"`
+	Bugs            int     `json:"bugs"`
+	Vulnerabilities int     `json:"vulnerabilities"`
+	CodeSmells      int     `json:"code_smells"`
+	Coverage        float64 `json:"coverage"`
+	DashboardURL    string  `json:"dashboard_url,omitempty"`
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDecodePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload CheckPayload
+		want    any
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			payload: CheckPayload{Kind: enum.CheckPayloadKindEmpty, Data: []byte("{}")},
+			want:    nil,
+		},
+		{
+			name:    "markdown",
+			payload: CheckPayload{Kind: enum.CheckPayloadKindMarkdown, Data: []byte(`{"details":"# ok"}`)},
+			want:    &CheckPayloadText{Details: "# ok"},
+		},
+		{
+			name:    "junit",
+			payload: CheckPayload{Kind: enum.CheckPayloadKindJUnit, Data: []byte(`{"tests":10,"failures":1}`)},
+			want:    &JUnitPayload{Tests: 10, Failures: 1},
+		},
+		{
+			name:    "unknown-kind",
+			payload: CheckPayload{Kind: "unknown", Data: []byte("{}")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-data",
+			payload: CheckPayload{Kind: enum.CheckPayloadKindSonar, Data: []byte(`{"bugs":"many"}`)},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DecodePayload(&Check{Payload: test.payload})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("DecodePayload() error = %v, wantErr %v", err, test.wantErr)
+			}
+
+			if !test.wantErr && !reflect.DeepEqual(got, test.want) {
+				t.Errorf("DecodePayload() = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegisterPayloadKind(t *testing.T) {
+	type customPayload struct {
+		Value string `json:"value"`
+	}
+
+	const kind enum.CheckPayloadKind = "test-custom"
+
+	RegisterPayloadKind(kind, func() any { return &customPayload{} })
+	t.Cleanup(func() {
+		checkPayloadKindsMu.Lock()
+		delete(checkPayloadKinds, kind)
+		checkPayloadKindsMu.Unlock()
+	})
+
+	got, err := DecodePayload(&Check{Payload: CheckPayload{Kind: kind, Data: []byte(`{"value":"x"}`)}})
+	if err != nil {
+		t.Fatalf("DecodePayload() error = %v", err)
+	}
+
+	if want := (&customPayload{Value: "x"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodePayload() = %#v, want %#v", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterPayloadKind() of an already registered kind didn't panic")
+		}
+	}()
+
+	RegisterPayloadKind(kind, func() any { return &customPayload{} })
+}
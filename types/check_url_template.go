@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/harness/gitness/errors"
+)
+
+// checkURLTemplateMaxLength is the maximum length of the URL template of a status check configuration.
+const checkURLTemplateMaxLength = 2048
+
+// parseCheckURLTemplate parses the URL template of a status check configuration.
+// Referencing metadata fields the status check doesn't have is an error, so that no broken links are generated.
+func parseCheckURLTemplate(text string) (*template.Template, error) {
+	return template.New("url_template").Option("missingkey=error").Parse(text)
+}
+
+// ValidateCheckURLTemplate returns an error if the URL template of a status check configuration is invalid.
+// The template must produce an absolute http(s) URL.
+func ValidateCheckURLTemplate(text string) error {
+	if len(text) > checkURLTemplateMaxLength {
+		return errors.InvalidArgument("URL template must be at most %d characters long", checkURLTemplateMaxLength)
+	}
+
+	if !strings.HasPrefix(text, "http://") && !strings.HasPrefix(text, "https://") {
+		return errors.InvalidArgument("URL template must start with http:// or https://")
+	}
+
+	if _, err := parseCheckURLTemplate(text); err != nil {
+		return errors.InvalidArgument("URL template is not valid: %s", err)
+	}
+
+	return nil
+}
+
+// RenderCheckURLTemplate renders the URL template of a status check configuration
+// with the fields of the status check metadata, e.g. https://ci.example.com/builds/{{.ExternalID}}.
+// It returns an empty string if the template doesn't produce a valid absolute http(s) URL.
+func RenderCheckURLTemplate(text string, metadata json.RawMessage) (string, error) {
+	tmpl, err := parseCheckURLTemplate(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse status check URL template: %w", err)
+	}
+
+	data := map[string]any{}
+	if len(metadata) > 0 {
+		if err = json.Unmarshal(metadata, &data); err != nil {
+			return "", fmt.Errorf("failed to unmarshal status check metadata: %w", err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("failed to render status check URL template: %w", err)
+	}
+
+	link := strings.TrimSpace(buf.String())
+
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", nil
+	}
+
+	return link, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+)
+
+func TestRenderCheckURLTemplate(t *testing.T) {
+	const tmpl = "https://ci.example.com/builds/{{.ExternalID}}"
+
+	tests := []struct {
+		name     string
+		template string
+		metadata string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "field",
+			template: tmpl,
+			metadata: `{"ExternalID":"42"}`,
+			want:     "https://ci.example.com/builds/42",
+		},
+		{
+			name:     "escaped field",
+			template: "https://ci.example.com/jobs?name={{urlquery .job}}",
+			metadata: `{"job":"build & test"}`,
+			want:     "https://ci.example.com/jobs?name=build+%26+test",
+		},
+		{
+			name:     "missing field",
+			template: tmpl,
+			metadata: `{}`,
+			wantErr:  true,
+		},
+		{
+			name:     "empty result",
+			template: "{{.link}}",
+			metadata: `{"link":""}`,
+			want:     "",
+		},
+		{
+			name:     "not a url",
+			template: "{{.link}}",
+			metadata: `{"link":"javascript:alert(1)"}`,
+			want:     "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := RenderCheckURLTemplate(test.template, []byte(test.metadata))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("RenderCheckURLTemplate() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("RenderCheckURLTemplate() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateCheckURLTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  bool
+	}{
+		{template: "https://ci.example.com/builds/{{.ExternalID}}"},
+		{template: "http://jenkins.local/job/{{.job}}/{{.build}}/"},
+		{template: "ftp://ci.example.com/{{.ExternalID}}", wantErr: true},
+		{template: "https://ci.example.com/builds/{{.ExternalID", wantErr: true},
+		{template: "https://ci.example.com/builds/{{unknown .ExternalID}}", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			err := ValidateCheckURLTemplate(test.template)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ValidateCheckURLTemplate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
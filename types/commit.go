@@ -14,8 +14,50 @@
 
 package types
 
+import (
+	"regexp"
+	"strings"
+)
+
 // CommitFilesResponse holds commit id.
 type CommitFilesResponse struct {
 	CommitID string `json:"commit_id"`
 	DryRunRulesOutput
 }
+
+// CommitDirectives holds the directives found in a commit message.
+type CommitDirectives struct {
+	// SkipChecks is true if status checks shouldn't be executed for the commit.
+	SkipChecks bool
+}
+
+// skipChecksTags are the commit message tags that request skipping of status checks, matched case-insensitively.
+var skipChecksTags = []string{
+	"[skip ci]",
+	"[ci skip]",
+	"[no ci]",
+	"[skip checks]",
+	"[checks skip]",
+}
+
+// skipChecksTrailerRegex matches the "skip-checks: true" commit message trailer.
+var skipChecksTrailerRegex = regexp.MustCompile(`(?im)^\s*skip-checks\s*:\s*true\s*$`)
+
+// ParseCommitDirectives returns the directives found in the provided commit message.
+func ParseCommitDirectives(message string) CommitDirectives {
+	var directives CommitDirectives
+
+	lower := strings.ToLower(message)
+	for _, tag := range skipChecksTags {
+		if strings.Contains(lower, tag) {
+			directives.SkipChecks = true
+			break
+		}
+	}
+
+	if !directives.SkipChecks && skipChecksTrailerRegex.MatchString(message) {
+		directives.SkipChecks = true
+	}
+
+	return directives
+}
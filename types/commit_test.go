@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "testing"
+
+func TestParseCommitDirectives(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		exp     CommitDirectives
+	}{
+		{name: "empty", message: "", exp: CommitDirectives{}},
+		{name: "no-directive", message: "fix: skip ci tests in build", exp: CommitDirectives{}},
+		{name: "skip-ci", message: "docs: update readme [skip ci]", exp: CommitDirectives{SkipChecks: true}},
+		{name: "ci-skip-upper", message: "[CI SKIP] bump version", exp: CommitDirectives{SkipChecks: true}},
+		{name: "no-ci", message: "wip\n\nnot ready yet [no ci]", exp: CommitDirectives{SkipChecks: true}},
+		{name: "skip-checks", message: "typo [Skip Checks]", exp: CommitDirectives{SkipChecks: true}},
+		{name: "trailer", message: "typo\n\nSkip-Checks: true\n", exp: CommitDirectives{SkipChecks: true}},
+		{name: "trailer-false", message: "typo\n\nskip-checks: false", exp: CommitDirectives{}},
+		{name: "trailer-inline", message: "typo skip-checks: true", exp: CommitDirectives{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ParseCommitDirectives(test.message); got != test.exp {
+				t.Errorf("expected %+v, got %+v", test.exp, got)
+			}
+		})
+	}
+}
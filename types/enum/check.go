@@ -14,7 +14,12 @@
 
 package enum
 
-import "golang.org/x/exp/slices"
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
 
 // CheckStatus defines status check status.
 type CheckStatus string
@@ -24,12 +29,17 @@ func (s CheckStatus) Sanitize() (CheckStatus, bool)     { return Sanitize(s, Get
 func GetAllCheckStatuses() ([]CheckStatus, CheckStatus) { return checkStatuses, "" }
 
 // CheckStatus enumeration.
+// The values are enforced by the checks_status_valid database constraint - changing them requires a new migration.
 const (
 	CheckStatusPending CheckStatus = "pending"
 	CheckStatusRunning CheckStatus = "running"
 	CheckStatusSuccess CheckStatus = "success"
 	CheckStatusFailure CheckStatus = "failure"
 	CheckStatusError   CheckStatus = "error"
+	// CheckStatusSkipped marks a status check that wasn't executed on purpose (e.g. because of a commit message
+	// directive). Skipped status checks only satisfy status check requirements of protection rules
+	// that explicitly allow it.
+	CheckStatusSkipped CheckStatus = "skipped"
 )
 
 var checkStatuses = sortEnum([]CheckStatus{
@@ -38,9 +48,180 @@ var checkStatuses = sortEnum([]CheckStatus{
 	CheckStatusSuccess,
 	CheckStatusFailure,
 	CheckStatusError,
+	CheckStatusSkipped,
+})
+
+// CheckVisibility defines who can see a status check result.
+type CheckVisibility string
+
+func (CheckVisibility) Enum() []interface{} { return toInterfaceSlice(checkVisibilities) }
+func (v CheckVisibility) Sanitize() (CheckVisibility, bool) {
+	return Sanitize(v, GetAllCheckVisibilities)
+}
+func GetAllCheckVisibilities() ([]CheckVisibility, CheckVisibility) {
+	return checkVisibilities, CheckVisibilityPublic
+}
+
+// CheckVisibility enumeration.
+const (
+	// CheckVisibilityPublic status checks are visible to everyone who can view the repository.
+	CheckVisibilityPublic CheckVisibility = "public"
+	// CheckVisibilityRestricted status checks are visible to the contributors of the repository.
+	CheckVisibilityRestricted CheckVisibility = "restricted"
+	// CheckVisibilityPrivate status checks are visible to the admins of the repository.
+	CheckVisibilityPrivate CheckVisibility = "private"
+)
+
+var checkVisibilities = sortEnum([]CheckVisibility{
+	CheckVisibilityPublic,
+	CheckVisibilityRestricted,
+	CheckVisibilityPrivate,
+})
+
+// checkStatusTransitions defines the statuses a status check with a given status can be updated to.
+// A successful status check can't become pending again, every other transition is allowed,
+// for example to rerun a status check or to correct a reported status.
+var checkStatusTransitions = map[CheckStatus][]CheckStatus{
+	CheckStatusPending: checkStatuses,
+	CheckStatusRunning: checkStatuses,
+	CheckStatusSuccess: {
+		CheckStatusRunning, CheckStatusSuccess, CheckStatusFailure, CheckStatusError, CheckStatusSkipped,
+	},
+	CheckStatusFailure: checkStatuses,
+	CheckStatusError:   checkStatuses,
+	CheckStatusSkipped: checkStatuses,
+}
+
+var ErrInvalidCheckStatusTransition = errors.New("invalid status check status transition")
+
+// ValidateStatusTransition returns an error if a status check with the current status
+// can't be updated to the next status. An empty current status means the status check doesn't exist yet.
+func ValidateStatusTransition(current, next CheckStatus) error {
+	if current == "" {
+		return nil
+	}
+
+	if !slices.Contains(checkStatusTransitions[current], next) {
+		return fmt.Errorf("%w from %s to %s", ErrInvalidCheckStatusTransition, current, next)
+	}
+
+	return nil
+}
+
+var terminalCheckStatuses = []CheckStatus{CheckStatusFailure, CheckStatusSuccess, CheckStatusError, CheckStatusSkipped}
+
+// CheckFailureCategory defines the kind of problem a failed status check represents.
+type CheckFailureCategory string
+
+func (CheckFailureCategory) Enum() []interface{} { return toInterfaceSlice(checkFailureCategories) }
+func (c CheckFailureCategory) Sanitize() (CheckFailureCategory, bool) {
+	return Sanitize(c, GetAllCheckFailureCategories)
+}
+func GetAllCheckFailureCategories() ([]CheckFailureCategory, CheckFailureCategory) {
+	return checkFailureCategories, ""
+}
+
+// CheckFailureCategory enumeration.
+const (
+	CheckFailureCategoryCodeFailure  CheckFailureCategory = "code_failure"
+	CheckFailureCategoryInfraFailure CheckFailureCategory = "infra_failure"
+	CheckFailureCategoryTimeout      CheckFailureCategory = "timeout"
+	CheckFailureCategoryCancelled    CheckFailureCategory = "cancelled"
+)
+
+var checkFailureCategories = sortEnum([]CheckFailureCategory{
+	CheckFailureCategoryCodeFailure,
+	CheckFailureCategoryInfraFailure,
+	CheckFailureCategoryTimeout,
+	CheckFailureCategoryCancelled,
+})
+
+// DefaultFailureCategory returns the failure category of a status check with the status
+// if the reporter didn't provide one. Failed status checks are attributed to the code
+// and erroneous ones to the infrastructure. Other statuses have no failure category.
+func (s CheckStatus) DefaultFailureCategory() CheckFailureCategory {
+	switch s {
+	case CheckStatusFailure:
+		return CheckFailureCategoryCodeFailure
+	case CheckStatusError:
+		return CheckFailureCategoryInfraFailure
+	case CheckStatusPending, CheckStatusRunning, CheckStatusSuccess, CheckStatusSkipped:
+		return ""
+	}
+
+	return ""
+}
+
+// CheckTrailerFormat defines which status check trailers are added to merge commit messages.
+type CheckTrailerFormat string
+
+func (CheckTrailerFormat) Enum() []interface{} { return toInterfaceSlice(checkTrailerFormats) }
+func (f CheckTrailerFormat) Sanitize() (CheckTrailerFormat, bool) {
+	return Sanitize(f, GetAllCheckTrailerFormats)
+}
+func GetAllCheckTrailerFormats() ([]CheckTrailerFormat, CheckTrailerFormat) {
+	return checkTrailerFormats, CheckTrailerFormatNone
+}
+
+// CheckTrailerFormat enumeration.
+const (
+	CheckTrailerFormatNone CheckTrailerFormat = "none"
+	// CheckTrailerFormatShort adds only the overall status of the status checks.
+	CheckTrailerFormatShort CheckTrailerFormat = "short"
+	// CheckTrailerFormatVerbose adds the overall status and the list of the passed status checks.
+	CheckTrailerFormatVerbose CheckTrailerFormat = "verbose"
+)
+
+var checkTrailerFormats = sortEnum([]CheckTrailerFormat{
+	CheckTrailerFormatNone,
+	CheckTrailerFormatShort,
+	CheckTrailerFormatVerbose,
+})
+
+// CheckGateStatus defines the outcome of waiting for the required status checks of a commit.
+type CheckGateStatus string
+
+func (CheckGateStatus) Enum() []interface{} { return toInterfaceSlice(checkGateStatuses) }
+
+// CheckGateStatus enumeration.
+const (
+	// CheckGateStatusPassed means that all required status checks are satisfied.
+	CheckGateStatusPassed CheckGateStatus = "passed"
+	// CheckGateStatusFailed means that at least one required status check completed without being satisfied.
+	CheckGateStatusFailed CheckGateStatus = "failed"
+	// CheckGateStatusTimedOut means that the required status checks didn't complete in time.
+	CheckGateStatusTimedOut CheckGateStatus = "timed_out"
+)
+
+var checkGateStatuses = sortEnum([]CheckGateStatus{
+	CheckGateStatusPassed,
+	CheckGateStatusFailed,
+	CheckGateStatusTimedOut,
 })
 
-var terminalCheckStatuses = []CheckStatus{CheckStatusFailure, CheckStatusSuccess, CheckStatusError}
+// IssueTracker defines the issue tracker that failed status checks are propagated to.
+type IssueTracker string
+
+func (IssueTracker) Enum() []interface{} { return toInterfaceSlice(issueTrackers) }
+func (t IssueTracker) Sanitize() (IssueTracker, bool) {
+	return Sanitize(t, GetAllIssueTrackers)
+}
+func GetAllIssueTrackers() ([]IssueTracker, IssueTracker) {
+	return issueTrackers, IssueTrackerNone
+}
+
+// IssueTracker enumeration.
+const (
+	IssueTrackerNone   IssueTracker = "none"
+	IssueTrackerJira   IssueTracker = "jira"
+	IssueTrackerLinear IssueTracker = "linear"
+)
+
+var issueTrackers = sortEnum([]IssueTracker{
+	IssueTrackerNone,
+	IssueTrackerJira,
+	IssueTrackerLinear,
+})
 
 // CheckPayloadKind defines status payload type.
 type CheckPayloadKind string
@@ -59,6 +240,8 @@ const (
 	CheckPayloadKindRaw      CheckPayloadKind = "raw"
 	CheckPayloadKindMarkdown CheckPayloadKind = "markdown"
 	CheckPayloadKindPipeline CheckPayloadKind = "pipeline"
+	CheckPayloadKindJUnit    CheckPayloadKind = "junit"
+	CheckPayloadKindSonar    CheckPayloadKind = "sonar"
 )
 
 var checkPayloadTypes = sortEnum([]CheckPayloadKind{
@@ -66,8 +249,119 @@ var checkPayloadTypes = sortEnum([]CheckPayloadKind{
 	CheckPayloadKindRaw,
 	CheckPayloadKindMarkdown,
 	CheckPayloadKindPipeline,
+	CheckPayloadKindJUnit,
+	CheckPayloadKindSonar,
+})
+
+// CheckAnnotationLevel defines the severity of a status check annotation.
+type CheckAnnotationLevel string
+
+func (CheckAnnotationLevel) Enum() []interface{} { return toInterfaceSlice(checkAnnotationLevels) }
+func (l CheckAnnotationLevel) Sanitize() (CheckAnnotationLevel, bool) {
+	return Sanitize(l, GetAllCheckAnnotationLevels)
+}
+func GetAllCheckAnnotationLevels() ([]CheckAnnotationLevel, CheckAnnotationLevel) {
+	return checkAnnotationLevels, CheckAnnotationLevelWarning
+}
+
+// CheckAnnotationLevel enumeration.
+const (
+	CheckAnnotationLevelNotice  CheckAnnotationLevel = "notice"
+	CheckAnnotationLevelWarning CheckAnnotationLevel = "warning"
+	CheckAnnotationLevelFailure CheckAnnotationLevel = "failure"
+)
+
+var checkAnnotationLevels = sortEnum([]CheckAnnotationLevel{
+	CheckAnnotationLevelNotice,
+	CheckAnnotationLevelWarning,
+	CheckAnnotationLevelFailure,
+})
+
+// ConflictStrategy defines how a batch upsert of status checks resolves conflicts with existing status checks.
+type ConflictStrategy string
+
+func (ConflictStrategy) Enum() []interface{} { return toInterfaceSlice(conflictStrategies) }
+func (s ConflictStrategy) Sanitize() (ConflictStrategy, bool) {
+	return Sanitize(s, GetAllConflictStrategies)
+}
+func GetAllConflictStrategies() ([]ConflictStrategy, ConflictStrategy) {
+	return conflictStrategies, ConflictStrategyOverwrite
+}
+
+// ConflictStrategy enumeration.
+const (
+	// ConflictStrategyOverwrite always overwrites the existing status check.
+	ConflictStrategyOverwrite ConflictStrategy = "overwrite"
+	// ConflictStrategyIgnore keeps the existing status check untouched.
+	ConflictStrategyIgnore ConflictStrategy = "ignore"
+	// ConflictStrategyTerminalWins overwrites the existing status check unless
+	// that would replace a completed status with a status that isn't completed.
+	ConflictStrategyTerminalWins ConflictStrategy = "terminal_wins"
+)
+
+var conflictStrategies = sortEnum([]ConflictStrategy{
+	ConflictStrategyOverwrite,
+	ConflictStrategyIgnore,
+	ConflictStrategyTerminalWins,
 })
 
+// CheckEventType defines the type of change of a status check recorded in the status check event log.
+type CheckEventType string
+
+// CheckEventType enumeration.
+const (
+	CheckEventTypeReported         CheckEventType = "reported"
+	CheckEventTypePatched          CheckEventType = "patched"
+	CheckEventTypeRetryIncremented CheckEventType = "retry_incremented"
+	CheckEventTypeMoved            CheckEventType = "moved"
+	CheckEventTypeDeleted          CheckEventType = "deleted"
+)
+
 func (s CheckStatus) IsCompleted() bool {
 	return slices.Contains(terminalCheckStatuses, s)
 }
+
+// IsSatisfied returns true if the status check status fulfills a status check requirement.
+func (s CheckStatus) IsSatisfied() bool {
+	return s == CheckStatusSuccess
+}
+
+// IsFailed returns true if the status check status is a failure or an error.
+func (s CheckStatus) IsFailed() bool {
+	return s == CheckStatusFailure || s == CheckStatusError
+}
+
+// CheckSort is used to specify sorting of status check results.
+type CheckSort string
+
+// CheckSort enumeration.
+const (
+	CheckSortUpdated    CheckSort = updated
+	CheckSortIdentifier CheckSort = identifier
+	// CheckSortStatus sorts status checks by severity of their status, failed status checks are the most severe.
+	CheckSortStatus CheckSort = "status"
+	// CheckSortDuration sorts status checks by the duration of their execution.
+	CheckSortDuration CheckSort = "duration"
+)
+
+var checkSorts = sortEnum([]CheckSort{
+	CheckSortUpdated,
+	CheckSortIdentifier,
+	CheckSortStatus,
+	CheckSortDuration,
+})
+
+func (CheckSort) Enum() []interface{}           { return toInterfaceSlice(checkSorts) }
+func (s CheckSort) Sanitize() (CheckSort, bool) { return Sanitize(s, GetAllCheckSorts) }
+func GetAllCheckSorts() ([]CheckSort, CheckSort) {
+	return checkSorts, CheckSortUpdated
+}
+
+// CheckIssueAction defines an action taken on a tracked issue for a failed status check.
+type CheckIssueAction string
+
+// CheckIssueAction enumeration.
+const (
+	CheckIssueActionComment    CheckIssueAction = "comment"
+	CheckIssueActionTransition CheckIssueAction = "transition"
+)
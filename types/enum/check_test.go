@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateStatusTransition(t *testing.T) {
+	tests := []struct {
+		current CheckStatus
+		next    CheckStatus
+		valid   bool
+	}{
+		{"", CheckStatusSuccess, true},
+		{CheckStatusPending, CheckStatusPending, true},
+		{CheckStatusPending, CheckStatusRunning, true},
+		{CheckStatusPending, CheckStatusSuccess, true},
+		{CheckStatusPending, CheckStatusSkipped, true},
+		{CheckStatusRunning, CheckStatusRunning, true},
+		{CheckStatusRunning, CheckStatusFailure, true},
+		{CheckStatusRunning, CheckStatusPending, true},
+		{CheckStatusSuccess, CheckStatusSuccess, true},
+		{CheckStatusSuccess, CheckStatusPending, false},
+		{CheckStatusSuccess, CheckStatusRunning, true},
+		{CheckStatusSuccess, CheckStatusFailure, true},
+		{CheckStatusFailure, CheckStatusPending, true},
+		{CheckStatusFailure, CheckStatusRunning, true},
+		{CheckStatusFailure, CheckStatusSuccess, true},
+		{CheckStatusError, CheckStatusRunning, true},
+		{CheckStatusError, CheckStatusFailure, true},
+		{CheckStatusSkipped, CheckStatusPending, true},
+		{CheckStatusSkipped, CheckStatusSuccess, true},
+	}
+
+	for _, test := range tests {
+		err := ValidateStatusTransition(test.current, test.next)
+		if test.valid && err != nil {
+			t.Errorf("Want transition from %q to %q to be valid, got %v", test.current, test.next, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidCheckStatusTransition) {
+			t.Errorf("Want transition from %q to %q to be invalid, got %v", test.current, test.next, err)
+		}
+	}
+}
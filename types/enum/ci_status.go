@@ -67,6 +67,15 @@ func (status CIStatus) ConvertToCheckStatus() CheckStatus {
 	return CheckStatusError
 }
 
+// ConvertToCheckFailureCategory returns the failure category of the status check of a pipeline execution.
+// Killed executions were cancelled.
+func (status CIStatus) ConvertToCheckFailureCategory() CheckFailureCategory {
+	if status == CIStatusKilled {
+		return CheckFailureCategoryCancelled
+	}
+	return status.ConvertToCheckStatus().DefaultFailureCategory()
+}
+
 // ParseCIStatus converts the status from a string to typed enum.
 // If the match is not exact, will just return default error status
 // instead of explicitly returning not found error.
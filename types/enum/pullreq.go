@@ -238,6 +238,31 @@ func (m MergeMethod) Sanitize() (MergeMethod, bool) {
 	return MergeMethod(s), ok
 }
 
+// MergeFreezeBypass defines the condition under which pull requests can be merged during a merge freeze.
+type MergeFreezeBypass string
+
+func (MergeFreezeBypass) Enum() []interface{} { return toInterfaceSlice(mergeFreezeBypasses) }
+func (b MergeFreezeBypass) Sanitize() (MergeFreezeBypass, bool) {
+	return Sanitize(b, GetAllMergeFreezeBypasses)
+}
+func GetAllMergeFreezeBypasses() ([]MergeFreezeBypass, MergeFreezeBypass) {
+	return mergeFreezeBypasses, MergeFreezeBypassNone
+}
+
+// MergeFreezeBypass enumeration.
+const (
+	// MergeFreezeBypassNone blocks all merges during a merge freeze.
+	MergeFreezeBypassNone MergeFreezeBypass = "none"
+	// MergeFreezeBypassChecksPassing allows merging during a merge freeze
+	// if all status checks of the source commit completed successfully.
+	MergeFreezeBypassChecksPassing MergeFreezeBypass = "checks_passing"
+)
+
+var mergeFreezeBypasses = sortEnum([]MergeFreezeBypass{
+	MergeFreezeBypassNone,
+	MergeFreezeBypassChecksPassing,
+})
+
 type MergeCheckStatus string
 
 const (
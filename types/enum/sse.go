@@ -30,4 +30,6 @@ const (
 	SSETypePullRequestUpdated SSEType = "pullreq_updated"
 
 	SSETypeLogLineAppended SSEType = "log_line_appended"
+
+	SSETypeCheckStatusChanged SSEType = "check_status_changed"
 )
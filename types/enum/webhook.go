@@ -113,12 +113,17 @@ const (
 
 	// WebhookExecutionResultFatalError describes a webhook execution result that failed with an unrecoverable error.
 	WebhookExecutionResultFatalError WebhookExecutionResult = "fatal_error"
+
+	// WebhookExecutionResultCircuitOpen describes a webhook execution that wasn't sent
+	// because the endpoint failed repeatedly and the delivery to it is paused.
+	WebhookExecutionResultCircuitOpen WebhookExecutionResult = "circuit_open"
 )
 
 var webhookExecutionResults = sortEnum([]WebhookExecutionResult{
 	WebhookExecutionResultSuccess,
 	WebhookExecutionResultRetriableError,
 	WebhookExecutionResultFatalError,
+	WebhookExecutionResultCircuitOpen,
 })
 
 // WebhookTrigger defines the different types of webhook triggers available.
@@ -165,6 +170,11 @@ const (
 
 	// WebhookTriggerPullReqReviewSubmitted gets triggered when a pull request review is submitted.
 	WebhookTriggerPullReqReviewSubmitted = "pullreq_review_submitted"
+
+	// WebhookTriggerCheckCreated gets triggered when a status check gets reported for the first time.
+	WebhookTriggerCheckCreated WebhookTrigger = "check_created"
+	// WebhookTriggerCheckStatusChanged gets triggered when the status of a reported status check changes.
+	WebhookTriggerCheckStatusChanged WebhookTrigger = "check_status_changed"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -182,4 +192,6 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
 	WebhookTriggerPullReqLabelAssigned,
+	WebhookTriggerCheckCreated,
+	WebhookTriggerCheckStatusChanged,
 })
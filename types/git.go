@@ -38,6 +38,13 @@ type CommitFilter struct {
 	Until        int64  `json:"until"`
 	Committer    string `json:"committer"`
 	IncludeStats bool   `json:"include_stats"`
+
+	// ChecksStatus and ChecksIdentifier restrict the commits to those with a matching status check result.
+	ChecksStatus     enum.CheckStatus `json:"checks_status"`
+	ChecksIdentifier string           `json:"checks_uid"`
+
+	// IncludeChecks adds the combined status check status to each listed commit.
+	IncludeChecks bool `json:"include_checks"`
 }
 
 type BranchMetadataOptions struct {
@@ -93,6 +100,15 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	// CheckStatus is the combined status of the status checks of the commit, if requested and available.
+	CheckStatus *enum.CheckStatus `json:"check_status,omitempty"`
+}
+
+// CommitExtended is a commit enriched with optional metadata.
+type CommitExtended struct {
+	Commit
+	Checks []Check `json:"checks,omitempty"`
 }
 
 type Signature struct {
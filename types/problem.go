@@ -0,0 +1,29 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// ProblemDetails is an API error in the format of RFC 7807 (problem details for HTTP APIs).
+type ProblemDetails struct {
+	// Type is a URI identifying the kind of problem, "about:blank" if it's only described by the status code.
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Message equals the detail, it's kept for clients of the previous error format.
+	Message string         `json:"message"`
+	Values  map[string]any `json:"values,omitempty"`
+}
@@ -18,6 +18,17 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+// MergeFreeze defines the time windows in which pull requests of a repository can't be merged.
+type MergeFreeze struct {
+	// Schedule is a cron expression matching every minute of the freeze windows,
+	// e.g. "* 15-23 * * 5" freezes merges on Fridays from 3pm. Merges are never frozen if it's empty.
+	Schedule string `json:"schedule" yaml:"schedule"`
+	// Timezone is the IANA time zone the schedule is evaluated in. UTC is used if it's empty.
+	Timezone string `json:"timezone" yaml:"timezone"`
+	// Bypass is the condition under which pull requests can be merged during a freeze.
+	Bypass enum.MergeFreezeBypass `json:"bypass" yaml:"bypass"`
+}
+
 // PullReq represents a pull request.
 type PullReq struct {
 	ID      int64 `json:"-"` // not returned, it's an internal field
@@ -60,6 +71,9 @@ type PullReq struct {
 	RebaseCheckStatus enum.MergeCheckStatus `json:"rebase_check_status"`
 	RebaseConflicts   []string              `json:"rebase_conflicts,omitempty"`
 
+	// RequiredChecksPassing is nil if the required status checks of the pull request haven't been evaluated yet.
+	RequiredChecksPassing *bool `json:"required_checks_passing,omitempty"`
+
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
 	Stats  PullReqStats   `json:"stats"`
@@ -120,6 +134,17 @@ type DiffStats struct {
 	FilesChanged *int64 `json:"files_changed,omitempty"`
 	Additions    *int64 `json:"additions"`
 	Deletions    *int64 `json:"deletions"`
+
+	// CheckAnnotations holds status check annotations of the head commit that affect changed lines.
+	CheckAnnotations []DiffCheckAnnotations `json:"check_annotations,omitempty"`
+}
+
+// DiffCheckAnnotations holds the status check annotations affecting a range of changed lines of a file.
+type DiffCheckAnnotations struct {
+	Path        string            `json:"path"`
+	LineStart   int               `json:"line_start"`
+	LineEnd     int               `json:"line_end"`
+	Annotations []CheckAnnotation `json:"annotations"`
 }
 
 func NewDiffStats(commitCount, fileCount, additions, deletions int) DiffStats {
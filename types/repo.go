@@ -91,6 +91,15 @@ type RepoFilter struct {
 	DeletedAt         *int64        `json:"deleted_at,omitempty"`
 	DeletedBeforeOrAt *int64        `json:"deleted_before_or_at,omitempty"`
 	Recursive         bool
+	// FailingChecks limits the repositories to the ones with failed status checks on the latest checked commit.
+	FailingChecks bool `json:"failing_checks"`
+}
+
+// RepoForkFilter stores fork list query parameters.
+type RepoForkFilter struct {
+	RepoFilter
+	// IncludeChecks adds the status check status of the default branch head of each fork.
+	IncludeChecks bool `json:"include_checks"`
 }
 
 // RepositoryGitInfo holds git info for a repository.
@@ -113,6 +122,20 @@ type RepositorySummary struct {
 	BranchCount              int                      `json:"branch_count"`
 	TagCount                 int                      `json:"tag_count"`
 	PullReqSummary           RepositoryPullReqSummary `json:"pull_req_summary"`
+	Checks                   *RepositoryCheckSummary  `json:"checks,omitempty"`
+}
+
+// RepositoryCheckSummary holds the status check metrics of a repository.
+type RepositoryCheckSummary struct {
+	// Since is the time from which the status checks are included in the metrics.
+	Since     int64 `json:"since"`
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+	Succeeded int64 `json:"succeeded"`
+	// PassRate is the ratio of succeeded to completed status checks.
+	PassRate float64 `json:"pass_rate"`
+	// MeanTimeToGreen is the average duration of the successful status checks in milliseconds.
+	MeanTimeToGreen int64 `json:"mean_time_to_green"`
 }
 
 type RepositoryCount struct {
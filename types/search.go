@@ -14,6 +14,8 @@
 
 package types
 
+import "github.com/harness/gitness/types/enum"
+
 type (
 	SearchInput struct {
 		Query string `json:"query"`
@@ -33,6 +35,9 @@ type (
 		// Search all the repos in a space and its subspaces recursively.
 		// Valid only when spacePaths is set.
 		Recursive bool `json:"recursive"`
+
+		// IncludeChecks adds the status check status of the branch head to the file matches.
+		IncludeChecks bool `json:"include_checks"`
 	}
 
 	SearchResult struct {
@@ -52,6 +57,14 @@ type (
 		RepoBranch string  `json:"repo_branch"`
 		Language   string  `json:"language"`
 		Matches    []Match `json:"matches"`
+
+		// CommitSHA is the indexed commit containing the match. If the searcher doesn't know it
+		// and status checks are included, it's the branch head commit.
+		CommitSHA string `json:"commit_sha,omitempty"`
+
+		// HeadCheckStatus is the overall status check status of the branch head,
+		// set only if the match is contained in the branch head commit.
+		HeadCheckStatus *enum.CheckStatus `json:"head_check_status,omitempty"`
 	}
 
 	// Match holds the per line data.